@@ -1,16 +1,41 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package v1alpha1
 
-import corev1 "k8s.io/api/core/v1"
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 // This file contains common types and functions used by all MultiCluster Custom Resource Types
 
-// Placement contains the name of each cluster where a resource will be located.
+// Placement contains the clusters where a resource will be located.  Clusters are either listed by name, or selected
+// by the labels of their VerrazzanoManagedCluster resources on the admin cluster.
 type Placement struct {
 	// List of clusters.
-	Clusters []Cluster `json:"clusters"`
+	// +optional
+	Clusters []Cluster `json:"clusters,omitempty"`
+	// Selects the managed clusters by the labels of their VerrazzanoManagedCluster resources, in addition to the
+	// clusters listed by name. The admin cluster is only selected when listed by name.
+	// +optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+	// List of clusters never selected, even when listed by name or selected by labels.
+	// +optional
+	ExcludedClusters []Cluster `json:"excludedClusters,omitempty"`
+	// Limits the number of clusters selected by labels in each topology domain.
+	// +optional
+	Spread *PlacementSpread `json:"spread,omitempty"`
+}
+
+// PlacementSpread limits the number of clusters selected by labels in each topology domain.
+type PlacementSpread struct {
+	// The label of the VerrazzanoManagedCluster resources whose values are the topology domains, for example
+	// `region`. Clusters without this label are not selected by labels.
+	TopologyKey string `json:"topologyKey"`
+	// The maximum number of clusters selected by labels in each topology domain.
+	// +kubebuilder:validation:Minimum=1
+	MaxClustersPerDomain int `json:"maxClustersPerDomain"`
 }
 
 // Cluster contains the name of a single cluster.
@@ -35,6 +60,10 @@ type Condition struct {
 
 // ClusterLevelStatus describes the status of the multicluster resource in a specific cluster.
 type ClusterLevelStatus struct {
+	// The drift of the resource in this cluster from the multicluster resource, as last detected by the agent of
+	// this cluster.
+	// +optional
+	Drift *DriftStatus `json:"drift,omitempty"`
 	// Last update time of the resource state in this cluster.
 	LastUpdateTime string `json:"lastUpdateTime"`
 	// Message details about the status in this cluster.
@@ -45,6 +74,49 @@ type ClusterLevelStatus struct {
 	State StateType `json:"state"`
 }
 
+// DriftPolicy specifies how changes made in a managed cluster to the resource created from a multicluster resource
+// are handled.
+type DriftPolicy string
+
+const (
+	// DriftPolicyEnforce overwrites the changes with the contents of the multicluster resource.
+	DriftPolicyEnforce DriftPolicy = "Enforce"
+
+	// DriftPolicyReportOnly keeps the changes and reports them in the status of the multicluster resource.
+	DriftPolicyReportOnly DriftPolicy = "ReportOnly"
+
+	// DriftPolicyAdopt copies the changes made in the adopting cluster to the multicluster resource on the admin
+	// cluster, from where they are synchronized to all the clusters the resource is placed in.  The changes made in
+	// the other clusters are kept and reported, until they are overwritten by the next change of the multicluster
+	// resource.
+	DriftPolicyAdopt DriftPolicy = "Adopt"
+)
+
+// DriftAction identifies the action taken on the drift of a resource in a cluster.
+type DriftAction string
+
+const (
+	// DriftOverwritten means that the changes were overwritten with the contents of the multicluster resource.
+	DriftOverwritten DriftAction = "Overwritten"
+
+	// DriftReported means that the changes were kept and only reported.
+	DriftReported DriftAction = "Reported"
+
+	// DriftAdopted means that the changes were copied to the multicluster resource.
+	DriftAdopted DriftAction = "Adopted"
+)
+
+// DriftStatus describes the changes made in a cluster to the resource created from a multicluster resource.
+type DriftStatus struct {
+	// The action taken on the drift, as specified by the drift policy of the multicluster resource.
+	Action DriftAction `json:"action"`
+	// The time the drift was detected.
+	DetectedTime string `json:"detectedTime"`
+	// The keys of the data whose values in this cluster differ from the multicluster resource.
+	// +optional
+	Keys []string `json:"keys,omitempty"`
+}
+
 // ConditionType identifies the condition of the multicluster resource which can be checked with `kubectl wait`.
 type ConditionType string
 
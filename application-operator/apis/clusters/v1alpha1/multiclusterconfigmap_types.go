@@ -17,6 +17,16 @@ type MultiClusterConfigMapSpec struct {
 
 	// The embedded Kubernetes ConfigMap.
 	Template ConfigMapTemplate `json:"template"`
+	// How changes made in a managed cluster to the ConfigMap created from the template are handled: `Enforce` overwrites
+	// the changes, `ReportOnly` keeps and reports the changes, and `Adopt` copies the changes to this resource. The
+	// default is `Enforce`.
+	// +kubebuilder:validation:Enum=Enforce;ReportOnly;Adopt
+	// +optional
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+	// The managed cluster whose changes are adopted when the drift policy is `Adopt`, the changes made in the other
+	// clusters are only reported. When not set, it is set to the first managed cluster that adopts changes.
+	// +optional
+	AdoptingCluster string `json:"adoptingCluster,omitempty"`
 }
 
 // ConfigMapTemplate has the metadata and spec of the Kubernetes ConfigMap.
@@ -18,6 +18,16 @@ type MultiClusterSecretSpec struct {
 
 	// The embedded Kubernetes secret.
 	Template SecretTemplate `json:"template"`
+	// How changes made in a managed cluster to the secret created from the template are handled: `Enforce` overwrites
+	// the changes, `ReportOnly` keeps and reports the changes, and `Adopt` copies the changes to this resource. The
+	// default is `Enforce`.
+	// +kubebuilder:validation:Enum=Enforce;ReportOnly;Adopt
+	// +optional
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+	// The managed cluster whose changes are adopted when the drift policy is `Adopt`, the changes made in the other
+	// clusters are only reported. When not set, it is set to the first managed cluster that adopts changes.
+	// +optional
+	AdoptingCluster string `json:"adoptingCluster,omitempty"`
 }
 
 // SecretTemplate has the metadata and spec of the Kubernetes Secret.
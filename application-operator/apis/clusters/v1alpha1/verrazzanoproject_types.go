@@ -20,6 +20,16 @@ type NamespaceTemplate struct {
 	Metadata metav1.ObjectMeta `json:"metadata"`
 	// The specification of a namespace.
 	Spec corev1.NamespaceSpec `json:"spec,omitempty"`
+	// The resource quota of the namespace.
+	// +optional
+	ResourceQuota *corev1.ResourceQuotaSpec `json:"resourceQuota,omitempty"`
+	// The limit range of the namespace, providing default and maximum resources to containers.
+	// +optional
+	LimitRange *corev1.LimitRangeSpec `json:"limitRange,omitempty"`
+	// The Pod Security Admission level enforced in the namespace: `privileged`, `baseline`, or `restricted`.
+	// +kubebuilder:validation:Enum=privileged;baseline;restricted
+	// +optional
+	PodSecurity string `json:"podSecurity,omitempty"`
 }
 
 // NetworkPolicyTemplate contains the metadata and specification of a Kubernetes NetworkPolicy.
@@ -43,6 +53,29 @@ type SecuritySpec struct {
 	// The subjects to bind to the `verrazzano-project-monitoring` role.
 	// +optional
 	ProjectMonitorSubjects []rbacv1.Subject `json:"projectMonitorSubjects,omitempty"`
+	// Named role bindings binding subjects to built-in Verrazzano project roles or to custom ClusterRoles.
+	// +optional
+	ProjectRoleBindings []ProjectRoleBinding `json:"projectRoleBindings,omitempty"`
+}
+
+// ProjectRoleBinding binds subjects to a role in all the namespaces of a Verrazzano Project.
+type ProjectRoleBinding struct {
+	// The name of the role binding, unique in the project.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=40
+	Name string `json:"name"`
+	// The name of the ClusterRole bound to the subjects: `verrazzano-project-admin`, `verrazzano-project-monitor`,
+	// or the name of a custom ClusterRole. The user creating or changing the role binding must be allowed to `bind`
+	// the ClusterRole in the project namespaces.
+	Role string `json:"role"`
+	// The subjects to bind to the role.
+	Subjects []rbacv1.Subject `json:"subjects"`
+	// Grants the subjects read access to the logs of the project namespaces in OpenSearch. Group subjects are
+	// matched against the backend roles of the OpenSearch users, which are the groups of the users in Keycloak.
+	// Only the OpenSearch access is granted: the Keycloak groups and the Grafana access of the subjects are not
+	// managed by the project.
+	// +optional
+	LogAccess bool `json:"logAccess,omitempty"`
 }
 
 // ProjectTemplate contains the list of namespaces to create and the optional security configuration for each namespace.
@@ -57,6 +90,12 @@ type ProjectTemplate struct {
 	// The project security configuration.
 	// +optional
 	Security SecuritySpec `json:"security,omitempty"`
+
+	// The hard limits of the resources used by all the namespaces of the project on each cluster. The limits are
+	// enforced by a resource quota in each namespace, allowing the resources used by the namespace plus an even share
+	// of the resources left, so a namespace may be denied resources while other namespaces have an unused share.
+	// +optional
+	AggregateResourceQuota corev1.ResourceList `json:"aggregateResourceQuota,omitempty"`
 }
 
 // VerrazzanoProjectSpec defines the desired state of a Verrazzano Project.
@@ -81,7 +120,31 @@ type VerrazzanoProject struct {
 	// The desired state of a Verrazzano Project resource.
 	Spec VerrazzanoProjectSpec `json:"spec"`
 	// The observed state of a Verrazzano Project resource.
-	Status MultiClusterResourceStatus `json:"status,omitempty"`
+	Status VerrazzanoProjectStatus `json:"status,omitempty"`
+}
+
+// VerrazzanoProjectStatus defines the observed state of a Verrazzano Project.
+type VerrazzanoProjectStatus struct {
+	MultiClusterResourceStatus `json:",inline"`
+
+	// The usage of the resource quotas of the project on each cluster.
+	// +optional
+	Quotas []ProjectQuotaStatus `json:"quotas,omitempty"`
+}
+
+// ProjectQuotaStatus describes the usage of a resource quota of a Verrazzano Project in a specific cluster.
+type ProjectQuotaStatus struct {
+	// Name of the cluster.
+	Cluster string `json:"cluster"`
+	// The namespace of the resource quota. Empty for the aggregate resource quota of the project.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// The hard limits of the resources.
+	// +optional
+	Hard corev1.ResourceList `json:"hard,omitempty"`
+	// The resources used.
+	// +optional
+	Used corev1.ResourceList `json:"used,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -99,7 +162,7 @@ func init() {
 
 // GetStatus returns the MultiClusterResourceStatus of this resource.
 func (in *VerrazzanoProject) GetStatus() MultiClusterResourceStatus {
-	return in.Status
+	return in.Status.MultiClusterResourceStatus
 }
 
 // GetPlacement returns the Placement of this resource.
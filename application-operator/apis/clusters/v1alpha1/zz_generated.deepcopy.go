@@ -9,7 +9,9 @@
 package v1alpha1
 
 import (
-	"k8s.io/api/rbac/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -48,6 +50,11 @@ func (in *Cluster) DeepCopy() *Cluster {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterLevelStatus) DeepCopyInto(out *ClusterLevelStatus) {
 	*out = *in
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = new(DriftStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterLevelStatus.
@@ -135,6 +142,26 @@ func (in *ConfigMapTemplate) DeepCopy() *ConfigMapTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftStatus) DeepCopyInto(out *DriftStatus) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftStatus.
+func (in *DriftStatus) DeepCopy() *DriftStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmbeddedObjectMeta) DeepCopyInto(out *EmbeddedObjectMeta) {
 	*out = *in
@@ -403,7 +430,9 @@ func (in *MultiClusterResourceStatus) DeepCopyInto(out *MultiClusterResourceStat
 	if in.Clusters != nil {
 		in, out := &in.Clusters, &out.Clusters
 		*out = make([]ClusterLevelStatus, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -503,6 +532,16 @@ func (in *NamespaceTemplate) DeepCopyInto(out *NamespaceTemplate) {
 	*out = *in
 	in.Metadata.DeepCopyInto(&out.Metadata)
 	in.Spec.DeepCopyInto(&out.Spec)
+	if in.ResourceQuota != nil {
+		in, out := &in.ResourceQuota, &out.ResourceQuota
+		*out = new(corev1.ResourceQuotaSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LimitRange != nil {
+		in, out := &in.LimitRange, &out.LimitRange
+		*out = new(corev1.LimitRangeSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceTemplate.
@@ -540,6 +579,21 @@ func (in *Placement) DeepCopyInto(out *Placement) {
 		*out = make([]Cluster, len(*in))
 		copy(*out, *in)
 	}
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExcludedClusters != nil {
+		in, out := &in.ExcludedClusters, &out.ExcludedClusters
+		*out = make([]Cluster, len(*in))
+		copy(*out, *in)
+	}
+	if in.Spread != nil {
+		in, out := &in.Spread, &out.Spread
+		*out = new(PlacementSpread)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Placement.
@@ -552,6 +606,70 @@ func (in *Placement) DeepCopy() *Placement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlacementSpread) DeepCopyInto(out *PlacementSpread) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlacementSpread.
+func (in *PlacementSpread) DeepCopy() *PlacementSpread {
+	if in == nil {
+		return nil
+	}
+	out := new(PlacementSpread)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectQuotaStatus) DeepCopyInto(out *ProjectQuotaStatus) {
+	*out = *in
+	if in.Hard != nil {
+		in, out := &in.Hard, &out.Hard
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Used != nil {
+		in, out := &in.Used, &out.Used
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectQuotaStatus.
+func (in *ProjectQuotaStatus) DeepCopy() *ProjectQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectRoleBinding) DeepCopyInto(out *ProjectRoleBinding) {
+	*out = *in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]rbacv1.Subject, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRoleBinding.
+func (in *ProjectRoleBinding) DeepCopy() *ProjectRoleBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectRoleBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProjectTemplate) DeepCopyInto(out *ProjectTemplate) {
 	*out = *in
@@ -570,6 +688,13 @@ func (in *ProjectTemplate) DeepCopyInto(out *ProjectTemplate) {
 		}
 	}
 	in.Security.DeepCopyInto(&out.Security)
+	if in.AggregateResourceQuota != nil {
+		in, out := &in.AggregateResourceQuota, &out.AggregateResourceQuota
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectTemplate.
@@ -625,14 +750,21 @@ func (in *SecuritySpec) DeepCopyInto(out *SecuritySpec) {
 	*out = *in
 	if in.ProjectAdminSubjects != nil {
 		in, out := &in.ProjectAdminSubjects, &out.ProjectAdminSubjects
-		*out = make([]v1.Subject, len(*in))
+		*out = make([]rbacv1.Subject, len(*in))
 		copy(*out, *in)
 	}
 	if in.ProjectMonitorSubjects != nil {
 		in, out := &in.ProjectMonitorSubjects, &out.ProjectMonitorSubjects
-		*out = make([]v1.Subject, len(*in))
+		*out = make([]rbacv1.Subject, len(*in))
 		copy(*out, *in)
 	}
+	if in.ProjectRoleBindings != nil {
+		in, out := &in.ProjectRoleBindings, &out.ProjectRoleBindings
+		*out = make([]ProjectRoleBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecuritySpec.
@@ -720,3 +852,26 @@ func (in *VerrazzanoProjectSpec) DeepCopy() *VerrazzanoProjectSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerrazzanoProjectStatus) DeepCopyInto(out *VerrazzanoProjectStatus) {
+	*out = *in
+	in.MultiClusterResourceStatus.DeepCopyInto(&out.MultiClusterResourceStatus)
+	if in.Quotas != nil {
+		in, out := &in.Quotas, &out.Quotas
+		*out = make([]ProjectQuotaStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerrazzanoProjectStatus.
+func (in *VerrazzanoProjectStatus) DeepCopy() *VerrazzanoProjectStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VerrazzanoProjectStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package v1alpha1
+
+import (
+	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AutoscalerTraitKind identifies the Kind for the autoscaler trait.
+const AutoscalerTraitKind string = "AutoscalerTrait"
+
+// Types of the metrics of an autoscaler trait
+const (
+	AutoscalerMetricCPU    = "cpu"
+	AutoscalerMetricMemory = "memory"
+	AutoscalerMetricCustom = "custom"
+)
+
+func init() {
+	SchemeBuilder.Register(&AutoscalerTrait{}, &AutoscalerTraitList{})
+}
+
+// AutoscalerTraitList contains a list of AutoscalerTrait.
+// +kubebuilder:object:root=true
+type AutoscalerTraitList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AutoscalerTrait `json:"items"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// AutoscalerTrait specifies the autoscaler trait API.
+type AutoscalerTrait struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AutoscalerTraitSpec `json:"spec,omitempty"`
+	// The observed state of an autoscaler trait and related resources.
+	Status AutoscalerTraitStatus `json:"status,omitempty"`
+}
+
+// AutoscalerTraitSpec specifies the desired state of an autoscaler trait.
+type AutoscalerTraitSpec struct {
+	// The minimum number of replicas of the workload. Defaults to `1`.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// The maximum number of replicas of the workload.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// The name of the WebLogic cluster scaled by the trait. Required when the domain of a WebLogic workload has
+	// more than one cluster.
+	// +optional
+	Cluster string `json:"cluster,omitempty"`
+
+	// The metrics used to compute the number of replicas of the workload. Defaults to an average CPU utilization
+	// of 80%.
+	// +optional
+	Metrics []AutoscalerMetric `json:"metrics,omitempty"`
+
+	// The WorkloadReference of the workload to which this trait applies.
+	// This value is populated by the OAM runtime when an ApplicationConfiguration
+	// resource is processed.  When the ApplicationConfiguration is processed, a trait and
+	// a workload resource are created from the content of the ApplicationConfiguration.
+	// The WorkloadReference is provided in the trait by OAM to ensure that the trait controller
+	// can find the workload associated with the component containing the trait within the
+	// original ApplicationConfiguration.
+	WorkloadReference oamrt.TypedReference `json:"workloadRef"`
+}
+
+// AutoscalerMetric specifies a metric and its target value.
+type AutoscalerMetric struct {
+	// The type of the metric: `cpu`, `memory`, or `custom`.
+	// +kubebuilder:validation:Enum=cpu;memory;custom
+	Type string `json:"type"`
+
+	// The name of a custom metric. Required for the `custom` type.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// The Prometheus query computing the value of a custom metric, for example
+	// `sum(rate(http_requests_total{<<.LabelMatchers>>}[2m]))`. Each selector of the query must include
+	// `<<.LabelMatchers>>`, which selects the series of the namespace of the trait. Required for the `custom` type.
+	// +optional
+	Query string `json:"query,omitempty"`
+
+	// The target average utilization of the `cpu` and `memory` metrics, as a percentage of the requested
+	// resources of the pods.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetAverageUtilization *int32 `json:"targetAverageUtilization,omitempty"`
+
+	// The target value of the metric averaged over the pods of the workload, as a quantity, for example `500m` or
+	// `256Mi`. Required for the `custom` type.
+	// +optional
+	TargetAverageValue string `json:"targetAverageValue,omitempty"`
+}
+
+// AutoscalerTraitStatus defines the observed state of an autoscaler trait and related resources.
+type AutoscalerTraitStatus struct {
+	// Reconcile status of this autoscaler trait.
+	oamrt.ConditionedStatus `json:",inline"`
+
+	// Related resources affected by this autoscaler trait.
+	Resources []QualifiedResourceRelation `json:"resources,omitempty"`
+}
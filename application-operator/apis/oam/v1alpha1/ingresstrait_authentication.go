@@ -0,0 +1,53 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package v1alpha1
+
+const (
+	// TLSModeSimple secures the transport with the certificate of the ingress trait
+	TLSModeSimple = "SIMPLE"
+	// TLSModeMutual secures the transport with the certificate of the ingress trait and requires client certificates
+	TLSModeMutual = "MUTUAL"
+)
+
+// IngressAuthentication specifies the authentication of the requests to an ingress trait with JSON Web Tokens.
+// Requests with a token from one of the issuers are authenticated, and the request principal of the token can be used
+// in the authorization policies of the paths.  Requests with an invalid token are rejected.
+type IngressAuthentication struct {
+	// The issuers of the JSON Web Tokens accepted by the ingress trait.
+	// +optional
+	JWT []IngressJWTIssuer `json:"jwt,omitempty"`
+}
+
+// IngressJWTIssuer specifies an issuer of JSON Web Tokens, for example an external OpenID Connect provider.  The
+// tokens are validated by the shared ingress gateway, so the ingress traits specifying the same issuer must specify the
+// same keys and token forwarding.  The audiences and claim mappings only apply to the hosts of the ingress trait.
+type IngressJWTIssuer struct {
+	// The issuer of the tokens, matching the `iss` claim of the tokens.
+	Issuer string `json:"issuer"`
+	// The URL of the JSON Web Key Set of the issuer, used to validate the signatures of the tokens.
+	// +optional
+	JwksURI string `json:"jwksUri,omitempty"`
+	// The JSON Web Key Set of the issuer, inline.  Cannot be specified with the JWKS URI.
+	// +optional
+	Jwks string `json:"jwks,omitempty"`
+	// The audiences allowed to access the ingress trait, matching the `aud` claim of the tokens.  When empty, the
+	// tokens are accepted regardless of their audiences.
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+	// Forward the tokens to the destinations.  By default, the tokens are removed from the requests.
+	// +optional
+	ForwardOriginalToken bool `json:"forwardOriginalToken,omitempty"`
+	// The claims of the tokens copied to request headers.
+	// +optional
+	ClaimToHeaders []IngressClaimToHeader `json:"claimToHeaders,omitempty"`
+}
+
+// IngressClaimToHeader specifies a claim of the tokens copied to a request header.  The header is removed from the
+// requests without a valid token of the issuer.
+type IngressClaimToHeader struct {
+	// The name of the claim.
+	Claim string `json:"claim"`
+	// The name of the request header.
+	Header string `json:"header"`
+}
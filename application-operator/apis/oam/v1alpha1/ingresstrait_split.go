@@ -0,0 +1,56 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package v1alpha1
+
+// IngressWeightedDestination specifies one of the weighted destinations of an ingress rule.  The destination is the
+// service of a component of the application, an explicit host, or by default the service of the workload of the trait.
+type IngressWeightedDestination struct {
+	// The name of the destination.  It is the name of the DestinationRule subset of the destination when labels are
+	// specified.
+	Name string `json:"name"`
+	// The name of the component of the application whose service is the destination.
+	// +optional
+	Component string `json:"component,omitempty"`
+	// The destination host.  Cannot be specified with a component.
+	// +optional
+	Host string `json:"host,omitempty"`
+	// The destination port.  Defaults to the port of the destination of the rule.
+	// +optional
+	Port uint32 `json:"port,omitempty"`
+	// The labels selecting the pods of the destination, for example `version: v2`.  When specified, the requests are
+	// routed to a subset of the service.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// The percentage of the requests routed to the destination.  The percentage not assigned by the weights is split
+	// evenly between the destinations without a weight.
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+	// The requests with all of these header values are routed to the destination, regardless of the weights.
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// IngressTrafficSplitStatus specifies the effective weights of the weighted destinations of an ingress rule.
+type IngressTrafficSplitStatus struct {
+	// The index of the rule in the ingress trait.
+	Rule int `json:"rule"`
+	// The weighted destinations of the rule.
+	Destinations []IngressDestinationWeight `json:"destinations,omitempty"`
+}
+
+// IngressDestinationWeight specifies the effective weight of a weighted destination.
+type IngressDestinationWeight struct {
+	// The name of the destination.
+	Name string `json:"name"`
+	// The resolved destination host.
+	Host string `json:"host"`
+	// The resolved destination port.
+	// +optional
+	Port uint32 `json:"port,omitempty"`
+	// The DestinationRule subset of the destination.
+	// +optional
+	Subset string `json:"subset,omitempty"`
+	// The percentage of the requests routed to the destination.
+	Weight int32 `json:"weight"`
+}
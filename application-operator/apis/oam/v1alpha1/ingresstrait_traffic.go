@@ -0,0 +1,120 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package v1alpha1
+
+const (
+	// RateLimitUnitSecond limits the number of requests per second
+	RateLimitUnitSecond = "second"
+	// RateLimitUnitMinute limits the number of requests per minute
+	RateLimitUnitMinute = "minute"
+	// RateLimitUnitHour limits the number of requests per hour
+	RateLimitUnitHour = "hour"
+)
+
+// The durations of the traffic management settings are strings parsed as Go durations, for example `500ms` or `10s`.
+
+// IngressRetryPolicy specifies the retries of the requests to a path.
+type IngressRetryPolicy struct {
+	// The number of retries for a request.
+	Attempts int32 `json:"attempts"`
+	// The timeout of each try, including the initial request. Defaults to the request timeout of the path.
+	// +optional
+	PerTryTimeout string `json:"perTryTimeout,omitempty"`
+	// The conditions under which a retry takes place, as a comma-separated list of Envoy retry policies,
+	// for example `5xx,connect-failure,reset`.
+	// +optional
+	RetryOn string `json:"retryOn,omitempty"`
+}
+
+// IngressCORSPolicy specifies the Cross-Origin Resource Sharing policy of a path.
+type IngressCORSPolicy struct {
+	// The origins allowed to make requests. An origin of `*` allows all origins.
+	AllowOrigins []string `json:"allowOrigins,omitempty"`
+	// The HTTP methods allowed to access the path.
+	// +optional
+	AllowMethods []string `json:"allowMethods,omitempty"`
+	// The HTTP headers which can be used when requesting the path.
+	// +optional
+	AllowHeaders []string `json:"allowHeaders,omitempty"`
+	// The HTTP headers which browsers are allowed to access.
+	// +optional
+	ExposeHeaders []string `json:"exposeHeaders,omitempty"`
+	// How long the results of a preflight request can be cached.
+	// +optional
+	MaxAge string `json:"maxAge,omitempty"`
+	// Indicates whether the caller is allowed to send the actual request with credentials.
+	// +optional
+	AllowCredentials *bool `json:"allowCredentials,omitempty"`
+}
+
+// IngressHeaderOperations specifies the header manipulations applied to the requests or to the responses.
+type IngressHeaderOperations struct {
+	// Overwrite the headers specified by key with the given values.
+	// +optional
+	Set map[string]string `json:"set,omitempty"`
+	// Append the given values to the headers specified by key.
+	// +optional
+	Add map[string]string `json:"add,omitempty"`
+	// Remove the specified headers.
+	// +optional
+	Remove []string `json:"remove,omitempty"`
+}
+
+// IngressHeaders specifies the header manipulations of a path.
+type IngressHeaders struct {
+	// The header manipulations applied to the requests before they are forwarded to the destination.
+	// +optional
+	Request *IngressHeaderOperations `json:"request,omitempty"`
+	// The header manipulations applied to the responses before they are returned to the client.
+	// +optional
+	Response *IngressHeaderOperations `json:"response,omitempty"`
+}
+
+// IngressRateLimit specifies the local rate limiting of the requests to a path. The limit is enforced by each replica
+// of the Istio ingress gateway, requests over the limit are rejected with the HTTP status 429.
+type IngressRateLimit struct {
+	// The number of requests allowed per unit of time.
+	Requests uint32 `json:"requests"`
+	// The unit of time of the limit: `second`, `minute` or `hour`. Defaults to `second`.
+	// +optional
+	Unit string `json:"unit,omitempty"`
+}
+
+// IngressConnectionPool specifies the volume of connections to the destination of an ingress rule.
+type IngressConnectionPool struct {
+	// The maximum number of TCP connections to the destination.
+	// +optional
+	MaxConnections int32 `json:"maxConnections,omitempty"`
+	// The TCP connection timeout.
+	// +optional
+	ConnectTimeout string `json:"connectTimeout,omitempty"`
+	// The maximum number of requests waiting for a connection to the destination.
+	// +optional
+	MaxPendingRequests int32 `json:"maxPendingRequests,omitempty"`
+	// The maximum number of active requests to the destination.
+	// +optional
+	MaxRequests int32 `json:"maxRequests,omitempty"`
+	// The maximum number of requests per connection to the destination.
+	// +optional
+	MaxRequestsPerConnection int32 `json:"maxRequestsPerConnection,omitempty"`
+	// The idle timeout of the connections to the destination.
+	// +optional
+	IdleTimeout string `json:"idleTimeout,omitempty"`
+}
+
+// IngressOutlierDetection specifies the ejection of the unhealthy hosts of the destination of an ingress rule.
+type IngressOutlierDetection struct {
+	// The number of consecutive 5xx errors before a host is ejected.
+	// +optional
+	Consecutive5xxErrors uint32 `json:"consecutive5xxErrors,omitempty"`
+	// The time interval between the ejection sweep analysis.
+	// +optional
+	Interval string `json:"interval,omitempty"`
+	// The minimum ejection duration of a host.
+	// +optional
+	BaseEjectionTime string `json:"baseEjectionTime,omitempty"`
+	// The maximum percentage of the hosts of the destination that can be ejected.
+	// +optional
+	MaxEjectionPercent int32 `json:"maxEjectionPercent,omitempty"`
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2020, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2020, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package v1alpha1
@@ -22,6 +22,10 @@ type IngressTraitSpec struct {
 	// +optional
 	TLS IngressSecurity `json:"tls,omitempty"`
 
+	// The authentication of the requests to an ingress trait with JSON Web Tokens.
+	// +optional
+	Authentication *IngressAuthentication `json:"authentication,omitempty"`
+
 	// The WorkloadReference of the workload to which this trait applies.
 	// This value is populated by the OAM runtime when an ApplicationConfiguration
 	// resource is processed.  When the ApplicationConfiguration is processed, a trait and
@@ -37,6 +41,10 @@ type IngressRule struct {
 	// The destination host and port for the ingress paths.
 	// +optional
 	Destination IngressDestination `json:"destination,omitempty"`
+	// The weighted destinations of the ingress paths, to split the traffic between several versions of a
+	// component.  The port and the traffic policy of the destination apply to all the weighted destinations.
+	// +optional
+	Destinations []IngressWeightedDestination `json:"destinations,omitempty"`
 	// One or more hosts exposed by the ingress trait. Wildcard hosts or hosts that are
 	// empty are filtered out. If there are no valid hosts provided, then a DNS host name
 	// is automatically generated and used.
@@ -51,6 +59,14 @@ type IngressSecurity struct {
 	// The name of a secret containing the certificate securing the transport.  The specification of a secret here
 	// implies that a certificate was created for specific hosts, as specified in an [IngressRule](#oam.verrazzano.io/v1alpha1.IngressRule).
 	SecretName string `json:"secretName,omitempty"`
+	// The TLS mode of the ingress trait: `SIMPLE` or `MUTUAL`.  In `MUTUAL` mode, the clients must present a
+	// certificate signed by a certificate authority of the client CA secret.  Defaults to `SIMPLE`.
+	// +optional
+	Mode string `json:"mode,omitempty"`
+	// The name of a secret, in the namespace of the ingress trait, containing the bundle of the certificate
+	// authorities verifying the client certificates in the `ca.crt` key.  Required in `MUTUAL` mode.
+	// +optional
+	ClientCASecretName string `json:"clientCASecretName,omitempty"`
 }
 
 // IngressPath specifies a specific path to be exposed for an ingress trait.
@@ -66,6 +82,21 @@ type IngressPath struct {
 	// Defines the set of rules for authorizing a request.
 	// +optional
 	Policy *AuthorizationPolicy `json:"authorizationPolicy,omitempty"`
+	// The timeout of the requests to the path, for example `10s`.
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+	// The retry policy of the requests to the path.
+	// +optional
+	Retries *IngressRetryPolicy `json:"retries,omitempty"`
+	// The Cross-Origin Resource Sharing policy of the path.
+	// +optional
+	CORS *IngressCORSPolicy `json:"cors,omitempty"`
+	// The header manipulations of the requests to the path and of the responses.
+	// +optional
+	Headers *IngressHeaders `json:"headers,omitempty"`
+	// The local rate limit of the requests to the path.
+	// +optional
+	RateLimit *IngressRateLimit `json:"rateLimit,omitempty"`
 }
 
 // IngressDestination specifies a specific destination host and port for the ingress paths.
@@ -84,6 +115,12 @@ type IngressDestination struct {
 	// Destination port.
 	// +optional
 	Port uint32 `json:"port,omitempty"`
+	// The connection pool settings of the destination. They apply to all the paths of the rule.
+	// +optional
+	ConnectionPool *IngressConnectionPool `json:"connectionPool,omitempty"`
+	// The outlier detection settings of the destination. They apply to all the paths of the rule.
+	// +optional
+	OutlierDetection *IngressOutlierDetection `json:"outlierDetection,omitempty"`
 }
 
 // IngressDestinationHTTPCookie specifies a session affinity cookie for an ingress trait.
@@ -105,6 +142,8 @@ type IngressTraitStatus struct {
 	oamrt.ConditionedStatus `json:",inline"`
 	// The resources managed by this ingress trait.
 	Resources []oamrt.TypedReference `json:"resources,omitempty"`
+	// The effective weights of the weighted destinations of the rules.
+	TrafficSplits []IngressTrafficSplitStatus `json:"trafficSplits,omitempty"`
 }
 
 // +genclient
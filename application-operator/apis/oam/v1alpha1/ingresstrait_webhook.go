@@ -1,12 +1,15 @@
-// Copyright (c) 2020, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2020, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
 	s "strings"
+	"time"
 
 	vzlog "github.com/verrazzano/verrazzano/pkg/log"
 	"go.uber.org/zap"
@@ -88,6 +91,16 @@ func (r *IngressTrait) validateIngressTrait(existingTraits []IngressTrait) error
 	// - "All" ingressTrait's don't conflict with "prefix" ingressTraits which take precedence because they are more specific
 	// - "All" ingressTrait's don't conflict with "exact" ingressTraits which take precedence because they are more specific
 
+	if err := r.validateTrafficSettings(); err != nil {
+		return err
+	}
+	if err := r.validateAuthenticationSettings(); err != nil {
+		return err
+	}
+	if err := r.validateIssuerConflicts(existingTraits); err != nil {
+		return err
+	}
+
 	hostPathMap, e := r.createIngressTraitMap()
 	if e != nil {
 		return e
@@ -191,6 +204,267 @@ func (r *IngressTrait) validateHost(host string) error {
 	return nil
 }
 
+// validateTrafficSettings validates the traffic management settings of the paths and of the destinations
+func (r *IngressTrait) validateTrafficSettings() error {
+	var errMessages []string
+	for i, rule := range r.Spec.Rules {
+		errMessages = append(errMessages, validateDestinationTrafficSettings(fmt.Sprintf("rules[%d].destination", i), rule.Destination)...)
+		errMessages = append(errMessages, validateWeightedDestinations(fmt.Sprintf("rules[%d]", i), rule)...)
+		for j, path := range rule.Paths {
+			errMessages = append(errMessages, validatePathTrafficSettings(fmt.Sprintf("rules[%d].paths[%d]", i, j), path)...)
+		}
+	}
+	if len(errMessages) > 0 {
+		return fmt.Errorf("invalid traffic settings specified for IngressTrait with name '%v': %v",
+			r.Name, s.Join(errMessages, ", "))
+	}
+	return nil
+}
+
+// validateAuthenticationSettings validates the TLS mode and the JWT issuers of the trait
+func (r *IngressTrait) validateAuthenticationSettings() error {
+	var errMessages []string
+	switch r.Spec.TLS.Mode {
+	case "", TLSModeSimple:
+		if len(r.Spec.TLS.ClientCASecretName) > 0 {
+			errMessages = append(errMessages, fmt.Sprintf("tls.clientCASecretName can only be specified with the %q mode", TLSModeMutual))
+		}
+	case TLSModeMutual:
+		if len(r.Spec.TLS.ClientCASecretName) == 0 {
+			errMessages = append(errMessages, fmt.Sprintf("tls.clientCASecretName must be specified with the %q mode", TLSModeMutual))
+		}
+	default:
+		errMessages = append(errMessages, fmt.Sprintf("tls.mode must be one of %q or %q", TLSModeSimple, TLSModeMutual))
+	}
+	if r.Spec.Authentication != nil {
+		issuers := map[string]bool{}
+		for i, issuer := range r.Spec.Authentication.JWT {
+			field := fmt.Sprintf("authentication.jwt[%d]", i)
+			if len(issuer.Issuer) == 0 {
+				errMessages = append(errMessages, fmt.Sprintf("%s.issuer must be specified", field))
+			} else if issuers[issuer.Issuer] {
+				errMessages = append(errMessages, fmt.Sprintf("%s.issuer %q is not unique", field, issuer.Issuer))
+			}
+			issuers[issuer.Issuer] = true
+			errMessages = append(errMessages, validateJWKS(field, issuer)...)
+			for j, mapping := range issuer.ClaimToHeaders {
+				mappingField := fmt.Sprintf("%s.claimToHeaders[%d]", field, j)
+				if len(mapping.Claim) == 0 || !isPrintableASCII(mapping.Claim) {
+					errMessages = append(errMessages, fmt.Sprintf("%s.claim must be a non-empty string of printable ASCII characters", mappingField))
+				}
+				for _, msg := range k8sValidations.IsHTTPHeaderName(mapping.Header) {
+					errMessages = append(errMessages, fmt.Sprintf("%s.header: %s", mappingField, msg))
+				}
+			}
+		}
+	}
+	if len(errMessages) > 0 {
+		return fmt.Errorf("invalid authentication settings specified for IngressTrait with name '%v': %v",
+			r.Name, s.Join(errMessages, ", "))
+	}
+	return nil
+}
+
+// validateIssuerConflicts validates that the JWT issuers of the ingress trait have the same keys and token forwarding
+// as in the other ingress traits.  The tokens are validated by the shared ingress gateway, so different settings
+// would change the validation of the tokens sent to the hosts of the other ingress traits.
+func (r *IngressTrait) validateIssuerConflicts(existingTraits []IngressTrait) error {
+	if r.Spec.Authentication == nil {
+		return nil
+	}
+	for _, issuer := range r.Spec.Authentication.JWT {
+		for _, ingressTrait := range existingTraits {
+			if ingressTrait.Spec.Authentication == nil {
+				continue
+			}
+			for _, existing := range ingressTrait.Spec.Authentication.JWT {
+				if existing.Issuer != issuer.Issuer {
+					continue
+				}
+				if existing.JwksURI != issuer.JwksURI || existing.Jwks != issuer.Jwks || existing.ForwardOriginalToken != issuer.ForwardOriginalToken {
+					return fmt.Errorf(
+						"IngressTrait JWT issuer conflict. An existing IngressTrait with the name: '%v' in the namespace: '%v' specifies the issuer: '%v' with different keys or token forwarding",
+						ingressTrait.Name, ingressTrait.Namespace, issuer.Issuer)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateJWKS validates the location or the content of the public keys verifying the tokens of a JWT issuer
+func validateJWKS(field string, issuer IngressJWTIssuer) []string {
+	if len(issuer.JwksURI) > 0 && len(issuer.Jwks) > 0 {
+		return []string{fmt.Sprintf("%s.jwksUri and %s.jwks cannot both be specified", field, field)}
+	}
+	if len(issuer.JwksURI) > 0 {
+		uri, err := url.Parse(issuer.JwksURI)
+		if err != nil || uri.Scheme != "https" || len(uri.Host) == 0 {
+			return []string{fmt.Sprintf("%s.jwksUri must be a valid https URL", field)}
+		}
+	}
+	if len(issuer.Jwks) > 0 && !json.Valid([]byte(issuer.Jwks)) {
+		return []string{fmt.Sprintf("%s.jwks must be a valid JSON Web Key Set", field)}
+	}
+	return nil
+}
+
+// isPrintableASCII returns true if the string only contains printable ASCII characters
+func isPrintableASCII(value string) bool {
+	for _, c := range value {
+		if c < ' ' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// validatePathTrafficSettings validates the timeout, retries, CORS policy, headers and rate limit of a path
+func validatePathTrafficSettings(field string, path IngressPath) []string {
+	var errMessages []string
+	errMessages = append(errMessages, validateDuration(field+".timeout", path.Timeout)...)
+	if path.Retries != nil {
+		if path.Retries.Attempts < 0 {
+			errMessages = append(errMessages, fmt.Sprintf("%s.retries.attempts must not be negative", field))
+		}
+		errMessages = append(errMessages, validateDuration(field+".retries.perTryTimeout", path.Retries.PerTryTimeout)...)
+	}
+	if path.CORS != nil {
+		if len(path.CORS.AllowOrigins) == 0 {
+			errMessages = append(errMessages, fmt.Sprintf("%s.cors.allowOrigins must not be empty", field))
+		}
+		errMessages = append(errMessages, validateDuration(field+".cors.maxAge", path.CORS.MaxAge)...)
+	}
+	if path.Headers != nil {
+		errMessages = append(errMessages, validateHeaderOperations(field+".headers.request", path.Headers.Request)...)
+		errMessages = append(errMessages, validateHeaderOperations(field+".headers.response", path.Headers.Response)...)
+	}
+	if path.RateLimit != nil {
+		if path.RateLimit.Requests == 0 {
+			errMessages = append(errMessages, fmt.Sprintf("%s.rateLimit.requests must be greater than 0", field))
+		}
+		switch path.RateLimit.Unit {
+		case "", RateLimitUnitSecond, RateLimitUnitMinute, RateLimitUnitHour:
+		default:
+			errMessages = append(errMessages, fmt.Sprintf("%s.rateLimit.unit must be one of %q, %q or %q", field,
+				RateLimitUnitSecond, RateLimitUnitMinute, RateLimitUnitHour))
+		}
+	}
+	return errMessages
+}
+
+// validateDestinationTrafficSettings validates the connection pool and outlier detection settings of a destination
+func validateDestinationTrafficSettings(field string, destination IngressDestination) []string {
+	var errMessages []string
+	if pool := destination.ConnectionPool; pool != nil {
+		if pool.MaxConnections < 0 || pool.MaxPendingRequests < 0 || pool.MaxRequests < 0 || pool.MaxRequestsPerConnection < 0 {
+			errMessages = append(errMessages, fmt.Sprintf("%s.connectionPool limits must not be negative", field))
+		}
+		errMessages = append(errMessages, validateDuration(field+".connectionPool.connectTimeout", pool.ConnectTimeout)...)
+		errMessages = append(errMessages, validateDuration(field+".connectionPool.idleTimeout", pool.IdleTimeout)...)
+	}
+	if outlier := destination.OutlierDetection; outlier != nil {
+		if outlier.MaxEjectionPercent < 0 || outlier.MaxEjectionPercent > 100 {
+			errMessages = append(errMessages, fmt.Sprintf("%s.outlierDetection.maxEjectionPercent must be between 0 and 100", field))
+		}
+		errMessages = append(errMessages, validateDuration(field+".outlierDetection.interval", outlier.Interval)...)
+		errMessages = append(errMessages, validateDuration(field+".outlierDetection.baseEjectionTime", outlier.BaseEjectionTime)...)
+	}
+	return errMessages
+}
+
+// validateWeightedDestinations validates the weighted destinations of a rule
+func validateWeightedDestinations(field string, rule IngressRule) []string {
+	if len(rule.Destinations) == 0 {
+		return nil
+	}
+	var errMessages []string
+	if len(rule.Destination.Host) > 0 {
+		errMessages = append(errMessages, fmt.Sprintf("%s.destination.host cannot be specified with weighted destinations", field))
+	}
+	names := map[string]bool{}
+	total := int32(0)
+	unweighted := 0
+	for i, destination := range rule.Destinations {
+		destinationField := fmt.Sprintf("%s.destinations[%d]", field, i)
+		if len(destination.Name) == 0 {
+			errMessages = append(errMessages, fmt.Sprintf("%s.name must be specified", destinationField))
+		} else if names[destination.Name] {
+			errMessages = append(errMessages, fmt.Sprintf("%s.name %q is not unique", destinationField, destination.Name))
+		} else {
+			for _, msg := range k8sValidations.IsDNS1123Label(destination.Name) {
+				errMessages = append(errMessages, fmt.Sprintf("%s.name: %s", destinationField, msg))
+			}
+		}
+		names[destination.Name] = true
+		if len(destination.Host) > 0 && len(destination.Component) > 0 {
+			errMessages = append(errMessages, fmt.Sprintf("%s.host cannot be specified with a component", destinationField))
+		}
+		for key, value := range destination.Labels {
+			for _, msg := range k8sValidations.IsQualifiedName(key) {
+				errMessages = append(errMessages, fmt.Sprintf("%s.labels: %s", destinationField, msg))
+			}
+			for _, msg := range k8sValidations.IsValidLabelValue(value) {
+				errMessages = append(errMessages, fmt.Sprintf("%s.labels: %s", destinationField, msg))
+			}
+		}
+		for name := range destination.Headers {
+			for _, msg := range k8sValidations.IsHTTPHeaderName(name) {
+				errMessages = append(errMessages, fmt.Sprintf("%s.headers: %s", destinationField, msg))
+			}
+		}
+		if destination.Weight == nil {
+			unweighted++
+			continue
+		}
+		if *destination.Weight < 0 || *destination.Weight > 100 {
+			errMessages = append(errMessages, fmt.Sprintf("%s.weight must be between 0 and 100", destinationField))
+		}
+		total += *destination.Weight
+	}
+	if total > 100 || (unweighted == 0 && total != 100) {
+		errMessages = append(errMessages, fmt.Sprintf("%s.destinations weights must add up to 100, the weights add up to %d", field, total))
+	}
+	return errMessages
+}
+
+// validateHeaderOperations validates the names of the headers manipulated by the operations
+func validateHeaderOperations(field string, ops *IngressHeaderOperations) []string {
+	if ops == nil {
+		return nil
+	}
+	var names []string
+	for name := range ops.Set {
+		names = append(names, name)
+	}
+	for name := range ops.Add {
+		names = append(names, name)
+	}
+	names = append(names, ops.Remove...)
+	var errMessages []string
+	for _, name := range names {
+		for _, msg := range k8sValidations.IsHTTPHeaderName(name) {
+			errMessages = append(errMessages, fmt.Sprintf("%s: %s", field, msg))
+		}
+	}
+	return errMessages
+}
+
+// validateDuration validates that an optional duration is a positive Go duration
+func validateDuration(field string, value string) []string {
+	if len(value) == 0 {
+		return nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return []string{fmt.Sprintf("%s is not a valid duration: %v", field, err)}
+	}
+	if duration <= 0 {
+		return []string{fmt.Sprintf("%s must be greater than 0", field)}
+	}
+	return nil
+}
+
 // getNormalizedHosts gets a normalized host string from a rule
 func getNormalizedHosts(rule IngressRule) []string {
 	hosts := make([]string, len(rule.Hosts))
@@ -1,4 +1,4 @@
-// Copyright (C) 2020, 2022, Oracle and/or its affiliates.
+// Copyright (C) 2020, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package v1alpha1
@@ -533,3 +533,267 @@ func TestValidateUpdateChangeHostCollision(t *testing.T) {
 func testListIngressTraits(namespace string) (*IngressTraitList, error) {
 	return &existingTraits, nil
 }
+
+// TestValidateCreateValidTrafficSettings tests validation of an IngressTrait create with traffic management settings.
+// GIVEN no existing IngressTrait's
+// WHEN validate is called on a new IngressTrait with valid timeouts, retries, CORS policy, headers, rate limit,
+// connection pool and outlier detection settings
+// THEN validate is successful and returns no errors
+func TestValidateCreateValidTrafficSettings(t *testing.T) {
+	originalListIngressTraits := getAllIngressTraits
+	getAllIngressTraits = testListIngressTraits
+	defer func() { getAllIngressTraits = originalListIngressTraits }()
+
+	path := IngressPath{
+		Path:      "/test/path",
+		Timeout:   "10s",
+		Retries:   &IngressRetryPolicy{Attempts: 3, PerTryTimeout: "2s", RetryOn: "5xx"},
+		CORS:      &IngressCORSPolicy{AllowOrigins: []string{"*"}, MaxAge: "24h"},
+		Headers:   &IngressHeaders{Request: &IngressHeaderOperations{Set: map[string]string{"X-Test": "true"}, Remove: []string{"X-Remove"}}},
+		RateLimit: &IngressRateLimit{Requests: 100, Unit: RateLimitUnitMinute},
+	}
+	destination := IngressDestination{
+		Host:             "test-service",
+		ConnectionPool:   &IngressConnectionPool{MaxConnections: 100, ConnectTimeout: "500ms"},
+		OutlierDetection: &IngressOutlierDetection{Consecutive5xxErrors: 5, Interval: "30s", MaxEjectionPercent: 50},
+	}
+	rule := IngressRule{Hosts: []string{"foo.bar.com"}, Paths: []IngressPath{path}, Destination: destination}
+	ingressTrait := IngressTrait{Spec: IngressTraitSpec{Rules: []IngressRule{rule}}}
+	err := ingressTrait.ValidateCreate()
+	assert.Nil(t, err)
+}
+
+// TestValidateCreateInvalidTrafficSettings tests validation of an IngressTrait create with invalid traffic management settings.
+// GIVEN no existing IngressTrait's
+// WHEN validate is called on a new IngressTrait with invalid traffic management settings
+// THEN validate fails and returns an error naming each invalid setting
+func TestValidateCreateInvalidTrafficSettings(t *testing.T) {
+	originalListIngressTraits := getAllIngressTraits
+	getAllIngressTraits = testListIngressTraits
+	defer func() { getAllIngressTraits = originalListIngressTraits }()
+
+	tests := []struct {
+		name        string
+		path        IngressPath
+		destination IngressDestination
+		expected    string
+	}{
+		{name: "timeout", path: IngressPath{Timeout: "10"}, expected: "rules[0].paths[0].timeout is not a valid duration"},
+		{name: "negative timeout", path: IngressPath{Timeout: "-1s"}, expected: "rules[0].paths[0].timeout must be greater than 0"},
+		{name: "retries", path: IngressPath{Retries: &IngressRetryPolicy{Attempts: -1}}, expected: "retries.attempts must not be negative"},
+		{name: "cors", path: IngressPath{CORS: &IngressCORSPolicy{}}, expected: "cors.allowOrigins must not be empty"},
+		{name: "headers", path: IngressPath{Headers: &IngressHeaders{Response: &IngressHeaderOperations{Remove: []string{"bad header"}}}}, expected: "headers.response"},
+		{name: "rate limit requests", path: IngressPath{RateLimit: &IngressRateLimit{}}, expected: "rateLimit.requests must be greater than 0"},
+		{name: "rate limit unit", path: IngressPath{RateLimit: &IngressRateLimit{Requests: 1, Unit: "day"}}, expected: "rateLimit.unit must be one of"},
+		{name: "connection pool", destination: IngressDestination{ConnectionPool: &IngressConnectionPool{MaxRequests: -1}}, expected: "rules[0].destination.connectionPool limits must not be negative"},
+		{name: "outlier detection", destination: IngressDestination{OutlierDetection: &IngressOutlierDetection{MaxEjectionPercent: 101}}, expected: "maxEjectionPercent must be between 0 and 100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := IngressRule{Hosts: []string{"foo.bar.com"}, Paths: []IngressPath{tt.path}, Destination: tt.destination}
+			ingressTrait := IngressTrait{Spec: IngressTraitSpec{Rules: []IngressRule{rule}}}
+			err := ingressTrait.ValidateCreate()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
+
+// TestValidateCreateWeightedDestinations tests validation of an IngressTrait create with weighted destinations.
+// GIVEN no existing IngressTrait's
+// WHEN validate is called on new IngressTraits with valid and invalid weighted destinations
+// THEN validate succeeds for the valid destinations and fails for the invalid destinations
+func TestValidateCreateWeightedDestinations(t *testing.T) {
+	originalListIngressTraits := getAllIngressTraits
+	getAllIngressTraits = testListIngressTraits
+	defer func() { getAllIngressTraits = originalListIngressTraits }()
+
+	weight := func(w int32) *int32 { return &w }
+	tests := []struct {
+		name         string
+		destination  IngressDestination
+		destinations []IngressWeightedDestination
+		expected     string
+	}{
+		{
+			name: "valid",
+			destinations: []IngressWeightedDestination{
+				{Name: "stable", Labels: map[string]string{"version": "v1"}, Weight: weight(90)},
+				{Name: "canary", Labels: map[string]string{"version": "v2"}, Weight: weight(10), Headers: map[string]string{"X-Canary": "true"}},
+			},
+		},
+		{
+			name:         "valid without weights",
+			destinations: []IngressWeightedDestination{{Name: "stable", Component: "hello-v1"}, {Name: "canary", Component: "hello-v2"}},
+		},
+		{
+			name:         "host of the rule destination",
+			destination:  IngressDestination{Host: "hello"},
+			destinations: []IngressWeightedDestination{{Name: "stable"}},
+			expected:     "rules[0].destination.host cannot be specified with weighted destinations",
+		},
+		{
+			name:         "duplicate names",
+			destinations: []IngressWeightedDestination{{Name: "stable"}, {Name: "stable"}},
+			expected:     `rules[0].destinations[1].name "stable" is not unique`,
+		},
+		{
+			name:         "host and component",
+			destinations: []IngressWeightedDestination{{Name: "stable", Host: "hello", Component: "hello"}},
+			expected:     "rules[0].destinations[0].host cannot be specified with a component",
+		},
+		{
+			name:         "weights over 100",
+			destinations: []IngressWeightedDestination{{Name: "stable", Weight: weight(90)}, {Name: "canary", Weight: weight(20)}},
+			expected:     "rules[0].destinations weights must add up to 100, the weights add up to 110",
+		},
+		{
+			name:         "weights under 100",
+			destinations: []IngressWeightedDestination{{Name: "stable", Weight: weight(80)}, {Name: "canary", Weight: weight(10)}},
+			expected:     "the weights add up to 90",
+		},
+		{
+			name:         "invalid label",
+			destinations: []IngressWeightedDestination{{Name: "stable", Labels: map[string]string{"version": "not valid"}}},
+			expected:     "rules[0].destinations[0].labels",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := IngressRule{Hosts: []string{"foo.bar.com"}, Destination: tt.destination, Destinations: tt.destinations}
+			ingressTrait := IngressTrait{Spec: IngressTraitSpec{Rules: []IngressRule{rule}}}
+			err := ingressTrait.ValidateCreate()
+			if len(tt.expected) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
+
+// TestValidateCreateAuthentication tests validation of an IngressTrait create with TLS modes and JWT issuers.
+// GIVEN no existing IngressTrait's
+// WHEN validate is called on new IngressTraits with valid and invalid authentication settings
+// THEN validate succeeds for the valid settings and fails for the invalid settings
+func TestValidateCreateAuthentication(t *testing.T) {
+	originalListIngressTraits := getAllIngressTraits
+	getAllIngressTraits = testListIngressTraits
+	defer func() { getAllIngressTraits = originalListIngressTraits }()
+
+	tests := []struct {
+		name           string
+		tls            IngressSecurity
+		authentication *IngressAuthentication
+		expected       string
+	}{
+		{
+			name: "valid",
+			tls:  IngressSecurity{SecretName: "hello-tls", Mode: TLSModeMutual, ClientCASecretName: "hello-ca"},
+			authentication: &IngressAuthentication{JWT: []IngressJWTIssuer{
+				{Issuer: "https://issuer.example.com", JwksURI: "https://issuer.example.com/keys", ClaimToHeaders: []IngressClaimToHeader{{Claim: "sub", Header: "X-User"}}},
+				{Issuer: "other", Jwks: `{"keys":[]}`},
+			}},
+		},
+		{
+			name:     "invalid mode",
+			tls:      IngressSecurity{Mode: "OPTIONAL"},
+			expected: `tls.mode must be one of "SIMPLE" or "MUTUAL"`,
+		},
+		{
+			name:     "mutual without client CA",
+			tls:      IngressSecurity{Mode: TLSModeMutual},
+			expected: `tls.clientCASecretName must be specified with the "MUTUAL" mode`,
+		},
+		{
+			name:     "client CA without mutual",
+			tls:      IngressSecurity{ClientCASecretName: "hello-ca"},
+			expected: `tls.clientCASecretName can only be specified with the "MUTUAL" mode`,
+		},
+		{
+			name:           "missing issuer",
+			authentication: &IngressAuthentication{JWT: []IngressJWTIssuer{{}}},
+			expected:       "authentication.jwt[0].issuer must be specified",
+		},
+		{
+			name:           "duplicate issuers",
+			authentication: &IngressAuthentication{JWT: []IngressJWTIssuer{{Issuer: "a"}, {Issuer: "a"}}},
+			expected:       `authentication.jwt[1].issuer "a" is not unique`,
+		},
+		{
+			name:           "jwks and jwks uri",
+			authentication: &IngressAuthentication{JWT: []IngressJWTIssuer{{Issuer: "a", JwksURI: "https://a/keys", Jwks: "{}"}}},
+			expected:       "authentication.jwt[0].jwksUri and authentication.jwt[0].jwks cannot both be specified",
+		},
+		{
+			name:           "http jwks uri",
+			authentication: &IngressAuthentication{JWT: []IngressJWTIssuer{{Issuer: "a", JwksURI: "http://a/keys"}}},
+			expected:       "authentication.jwt[0].jwksUri must be a valid https URL",
+		},
+		{
+			name:           "invalid jwks",
+			authentication: &IngressAuthentication{JWT: []IngressJWTIssuer{{Issuer: "a", Jwks: "{keys"}}},
+			expected:       "authentication.jwt[0].jwks must be a valid JSON Web Key Set",
+		},
+		{
+			name:           "invalid claim",
+			authentication: &IngressAuthentication{JWT: []IngressJWTIssuer{{Issuer: "a", ClaimToHeaders: []IngressClaimToHeader{{Claim: "sub\n", Header: "X-User"}}}}},
+			expected:       "authentication.jwt[0].claimToHeaders[0].claim must be a non-empty string of printable ASCII characters",
+		},
+		{
+			name:           "invalid header",
+			authentication: &IngressAuthentication{JWT: []IngressJWTIssuer{{Issuer: "a", ClaimToHeaders: []IngressClaimToHeader{{Claim: "sub", Header: "X User"}}}}},
+			expected:       "authentication.jwt[0].claimToHeaders[0].header",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := IngressRule{Hosts: []string{"foo.bar.com"}}
+			ingressTrait := IngressTrait{Spec: IngressTraitSpec{Rules: []IngressRule{rule}, TLS: tt.tls, Authentication: tt.authentication}}
+			err := ingressTrait.ValidateCreate()
+			if len(tt.expected) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
+
+// TestValidateCreateIssuerConflict tests validation of the JWT issuers of an IngressTrait against the other traits
+// GIVEN an existing IngressTrait in another namespace with a JWT issuer
+// WHEN validate is called on a new IngressTrait specifying the same issuer with other keys, or other audiences
+// THEN validate fails when the keys differ, and succeeds when only the audiences differ
+func TestValidateCreateIssuerConflict(t *testing.T) {
+	originalListIngressTraits := getAllIngressTraits
+	getAllIngressTraits = testListIngressTraits
+	defer func() { getAllIngressTraits = originalListIngressTraits }()
+
+	existingTraits.Items = append(existingTraits.Items, IngressTrait{
+		ObjectMeta: v1.ObjectMeta{Namespace: "other", Name: "other-trait"},
+		Spec: IngressTraitSpec{
+			Rules: []IngressRule{{Hosts: []string{"other.bar.com"}}},
+			Authentication: &IngressAuthentication{JWT: []IngressJWTIssuer{
+				{Issuer: "https://issuer.example.com", JwksURI: "https://issuer.example.com/keys", Audiences: []string{"other"}},
+			}},
+		},
+	})
+	defer func() { existingTraits.Items = existingTraits.Items[:0] }()
+
+	ingressTrait := IngressTrait{Spec: IngressTraitSpec{
+		Rules: []IngressRule{{Hosts: []string{"foo.bar.com"}}},
+		Authentication: &IngressAuthentication{JWT: []IngressJWTIssuer{
+			{Issuer: "https://issuer.example.com", JwksURI: "https://attacker.example.com/keys"},
+		}},
+	}}
+	err := ingressTrait.ValidateCreate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "IngressTrait JWT issuer conflict")
+	assert.Contains(t, err.Error(), "'other-trait' in the namespace: 'other'")
+
+	ingressTrait.Spec.Authentication.JWT[0].JwksURI = "https://issuer.example.com/keys"
+	ingressTrait.Spec.Authentication.JWT[0].Audiences = []string{"hello"}
+	assert.NoError(t, ingressTrait.ValidateCreate())
+}
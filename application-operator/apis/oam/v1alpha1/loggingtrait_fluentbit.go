@@ -0,0 +1,104 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package v1alpha1
+
+// Formats of the Fluent Bit parsers of a logging trait
+const (
+	FluentBitParserFormatRegex  = "regex"
+	FluentBitParserFormatJSON   = "json"
+	FluentBitParserFormatLogfmt = "logfmt"
+)
+
+// Log parsing profiles of a logging trait
+const (
+	LoggingProfileWebLogic  = "weblogic"
+	LoggingProfileHelidon   = "helidon"
+	LoggingProfileCoherence = "coherence"
+	LoggingProfileJSON      = "json"
+	LoggingProfileLogfmt    = "logfmt"
+)
+
+// FluentBitLogging specifies how Fluent Bit processes the logs of the workload.  The parsers, multiline rules and
+// field filters are rendered as Fluent Operator resources in the namespace of the workload, they only apply to the
+// logs of the workload.
+type FluentBitLogging struct {
+	// The key of the log records holding the log line, defaults to log.
+	// +optional
+	KeyName string `json:"keyName,omitempty"`
+	// The name of a log parsing profile of the catalog, one of weblogic, helidon, coherence, json or logfmt. The
+	// profile provides the multiline rules and the parsers extracting the fields of the logs of the workload. The
+	// multiline rules, parsers and field filters of the trait are applied in addition to the ones of the profile.
+	// Profiles only apply to the logs written to the standard output of the containers, which Fluent Bit processes.
+	// The server log files of WebLogic workloads are still collected and parsed by the Fluentd sidecar of the
+	// workload, the weblogic profile parses the server logs written to the standard output.
+	// +kubebuilder:validation:Enum=weblogic;helidon;coherence;json;logfmt
+	// +optional
+	Profile string `json:"profile,omitempty"`
+	// If true, the logs parsed by the profile are also sent to the OpenSearch index of the profile,
+	// verrazzano-application-<namespace>.<profile>. The logs are still sent to the application data stream of the
+	// namespace, so they are indexed twice and use twice the OpenSearch storage. The index of the profile matches the
+	// verrazzano-application* index pattern, so the retention policies of the application logs also apply to it.
+	// Requires a profile.
+	// +optional
+	ProfileIndex bool `json:"profileIndex,omitempty"`
+	// The multiline rules concatenating the log lines of a single log entry, such as stack traces. The multiline rules
+	// are applied before the parsers.
+	// +optional
+	Multiline *FluentBitMultiline `json:"multiline,omitempty"`
+	// The parsers extracting the fields of the log lines, tried in order until one of them matches.
+	// +optional
+	Parsers []FluentBitParser `json:"parsers,omitempty"`
+	// The filters of the fields of the log records, applied after the parsers.
+	// +optional
+	Fields *FluentBitFieldFilters `json:"fields,omitempty"`
+	// The output of the logs. The logs are sent to the OpenSearch of the cluster by default.
+	// +optional
+	Output *FluentBitOutput `json:"output,omitempty"`
+}
+
+// FluentBitMultiline specifies the multiline rules of the logs of the workload.
+type FluentBitMultiline struct {
+	// The names of the Fluent Bit built-in multiline parsers to apply, such as java, go or python.
+	Parsers []string `json:"parsers"`
+}
+
+// FluentBitParser specifies a parser of the log lines of the workload.
+type FluentBitParser struct {
+	// The name of the parser, unique within the trait.
+	Name string `json:"name"`
+	// The format of the log lines, one of regex, json or logfmt.
+	// +kubebuilder:validation:Enum=regex;json;logfmt
+	Format string `json:"format"`
+	// The regular expression extracting the fields of the log lines with named capture groups, required by the
+	// regex format.
+	// +optional
+	Regex string `json:"regex,omitempty"`
+	// The field holding the time of the log entry.
+	// +optional
+	TimeKey string `json:"timeKey,omitempty"`
+	// The format of the time of the log entry, in strptime format.
+	// +optional
+	TimeFormat string `json:"timeFormat,omitempty"`
+}
+
+// FluentBitFieldFilters specifies the fields added, renamed and removed from the log records.
+type FluentBitFieldFilters struct {
+	// The fields added to the log records, unless the log records already have them.
+	// +optional
+	Add map[string]string `json:"add,omitempty"`
+	// The fields renamed, by their current names.
+	// +optional
+	Rename map[string]string `json:"rename,omitempty"`
+	// The fields removed from the log records.
+	// +optional
+	Remove []string `json:"remove,omitempty"`
+}
+
+// FluentBitOutput specifies the output of the logs of the workload.
+type FluentBitOutput struct {
+	// The name of a ClusterOutput whose output plugin configuration is used to send the logs of the workload. The logs
+	// are sent to the output in addition to the OpenSearch of the cluster.
+	// +optional
+	ClusterOutput string `json:"clusterOutput,omitempty"`
+}
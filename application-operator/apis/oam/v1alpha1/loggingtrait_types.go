@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package v1alpha1
@@ -27,6 +27,11 @@ type LoggingTraitSpec struct {
 	// +optional
 	LoggingImage string `json:"loggingImage,omitempty"`
 
+	// The Fluent Bit processing of the logs of the workload.  When specified, the logs are processed by the Fluent Bit
+	// daemon set of the cluster instead of a Fluentd sidecar, and the Fluentd configuration and image are ignored.
+	// +optional
+	FluentBit *FluentBitLogging `json:"fluentBit,omitempty"`
+
 	// The WorkloadReference of the workload to which this trait applies.
 	// This value is populated by the OAM runtime when an ApplicationConfiguration
 	// resource is processed.  When the ApplicationConfiguration is processed, a trait and
@@ -0,0 +1,107 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package v1alpha1
+
+import (
+	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TracingTraitKind identifies the Kind for the tracing trait.
+const TracingTraitKind string = "TracingTrait"
+
+// Propagation formats of the trace context of a tracing trait
+const (
+	TracingPropagationTraceContext = "tracecontext"
+	TracingPropagationB3           = "b3"
+	TracingPropagationB3Multi      = "b3multi"
+	TracingPropagationJaeger       = "jaeger"
+)
+
+// Protocols of the exporter of a tracing trait
+const (
+	TracingProtocolGRPC         = "grpc"
+	TracingProtocolHTTPProtobuf = "http/protobuf"
+)
+
+func init() {
+	SchemeBuilder.Register(&TracingTrait{}, &TracingTraitList{})
+}
+
+// TracingTraitList contains a list of TracingTrait.
+// +kubebuilder:object:root=true
+type TracingTraitList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TracingTrait `json:"items"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// TracingTrait specifies the tracing trait API.  The OpenTelemetry environment variables of WebLogic and Coherence
+// workloads are set by their workload controllers, changes to the trait apply to them when the workload is updated
+// or restarted.
+type TracingTrait struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TracingTraitSpec `json:"spec,omitempty"`
+	// The observed state of a tracing trait and related resources.
+	Status TracingTraitStatus `json:"status,omitempty"`
+}
+
+// TracingTraitSpec specifies the desired state of a tracing trait.
+type TracingTraitSpec struct {
+	// Specifies whether tracing is enabled. Defaults to `true`.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// The percentage of the requests traced, from 0 to 100. Defaults to `100`.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	SamplingPercentage *int32 `json:"samplingPercentage,omitempty"`
+
+	// The format of the trace context propagated with the requests: `tracecontext`, `b3`, `b3multi`, or `jaeger`.
+	// Defaults to `tracecontext`.
+	// +kubebuilder:validation:Enum=tracecontext;b3;b3multi;jaeger
+	// +optional
+	Propagation string `json:"propagation,omitempty"`
+
+	// The exporter of the traces. By default, the traces are exported to the Jaeger collector installed by
+	// Verrazzano.
+	// +optional
+	Exporter *TracingExporter `json:"exporter,omitempty"`
+
+	// The WorkloadReference of the workload to which this trait applies.
+	// This value is populated by the OAM runtime when an ApplicationConfiguration
+	// resource is processed.  When the ApplicationConfiguration is processed, a trait and
+	// a workload resource are created from the content of the ApplicationConfiguration.
+	// The WorkloadReference is provided in the trait by OAM to ensure that the trait controller
+	// can find the workload associated with the component containing the trait within the
+	// original ApplicationConfiguration.
+	WorkloadReference oamrt.TypedReference `json:"workloadRef"`
+}
+
+// TracingExporter specifies the OpenTelemetry Protocol (OTLP) exporter of the traces.
+type TracingExporter struct {
+	// The OTLP endpoint of the collector receiving the traces, for example `http://otel-collector.tracing:4317`.
+	Endpoint string `json:"endpoint"`
+
+	// The OTLP protocol of the endpoint: `grpc` or `http/protobuf`. Defaults to `grpc`.
+	// +kubebuilder:validation:Enum=grpc;http/protobuf
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+}
+
+// TracingTraitStatus defines the observed state of a tracing trait and related resources.
+type TracingTraitStatus struct {
+	// Reconcile status of this tracing trait.
+	oamrt.ConditionedStatus `json:",inline"`
+
+	// Related resources affected by this tracing trait.
+	Resources []QualifiedResourceRelation `json:"resources,omitempty"`
+}
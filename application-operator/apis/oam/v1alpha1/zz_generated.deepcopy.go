@@ -110,6 +110,134 @@ func (in *AuthorizationRuleFrom) DeepCopy() *AuthorizationRuleFrom {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalerMetric) DeepCopyInto(out *AutoscalerMetric) {
+	*out = *in
+	if in.TargetAverageUtilization != nil {
+		in, out := &in.TargetAverageUtilization, &out.TargetAverageUtilization
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalerMetric.
+func (in *AutoscalerMetric) DeepCopy() *AutoscalerMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalerMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalerTrait) DeepCopyInto(out *AutoscalerTrait) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalerTrait.
+func (in *AutoscalerTrait) DeepCopy() *AutoscalerTrait {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalerTrait)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoscalerTrait) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalerTraitList) DeepCopyInto(out *AutoscalerTraitList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AutoscalerTrait, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalerTraitList.
+func (in *AutoscalerTraitList) DeepCopy() *AutoscalerTraitList {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalerTraitList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoscalerTraitList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalerTraitSpec) DeepCopyInto(out *AutoscalerTraitSpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]AutoscalerMetric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.WorkloadReference = in.WorkloadReference
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalerTraitSpec.
+func (in *AutoscalerTraitSpec) DeepCopy() *AutoscalerTraitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalerTraitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalerTraitStatus) DeepCopyInto(out *AutoscalerTraitStatus) {
+	*out = *in
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]QualifiedResourceRelation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalerTraitStatus.
+func (in *AutoscalerTraitStatus) DeepCopy() *AutoscalerTraitStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalerTraitStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeploymentTemplate) DeepCopyInto(out *DeploymentTemplate) {
 	*out = *in
@@ -129,6 +257,217 @@ func (in *DeploymentTemplate) DeepCopy() *DeploymentTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentBitFieldFilters) DeepCopyInto(out *FluentBitFieldFilters) {
+	*out = *in
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Rename != nil {
+		in, out := &in.Rename, &out.Rename
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentBitFieldFilters.
+func (in *FluentBitFieldFilters) DeepCopy() *FluentBitFieldFilters {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentBitFieldFilters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentBitLogging) DeepCopyInto(out *FluentBitLogging) {
+	*out = *in
+	if in.Multiline != nil {
+		in, out := &in.Multiline, &out.Multiline
+		*out = new(FluentBitMultiline)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Parsers != nil {
+		in, out := &in.Parsers, &out.Parsers
+		*out = make([]FluentBitParser, len(*in))
+		copy(*out, *in)
+	}
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = new(FluentBitFieldFilters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Output != nil {
+		in, out := &in.Output, &out.Output
+		*out = new(FluentBitOutput)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentBitLogging.
+func (in *FluentBitLogging) DeepCopy() *FluentBitLogging {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentBitLogging)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentBitMultiline) DeepCopyInto(out *FluentBitMultiline) {
+	*out = *in
+	if in.Parsers != nil {
+		in, out := &in.Parsers, &out.Parsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentBitMultiline.
+func (in *FluentBitMultiline) DeepCopy() *FluentBitMultiline {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentBitMultiline)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentBitOutput) DeepCopyInto(out *FluentBitOutput) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentBitOutput.
+func (in *FluentBitOutput) DeepCopy() *FluentBitOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentBitOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentBitParser) DeepCopyInto(out *FluentBitParser) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentBitParser.
+func (in *FluentBitParser) DeepCopy() *FluentBitParser {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentBitParser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressAuthentication) DeepCopyInto(out *IngressAuthentication) {
+	*out = *in
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = make([]IngressJWTIssuer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressAuthentication.
+func (in *IngressAuthentication) DeepCopy() *IngressAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressCORSPolicy) DeepCopyInto(out *IngressCORSPolicy) {
+	*out = *in
+	if in.AllowOrigins != nil {
+		in, out := &in.AllowOrigins, &out.AllowOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowMethods != nil {
+		in, out := &in.AllowMethods, &out.AllowMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowHeaders != nil {
+		in, out := &in.AllowHeaders, &out.AllowHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExposeHeaders != nil {
+		in, out := &in.ExposeHeaders, &out.ExposeHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowCredentials != nil {
+		in, out := &in.AllowCredentials, &out.AllowCredentials
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressCORSPolicy.
+func (in *IngressCORSPolicy) DeepCopy() *IngressCORSPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressCORSPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressClaimToHeader) DeepCopyInto(out *IngressClaimToHeader) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressClaimToHeader.
+func (in *IngressClaimToHeader) DeepCopy() *IngressClaimToHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressClaimToHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressConnectionPool) DeepCopyInto(out *IngressConnectionPool) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressConnectionPool.
+func (in *IngressConnectionPool) DeepCopy() *IngressConnectionPool {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressConnectionPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngressDestination) DeepCopyInto(out *IngressDestination) {
 	*out = *in
@@ -137,6 +476,16 @@ func (in *IngressDestination) DeepCopyInto(out *IngressDestination) {
 		*out = new(IngressDestinationHTTPCookie)
 		**out = **in
 	}
+	if in.ConnectionPool != nil {
+		in, out := &in.ConnectionPool, &out.ConnectionPool
+		*out = new(IngressConnectionPool)
+		**out = **in
+	}
+	if in.OutlierDetection != nil {
+		in, out := &in.OutlierDetection, &out.OutlierDetection
+		*out = new(IngressOutlierDetection)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressDestination.
@@ -149,6 +498,21 @@ func (in *IngressDestination) DeepCopy() *IngressDestination {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressDestinationWeight) DeepCopyInto(out *IngressDestinationWeight) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressDestinationWeight.
+func (in *IngressDestinationWeight) DeepCopy() *IngressDestinationWeight {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressDestinationWeight)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngressDestinationHTTPCookie) DeepCopyInto(out *IngressDestinationHTTPCookie) {
 	*out = *in
@@ -164,6 +528,105 @@ func (in *IngressDestinationHTTPCookie) DeepCopy() *IngressDestinationHTTPCookie
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressHeaderOperations) DeepCopyInto(out *IngressHeaderOperations) {
+	*out = *in
+	if in.Set != nil {
+		in, out := &in.Set, &out.Set
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressHeaderOperations.
+func (in *IngressHeaderOperations) DeepCopy() *IngressHeaderOperations {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressHeaderOperations)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressHeaders) DeepCopyInto(out *IngressHeaders) {
+	*out = *in
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		*out = new(IngressHeaderOperations)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Response != nil {
+		in, out := &in.Response, &out.Response
+		*out = new(IngressHeaderOperations)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressHeaders.
+func (in *IngressHeaders) DeepCopy() *IngressHeaders {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressHeaders)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressJWTIssuer) DeepCopyInto(out *IngressJWTIssuer) {
+	*out = *in
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClaimToHeaders != nil {
+		in, out := &in.ClaimToHeaders, &out.ClaimToHeaders
+		*out = make([]IngressClaimToHeader, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressJWTIssuer.
+func (in *IngressJWTIssuer) DeepCopy() *IngressJWTIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressJWTIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressOutlierDetection) DeepCopyInto(out *IngressOutlierDetection) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressOutlierDetection.
+func (in *IngressOutlierDetection) DeepCopy() *IngressOutlierDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressOutlierDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngressPath) DeepCopyInto(out *IngressPath) {
 	*out = *in
@@ -172,6 +635,26 @@ func (in *IngressPath) DeepCopyInto(out *IngressPath) {
 		*out = new(AuthorizationPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(IngressRetryPolicy)
+		**out = **in
+	}
+	if in.CORS != nil {
+		in, out := &in.CORS, &out.CORS
+		*out = new(IngressCORSPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = new(IngressHeaders)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(IngressRateLimit)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressPath.
@@ -184,10 +667,47 @@ func (in *IngressPath) DeepCopy() *IngressPath {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressRateLimit) DeepCopyInto(out *IngressRateLimit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressRateLimit.
+func (in *IngressRateLimit) DeepCopy() *IngressRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressRetryPolicy) DeepCopyInto(out *IngressRetryPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressRetryPolicy.
+func (in *IngressRetryPolicy) DeepCopy() *IngressRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngressRule) DeepCopyInto(out *IngressRule) {
 	*out = *in
 	in.Destination.DeepCopyInto(&out.Destination)
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]IngressWeightedDestination, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Hosts != nil {
 		in, out := &in.Hosts, &out.Hosts
 		*out = make([]string, len(*in))
@@ -227,6 +747,26 @@ func (in *IngressSecurity) DeepCopy() *IngressSecurity {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressTrafficSplitStatus) DeepCopyInto(out *IngressTrafficSplitStatus) {
+	*out = *in
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]IngressDestinationWeight, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressTrafficSplitStatus.
+func (in *IngressTrafficSplitStatus) DeepCopy() *IngressTrafficSplitStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressTrafficSplitStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngressTrait) DeepCopyInto(out *IngressTrait) {
 	*out = *in
@@ -297,6 +837,11 @@ func (in *IngressTraitSpec) DeepCopyInto(out *IngressTraitSpec) {
 		}
 	}
 	out.TLS = in.TLS
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(IngressAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
 	out.WorkloadReference = in.WorkloadReference
 }
 
@@ -319,6 +864,13 @@ func (in *IngressTraitStatus) DeepCopyInto(out *IngressTraitStatus) {
 		*out = make([]v1.TypedReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.TrafficSplits != nil {
+		in, out := &in.TrafficSplits, &out.TrafficSplits
+		*out = make([]IngressTrafficSplitStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressTraitStatus.
@@ -331,12 +883,46 @@ func (in *IngressTraitStatus) DeepCopy() *IngressTraitStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressWeightedDestination) DeepCopyInto(out *IngressWeightedDestination) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressWeightedDestination.
+func (in *IngressWeightedDestination) DeepCopy() *IngressWeightedDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressWeightedDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoggingTrait) DeepCopyInto(out *LoggingTrait) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -393,6 +979,11 @@ func (in *LoggingTraitList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoggingTraitSpec) DeepCopyInto(out *LoggingTraitSpec) {
 	*out = *in
+	if in.FluentBit != nil {
+		in, out := &in.FluentBit, &out.FluentBit
+		*out = new(FluentBitLogging)
+		(*in).DeepCopyInto(*out)
+	}
 	out.WorkloadReference = in.WorkloadReference
 }
 
@@ -612,6 +1203,132 @@ func (in *ServiceTemplate) DeepCopy() *ServiceTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingExporter) DeepCopyInto(out *TracingExporter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingExporter.
+func (in *TracingExporter) DeepCopy() *TracingExporter {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingExporter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingTrait) DeepCopyInto(out *TracingTrait) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingTrait.
+func (in *TracingTrait) DeepCopy() *TracingTrait {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingTrait)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TracingTrait) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingTraitList) DeepCopyInto(out *TracingTraitList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TracingTrait, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingTraitList.
+func (in *TracingTraitList) DeepCopy() *TracingTraitList {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingTraitList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TracingTraitList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingTraitSpec) DeepCopyInto(out *TracingTraitSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SamplingPercentage != nil {
+		in, out := &in.SamplingPercentage, &out.SamplingPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Exporter != nil {
+		in, out := &in.Exporter, &out.Exporter
+		*out = new(TracingExporter)
+		**out = **in
+	}
+	out.WorkloadReference = in.WorkloadReference
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingTraitSpec.
+func (in *TracingTraitSpec) DeepCopy() *TracingTraitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingTraitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingTraitStatus) DeepCopyInto(out *TracingTraitStatus) {
+	*out = *in
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]QualifiedResourceRelation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingTraitStatus.
+func (in *TracingTraitStatus) DeepCopy() *TracingTraitStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingTraitStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VerrazzanoCoherenceWorkload) DeepCopyInto(out *VerrazzanoCoherenceWorkload) {
 	*out = *in
@@ -15,6 +15,10 @@ type FakeOamV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeOamV1alpha1) AutoscalerTraits(namespace string) v1alpha1.AutoscalerTraitInterface {
+	return &FakeAutoscalerTraits{c, namespace}
+}
+
 func (c *FakeOamV1alpha1) IngressTraits(namespace string) v1alpha1.IngressTraitInterface {
 	return &FakeIngressTraits{c, namespace}
 }
@@ -27,6 +31,10 @@ func (c *FakeOamV1alpha1) MetricsTraits(namespace string) v1alpha1.MetricsTraitI
 	return &FakeMetricsTraits{c, namespace}
 }
 
+func (c *FakeOamV1alpha1) TracingTraits(namespace string) v1alpha1.TracingTraitInterface {
+	return &FakeTracingTraits{c, namespace}
+}
+
 func (c *FakeOamV1alpha1) VerrazzanoCoherenceWorkloads(namespace string) v1alpha1.VerrazzanoCoherenceWorkloadInterface {
 	return &FakeVerrazzanoCoherenceWorkloads{c, namespace}
 }
@@ -5,12 +5,16 @@
 
 package v1alpha1
 
+type AutoscalerTraitExpansion interface{}
+
 type IngressTraitExpansion interface{}
 
 type LoggingTraitExpansion interface{}
 
 type MetricsTraitExpansion interface{}
 
+type TracingTraitExpansion interface{}
+
 type VerrazzanoCoherenceWorkloadExpansion interface{}
 
 type VerrazzanoHelidonWorkloadExpansion interface{}
@@ -15,9 +15,11 @@ import (
 
 type OamV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	AutoscalerTraitsGetter
 	IngressTraitsGetter
 	LoggingTraitsGetter
 	MetricsTraitsGetter
+	TracingTraitsGetter
 	VerrazzanoCoherenceWorkloadsGetter
 	VerrazzanoHelidonWorkloadsGetter
 	VerrazzanoWebLogicWorkloadsGetter
@@ -28,6 +30,10 @@ type OamV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *OamV1alpha1Client) AutoscalerTraits(namespace string) AutoscalerTraitInterface {
+	return newAutoscalerTraits(c, namespace)
+}
+
 func (c *OamV1alpha1Client) IngressTraits(namespace string) IngressTraitInterface {
 	return newIngressTraits(c, namespace)
 }
@@ -40,6 +46,10 @@ func (c *OamV1alpha1Client) MetricsTraits(namespace string) MetricsTraitInterfac
 	return newMetricsTraits(c, namespace)
 }
 
+func (c *OamV1alpha1Client) TracingTraits(namespace string) TracingTraitInterface {
+	return newTracingTraits(c, namespace)
+}
+
 func (c *OamV1alpha1Client) VerrazzanoCoherenceWorkloads(namespace string) VerrazzanoCoherenceWorkloadInterface {
 	return newVerrazzanoCoherenceWorkloads(c, namespace)
 }
@@ -16,6 +16,10 @@ const MCAgentSecret = "verrazzano-cluster-agent" //nolint:gosec //#gosec G101
 // MCRegistrationSecret - the name of the secret that contains the cluster registration information
 const MCRegistrationSecret = "verrazzano-cluster-registration" //nolint:gosec //#gosec G101
 
+// MCFleetUpgradeSecret contains the Verrazzano version, and optionally the platform operator image, that a
+// VerrazzanoFleetUpgrade on the admin cluster rolls out to the managed cluster.
+const MCFleetUpgradeSecret = "verrazzano-fleet-upgrade" //nolint:gosec //#gosec G101
+
 // MCLocalRegistrationSecret - the name of the local secret that contains the cluster registration information.
 // Thos is created at Verrazzano install.
 const MCLocalRegistrationSecret = "verrazzano-local-registration" //nolint:gosec //#gosec G101
@@ -53,6 +57,12 @@ const LabelWorkloadType = "verrazzano.io/workload-type"
 // LabelIngressTraitNsn - Namespaced name of the ingress trait
 const LabelIngressTraitNsn = "verrazzano.io/ingress-trait"
 
+// LabelLoggingTrait - Name of the logging trait
+const LabelLoggingTrait = "verrazzano.io/logging-trait"
+
+// LabelAutoscalerTrait - Name of the autoscaler trait
+const LabelAutoscalerTrait = "verrazzano.io/autoscaler-trait"
+
 // WorkloadTypeWeblogic indicates the workload is WebLogic
 const WorkloadTypeWeblogic = "weblogic"
 
@@ -60,10 +70,6 @@ const WorkloadTypeWeblogic = "weblogic"
 // by the agent channel before controllers trying to send more status updates will start blocking
 const StatusUpdateChannelBufferSize = 50
 
-// StatusUpdateBatchSize - the number of status update messages the multi cluster agent should
-// process each time it wakes up
-const StatusUpdateBatchSize = 10
-
 // VzConsoleIngress - the name of the ingress for Verrazzano console and api
 const VzConsoleIngress = "verrazzano-ingress"
 
@@ -106,6 +112,10 @@ const StatusReasonSuccess = "success"
 // OCILoggingIDAnnotation Annotation name for a customized OCI log ID for all containers in a namespace
 const OCILoggingIDAnnotation = "verrazzano.io/oci-log-id"
 
+// MultiClusterGenerationAnnotation Annotation name for the generation of the multi-cluster resource last applied to
+// the resource created from it
+const MultiClusterGenerationAnnotation = "clusters.verrazzano.io/multicluster-generation"
+
 // WorkloadTypeCoherence indicates the workload is Coherence
 const WorkloadTypeCoherence = "coherence"
 
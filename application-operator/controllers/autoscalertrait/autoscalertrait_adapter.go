@@ -0,0 +1,261 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package autoscalertrait
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/prometheus/promql/parser"
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// adapterName is the name of the Prometheus Adapter deployment and of the ConfigMap of the configuration of its
+	// Helm chart
+	adapterName = "prometheus-adapter"
+	// adapterConfigKey is the key of the configuration of the Prometheus Adapter in its ConfigMaps
+	adapterConfigKey = "config.yaml"
+	// externalRulesKey is the key of the rules of the external metrics in the configuration of the Prometheus Adapter
+	externalRulesKey = "externalRules"
+	// adapterConfigHashAnnotation is the annotation of the pods of the Prometheus Adapter holding the hash of the
+	// configuration they were started with
+	adapterConfigHashAnnotation = "verrazzano.io/prometheus-adapter-config-hash"
+	// adapterRestartInterval is the minimum time between two restarts of the Prometheus Adapter, so that the changes
+	// of the rules of many traits are applied by a single restart
+	adapterRestartInterval = time.Minute
+	// labelMatchersPlaceholder is replaced by the Prometheus Adapter with the label matchers of the series of the
+	// namespace of the HorizontalPodAutoscaler requesting an external metric
+	labelMatchersPlaceholder = "<<.LabelMatchers>>"
+	// labelMatchersLabel is the label matcher replacing the label matchers of the Prometheus Adapter when a query is
+	// parsed
+	labelMatchersLabel = "__verrazzano_label_matchers__"
+)
+
+// buildExternalMetricName builds the name of the external metric of a custom metric of a trait.  Names of namespaces
+// and traits cannot contain underscores, so the metrics of different traits never clash.
+func buildExternalMetricName(trait *vzapi.AutoscalerTrait, name string) string {
+	return buildExternalMetricPrefix(trait) + name
+}
+
+// buildExternalMetricPrefix builds the prefix of the names of the external metrics of a trait
+func buildExternalMetricPrefix(trait *vzapi.AutoscalerTrait) string {
+	return fmt.Sprintf("verrazzano_%s_%s_", trait.Namespace, trait.Name)
+}
+
+// buildAdapterRules builds the Prometheus Adapter rules serving the custom metrics of a trait as external metrics.
+// The value of a metric is the result of the query of the metric, the series query only makes the metric available
+// once Prometheus scrapes targets in the namespace of the trait.  The rules are namespaced, so that the Prometheus
+// Adapter restricts the selectors of the query to the namespace of the HorizontalPodAutoscaler requesting the metric.
+func buildAdapterRules(trait *vzapi.AutoscalerTrait) []interface{} {
+	var rules []interface{}
+	for _, m := range trait.Spec.Metrics {
+		if m.Type != vzapi.AutoscalerMetricCustom {
+			continue
+		}
+		rules = append(rules, map[string]interface{}{
+			"seriesQuery": fmt.Sprintf(`up{namespace="%s"}`, trait.Namespace),
+			"resources": map[string]interface{}{
+				"namespaced": true,
+				"overrides":  map[string]interface{}{"namespace": map[string]interface{}{"resource": "namespace"}},
+			},
+			"name":         map[string]interface{}{"as": buildExternalMetricName(trait, m.Name)},
+			"metricsQuery": m.Query,
+		})
+	}
+	return rules
+}
+
+// updateAdapterRules replaces the external metrics rules of a trait in the configuration read by the Prometheus Adapter.
+// The rules of each trait are kept under their own key of the ConfigMap of the configuration, and the configuration is
+// rebuilt from the configuration of the Helm chart and the rules of all the traits.
+func (r *Reconciler) updateAdapterRules(ctx context.Context, trait *vzapi.AutoscalerTrait, rules []interface{}, log vzlog.VerrazzanoLogger) (vzapi.QualifiedResourceRelation, controllerutil.OperationResult, error) {
+	rel := vzapi.QualifiedResourceRelation{APIVersion: "v1", Kind: "ConfigMap", Namespace: vzconst.VerrazzanoMonitoringNamespace, Name: vzconst.PrometheusAdapterConfigMapName, Role: adapterRulesRole}
+	res, err := r.createOrUpdateAdapterConfig(ctx, func(cm *corev1.ConfigMap) error {
+		key := buildTraitRulesKey(trait)
+		if len(rules) == 0 {
+			delete(cm.Data, key)
+			return nil
+		}
+		data, err := yaml.Marshal(rules)
+		if err != nil {
+			return err
+		}
+		cm.Data[key] = string(data)
+		return nil
+	})
+	if k8serrors.IsNotFound(err) {
+		if len(rules) == 0 {
+			return rel, controllerutil.OperationResultNone, nil
+		}
+		return rel, controllerutil.OperationResultNone, fmt.Errorf("the Prometheus Adapter is not installed, the custom metrics of the autoscaler trait %s are not available", trait.Name)
+	}
+	if res != controllerutil.OperationResultNone {
+		log.Infof("Updated the Prometheus Adapter rules of the autoscaler trait %s", trait.Name)
+	}
+	return rel, res, err
+}
+
+// syncAdapterConfig rebuilds the configuration read by the Prometheus Adapter when the configuration of the Helm chart
+// changes, and restarts the Prometheus Adapter to apply it.  Nothing is done when the Prometheus Adapter is not
+// installed.
+func (r *Reconciler) syncAdapterConfig(ctx context.Context, log vzlog.VerrazzanoLogger) (time.Duration, error) {
+	res, err := r.createOrUpdateAdapterConfig(ctx, func(_ *corev1.ConfigMap) error {
+		return nil
+	})
+	if err != nil {
+		return 0, client.IgnoreNotFound(err)
+	}
+	if res != controllerutil.OperationResultNone {
+		log.Infof("Updated the Prometheus Adapter configuration from the configuration of the %s chart", adapterName)
+	}
+	return r.restartAdapterIfRequired(ctx, log)
+}
+
+// createOrUpdateAdapterConfig creates or updates the ConfigMap of the configuration read by the Prometheus Adapter.
+// The mutate function updates the rules of the traits, then the configuration is rebuilt from the configuration of
+// the Helm chart, whose ConfigMap is reset by the upgrades of the chart, and the rules of all the traits.  A not found
+// error is returned when the Helm chart is not installed.
+func (r *Reconciler) createOrUpdateAdapterConfig(ctx context.Context, f func(cm *corev1.ConfigMap) error) (controllerutil.OperationResult, error) {
+	chartCM := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: vzconst.VerrazzanoMonitoringNamespace, Name: adapterName}, chartCM); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: vzconst.VerrazzanoMonitoringNamespace, Name: vzconst.PrometheusAdapterConfigMapName}}
+	return controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		if err := f(cm); err != nil {
+			return err
+		}
+		config, err := buildAdapterConfig(chartCM.Data[adapterConfigKey], cm.Data)
+		if err != nil {
+			return err
+		}
+		cm.Data[adapterConfigKey] = config
+		return nil
+	})
+}
+
+// buildAdapterConfig builds the configuration read by the Prometheus Adapter by adding the rules of the traits,
+// ordered by key, to the external rules of the configuration of the Helm chart
+func buildAdapterConfig(chartConfig string, data map[string]string) (string, error) {
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(chartConfig), &config); err != nil {
+		return "", err
+	}
+	rules, _ := config[externalRulesKey].([]interface{})
+	var keys []string
+	for key := range data {
+		if key != adapterConfigKey {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		var traitRules []interface{}
+		if err := yaml.Unmarshal([]byte(data[key]), &traitRules); err != nil {
+			return "", err
+		}
+		rules = append(rules, traitRules...)
+	}
+	if len(rules) > 0 {
+		config[externalRulesKey] = rules
+	}
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// buildTraitRulesKey builds the key of the rules of a trait in the ConfigMap of the configuration read by the
+// Prometheus Adapter.  Names of namespaces cannot contain dots, so the keys of different traits never clash.
+func buildTraitRulesKey(trait *vzapi.AutoscalerTrait) string {
+	return fmt.Sprintf("%s.%s", trait.Namespace, trait.Name)
+}
+
+// restartAdapterIfRequired restarts the Prometheus Adapter, which only reads its configuration at startup, when its
+// pods were started with a previous configuration.  The Prometheus Adapter is shared by all the traits, so it is
+// restarted at most once per restart interval.  Returns the time after which a restart that is not done yet is due.
+func (r *Reconciler) restartAdapterIfRequired(ctx context.Context, log vzlog.VerrazzanoLogger) (time.Duration, error) {
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: vzconst.VerrazzanoMonitoringNamespace, Name: vzconst.PrometheusAdapterConfigMapName}, cm); err != nil {
+		return 0, client.IgnoreNotFound(err)
+	}
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: vzconst.VerrazzanoMonitoringNamespace, Name: adapterName}, deployment); err != nil {
+		return 0, client.IgnoreNotFound(err)
+	}
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(cm.Data[adapterConfigKey])))
+	annotations := deployment.Spec.Template.Annotations
+	if annotations[adapterConfigHashAnnotation] == hash {
+		return 0, nil
+	}
+	if restartedAt, err := time.Parse(time.RFC3339, annotations[vzconst.VerrazzanoRestartAnnotation]); err == nil {
+		if delay := time.Until(restartedAt.Add(adapterRestartInterval)); delay > 0 {
+			return delay, nil
+		}
+	}
+
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[adapterConfigHashAnnotation] = hash
+	annotations[vzconst.VerrazzanoRestartAnnotation] = time.Now().Format(time.RFC3339)
+	deployment.Spec.Template.Annotations = annotations
+	log.Infof("Restarting the Prometheus Adapter to apply the changes of its rules")
+	return 0, r.Update(ctx, deployment)
+}
+
+// validateMetricQuery validates that each selector of the Prometheus query of a custom metric includes the label
+// matchers of the Prometheus Adapter, and does not match the namespace label itself, so that the query can only
+// read the series of the namespace of the trait.  The label matchers of the Prometheus Adapter are replaced with a
+// label matcher so that the query can be parsed.
+func validateMetricQuery(query string) error {
+	if strings.Contains(query, labelMatchersLabel) {
+		return fmt.Errorf("the label %s is reserved", labelMatchersLabel)
+	}
+	expr, err := parser.ParseExpr(strings.ReplaceAll(query, labelMatchersPlaceholder, labelMatchersLabel+`="true"`))
+	if err != nil {
+		return err
+	}
+	var selectors []*parser.VectorSelector
+	parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+		if selector, ok := node.(*parser.VectorSelector); ok {
+			selectors = append(selectors, selector)
+		}
+		return nil
+	})
+	for _, selector := range selectors {
+		name := strings.ReplaceAll(selector.String(), labelMatchersLabel+`="true"`, labelMatchersPlaceholder)
+		found, namespaced := false, false
+		for _, m := range selector.LabelMatchers {
+			found = found || m.Name == labelMatchersLabel
+			namespaced = namespaced || m.Name == "namespace"
+		}
+		if !found {
+			return fmt.Errorf("selector %s must include %s", name, labelMatchersPlaceholder)
+		}
+		if namespaced {
+			return fmt.Errorf("selector %s cannot match the namespace label", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,565 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package autoscalertrait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/clusters"
+	vznav "github.com/verrazzano/verrazzano/application-operator/controllers/navigation"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/reconcileresults"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	vzlog "github.com/verrazzano/verrazzano/pkg/log"
+	vzlog2 "github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzstring "github.com/verrazzano/verrazzano/pkg/string"
+	"go.uber.org/zap"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	controllerName = "autoscalertrait"
+
+	// The finalizer name used by this controller
+	finalizerName = "autoscalertrait.finalizers.verrazzano.io"
+
+	// Kubernetes resource Kinds and API versions
+	hpaAPIVersion        = "autoscaling/v2"
+	hpaKind              = "HorizontalPodAutoscaler"
+	deploymentKind       = "Deployment"
+	statefulSetKind      = "StatefulSet"
+	coherenceKind        = "Coherence"
+	weblogicDomainKind   = "Domain"
+	weblogicClusterKind  = "Cluster"
+	weblogicAPIVersionV1 = "weblogic.oracle/v1"
+	weblogicAPIVersionV8 = "weblogic.oracle/v8"
+
+	// In code defaults for autoscaler trait configuration
+	defaultMinReplicas              = 1
+	defaultTargetAverageUtilization = 80
+
+	// The roles of the resources related to an autoscaler trait
+	autoscalerRole   = "autoscaler"
+	adapterRulesRole = "adapter-rules"
+)
+
+// metricNameRegex matches the valid names of the custom metrics of a trait
+var metricNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// adapterConfigRequest is the request reconciling the configuration read by the Prometheus Adapter, named after the
+// ConfigMap of the configuration of the Helm chart of the Prometheus Adapter
+var adapterConfigRequest = reconcile.Request{NamespacedName: types.NamespacedName{Namespace: vzconst.VerrazzanoMonitoringNamespace, Name: adapterName}}
+
+// Reconciler reconciles an AutoscalerTrait object
+type Reconciler struct {
+	client.Client
+	Controller controller.Controller
+	Log        *zap.SugaredLogger
+	Scheme     *runtime.Scheme
+}
+
+// SetupWithManager creates a controller and adds it to the manager, and sets up any watches
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) (err error) {
+	r.Controller, err = ctrl.NewControllerManagedBy(mgr).
+		For(&vzapi.AutoscalerTrait{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Build(r)
+	if err != nil {
+		return err
+	}
+	// Set up a watch on the ConfigMaps of the Prometheus Adapter, to rebuild the configuration read by the Prometheus
+	// Adapter when the Prometheus Adapter is installed or upgraded, even if there is no autoscaler trait
+	return r.Controller.Watch(
+		&source.Kind{Type: &corev1.ConfigMap{}},
+		handler.EnqueueRequestsFromMapFunc(func(a client.Object) []reconcile.Request {
+			return createAdapterConfigReconcileRequests(a)
+		}),
+	)
+}
+
+// createAdapterConfigReconcileRequests returns the request reconciling the configuration read by the Prometheus
+// Adapter when one of the ConfigMaps of its configuration changes
+func createAdapterConfigReconcileRequests(cm client.Object) []reconcile.Request {
+	if cm.GetNamespace() != vzconst.VerrazzanoMonitoringNamespace {
+		return nil
+	}
+	if cm.GetName() != adapterName && cm.GetName() != vzconst.PrometheusAdapterConfigMapName {
+		return nil
+	}
+	return []reconcile.Request{adapterConfigRequest}
+}
+
+// Reconcile reconciles an autoscaler trait with related resources
+// +kubebuilder:rbac:groups=oam.verrazzano.io,resources=autoscalertraits,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=oam.verrazzano.io,resources=autoscalertraits/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=weblogic.oracle,resources=clusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;update;patch
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if ctx == nil {
+		return ctrl.Result{}, errors.New("context cannot be nil")
+	}
+
+	// We do not want any resource to get reconciled if it is in namespace kube-system
+	// This is due to a bug found in OKE, it should not affect functionality of any vz operators
+	// If this is the case then return success
+	if req.Namespace == vzconst.KubeSystem {
+		log := zap.S().With(vzlog.FieldResourceNamespace, req.Namespace, vzlog.FieldResourceName, req.Name, vzlog.FieldController, controllerName)
+		log.Infof("Autoscaler trait resource %v should not be reconciled in kube-system namespace, ignoring", req.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+
+	// There are no autoscaler traits in the namespace of the Prometheus Adapter
+	if req == adapterConfigRequest {
+		return r.reconcileAdapterConfig(ctx)
+	}
+
+	trait := &vzapi.AutoscalerTrait{}
+	if err := r.Get(ctx, req.NamespacedName, trait); err != nil {
+		return clusters.IgnoreNotFoundWithLog(err, zap.S())
+	}
+	log, err := clusters.GetResourceLogger("autoscalertrait", req.NamespacedName, trait)
+	if err != nil {
+		zap.S().Errorf("Failed to create controller logger for autoscaler trait resource: %v", err)
+		return clusters.NewRequeueWithDelay(), nil
+	}
+	log.Oncef("Reconciling autoscaler trait resource %v, generation %v", req.NamespacedName, trait.Generation)
+
+	res, err := r.doReconcile(ctx, trait, log)
+	if clusters.ShouldRequeue(res) {
+		return res, nil
+	}
+	// Never return an error since it has already been logged and we don't want the
+	// controller runtime to log again (with stack trace).  Just re-queue if there is an error.
+	if err != nil {
+		return clusters.NewRequeueWithDelay(), nil
+	}
+
+	log.Oncef("Finished reconciling autoscaler trait %v", req.NamespacedName)
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileAdapterConfig rebuilds the configuration read by the Prometheus Adapter from the configuration of its Helm
+// chart and the rules of the traits
+func (r *Reconciler) reconcileAdapterConfig(ctx context.Context) (ctrl.Result, error) {
+	log := vzlog2.DefaultLogger()
+	restartDelay, err := r.syncAdapterConfig(ctx, log)
+	if err != nil {
+		log.Errorf("Failed to update the Prometheus Adapter configuration: %v", err)
+		return clusters.NewRequeueWithDelay(), nil
+	}
+	return requeueWithin(ctrl.Result{}, restartDelay), nil
+}
+
+// doReconcile performs the reconciliation operations for the autoscaler trait
+func (r *Reconciler) doReconcile(ctx context.Context, trait *vzapi.AutoscalerTrait, log vzlog2.VerrazzanoLogger) (ctrl.Result, error) {
+	if trait.DeletionTimestamp.IsZero() {
+		return r.reconcileTraitCreateOrUpdate(ctx, trait, log)
+	}
+	return r.reconcileTraitDelete(ctx, trait, log)
+}
+
+// reconcileTraitCreateOrUpdate reconciles an autoscaler trait that is being created or updated.  A
+// HorizontalPodAutoscaler is created for the scalable resources of the workload, and the Prometheus Adapter rules
+// of the custom metrics of the trait are updated.
+func (r *Reconciler) reconcileTraitCreateOrUpdate(ctx context.Context, trait *vzapi.AutoscalerTrait, log vzlog2.VerrazzanoLogger) (ctrl.Result, error) {
+	if err := validateAutoscalerTrait(trait); err != nil {
+		log.Errorf("Invalid autoscaler trait %s: %v", trait.Name, err)
+		return reconcile.Result{}, err
+	}
+	if err := r.addFinalizerIfRequired(ctx, trait, log); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	workload, err := vznav.FetchWorkloadFromTrait(ctx, r, log, trait)
+	if err != nil || workload == nil {
+		return reconcile.Result{}, err
+	}
+
+	status := &reconcileresults.ReconcileResults{}
+	rules := buildAdapterRules(trait)
+	rel, res, err := r.updateAdapterRules(ctx, trait, rules, log)
+	if len(rules) > 0 || err != nil {
+		status.RecordOutcome(rel, res, err)
+	}
+	restartDelay, err := r.restartAdapterIfRequired(ctx, log)
+	if err != nil {
+		status.RecordOutcome(rel, controllerutil.OperationResultNone, err)
+	}
+
+	targets, err := r.fetchScaleTargets(ctx, trait, workload, log)
+	if err != nil {
+		rel := vzapi.QualifiedResourceRelation{APIVersion: workload.GetAPIVersion(), Kind: workload.GetKind(), Namespace: workload.GetNamespace(), Name: workload.GetName(), Role: autoscalerRole}
+		status.RecordOutcome(rel, controllerutil.OperationResultNone, err)
+		result, err := r.updateTraitStatus(ctx, trait, status, log)
+		return requeueWithin(result, restartDelay), err
+	}
+	var names []string
+	for _, target := range targets {
+		hpa := r.buildHorizontalPodAutoscaler(trait, target)
+		names = append(names, hpa.Name)
+		status.RecordOutcome(r.createOrUpdateHorizontalPodAutoscaler(ctx, trait, hpa))
+	}
+	if err := r.deleteHorizontalPodAutoscalers(ctx, trait, names); err != nil {
+		log.Errorf("Failed deleting the HorizontalPodAutoscalers no longer used by the trait %s: %v", trait.Name, err)
+		return reconcile.Result{}, err
+	}
+	result, err := r.updateTraitStatus(ctx, trait, status, log)
+	return requeueWithin(result, restartDelay), err
+}
+
+// reconcileTraitDelete reconciles an autoscaler trait that is being deleted.  The HorizontalPodAutoscalers and the
+// Prometheus Adapter rules of the trait are deleted.
+func (r *Reconciler) reconcileTraitDelete(ctx context.Context, trait *vzapi.AutoscalerTrait, log vzlog2.VerrazzanoLogger) (ctrl.Result, error) {
+	status := &reconcileresults.ReconcileResults{}
+	rel, res, err := r.updateAdapterRules(ctx, trait, nil, log)
+	if err != nil {
+		status.RecordOutcome(rel, res, err)
+	}
+	restartDelay, err := r.restartAdapterIfRequired(ctx, log)
+	if err != nil {
+		status.RecordOutcome(rel, controllerutil.OperationResultNone, err)
+	}
+	if err := r.deleteHorizontalPodAutoscalers(ctx, trait, nil); err != nil {
+		log.Errorf("Failed deleting the HorizontalPodAutoscalers of the trait %s: %v", trait.Name, err)
+		return reconcile.Result{}, err
+	}
+	// Keep the finalizer until the Prometheus Adapter is restarted without the rules of the trait
+	if restartDelay > 0 {
+		return reconcile.Result{Requeue: true, RequeueAfter: restartDelay}, nil
+	}
+	// Only remove the finalizer if the adapter rules were successfully removed.
+	if !status.ContainsErrors() {
+		if err := r.removeFinalizerIfRequired(ctx, trait, log); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return r.updateTraitStatus(ctx, trait, status, log)
+}
+
+// addFinalizerIfRequired adds the finalizer to the trait if required
+// The finalizer is only added if the trait is not being deleted and the finalizer has not previously been added
+func (r *Reconciler) addFinalizerIfRequired(ctx context.Context, trait *vzapi.AutoscalerTrait, log vzlog2.VerrazzanoLogger) error {
+	if trait.GetDeletionTimestamp().IsZero() && !vzstring.SliceContainsString(trait.Finalizers, finalizerName) {
+		traitName := vznav.GetNamespacedNameFromObjectMeta(trait.ObjectMeta)
+		log.Debugf("Adding finalizer from trait %s", traitName)
+		_, err := controllerutil.CreateOrUpdate(ctx, r.Client, trait, func() error {
+			trait.Finalizers = append(trait.Finalizers, finalizerName)
+			return nil
+		})
+		if err != nil {
+			return log.ErrorfNewErr("Failed to add finalizer to trait %s: %v", traitName, err)
+		}
+	}
+	return nil
+}
+
+// removeFinalizerIfRequired removes the finalizer from the trait if required
+// The finalizer is only removed if the trait is being deleted and the finalizer had been added
+func (r *Reconciler) removeFinalizerIfRequired(ctx context.Context, trait *vzapi.AutoscalerTrait, log vzlog2.VerrazzanoLogger) error {
+	if !trait.DeletionTimestamp.IsZero() && vzstring.SliceContainsString(trait.Finalizers, finalizerName) {
+		traitName := vznav.GetNamespacedNameFromObjectMeta(trait.ObjectMeta)
+		log.Debugf("Removing finalizer from trait %s", traitName)
+		_, err := controllerutil.CreateOrUpdate(ctx, r.Client, trait, func() error {
+			trait.Finalizers = vzstring.RemoveStringFromSlice(trait.Finalizers, finalizerName)
+			return nil
+		})
+		if err != nil {
+			log.Errorf("Failed to remove finalizer for trait %s: %v", traitName, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// fetchScaleTargets returns the resources of the workload scaled by the HorizontalPodAutoscalers of the trait.
+// Coherence clusters are scaled directly, WebLogic domains are scaled through the Cluster resource of the cluster of
+// the trait, and the other workloads through their Deployments and StatefulSets.
+func (r *Reconciler) fetchScaleTargets(ctx context.Context, trait *vzapi.AutoscalerTrait, workload *unstructured.Unstructured, log vzlog2.VerrazzanoLogger) ([]autoscalingv2.CrossVersionObjectReference, error) {
+	switch workload.GetKind() {
+	case coherenceKind:
+		return []autoscalingv2.CrossVersionObjectReference{toScaleTarget(workload)}, nil
+	case weblogicDomainKind:
+		cluster, err := r.fetchWebLogicCluster(ctx, trait, workload)
+		if err != nil {
+			return nil, err
+		}
+		return []autoscalingv2.CrossVersionObjectReference{toScaleTarget(cluster)}, nil
+	case deploymentKind, statefulSetKind:
+		return []autoscalingv2.CrossVersionObjectReference{toScaleTarget(workload)}, nil
+	}
+
+	children, err := vznav.FetchWorkloadChildren(ctx, r, log, workload)
+	if err != nil {
+		log.Errorw(fmt.Sprintf("Failed to retrieve the workloads child resources: %v", err), "workload", workload.UnstructuredContent())
+		return nil, err
+	}
+	var targets []autoscalingv2.CrossVersionObjectReference
+	for _, child := range children {
+		if child.GetKind() == deploymentKind || child.GetKind() == statefulSetKind {
+			targets = append(targets, toScaleTarget(child))
+		}
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("the %s %s has no Deployment or StatefulSet to scale", workload.GetKind(), workload.GetName())
+	}
+	return targets, nil
+}
+
+// fetchWebLogicCluster returns the Cluster resource of the WebLogic cluster scaled by the trait.  The clusters of
+// weblogic.oracle/v8 domains are part of the domain resource, which cannot be scaled by a HorizontalPodAutoscaler.
+func (r *Reconciler) fetchWebLogicCluster(ctx context.Context, trait *vzapi.AutoscalerTrait, domain *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	if domain.GetAPIVersion() == weblogicAPIVersionV8 {
+		return nil, fmt.Errorf("the Domain %s uses the %s API, autoscaling requires WebLogic Cluster resources", domain.GetName(), weblogicAPIVersionV8)
+	}
+	refs, _, err := unstructured.NestedSlice(domain.Object, "spec", "clusters")
+	if err != nil {
+		return nil, err
+	}
+	var found []*unstructured.Unstructured
+	for _, ref := range refs {
+		name, _, _ := unstructured.NestedString(ref.(map[string]interface{}), "name")
+		cluster := &unstructured.Unstructured{}
+		cluster.SetAPIVersion(weblogicAPIVersionV1)
+		cluster.SetKind(weblogicClusterKind)
+		if err := r.Get(ctx, types.NamespacedName{Namespace: domain.GetNamespace(), Name: name}, cluster); err != nil {
+			return nil, err
+		}
+		clusterName, _, _ := unstructured.NestedString(cluster.Object, "spec", "clusterName")
+		if len(trait.Spec.Cluster) == 0 || trait.Spec.Cluster == clusterName {
+			found = append(found, cluster)
+		}
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf("the Domain %s has no cluster %q", domain.GetName(), trait.Spec.Cluster)
+	}
+	if len(found) > 1 {
+		return nil, fmt.Errorf("the Domain %s has %d clusters, the cluster to scale must be specified", domain.GetName(), len(found))
+	}
+	return found[0], nil
+}
+
+// buildHorizontalPodAutoscaler builds the HorizontalPodAutoscaler of the trait scaling a resource of the workload
+func (r *Reconciler) buildHorizontalPodAutoscaler(trait *vzapi.AutoscalerTrait, target autoscalingv2.CrossVersionObjectReference) *autoscalingv2.HorizontalPodAutoscaler {
+	minReplicas := int32(defaultMinReplicas)
+	if trait.Spec.MinReplicas != nil {
+		minReplicas = *trait.Spec.MinReplicas
+	}
+	var metrics []autoscalingv2.MetricSpec
+	for _, m := range trait.Spec.Metrics {
+		metrics = append(metrics, buildMetricSpec(trait, m))
+	}
+	if len(metrics) == 0 {
+		metrics = append(metrics, buildMetricSpec(trait, vzapi.AutoscalerMetric{Type: vzapi.AutoscalerMetricCPU}))
+	}
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{APIVersion: hpaAPIVersion, Kind: hpaKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: trait.Namespace,
+			Name:      fmt.Sprintf("%s-%s", trait.Name, strings.ToLower(target.Name)),
+			Labels:    map[string]string{constants.LabelAutoscalerTrait: trait.Name},
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: target,
+			MinReplicas:    &minReplicas,
+			MaxReplicas:    trait.Spec.MaxReplicas,
+			Metrics:        metrics,
+		},
+	}
+}
+
+// createOrUpdateHorizontalPodAutoscaler creates or updates a HorizontalPodAutoscaler owned by the trait
+func (r *Reconciler) createOrUpdateHorizontalPodAutoscaler(ctx context.Context, trait *vzapi.AutoscalerTrait, desired *autoscalingv2.HorizontalPodAutoscaler) (vzapi.QualifiedResourceRelation, controllerutil.OperationResult, error) {
+	rel := vzapi.QualifiedResourceRelation{APIVersion: hpaAPIVersion, Kind: hpaKind, Namespace: desired.Namespace, Name: desired.Name, Role: autoscalerRole}
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Namespace: desired.Namespace, Name: desired.Name}}
+	res, err := controllerutil.CreateOrUpdate(ctx, r.Client, hpa, func() error {
+		if hpa.Labels == nil {
+			hpa.Labels = map[string]string{}
+		}
+		hpa.Labels[constants.LabelAutoscalerTrait] = trait.Name
+		hpa.Spec = desired.Spec
+		return controllerutil.SetControllerReference(trait, hpa, r.Scheme)
+	})
+	return rel, res, err
+}
+
+// deleteHorizontalPodAutoscalers deletes the HorizontalPodAutoscalers of the trait, except the ones that are kept
+func (r *Reconciler) deleteHorizontalPodAutoscalers(ctx context.Context, trait *vzapi.AutoscalerTrait, keep []string) error {
+	hpas := autoscalingv2.HorizontalPodAutoscalerList{}
+	if err := r.List(ctx, &hpas, client.InNamespace(trait.Namespace), client.MatchingLabels{constants.LabelAutoscalerTrait: trait.Name}); err != nil {
+		return err
+	}
+	for i := range hpas.Items {
+		if vzstring.SliceContainsString(keep, hpas.Items[i].Name) {
+			continue
+		}
+		if err := r.Delete(ctx, &hpas.Items[i]); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateTraitStatus updates the trait's status conditions and resources if they have changed.
+// The return value can be used as the result of the Reconcile method.
+func (r *Reconciler) updateTraitStatus(ctx context.Context, trait *vzapi.AutoscalerTrait, results *reconcileresults.ReconcileResults, log vzlog2.VerrazzanoLogger) (reconcile.Result, error) {
+	name := vznav.GetNamespacedNameFromObjectMeta(trait.ObjectMeta)
+
+	// If the status content has changed persist the updated status.
+	if trait.DeletionTimestamp.IsZero() && updateStatusIfRequired(&trait.Status, results) {
+		err := r.Status().Update(ctx, trait)
+		if err != nil {
+			return vzlog.IgnoreConflictWithLog(fmt.Sprintf("Failed to update autoscaler trait %s status", name.Name), err, zap.S())
+		}
+		log.Debugf("Updated autoscaler trait %s status", name.Name)
+	}
+
+	// If the results contained errors then requeue immediately.
+	if results.ContainsErrors() {
+		vzlog.ResultErrorsWithLog(fmt.Sprintf("Failed to reconcile autoscaler trait %s", name), results.Errors, zap.S())
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	// If the status has not change and there are no errors
+	// requeue with a jittered delay to account for situations where a workload
+	// changes but without necessarily updating the trait spec.
+	var seconds = rand.IntnRange(45, 90)
+	var duration = time.Duration(seconds) * time.Second
+	log.Debugf("Reconciled autoscaler trait %s successfully", name.Name)
+	return reconcile.Result{Requeue: true, RequeueAfter: duration}, nil
+}
+
+// requeueWithin returns the result of a reconciliation requeued no later than the given delay, when a delay is given
+func requeueWithin(result ctrl.Result, delay time.Duration) ctrl.Result {
+	if delay > 0 && (!clusters.ShouldRequeue(result) || result.RequeueAfter > delay) {
+		return reconcile.Result{Requeue: true, RequeueAfter: delay}
+	}
+	return result
+}
+
+// updateStatusIfRequired updates the traits status (i.e. resources and conditions) if they have changed.
+// Returns a boolean indicating if status resources or conditions have been updated.
+func updateStatusIfRequired(status *vzapi.AutoscalerTraitStatus, results *reconcileresults.ReconcileResults) bool {
+	updated := false
+	if !vzapi.QualifiedResourceRelationSlicesEquivalent(status.Resources, results.Relations) {
+		status.Resources = results.Relations
+		updated = true
+	}
+	conditionedStatus := results.CreateConditionedStatus()
+	if !reconcileresults.ConditionedStatusEquivalent(&status.ConditionedStatus, &conditionedStatus) {
+		status.ConditionedStatus = conditionedStatus
+		updated = true
+	}
+	return updated
+}
+
+// buildMetricSpec builds the HorizontalPodAutoscaler metric of a metric of the trait.  The CPU and memory metrics
+// are resource metrics of the pods, the custom metrics are external metrics served by the Prometheus Adapter.
+func buildMetricSpec(trait *vzapi.AutoscalerTrait, m vzapi.AutoscalerMetric) autoscalingv2.MetricSpec {
+	target := autoscalingv2.MetricTarget{}
+	if len(m.TargetAverageValue) > 0 {
+		value := resource.MustParse(m.TargetAverageValue)
+		target.Type = autoscalingv2.AverageValueMetricType
+		target.AverageValue = &value
+	} else {
+		utilization := int32(defaultTargetAverageUtilization)
+		if m.TargetAverageUtilization != nil {
+			utilization = *m.TargetAverageUtilization
+		}
+		target.Type = autoscalingv2.UtilizationMetricType
+		target.AverageUtilization = &utilization
+	}
+	switch m.Type {
+	case vzapi.AutoscalerMetricCustom:
+		return autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ExternalMetricSourceType,
+			External: &autoscalingv2.ExternalMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: buildExternalMetricName(trait, m.Name)},
+				Target: target,
+			},
+		}
+	case vzapi.AutoscalerMetricMemory:
+		return autoscalingv2.MetricSpec{
+			Type:     autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{Name: corev1.ResourceMemory, Target: target},
+		}
+	}
+	return autoscalingv2.MetricSpec{
+		Type:     autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{Name: corev1.ResourceCPU, Target: target},
+	}
+}
+
+// toScaleTarget returns the reference of a resource scaled by a HorizontalPodAutoscaler
+func toScaleTarget(u *unstructured.Unstructured) autoscalingv2.CrossVersionObjectReference {
+	return autoscalingv2.CrossVersionObjectReference{APIVersion: u.GetAPIVersion(), Kind: u.GetKind(), Name: u.GetName()}
+}
+
+// validateAutoscalerTrait validates the settings of an autoscaler trait that are not validated by the schema of the
+// trait
+func validateAutoscalerTrait(trait *vzapi.AutoscalerTrait) error {
+	var errMessages []string
+	if trait.Spec.MinReplicas != nil && *trait.Spec.MinReplicas > trait.Spec.MaxReplicas {
+		errMessages = append(errMessages, fmt.Sprintf("minReplicas %d is greater than maxReplicas %d", *trait.Spec.MinReplicas, trait.Spec.MaxReplicas))
+	}
+	names := map[string]bool{}
+	for i, m := range trait.Spec.Metrics {
+		field := fmt.Sprintf("metrics[%d]", i)
+		if len(m.TargetAverageValue) > 0 {
+			if _, err := resource.ParseQuantity(m.TargetAverageValue); err != nil {
+				errMessages = append(errMessages, fmt.Sprintf("%s.targetAverageValue %q is not a quantity", field, m.TargetAverageValue))
+			}
+			if m.TargetAverageUtilization != nil {
+				errMessages = append(errMessages, fmt.Sprintf("%s.targetAverageUtilization cannot be specified with targetAverageValue", field))
+			}
+		}
+		if m.Type != vzapi.AutoscalerMetricCustom {
+			if len(m.Name) > 0 || len(m.Query) > 0 {
+				errMessages = append(errMessages, fmt.Sprintf("%s.name and %s.query can only be specified with the %q type", field, field, vzapi.AutoscalerMetricCustom))
+			}
+			continue
+		}
+		if !metricNameRegex.MatchString(m.Name) {
+			errMessages = append(errMessages, fmt.Sprintf("%s.name %q must consist of lower case alphanumeric characters or '-'", field, m.Name))
+		} else if names[m.Name] {
+			errMessages = append(errMessages, fmt.Sprintf("%s.name %q is not unique", field, m.Name))
+		}
+		names[m.Name] = true
+		if len(m.Query) == 0 {
+			errMessages = append(errMessages, fmt.Sprintf("%s.query must be specified with the %q type", field, vzapi.AutoscalerMetricCustom))
+		} else if err := validateMetricQuery(m.Query); err != nil {
+			errMessages = append(errMessages, fmt.Sprintf("%s.query %q is invalid: %v", field, m.Query, err))
+		}
+		if len(m.TargetAverageValue) == 0 {
+			errMessages = append(errMessages, fmt.Sprintf("%s.targetAverageValue must be specified with the %q type", field, vzapi.AutoscalerMetricCustom))
+		}
+	}
+	if len(errMessages) > 0 {
+		return errors.New(strings.Join(errMessages, ", "))
+	}
+	return nil
+}
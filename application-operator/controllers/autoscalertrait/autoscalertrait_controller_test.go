@@ -0,0 +1,365 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package autoscalertrait
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	asserts "github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	k8sapps "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	k8score "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smeta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	namespaceName  = "test-namespace"
+	traitName      = "test-trait-name"
+	deploymentName = "test-deployment-name"
+	adapterConfig  = `rules:
+- seriesQuery: '{__name__=~"^container_.*"}'
+  metricsQuery: sum(rate(<<.Series>>{<<.LabelMatchers>>}[5m])) by (<<.GroupBy>>)
+`
+)
+
+// adapterConfigMapKey is the key of the ConfigMap of the configuration read by the Prometheus Adapter
+var adapterConfigMapKey = types.NamespacedName{Namespace: vzconst.VerrazzanoMonitoringNamespace, Name: vzconst.PrometheusAdapterConfigMapName}
+
+// TestReconcileAutoscalerTrait tests the reconciliation of an autoscaler trait
+// GIVEN an autoscaler trait of a Deployment with CPU and custom metrics
+// WHEN the autoscaler trait is reconciled
+// THEN a HorizontalPodAutoscaler scales the Deployment, and the Prometheus Adapter serves the custom metric
+func TestReconcileAutoscalerTrait(t *testing.T) {
+	assert := asserts.New(t)
+
+	trait := newTrait()
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(newDeployment(), newAdapterConfigMap(), newAdapterDeployment(), trait).Build()
+	reconciler := Reconciler{Client: cli, Scheme: cli.Scheme()}
+
+	res, err := reconciler.doReconcile(context.TODO(), trait, vzlog.DefaultLogger())
+	assert.NoError(err)
+	assert.True(res.Requeue)
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-" + deploymentName}, hpa))
+	assert.Equal(autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: deploymentName}, hpa.Spec.ScaleTargetRef)
+	assert.Equal(int32(2), *hpa.Spec.MinReplicas)
+	assert.Equal(int32(5), hpa.Spec.MaxReplicas)
+	assert.Len(hpa.Spec.Metrics, 2)
+	assert.Equal(k8score.ResourceCPU, hpa.Spec.Metrics[0].Resource.Name)
+	assert.Equal(int32(60), *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+	assert.Equal("verrazzano_test-namespace_test-trait-name_requests", hpa.Spec.Metrics[1].External.Metric.Name)
+	assert.Equal("10", hpa.Spec.Metrics[1].External.Target.AverageValue.String())
+	assert.Equal(traitName, hpa.OwnerReferences[0].Name)
+
+	cm := &k8score.ConfigMap{}
+	assert.NoError(cli.Get(context.TODO(), adapterConfigMapKey, cm))
+	assert.Contains(cm.Data, "test-namespace.test-trait-name")
+	assert.Contains(cm.Data[adapterConfigKey], "container_")
+	assert.Contains(cm.Data[adapterConfigKey], "as: verrazzano_test-namespace_test-trait-name_requests")
+	assert.Contains(cm.Data[adapterConfigKey], `metricsQuery: sum(rate(http_requests_total{<<.LabelMatchers>>}[2m]))`)
+	assert.Contains(cm.Data[adapterConfigKey], "namespaced: true")
+	adapter := &k8sapps.Deployment{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: vzconst.VerrazzanoMonitoringNamespace, Name: adapterName}, adapter))
+	restartedAt := adapter.Spec.Template.Annotations[vzconst.VerrazzanoRestartAnnotation]
+	assert.NotEmpty(restartedAt)
+
+	updated := &vzapi.AutoscalerTrait{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName}, updated))
+	assert.Contains(updated.Finalizers, finalizerName)
+	assert.Len(updated.Status.Resources, 2)
+
+	// The Prometheus Adapter is not restarted when the rules of the trait are unchanged
+	_, err = reconciler.doReconcile(context.TODO(), updated, vzlog.DefaultLogger())
+	assert.NoError(err)
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: vzconst.VerrazzanoMonitoringNamespace, Name: adapterName}, adapter))
+	assert.Equal(restartedAt, adapter.Spec.Template.Annotations[vzconst.VerrazzanoRestartAnnotation])
+
+	// The Prometheus Adapter is restarted at most once per restart interval when the rules of the trait change
+	updated.Spec.Metrics[1].Query = `sum(rate(http_requests_total{<<.LabelMatchers>>}[5m]))`
+	res, err = reconciler.doReconcile(context.TODO(), updated, vzlog.DefaultLogger())
+	assert.NoError(err)
+	assert.True(res.Requeue)
+	assert.LessOrEqual(res.RequeueAfter, adapterRestartInterval)
+	assert.NoError(cli.Get(context.TODO(), adapterConfigMapKey, cm))
+	assert.Contains(cm.Data[adapterConfigKey], "[5m]")
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: vzconst.VerrazzanoMonitoringNamespace, Name: adapterName}, adapter))
+	assert.Equal(restartedAt, adapter.Spec.Template.Annotations[vzconst.VerrazzanoRestartAnnotation])
+
+	adapter.Spec.Template.Annotations[vzconst.VerrazzanoRestartAnnotation] = time.Now().Add(-adapterRestartInterval).Format(time.RFC3339)
+	assert.NoError(cli.Update(context.TODO(), adapter))
+	_, err = reconciler.doReconcile(context.TODO(), updated, vzlog.DefaultLogger())
+	assert.NoError(err)
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: vzconst.VerrazzanoMonitoringNamespace, Name: adapterName}, adapter))
+	restartedAt = adapter.Spec.Template.Annotations[vzconst.VerrazzanoRestartAnnotation]
+	restartTime, err := time.Parse(time.RFC3339, restartedAt)
+	assert.NoError(err)
+	assert.WithinDuration(time.Now(), restartTime, adapterRestartInterval/2)
+
+	// The rules of the trait are removed and the HorizontalPodAutoscaler is deleted when the trait is deleted, and the
+	// finalizer of the trait is kept until the Prometheus Adapter is restarted without the rules of the trait
+	assert.NoError(cli.Delete(context.TODO(), updated))
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName}, updated))
+	res, err = reconciler.doReconcile(context.TODO(), updated, vzlog.DefaultLogger())
+	assert.NoError(err)
+	assert.True(res.Requeue)
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName}, updated))
+
+	adapter.Spec.Template.Annotations[vzconst.VerrazzanoRestartAnnotation] = time.Now().Add(-adapterRestartInterval).Format(time.RFC3339)
+	assert.NoError(cli.Update(context.TODO(), adapter))
+	_, err = reconciler.doReconcile(context.TODO(), updated, vzlog.DefaultLogger())
+	assert.NoError(err)
+	assert.NoError(cli.Get(context.TODO(), adapterConfigMapKey, cm))
+	assert.Contains(cm.Data[adapterConfigKey], "container_")
+	assert.NotContains(cm.Data, "test-namespace.test-trait-name")
+	assert.False(strings.Contains(cm.Data[adapterConfigKey], "externalRules"))
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-" + deploymentName}, hpa)
+	assert.True(errors.IsNotFound(err))
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName}, updated)
+	assert.True(errors.IsNotFound(err))
+}
+
+// TestReconcileAutoscalerTraitWithoutAdapter tests the reconciliation of an autoscaler trait when the Prometheus
+// Adapter is not installed
+// GIVEN an autoscaler trait with a custom metric and no Prometheus Adapter
+// WHEN the autoscaler trait is reconciled
+// THEN the status of the trait reports the error, and the HorizontalPodAutoscaler is created
+func TestReconcileAutoscalerTraitWithoutAdapter(t *testing.T) {
+	assert := asserts.New(t)
+
+	trait := newTrait()
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(newDeployment(), trait).Build()
+	reconciler := Reconciler{Client: cli, Scheme: cli.Scheme()}
+
+	res, err := reconciler.doReconcile(context.TODO(), trait, vzlog.DefaultLogger())
+	assert.NoError(err)
+	assert.True(res.Requeue)
+	assert.Zero(res.RequeueAfter)
+
+	updated := &vzapi.AutoscalerTrait{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName}, updated))
+	assert.Contains(updated.Status.Conditions[0].Message, "the Prometheus Adapter is not installed")
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-" + deploymentName}, &autoscalingv2.HorizontalPodAutoscaler{}))
+}
+
+// TestReconcileAdapterConfig tests the reconciliation of the configuration read by the Prometheus Adapter
+// GIVEN the rules of an autoscaler trait and an upgrade of the Helm chart of the Prometheus Adapter
+// WHEN the configuration of the Prometheus Adapter is reconciled
+// THEN the configuration is rebuilt from the configuration of the chart and the rules of the trait, and the Prometheus
+// Adapter is restarted
+func TestReconcileAdapterConfig(t *testing.T) {
+	assert := asserts.New(t)
+
+	trait := newTrait()
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(newDeployment(), newAdapterConfigMap(), newAdapterDeployment(), trait).Build()
+	reconciler := Reconciler{Client: cli, Scheme: cli.Scheme()}
+	_, err := reconciler.doReconcile(context.TODO(), trait, vzlog.DefaultLogger())
+	assert.NoError(err)
+
+	// The upgrade of the chart resets the configuration of the chart
+	chartCM := &k8score.ConfigMap{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: vzconst.VerrazzanoMonitoringNamespace, Name: adapterName}, chartCM))
+	chartCM.Data[adapterConfigKey] = strings.ReplaceAll(adapterConfig, "[5m]", "[1m]")
+	assert.NoError(cli.Update(context.TODO(), chartCM))
+	adapter := &k8sapps.Deployment{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: vzconst.VerrazzanoMonitoringNamespace, Name: adapterName}, adapter))
+	adapter.Spec.Template.Annotations[vzconst.VerrazzanoRestartAnnotation] = time.Now().Add(-adapterRestartInterval).Format(time.RFC3339)
+	assert.NoError(cli.Update(context.TODO(), adapter))
+
+	assert.Equal([]reconcile.Request{adapterConfigRequest}, createAdapterConfigReconcileRequests(chartCM))
+	res, err := reconciler.Reconcile(context.TODO(), adapterConfigRequest)
+	assert.NoError(err)
+	assert.False(res.Requeue)
+
+	cm := &k8score.ConfigMap{}
+	assert.NoError(cli.Get(context.TODO(), adapterConfigMapKey, cm))
+	assert.Contains(cm.Data[adapterConfigKey], "[1m]")
+	assert.Contains(cm.Data[adapterConfigKey], "as: verrazzano_test-namespace_test-trait-name_requests")
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: vzconst.VerrazzanoMonitoringNamespace, Name: adapterName}, adapter))
+	restartTime, err := time.Parse(time.RFC3339, adapter.Spec.Template.Annotations[vzconst.VerrazzanoRestartAnnotation])
+	assert.NoError(err)
+	assert.WithinDuration(time.Now(), restartTime, adapterRestartInterval/2)
+
+	// Other ConfigMaps are ignored
+	assert.Empty(createAdapterConfigReconcileRequests(&k8score.ConfigMap{ObjectMeta: k8smeta.ObjectMeta{Namespace: namespaceName, Name: adapterName}}))
+}
+
+// TestFetchScaleTargets tests the resources scaled for the Coherence and WebLogic workloads
+// GIVEN a Coherence cluster, a weblogic.oracle/v9 domain with two clusters, and a weblogic.oracle/v8 domain
+// WHEN the scale targets are fetched
+// THEN the Coherence cluster and the WebLogic Cluster resource of the trait are scaled, and v8 domains are rejected
+func TestFetchScaleTargets(t *testing.T) {
+	assert := asserts.New(t)
+
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(newCluster("hello-cluster-1", "cluster-1"), newCluster("hello-cluster-2", "cluster-2")).Build()
+	reconciler := Reconciler{Client: cli, Scheme: cli.Scheme()}
+	trait := newTrait()
+
+	coherence := &unstructured.Unstructured{}
+	coherence.SetAPIVersion("coherence.oracle.com/v1")
+	coherence.SetKind("Coherence")
+	coherence.SetName("hello-coherence")
+	targets, err := reconciler.fetchScaleTargets(context.TODO(), trait, coherence, vzlog.DefaultLogger())
+	assert.NoError(err)
+	assert.Equal([]autoscalingv2.CrossVersionObjectReference{{APIVersion: "coherence.oracle.com/v1", Kind: "Coherence", Name: "hello-coherence"}}, targets)
+
+	domain := &unstructured.Unstructured{}
+	domain.SetAPIVersion("weblogic.oracle/v9")
+	domain.SetKind("Domain")
+	domain.SetNamespace(namespaceName)
+	domain.SetName("hello-domain")
+	assert.NoError(unstructured.SetNestedSlice(domain.Object, []interface{}{
+		map[string]interface{}{"name": "hello-cluster-1"},
+		map[string]interface{}{"name": "hello-cluster-2"},
+	}, "spec", "clusters"))
+	_, err = reconciler.fetchScaleTargets(context.TODO(), trait, domain, vzlog.DefaultLogger())
+	assert.ErrorContains(err, "the Domain hello-domain has 2 clusters")
+	trait.Spec.Cluster = "cluster-2"
+	targets, err = reconciler.fetchScaleTargets(context.TODO(), trait, domain, vzlog.DefaultLogger())
+	assert.NoError(err)
+	assert.Equal([]autoscalingv2.CrossVersionObjectReference{{APIVersion: "weblogic.oracle/v1", Kind: "Cluster", Name: "hello-cluster-2"}}, targets)
+
+	domain.SetAPIVersion("weblogic.oracle/v8")
+	_, err = reconciler.fetchScaleTargets(context.TODO(), trait, domain, vzlog.DefaultLogger())
+	assert.ErrorContains(err, "uses the weblogic.oracle/v8 API")
+}
+
+// TestReconcileKubeSystem tests to make sure we do not reconcile
+// Any resource that belong to the kube-system namespace
+func TestReconcileKubeSystem(t *testing.T) {
+	assert := asserts.New(t)
+
+	reconciler := Reconciler{Client: fake.NewClientBuilder().WithScheme(newScheme()).Build()}
+	result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "kube-system", Name: traitName}})
+	assert.NoError(err)
+	assert.True(result.IsZero())
+}
+
+// TestValidateAutoscalerTrait tests the validation of an autoscaler trait
+// GIVEN autoscaler traits with invalid replicas and metrics
+// WHEN the autoscaler traits are validated
+// THEN an error names each invalid setting
+func TestValidateAutoscalerTrait(t *testing.T) {
+	assert := asserts.New(t)
+
+	assert.NoError(validateAutoscalerTrait(newTrait()))
+
+	trait := newTrait()
+	trait.Spec.MinReplicas = int32Ptr(6)
+	trait.Spec.Metrics = []vzapi.AutoscalerMetric{
+		{Type: vzapi.AutoscalerMetricMemory, TargetAverageValue: "lots", TargetAverageUtilization: int32Ptr(50)},
+		{Type: vzapi.AutoscalerMetricCPU, Query: "up"},
+		{Type: vzapi.AutoscalerMetricCustom, Name: "Requests"},
+		{Type: vzapi.AutoscalerMetricCustom, Name: "latency", Query: "up{<<.LabelMatchers>>}", TargetAverageValue: "1"},
+		{Type: vzapi.AutoscalerMetricCustom, Name: "latency", Query: "up", TargetAverageValue: "1"},
+	}
+	err := validateAutoscalerTrait(trait)
+	assert.ErrorContains(err, "minReplicas 6 is greater than maxReplicas 5")
+	assert.ErrorContains(err, `metrics[0].targetAverageValue "lots" is not a quantity`)
+	assert.ErrorContains(err, "metrics[0].targetAverageUtilization cannot be specified with targetAverageValue")
+	assert.ErrorContains(err, `metrics[1].name and metrics[1].query can only be specified with the "custom" type`)
+	assert.ErrorContains(err, `metrics[2].name "Requests" must consist of lower case alphanumeric characters or '-'`)
+	assert.ErrorContains(err, `metrics[2].query must be specified with the "custom" type`)
+	assert.ErrorContains(err, `metrics[2].targetAverageValue must be specified with the "custom" type`)
+	assert.ErrorContains(err, `metrics[4].name "latency" is not unique`)
+	assert.ErrorContains(err, `metrics[4].query "up" is invalid: selector up must include <<.LabelMatchers>>`)
+}
+
+// TestValidateMetricQuery tests the validation of the Prometheus queries of custom metrics
+// GIVEN Prometheus queries with and without the label matchers of the Prometheus Adapter
+// WHEN the queries are validated
+// THEN only the queries whose selectors all include the label matchers, and do not match the namespace label, are valid
+func TestValidateMetricQuery(t *testing.T) {
+	assert := asserts.New(t)
+
+	assert.NoError(validateMetricQuery(`sum(rate(http_requests_total{<<.LabelMatchers>>}[2m]))`))
+	assert.NoError(validateMetricQuery(`sum by (pod) (rate(http_requests_total{<<.LabelMatchers>>,code=~"5.."}[5m] offset 1m)) / on(pod) group_left sum(up{job="a}b",<<.LabelMatchers>>}) > bool 0.5`))
+	assert.NoError(validateMetricQuery(`max_over_time({__name__="queue_size",<<.LabelMatchers>>}[10m:1m]) * 2e3`))
+
+	assert.EqualError(validateMetricQuery(`sum(rate(http_requests_total{namespace="other"}[2m]))`),
+		`selector http_requests_total{namespace="other"} must include <<.LabelMatchers>>`)
+	assert.EqualError(validateMetricQuery(`sum(rate(http_requests_total{<<.LabelMatchers>>, namespace!="hello"}[2m]))`),
+		`selector http_requests_total{<<.LabelMatchers>>,namespace!="hello"} cannot match the namespace label`)
+	assert.EqualError(validateMetricQuery(`sum(rate(http_requests_total[2m]))`),
+		"selector http_requests_total must include <<.LabelMatchers>>")
+	assert.EqualError(validateMetricQuery(`sum(up{<<.LabelMatchers>>}) or vector(0) + up`),
+		"selector up must include <<.LabelMatchers>>")
+	assert.EqualError(validateMetricQuery(`up{__verrazzano_label_matchers__="true"}`),
+		"the label __verrazzano_label_matchers__ is reserved")
+	assert.Error(validateMetricQuery(`up{<<.LabelMatchers>>`))
+}
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = vzapi.AddToScheme(scheme)
+	_ = k8sapps.AddToScheme(scheme)
+	_ = k8score.AddToScheme(scheme)
+	_ = autoscalingv2.AddToScheme(scheme)
+	return scheme
+}
+
+func newDeployment() client.Object {
+	return &k8sapps.Deployment{
+		TypeMeta:   k8smeta.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: k8smeta.ObjectMeta{Namespace: namespaceName, Name: deploymentName},
+	}
+}
+
+func newAdapterConfigMap() client.Object {
+	return &k8score.ConfigMap{
+		ObjectMeta: k8smeta.ObjectMeta{Namespace: vzconst.VerrazzanoMonitoringNamespace, Name: adapterName},
+		Data:       map[string]string{adapterConfigKey: adapterConfig},
+	}
+}
+
+func newAdapterDeployment() client.Object {
+	return &k8sapps.Deployment{
+		ObjectMeta: k8smeta.ObjectMeta{Namespace: vzconst.VerrazzanoMonitoringNamespace, Name: adapterName},
+	}
+}
+
+func newCluster(name string, clusterName string) client.Object {
+	cluster := &unstructured.Unstructured{}
+	cluster.SetAPIVersion("weblogic.oracle/v1")
+	cluster.SetKind("Cluster")
+	cluster.SetNamespace(namespaceName)
+	cluster.SetName(name)
+	_ = unstructured.SetNestedField(cluster.Object, clusterName, "spec", "clusterName")
+	return cluster
+}
+
+func newTrait() *vzapi.AutoscalerTrait {
+	return &vzapi.AutoscalerTrait{
+		TypeMeta:   k8smeta.TypeMeta{APIVersion: "oam.verrazzano.io/v1alpha1", Kind: vzapi.AutoscalerTraitKind},
+		ObjectMeta: k8smeta.ObjectMeta{Namespace: namespaceName, Name: traitName, UID: "test-trait-uid"},
+		Spec: vzapi.AutoscalerTraitSpec{
+			MinReplicas: int32Ptr(2),
+			MaxReplicas: 5,
+			Metrics: []vzapi.AutoscalerMetric{
+				{Type: vzapi.AutoscalerMetricCPU, TargetAverageUtilization: int32Ptr(60)},
+				{Type: vzapi.AutoscalerMetricCustom, Name: "requests", Query: `sum(rate(http_requests_total{<<.LabelMatchers>>}[2m]))`, TargetAverageValue: "10"},
+			},
+			WorkloadReference: oamrt.TypedReference{APIVersion: "apps/v1", Kind: "Deployment", Name: deploymentName},
+		},
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
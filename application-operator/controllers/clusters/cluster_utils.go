@@ -6,6 +6,7 @@ package clusters
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	vzctrl "github.com/verrazzano/verrazzano/pkg/controller"
@@ -15,6 +16,7 @@ import (
 	"github.com/verrazzano/verrazzano/application-operator/constants"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -186,11 +188,81 @@ func SetClusterLevelStatus(status *clustersv1alpha1.MultiClusterResourceStatus,
 	if foundClusterIdx == -1 {
 		status.Clusters = append(status.Clusters, newClusterStatus)
 	} else {
+		// The drift of the resource is reported separately by the agent of the cluster, so it is kept
+		if newClusterStatus.Drift == nil {
+			newClusterStatus.Drift = status.Clusters[foundClusterIdx].Drift
+		}
 		status.Clusters[foundClusterIdx] = newClusterStatus
 		status.Clusters[foundClusterIdx].LastUpdateTime = time.Now().Format(time.RFC3339)
 	}
 }
 
+// SetClusterDriftStatus - given a multi cluster resource status object, replace the drift of the resource
+// in the given cluster with the new drift, keeping the rest of the cluster level status.  The drift is only
+// set once the cluster has reported its status.  Returns true if the status changed.
+func SetClusterDriftStatus(status *clustersv1alpha1.MultiClusterResourceStatus, clusterName string, drift *clustersv1alpha1.DriftStatus) bool {
+	for i := range status.Clusters {
+		if status.Clusters[i].Name != clusterName {
+			continue
+		}
+		oldDrift := status.Clusters[i].Drift
+		if oldDrift == nil && drift == nil {
+			return false
+		}
+		// The detected time of a drift that is still the same is kept
+		if oldDrift != nil && drift != nil && oldDrift.Action == drift.Action && equality.Semantic.DeepEqual(oldDrift.Keys, drift.Keys) {
+			return false
+		}
+		status.Clusters[i].Drift = drift
+		return true
+	}
+	return false
+}
+
+// KeepDrift returns true if the changes made in this cluster to the given resource, created from a multi cluster
+// resource with the given drift policy and generation, must be kept.  This is the case when the drift policy does
+// not enforce the template, and the multi cluster resource has not changed since it was applied to the resource.
+func KeepDrift(policy clustersv1alpha1.DriftPolicy, obj client.Object, generation int64) bool {
+	if policy == "" || policy == clustersv1alpha1.DriftPolicyEnforce || len(obj.GetResourceVersion()) == 0 {
+		return false
+	}
+	return IsMultiClusterGenerationApplied(obj, generation)
+}
+
+// IsMultiClusterGenerationApplied returns true if the given generation of the multi cluster resource has been
+// applied to the resource created from it
+func IsMultiClusterGenerationApplied(obj client.Object, generation int64) bool {
+	return obj.GetAnnotations()[constants.MultiClusterGenerationAnnotation] == strconv.FormatInt(generation, 10)
+}
+
+// SetMultiClusterGeneration records the generation of the multi cluster resource applied to the resource
+// created from it
+func SetMultiClusterGeneration(obj client.Object, generation int64) {
+	annotations := map[string]string{}
+	for k, v := range obj.GetAnnotations() {
+		annotations[k] = v
+	}
+	annotations[constants.MultiClusterGenerationAnnotation] = strconv.FormatInt(generation, 10)
+	obj.SetAnnotations(annotations)
+}
+
+// SetClusterQuotaStatus - given a VerrazzanoProject status object, replace the quota usage of the
+// given cluster with the new quota usage.  Returns true if the status changed.
+func SetClusterQuotaStatus(status *clustersv1alpha1.VerrazzanoProjectStatus, clusterName string, quotas []clustersv1alpha1.ProjectQuotaStatus) bool {
+	var newQuotas []clustersv1alpha1.ProjectQuotaStatus
+	for _, quota := range status.Quotas {
+		if quota.Cluster != clusterName {
+			newQuotas = append(newQuotas, quota)
+		}
+	}
+	newQuotas = append(newQuotas, quotas...)
+	if equality.Semantic.DeepEqual(status.Quotas, newQuotas) {
+		return false
+	}
+	status.Quotas = newQuotas
+	return true
+}
+
 // NewScheme creates a new scheme that includes this package's object to use for testing
 func NewScheme() *runtime.Scheme {
 	scheme := runtime.NewScheme()
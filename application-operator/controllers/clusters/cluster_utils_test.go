@@ -19,6 +19,7 @@ import (
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
 	kerr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	controllerruntime "sigs.k8s.io/controller-runtime"
@@ -387,6 +388,87 @@ func TestSetEffectiveStateIfChanged(t *testing.T) {
 	asserts.Equal(t, clustersv1alpha1.Failed, secret.Status.State)
 }
 
+// TestSetClusterQuotaStatus tests setting the quota usage of a cluster in the status of a VerrazzanoProject
+// GIVEN a VerrazzanoProject status with the quota usage of two clusters
+// WHEN SetClusterQuotaStatus is called with the same quota usage for a cluster
+// THEN the status should not be updated
+// WHEN SetClusterQuotaStatus is called with a new quota usage for a cluster
+// THEN the quota usage of the cluster should be replaced, and the quota usage of the other cluster kept
+func TestSetClusterQuotaStatus(t *testing.T) {
+	quota1 := clustersv1alpha1.ProjectQuotaStatus{
+		Cluster: "cluster1",
+		Hard:    v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")},
+		Used:    v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+	}
+	quota2 := clustersv1alpha1.ProjectQuotaStatus{
+		Cluster: "cluster2",
+		Hard:    v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")},
+	}
+	status := clustersv1alpha1.VerrazzanoProjectStatus{Quotas: []clustersv1alpha1.ProjectQuotaStatus{quota1, quota2}}
+
+	asserts.False(t, SetClusterQuotaStatus(&status, "cluster2", []clustersv1alpha1.ProjectQuotaStatus{quota2}))
+	asserts.Len(t, status.Quotas, 2)
+
+	newQuota2 := quota2
+	newQuota2.Used = v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m")}
+	asserts.True(t, SetClusterQuotaStatus(&status, "cluster2", []clustersv1alpha1.ProjectQuotaStatus{newQuota2}))
+	asserts.Equal(t, []clustersv1alpha1.ProjectQuotaStatus{quota1, newQuota2}, status.Quotas)
+
+	asserts.True(t, SetClusterQuotaStatus(&status, "cluster1", nil))
+	asserts.Equal(t, []clustersv1alpha1.ProjectQuotaStatus{newQuota2}, status.Quotas)
+}
+
+// TestSetClusterDriftStatus tests setting the drift of a resource in a cluster in a multi cluster resource status
+// GIVEN a multi cluster resource status with the status of a cluster
+// WHEN SetClusterDriftStatus is called with a new drift for the cluster
+// THEN the drift should be set, and kept when the status of the cluster is updated
+// WHEN SetClusterDriftStatus is called with the same drift, or for a cluster without status
+// THEN the status should not be updated
+func TestSetClusterDriftStatus(t *testing.T) {
+	status := clustersv1alpha1.MultiClusterResourceStatus{
+		Clusters: []clustersv1alpha1.ClusterLevelStatus{{Name: "cluster1", State: clustersv1alpha1.Succeeded}},
+	}
+	drift := &clustersv1alpha1.DriftStatus{Action: clustersv1alpha1.DriftReported, DetectedTime: "2023-01-01T00:00:00Z", Keys: []string{"key1"}}
+
+	asserts.False(t, SetClusterDriftStatus(&status, "cluster1", nil))
+	asserts.False(t, SetClusterDriftStatus(&status, "cluster2", drift))
+	asserts.True(t, SetClusterDriftStatus(&status, "cluster1", drift))
+	asserts.Equal(t, drift, status.Clusters[0].Drift)
+
+	sameDrift := *drift
+	sameDrift.DetectedTime = "2023-01-02T00:00:00Z"
+	asserts.False(t, SetClusterDriftStatus(&status, "cluster1", &sameDrift))
+	asserts.Equal(t, drift, status.Clusters[0].Drift)
+
+	SetClusterLevelStatus(&status, clustersv1alpha1.ClusterLevelStatus{Name: "cluster1", State: clustersv1alpha1.Failed})
+	asserts.Equal(t, clustersv1alpha1.Failed, status.Clusters[0].State)
+	asserts.Equal(t, drift, status.Clusters[0].Drift)
+
+	asserts.True(t, SetClusterDriftStatus(&status, "cluster1", nil))
+	asserts.Nil(t, status.Clusters[0].Drift)
+}
+
+// TestKeepDrift tests whether the changes made in a cluster to a resource created from a multi cluster resource are kept
+// GIVEN a resource to which a generation of the multi cluster resource has been applied
+// WHEN KeepDrift is called for each drift policy and generation
+// THEN the changes should only be kept for a drift policy not enforcing the template, and the applied generation
+func TestKeepDrift(t *testing.T) {
+	secret := &v1.Secret{}
+	SetMultiClusterGeneration(secret, 2)
+	asserts.Equal(t, "2", secret.Annotations[constants.MultiClusterGenerationAnnotation])
+	asserts.True(t, IsMultiClusterGenerationApplied(secret, 2))
+
+	// A resource that does not exist yet is always created from the template
+	asserts.False(t, KeepDrift(clustersv1alpha1.DriftPolicyReportOnly, secret, 2))
+
+	secret.ResourceVersion = "1"
+	asserts.False(t, KeepDrift("", secret, 2))
+	asserts.False(t, KeepDrift(clustersv1alpha1.DriftPolicyEnforce, secret, 2))
+	asserts.True(t, KeepDrift(clustersv1alpha1.DriftPolicyReportOnly, secret, 2))
+	asserts.True(t, KeepDrift(clustersv1alpha1.DriftPolicyAdopt, secret, 2))
+	asserts.False(t, KeepDrift(clustersv1alpha1.DriftPolicyAdopt, secret, 3))
+}
+
 // TestDeleteAssociatedResource tests that if DeleteAssociatedResource is called
 // the given resourceToDelete is deleted and the finalizer on the mcResource is removed
 // GIVEN a MultiCluster resource and a resourceToDelete,
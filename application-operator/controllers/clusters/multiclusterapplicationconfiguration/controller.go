@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package multiclusterapplicationconfiguration
@@ -95,8 +95,13 @@ func (r *Reconciler) doReconcile(ctx context.Context, mcAppConfig clustersv1alph
 		return reconcile.Result{}, err
 	}
 
-	oldState := clusters.SetEffectiveStateIfChanged(mcAppConfig.Spec.Placement, &mcAppConfig.Status)
-	if !clusters.IsPlacedInThisCluster(ctx, r, mcAppConfig.Spec.Placement) {
+	placement, err := clusters.ResolvePlacement(ctx, r.Client, mcAppConfig.Spec.Placement)
+	if err != nil {
+		log.Errorf("Failed to resolve the placement: %v", err)
+		return ctrl.Result{}, err
+	}
+	oldState := clusters.SetEffectiveStateIfChanged(placement, &mcAppConfig.Status)
+	if !clusters.IsPlacedInThisCluster(ctx, r, placement) {
 		if oldState != mcAppConfig.Status.State {
 			// This must be done whether the resource is placed in this cluster or not, because we
 			// could be in an admin cluster and receive cluster level statuses from managed clusters,
@@ -108,12 +113,12 @@ func (r *Reconciler) doReconcile(ctx context.Context, mcAppConfig clustersv1alph
 		}
 		// if this mc app config is no longer placed on this cluster, remove the associated app config
 		err := clusters.DeleteAssociatedResource(ctx, r.Client, &mcAppConfig, finalizerName, &v1alpha2.ApplicationConfiguration{}, types.NamespacedName{Namespace: mcAppConfig.Namespace, Name: mcAppConfig.Name})
-		return ctrl.Result{}, err
+		return clusters.NewPlacementResult(ctrl.Result{}, mcAppConfig.Spec.Placement), err
 	}
 
 	log.Debug("MultiClusterApplicationConfiguration create or update with underlying OAM applicationconfiguration",
 		"applicationconfiguration", mcAppConfig.Spec.Template.Metadata.Name,
-		"placement", mcAppConfig.Spec.Placement.Clusters[0].Name)
+		"placement", placement.Clusters[0].Name)
 	opResult, err := r.createOrUpdateAppConfig(ctx, mcAppConfig)
 
 	// Add our finalizer if not already added
@@ -121,7 +126,7 @@ func (r *Reconciler) doReconcile(ctx context.Context, mcAppConfig clustersv1alph
 		_, err = clusters.AddFinalizer(ctx, r.Client, &mcAppConfig, finalizerName)
 	}
 
-	ctrlResult, updateErr := r.updateStatus(ctx, &mcAppConfig, opResult, err)
+	ctrlResult, updateErr := r.updateStatus(ctx, &mcAppConfig, placement, opResult, err)
 
 	// if an error occurred in createOrUpdate, return that error with a requeue
 	// even if update status succeeded
@@ -130,7 +135,7 @@ func (r *Reconciler) doReconcile(ctx context.Context, mcAppConfig clustersv1alph
 		return res, err
 	}
 
-	return ctrlResult, updateErr
+	return clusters.NewPlacementResult(ctrlResult, mcAppConfig.Spec.Placement), updateErr
 }
 
 // SetupWithManager registers our controller with the manager
@@ -169,10 +174,10 @@ func (r *Reconciler) mutateAppConfig(mcAppConfig clustersv1alpha1.MultiClusterAp
 	oamAppConfig.Annotations = mcAppConfig.Spec.Template.Metadata.Annotations
 }
 
-func (r *Reconciler) updateStatus(ctx context.Context, mcAppConfig *clustersv1alpha1.MultiClusterApplicationConfiguration, opResult controllerutil.OperationResult, err error) (ctrl.Result, error) {
+func (r *Reconciler) updateStatus(ctx context.Context, mcAppConfig *clustersv1alpha1.MultiClusterApplicationConfiguration, placement clustersv1alpha1.Placement, opResult controllerutil.OperationResult, err error) (ctrl.Result, error) {
 	clusterName := clusters.GetClusterName(ctx, r.Client)
 	newCondition := clusters.GetConditionFromResult(err, opResult, "OAM Application Configuration")
 	updateFunc := func() error { return r.Status().Update(ctx, mcAppConfig) }
-	return clusters.UpdateStatus(mcAppConfig, &mcAppConfig.Status, mcAppConfig.Spec.Placement, newCondition, clusterName,
+	return clusters.UpdateStatus(mcAppConfig, &mcAppConfig.Status, placement, newCondition, clusterName,
 		r.AgentChannel, updateFunc)
 }
@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package multiclustercomponent
@@ -92,9 +92,14 @@ func (r *Reconciler) doReconcile(ctx context.Context, mcComp clustersv1alpha1.Mu
 		return ctrl.Result{}, err
 	}
 
-	oldState := clusters.SetEffectiveStateIfChanged(mcComp.Spec.Placement, &mcComp.Status)
+	placement, err := clusters.ResolvePlacement(ctx, r.Client, mcComp.Spec.Placement)
+	if err != nil {
+		log.Errorf("Failed to resolve the placement: %v", err)
+		return ctrl.Result{}, err
+	}
+	oldState := clusters.SetEffectiveStateIfChanged(placement, &mcComp.Status)
 
-	if !clusters.IsPlacedInThisCluster(ctx, r, mcComp.Spec.Placement) {
+	if !clusters.IsPlacedInThisCluster(ctx, r, placement) {
 		if oldState != mcComp.Status.State {
 			// This must be done whether the resource is placed in this cluster or not, because we
 			// could be in an admin cluster and receive cluster level statuses from managed clusters,
@@ -106,12 +111,12 @@ func (r *Reconciler) doReconcile(ctx context.Context, mcComp clustersv1alpha1.Mu
 		}
 		// if this mc component is no longer placed on this cluster, remove the associated component
 		err := clusters.DeleteAssociatedResource(ctx, r.Client, &mcComp, finalizerName, &v1alpha2.Component{}, types.NamespacedName{Namespace: mcComp.Namespace, Name: mcComp.Name})
-		return ctrl.Result{}, err
+		return clusters.NewPlacementResult(ctrl.Result{}, mcComp.Spec.Placement), err
 	}
 
 	log.Debug("MultiClusterComponent create or update with underlying component",
 		"component", mcComp.Spec.Template.Metadata.Name,
-		"placement", mcComp.Spec.Placement.Clusters[0].Name)
+		"placement", placement.Clusters[0].Name)
 	opResult, err := r.createOrUpdateComponent(ctx, mcComp)
 
 	// Add our finalizer if not already added
@@ -119,7 +124,7 @@ func (r *Reconciler) doReconcile(ctx context.Context, mcComp clustersv1alpha1.Mu
 		_, err = clusters.AddFinalizer(ctx, r.Client, &mcComp, finalizerName)
 	}
 
-	ctrlResult, updateErr := r.updateStatus(ctx, &mcComp, opResult, err)
+	ctrlResult, updateErr := r.updateStatus(ctx, &mcComp, placement, opResult, err)
 
 	// if an error occurred in createOrUpdate, return that error with a requeue
 	// even if update status succeeded
@@ -128,7 +133,7 @@ func (r *Reconciler) doReconcile(ctx context.Context, mcComp clustersv1alpha1.Mu
 		return res, err
 	}
 
-	return ctrlResult, updateErr
+	return clusters.NewPlacementResult(ctrlResult, mcComp.Spec.Placement), updateErr
 }
 
 // SetupWithManager registers our controller with the manager
@@ -163,9 +168,9 @@ func (r *Reconciler) mutateComponent(mcComp clustersv1alpha1.MultiClusterCompone
 	oamComp.Annotations = mcComp.Spec.Template.Metadata.Annotations
 }
 
-func (r *Reconciler) updateStatus(ctx context.Context, mcComp *clustersv1alpha1.MultiClusterComponent, opResult controllerutil.OperationResult, err error) (ctrl.Result, error) {
+func (r *Reconciler) updateStatus(ctx context.Context, mcComp *clustersv1alpha1.MultiClusterComponent, placement clustersv1alpha1.Placement, opResult controllerutil.OperationResult, err error) (ctrl.Result, error) {
 	clusterName := clusters.GetClusterName(ctx, r.Client)
 	newCondition := clusters.GetConditionFromResult(err, opResult, "OAM Component")
-	return clusters.UpdateStatus(mcComp, &mcComp.Status, mcComp.Spec.Placement, newCondition, clusterName,
+	return clusters.UpdateStatus(mcComp, &mcComp.Status, placement, newCondition, clusterName,
 		r.AgentChannel, func() error { return r.Status().Update(ctx, mcComp) })
 }
@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package multiclusterconfigmap
@@ -92,8 +92,13 @@ func (r *Reconciler) doReconcile(ctx context.Context, mcConfigMap clustersv1alph
 		return ctrl.Result{}, err
 	}
 
-	oldState := clusters.SetEffectiveStateIfChanged(mcConfigMap.Spec.Placement, &mcConfigMap.Status)
-	if !clusters.IsPlacedInThisCluster(ctx, r, mcConfigMap.Spec.Placement) {
+	placement, err := clusters.ResolvePlacement(ctx, r.Client, mcConfigMap.Spec.Placement)
+	if err != nil {
+		log.Errorf("Failed to resolve the placement: %v", err)
+		return ctrl.Result{}, err
+	}
+	oldState := clusters.SetEffectiveStateIfChanged(placement, &mcConfigMap.Status)
+	if !clusters.IsPlacedInThisCluster(ctx, r, placement) {
 		if oldState != mcConfigMap.Status.State {
 			// This must be done whether the resource is placed in this cluster or not, because we
 			// could be in an admin cluster and receive cluster level statuses from managed clusters,
@@ -105,12 +110,12 @@ func (r *Reconciler) doReconcile(ctx context.Context, mcConfigMap clustersv1alph
 		}
 		// if this mc config map is no longer placed on this cluster, remove the associated config map
 		err := clusters.DeleteAssociatedResource(ctx, r.Client, &mcConfigMap, finalizerName, &corev1.ConfigMap{}, types.NamespacedName{Namespace: mcConfigMap.Namespace, Name: mcConfigMap.Name})
-		return ctrl.Result{}, err
+		return clusters.NewPlacementResult(ctrl.Result{}, mcConfigMap.Spec.Placement), err
 	}
 
 	log.Debug("MultiClusterConfigMap create or update with underlying ConfigMap",
 		"ConfigMap", mcConfigMap.Spec.Template.Metadata.Name,
-		"placement", mcConfigMap.Spec.Placement.Clusters[0].Name)
+		"placement", placement.Clusters[0].Name)
 	// Immutable ConfigMaps are not supported - we need a webhook to validate, or add the support
 	opResult, err := r.createOrUpdateConfigMap(ctx, mcConfigMap)
 
@@ -119,7 +124,7 @@ func (r *Reconciler) doReconcile(ctx context.Context, mcConfigMap clustersv1alph
 		_, err = clusters.AddFinalizer(ctx, r.Client, &mcConfigMap, finalizerName)
 	}
 
-	ctrlResult, updateErr := r.updateStatus(ctx, &mcConfigMap, opResult, err)
+	ctrlResult, updateErr := r.updateStatus(ctx, &mcConfigMap, placement, opResult, err)
 
 	// if an error occurred in createOrUpdate, return that error with a requeue
 	// even if update status succeeded
@@ -128,7 +133,7 @@ func (r *Reconciler) doReconcile(ctx context.Context, mcConfigMap clustersv1alph
 		return res, err
 	}
 
-	return ctrlResult, updateErr
+	return clusters.NewPlacementResult(ctrlResult, mcConfigMap.Spec.Placement), updateErr
 }
 
 // SetupWithManager registers our controller with the manager
@@ -148,6 +153,11 @@ func (r *Reconciler) createOrUpdateConfigMap(ctx context.Context, mcConfigMap cl
 	configMap.Name = mcConfigMap.Name
 
 	return controllerutil.CreateOrUpdate(ctx, r.Client, &configMap, func() error {
+		// Changes made to the ConfigMap in this cluster are kept until the MultiClusterConfigMap changes, unless its
+		// drift policy enforces the template
+		if clusters.KeepDrift(mcConfigMap.Spec.DriftPolicy, &configMap, mcConfigMap.Generation) {
+			return nil
+		}
 		r.mutateConfigMap(mcConfigMap, &configMap)
 		return nil
 	})
@@ -165,11 +175,12 @@ func (r *Reconciler) mutateConfigMap(mcConfigMap clustersv1alpha1.MultiClusterCo
 	configMap.Labels[vzconst.VerrazzanoManagedLabelKey] = constants.LabelVerrazzanoManagedDefault
 
 	configMap.Annotations = mcConfigMap.Spec.Template.Metadata.Annotations
+	clusters.SetMultiClusterGeneration(configMap, mcConfigMap.Generation)
 }
 
-func (r *Reconciler) updateStatus(ctx context.Context, mcConfigMap *clustersv1alpha1.MultiClusterConfigMap, opResult controllerutil.OperationResult, err error) (ctrl.Result, error) {
+func (r *Reconciler) updateStatus(ctx context.Context, mcConfigMap *clustersv1alpha1.MultiClusterConfigMap, placement clustersv1alpha1.Placement, opResult controllerutil.OperationResult, err error) (ctrl.Result, error) {
 	clusterName := clusters.GetClusterName(ctx, r.Client)
 	newCondition := clusters.GetConditionFromResult(err, opResult, "ConfigMap")
-	return clusters.UpdateStatus(mcConfigMap, &mcConfigMap.Status, mcConfigMap.Spec.Placement, newCondition, clusterName,
+	return clusters.UpdateStatus(mcConfigMap, &mcConfigMap.Status, placement, newCondition, clusterName,
 		r.AgentChannel, func() error { return r.Status().Update(ctx, mcConfigMap) })
 }
@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package multiclustersecret
@@ -91,8 +91,13 @@ func (r *Reconciler) doReconcile(ctx context.Context, mcSecret clustersv1alpha1.
 		return ctrl.Result{}, err
 	}
 
-	oldState := clusters.SetEffectiveStateIfChanged(mcSecret.Spec.Placement, &mcSecret.Status)
-	if !clusters.IsPlacedInThisCluster(ctx, r, mcSecret.Spec.Placement) {
+	placement, err := clusters.ResolvePlacement(ctx, r.Client, mcSecret.Spec.Placement)
+	if err != nil {
+		log.Errorf("Failed to resolve the placement: %v", err)
+		return ctrl.Result{}, err
+	}
+	oldState := clusters.SetEffectiveStateIfChanged(placement, &mcSecret.Status)
+	if !clusters.IsPlacedInThisCluster(ctx, r, placement) {
 		if oldState != mcSecret.Status.State {
 			// This must be done whether the resource is placed in this cluster or not, because we
 			// could be in an admin cluster and receive cluster level statuses from managed clusters,
@@ -104,12 +109,12 @@ func (r *Reconciler) doReconcile(ctx context.Context, mcSecret clustersv1alpha1.
 		}
 		// if this mc secret is no longer placed on this cluster, remove the associated secret
 		err := clusters.DeleteAssociatedResource(ctx, r.Client, &mcSecret, finalizerName, &corev1.Secret{}, types.NamespacedName{Namespace: mcSecret.Namespace, Name: mcSecret.Name})
-		return ctrl.Result{}, err
+		return clusters.NewPlacementResult(ctrl.Result{}, mcSecret.Spec.Placement), err
 	}
 
 	log.Debug("MultiClusterSecret create or update with underlying secret",
 		"secret", mcSecret.Spec.Template.Metadata.Name,
-		"placement", mcSecret.Spec.Placement.Clusters[0].Name)
+		"placement", placement.Clusters[0].Name)
 	opResult, err := r.createOrUpdateSecret(ctx, mcSecret)
 
 	// Add our finalizer if not already added
@@ -117,7 +122,7 @@ func (r *Reconciler) doReconcile(ctx context.Context, mcSecret clustersv1alpha1.
 		_, err = clusters.AddFinalizer(ctx, r.Client, &mcSecret, finalizerName)
 	}
 
-	ctrlResult, updateErr := r.updateStatus(ctx, &mcSecret, opResult, err)
+	ctrlResult, updateErr := r.updateStatus(ctx, &mcSecret, placement, opResult, err)
 
 	// if an error occurred in createOrUpdate, return that error with a requeue
 	// even if update status succeeded
@@ -126,14 +131,14 @@ func (r *Reconciler) doReconcile(ctx context.Context, mcSecret clustersv1alpha1.
 		return res, err
 	}
 
-	return ctrlResult, updateErr
+	return clusters.NewPlacementResult(ctrlResult, mcSecret.Spec.Placement), updateErr
 
 }
 
-func (r *Reconciler) updateStatus(ctx context.Context, mcSecret *clustersv1alpha1.MultiClusterSecret, opResult controllerutil.OperationResult, err error) (ctrl.Result, error) {
+func (r *Reconciler) updateStatus(ctx context.Context, mcSecret *clustersv1alpha1.MultiClusterSecret, placement clustersv1alpha1.Placement, opResult controllerutil.OperationResult, err error) (ctrl.Result, error) {
 	clusterName := clusters.GetClusterName(ctx, r.Client)
 	newCondition := clusters.GetConditionFromResult(err, opResult, "Secret")
-	return clusters.UpdateStatus(mcSecret, &mcSecret.Status, mcSecret.Spec.Placement, newCondition, clusterName,
+	return clusters.UpdateStatus(mcSecret, &mcSecret.Status, placement, newCondition, clusterName,
 		r.AgentChannel, func() error { return r.Status().Update(ctx, mcSecret) })
 }
 
@@ -154,6 +159,11 @@ func (r *Reconciler) createOrUpdateSecret(ctx context.Context, mcSecret clusters
 	secret.Name = mcSecret.Name
 
 	return controllerutil.CreateOrUpdate(ctx, r.Client, &secret, func() error {
+		// Changes made to the Secret in this cluster are kept until the MultiClusterSecret changes, unless its
+		// drift policy enforces the template
+		if clusters.KeepDrift(mcSecret.Spec.DriftPolicy, &secret, mcSecret.Generation) {
+			return nil
+		}
 		r.mutateSecret(mcSecret, &secret)
 		return nil
 	})
@@ -171,4 +181,5 @@ func (r *Reconciler) mutateSecret(mcSecret clustersv1alpha1.MultiClusterSecret,
 	}
 	secret.Labels[vzconst.VerrazzanoManagedLabelKey] = constants.LabelVerrazzanoManagedDefault
 	secret.Annotations = mcSecret.Spec.Template.Metadata.Annotations
+	clusters.SetMultiClusterGeneration(secret, mcSecret.Generation)
 }
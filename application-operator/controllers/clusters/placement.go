@@ -0,0 +1,109 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package clusters
+
+import (
+	"context"
+	"sort"
+
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// HasDynamicPlacement returns true if the placement selects clusters by labels, so that the clusters of the placement
+// change as managed clusters are registered, deregistered or labelled
+func HasDynamicPlacement(placement clustersv1alpha1.Placement) bool {
+	return placement.ClusterSelector != nil
+}
+
+// GetPlacementClusters returns the names of the clusters of a placement, given the VerrazzanoManagedCluster resources
+// of the admin cluster.  The clusters listed by name come first, followed by the clusters selected by labels sorted by
+// name.  Excluded clusters are never returned, and the spread of the placement limits the number of clusters selected
+// by labels in each topology domain.
+func GetPlacementClusters(placement clustersv1alpha1.Placement, vmcs []v1alpha1.VerrazzanoManagedCluster) ([]string, error) {
+	excluded := make(map[string]bool)
+	for _, cluster := range placement.ExcludedClusters {
+		excluded[cluster.Name] = true
+	}
+
+	var names []string
+	for _, cluster := range placement.Clusters {
+		if !excluded[cluster.Name] {
+			names = append(names, cluster.Name)
+			excluded[cluster.Name] = true
+		}
+	}
+	if placement.ClusterSelector == nil {
+		return names, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(placement.ClusterSelector)
+	if err != nil {
+		return nil, err
+	}
+	var selected []v1alpha1.VerrazzanoManagedCluster
+	for _, vmc := range vmcs {
+		if !excluded[vmc.Name] && selector.Matches(labels.Set(vmc.Labels)) {
+			selected = append(selected, vmc)
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		return selected[i].Name < selected[j].Name
+	})
+
+	domains := make(map[string]int)
+	for _, vmc := range selected {
+		if spread := placement.Spread; spread != nil {
+			domain, ok := vmc.Labels[spread.TopologyKey]
+			if !ok || domains[domain] >= spread.MaxClustersPerDomain {
+				continue
+			}
+			domains[domain]++
+		}
+		names = append(names, vmc.Name)
+	}
+	return names, nil
+}
+
+// ResolvePlacement returns a placement listing the clusters of the given placement by name.  The
+// VerrazzanoManagedCluster resources are only read when the placement selects clusters by labels; they are only
+// found on the admin cluster.
+func ResolvePlacement(ctx context.Context, rdr client.Reader, placement clustersv1alpha1.Placement) (clustersv1alpha1.Placement, error) {
+	if !HasDynamicPlacement(placement) && len(placement.ExcludedClusters) == 0 {
+		return placement, nil
+	}
+	var vmcs []v1alpha1.VerrazzanoManagedCluster
+	if HasDynamicPlacement(placement) {
+		vmcList := v1alpha1.VerrazzanoManagedClusterList{}
+		err := rdr.List(ctx, &vmcList, client.InNamespace(constants.VerrazzanoMultiClusterNamespace))
+		if err != nil && !meta.IsNoMatchError(err) {
+			return placement, err
+		}
+		vmcs = vmcList.Items
+	}
+	names, err := GetPlacementClusters(placement, vmcs)
+	if err != nil {
+		return placement, err
+	}
+	resolved := clustersv1alpha1.Placement{}
+	for _, name := range names {
+		resolved.Clusters = append(resolved.Clusters, clustersv1alpha1.Cluster{Name: name})
+	}
+	return resolved, nil
+}
+
+// NewPlacementResult returns the result of reconciling a resource with the given placement.  Resources placed by
+// labels are requeued, so that their placement is resolved again as managed clusters come and go.
+func NewPlacementResult(result controllerruntime.Result, placement clustersv1alpha1.Placement) controllerruntime.Result {
+	if !HasDynamicPlacement(placement) || ShouldRequeue(result) {
+		return result
+	}
+	return NewRequeueWithRandomDelay(30, 60)
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package clusters
+
+import (
+	"context"
+	"testing"
+
+	asserts "github.com/stretchr/testify/assert"
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestGetPlacementClusters tests resolving the clusters of a placement
+// GIVEN managed clusters labelled with their region and zone
+// WHEN the clusters of placements listing clusters by name, selecting clusters by labels, excluding clusters and
+// spreading clusters over zones are resolved
+// THEN the clusters listed by name come first, followed by the selected clusters sorted by name, without the
+// excluded clusters and with at most the given number of clusters per zone
+func TestGetPlacementClusters(t *testing.T) {
+	assert := asserts.New(t)
+	vmcs := newPlacementManagedClusters()
+	euSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"region": "eu"}}
+
+	names, err := GetPlacementClusters(clustersv1alpha1.Placement{Clusters: []clustersv1alpha1.Cluster{{Name: "local"}, {Name: "eu-2"}}}, vmcs)
+	assert.NoError(err)
+	assert.Equal([]string{"local", "eu-2"}, names)
+
+	names, err = GetPlacementClusters(clustersv1alpha1.Placement{
+		Clusters:        []clustersv1alpha1.Cluster{{Name: "local"}, {Name: "eu-2"}},
+		ClusterSelector: euSelector,
+	}, vmcs)
+	assert.NoError(err)
+	assert.Equal([]string{"local", "eu-2", "eu-1", "eu-3"}, names)
+
+	names, err = GetPlacementClusters(clustersv1alpha1.Placement{
+		ClusterSelector:  euSelector,
+		ExcludedClusters: []clustersv1alpha1.Cluster{{Name: "eu-1"}},
+	}, vmcs)
+	assert.NoError(err)
+	assert.Equal([]string{"eu-2", "eu-3"}, names)
+
+	names, err = GetPlacementClusters(clustersv1alpha1.Placement{
+		ClusterSelector: &metav1.LabelSelector{},
+		Spread:          &clustersv1alpha1.PlacementSpread{TopologyKey: "zone", MaxClustersPerDomain: 1},
+	}, vmcs)
+	assert.NoError(err)
+	// us-1 has no zone label, eu-2 is in the same zone as eu-1
+	assert.Equal([]string{"eu-1", "eu-3"}, names)
+
+	_, err = GetPlacementClusters(clustersv1alpha1.Placement{ClusterSelector: &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "region", Operator: "Near"}},
+	}}, vmcs)
+	assert.Error(err)
+}
+
+// TestResolvePlacement tests resolving a placement against the managed clusters of the admin cluster
+// GIVEN a placement selecting clusters by labels
+// WHEN the placement is resolved
+// THEN the resolved placement lists the selected managed clusters, and resources placed by labels are requeued
+func TestResolvePlacement(t *testing.T) {
+	assert := asserts.New(t)
+	vmcs := newPlacementManagedClusters()
+	cli := fake.NewClientBuilder().WithScheme(newPlacementScheme()).WithObjects(&vmcs[0], &vmcs[1], &vmcs[2], &vmcs[3]).Build()
+
+	placement := clustersv1alpha1.Placement{Clusters: []clustersv1alpha1.Cluster{{Name: "local"}}}
+	resolved, err := ResolvePlacement(context.TODO(), cli, placement)
+	assert.NoError(err)
+	assert.Equal(placement, resolved)
+	assert.Equal(controllerruntime.Result{}, NewPlacementResult(controllerruntime.Result{}, placement))
+
+	placement.ClusterSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"region": "us"}}
+	resolved, err = ResolvePlacement(context.TODO(), cli, placement)
+	assert.NoError(err)
+	assert.Equal(clustersv1alpha1.Placement{Clusters: []clustersv1alpha1.Cluster{{Name: "local"}, {Name: "us-1"}}}, resolved)
+	assert.True(NewPlacementResult(controllerruntime.Result{}, placement).Requeue)
+}
+
+// newPlacementManagedClusters returns managed clusters labelled with their region and zone
+func newPlacementManagedClusters() []v1alpha1.VerrazzanoManagedCluster {
+	newVMC := func(name string, labels map[string]string) v1alpha1.VerrazzanoManagedCluster {
+		return v1alpha1.VerrazzanoManagedCluster{ObjectMeta: metav1.ObjectMeta{
+			Namespace: constants.VerrazzanoMultiClusterNamespace,
+			Name:      name,
+			Labels:    labels,
+		}}
+	}
+	return []v1alpha1.VerrazzanoManagedCluster{
+		newVMC("us-1", map[string]string{"region": "us"}),
+		newVMC("eu-3", map[string]string{"region": "eu", "zone": "eu-b"}),
+		newVMC("eu-2", map[string]string{"region": "eu", "zone": "eu-a"}),
+		newVMC("eu-1", map[string]string{"region": "eu", "zone": "eu-a"}),
+	}
+}
+
+// newPlacementScheme returns a scheme including the managed clusters
+func newPlacementScheme() *runtime.Scheme {
+	scheme := NewScheme()
+	_ = v1alpha1.AddToScheme(scheme)
+	return scheme
+}
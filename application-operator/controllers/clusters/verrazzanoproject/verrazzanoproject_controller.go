@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package verrazzanoproject
@@ -115,6 +115,10 @@ func (r *Reconciler) doReconcile(ctx context.Context, vp clustersv1alpha1.Verraz
 			if err := r.deleteRoleBindings(ctx, &vp, log); err != nil {
 				return reconcile.Result{}, err
 			}
+			log.Debug("Deleting all resource quotas, limit ranges and project role bindings for project")
+			if err := r.deleteProjectResources(ctx, &vp, log); err != nil {
+				return reconcile.Result{}, err
+			}
 			// Remove the finalizer and update the Verrazzano resource if the deletion has finished.
 			vp.ObjectMeta.Finalizers = vzstring.RemoveStringFromSlice(vp.ObjectMeta.Finalizers, finalizerName)
 			err := r.Update(ctx, &vp)
@@ -133,22 +137,35 @@ func (r *Reconciler) doReconcile(ctx context.Context, vp clustersv1alpha1.Verraz
 		}
 	}
 
+	// Resolve the clusters the project is placed in
+	placement, err := clusters.ResolvePlacement(ctx, r.Client, vp.Spec.Placement)
+	if err != nil {
+		log.Errorf("Failed to resolve the placement: %v", err)
+		return ctrl.Result{}, err
+	}
+
 	// Use OperationResultCreated by default since we don't really know what happened to individual resources
 	opResult := controllerutil.OperationResultCreated
-	err := r.syncAll(ctx, vp, log)
+	err = r.syncAll(ctx, vp, placement, log)
 	if err != nil {
 		opResult = controllerutil.OperationResultNone
 	}
 
 	// Update the cluster status
-	_, statusErr := r.updateStatus(ctx, &vp, opResult, err)
+	clusterName := clusters.GetClusterName(ctx, r.Client)
+	_, statusErr := r.updateStatus(ctx, &vp, placement, clusterName, opResult, err)
 	if statusErr != nil {
 		return ctrl.Result{}, statusErr
 	}
 
-	// Update the VerrazzanoProject state
-	oldState := clusters.SetEffectiveStateIfChanged(vp.Spec.Placement, &vp.Status)
-	if oldState != vp.Status.State {
+	// Update the VerrazzanoProject state and the usage of its quotas on this cluster
+	quotas, quotaErr := r.getQuotaStatus(ctx, &vp, clusterName)
+	if quotaErr != nil {
+		return ctrl.Result{}, quotaErr
+	}
+	quotasChanged := clusters.SetClusterQuotaStatus(&vp.Status, clusterName, quotas)
+	oldState := clusters.SetEffectiveStateIfChanged(placement, &vp.Status.MultiClusterResourceStatus)
+	if oldState != vp.Status.State || quotasChanged {
 		stateErr := r.Status().Update(ctx, &vp)
 		if stateErr != nil {
 			return ctrl.Result{}, stateErr
@@ -160,12 +177,18 @@ func (r *Reconciler) doReconcile(ctx context.Context, vp clustersv1alpha1.Verraz
 	if err != nil {
 		return ctrl.Result{Requeue: true, RequeueAfter: clusters.GetRandomRequeueDelay()}, err
 	}
-	return ctrl.Result{}, nil
+
+	// Periodically requeue projects with quotas to share the aggregate quota according to
+	// the usage of the namespaces, and to update the usage of the quotas
+	if hasQuotas(&vp) {
+		return ctrl.Result{Requeue: true, RequeueAfter: clusters.GetRandomRequeueDelayInRange(30, 60)}, nil
+	}
+	return clusters.NewPlacementResult(ctrl.Result{}, vp.Spec.Placement), nil
 }
 
 // Sync all the project resources, return immediately with error if failure
-func (r *Reconciler) syncAll(ctx context.Context, vp clustersv1alpha1.VerrazzanoProject, log vzlog2.VerrazzanoLogger) error {
-	err := r.createOrUpdateNamespaces(ctx, vp, log)
+func (r *Reconciler) syncAll(ctx context.Context, vp clustersv1alpha1.VerrazzanoProject, placement clustersv1alpha1.Placement, log vzlog2.VerrazzanoLogger) error {
+	err := r.createOrUpdateNamespaces(ctx, vp, placement, log)
 	if err != nil {
 		return err
 	}
@@ -178,7 +201,7 @@ func (r *Reconciler) syncAll(ctx context.Context, vp clustersv1alpha1.Verrazzano
 	return nil
 }
 
-func (r *Reconciler) createOrUpdateNamespaces(ctx context.Context, vp clustersv1alpha1.VerrazzanoProject, log vzlog2.VerrazzanoLogger) error {
+func (r *Reconciler) createOrUpdateNamespaces(ctx context.Context, vp clustersv1alpha1.VerrazzanoProject, placement clustersv1alpha1.Placement, log vzlog2.VerrazzanoLogger) error {
 	if vp.Namespace == constants.VerrazzanoMultiClusterNamespace {
 		for _, nsTemplate := range vp.Spec.Template.Namespaces {
 			log.Debug("create or update with underlying namespace %s", nsTemplate.Metadata.Name)
@@ -195,21 +218,37 @@ func (r *Reconciler) createOrUpdateNamespaces(ctx context.Context, vp clustersv1
 				istioInjection = val
 			}
 
+			var applied []string
+			resources := getProjectResources(&vp, nsTemplate)
 			opResult, err := controllerutil.CreateOrUpdate(ctx, r.Client, &namespace, func() error {
+				applied = getAppliedProjectResources(&namespace)
 				r.mutateNamespace(nsTemplate, istioInjection, &namespace)
+				mutateProjectResources(nsTemplate, applied, resources, &namespace)
 				return nil
 			})
 			if err != nil {
 				return log2.ConflictWithLog(fmt.Sprintf("Failed to create or update namespace %s. result: %v", nsTemplate.Metadata.Name, opResult), err, zap.S())
 			}
 
-			if err = r.createOrUpdateRoleBindings(ctx, nsTemplate.Metadata.Name, vp, log); err != nil {
+			if err = r.createOrUpdateRoleBindings(ctx, nsTemplate.Metadata.Name, vp, placement, log); err != nil {
 				return err
 			}
 
 			if err = r.deleteRoleBindings(ctx, nil, log); err != nil {
 				return err
 			}
+
+			if err = r.syncNamespaceResources(ctx, &vp, nsTemplate, applied, resources, log); err != nil {
+				return err
+			}
+		}
+
+		if err := r.syncAggregateResourceQuota(ctx, &vp, log); err != nil {
+			return err
+		}
+
+		if err := r.syncLogAccess(ctx, &vp, log); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -236,7 +275,7 @@ func (r *Reconciler) mutateNamespace(nsTemplate clustersv1alpha1.NamespaceTempla
 
 // createOrUpdateRoleBindings creates project role bindings if there are security subjects specified in
 // the project spec
-func (r *Reconciler) createOrUpdateRoleBindings(ctx context.Context, namespace string, vp clustersv1alpha1.VerrazzanoProject, log vzlog2.VerrazzanoLogger) error {
+func (r *Reconciler) createOrUpdateRoleBindings(ctx context.Context, namespace string, vp clustersv1alpha1.VerrazzanoProject, placement clustersv1alpha1.Placement, log vzlog2.VerrazzanoLogger) error {
 	log.Oncef("Create or update role bindings for namespace %s", namespace)
 
 	// get the default binding subjects
@@ -275,7 +314,7 @@ func (r *Reconciler) createOrUpdateRoleBindings(ctx context.Context, namespace s
 	}
 
 	// create role binding for each managed cluster to limit resource access to admin cluster
-	for _, cluster := range vp.Spec.Placement.Clusters {
+	for _, cluster := range placement.Clusters {
 		if cluster.Name != constants.DefaultClusterName {
 			rb := newRoleBindingManagedCluster(namespace, cluster.Name)
 			if err := r.createOrUpdateRoleBinding(ctx, rb, log); err != nil {
@@ -307,11 +346,10 @@ func (r *Reconciler) createOrUpdateRoleBinding(ctx context.Context, roleBinding
 }
 
 // updateStatus updates the status of a VerrazzanoProject
-func (r *Reconciler) updateStatus(ctx context.Context, vp *clustersv1alpha1.VerrazzanoProject, opResult controllerutil.OperationResult, err error) (ctrl.Result, error) {
-	clusterName := clusters.GetClusterName(ctx, r.Client)
+func (r *Reconciler) updateStatus(ctx context.Context, vp *clustersv1alpha1.VerrazzanoProject, placement clustersv1alpha1.Placement, clusterName string, opResult controllerutil.OperationResult, err error) (ctrl.Result, error) {
 	newCondition := clusters.GetConditionFromResult(err, opResult, "VerrazzanoProject")
 	updateFunc := func() error { return r.Status().Update(ctx, vp) }
-	return clusters.UpdateStatus(vp, &vp.Status, vp.Spec.Placement, newCondition, clusterName,
+	return clusters.UpdateStatus(vp, &vp.Status.MultiClusterResourceStatus, placement, newCondition, clusterName,
 		r.AgentChannel, updateFunc)
 }
 
@@ -405,26 +443,30 @@ func (r *Reconciler) deleteRoleBindings(ctx context.Context, project *clustersv1
 		return err
 	}
 
+	// Get the list of VerrazzanoManagedCluster resources
+	vmcList := v1alpha1.VerrazzanoManagedClusterList{}
+	err := r.List(ctx, &vmcList, client.InNamespace(constants.VerrazzanoMultiClusterNamespace))
+	if err != nil {
+		return err
+	}
+
 	// Create map of expected namespace/cluster pairs for rolebindings
 	expectedPairs := make(map[string]bool)
 	for _, vp := range vpList.Items {
 		if project != nil && project.Name == vp.Name {
 			continue
 		}
+		placementClusters, err := clusters.GetPlacementClusters(vp.Spec.Placement, vmcList.Items)
+		if err != nil {
+			return err
+		}
 		for _, ns := range vp.Spec.Template.Namespaces {
-			for _, cluster := range vp.Spec.Placement.Clusters {
-				expectedPairs[ns.Metadata.Name+cluster.Name] = true
+			for _, cluster := range placementClusters {
+				expectedPairs[ns.Metadata.Name+cluster] = true
 			}
 		}
 	}
 
-	// Get the list of VerrazzanoManagedCluster resources
-	vmcList := v1alpha1.VerrazzanoManagedClusterList{}
-	err := r.List(ctx, &vmcList, client.InNamespace(constants.VerrazzanoMultiClusterNamespace))
-	if err != nil {
-		return err
-	}
-
 	for _, vmc := range vmcList.Items {
 		for _, vp := range vpList.Items {
 			for _, ns := range vp.Spec.Template.Namespaces {
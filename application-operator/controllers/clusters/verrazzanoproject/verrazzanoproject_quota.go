@@ -0,0 +1,328 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzanoproject
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	vzlog2 "github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzstring "github.com/verrazzano/verrazzano/pkg/string"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	resourceQuotaName          = "verrazzano-project-quota"
+	aggregateResourceQuotaName = "verrazzano-project-aggregate-quota"
+	limitRangeName             = "verrazzano-project-limit-range"
+
+	// projectResourcesAnnotation records the resources applied to a namespace from the project template, so that
+	// they can be removed when they are removed from the template
+	projectResourcesAnnotation     = "verrazzano.io/project-resources"
+	resourceQuotaResource          = "resourcequota"
+	aggregateResourceQuotaResource = "aggregateresourcequota"
+	limitRangeResource             = "limitrange"
+	podSecurityResource            = "podsecurity"
+
+	podSecurityEnforceLabel = "pod-security.kubernetes.io/enforce"
+	podSecurityAuditLabel   = "pod-security.kubernetes.io/audit"
+	podSecurityWarnLabel    = "pod-security.kubernetes.io/warn"
+)
+
+// hasQuotas returns true if the project template declares resource quotas
+func hasQuotas(vp *clustersv1alpha1.VerrazzanoProject) bool {
+	if len(vp.Spec.Template.AggregateResourceQuota) > 0 {
+		return true
+	}
+	for _, ns := range vp.Spec.Template.Namespaces {
+		if ns.ResourceQuota != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// getProjectResources returns the resources of the project template applied to a namespace
+func getProjectResources(vp *clustersv1alpha1.VerrazzanoProject, nsTemplate clustersv1alpha1.NamespaceTemplate) []string {
+	var resources []string
+	if len(vp.Spec.Template.AggregateResourceQuota) > 0 {
+		resources = append(resources, aggregateResourceQuotaResource)
+	}
+	if nsTemplate.LimitRange != nil {
+		resources = append(resources, limitRangeResource)
+	}
+	if nsTemplate.PodSecurity != "" {
+		resources = append(resources, podSecurityResource)
+	}
+	if nsTemplate.ResourceQuota != nil {
+		resources = append(resources, resourceQuotaResource)
+	}
+	return append(resources, getRoleBindingResources(vp)...)
+}
+
+// getAppliedProjectResources returns the resources of the project template previously applied to a namespace
+func getAppliedProjectResources(namespace *corev1.Namespace) []string {
+	value := namespace.Annotations[projectResourcesAnnotation]
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// mutateProjectResources applies the Pod Security Admission level of the project template to a namespace, and records the
+// resources of the project template applied to the namespace.  The labels of a level applied previously are removed
+// when the level is removed from the template.
+func mutateProjectResources(nsTemplate clustersv1alpha1.NamespaceTemplate, applied []string, resources []string, namespace *corev1.Namespace) {
+	if nsTemplate.PodSecurity != "" {
+		namespace.Labels[podSecurityEnforceLabel] = nsTemplate.PodSecurity
+		namespace.Labels[podSecurityAuditLabel] = nsTemplate.PodSecurity
+		namespace.Labels[podSecurityWarnLabel] = nsTemplate.PodSecurity
+	} else if vzstring.SliceContainsString(applied, podSecurityResource) {
+		delete(namespace.Labels, podSecurityEnforceLabel)
+		delete(namespace.Labels, podSecurityAuditLabel)
+		delete(namespace.Labels, podSecurityWarnLabel)
+	}
+
+	if len(resources) == 0 {
+		return
+	}
+	// Copy the annotations so that the annotations of the template are not modified
+	annotations := map[string]string{}
+	for key, value := range namespace.Annotations {
+		annotations[key] = value
+	}
+	annotations[projectResourcesAnnotation] = strings.Join(resources, ",")
+	namespace.Annotations = annotations
+}
+
+// syncNamespaceResources creates or updates the resource quota, limit range and project role bindings of a namespace,
+// and deletes the resources previously applied to the namespace that are no longer in the project template
+func (r *Reconciler) syncNamespaceResources(ctx context.Context, vp *clustersv1alpha1.VerrazzanoProject, nsTemplate clustersv1alpha1.NamespaceTemplate, applied []string, resources []string, log vzlog2.VerrazzanoLogger) error {
+	namespace := nsTemplate.Metadata.Name
+	if nsTemplate.ResourceQuota != nil {
+		quota := corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: resourceQuotaName}}
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, &quota, func() error {
+			nsTemplate.ResourceQuota.DeepCopyInto(&quota.Spec)
+			return nil
+		}); err != nil {
+			log.Errorf("Failed to create or update resource quota in namespace %s: %v", namespace, err)
+			return err
+		}
+	}
+	if nsTemplate.LimitRange != nil {
+		limitRange := corev1.LimitRange{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: limitRangeName}}
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, &limitRange, func() error {
+			nsTemplate.LimitRange.DeepCopyInto(&limitRange.Spec)
+			return nil
+		}); err != nil {
+			log.Errorf("Failed to create or update limit range in namespace %s: %v", namespace, err)
+			return err
+		}
+	}
+	if err := r.createOrUpdateProjectRoleBindings(ctx, namespace, vp, log); err != nil {
+		return err
+	}
+
+	for _, resource := range applied {
+		if vzstring.SliceContainsString(resources, resource) {
+			continue
+		}
+		if err := r.deleteNamespaceResource(ctx, vp, namespace, resource, log); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncAggregateResourceQuota shares the aggregate resource quota of the project between the project namespaces.
+// Each namespace gets a resource quota allowing the resources it already uses plus an even share of the resources
+// left in the aggregate quota, so that the namespaces together never use more than the aggregate quota.  The shares
+// are recomputed from the usage of the namespaces each time the project is reconciled.
+func (r *Reconciler) syncAggregateResourceQuota(ctx context.Context, vp *clustersv1alpha1.VerrazzanoProject, log vzlog2.VerrazzanoLogger) error {
+	hard := vp.Spec.Template.AggregateResourceQuota
+	if len(hard) == 0 {
+		return nil
+	}
+
+	used := map[string]corev1.ResourceList{}
+	total := corev1.ResourceList{}
+	for _, nsTemplate := range vp.Spec.Template.Namespaces {
+		quota := corev1.ResourceQuota{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: nsTemplate.Metadata.Name, Name: aggregateResourceQuotaName}, &quota)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		used[nsTemplate.Metadata.Name] = quota.Status.Used
+		addResources(total, quota.Status.Used)
+	}
+
+	for i, nsTemplate := range vp.Spec.Template.Namespaces {
+		quota := corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Namespace: nsTemplate.Metadata.Name, Name: aggregateResourceQuotaName}}
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, &quota, func() error {
+			quota.Spec.Hard = shareAggregateResourceQuota(hard, total, used[nsTemplate.Metadata.Name], i, len(vp.Spec.Template.Namespaces))
+			return nil
+		}); err != nil {
+			log.Errorf("Failed to create or update aggregate resource quota in namespace %s: %v", nsTemplate.Metadata.Name, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// shareAggregateResourceQuota returns the hard limits of the share of the namespace at the given index of the aggregate
+// resource quota, the resources used by the namespace plus its share of the resources left in the aggregate quota
+func shareAggregateResourceQuota(hard corev1.ResourceList, total corev1.ResourceList, used corev1.ResourceList, index int, count int) corev1.ResourceList {
+	share := corev1.ResourceList{}
+	for name, quantity := range hard {
+		remaining := quantity.DeepCopy()
+		remaining.Sub(total[name])
+		if remaining.Sign() < 0 {
+			remaining.Set(0)
+		}
+		limit := splitQuantity(name, remaining, index, count)
+		limit.Add(used[name])
+		share[name] = limit
+	}
+	return share
+}
+
+// splitQuantity returns the part of the namespace at the given index of a quantity split evenly between namespaces.
+// Integer resources are split in whole units and the other resources in thousandths of units, the remainder goes to
+// the first namespaces so that the parts add up to the quantity.
+func splitQuantity(name corev1.ResourceName, quantity resource.Quantity, index int, count int) resource.Quantity {
+	if isIntegerResource(name) {
+		value := quantity.Value()
+		part := value / int64(count)
+		if int64(index) < value%int64(count) {
+			part++
+		}
+		return *resource.NewQuantity(part, quantity.Format)
+	}
+	value := quantity.MilliValue()
+	part := value / int64(count)
+	if int64(index) < value%int64(count) {
+		part++
+	}
+	if part%1000 == 0 {
+		return *resource.NewQuantity(part/1000, quantity.Format)
+	}
+	return *resource.NewMilliQuantity(part, quantity.Format)
+}
+
+// isIntegerResource returns true if the resource quota of a resource must be a whole number, such as object counts
+func isIntegerResource(name corev1.ResourceName) bool {
+	switch name {
+	case corev1.ResourcePods, corev1.ResourceServices, corev1.ResourceReplicationControllers, corev1.ResourceQuotas,
+		corev1.ResourceSecrets, corev1.ResourceConfigMaps, corev1.ResourcePersistentVolumeClaims,
+		corev1.ResourceServicesNodePorts, corev1.ResourceServicesLoadBalancers:
+		return true
+	}
+	return strings.HasPrefix(string(name), "count/")
+}
+
+// deleteProjectResources deletes the resource quotas, limit ranges and project role bindings of the project template
+func (r *Reconciler) deleteProjectResources(ctx context.Context, vp *clustersv1alpha1.VerrazzanoProject, log vzlog2.VerrazzanoLogger) error {
+	if vp.Namespace != constants.VerrazzanoMultiClusterNamespace {
+		return nil
+	}
+	for _, nsTemplate := range vp.Spec.Template.Namespaces {
+		for _, resource := range getProjectResources(vp, nsTemplate) {
+			if err := r.deleteNamespaceResource(ctx, vp, nsTemplate.Metadata.Name, resource, log); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deleteNamespaceResource deletes a resource of the project template applied to a namespace
+func (r *Reconciler) deleteNamespaceResource(ctx context.Context, vp *clustersv1alpha1.VerrazzanoProject, namespace string, resource string, log vzlog2.VerrazzanoLogger) error {
+	if name := strings.TrimPrefix(resource, roleBindingResourcePrefix); name != resource {
+		return r.deleteProjectRoleBinding(ctx, namespace, name, log)
+	}
+	if name := strings.TrimPrefix(resource, logAccessResourcePrefix); name != resource {
+		return r.deleteLogAccess(ctx, vp, name, log)
+	}
+
+	var obj client.Object
+	switch resource {
+	case resourceQuotaResource:
+		obj = &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: resourceQuotaName}}
+	case aggregateResourceQuotaResource:
+		obj = &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: aggregateResourceQuotaName}}
+	case limitRangeResource:
+		obj = &corev1.LimitRange{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: limitRangeName}}
+	default:
+		// The Pod Security Admission labels are removed when the namespace is updated
+		return nil
+	}
+	log.Debugf("Deleting %s %s from namespace %s", resource, obj.GetName(), namespace)
+	if err := r.Delete(ctx, obj); err != nil && !k8serrors.IsNotFound(err) {
+		log.Errorf("Failed to delete %s %s from namespace %s: %v", resource, obj.GetName(), namespace, err)
+		return err
+	}
+	return nil
+}
+
+// getQuotaStatus returns the usage of the resource quotas of the project on this cluster
+func (r *Reconciler) getQuotaStatus(ctx context.Context, vp *clustersv1alpha1.VerrazzanoProject, clusterName string) ([]clustersv1alpha1.ProjectQuotaStatus, error) {
+	if vp.Namespace != constants.VerrazzanoMultiClusterNamespace || !hasQuotas(vp) {
+		return nil, nil
+	}
+
+	var quotas []clustersv1alpha1.ProjectQuotaStatus
+	aggregateUsed := corev1.ResourceList{}
+	for _, nsTemplate := range vp.Spec.Template.Namespaces {
+		if nsTemplate.ResourceQuota != nil {
+			quota := corev1.ResourceQuota{}
+			err := r.Get(ctx, types.NamespacedName{Namespace: nsTemplate.Metadata.Name, Name: resourceQuotaName}, &quota)
+			if err != nil && !k8serrors.IsNotFound(err) {
+				return nil, err
+			}
+			quotas = append(quotas, clustersv1alpha1.ProjectQuotaStatus{
+				Cluster:   clusterName,
+				Namespace: nsTemplate.Metadata.Name,
+				Hard:      nsTemplate.ResourceQuota.Hard,
+				Used:      quota.Status.Used,
+			})
+		}
+		if len(vp.Spec.Template.AggregateResourceQuota) > 0 {
+			quota := corev1.ResourceQuota{}
+			err := r.Get(ctx, types.NamespacedName{Namespace: nsTemplate.Metadata.Name, Name: aggregateResourceQuotaName}, &quota)
+			if err != nil && !k8serrors.IsNotFound(err) {
+				return nil, err
+			}
+			addResources(aggregateUsed, quota.Status.Used)
+		}
+	}
+	if len(vp.Spec.Template.AggregateResourceQuota) > 0 {
+		quotas = append(quotas, clustersv1alpha1.ProjectQuotaStatus{
+			Cluster: clusterName,
+			Hard:    vp.Spec.Template.AggregateResourceQuota,
+			Used:    aggregateUsed,
+		})
+	}
+	sort.SliceStable(quotas, func(i, j int) bool {
+		return quotas[i].Namespace < quotas[j].Namespace
+	})
+	return quotas, nil
+}
+
+// addResources adds resources to a list of resources
+func addResources(total corev1.ResourceList, resources corev1.ResourceList) {
+	for name, quantity := range resources {
+		sum := total[name]
+		sum.Add(quantity)
+		total[name] = sum
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzanoproject
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	asserts "github.com/stretchr/testify/assert"
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/clusters"
+	clusterstest "github.com/verrazzano/verrazzano/application-operator/controllers/clusters/test"
+	"github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const quotaProjectName = "quota-project"
+
+// TestReconcileProjectQuotas tests enforcing the quotas, limit ranges and Pod Security Admission levels of a project
+// GIVEN a VerrazzanoProject with a resource quota, a limit range and a Pod Security Admission level per namespace,
+// and an aggregate resource quota
+// WHEN the controller Reconcile function is called
+// THEN the namespaces are labelled with the Pod Security Admission level, the resource quotas and limit ranges are
+// created, the aggregate resource quota is shared according to the usage of the namespaces, and the usage of the
+// quotas is reported in the project status
+func TestReconcileProjectQuotas(t *testing.T) {
+	assert := asserts.New(t)
+
+	vp := newQuotaProject()
+	cli := fake.NewClientBuilder().WithScheme(newQuotaScheme()).WithObjects(
+		append(newQuotaClusterObjects(),
+			vp,
+			newAggregateResourceQuota("ns1", "1"),
+			newAggregateResourceQuota("ns2", "2"),
+		)...).Build()
+	reconciler := newVerrazzanoProjectReconciler(cli)
+	reconciler.Scheme = newQuotaScheme()
+
+	result, err := reconciler.Reconcile(context.TODO(), clusterstest.NewRequest(constants.VerrazzanoMultiClusterNamespace, quotaProjectName))
+	assert.NoError(err)
+	assert.True(result.Requeue)
+	assert.GreaterOrEqual(result.RequeueAfter, 30*time.Second)
+
+	// The Pod Security Admission level is applied to ns1 only
+	ns := corev1.Namespace{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Name: "ns1"}, &ns))
+	assert.Equal("restricted", ns.Labels[podSecurityEnforceLabel])
+	assert.Equal("restricted", ns.Labels[podSecurityAuditLabel])
+	assert.Equal("restricted", ns.Labels[podSecurityWarnLabel])
+	assert.Equal("aggregateresourcequota,limitrange,podsecurity,resourcequota", ns.Annotations[projectResourcesAnnotation])
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Name: "ns2"}, &ns))
+	assert.NotContains(ns.Labels, podSecurityEnforceLabel)
+	assert.Equal("aggregateresourcequota", ns.Annotations[projectResourcesAnnotation])
+
+	quota := corev1.ResourceQuota{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: "ns1", Name: resourceQuotaName}, &quota))
+	assert.Equal("10", quota.Spec.Hard.Pods().String())
+	limitRange := corev1.LimitRange{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: "ns1", Name: limitRangeName}, &limitRange))
+	assert.Len(limitRange.Spec.Limits, 1)
+	assert.True(k8serrors.IsNotFound(cli.Get(context.TODO(), types.NamespacedName{Namespace: "ns2", Name: resourceQuotaName}, &quota)))
+
+	// The namespaces use 3 of the 4 CPUs of the aggregate quota, the CPU left is split between the namespaces
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: "ns1", Name: aggregateResourceQuotaName}, &quota))
+	assert.Equal("1500m", quota.Spec.Hard.Cpu().String())
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: "ns2", Name: aggregateResourceQuotaName}, &quota))
+	assert.Equal("2500m", quota.Spec.Hard.Cpu().String())
+
+	// The usage of the quotas is reported in the status
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.VerrazzanoMultiClusterNamespace, Name: quotaProjectName}, vp))
+	assert.Len(vp.Status.Quotas, 2)
+	assert.Equal(clusterstest.UnitTestClusterName, vp.Status.Quotas[0].Cluster)
+	assert.Empty(vp.Status.Quotas[0].Namespace)
+	assert.Equal("4", vp.Status.Quotas[0].Hard.Cpu().String())
+	assert.Equal("3", vp.Status.Quotas[0].Used.Cpu().String())
+	assert.Equal("ns1", vp.Status.Quotas[1].Namespace)
+	assert.Equal("10", vp.Status.Quotas[1].Hard.Pods().String())
+}
+
+// TestReconcileRemovedProjectQuotas tests removing the quotas, limit ranges and Pod Security Admission levels of a
+// project
+// GIVEN a VerrazzanoProject whose quotas, limit ranges and Pod Security Admission levels are removed
+// WHEN the controller Reconcile function is called
+// THEN the resource quotas and limit ranges are deleted, and the Pod Security Admission labels are removed
+func TestReconcileRemovedProjectQuotas(t *testing.T) {
+	assert := asserts.New(t)
+
+	vp := newQuotaProject()
+	cli := fake.NewClientBuilder().WithScheme(newQuotaScheme()).WithObjects(append(newQuotaClusterObjects(), vp)...).Build()
+	reconciler := newVerrazzanoProjectReconciler(cli)
+	reconciler.Scheme = newQuotaScheme()
+	request := clusterstest.NewRequest(constants.VerrazzanoMultiClusterNamespace, quotaProjectName)
+	_, err := reconciler.Reconcile(context.TODO(), request)
+	assert.NoError(err)
+
+	assert.NoError(cli.Get(context.TODO(), request.NamespacedName, vp))
+	for i := range vp.Spec.Template.Namespaces {
+		vp.Spec.Template.Namespaces[i].ResourceQuota = nil
+		vp.Spec.Template.Namespaces[i].LimitRange = nil
+		vp.Spec.Template.Namespaces[i].PodSecurity = ""
+	}
+	vp.Spec.Template.AggregateResourceQuota = nil
+	assert.NoError(cli.Update(context.TODO(), vp))
+	result, err := reconciler.Reconcile(context.TODO(), request)
+	assert.NoError(err)
+	assert.False(result.Requeue)
+
+	ns := corev1.Namespace{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Name: "ns1"}, &ns))
+	assert.NotContains(ns.Labels, podSecurityEnforceLabel)
+	assert.NotContains(ns.Labels, podSecurityAuditLabel)
+	assert.NotContains(ns.Labels, podSecurityWarnLabel)
+	quotas := corev1.ResourceQuotaList{}
+	assert.NoError(cli.List(context.TODO(), &quotas))
+	assert.Empty(quotas.Items)
+	limitRanges := corev1.LimitRangeList{}
+	assert.NoError(cli.List(context.TODO(), &limitRanges))
+	assert.Empty(limitRanges.Items)
+
+	assert.NoError(cli.Get(context.TODO(), request.NamespacedName, vp))
+	assert.Empty(vp.Status.Quotas)
+}
+
+// TestShareAggregateResourceQuota tests sharing an aggregate resource quota between namespaces
+// GIVEN an aggregate resource quota and the usage of the namespaces
+// WHEN the shares of the namespaces are computed
+// THEN each share allows the resources used by the namespace plus an even share of the resources left in the
+// aggregate quota, and the shares add up to the aggregate quota
+func TestShareAggregateResourceQuota(t *testing.T) {
+	assert := asserts.New(t)
+
+	hard := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("4"),
+		corev1.ResourceMemory: resource.MustParse("4Gi"),
+		corev1.ResourcePods:   resource.MustParse("10"),
+	}
+	total := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("5"),
+		corev1.ResourceMemory: resource.MustParse("1Gi"),
+		corev1.ResourcePods:   resource.MustParse("5"),
+	}
+	used := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourcePods: resource.MustParse("2")}
+
+	share := shareAggregateResourceQuota(hard, total, used, 0, 2)
+	// The namespaces use more CPUs than the aggregate quota, the namespace may not use more CPUs
+	assert.Equal("2", share.Cpu().String())
+	// The namespace does not use memory yet, it may use half of the memory left in the aggregate quota
+	assert.Equal("1536Mi", share.Memory().String())
+	// The first namespace gets the remainder of the pods left in the aggregate quota
+	assert.Equal("5", share.Pods().String())
+	share = shareAggregateResourceQuota(hard, total, corev1.ResourceList{}, 1, 2)
+	assert.Equal("2", share.Pods().String())
+
+	// The CPUs left are split in thousandths of CPUs
+	share = shareAggregateResourceQuota(hard, corev1.ResourceList{}, corev1.ResourceList{}, 2, 3)
+	assert.Equal("1333m", share.Cpu().String())
+}
+
+// newQuotaProject returns a VerrazzanoProject with quotas, limit ranges and Pod Security Admission levels
+func newQuotaProject() *clustersv1alpha1.VerrazzanoProject {
+	return &clustersv1alpha1.VerrazzanoProject{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  constants.VerrazzanoMultiClusterNamespace,
+			Name:       quotaProjectName,
+			Finalizers: []string{finalizerName},
+		},
+		Spec: clustersv1alpha1.VerrazzanoProjectSpec{
+			Placement: clustersv1alpha1.Placement{Clusters: []clustersv1alpha1.Cluster{{Name: clusterstest.UnitTestClusterName}}},
+			Template: clustersv1alpha1.ProjectTemplate{
+				Namespaces: []clustersv1alpha1.NamespaceTemplate{
+					{
+						Metadata: metav1.ObjectMeta{Name: "ns1"},
+						ResourceQuota: &corev1.ResourceQuotaSpec{
+							Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10")},
+						},
+						LimitRange: &corev1.LimitRangeSpec{
+							Limits: []corev1.LimitRangeItem{{
+								Type:    corev1.LimitTypeContainer,
+								Default: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+							}},
+						},
+						PodSecurity: "restricted",
+					},
+					{
+						Metadata: metav1.ObjectMeta{Name: "ns2"},
+					},
+				},
+				AggregateResourceQuota: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+			},
+		},
+	}
+}
+
+// newAggregateResourceQuota returns a share of the aggregate resource quota using the given number of CPUs
+func newAggregateResourceQuota(namespace string, cpu string) *corev1.ResourceQuota {
+	return &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: aggregateResourceQuotaName},
+		Status: corev1.ResourceQuotaStatus{
+			Used: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+		},
+	}
+}
+
+// newQuotaClusterObjects returns the objects of the cluster needed to reconcile a project
+func newQuotaClusterObjects() []client.Object {
+	return []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: constants.VerrazzanoSystemNamespace}},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: clusters.MCRegistrationSecretFullName.Namespace,
+				Name:      clusters.MCRegistrationSecretFullName.Name,
+			},
+			Data: map[string][]byte{constants.ClusterNameData: []byte(clusterstest.UnitTestClusterName)},
+		},
+	}
+}
+
+// newQuotaScheme returns a scheme including the resources used to reconcile a project
+func newQuotaScheme() *runtime.Scheme {
+	scheme := clusters.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = v1alpha1.AddToScheme(scheme)
+	_ = netv1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	return scheme
+}
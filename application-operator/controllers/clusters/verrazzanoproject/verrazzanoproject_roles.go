@@ -0,0 +1,213 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzanoproject
+
+import (
+	"context"
+	"fmt"
+
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	vzlog2 "github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	projectRoleBindingTemplate    = "verrazzano-project-binding-%s"
+	projectK8sRoleBindingTemplate = "verrazzano-project-binding-%s-%s"
+	roleBindingResourcePrefix     = "rolebinding/"
+	logAccessResourcePrefix       = "logaccess/"
+
+	openSearchAPIVersion           = "opensearch.opster.io/v1"
+	openSearchClusterKind          = "OpenSearchCluster"
+	openSearchRoleKind             = "OpensearchRole"
+	openSearchUserRoleBindingKind  = "OpensearchUserRoleBinding"
+	logAccessRoleTemplate          = "vz-project-%s-%s-logs"
+	applicationLogsPatternTemplate = "verrazzano-application-%s*"
+	dataStreamPatternTemplate      = ".ds-verrazzano-application-%s*"
+)
+
+// getRoleBindingResources returns the resources applied to each project namespace for the role bindings of a project
+func getRoleBindingResources(vp *clustersv1alpha1.VerrazzanoProject) []string {
+	var resources []string
+	for _, binding := range vp.Spec.Template.Security.ProjectRoleBindings {
+		resources = append(resources, roleBindingResourcePrefix+binding.Name)
+		if binding.LogAccess {
+			resources = append(resources, logAccessResourcePrefix+binding.Name)
+		}
+	}
+	return resources
+}
+
+// getK8sRole returns the Kubernetes role bound together with a built-in Verrazzano project role, or an empty
+// string for a custom role
+func getK8sRole(role string) string {
+	switch role {
+	case projectAdminRole:
+		return projectAdminK8sRole
+	case projectMonitorRole:
+		return projectMonitorK8sRole
+	}
+	return ""
+}
+
+// createOrUpdateProjectRoleBindings creates or updates the role bindings of the project in a namespace.  The subjects
+// of a built-in Verrazzano project role are also bound to the matching Kubernetes role.
+func (r *Reconciler) createOrUpdateProjectRoleBindings(ctx context.Context, namespace string, vp *clustersv1alpha1.VerrazzanoProject, log vzlog2.VerrazzanoLogger) error {
+	for _, binding := range vp.Spec.Template.Security.ProjectRoleBindings {
+		rb := newRoleBinding(namespace, binding.Role, binding.Subjects)
+		rb.Name = fmt.Sprintf(projectRoleBindingTemplate, binding.Name)
+		if err := r.createOrUpdateRoleBinding(ctx, rb, log); err != nil {
+			return err
+		}
+		if k8sRole := getK8sRole(binding.Role); k8sRole != "" {
+			rb = newRoleBinding(namespace, k8sRole, binding.Subjects)
+			rb.Name = fmt.Sprintf(projectK8sRoleBindingTemplate, binding.Name, k8sRole)
+			if err := r.createOrUpdateRoleBinding(ctx, rb, log); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deleteProjectRoleBinding deletes the role bindings of a project role binding from a namespace
+func (r *Reconciler) deleteProjectRoleBinding(ctx context.Context, namespace string, name string, log vzlog2.VerrazzanoLogger) error {
+	names := []string{
+		fmt.Sprintf(projectRoleBindingTemplate, name),
+		fmt.Sprintf(projectK8sRoleBindingTemplate, name, projectAdminK8sRole),
+		fmt.Sprintf(projectK8sRoleBindingTemplate, name, projectMonitorK8sRole),
+	}
+	for _, rbName := range names {
+		rb := rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: rbName}}
+		if err := r.Delete(ctx, &rb); err != nil && !k8serrors.IsNotFound(err) {
+			log.Errorf("Failed to delete rolebinding %s from namespace %s: %v", rbName, namespace, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// syncLogAccess grants the subjects of the project role bindings with log access read access to the logs of the
+// project namespaces in OpenSearch.  The access is only managed on the cluster where the OpenSearch operator manages
+// the OpenSearch cluster.
+func (r *Reconciler) syncLogAccess(ctx context.Context, vp *clustersv1alpha1.VerrazzanoProject, log vzlog2.VerrazzanoLogger) error {
+	var bindings []clustersv1alpha1.ProjectRoleBinding
+	for _, binding := range vp.Spec.Template.Security.ProjectRoleBindings {
+		if binding.LogAccess {
+			bindings = append(bindings, binding)
+		}
+	}
+	if len(bindings) == 0 {
+		return nil
+	}
+	cluster, err := r.getOpenSearchCluster(ctx)
+	if err != nil || cluster == nil {
+		return err
+	}
+
+	var indexPatterns []interface{}
+	for _, ns := range vp.Spec.Template.Namespaces {
+		indexPatterns = append(indexPatterns,
+			fmt.Sprintf(applicationLogsPatternTemplate, ns.Metadata.Name),
+			fmt.Sprintf(dataStreamPatternTemplate, ns.Metadata.Name))
+	}
+	clusterRef := map[string]interface{}{"name": cluster.GetName()}
+
+	for _, binding := range bindings {
+		name := fmt.Sprintf(logAccessRoleTemplate, vp.Name, binding.Name)
+		role := newOpenSearchObject(openSearchRoleKind, cluster.GetNamespace(), name)
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, role, func() error {
+			return unstructured.SetNestedField(role.Object, map[string]interface{}{
+				"opensearchCluster":  clusterRef,
+				"clusterPermissions": []interface{}{"cluster_composite_ops_ro"},
+				"indexPermissions": []interface{}{
+					map[string]interface{}{
+						"indexPatterns":  indexPatterns,
+						"allowedActions": []interface{}{"read"},
+					},
+				},
+			}, "spec")
+		}); err != nil {
+			log.Errorf("Failed to create or update OpenSearch role %s: %v", name, err)
+			return err
+		}
+
+		var users, backendRoles []interface{}
+		for _, subject := range binding.Subjects {
+			switch subject.Kind {
+			case rbacv1.UserKind:
+				users = append(users, subject.Name)
+			case rbacv1.GroupKind:
+				backendRoles = append(backendRoles, subject.Name)
+			}
+		}
+		roleBinding := newOpenSearchObject(openSearchUserRoleBindingKind, cluster.GetNamespace(), name)
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, roleBinding, func() error {
+			spec := map[string]interface{}{
+				"opensearchCluster": clusterRef,
+				"roles":             []interface{}{name},
+			}
+			if len(users) > 0 {
+				spec["users"] = users
+			}
+			if len(backendRoles) > 0 {
+				spec["backendRoles"] = backendRoles
+			}
+			return unstructured.SetNestedField(roleBinding.Object, spec, "spec")
+		}); err != nil {
+			log.Errorf("Failed to create or update OpenSearch user role binding %s: %v", name, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteLogAccess deletes the OpenSearch role and user role binding granting log access to a project role binding
+func (r *Reconciler) deleteLogAccess(ctx context.Context, vp *clustersv1alpha1.VerrazzanoProject, name string, log vzlog2.VerrazzanoLogger) error {
+	cluster, err := r.getOpenSearchCluster(ctx)
+	if err != nil || cluster == nil {
+		return err
+	}
+	name = fmt.Sprintf(logAccessRoleTemplate, vp.Name, name)
+	for _, kind := range []string{openSearchUserRoleBindingKind, openSearchRoleKind} {
+		if err := r.Delete(ctx, newOpenSearchObject(kind, cluster.GetNamespace(), name)); err != nil && !k8serrors.IsNotFound(err) {
+			log.Errorf("Failed to delete %s %s: %v", kind, name, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// getOpenSearchCluster returns the OpenSearch cluster managed by the OpenSearch operator on this cluster, or nil if
+// there is none
+func (r *Reconciler) getOpenSearchCluster(ctx context.Context) (*unstructured.Unstructured, error) {
+	clusters := unstructured.UnstructuredList{}
+	clusters.SetAPIVersion(openSearchAPIVersion)
+	clusters.SetKind(openSearchClusterKind + "List")
+	if err := r.List(ctx, &clusters); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(clusters.Items) == 0 {
+		return nil, nil
+	}
+	return &clusters.Items[0], nil
+}
+
+// newOpenSearchObject returns an OpenSearch operator resource
+func newOpenSearchObject(kind string, namespace string, name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(openSearchAPIVersion)
+	u.SetKind(kind)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	return u
+}
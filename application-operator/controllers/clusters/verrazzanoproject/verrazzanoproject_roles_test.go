@@ -0,0 +1,165 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzanoproject
+
+import (
+	"context"
+	"testing"
+
+	asserts "github.com/stretchr/testify/assert"
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	clusterstest "github.com/verrazzano/verrazzano/application-operator/controllers/clusters/test"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	rolesProjectName    = "roles-project"
+	openSearchNamespace = "verrazzano-logging"
+	openSearchName      = "opensearch"
+	logAccessRoleName   = "vz-project-roles-project-developers-logs"
+	developersBinding   = "verrazzano-project-binding-developers"
+	operatorsBinding    = "verrazzano-project-binding-operators"
+	operatorsK8sBinding = "verrazzano-project-binding-operators-view"
+	developersGroup     = "developers"
+	operatorsUser       = "operator"
+	customClusterRole   = "project-developer"
+)
+
+// TestReconcileProjectRoleBindings tests creating the role bindings of a project
+// GIVEN a VerrazzanoProject with a role binding to a custom ClusterRole with log access, and a role binding to the
+// built-in project monitor role
+// WHEN the controller Reconcile function is called
+// THEN the role bindings are created in each project namespace, the monitor subjects are also bound to the
+// Kubernetes view role, and the OpenSearch role and user role binding grant read access to the project logs
+func TestReconcileProjectRoleBindings(t *testing.T) {
+	assert := asserts.New(t)
+
+	vp := newRolesProject()
+	cli := fake.NewClientBuilder().WithScheme(newQuotaScheme()).WithObjects(append(newQuotaClusterObjects(), vp, newOpenSearchCluster())...).Build()
+	reconciler := newVerrazzanoProjectReconciler(cli)
+	reconciler.Scheme = newQuotaScheme()
+
+	_, err := reconciler.Reconcile(context.TODO(), clusterstest.NewRequest(constants.VerrazzanoMultiClusterNamespace, rolesProjectName))
+	assert.NoError(err)
+
+	for _, ns := range []string{"ns1", "ns2"} {
+		rb := rbacv1.RoleBinding{}
+		assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: developersBinding}, &rb))
+		assert.Equal(customClusterRole, rb.RoleRef.Name)
+		assert.Equal("ClusterRole", rb.RoleRef.Kind)
+		assert.Equal(developersGroup, rb.Subjects[0].Name)
+		assert.True(k8serrors.IsNotFound(cli.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: developersBinding + "-admin"}, &rb)))
+
+		assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: operatorsBinding}, &rb))
+		assert.Equal(projectMonitorRole, rb.RoleRef.Name)
+		assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: operatorsK8sBinding}, &rb))
+		assert.Equal(projectMonitorK8sRole, rb.RoleRef.Name)
+		assert.Equal(operatorsUser, rb.Subjects[0].Name)
+	}
+
+	role := newOpenSearchObject(openSearchRoleKind, "", "")
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: openSearchNamespace, Name: logAccessRoleName}, role))
+	clusterName, _, _ := unstructured.NestedString(role.Object, "spec", "opensearchCluster", "name")
+	assert.Equal(openSearchName, clusterName)
+	indexPermissions, _, _ := unstructured.NestedSlice(role.Object, "spec", "indexPermissions")
+	assert.Len(indexPermissions, 1)
+	indexPatterns, _, _ := unstructured.NestedStringSlice(indexPermissions[0].(map[string]interface{}), "indexPatterns")
+	assert.Equal([]string{"verrazzano-application-ns1*", ".ds-verrazzano-application-ns1*", "verrazzano-application-ns2*", ".ds-verrazzano-application-ns2*"}, indexPatterns)
+
+	binding := newOpenSearchObject(openSearchUserRoleBindingKind, "", "")
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: openSearchNamespace, Name: logAccessRoleName}, binding))
+	backendRoles, _, _ := unstructured.NestedStringSlice(binding.Object, "spec", "backendRoles")
+	assert.Equal([]string{developersGroup}, backendRoles)
+	roles, _, _ := unstructured.NestedStringSlice(binding.Object, "spec", "roles")
+	assert.Equal([]string{logAccessRoleName}, roles)
+
+	// The monitor role binding does not grant log access
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: openSearchNamespace, Name: "vz-project-roles-project-operators-logs"}, newOpenSearchObject(openSearchRoleKind, "", ""))
+	assert.True(k8serrors.IsNotFound(err))
+}
+
+// TestReconcileRemovedProjectRoleBindings tests removing the role bindings of a project
+// GIVEN a VerrazzanoProject whose role bindings are removed
+// WHEN the controller Reconcile function is called
+// THEN the role bindings are deleted from the project namespaces, and the OpenSearch role and user role binding
+// are deleted
+func TestReconcileRemovedProjectRoleBindings(t *testing.T) {
+	assert := asserts.New(t)
+
+	vp := newRolesProject()
+	cli := fake.NewClientBuilder().WithScheme(newQuotaScheme()).WithObjects(append(newQuotaClusterObjects(), vp, newOpenSearchCluster())...).Build()
+	reconciler := newVerrazzanoProjectReconciler(cli)
+	reconciler.Scheme = newQuotaScheme()
+	request := clusterstest.NewRequest(constants.VerrazzanoMultiClusterNamespace, rolesProjectName)
+	_, err := reconciler.Reconcile(context.TODO(), request)
+	assert.NoError(err)
+
+	assert.NoError(cli.Get(context.TODO(), request.NamespacedName, vp))
+	vp.Spec.Template.Security.ProjectRoleBindings = nil
+	assert.NoError(cli.Update(context.TODO(), vp))
+	_, err = reconciler.Reconcile(context.TODO(), request)
+	assert.NoError(err)
+
+	for _, ns := range []string{"ns1", "ns2"} {
+		for _, name := range []string{developersBinding, operatorsBinding, operatorsK8sBinding} {
+			err = cli.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: name}, &rbacv1.RoleBinding{})
+			assert.True(k8serrors.IsNotFound(err), "rolebinding %s/%s was not deleted", ns, name)
+		}
+	}
+	for _, kind := range []string{openSearchRoleKind, openSearchUserRoleBindingKind} {
+		err = cli.Get(context.TODO(), types.NamespacedName{Namespace: openSearchNamespace, Name: logAccessRoleName}, newOpenSearchObject(kind, "", ""))
+		assert.True(k8serrors.IsNotFound(err), "%s was not deleted", kind)
+	}
+}
+
+// newRolesProject returns a VerrazzanoProject with project role bindings
+func newRolesProject() *clustersv1alpha1.VerrazzanoProject {
+	return &clustersv1alpha1.VerrazzanoProject{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  constants.VerrazzanoMultiClusterNamespace,
+			Name:       rolesProjectName,
+			Finalizers: []string{finalizerName},
+		},
+		Spec: clustersv1alpha1.VerrazzanoProjectSpec{
+			Placement: clustersv1alpha1.Placement{Clusters: []clustersv1alpha1.Cluster{{Name: clusterstest.UnitTestClusterName}}},
+			Template: clustersv1alpha1.ProjectTemplate{
+				Namespaces: []clustersv1alpha1.NamespaceTemplate{
+					{Metadata: metav1.ObjectMeta{Name: "ns1"}},
+					{Metadata: metav1.ObjectMeta{Name: "ns2"}},
+				},
+				Security: clustersv1alpha1.SecuritySpec{
+					ProjectRoleBindings: []clustersv1alpha1.ProjectRoleBinding{
+						{
+							Name:      "developers",
+							Role:      customClusterRole,
+							Subjects:  []rbacv1.Subject{{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: developersGroup}},
+							LogAccess: true,
+						},
+						{
+							Name:     "operators",
+							Role:     projectMonitorRole,
+							Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: operatorsUser}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// newOpenSearchCluster returns an OpenSearch cluster managed by the OpenSearch operator
+func newOpenSearchCluster() *unstructured.Unstructured {
+	cluster := &unstructured.Unstructured{}
+	cluster.SetAPIVersion(openSearchAPIVersion)
+	cluster.SetKind(openSearchClusterKind)
+	cluster.SetNamespace(openSearchNamespace)
+	cluster.SetName(openSearchName)
+	return cluster
+}
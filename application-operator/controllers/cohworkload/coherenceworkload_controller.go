@@ -19,6 +19,7 @@ import (
 	"github.com/verrazzano/verrazzano/application-operator/controllers/logging"
 	"github.com/verrazzano/verrazzano/application-operator/controllers/metricstrait"
 	vznav "github.com/verrazzano/verrazzano/application-operator/controllers/navigation"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/tracingtrait"
 	"github.com/verrazzano/verrazzano/application-operator/metricsexporter"
 	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
 	log2 "github.com/verrazzano/verrazzano/pkg/log"
@@ -264,6 +265,11 @@ func (r *Reconciler) doReconcile(ctx context.Context, workload *vzapi.Verrazzano
 		return reconcile.Result{}, err
 	}
 
+	// Add the tracing environment variables of the tracing trait to the Coherence resource if it exists
+	if err = r.addTracingTrait(ctx, log, workload, u); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	if err = r.addMetrics(ctx, log, workload.Namespace, workload, u); err != nil {
 		return reconcile.Result{}, err
 	}
@@ -680,13 +686,23 @@ func (r *Reconciler) updateStatusReconcileDone(ctx context.Context, workload *vz
 	return nil
 }
 
+// addTracingTrait adds the OpenTelemetry environment variables of the tracing trait to the Coherence resource
+func (r *Reconciler) addTracingTrait(ctx context.Context, log vzlog2.VerrazzanoLogger, workload *vzapi.VerrazzanoCoherenceWorkload, coherence *unstructured.Unstructured) error {
+	tracingTrait, err := vznav.TracingTraitFromWorkloadLabels(ctx, r.Client, log, workload.GetNamespace(), workload.ObjectMeta)
+	if err != nil {
+		return err
+	}
+	return tracingtrait.AddTracingEnv(tracingTrait, coherence)
+}
+
 // addLoggingTrait adds the logging trait sidecar to the workload
 func (r *Reconciler) addLoggingTrait(ctx context.Context, log vzlog2.VerrazzanoLogger, workload *vzapi.VerrazzanoCoherenceWorkload, coherence *unstructured.Unstructured, coherenceSpec map[string]interface{}) error {
 	loggingTrait, err := vznav.LoggingTraitFromWorkloadLabels(ctx, r.Client, log, workload.GetNamespace(), workload.ObjectMeta)
 	if err != nil {
 		return err
 	}
-	if loggingTrait == nil {
+	// The logs of a Fluent Bit logging trait are processed by the Fluent Bit daemon set, without a sidecar
+	if loggingTrait == nil || loggingTrait.Spec.FluentBit != nil {
 		return nil
 	}
 
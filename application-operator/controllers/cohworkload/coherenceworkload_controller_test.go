@@ -172,7 +172,7 @@ func TestReconcileCreateCoherence(t *testing.T) {
 				DoAndReturn(func(ctx context.Context, name types.NamespacedName, appConfig *oamcore.ApplicationConfiguration, opt ...client.GetOption) error {
 					appConfig.Spec.Components = []oamcore.ApplicationConfigurationComponent{{ComponentName: componentName}}
 					return nil
-				})
+				}).Times(2)
 			// expect a call to attempt to get the Coherence CR - return not found
 			cli.EXPECT().
 				Get(gomock.Any(), gomock.Eq(client.ObjectKey{Namespace: namespace, Name: "unit-test-cluster"}), gomock.Not(gomock.Nil()), gomock.Any()).
@@ -311,7 +311,7 @@ func TestReconcileCreateCoherenceWithLogging(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, name types.NamespacedName, appConfig *oamcore.ApplicationConfiguration, opt ...client.GetOption) error {
 			appConfig.Spec.Components = []oamcore.ApplicationConfigurationComponent{{ComponentName: componentName}}
 			return nil
-		})
+		}).Times(2)
 	// expect a call to attempt to get the Coherence CR - return not found
 	cli.EXPECT().
 		Get(gomock.Any(), gomock.Eq(client.ObjectKey{Namespace: namespace, Name: "unit-test-cluster"}), gomock.Not(gomock.Nil()), gomock.Any()).
@@ -456,7 +456,7 @@ func TestReconcileCreateCoherenceWithCustomLogging(t *testing.T) {
 				},
 			}
 			return nil
-		})
+		}).Times(2)
 	// expect a call to get the ConfigMap for logging - return not found
 	cli.EXPECT().
 		Get(gomock.Any(), gomock.Eq(client.ObjectKey{Namespace: namespace, Name: loggingNamePart + "-unit-test-cluster-coherence"}), gomock.Not(gomock.Nil()), gomock.Any()).
@@ -659,7 +659,7 @@ func TestReconcileCreateCoherenceWithCustomLoggingConfigMapExists(t *testing.T)
 				},
 			}
 			return nil
-		})
+		}).Times(2)
 	// expect a call to get the ConfigMap for logging - return not found
 	cli.EXPECT().
 		Get(gomock.Any(), gomock.Eq(client.ObjectKey{Namespace: namespace, Name: loggingNamePart + "-unit-test-cluster-coherence"}), gomock.Not(gomock.Nil()), gomock.Any()).
@@ -842,7 +842,7 @@ func TestReconcileUpdateFluentdImage(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, name types.NamespacedName, appConfig *oamcore.ApplicationConfiguration, opt ...client.GetOption) error {
 			appConfig.Spec.Components = []oamcore.ApplicationConfigurationComponent{{ComponentName: componentName}}
 			return nil
-		})
+		}).Times(2)
 	// expect a call to get the namespace for the Coherence resource
 	cli.EXPECT().
 		Get(gomock.Any(), gomock.Eq(client.ObjectKey{Namespace: "", Name: namespace}), gomock.Not(gomock.Nil()), gomock.Any()).
@@ -940,7 +940,7 @@ func TestReconcileUpdateCR(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, name types.NamespacedName, appConfig *oamcore.ApplicationConfiguration, opt ...client.GetOption) error {
 			appConfig.Spec.Components = []oamcore.ApplicationConfigurationComponent{{ComponentName: componentName}}
 			return nil
-		})
+		}).Times(2)
 	// expect a call to attempt to get the Coherence CR and return an existing resource
 	cli.EXPECT().
 		Get(gomock.Any(), gomock.Eq(client.ObjectKey{Namespace: namespace, Name: "unit-test-cluster"}), gomock.Not(gomock.Nil()), gomock.Any()).
@@ -1060,7 +1060,7 @@ func TestReconcileWithLoggingWithJvmArgs(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, name types.NamespacedName, appConfig *oamcore.ApplicationConfiguration, opt ...client.GetOption) error {
 			appConfig.Spec.Components = []oamcore.ApplicationConfigurationComponent{{ComponentName: componentName}}
 			return nil
-		})
+		}).Times(2)
 	// expect a call to attempt to get the Coherence CR - return not found
 	cli.EXPECT().
 		Get(gomock.Any(), gomock.Eq(client.ObjectKey{Namespace: namespace, Name: "unit-test-cluster"}), gomock.Not(gomock.Nil()), gomock.Any()).
@@ -1176,7 +1176,7 @@ func TestReconcileErrorOnCreate(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, name types.NamespacedName, appConfig *oamcore.ApplicationConfiguration, opt ...client.GetOption) error {
 			appConfig.Spec.Components = []oamcore.ApplicationConfigurationComponent{{ComponentName: componentName}}
 			return nil
-		})
+		}).Times(2)
 	// expect a call to attempt to get the Coherence CR - return not found
 	cli.EXPECT().
 		Get(gomock.Any(), gomock.Eq(client.ObjectKey{Namespace: namespace, Name: "unit-test-cluster"}), gomock.Not(gomock.Nil()), gomock.Any()).
@@ -1557,7 +1557,7 @@ func TestReconcileRestart(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, name types.NamespacedName, appConfig *oamcore.ApplicationConfiguration, opt ...client.GetOption) error {
 			appConfig.Spec.Components = []oamcore.ApplicationConfigurationComponent{{ComponentName: componentName}}
 			return nil
-		})
+		}).Times(2)
 	// expect a call to get the namespace for the Coherence resource
 	cli.EXPECT().
 		Get(gomock.Any(), gomock.Eq(client.ObjectKey{Namespace: "", Name: namespace}), gomock.Not(gomock.Nil()), gomock.Any()).
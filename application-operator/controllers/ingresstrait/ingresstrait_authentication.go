@@ -0,0 +1,412 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package ingresstrait
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/reconcileresults"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"google.golang.org/protobuf/types/known/structpb"
+	istionet "istio.io/api/networking/v1alpha3"
+	"istio.io/api/security/v1beta1"
+	v1beta12 "istio.io/api/type/v1beta1"
+	istioclient "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	clisecurity "istio.io/client-go/pkg/apis/security/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	requestAuthnAPIVersion = "security.istio.io/v1beta1"
+	requestAuthnKind       = "RequestAuthentication"
+	secretAPIVersion       = "v1"
+	secretKind             = "Secret"
+	// clientCACertKey is the key of the bundle of the certificate authorities in the client CA secret of a trait
+	clientCACertKey = "ca.crt"
+	// gatewayCACertKey is the key of the bundle of the certificate authorities in the gateway CA secret
+	gatewayCACertKey = "cacert"
+	// jwtAuthnFilter is the Envoy filter storing the payloads of the validated tokens in the dynamic metadata, under
+	// the issuers of the tokens
+	jwtAuthnFilter  = "envoy.filters.http.jwt_authn"
+	luaFilter       = "envoy.filters.http.lua"
+	luaTypeURL      = "type.googleapis.com/envoy.extensions.filters.http.lua.v3.Lua"
+	luaRouteTypeURL = "type.googleapis.com/envoy.extensions.filters.http.lua.v3.LuaPerRoute"
+	// claimHeadersFilterName is the name of the EnvoyFilter adding the Lua HTTP filter to the ingress gateway.  The
+	// filter does nothing until a virtual host of a trait sets its script, it is shared by all the ingress traits.
+	claimHeadersFilterName = "verrazzano-claim-headers"
+	// claimHeadersFilterLabel labels the EnvoyFilters setting the claim headers scripts of the virtual hosts of a trait.
+	// The shared claim headers filter is deleted when no EnvoyFilter has the label.
+	claimHeadersFilterLabel = "verrazzano.io/ingress-claim-headers"
+	// gatewayHTTPSPort is the port of the servers of the traits in the gateways, Istio names the virtual hosts of the
+	// ingress gateway "<host>:<port>"
+	gatewayHTTPSPort = 443
+)
+
+// buildGatewayCASecretName builds the name of the secret of the certificate authorities verifying the client
+// certificates.  The Istio ingress gateway finds the secret from the name of the credential of the server.
+func buildGatewayCASecretName(secretName string) string {
+	return fmt.Sprintf("%s-cacert", secretName)
+}
+
+// buildRequestAuthenticationName builds the name of the RequestAuthentication of a trait
+func buildRequestAuthenticationName(trait *vzapi.IngressTrait) string {
+	return fmt.Sprintf("%s-%s-authn", trait.Namespace, trait.Name)
+}
+
+// buildClaimHeadersFilterName builds the name of the EnvoyFilter copying the claims of the tokens to request headers
+func buildClaimHeadersFilterName(trait *vzapi.IngressTrait) string {
+	return fmt.Sprintf("%s-%s-claims", trait.Namespace, trait.Name)
+}
+
+// buildAudiencePolicyName builds the name of the AuthorizationPolicy checking the audiences of the tokens of a trait
+func buildAudiencePolicyName(trait *vzapi.IngressTrait) string {
+	return fmt.Sprintf("%s-%s-audiences", trait.Namespace, trait.Name)
+}
+
+// createOrUpdateGatewayCASecret copies the bundle of the certificate authorities of the client CA secret of the trait
+// to the Istio system namespace, where the ingress gateway reads it, when the trait requires client certificates.
+// Results are added to the status object.
+func (r *Reconciler) createOrUpdateGatewayCASecret(ctx context.Context, trait *vzapi.IngressTrait, secretName string, status *reconcileresults.ReconcileResults, log vzlog.VerrazzanoLogger) {
+	name := buildGatewayCASecretName(secretName)
+	gatewaySecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: constants.IstioSystemNamespace, Name: name}}
+	if trait.Spec.TLS.Mode != vzapi.TLSModeMutual {
+		r.deleteStaleResource(ctx, trait, gatewaySecret, secretKind, log)
+		return
+	}
+
+	ref := vzapi.QualifiedResourceRelation{APIVersion: secretAPIVersion, Kind: secretKind, Name: name, Role: "secret"}
+	clientCASecret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: trait.Namespace, Name: trait.Spec.TLS.ClientCASecretName}, clientCASecret)
+	if err == nil && len(clientCASecret.Data[clientCACertKey]) == 0 {
+		err = fmt.Errorf("the client CA secret %s/%s does not contain the key %s", trait.Namespace, trait.Spec.TLS.ClientCASecretName, clientCACertKey)
+	}
+	if err != nil {
+		log.Errorf("Failed to get the client certificate authorities: %v", err)
+		status.Relations = append(status.Relations, ref)
+		status.Results = append(status.Results, controllerutil.OperationResultNone)
+		status.Errors = append(status.Errors, err)
+		return
+	}
+
+	res, err := controllerutil.CreateOrUpdate(ctx, r.Client, gatewaySecret, func() error {
+		if gatewaySecret.Labels == nil {
+			gatewaySecret.Labels = map[string]string{}
+		}
+		gatewaySecret.Labels[constants.LabelIngressTraitNsn] = getIngressTraitNsn(trait.Namespace, trait.Name)
+		gatewaySecret.Type = corev1.SecretTypeOpaque
+		gatewaySecret.Data = map[string][]byte{gatewayCACertKey: clientCASecret.Data[clientCACertKey]}
+		return nil
+	})
+	status.Relations = append(status.Relations, ref)
+	status.Results = append(status.Results, res)
+	status.Errors = append(status.Errors, err)
+
+	if err != nil {
+		log.Errorf("Failed to create or update the gateway client CA secret: %v", err)
+	}
+}
+
+// createOrUpdateRequestAuthentication creates or updates the RequestAuthentication validating the tokens of the JWT
+// issuers of the trait on the ingress gateway.  The RequestAuthentication applies to all the hosts of the gateway, the
+// webhook rejects the traits specifying an issuer with other keys or token forwarding than the other traits, and the
+// audiences are checked by an AuthorizationPolicy scoped to the hosts of the trait.  The RequestAuthentication is
+// deleted when the trait no longer has JWT issuers.  Results are added to the status object.
+func (r *Reconciler) createOrUpdateRequestAuthentication(ctx context.Context, trait *vzapi.IngressTrait, status *reconcileresults.ReconcileResults, log vzlog.VerrazzanoLogger) {
+	name := buildRequestAuthenticationName(trait)
+	requestAuthn := &clisecurity.RequestAuthentication{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: requestAuthnAPIVersion,
+			Kind:       requestAuthnKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: constants.IstioSystemNamespace,
+			Name:      name,
+			Labels:    map[string]string{constants.LabelIngressTraitNsn: getIngressTraitNsn(trait.Namespace, trait.Name)}},
+	}
+	if trait.Spec.Authentication == nil || len(trait.Spec.Authentication.JWT) == 0 {
+		r.deleteStaleResource(ctx, trait, requestAuthn, requestAuthnKind, log)
+		return
+	}
+
+	res, err := common.CreateOrUpdateProtobuf(ctx, r.Client, requestAuthn, func() error {
+		requestAuthn.Spec = v1beta1.RequestAuthentication{
+			Selector: &v1beta12.WorkloadSelector{MatchLabels: map[string]string{"istio": "ingressgateway"}},
+		}
+		for _, issuer := range trait.Spec.Authentication.JWT {
+			requestAuthn.Spec.JwtRules = append(requestAuthn.Spec.JwtRules, &v1beta1.JWTRule{
+				Issuer:               issuer.Issuer,
+				JwksUri:              issuer.JwksURI,
+				Jwks:                 issuer.Jwks,
+				ForwardOriginalToken: issuer.ForwardOriginalToken,
+			})
+		}
+		return nil
+	})
+
+	ref := vzapi.QualifiedResourceRelation{APIVersion: requestAuthnAPIVersion, Kind: requestAuthnKind, Name: name, Role: "requestauthentication"}
+	status.Relations = append(status.Relations, ref)
+	status.Results = append(status.Results, res)
+	status.Errors = append(status.Errors, err)
+
+	if err != nil {
+		log.Errorf("Failed to create or update request authentication: %v", err)
+	}
+}
+
+// createOrUpdateAudiencePolicy creates or updates the AuthorizationPolicy denying the requests to the hosts of the
+// trait with a token of an issuer of the trait which is not intended for one of the audiences of the issuer.  The
+// AuthorizationPolicy is deleted when no issuer of the trait has audiences.  Results are added to the status object.
+func (r *Reconciler) createOrUpdateAudiencePolicy(ctx context.Context, trait *vzapi.IngressTrait, hosts []string, status *reconcileresults.ReconcileResults, log vzlog.VerrazzanoLogger) {
+	name := buildAudiencePolicyName(trait)
+	authzPolicy := &clisecurity.AuthorizationPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: authzPolicyAPIVersion,
+			Kind:       authzPolicyKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: constants.IstioSystemNamespace,
+			Name:      name,
+			Labels:    map[string]string{constants.LabelIngressTraitNsn: getIngressTraitNsn(trait.Namespace, trait.Name)}},
+	}
+	var rules []*v1beta1.Rule
+	if trait.Spec.Authentication != nil {
+		for _, issuer := range trait.Spec.Authentication.JWT {
+			if len(issuer.Audiences) == 0 {
+				continue
+			}
+			rules = append(rules, &v1beta1.Rule{
+				From: []*v1beta1.Rule_From{{Source: &v1beta1.Source{RequestPrincipals: []string{issuer.Issuer + "/*"}}}},
+				To:   []*v1beta1.Rule_To{{Operation: &v1beta1.Operation{Hosts: hosts}}},
+				When: []*v1beta1.Condition{{Key: "request.auth.audiences", NotValues: issuer.Audiences}},
+			})
+		}
+	}
+	if len(rules) == 0 {
+		r.deleteStaleResource(ctx, trait, authzPolicy, authzPolicyKind, log)
+		return
+	}
+
+	res, err := common.CreateOrUpdateProtobuf(ctx, r.Client, authzPolicy, func() error {
+		authzPolicy.Spec = v1beta1.AuthorizationPolicy{
+			Selector: &v1beta12.WorkloadSelector{MatchLabels: map[string]string{"istio": "ingressgateway"}},
+			Action:   v1beta1.AuthorizationPolicy_DENY,
+			Rules:    rules,
+		}
+		return nil
+	})
+
+	ref := vzapi.QualifiedResourceRelation{APIVersion: authzPolicyAPIVersion, Kind: authzPolicyKind, Name: name, Role: "authorizationpolicy"}
+	status.Relations = append(status.Relations, ref)
+	status.Results = append(status.Results, res)
+	status.Errors = append(status.Errors, err)
+
+	if err != nil {
+		log.Errorf("Failed to create or update the audience authorization policy: %v", err)
+	}
+}
+
+// createOrUpdateClaimHeadersFilter creates or updates the EnvoyFilter copying the claims of the validated tokens to
+// request headers, for the requests to the hosts of the trait.  The script is set on the virtual hosts of the trait in
+// the ingress gateway, so the shared Lua filter only runs it for the requests to the trait.  The EnvoyFilter is
+// deleted when the trait no longer maps claims to headers.  Results are added to the status object.
+func (r *Reconciler) createOrUpdateClaimHeadersFilter(ctx context.Context, trait *vzapi.IngressTrait, hosts []string, status *reconcileresults.ReconcileResults, log vzlog.VerrazzanoLogger) {
+	name := buildClaimHeadersFilterName(trait)
+	envoyFilter := &istioclient.EnvoyFilter{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: envoyFilterAPIVersion,
+			Kind:       envoyFilterKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: constants.IstioSystemNamespace,
+			Name:      name,
+			Labels: map[string]string{
+				constants.LabelIngressTraitNsn: getIngressTraitNsn(trait.Namespace, trait.Name),
+				claimHeadersFilterLabel:        "true",
+			}},
+	}
+	script := buildClaimHeadersScript(trait)
+	if len(script) == 0 || len(hosts) == 0 {
+		if r.deleteStaleResource(ctx, trait, envoyFilter, envoyFilterKind, log) {
+			if err := deleteUnusedSharedEnvoyFilter(ctx, r.Client, claimHeadersFilterName, claimHeadersFilterLabel, log); err != nil {
+				status.Errors = append(status.Errors, err)
+			}
+		}
+		return
+	}
+
+	if err := r.createSharedLuaFilter(ctx); err != nil {
+		status.Errors = append(status.Errors, err)
+		log.Errorf("Failed to create the claim headers envoy filter: %v", err)
+		return
+	}
+	res, err := common.CreateOrUpdateProtobuf(ctx, r.Client, envoyFilter, func() error {
+		value, err := structpb.NewStruct(map[string]interface{}{
+			"typed_per_filter_config": map[string]interface{}{
+				luaFilter: map[string]interface{}{
+					"@type":       luaRouteTypeURL,
+					"source_code": map[string]interface{}{"inline_string": script},
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if envoyFilter.Labels == nil {
+			envoyFilter.Labels = map[string]string{}
+		}
+		envoyFilter.Labels[constants.LabelIngressTraitNsn] = getIngressTraitNsn(trait.Namespace, trait.Name)
+		envoyFilter.Labels[claimHeadersFilterLabel] = "true"
+		envoyFilter.Spec = istionet.EnvoyFilter{
+			WorkloadSelector: &istionet.WorkloadSelector{Labels: map[string]string{"istio": "ingressgateway"}},
+		}
+		for _, host := range hosts {
+			envoyFilter.Spec.ConfigPatches = append(envoyFilter.Spec.ConfigPatches, &istionet.EnvoyFilter_EnvoyConfigObjectPatch{
+				ApplyTo: istionet.EnvoyFilter_VIRTUAL_HOST,
+				Match: &istionet.EnvoyFilter_EnvoyConfigObjectMatch{
+					Context: istionet.EnvoyFilter_GATEWAY,
+					ObjectTypes: &istionet.EnvoyFilter_EnvoyConfigObjectMatch_RouteConfiguration{
+						RouteConfiguration: &istionet.EnvoyFilter_RouteConfigurationMatch{
+							Vhost: &istionet.EnvoyFilter_RouteConfigurationMatch_VirtualHostMatch{
+								Name: fmt.Sprintf("%s:%d", strings.ToLower(host), gatewayHTTPSPort),
+							},
+						},
+					},
+				},
+				Patch: &istionet.EnvoyFilter_Patch{Operation: istionet.EnvoyFilter_Patch_MERGE, Value: value},
+			})
+		}
+		return nil
+	})
+
+	ref := vzapi.QualifiedResourceRelation{APIVersion: envoyFilterAPIVersion, Kind: envoyFilterKind, Name: name, Role: "envoyfilter"}
+	status.Relations = append(status.Relations, ref)
+	status.Results = append(status.Results, res)
+	status.Errors = append(status.Errors, err)
+
+	if err != nil {
+		log.Errorf("Failed to create or update the claim headers envoy filter: %v", err)
+	}
+}
+
+// createSharedLuaFilter creates the EnvoyFilter adding the Lua HTTP filter to the ingress gateway, after the JWT
+// authentication filter, when it does not exist.  The default script does nothing.
+func (r *Reconciler) createSharedLuaFilter(ctx context.Context) error {
+	value, err := structpb.NewStruct(map[string]interface{}{
+		"name": luaFilter,
+		"typed_config": map[string]interface{}{
+			"@type":       luaTypeURL,
+			"inline_code": "function envoy_on_request(request_handle)\nend\n",
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return r.createSharedHTTPFilter(ctx, claimHeadersFilterName, value)
+}
+
+// buildClaimHeadersScript builds the Lua script copying the claims of the validated tokens to request headers.  The
+// JWT authentication filter stores the payloads of the validated tokens in its dynamic metadata, under the issuers of
+// the tokens.  The headers are always removed first, so that clients cannot set them.  An empty script is returned
+// when the trait does not map claims to headers.
+func buildClaimHeadersScript(trait *vzapi.IngressTrait) string {
+	if trait.Spec.Authentication == nil {
+		return ""
+	}
+	var claims []string
+	for _, issuer := range trait.Spec.Authentication.JWT {
+		for _, mapping := range issuer.ClaimToHeaders {
+			claims = append(claims, fmt.Sprintf("    {issuer = %q, claim = %q, header = %q},", issuer.Issuer, mapping.Claim, strings.ToLower(mapping.Header)))
+		}
+	}
+	if len(claims) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(`local claims = {
+%s
+}
+
+function envoy_on_request(request_handle)
+  local payloads = request_handle:streamInfo():dynamicMetadata():get(%q)
+  for _, mapping in ipairs(claims) do
+    request_handle:headers():remove(mapping.header)
+    local payload = payloads and payloads[mapping.issuer]
+    local value = payload and payload[mapping.claim]
+    if value ~= nil and type(value) ~= "table" then
+      request_handle:headers():add(mapping.header, tostring(value))
+    end
+  end
+end
+`, strings.Join(claims, "\n"), jwtAuthnFilter)
+}
+
+// deleteStaleResource deletes a resource created by an earlier reconcile of the trait, when the resource is no
+// longer needed.  Only the resources listed in the status of the trait are deleted.  Returns true when the resource
+// was deleted.
+func (r *Reconciler) deleteStaleResource(ctx context.Context, trait *vzapi.IngressTrait, obj client.Object, kind string, log vzlog.VerrazzanoLogger) bool {
+	for _, resource := range trait.Status.Resources {
+		if resource.Kind != kind || resource.Name != obj.GetName() {
+			continue
+		}
+		err := r.Delete(ctx, obj)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			log.Errorf("Failed to delete the %s %s: %v", kind, obj.GetName(), err)
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// cleanupRequestAuthentications deletes the RequestAuthentications created for the ingress trait
+func cleanupRequestAuthentications(trait *vzapi.IngressTrait, c client.Client, log vzlog.VerrazzanoLogger) error {
+	requestAuthnList := clisecurity.RequestAuthenticationList{}
+	err := c.List(context.TODO(), &requestAuthnList, &client.ListOptions{Namespace: constants.IstioSystemNamespace, LabelSelector: buildIngressTraitSelector(trait)})
+	if err != nil {
+		log.Errorf("Failed listing the request authentications: %v", err)
+		return nil
+	}
+	for i, requestAuthn := range requestAuthnList.Items {
+		log.Debugf("Deleting request authentication: %s", requestAuthn.Name)
+		err := c.Delete(context.TODO(), requestAuthnList.Items[i])
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return log.ErrorfNewErr("Failed deleting the request authentication %s: %v", requestAuthn.Name, err)
+		}
+		log.Oncef("Ingress trait request authentication %s deleted", requestAuthn.Name)
+	}
+	return nil
+}
+
+// cleanupGatewayCASecrets deletes the client CA secrets copied to the Istio system namespace for the ingress trait
+func cleanupGatewayCASecrets(trait *vzapi.IngressTrait, c client.Client, log vzlog.VerrazzanoLogger) error {
+	secretList := corev1.SecretList{}
+	err := c.List(context.TODO(), &secretList, &client.ListOptions{Namespace: constants.IstioSystemNamespace, LabelSelector: buildIngressTraitSelector(trait)})
+	if err != nil {
+		log.Errorf("Failed listing the gateway client CA secrets: %v", err)
+		return nil
+	}
+	for i, secret := range secretList.Items {
+		log.Debugf("Deleting gateway client CA secret: %s", secret.Name)
+		err := c.Delete(context.TODO(), &secretList.Items[i])
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return log.ErrorfNewErr("Failed deleting the gateway client CA secret %s: %v", secret.Name, err)
+		}
+		log.Oncef("Ingress trait gateway client CA secret %s deleted", secret.Name)
+	}
+	return nil
+}
+
+// buildIngressTraitSelector builds the selector of the resources labeled with the ingress trait
+func buildIngressTraitSelector(trait *vzapi.IngressTrait) labels.Selector {
+	traitNameReq, _ := labels.NewRequirement(constants.LabelIngressTraitNsn, selection.Equals, []string{getIngressTraitNsn(trait.Namespace, trait.Name)})
+	return labels.NewSelector().Add(*traitNameReq)
+}
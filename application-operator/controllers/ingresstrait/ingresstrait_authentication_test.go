@@ -0,0 +1,241 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package ingresstrait
+
+import (
+	"context"
+	"testing"
+
+	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/reconcileresults"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	istionet "istio.io/api/networking/v1alpha3"
+	"istio.io/api/security/v1beta1"
+	istioclient "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	clisecurity "istio.io/client-go/pkg/apis/security/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestCreateOrUpdateGatewayCASecret tests copying the client certificate authorities to the Istio system namespace
+// GIVEN a trait requiring client certificates, with a client CA secret in the namespace of the trait
+// WHEN the gateway CA secret is created
+// THEN the certificate authorities are copied to the secret read by the ingress gateway, and the secret is deleted
+// when the trait no longer requires client certificates
+func TestCreateOrUpdateGatewayCASecret(t *testing.T) {
+	assert := assert.New(t)
+
+	clientCASecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: trafficTestNamespace, Name: "hello-ca"},
+		Data:       map[string][]byte{clientCACertKey: []byte("ca-bundle")},
+	}
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(clientCASecret).Build()
+	reconciler := newIngressTraitReconciler(cli)
+	trait := newTrafficTestTrait(vzapi.IngressRule{})
+	trait.Spec.TLS = vzapi.IngressSecurity{SecretName: "hello-tls", Mode: vzapi.TLSModeMutual, ClientCASecretName: "hello-ca"}
+
+	status := reconcileresults.ReconcileResults{}
+	reconciler.createOrUpdateGatewayCASecret(context.TODO(), trait, "hello-tls", &status, vzlog.DefaultLogger())
+	assert.False(status.ContainsErrors())
+	gatewaySecret := &corev1.Secret{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: "hello-tls-cacert"}, gatewaySecret))
+	assert.Equal("ca-bundle", string(gatewaySecret.Data[gatewayCACertKey]))
+	assert.Equal("test-space-test-trait", gatewaySecret.Labels[constants.LabelIngressTraitNsn])
+
+	// A missing client CA secret is reported in the status
+	trait.Spec.TLS.ClientCASecretName = "missing-ca"
+	status = reconcileresults.ReconcileResults{}
+	reconciler.createOrUpdateGatewayCASecret(context.TODO(), trait, "hello-tls", &status, vzlog.DefaultLogger())
+	assert.True(status.ContainsErrors())
+
+	// Without mutual TLS, the secret created by an earlier reconcile is deleted
+	trait.Spec.TLS = vzapi.IngressSecurity{SecretName: "hello-tls"}
+	trait.Status.Resources = []oamrt.TypedReference{{APIVersion: secretAPIVersion, Kind: secretKind, Name: "hello-tls-cacert"}}
+	status = reconcileresults.ReconcileResults{}
+	reconciler.createOrUpdateGatewayCASecret(context.TODO(), trait, "hello-tls", &status, vzlog.DefaultLogger())
+	assert.Empty(status.Relations)
+	err := cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: "hello-tls-cacert"}, gatewaySecret)
+	assert.True(k8serrors.IsNotFound(err))
+}
+
+// TestMutateGatewayWithMutualTLS tests the TLS mode of the gateway server of a trait
+// GIVEN a trait requiring client certificates
+// WHEN the gateway is mutated
+// THEN the server of the trait requires client certificates
+func TestMutateGatewayWithMutualTLS(t *testing.T) {
+	trait := newTrafficTestTrait(vzapi.IngressRule{})
+	trait.Spec.TLS = vzapi.IngressSecurity{SecretName: "hello-tls", Mode: vzapi.TLSModeMutual, ClientCASecretName: "hello-ca"}
+	gw := &istioclient.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: trafficTestNamespace, Name: "test-gw"}}
+
+	reconciler := newIngressTraitReconciler(fake.NewClientBuilder().WithScheme(newScheme()).Build())
+	assert.NoError(t, reconciler.mutateGateway(gw, trait, []string{"example.com"}, "hello-tls"))
+	assert.Len(t, gw.Spec.Servers, 1)
+	assert.Equal(t, istionet.ServerTLSSettings_MUTUAL, gw.Spec.Servers[0].Tls.Mode)
+	assert.Equal(t, "hello-tls", gw.Spec.Servers[0].Tls.CredentialName)
+}
+
+// TestCreateOrUpdateRequestAuthentication tests the RequestAuthentication of the JWT issuers of a trait
+// GIVEN a trait with two JWT issuers
+// WHEN the request authentication is created
+// THEN the ingress gateway validates the tokens of both issuers, and the request authentication is deleted when
+// the issuers are removed
+func TestCreateOrUpdateRequestAuthentication(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).Build()
+	reconciler := newIngressTraitReconciler(cli)
+	trait := newTrafficTestTrait(vzapi.IngressRule{})
+	trait.Spec.Authentication = &vzapi.IngressAuthentication{JWT: []vzapi.IngressJWTIssuer{
+		{Issuer: "https://issuer.example.com", JwksURI: "https://issuer.example.com/keys", Audiences: []string{"hello"}},
+		{Issuer: "other", Jwks: `{"keys":[]}`, ForwardOriginalToken: true},
+	}}
+
+	status := reconcileresults.ReconcileResults{}
+	reconciler.createOrUpdateRequestAuthentication(context.TODO(), trait, &status, vzlog.DefaultLogger())
+	assert.False(status.ContainsErrors())
+	requestAuthn := &clisecurity.RequestAuthentication{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: "test-space-test-trait-authn"}, requestAuthn))
+	assert.Equal("ingressgateway", requestAuthn.Spec.Selector.MatchLabels["istio"])
+	assert.Len(requestAuthn.Spec.JwtRules, 2)
+	assert.Equal("https://issuer.example.com/keys", requestAuthn.Spec.JwtRules[0].JwksUri)
+	assert.Empty(requestAuthn.Spec.JwtRules[0].Audiences)
+	assert.Equal(`{"keys":[]}`, requestAuthn.Spec.JwtRules[1].Jwks)
+	assert.True(requestAuthn.Spec.JwtRules[1].ForwardOriginalToken)
+
+	trait.Spec.Authentication = nil
+	trait.Status.Resources = []oamrt.TypedReference{{APIVersion: requestAuthnAPIVersion, Kind: requestAuthnKind, Name: "test-space-test-trait-authn"}}
+	status = reconcileresults.ReconcileResults{}
+	reconciler.createOrUpdateRequestAuthentication(context.TODO(), trait, &status, vzlog.DefaultLogger())
+	err := cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: "test-space-test-trait-authn"}, requestAuthn)
+	assert.True(k8serrors.IsNotFound(err))
+
+	// The request authentications are deleted with the trait
+	trait.Spec.Authentication = &vzapi.IngressAuthentication{JWT: []vzapi.IngressJWTIssuer{{Issuer: "other"}}}
+	reconciler.createOrUpdateRequestAuthentication(context.TODO(), trait, &status, vzlog.DefaultLogger())
+	assert.NoError(cleanupRequestAuthentications(trait, cli, vzlog.DefaultLogger()))
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: "test-space-test-trait-authn"}, requestAuthn)
+	assert.True(k8serrors.IsNotFound(err))
+}
+
+// TestCreateOrUpdateAudiencePolicy tests the AuthorizationPolicy checking the audiences of the tokens
+// GIVEN a trait with a JWT issuer restricted to an audience
+// WHEN the audience policy is created
+// THEN the ingress gateway denies the requests to the hosts of the trait with a token of the issuer for another
+// audience, and the policy is deleted when the audiences are removed
+func TestCreateOrUpdateAudiencePolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).Build()
+	reconciler := newIngressTraitReconciler(cli)
+	trait := newTrafficTestTrait(vzapi.IngressRule{})
+	trait.Spec.Authentication = &vzapi.IngressAuthentication{JWT: []vzapi.IngressJWTIssuer{
+		{Issuer: "https://issuer.example.com", Audiences: []string{"hello"}},
+		{Issuer: "other"},
+	}}
+
+	status := reconcileresults.ReconcileResults{}
+	reconciler.createOrUpdateAudiencePolicy(context.TODO(), trait, []string{"hello.example.com"}, &status, vzlog.DefaultLogger())
+	assert.False(status.ContainsErrors())
+	authzPolicy := &clisecurity.AuthorizationPolicy{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: "test-space-test-trait-audiences"}, authzPolicy))
+	assert.Equal("ingressgateway", authzPolicy.Spec.Selector.MatchLabels["istio"])
+	assert.Equal(v1beta1.AuthorizationPolicy_DENY, authzPolicy.Spec.Action)
+	assert.Len(authzPolicy.Spec.Rules, 1)
+	rule := authzPolicy.Spec.Rules[0]
+	assert.Equal([]string{"https://issuer.example.com/*"}, rule.From[0].Source.RequestPrincipals)
+	assert.Equal([]string{"hello.example.com"}, rule.To[0].Operation.Hosts)
+	assert.Equal("request.auth.audiences", rule.When[0].Key)
+	assert.Equal([]string{"hello"}, rule.When[0].NotValues)
+	assert.Equal("test-space-test-trait", authzPolicy.Labels[constants.LabelIngressTraitNsn])
+
+	// Without audiences, the policy created by an earlier reconcile is deleted
+	trait.Spec.Authentication.JWT[0].Audiences = nil
+	trait.Status.Resources = []oamrt.TypedReference{{APIVersion: authzPolicyAPIVersion, Kind: authzPolicyKind, Name: "test-space-test-trait-audiences"}}
+	status = reconcileresults.ReconcileResults{}
+	reconciler.createOrUpdateAudiencePolicy(context.TODO(), trait, []string{"hello.example.com"}, &status, vzlog.DefaultLogger())
+	assert.Empty(status.Relations)
+	err := cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: "test-space-test-trait-audiences"}, authzPolicy)
+	assert.True(k8serrors.IsNotFound(err))
+}
+
+// TestCreateOrUpdateClaimHeadersFilter tests the EnvoyFilter copying the claims of the tokens to request headers
+// GIVEN a trait with a JWT issuer mapping a claim to a header
+// WHEN the claim headers filter is created
+// THEN a shared Lua filter is inserted before the router of the ingress gateway, and the script setting the header
+// from the claim is set on the virtual hosts of the trait
+func TestCreateOrUpdateClaimHeadersFilter(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).Build()
+	reconciler := newIngressTraitReconciler(cli)
+	trait := newTrafficTestTrait(vzapi.IngressRule{})
+	trait.Spec.Authentication = &vzapi.IngressAuthentication{JWT: []vzapi.IngressJWTIssuer{
+		{Issuer: "https://issuer.example.com", ClaimToHeaders: []vzapi.IngressClaimToHeader{{Claim: "sub", Header: "X-User"}}},
+	}}
+
+	status := reconcileresults.ReconcileResults{}
+	reconciler.createOrUpdateClaimHeadersFilter(context.TODO(), trait, []string{"Hello.Example.com"}, &status, vzlog.DefaultLogger())
+	assert.False(status.ContainsErrors())
+	sharedFilter := &istioclient.EnvoyFilter{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: claimHeadersFilterName}, sharedFilter))
+	assert.Len(sharedFilter.Spec.ConfigPatches, 1)
+	patch := sharedFilter.Spec.ConfigPatches[0]
+	assert.Equal(istionet.EnvoyFilter_HTTP_FILTER, patch.ApplyTo)
+	assert.Equal(istionet.EnvoyFilter_Patch_INSERT_BEFORE, patch.Patch.Operation)
+	assert.Equal("envoy.filters.http.router", patch.Match.GetListener().FilterChain.Filter.SubFilter.Name)
+
+	filter := &istioclient.EnvoyFilter{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: "test-space-test-trait-claims"}, filter))
+	assert.Equal("true", filter.Labels[claimHeadersFilterLabel])
+	assert.Len(filter.Spec.ConfigPatches, 1)
+	patch = filter.Spec.ConfigPatches[0]
+	assert.Equal(istionet.EnvoyFilter_VIRTUAL_HOST, patch.ApplyTo)
+	assert.Equal(istionet.EnvoyFilter_Patch_MERGE, patch.Patch.Operation)
+	assert.Equal("hello.example.com:443", patch.Match.GetRouteConfiguration().Vhost.Name)
+	script := patch.Patch.Value.Fields["typed_per_filter_config"].GetStructValue().Fields[luaFilter].GetStructValue().
+		Fields["source_code"].GetStructValue().Fields["inline_string"].GetStringValue()
+	assert.Contains(script, `{issuer = "https://issuer.example.com", claim = "sub", header = "x-user"}`)
+	assert.Contains(script, `dynamicMetadata():get("envoy.filters.http.jwt_authn")`)
+
+	// Without claim mappings, the filter created by an earlier reconcile is deleted, with the unused shared filter
+	trait.Spec.Authentication.JWT[0].ClaimToHeaders = nil
+	trait.Status.Resources = []oamrt.TypedReference{{APIVersion: envoyFilterAPIVersion, Kind: envoyFilterKind, Name: "test-space-test-trait-claims"}}
+	status = reconcileresults.ReconcileResults{}
+	reconciler.createOrUpdateClaimHeadersFilter(context.TODO(), trait, []string{"hello.example.com"}, &status, vzlog.DefaultLogger())
+	err := cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: "test-space-test-trait-claims"}, filter)
+	assert.True(k8serrors.IsNotFound(err))
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: claimHeadersFilterName}, sharedFilter)
+	assert.True(k8serrors.IsNotFound(err))
+}
+
+// TestCreateClientCAReconcileRequests tests the reconcile requests of a client CA secret change
+// GIVEN traits requiring client certificates from different secrets, and a trait without client certificates
+// WHEN a client CA secret changes
+// THEN only the traits using that secret are reconciled
+func TestCreateClientCAReconcileRequests(t *testing.T) {
+	mutualTrait := newTrafficTestTrait(vzapi.IngressRule{})
+	mutualTrait.Spec.TLS = vzapi.IngressSecurity{SecretName: "hello-tls", Mode: vzapi.TLSModeMutual, ClientCASecretName: "hello-ca"}
+	otherTrait := newTrafficTestTrait(vzapi.IngressRule{})
+	otherTrait.Name = "other-trait"
+	otherTrait.Spec.TLS = vzapi.IngressSecurity{SecretName: "hello-tls", Mode: vzapi.TLSModeMutual, ClientCASecretName: "other-ca"}
+	simpleTrait := newTrafficTestTrait(vzapi.IngressRule{})
+	simpleTrait.Name = "simple-trait"
+	simpleTrait.Spec.TLS = vzapi.IngressSecurity{SecretName: "hello-ca"}
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(mutualTrait, otherTrait, simpleTrait).Build()
+	reconciler := newIngressTraitReconciler(cli)
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: trafficTestNamespace, Name: "hello-ca"}}
+	requests := reconciler.createClientCAReconcileRequests(secret)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, types.NamespacedName{Namespace: trafficTestNamespace, Name: mutualTrait.Name}, requests[0].NamespacedName)
+
+	secret.Namespace = "other-space"
+	assert.Empty(t, reconciler.createClientCAReconcileRequests(secret))
+}
@@ -186,7 +186,7 @@ func (r *Reconciler) doReconcile(ctx context.Context, trait *vzapi.IngressTrait,
 	}
 
 	// Create or update the child resources of the trait and collect the outcomes.
-	status, result, err := r.createOrUpdateChildResources(ctx, trait, log)
+	status, trafficSplits, result, err := r.createOrUpdateChildResources(ctx, trait, log)
 	if err != nil {
 		return reconcile.Result{}, err
 	} else if result.Requeue {
@@ -194,7 +194,7 @@ func (r *Reconciler) doReconcile(ctx context.Context, trait *vzapi.IngressTrait,
 	}
 
 	// Update the status of the trait resource using the outcomes of the create or update.
-	return r.updateTraitStatus(ctx, trait, status)
+	return r.updateTraitStatus(ctx, trait, status, trafficSplits)
 }
 
 // isIngressTraitBeingDeleted determines if the ingress trait is in the process of being deleted.
@@ -235,8 +235,13 @@ func (r *Reconciler) addFinalizerIfRequired(ctx context.Context, trait *vzapi.In
 //	1 Gateway per Application
 //	1 Gateway server per IngressTrait
 //	1 VirtualService per IngressTrait rule
-func (r *Reconciler) createOrUpdateChildResources(ctx context.Context, trait *vzapi.IngressTrait, log vzlog.VerrazzanoLogger) (*reconcileresults.ReconcileResults, ctrl.Result, error) {
+//	1 rate limit EnvoyFilter per IngressTrait rule with rate limited paths
+//	1 DestinationRule per destination host of an IngressTrait rule with subsets or a traffic policy
+//
+// The effective weights of the rules with weighted destinations are returned along with the results.
+func (r *Reconciler) createOrUpdateChildResources(ctx context.Context, trait *vzapi.IngressTrait, log vzlog.VerrazzanoLogger) (*reconcileresults.ReconcileResults, []vzapi.IngressTrafficSplitStatus, ctrl.Result, error) {
 	status := reconcileresults.ReconcileResults{}
+	var trafficSplits []vzapi.IngressTrafficSplitStatus
 	rules := trait.Spec.Rules
 	// If there are no rules, create a single default rule
 	if len(rules) == 0 {
@@ -256,17 +261,37 @@ func (r *Reconciler) createOrUpdateChildResources(ctx context.Context, trait *vz
 			// - Must create GW before service so that external DNS sees the GW once the service is created
 			gateway, err := r.createOrUpdateGateway(ctx, trait, allHostsForTrait, gwName, secretName, &status, log)
 			if err != nil {
-				return &status, ctrl.Result{}, err
+				return &status, nil, ctrl.Result{}, err
 			}
+			r.createOrUpdateGatewayCASecret(ctx, trait, secretName, &status, log)
+			r.createOrUpdateRequestAuthentication(ctx, trait, &status, log)
+			r.createOrUpdateAudiencePolicy(ctx, trait, allHostsForTrait, &status, log)
+			r.createOrUpdateClaimHeadersFilter(ctx, trait, allHostsForTrait, &status, log)
+			// The rate limit filters of the rules, the filters of the removed rules are deleted
+			rateLimitFilters := map[string]bool{}
 			for index, rule := range rules {
 				// Find the services associated with the trait in the application configuration.
 				var services []*corev1.Service
 				services, err := r.fetchServicesFromTrait(ctx, trait, log)
 				if err != nil {
-					return &status, reconcile.Result{}, err
+					return &status, nil, reconcile.Result{}, err
 				} else if len(services) == 0 {
 					// This will be the case if the service has not started yet so we requeue and try again.
-					return &status, reconcile.Result{Requeue: true, RequeueAfter: clusters.GetRandomRequeueDelay()}, err
+					return &status, nil, reconcile.Result{Requeue: true, RequeueAfter: clusters.GetRandomRequeueDelay()}, err
+				}
+
+				// Resolve the weighted destinations of the rule.  The child resources of the rule are left unchanged
+				// until the destinations can be resolved.
+				var destinations []*istionet.HTTPRouteDestination
+				if len(rule.Destinations) > 0 {
+					destinations, err = r.resolveWeightedDestinations(ctx, trait, rule, services, log)
+					if err != nil {
+						status.Errors = append(status.Errors, err)
+						log.Errorf("Failed to resolve the weighted destinations of the ingress rule %d: %v", index, err)
+						rateLimitFilters[buildRateLimitFilterName(trait, index)] = true
+						continue
+					}
+					trafficSplits = append(trafficSplits, createTrafficSplitStatus(index, rule, destinations))
 				}
 
 				// Get the list of hosts for this rule.  A virtual service can have the same hosts as another virtual service
@@ -280,13 +305,18 @@ func (r *Reconciler) createOrUpdateChildResources(ctx context.Context, trait *vz
 				vsName := fmt.Sprintf("%s-rule-%d-vs", trait.Name, index)
 				drName := fmt.Sprintf("%s-rule-%d-dr", trait.Name, index)
 				authzPolicyName := fmt.Sprintf("%s-rule-%d-authz", trait.Name, index)
-				r.createOrUpdateVirtualService(ctx, trait, rule, vsHosts, vsName, services, gateway, &status, log)
-				r.createOrUpdateDestinationRule(ctx, trait, rule, drName, &status, log, services)
+				rateLimitName := buildRateLimitFilterName(trait, index)
+				r.createOrUpdateVirtualService(ctx, trait, rule, vsHosts, vsName, services, destinations, gateway, &status, log)
+				r.createOrUpdateDestinationRule(ctx, trait, rule, drName, &status, log, services, destinations)
 				r.createOrUpdateAuthorizationPolicies(ctx, trait, rule, authzPolicyName, allHostsForTrait, &status, log)
+				if r.createOrUpdateRateLimitFilter(ctx, trait, rule, vsName, rateLimitName, &status, log) {
+					rateLimitFilters[rateLimitName] = true
+				}
 			}
+			r.deleteStaleRateLimitFilters(ctx, trait, rateLimitFilters, &status, log)
 		}
 	}
-	return &status, ctrl.Result{}, nil
+	return &status, trafficSplits, ctrl.Result{}, nil
 }
 
 func (r *Reconciler) coallateAllHostsForTrait(trait *vzapi.IngressTrait, status reconcileresults.ReconcileResults) []string {
@@ -340,12 +370,13 @@ func buildLegacyCertificateSecretName(trait *vzapi.IngressTrait) string {
 }
 
 // updateTraitStatus updates the trait's status conditions and resources if they have changed.
-func (r *Reconciler) updateTraitStatus(ctx context.Context, trait *vzapi.IngressTrait, status *reconcileresults.ReconcileResults) (reconcile.Result, error) {
+func (r *Reconciler) updateTraitStatus(ctx context.Context, trait *vzapi.IngressTrait, status *reconcileresults.ReconcileResults, trafficSplits []vzapi.IngressTrafficSplitStatus) (reconcile.Result, error) {
 	resources := status.CreateResources()
-	if status.ContainsErrors() || !reflect.DeepEqual(trait.Status.Resources, resources) {
+	if status.ContainsErrors() || !reflect.DeepEqual(trait.Status.Resources, resources) || !reflect.DeepEqual(trait.Status.TrafficSplits, trafficSplits) {
 		trait.Status = vzapi.IngressTraitStatus{
 			ConditionedStatus: status.CreateConditionedStatus(),
-			Resources:         resources}
+			Resources:         resources,
+			TrafficSplits:     trafficSplits}
 		// Requeue to prevent potential conflict errors being logged.
 		return reconcile.Result{Requeue: true}, r.Status().Update(ctx, trait)
 	}
@@ -463,7 +494,7 @@ func (r *Reconciler) fetchChildResourcesByAPIVersionKinds(ctx context.Context, n
 func (r *Reconciler) createOrUseGatewaySecret(ctx context.Context, trait *vzapi.IngressTrait, hostsForTrait []string, status *reconcileresults.ReconcileResults, log vzlog.VerrazzanoLogger) string {
 	var secretName string
 
-	if trait.Spec.TLS != (vzapi.IngressSecurity{}) {
+	if len(trait.Spec.TLS.SecretName) > 0 {
 		secretName = r.validateConfiguredSecret(trait, status)
 	} else {
 		cleanupCert(buildLegacyCertificateName(trait), r.Client, log)
@@ -598,6 +629,10 @@ func (r *Reconciler) mutateGateway(gateway *istioclient.Gateway, trait *vzapi.In
 			CredentialName: secretName,
 		},
 	}
+	// With mutual TLS, the gateway reads the client certificate authorities from the "<secretName>-cacert" secret
+	if trait.Spec.TLS.Mode == vzapi.TLSModeMutual {
+		server.Tls.Mode = istionet.ServerTLSSettings_MUTUAL
+	}
 	gateway.Spec.Servers = r.updateGatewayServersList(gateway.Spec.Servers, server)
 
 	// Set the spec content.
@@ -661,9 +696,10 @@ func findHost(hosts []string, newHost string) (int, bool) {
 }
 
 // createOrUpdateVirtualService creates or updates the VirtualService child resource of the trait.
+// The destinations are the resolved weighted destinations of the rule, if any.
 // Results are added to the status object.
 func (r *Reconciler) createOrUpdateVirtualService(ctx context.Context, trait *vzapi.IngressTrait, rule vzapi.IngressRule,
-	allHostsForTrait []string, name string, services []*corev1.Service, gateway *istioclient.Gateway,
+	allHostsForTrait []string, name string, services []*corev1.Service, destinations []*istionet.HTTPRouteDestination, gateway *istioclient.Gateway,
 	status *reconcileresults.ReconcileResults, log vzlog.VerrazzanoLogger) {
 	// Create a virtual service populating only name metadata.
 	// This is used as default if the virtual service needs to be created.
@@ -676,7 +712,7 @@ func (r *Reconciler) createOrUpdateVirtualService(ctx context.Context, trait *vz
 			Name:      name}}
 
 	res, err := common.CreateOrUpdateProtobuf(ctx, r.Client, virtualService, func() error {
-		return r.mutateVirtualService(virtualService, trait, rule, allHostsForTrait, services, gateway)
+		return r.mutateVirtualService(virtualService, trait, rule, allHostsForTrait, services, destinations, gateway)
 	})
 
 	ref := vzapi.QualifiedResourceRelation{APIVersion: virtualServiceAPIVersion, Kind: virtualServiceKind, Name: name, Role: "virtualservice"}
@@ -690,34 +726,70 @@ func (r *Reconciler) createOrUpdateVirtualService(ctx context.Context, trait *vz
 }
 
 // mutateVirtualService mutates the output virtual service resource
-func (r *Reconciler) mutateVirtualService(virtualService *istioclient.VirtualService, trait *vzapi.IngressTrait, rule vzapi.IngressRule, allHostsForTrait []string, services []*corev1.Service, gateway *istioclient.Gateway) error {
+func (r *Reconciler) mutateVirtualService(virtualService *istioclient.VirtualService, trait *vzapi.IngressTrait, rule vzapi.IngressRule, allHostsForTrait []string, services []*corev1.Service, destinations []*istionet.HTTPRouteDestination, gateway *istioclient.Gateway) error {
 	// Set the spec content.
-	var err error
 	virtualService.Spec.Gateways = []string{gateway.Name}
 	virtualService.Spec.Hosts = allHostsForTrait // We may set this multiple times if there are multiple rules, but should be OK
-	matches := []*istionet.HTTPMatchRequest{}
+	// The requests are split between the weighted destinations of the rule, if any
+	if len(destinations) == 0 {
+		dest, err := createDestinationFromRuleOrService(rule, services)
+		if err != nil {
+			return err
+		}
+		destinations = []*istionet.HTTPRouteDestination{dest}
+	}
 	paths := getPathsFromRule(rule)
+	// The paths share a single route, unless a path has traffic management settings.  In that case, each path has a
+	// route of its own, in the order of the paths.
+	routeSettings := false
 	for _, path := range paths {
-		matches = append(matches, &istionet.HTTPMatchRequest{
-			Uri: createVirtualServiceMatchURIFromIngressTraitPath(path)})
+		if hasRouteSettings(path) {
+			routeSettings = true
+			break
+		}
 	}
-	dest, err := createDestinationFromRuleOrService(rule, services)
-	if err != nil {
-		return err
+	pathGroups := [][]vzapi.IngressPath{paths}
+	if routeSettings {
+		pathGroups = nil
+		for _, path := range paths {
+			pathGroups = append(pathGroups, []vzapi.IngressPath{path})
+		}
 	}
-	route := istionet.HTTPRoute{
-		Match: matches,
-		Route: []*istionet.HTTPRouteDestination{dest}}
-	if vznav.IsWeblogicWorkloadKind(trait) {
-		route.Headers = &istionet.Headers{
-			Request: &istionet.Headers_HeaderOperations{
-				Add: map[string]string{
-					wlProxySSLHeader: wlProxySSLHeaderVal,
-				},
-			},
+	virtualService.Spec.Http = nil
+	for i, group := range pathGroups {
+		matches := []*istionet.HTTPMatchRequest{}
+		for _, path := range group {
+			matches = append(matches, &istionet.HTTPMatchRequest{
+				Uri: createVirtualServiceMatchURIFromIngressTraitPath(path)})
+		}
+		routeName := ""
+		if routeSettings {
+			routeName = buildRouteName(trait.Namespace, virtualService.Name, i)
 		}
+		// The requests with the headers of a weighted destination are routed to it before the weights apply
+		routes := createHeaderRoutes(rule, matches, destinations, routeName)
+		routes = append(routes, &istionet.HTTPRoute{
+			Name:  routeName,
+			Match: matches,
+			Route: destinations})
+		for _, route := range routes {
+			if vznav.IsWeblogicWorkloadKind(trait) {
+				route.Headers = &istionet.Headers{
+					Request: &istionet.Headers_HeaderOperations{
+						Add: map[string]string{
+							wlProxySSLHeader: wlProxySSLHeaderVal,
+						},
+					},
+				}
+			}
+			if routeSettings {
+				if err := applyRouteSettings(route, group[0]); err != nil {
+					return err
+				}
+			}
+		}
+		virtualService.Spec.Http = append(virtualService.Spec.Http, routes...)
 	}
-	virtualService.Spec.Http = []*istionet.HTTPRoute{&route}
 
 	// Set the owner reference.
 	_ = controllerutil.SetControllerReference(trait, virtualService, r.Scheme)
@@ -725,39 +797,64 @@ func (r *Reconciler) mutateVirtualService(virtualService *istioclient.VirtualSer
 }
 
 // createOfUpdateDestinationRule creates or updates the DestinationRule.
-func (r *Reconciler) createOrUpdateDestinationRule(ctx context.Context, trait *vzapi.IngressTrait, rule vzapi.IngressRule, name string, status *reconcileresults.ReconcileResults, log vzlog.VerrazzanoLogger, services []*corev1.Service) {
-	if rule.Destination.HTTPCookie != nil {
-		destinationRule := &istioclient.DestinationRule{
-			TypeMeta: metav1.TypeMeta{
-				APIVersion: destinationRuleAPIVersion,
-				Kind:       destinationRuleKind},
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: trait.Namespace,
-				Name:      name},
+// When the rule has weighted destinations, a DestinationRule is created for each host of the destinations which has
+// subsets, or when the rule has a traffic policy.  The DestinationRules of the additional hosts are suffixed with
+// the index of the host.
+func (r *Reconciler) createOrUpdateDestinationRule(ctx context.Context, trait *vzapi.IngressTrait, rule vzapi.IngressRule, name string, status *reconcileresults.ReconcileResults, log vzlog.VerrazzanoLogger, services []*corev1.Service, destinations []*istionet.HTTPRouteDestination) {
+	if len(destinations) == 0 {
+		if hasDestinationTrafficPolicy(rule) {
+			r.createOrUpdateHostDestinationRule(ctx, trait, rule, name, nil, status, log, services)
 		}
-		namespace := &corev1.Namespace{}
-		namespaceErr := r.Client.Get(ctx, client.ObjectKey{Namespace: "", Name: trait.Namespace}, namespace)
-		if namespaceErr != nil {
-			log.Errorf("Failed to retrieve namespace resource: %v", namespaceErr)
+		return
+	}
+	for i, host := range getDestinationHosts(destinations) {
+		subsets := createDestinationSubsets(rule, destinations, host)
+		if len(subsets) == 0 && !hasDestinationTrafficPolicy(rule) {
+			continue
+		}
+		hostRule := rule
+		hostRule.Destination.Host = host
+		drName := name
+		if i > 0 {
+			drName = fmt.Sprintf("%s-%d", name, i)
 		}
+		r.createOrUpdateHostDestinationRule(ctx, trait, hostRule, drName, subsets, status, log, services)
+	}
+}
 
-		res, err := common.CreateOrUpdateProtobuf(ctx, r.Client, destinationRule, func() error {
-			return r.mutateDestinationRule(destinationRule, trait, rule, services, namespace)
-		})
+// createOrUpdateHostDestinationRule creates or updates the DestinationRule of the destination host of a rule.
+// Results are added to the status object.
+func (r *Reconciler) createOrUpdateHostDestinationRule(ctx context.Context, trait *vzapi.IngressTrait, rule vzapi.IngressRule, name string, subsets []*istionet.Subset, status *reconcileresults.ReconcileResults, log vzlog.VerrazzanoLogger, services []*corev1.Service) {
+	destinationRule := &istioclient.DestinationRule{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: destinationRuleAPIVersion,
+			Kind:       destinationRuleKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: trait.Namespace,
+			Name:      name},
+	}
+	namespace := &corev1.Namespace{}
+	namespaceErr := r.Client.Get(ctx, client.ObjectKey{Namespace: "", Name: trait.Namespace}, namespace)
+	if namespaceErr != nil {
+		log.Errorf("Failed to retrieve namespace resource: %v", namespaceErr)
+	}
 
-		ref := vzapi.QualifiedResourceRelation{APIVersion: destinationRuleAPIVersion, Kind: destinationRuleKind, Name: name, Role: "destinationrule"}
-		status.Relations = append(status.Relations, ref)
-		status.Results = append(status.Results, res)
-		status.Errors = append(status.Errors, err)
+	res, err := common.CreateOrUpdateProtobuf(ctx, r.Client, destinationRule, func() error {
+		return r.mutateDestinationRule(destinationRule, trait, rule, services, subsets, namespace)
+	})
 
-		if err != nil {
-			log.Errorf("Failed to create or update destination rule: %v", err)
-		}
+	ref := vzapi.QualifiedResourceRelation{APIVersion: destinationRuleAPIVersion, Kind: destinationRuleKind, Name: name, Role: "destinationrule"}
+	status.Relations = append(status.Relations, ref)
+	status.Results = append(status.Results, res)
+	status.Errors = append(status.Errors, err)
+
+	if err != nil {
+		log.Errorf("Failed to create or update destination rule: %v", err)
 	}
 }
 
 // mutateDestinationRule changes the destination rule based upon a traits configuration
-func (r *Reconciler) mutateDestinationRule(destinationRule *istioclient.DestinationRule, trait *vzapi.IngressTrait, rule vzapi.IngressRule, services []*corev1.Service, namespace *corev1.Namespace) error {
+func (r *Reconciler) mutateDestinationRule(destinationRule *istioclient.DestinationRule, trait *vzapi.IngressTrait, rule vzapi.IngressRule, services []*corev1.Service, subsets []*istionet.Subset, namespace *corev1.Namespace) error {
 	dest, err := createDestinationFromRuleOrService(rule, services)
 	if err != nil {
 		return err
@@ -769,24 +866,39 @@ func (r *Reconciler) mutateDestinationRule(destinationRule *istioclient.Destinat
 		mode = istionet.ClientTLSSettings_ISTIO_MUTUAL
 	}
 	destinationRule.Spec = istionet.DestinationRule{
-		Host: dest.Destination.Host,
+		Host:    dest.Destination.Host,
+		Subsets: subsets,
 		TrafficPolicy: &istionet.TrafficPolicy{
 			Tls: &istionet.ClientTLSSettings{
 				Mode: mode,
 			},
-			LoadBalancer: &istionet.LoadBalancerSettings{
-				LbPolicy: &istionet.LoadBalancerSettings_ConsistentHash{
-					ConsistentHash: &istionet.LoadBalancerSettings_ConsistentHashLB{
-						HashKey: &istionet.LoadBalancerSettings_ConsistentHashLB_HttpCookie{
-							HttpCookie: &istionet.LoadBalancerSettings_ConsistentHashLB_HTTPCookie{
-								Name: rule.Destination.HTTPCookie.Name,
-								Path: rule.Destination.HTTPCookie.Path,
-								Ttl:  durationpb.New(rule.Destination.HTTPCookie.TTL * time.Second)},
-						},
+		},
+	}
+	if rule.Destination.HTTPCookie != nil {
+		destinationRule.Spec.TrafficPolicy.LoadBalancer = &istionet.LoadBalancerSettings{
+			LbPolicy: &istionet.LoadBalancerSettings_ConsistentHash{
+				ConsistentHash: &istionet.LoadBalancerSettings_ConsistentHashLB{
+					HashKey: &istionet.LoadBalancerSettings_ConsistentHashLB_HttpCookie{
+						HttpCookie: &istionet.LoadBalancerSettings_ConsistentHashLB_HTTPCookie{
+							Name: rule.Destination.HTTPCookie.Name,
+							Path: rule.Destination.HTTPCookie.Path,
+							Ttl:  durationpb.New(rule.Destination.HTTPCookie.TTL * time.Second)},
 					},
 				},
 			},
-		},
+		}
+	}
+	if rule.Destination.ConnectionPool != nil {
+		destinationRule.Spec.TrafficPolicy.ConnectionPool, err = createConnectionPoolSettings(rule.Destination.ConnectionPool)
+		if err != nil {
+			return err
+		}
+	}
+	if rule.Destination.OutlierDetection != nil {
+		destinationRule.Spec.TrafficPolicy.OutlierDetection, err = createOutlierDetection(rule.Destination.OutlierDetection)
+		if err != nil {
+			return err
+		}
 	}
 
 	return controllerutil.SetControllerReference(trait, destinationRule, r.Scheme)
@@ -975,7 +1087,14 @@ func (r *Reconciler) setupWatches() error {
 	if err != nil {
 		return err
 	}
-	return nil
+	// Set up a watch on the secrets to copy the changes of the client certificate authorities of the traits requiring
+	// client certificates to the ingress gateway
+	return r.Controller.Watch(
+		&source.Kind{Type: &corev1.Secret{}},
+		handler.EnqueueRequestsFromMapFunc(func(a client.Object) []reconcile.Request {
+			return r.createClientCAReconcileRequests(a)
+		}),
+	)
 }
 
 // isConsoleIngressUpdated Predicate func used by the Ingress watcher, returns true if the TLS settings have changed;
@@ -1032,6 +1151,34 @@ func (r *Reconciler) createIngressTraitReconcileRequests() []reconcile.Request {
 	return requests
 }
 
+// createClientCAReconcileRequests creates the requests to reconcile the ingress traits using the given secret as the
+// client certificate authorities
+func (r *Reconciler) createClientCAReconcileRequests(secret client.Object) []reconcile.Request {
+	requests := []reconcile.Request{}
+
+	ingressTraitList := vzapi.IngressTraitList{}
+	if err := r.List(context.TODO(), &ingressTraitList, client.InNamespace(secret.GetNamespace())); err != nil {
+		r.Log.Errorf("Failed to list ingress traits in namespace %s: %v", secret.GetNamespace(), err)
+		return requests
+	}
+
+	for _, ingressTrait := range ingressTraitList.Items {
+		if ingressTrait.Spec.TLS.Mode != vzapi.TLSModeMutual || ingressTrait.Spec.TLS.ClientCASecretName != secret.GetName() {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: ingressTrait.Namespace,
+				Name:      ingressTrait.Name,
+			},
+		})
+	}
+	if len(requests) > 0 {
+		r.Log.Infof("Client CA secret %s/%s has changed, requesting ingress trait reconcile: %v", secret.GetNamespace(), secret.GetName(), requests)
+	}
+	return requests
+}
+
 // createDestinationFromRuleOrService creates a destination from either the rule or the service.
 // If the rule contains destination information that is used.
 // Otherwise, the appropriate service is selected and its information is used.
@@ -2294,7 +2294,7 @@ func TestIngressTraitHostsForVirtualServiceAndGateway(t *testing.T) {
 
 			// Reconcile each trait
 			for i, trait := range test.traits {
-				_, _, err := r.createOrUpdateChildResources(context.TODO(), test.traits[i], vzlog.DefaultLogger())
+				_, _, _, err := r.createOrUpdateChildResources(context.TODO(), test.traits[i], vzlog.DefaultLogger())
 				assert.NoError(err)
 
 				// Every trait rule must have a VS with all the hosts.  This test must use explicit hosts
@@ -3563,6 +3563,7 @@ func newScheme() *runtime.Scheme {
 	_ = certapiv1.AddToScheme(scheme)
 	_ = k8net.AddToScheme(scheme)
 	_ = istioclient.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
 	_ = v1alpha2.SchemeBuilder.AddToScheme(scheme)
 
 	return scheme
@@ -4304,7 +4305,7 @@ func TestMutateGatewayAddTrait(t *testing.T) {
 	}
 
 	reconciler := setupTraitTestFakes(appName, gw)
-	_, _, err := reconciler.createOrUpdateChildResources(context.TODO(), trait, vzlog.DefaultLogger())
+	_, _, _, err := reconciler.createOrUpdateChildResources(context.TODO(), trait, vzlog.DefaultLogger())
 	assert.NoError(err)
 
 	updatedGateway := &istioclient.Gateway{}
@@ -4376,7 +4377,7 @@ func TestMutateGatewayHostsAddRemoveTraitRule(t *testing.T) {
 		},
 	}
 
-	_, _, err := reconciler.createOrUpdateChildResources(context.TODO(), updatedTrait, vzlog.DefaultLogger())
+	_, _, _, err := reconciler.createOrUpdateChildResources(context.TODO(), updatedTrait, vzlog.DefaultLogger())
 	assert.NoError(err)
 
 	updatedGateway := &istioclient.Gateway{}
@@ -4402,7 +4403,7 @@ func TestMutateGatewayHostsAddRemoveTraitRule(t *testing.T) {
 			WorkloadReference: createWorkloadReference(appName),
 		},
 	}
-	_, _, err2 := reconciler.createOrUpdateChildResources(context.TODO(), updatedTraitRemovedRule, vzlog.DefaultLogger())
+	_, _, _, err2 := reconciler.createOrUpdateChildResources(context.TODO(), updatedTraitRemovedRule, vzlog.DefaultLogger())
 	assert.NoError(err2)
 
 	updatedGatewayRemovedRule := &istioclient.Gateway{}
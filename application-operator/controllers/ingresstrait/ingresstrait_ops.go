@@ -39,6 +39,18 @@ func cleanup(trait *vzapi.IngressTrait, client client.Client, log vzlog.Verrazza
 	if err != nil {
 		return
 	}
+	err = cleanupEnvoyFilters(trait, client, log)
+	if err != nil {
+		return
+	}
+	err = cleanupRequestAuthentications(trait, client, log)
+	if err != nil {
+		return
+	}
+	err = cleanupGatewayCASecrets(trait, client, log)
+	if err != nil {
+		return
+	}
 	err = cleanupGateway(trait, client, log)
 	if err != nil {
 		return
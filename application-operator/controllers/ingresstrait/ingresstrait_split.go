@@ -0,0 +1,200 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package ingresstrait
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	vznav "github.com/verrazzano/verrazzano/application-operator/controllers/navigation"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	"google.golang.org/protobuf/proto"
+	istionet "istio.io/api/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// resolveWeightedDestinations resolves the route destinations of the weighted destinations of a rule, in the order
+// of the weighted destinations.  The weights of the route destinations are the effective weights.
+func (r *Reconciler) resolveWeightedDestinations(ctx context.Context, trait *vzapi.IngressTrait, rule vzapi.IngressRule, services []*corev1.Service, log vzlog.VerrazzanoLogger) ([]*istionet.HTTPRouteDestination, error) {
+	weights := getEffectiveWeights(rule.Destinations)
+	destinations := make([]*istionet.HTTPRouteDestination, 0, len(rule.Destinations))
+	for i, weighted := range rule.Destinations {
+		destination := vzapi.IngressDestination{Host: weighted.Host, Port: weighted.Port}
+		if destination.Port == 0 {
+			destination.Port = rule.Destination.Port
+		}
+		destinationServices := services
+		if len(weighted.Component) > 0 {
+			var err error
+			destinationServices, err = r.fetchServicesFromComponent(ctx, trait, weighted.Component, log)
+			if err != nil {
+				return nil, err
+			}
+			if len(destinationServices) == 0 {
+				return nil, fmt.Errorf("failed to find a service for the component %s of the weighted destination %s", weighted.Component, weighted.Name)
+			}
+		}
+		dest, err := createDestinationFromRuleOrService(vzapi.IngressRule{Destination: destination}, destinationServices)
+		if err != nil {
+			return nil, err
+		}
+		if len(weighted.Labels) > 0 {
+			dest.Destination.Subset = weighted.Name
+		}
+		dest.Weight = weights[i]
+		destinations = append(destinations, dest)
+	}
+	return destinations, nil
+}
+
+// getEffectiveWeights returns the effective weights of the weighted destinations.  The percentage not assigned by
+// the weights is split evenly between the destinations without a weight, the first destinations receive the remainder.
+func getEffectiveWeights(destinations []vzapi.IngressWeightedDestination) []int32 {
+	weights := make([]int32, len(destinations))
+	remaining := int32(100)
+	var unweighted []int
+	for i, destination := range destinations {
+		if destination.Weight == nil {
+			unweighted = append(unweighted, i)
+			continue
+		}
+		weights[i] = *destination.Weight
+		remaining -= *destination.Weight
+	}
+	if len(unweighted) == 0 || remaining <= 0 {
+		return weights
+	}
+	share := remaining / int32(len(unweighted))
+	extra := remaining % int32(len(unweighted))
+	for j, i := range unweighted {
+		weights[i] = share
+		if int32(j) < extra {
+			weights[i]++
+		}
+	}
+	return weights
+}
+
+// fetchServicesFromComponent finds the services of the workload of a component of the application of the trait
+func (r *Reconciler) fetchServicesFromComponent(ctx context.Context, trait *vzapi.IngressTrait, component string, log vzlog.VerrazzanoLogger) ([]*corev1.Service, error) {
+	appName, ok := trait.Labels[oam.LabelAppName]
+	if !ok {
+		return nil, fmt.Errorf("OAM app name label missing from metadata, unable to find the component %s", component)
+	}
+	appConfig := v1alpha2.ApplicationConfiguration{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: trait.Namespace, Name: appName}, &appConfig); err != nil {
+		return nil, err
+	}
+	for _, workloadStatus := range appConfig.Status.Workloads {
+		if workloadStatus.ComponentName != component {
+			continue
+		}
+		workload := &unstructured.Unstructured{}
+		workload.SetAPIVersion(workloadStatus.Reference.APIVersion)
+		workload.SetKind(workloadStatus.Reference.Kind)
+		if err := r.Get(ctx, types.NamespacedName{Namespace: trait.Namespace, Name: workloadStatus.Reference.Name}, workload); err != nil {
+			return nil, err
+		}
+		workload, err := vznav.FetchWorkloadResource(ctx, r.Client, log, workload)
+		if err != nil {
+			return nil, err
+		}
+		children, err := r.fetchWorkloadChildren(ctx, workload, log)
+		if err != nil {
+			return nil, err
+		}
+		return r.extractServicesFromUnstructuredChildren(children, log)
+	}
+	return nil, fmt.Errorf("failed to find the workload of the component %s in the application %s", component, appName)
+}
+
+// createHeaderRoutes creates the routes sending the requests with the headers of a weighted destination to the
+// destination, regardless of the weights.  The routes precede the weighted route of the paths, they are named after
+// the weighted route when it has a name.
+func createHeaderRoutes(rule vzapi.IngressRule, matches []*istionet.HTTPMatchRequest, destinations []*istionet.HTTPRouteDestination, routeName string) []*istionet.HTTPRoute {
+	var routes []*istionet.HTTPRoute
+	for i, weighted := range rule.Destinations {
+		if len(weighted.Headers) == 0 {
+			continue
+		}
+		var headerMatches []*istionet.HTTPMatchRequest
+		for _, match := range matches {
+			headerMatch := proto.Clone(match).(*istionet.HTTPMatchRequest)
+			headerMatch.Headers = map[string]*istionet.StringMatch{}
+			for name, value := range weighted.Headers {
+				headerMatch.Headers[name] = &istionet.StringMatch{MatchType: &istionet.StringMatch_Exact{Exact: value}}
+			}
+			headerMatches = append(headerMatches, headerMatch)
+		}
+		destination := proto.Clone(destinations[i]).(*istionet.HTTPRouteDestination)
+		destination.Weight = 0
+		route := &istionet.HTTPRoute{
+			Match: headerMatches,
+			Route: []*istionet.HTTPRouteDestination{destination}}
+		if len(routeName) > 0 {
+			route.Name = buildHeaderRouteName(routeName, weighted.Name)
+		}
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+// buildHeaderRouteName builds the name of the virtual service route of the requests to a path with the headers of
+// a weighted destination, from the name of the route of the path
+func buildHeaderRouteName(routeName string, destinationName string) string {
+	return fmt.Sprintf("%s-%s", routeName, destinationName)
+}
+
+// getDestinationHosts returns the distinct hosts of the route destinations, in the order of the destinations
+func getDestinationHosts(destinations []*istionet.HTTPRouteDestination) []string {
+	var hosts []string
+	for _, destination := range destinations {
+		found := false
+		for _, host := range hosts {
+			if host == destination.Destination.Host {
+				found = true
+				break
+			}
+		}
+		if !found {
+			hosts = append(hosts, destination.Destination.Host)
+		}
+	}
+	return hosts
+}
+
+// createDestinationSubsets creates the destination rule subsets of the weighted destinations of a host
+func createDestinationSubsets(rule vzapi.IngressRule, destinations []*istionet.HTTPRouteDestination, host string) []*istionet.Subset {
+	var subsets []*istionet.Subset
+	for i, weighted := range rule.Destinations {
+		if i >= len(destinations) || destinations[i].Destination.Host != host || len(weighted.Labels) == 0 {
+			continue
+		}
+		subsets = append(subsets, &istionet.Subset{Name: weighted.Name, Labels: weighted.Labels})
+	}
+	return subsets
+}
+
+// createTrafficSplitStatus creates the status reporting the effective weights of the weighted destinations of a rule
+func createTrafficSplitStatus(index int, rule vzapi.IngressRule, destinations []*istionet.HTTPRouteDestination) vzapi.IngressTrafficSplitStatus {
+	split := vzapi.IngressTrafficSplitStatus{Rule: index}
+	for i, destination := range destinations {
+		weight := vzapi.IngressDestinationWeight{
+			Name:   rule.Destinations[i].Name,
+			Host:   destination.Destination.Host,
+			Subset: destination.Destination.Subset,
+			Weight: destination.Weight,
+		}
+		if destination.Destination.Port != nil {
+			weight.Port = destination.Destination.Port.Number
+		}
+		split.Destinations = append(split.Destinations, weight)
+	}
+	return split
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package ingresstrait
+
+import (
+	"context"
+	"testing"
+
+	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/reconcileresults"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	istionet "istio.io/api/networking/v1alpha3"
+	istioclient "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestGetEffectiveWeights tests the effective weights of weighted destinations
+// GIVEN weighted destinations with and without weights
+// WHEN the effective weights are computed
+// THEN the percentage not assigned by the weights is split evenly between the destinations without a weight
+func TestGetEffectiveWeights(t *testing.T) {
+	weight := func(w int32) *int32 { return &w }
+	tests := []struct {
+		name         string
+		destinations []vzapi.IngressWeightedDestination
+		expected     []int32
+	}{
+		{name: "all weighted", destinations: []vzapi.IngressWeightedDestination{{Weight: weight(90)}, {Weight: weight(10)}}, expected: []int32{90, 10}},
+		{name: "none weighted", destinations: []vzapi.IngressWeightedDestination{{}, {}, {}}, expected: []int32{34, 33, 33}},
+		{name: "some weighted", destinations: []vzapi.IngressWeightedDestination{{}, {Weight: weight(20)}, {}}, expected: []int32{40, 20, 40}},
+		{name: "nothing remaining", destinations: []vzapi.IngressWeightedDestination{{Weight: weight(100)}, {}}, expected: []int32{100, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getEffectiveWeights(tt.destinations))
+		})
+	}
+}
+
+// TestResolveWeightedDestinations tests resolving the weighted destinations of a rule
+// GIVEN a rule with a destination selecting the pods of the service of the workload by labels, and a destination
+// which is the service of another component of the application
+// WHEN the weighted destinations are resolved
+// THEN the route destinations have the hosts of the services, the subset of the labels and the effective weights
+func TestResolveWeightedDestinations(t *testing.T) {
+	assert := assert.New(t)
+
+	canaryService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: trafficTestNamespace, Name: "hello-v2"},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 8080}}},
+	}
+	appConfig := &v1alpha2.ApplicationConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: trafficTestNamespace, Name: "hello-app"},
+		Status: v1alpha2.ApplicationConfigurationStatus{Workloads: []v1alpha2.WorkloadStatus{{
+			ComponentName: "hello-v2",
+			Reference:     oamrt.TypedReference{APIVersion: "v1", Kind: "Service", Name: "hello-v2"},
+		}}},
+	}
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(canaryService, appConfig).Build()
+
+	weight := int32(90)
+	rule := vzapi.IngressRule{
+		Destination: vzapi.IngressDestination{Port: 8001},
+		Destinations: []vzapi.IngressWeightedDestination{
+			{Name: "stable", Labels: map[string]string{"version": "v1"}, Weight: &weight},
+			{Name: "canary", Component: "hello-v2", Port: 8080},
+		},
+	}
+	trait := newTrafficTestTrait(rule)
+	trait.Labels = map[string]string{oam.LabelAppName: "hello-app"}
+	services := []*corev1.Service{{
+		ObjectMeta: metav1.ObjectMeta{Namespace: trafficTestNamespace, Name: "hello-v1"},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 8001}}},
+	}}
+
+	reconciler := newIngressTraitReconciler(cli)
+	destinations, err := reconciler.resolveWeightedDestinations(context.TODO(), trait, rule, services, vzlog.DefaultLogger())
+	assert.NoError(err)
+	assert.Len(destinations, 2)
+	assert.Equal("hello-v1", destinations[0].Destination.Host)
+	assert.Equal(uint32(8001), destinations[0].Destination.Port.Number)
+	assert.Equal("stable", destinations[0].Destination.Subset)
+	assert.Equal(int32(90), destinations[0].Weight)
+	assert.Equal("hello-v2", destinations[1].Destination.Host)
+	assert.Equal(uint32(8080), destinations[1].Destination.Port.Number)
+	assert.Empty(destinations[1].Destination.Subset)
+	assert.Equal(int32(10), destinations[1].Weight)
+
+	split := createTrafficSplitStatus(0, rule, destinations)
+	assert.Equal(vzapi.IngressTrafficSplitStatus{Rule: 0, Destinations: []vzapi.IngressDestinationWeight{
+		{Name: "stable", Host: "hello-v1", Port: 8001, Subset: "stable", Weight: 90},
+		{Name: "canary", Host: "hello-v2", Port: 8080, Weight: 10},
+	}}, split)
+
+	// A component without a workload in the application cannot be resolved
+	rule.Destinations[1].Component = "hello-v3"
+	_, err = reconciler.resolveWeightedDestinations(context.TODO(), trait, rule, services, vzlog.DefaultLogger())
+	assert.ErrorContains(err, "failed to find the workload of the component hello-v3")
+}
+
+// TestMutateVirtualServiceWithWeightedDestinations tests rendering the routes of weighted destinations
+// GIVEN a rule with a path, a stable destination and a canary destination selected by a header
+// WHEN the virtual service is mutated
+// THEN the requests with the header are routed to the canary, and the other requests are split by the weights
+func TestMutateVirtualServiceWithWeightedDestinations(t *testing.T) {
+	assert := assert.New(t)
+
+	rule := vzapi.IngressRule{
+		Paths: []vzapi.IngressPath{{Path: "/greet", PathType: "prefix"}},
+		Destinations: []vzapi.IngressWeightedDestination{
+			{Name: "stable", Labels: map[string]string{"version": "v1"}},
+			{Name: "canary", Labels: map[string]string{"version": "v2"}, Headers: map[string]string{"X-Canary": "true"}},
+		},
+	}
+	destinations := []*istionet.HTTPRouteDestination{
+		{Destination: &istionet.Destination{Host: "hello", Subset: "stable"}, Weight: 90},
+		{Destination: &istionet.Destination{Host: "hello", Subset: "canary"}, Weight: 10},
+	}
+	trait := newTrafficTestTrait(rule)
+	vs := &istioclient.VirtualService{ObjectMeta: metav1.ObjectMeta{Namespace: trafficTestNamespace, Name: "test-trait-rule-0-vs"}}
+	gw := &istioclient.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: trafficTestNamespace, Name: "test-gw"}}
+
+	reconciler := newIngressTraitReconciler(fake.NewClientBuilder().WithScheme(newScheme()).Build())
+	assert.NoError(reconciler.mutateVirtualService(vs, trait, rule, []string{"example.com"}, nil, destinations, gw))
+
+	assert.Len(vs.Spec.Http, 2)
+	headerRoute := vs.Spec.Http[0]
+	assert.Empty(headerRoute.Name)
+	assert.Equal("/greet", headerRoute.Match[0].Uri.GetPrefix())
+	assert.Equal("true", headerRoute.Match[0].Headers["X-Canary"].GetExact())
+	assert.Len(headerRoute.Route, 1)
+	assert.Equal("canary", headerRoute.Route[0].Destination.Subset)
+	assert.Equal(int32(0), headerRoute.Route[0].Weight)
+
+	weightedRoute := vs.Spec.Http[1]
+	assert.Nil(weightedRoute.Match[0].Headers)
+	assert.Len(weightedRoute.Route, 2)
+	assert.Equal(int32(90), weightedRoute.Route[0].Weight)
+	assert.Equal(int32(10), weightedRoute.Route[1].Weight)
+
+	// With route settings, the routes are named after the path and have the settings of the path
+	rule.Paths[0].Timeout = "5s"
+	assert.NoError(reconciler.mutateVirtualService(vs, trait, rule, []string{"example.com"}, nil, destinations, gw))
+	assert.Len(vs.Spec.Http, 2)
+	assert.Equal("test-space-test-trait-rule-0-vs-path-0-canary", vs.Spec.Http[0].Name)
+	assert.Equal("test-space-test-trait-rule-0-vs-path-0", vs.Spec.Http[1].Name)
+	assert.NotNil(vs.Spec.Http[0].Timeout)
+	assert.NotNil(vs.Spec.Http[1].Timeout)
+}
+
+// TestCreateOrUpdateDestinationRuleWithSubsets tests the destination rules of weighted destinations
+// GIVEN a rule with weighted destinations on two hosts, one of them with subsets
+// WHEN the destination rules are created
+// THEN a destination rule with the subsets is created for the host with subsets only
+func TestCreateOrUpdateDestinationRuleWithSubsets(t *testing.T) {
+	assert := assert.New(t)
+
+	rule := vzapi.IngressRule{
+		Destinations: []vzapi.IngressWeightedDestination{
+			{Name: "stable", Labels: map[string]string{"version": "v1"}},
+			{Name: "canary", Labels: map[string]string{"version": "v2"}},
+			{Name: "other", Host: "other"},
+		},
+	}
+	destinations := []*istionet.HTTPRouteDestination{
+		{Destination: &istionet.Destination{Host: "hello", Subset: "stable"}, Weight: 80},
+		{Destination: &istionet.Destination{Host: "hello", Subset: "canary"}, Weight: 10},
+		{Destination: &istionet.Destination{Host: "other"}, Weight: 10},
+	}
+	trait := newTrafficTestTrait(rule)
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).Build()
+	reconciler := newIngressTraitReconciler(cli)
+
+	status := reconcileresults.ReconcileResults{}
+	reconciler.createOrUpdateDestinationRule(context.TODO(), trait, rule, "test-trait-rule-0-dr", &status, vzlog.DefaultLogger(), nil, destinations)
+	assert.False(status.ContainsErrors())
+	assert.Len(status.Relations, 1)
+
+	dr := &istioclient.DestinationRule{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: trafficTestNamespace, Name: "test-trait-rule-0-dr"}, dr))
+	assert.Equal("hello", dr.Spec.Host)
+	assert.Len(dr.Spec.Subsets, 2)
+	assert.Equal("stable", dr.Spec.Subsets[0].Name)
+	assert.Equal("v1", dr.Spec.Subsets[0].Labels["version"])
+	assert.Equal("canary", dr.Spec.Subsets[1].Name)
+
+	// With a traffic policy, the other host has a destination rule too
+	rule.Destination.OutlierDetection = &vzapi.IngressOutlierDetection{Consecutive5xxErrors: 5}
+	status = reconcileresults.ReconcileResults{}
+	reconciler.createOrUpdateDestinationRule(context.TODO(), trait, rule, "test-trait-rule-0-dr", &status, vzlog.DefaultLogger(), nil, destinations)
+	assert.False(status.ContainsErrors())
+	assert.Len(status.Relations, 2)
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: trafficTestNamespace, Name: "test-trait-rule-0-dr-1"}, dr))
+	assert.Equal("other", dr.Spec.Host)
+	assert.Empty(dr.Spec.Subsets)
+	assert.Equal(uint32(5), dr.Spec.TrafficPolicy.OutlierDetection.Consecutive_5XxErrors.GetValue())
+}
@@ -0,0 +1,465 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package ingresstrait
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/reconcileresults"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	istionet "istio.io/api/networking/v1alpha3"
+	istioclient "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	envoyFilterAPIVersion = "networking.istio.io/v1alpha3"
+	envoyFilterKind       = "EnvoyFilter"
+	// localRateLimitFilterName is the name of the EnvoyFilter adding the local rate limit HTTP filter to the
+	// ingress gateway.  The filter does nothing until a route enables it, it is shared by all the ingress traits.
+	localRateLimitFilterName = "verrazzano-local-ratelimit"
+	localRateLimitFilter     = "envoy.filters.http.local_ratelimit"
+	localRateLimitTypeURL    = "type.googleapis.com/envoy.extensions.filters.http.local_ratelimit.v3.LocalRateLimit"
+	typedStructTypeURL       = "type.googleapis.com/udpa.type.v1.TypedStruct"
+	localRateLimitStatPrefix = "http_local_rate_limiter"
+	// rateLimitFilterLabel labels the EnvoyFilters enabling the local rate limit on the routes of an ingress rule.  The
+	// shared local rate limit filter is deleted when no EnvoyFilter has the label.
+	rateLimitFilterLabel = "verrazzano.io/ingress-ratelimit"
+)
+
+// hasRouteSettings returns true when the path has settings which require a route of its own in the virtual service
+func hasRouteSettings(path vzapi.IngressPath) bool {
+	return len(path.Timeout) > 0 || path.Retries != nil || path.CORS != nil || path.Headers != nil || path.RateLimit != nil
+}
+
+// buildRouteName builds the name of the virtual service route of a path.  The name is also the name of the route in
+// the ingress gateway configuration, so it includes the namespace to be unique across the applications.
+func buildRouteName(namespace string, vsName string, pathIndex int) string {
+	return fmt.Sprintf("%s-%s-path-%d", namespace, vsName, pathIndex)
+}
+
+// applyRouteSettings sets the timeout, retries, CORS policy and header manipulations of a path on its route
+func applyRouteSettings(route *istionet.HTTPRoute, path vzapi.IngressPath) error {
+	if len(path.Timeout) > 0 {
+		timeout, err := time.ParseDuration(path.Timeout)
+		if err != nil {
+			return err
+		}
+		route.Timeout = durationpb.New(timeout)
+	}
+	if path.Retries != nil {
+		route.Retries = &istionet.HTTPRetry{Attempts: path.Retries.Attempts, RetryOn: path.Retries.RetryOn}
+		if len(path.Retries.PerTryTimeout) > 0 {
+			perTryTimeout, err := time.ParseDuration(path.Retries.PerTryTimeout)
+			if err != nil {
+				return err
+			}
+			route.Retries.PerTryTimeout = durationpb.New(perTryTimeout)
+		}
+	}
+	if path.CORS != nil {
+		cors, err := createCorsPolicy(path.CORS)
+		if err != nil {
+			return err
+		}
+		route.CorsPolicy = cors
+	}
+	if path.Headers != nil {
+		if route.Headers == nil {
+			route.Headers = &istionet.Headers{}
+		}
+		route.Headers.Request = mergeHeaderOperations(route.Headers.Request, path.Headers.Request)
+		route.Headers.Response = mergeHeaderOperations(route.Headers.Response, path.Headers.Response)
+	}
+	return nil
+}
+
+// createCorsPolicy creates the Istio CORS policy of a path
+func createCorsPolicy(policy *vzapi.IngressCORSPolicy) (*istionet.CorsPolicy, error) {
+	cors := &istionet.CorsPolicy{
+		AllowMethods:  policy.AllowMethods,
+		AllowHeaders:  policy.AllowHeaders,
+		ExposeHeaders: policy.ExposeHeaders,
+	}
+	for _, origin := range policy.AllowOrigins {
+		if origin == "*" {
+			cors.AllowOrigins = append(cors.AllowOrigins, &istionet.StringMatch{MatchType: &istionet.StringMatch_Regex{Regex: ".*"}})
+			continue
+		}
+		cors.AllowOrigins = append(cors.AllowOrigins, &istionet.StringMatch{MatchType: &istionet.StringMatch_Exact{Exact: origin}})
+	}
+	if len(policy.MaxAge) > 0 {
+		maxAge, err := time.ParseDuration(policy.MaxAge)
+		if err != nil {
+			return nil, err
+		}
+		cors.MaxAge = durationpb.New(maxAge)
+	}
+	if policy.AllowCredentials != nil {
+		cors.AllowCredentials = wrapperspb.Bool(*policy.AllowCredentials)
+	}
+	return cors, nil
+}
+
+// mergeHeaderOperations merges the header manipulations of a path into the header manipulations of a route
+func mergeHeaderOperations(ops *istionet.Headers_HeaderOperations, pathOps *vzapi.IngressHeaderOperations) *istionet.Headers_HeaderOperations {
+	if pathOps == nil {
+		return ops
+	}
+	if ops == nil {
+		ops = &istionet.Headers_HeaderOperations{}
+	}
+	for key, value := range pathOps.Set {
+		if ops.Set == nil {
+			ops.Set = map[string]string{}
+		}
+		ops.Set[key] = value
+	}
+	for key, value := range pathOps.Add {
+		if ops.Add == nil {
+			ops.Add = map[string]string{}
+		}
+		ops.Add[key] = value
+	}
+	ops.Remove = append(ops.Remove, pathOps.Remove...)
+	return ops
+}
+
+// hasDestinationTrafficPolicy returns true when the destination of a rule needs a destination rule
+func hasDestinationTrafficPolicy(rule vzapi.IngressRule) bool {
+	return rule.Destination.HTTPCookie != nil || rule.Destination.ConnectionPool != nil || rule.Destination.OutlierDetection != nil
+}
+
+// createConnectionPoolSettings creates the Istio connection pool settings of a destination
+func createConnectionPoolSettings(pool *vzapi.IngressConnectionPool) (*istionet.ConnectionPoolSettings, error) {
+	settings := &istionet.ConnectionPoolSettings{
+		Tcp: &istionet.ConnectionPoolSettings_TCPSettings{MaxConnections: pool.MaxConnections},
+		Http: &istionet.ConnectionPoolSettings_HTTPSettings{
+			Http1MaxPendingRequests:  pool.MaxPendingRequests,
+			Http2MaxRequests:         pool.MaxRequests,
+			MaxRequestsPerConnection: pool.MaxRequestsPerConnection,
+		},
+	}
+	if len(pool.ConnectTimeout) > 0 {
+		connectTimeout, err := time.ParseDuration(pool.ConnectTimeout)
+		if err != nil {
+			return nil, err
+		}
+		settings.Tcp.ConnectTimeout = durationpb.New(connectTimeout)
+	}
+	if len(pool.IdleTimeout) > 0 {
+		idleTimeout, err := time.ParseDuration(pool.IdleTimeout)
+		if err != nil {
+			return nil, err
+		}
+		settings.Http.IdleTimeout = durationpb.New(idleTimeout)
+	}
+	return settings, nil
+}
+
+// createOutlierDetection creates the Istio outlier detection settings of a destination
+func createOutlierDetection(outlier *vzapi.IngressOutlierDetection) (*istionet.OutlierDetection, error) {
+	detection := &istionet.OutlierDetection{MaxEjectionPercent: outlier.MaxEjectionPercent}
+	if outlier.Consecutive5xxErrors > 0 {
+		detection.Consecutive_5XxErrors = wrapperspb.UInt32(outlier.Consecutive5xxErrors)
+	}
+	if len(outlier.Interval) > 0 {
+		interval, err := time.ParseDuration(outlier.Interval)
+		if err != nil {
+			return nil, err
+		}
+		detection.Interval = durationpb.New(interval)
+	}
+	if len(outlier.BaseEjectionTime) > 0 {
+		baseEjectionTime, err := time.ParseDuration(outlier.BaseEjectionTime)
+		if err != nil {
+			return nil, err
+		}
+		detection.BaseEjectionTime = durationpb.New(baseEjectionTime)
+	}
+	return detection, nil
+}
+
+// buildRateLimitFilterName builds the name of the EnvoyFilter enabling the local rate limit on the routes of a rule
+func buildRateLimitFilterName(trait *vzapi.IngressTrait, ruleIndex int) string {
+	return fmt.Sprintf("%s-%s-rule-%d-ratelimit", trait.Namespace, trait.Name, ruleIndex)
+}
+
+// createOrUpdateRateLimitFilter creates or updates the EnvoyFilter enabling the local rate limit on the ingress
+// gateway routes of the paths of a rule.  Results are added to the status object.  Returns true when the rule needs
+// the EnvoyFilter, the EnvoyFilter is deleted by deleteStaleRateLimitFilters otherwise.
+func (r *Reconciler) createOrUpdateRateLimitFilter(ctx context.Context, trait *vzapi.IngressTrait, rule vzapi.IngressRule, vsName string, name string, status *reconcileresults.ReconcileResults, log vzlog.VerrazzanoLogger) bool {
+	envoyFilter := &istioclient.EnvoyFilter{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: envoyFilterAPIVersion,
+			Kind:       envoyFilterKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: constants.IstioSystemNamespace,
+			Name:      name},
+	}
+	var patches []*istionet.EnvoyFilter_EnvoyConfigObjectPatch
+	for i, path := range rule.Paths {
+		if path.RateLimit == nil {
+			continue
+		}
+		// The rate limit also applies to the routes of the requests with the headers of a weighted destination
+		routeName := buildRouteName(trait.Namespace, vsName, i)
+		routeNames := []string{routeName}
+		for _, weighted := range rule.Destinations {
+			if len(weighted.Headers) > 0 {
+				routeNames = append(routeNames, buildHeaderRouteName(routeName, weighted.Name))
+			}
+		}
+		for _, route := range routeNames {
+			patch, err := createRateLimitRoutePatch(route, path.RateLimit)
+			if err != nil {
+				status.Errors = append(status.Errors, err)
+				return true
+			}
+			patches = append(patches, patch)
+		}
+	}
+	if len(patches) == 0 {
+		// The EnvoyFilter created by an earlier reconcile is deleted with the stale rate limit filters
+		return false
+	}
+
+	if err := r.createLocalRateLimitFilter(ctx); err != nil {
+		status.Errors = append(status.Errors, err)
+		log.Errorf("Failed to create the local rate limit envoy filter: %v", err)
+		return true
+	}
+	res, err := common.CreateOrUpdateProtobuf(ctx, r.Client, envoyFilter, func() error {
+		if envoyFilter.Labels == nil {
+			envoyFilter.Labels = map[string]string{}
+		}
+		envoyFilter.Labels[constants.LabelIngressTraitNsn] = getIngressTraitNsn(trait.Namespace, trait.Name)
+		envoyFilter.Labels[rateLimitFilterLabel] = "true"
+		envoyFilter.Spec = istionet.EnvoyFilter{
+			WorkloadSelector: &istionet.WorkloadSelector{Labels: map[string]string{"istio": "ingressgateway"}},
+			ConfigPatches:    patches,
+		}
+		return nil
+	})
+
+	ref := vzapi.QualifiedResourceRelation{APIVersion: envoyFilterAPIVersion, Kind: envoyFilterKind, Name: name, Role: "envoyfilter"}
+	status.Relations = append(status.Relations, ref)
+	status.Results = append(status.Results, res)
+	status.Errors = append(status.Errors, err)
+
+	if err != nil {
+		log.Errorf("Failed to create or update the rate limit envoy filter: %v", err)
+	}
+	return true
+}
+
+// deleteStaleRateLimitFilters deletes the rate limit EnvoyFilters listed in the status of the ingress trait which are
+// not in the desired set, the filters of the rules removed from the trait or which no longer have a rate limit.  The
+// desired filters of the rules which were not reconciled are kept in the status.  The shared local rate limit filter
+// is deleted when no ingress trait uses rate limiting anymore.
+func (r *Reconciler) deleteStaleRateLimitFilters(ctx context.Context, trait *vzapi.IngressTrait, desired map[string]bool, status *reconcileresults.ReconcileResults, log vzlog.VerrazzanoLogger) {
+	prefix := fmt.Sprintf("%s-%s-rule-", trait.Namespace, trait.Name)
+	deleted := false
+	for _, resource := range trait.Status.Resources {
+		if resource.Kind != envoyFilterKind || !strings.HasPrefix(resource.Name, prefix) || !strings.HasSuffix(resource.Name, "-ratelimit") {
+			continue
+		}
+		if desired[resource.Name] {
+			ref := vzapi.QualifiedResourceRelation{APIVersion: envoyFilterAPIVersion, Kind: envoyFilterKind, Name: resource.Name, Role: "envoyfilter"}
+			if !status.ContainsRelation(ref) {
+				status.Relations = append(status.Relations, ref)
+			}
+			continue
+		}
+		envoyFilter := &istioclient.EnvoyFilter{ObjectMeta: metav1.ObjectMeta{Namespace: constants.IstioSystemNamespace, Name: resource.Name}}
+		if err := r.Delete(ctx, envoyFilter); err != nil && !k8serrors.IsNotFound(err) {
+			status.Errors = append(status.Errors, err)
+			log.Errorf("Failed deleting the rate limit envoy filter %s: %v", resource.Name, err)
+			return
+		}
+		log.Oncef("Ingress trait rate limit envoy filter %s deleted", resource.Name)
+		deleted = true
+	}
+	if !deleted || len(desired) > 0 {
+		return
+	}
+	if err := deleteUnusedSharedEnvoyFilter(ctx, r.Client, localRateLimitFilterName, rateLimitFilterLabel, log); err != nil {
+		status.Errors = append(status.Errors, err)
+	}
+}
+
+// deleteUnusedSharedEnvoyFilter deletes a shared EnvoyFilter of the ingress gateway when there is no EnvoyFilter left
+// with the label of the EnvoyFilters using it.  An ingress trait using the shared filter concurrently creates it again
+// when it is next reconciled.
+func deleteUnusedSharedEnvoyFilter(ctx context.Context, c client.Client, name string, label string, log vzlog.VerrazzanoLogger) error {
+	envoyFilterList := istioclient.EnvoyFilterList{}
+	selector := labels.SelectorFromSet(map[string]string{label: "true"})
+	if err := c.List(ctx, &envoyFilterList, &client.ListOptions{Namespace: constants.IstioSystemNamespace, LabelSelector: selector}); err != nil {
+		return log.ErrorfNewErr("Failed listing the envoy filters using the envoy filter %s: %v", name, err)
+	}
+	if len(envoyFilterList.Items) > 0 {
+		return nil
+	}
+	envoyFilter := &istioclient.EnvoyFilter{ObjectMeta: metav1.ObjectMeta{Namespace: constants.IstioSystemNamespace, Name: name}}
+	err := c.Delete(ctx, envoyFilter)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return log.ErrorfNewErr("Failed deleting the envoy filter %s: %v", name, err)
+	}
+	log.Oncef("Shared envoy filter %s deleted, no ingress trait uses it", name)
+	return nil
+}
+
+// createLocalRateLimitFilter creates the EnvoyFilter adding the local rate limit HTTP filter to the ingress gateway,
+// when it does not exist
+func (r *Reconciler) createLocalRateLimitFilter(ctx context.Context) error {
+	value, err := structpb.NewStruct(map[string]interface{}{
+		"name": localRateLimitFilter,
+		"typed_config": map[string]interface{}{
+			"@type":    typedStructTypeURL,
+			"type_url": localRateLimitTypeURL,
+			"value":    map[string]interface{}{"stat_prefix": localRateLimitStatPrefix},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return r.createSharedHTTPFilter(ctx, localRateLimitFilterName, value)
+}
+
+// createSharedHTTPFilter creates the EnvoyFilter inserting an HTTP filter right before the router of the ingress
+// gateway, when it does not exist.  The HTTP filter is shared by all the ingress traits.
+func (r *Reconciler) createSharedHTTPFilter(ctx context.Context, name string, value *structpb.Struct) error {
+	envoyFilter := &istioclient.EnvoyFilter{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: name}, envoyFilter)
+	if err == nil || !k8serrors.IsNotFound(err) {
+		return err
+	}
+	envoyFilter = &istioclient.EnvoyFilter{
+		ObjectMeta: metav1.ObjectMeta{Namespace: constants.IstioSystemNamespace, Name: name},
+		Spec: istionet.EnvoyFilter{
+			WorkloadSelector: &istionet.WorkloadSelector{Labels: map[string]string{"istio": "ingressgateway"}},
+			ConfigPatches: []*istionet.EnvoyFilter_EnvoyConfigObjectPatch{{
+				ApplyTo: istionet.EnvoyFilter_HTTP_FILTER,
+				Match: &istionet.EnvoyFilter_EnvoyConfigObjectMatch{
+					Context: istionet.EnvoyFilter_GATEWAY,
+					ObjectTypes: &istionet.EnvoyFilter_EnvoyConfigObjectMatch_Listener{
+						Listener: &istionet.EnvoyFilter_ListenerMatch{
+							FilterChain: &istionet.EnvoyFilter_ListenerMatch_FilterChainMatch{
+								Filter: &istionet.EnvoyFilter_ListenerMatch_FilterMatch{
+									Name:      "envoy.filters.network.http_connection_manager",
+									SubFilter: &istionet.EnvoyFilter_ListenerMatch_SubFilterMatch{Name: "envoy.filters.http.router"},
+								},
+							},
+						},
+					},
+				},
+				Patch: &istionet.EnvoyFilter_Patch{Operation: istionet.EnvoyFilter_Patch_INSERT_BEFORE, Value: value},
+			}},
+		},
+	}
+	err = r.Create(ctx, envoyFilter)
+	if k8serrors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+// createRateLimitRoutePatch creates the patch enabling the local rate limit on the ingress gateway route of a path
+func createRateLimitRoutePatch(routeName string, rateLimit *vzapi.IngressRateLimit) (*istionet.EnvoyFilter_EnvoyConfigObjectPatch, error) {
+	fillInterval, err := getRateLimitFillInterval(rateLimit.Unit)
+	if err != nil {
+		return nil, err
+	}
+	percent := map[string]interface{}{"numerator": 100, "denominator": "HUNDRED"}
+	value, err := structpb.NewStruct(map[string]interface{}{
+		"typed_per_filter_config": map[string]interface{}{
+			localRateLimitFilter: map[string]interface{}{
+				"@type":    typedStructTypeURL,
+				"type_url": localRateLimitTypeURL,
+				"value": map[string]interface{}{
+					"stat_prefix": localRateLimitStatPrefix,
+					"token_bucket": map[string]interface{}{
+						"max_tokens":      rateLimit.Requests,
+						"tokens_per_fill": rateLimit.Requests,
+						"fill_interval":   fillInterval,
+					},
+					"filter_enabled":  map[string]interface{}{"runtime_key": "local_rate_limit_enabled", "default_value": percent},
+					"filter_enforced": map[string]interface{}{"runtime_key": "local_rate_limit_enforced", "default_value": percent},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &istionet.EnvoyFilter_EnvoyConfigObjectPatch{
+		ApplyTo: istionet.EnvoyFilter_HTTP_ROUTE,
+		Match: &istionet.EnvoyFilter_EnvoyConfigObjectMatch{
+			Context: istionet.EnvoyFilter_GATEWAY,
+			ObjectTypes: &istionet.EnvoyFilter_EnvoyConfigObjectMatch_RouteConfiguration{
+				RouteConfiguration: &istionet.EnvoyFilter_RouteConfigurationMatch{
+					Vhost: &istionet.EnvoyFilter_RouteConfigurationMatch_VirtualHostMatch{
+						Route: &istionet.EnvoyFilter_RouteConfigurationMatch_RouteMatch{Name: routeName},
+					},
+				},
+			},
+		},
+		Patch: &istionet.EnvoyFilter_Patch{Operation: istionet.EnvoyFilter_Patch_MERGE, Value: value},
+	}, nil
+}
+
+// getRateLimitFillInterval returns the token bucket fill interval of a rate limit unit
+func getRateLimitFillInterval(unit string) (string, error) {
+	switch unit {
+	case "", vzapi.RateLimitUnitSecond:
+		return "1s", nil
+	case vzapi.RateLimitUnitMinute:
+		return "60s", nil
+	case vzapi.RateLimitUnitHour:
+		return "3600s", nil
+	}
+	return "", fmt.Errorf("invalid rate limit unit %q", unit)
+}
+
+// cleanupEnvoyFilters deletes the rate limit and claim headers EnvoyFilters created for the ingress trait, and the
+// shared local rate limit and claim headers EnvoyFilters when no other ingress trait uses them
+func cleanupEnvoyFilters(trait *vzapi.IngressTrait, c client.Client, log vzlog.VerrazzanoLogger) error {
+	traitNameReq, _ := labels.NewRequirement(constants.LabelIngressTraitNsn, selection.Equals, []string{getIngressTraitNsn(trait.Namespace, trait.Name)})
+	selector := labels.NewSelector().Add(*traitNameReq)
+	envoyFilterList := istioclient.EnvoyFilterList{}
+	err := c.List(context.TODO(), &envoyFilterList, &client.ListOptions{Namespace: constants.IstioSystemNamespace, LabelSelector: selector})
+	if err != nil {
+		log.Errorf("Failed listing the envoy filters: %v", err)
+		return nil
+	}
+	for i, envoyFilter := range envoyFilterList.Items {
+		log.Debugf("Deleting envoy filter: %s", envoyFilter.Name)
+		err := c.Delete(context.TODO(), envoyFilterList.Items[i])
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return log.ErrorfNewErr("Failed deleting the envoy filter %s: %v", envoyFilter.Name, err)
+		}
+		log.Oncef("Ingress trait envoy filter %s deleted", envoyFilter.Name)
+	}
+	if err := deleteUnusedSharedEnvoyFilter(context.TODO(), c, localRateLimitFilterName, rateLimitFilterLabel, log); err != nil {
+		return err
+	}
+	return deleteUnusedSharedEnvoyFilter(context.TODO(), c, claimHeadersFilterName, claimHeadersFilterLabel, log)
+}
@@ -0,0 +1,351 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package ingresstrait
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/reconcileresults"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	istionet "istio.io/api/networking/v1alpha3"
+	istioclient "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	trafficTestNamespace = "test-space"
+	trafficTestTrait     = "test-trait"
+)
+
+// TestMutateVirtualServiceWithRouteSettings tests rendering the traffic management settings of the paths
+// GIVEN an ingress rule with a path with a timeout, retries, a CORS policy and header manipulations, and a path without settings
+// WHEN the virtual service is mutated
+// THEN each path has a route of its own, named after the path, with the settings of the path
+func TestMutateVirtualServiceWithRouteSettings(t *testing.T) {
+	assert := assert.New(t)
+
+	allowCredentials := true
+	rule := vzapi.IngressRule{
+		Destination: vzapi.IngressDestination{Host: "test-service", Port: 8080},
+		Paths: []vzapi.IngressPath{
+			{
+				Path:     "/api",
+				PathType: "prefix",
+				Timeout:  "10s",
+				Retries:  &vzapi.IngressRetryPolicy{Attempts: 3, PerTryTimeout: "2s", RetryOn: "5xx"},
+				CORS:     &vzapi.IngressCORSPolicy{AllowOrigins: []string{"*", "https://example.com"}, MaxAge: "1h", AllowCredentials: &allowCredentials},
+				Headers: &vzapi.IngressHeaders{
+					Request:  &vzapi.IngressHeaderOperations{Set: map[string]string{"X-Request": "true"}},
+					Response: &vzapi.IngressHeaderOperations{Remove: []string{"Server"}},
+				},
+			},
+			{Path: "/ui", PathType: "prefix"},
+		},
+	}
+	trait := newTrafficTestTrait(rule)
+	vs := &istioclient.VirtualService{ObjectMeta: metav1.ObjectMeta{Namespace: trafficTestNamespace, Name: "test-trait-rule-0-vs"}}
+	gw := &istioclient.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: trafficTestNamespace, Name: "test-gw"}}
+
+	reconciler := newIngressTraitReconciler(fake.NewClientBuilder().WithScheme(newScheme()).Build())
+	err := reconciler.mutateVirtualService(vs, trait, rule, []string{"example.com"}, nil, nil, gw)
+	assert.NoError(err)
+
+	assert.Len(vs.Spec.Http, 2)
+	route := vs.Spec.Http[0]
+	assert.Equal("test-space-test-trait-rule-0-vs-path-0", route.Name)
+	assert.Equal("/api", route.Match[0].Uri.GetPrefix())
+	assert.Equal(10*time.Second, route.Timeout.AsDuration())
+	assert.Equal(int32(3), route.Retries.Attempts)
+	assert.Equal(2*time.Second, route.Retries.PerTryTimeout.AsDuration())
+	assert.Equal("5xx", route.Retries.RetryOn)
+	assert.Equal(".*", route.CorsPolicy.AllowOrigins[0].GetRegex())
+	assert.Equal("https://example.com", route.CorsPolicy.AllowOrigins[1].GetExact())
+	assert.Equal(time.Hour, route.CorsPolicy.MaxAge.AsDuration())
+	assert.True(route.CorsPolicy.AllowCredentials.GetValue())
+	assert.Equal("true", route.Headers.Request.Set["X-Request"])
+	assert.Equal([]string{"Server"}, route.Headers.Response.Remove)
+
+	route = vs.Spec.Http[1]
+	assert.Equal("test-space-test-trait-rule-0-vs-path-1", route.Name)
+	assert.Equal("/ui", route.Match[0].Uri.GetPrefix())
+	assert.Nil(route.Timeout)
+	assert.Nil(route.Retries)
+	assert.Nil(route.CorsPolicy)
+	assert.Nil(route.Headers)
+}
+
+// TestMutateVirtualServiceWithoutRouteSettings tests rendering paths without traffic management settings
+// GIVEN an ingress rule with two paths without settings
+// WHEN the virtual service is mutated
+// THEN the paths share a single unnamed route
+func TestMutateVirtualServiceWithoutRouteSettings(t *testing.T) {
+	assert := assert.New(t)
+
+	rule := vzapi.IngressRule{
+		Destination: vzapi.IngressDestination{Host: "test-service"},
+		Paths:       []vzapi.IngressPath{{Path: "/api", PathType: "prefix"}, {Path: "/ui", PathType: "prefix"}},
+	}
+	trait := newTrafficTestTrait(rule)
+	vs := &istioclient.VirtualService{ObjectMeta: metav1.ObjectMeta{Namespace: trafficTestNamespace, Name: "test-trait-rule-0-vs"}}
+	gw := &istioclient.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: trafficTestNamespace, Name: "test-gw"}}
+
+	reconciler := newIngressTraitReconciler(fake.NewClientBuilder().WithScheme(newScheme()).Build())
+	err := reconciler.mutateVirtualService(vs, trait, rule, []string{"example.com"}, nil, nil, gw)
+	assert.NoError(err)
+
+	assert.Len(vs.Spec.Http, 1)
+	assert.Empty(vs.Spec.Http[0].Name)
+	assert.Len(vs.Spec.Http[0].Match, 2)
+}
+
+// TestMutateVirtualServiceSinglePathWithRouteSettings tests rendering the traffic management settings of a single path
+// GIVEN an ingress rule with a single path with a timeout
+// WHEN the virtual service is mutated
+// THEN the path has a route named after the path, with the timeout of the path
+func TestMutateVirtualServiceSinglePathWithRouteSettings(t *testing.T) {
+	assert := assert.New(t)
+
+	rule := vzapi.IngressRule{
+		Destination: vzapi.IngressDestination{Host: "test-service"},
+		Paths:       []vzapi.IngressPath{{Path: "/api", PathType: "prefix", Timeout: "10s"}},
+	}
+	trait := newTrafficTestTrait(rule)
+	vs := &istioclient.VirtualService{ObjectMeta: metav1.ObjectMeta{Namespace: trafficTestNamespace, Name: "test-trait-rule-0-vs"}}
+	gw := &istioclient.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: trafficTestNamespace, Name: "test-gw"}}
+
+	reconciler := newIngressTraitReconciler(fake.NewClientBuilder().WithScheme(newScheme()).Build())
+	err := reconciler.mutateVirtualService(vs, trait, rule, []string{"example.com"}, nil, nil, gw)
+	assert.NoError(err)
+
+	assert.Len(vs.Spec.Http, 1)
+	route := vs.Spec.Http[0]
+	assert.Equal("test-space-test-trait-rule-0-vs-path-0", route.Name)
+	assert.Equal("/api", route.Match[0].Uri.GetPrefix())
+	assert.Equal(10*time.Second, route.Timeout.AsDuration())
+}
+
+// TestMutateDestinationRuleWithTrafficPolicy tests rendering the connection pool and outlier detection of a destination
+// GIVEN an ingress rule with a destination with connection pool and outlier detection settings, and no HTTP cookie
+// WHEN the destination rule is mutated
+// THEN the destination rule has the connection pool and outlier detection settings, and no load balancer settings
+func TestMutateDestinationRuleWithTrafficPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	rule := vzapi.IngressRule{
+		Destination: vzapi.IngressDestination{
+			Host: "test-service",
+			ConnectionPool: &vzapi.IngressConnectionPool{
+				MaxConnections:     100,
+				ConnectTimeout:     "500ms",
+				MaxPendingRequests: 10,
+				MaxRequests:        50,
+				IdleTimeout:        "1m",
+			},
+			OutlierDetection: &vzapi.IngressOutlierDetection{
+				Consecutive5xxErrors: 5,
+				Interval:             "30s",
+				BaseEjectionTime:     "1m",
+				MaxEjectionPercent:   50,
+			},
+		},
+	}
+	assert.True(hasDestinationTrafficPolicy(rule))
+	trait := newTrafficTestTrait(rule)
+	dr := &istioclient.DestinationRule{ObjectMeta: metav1.ObjectMeta{Namespace: trafficTestNamespace, Name: "test-trait-rule-0-dr"}}
+
+	reconciler := newIngressTraitReconciler(fake.NewClientBuilder().WithScheme(newScheme()).Build())
+	err := reconciler.mutateDestinationRule(dr, trait, rule, nil, nil, &corev1.Namespace{})
+	assert.NoError(err)
+
+	policy := dr.Spec.TrafficPolicy
+	assert.Equal("test-service", dr.Spec.Host)
+	assert.Nil(policy.LoadBalancer)
+	assert.Equal(int32(100), policy.ConnectionPool.Tcp.MaxConnections)
+	assert.Equal(500*time.Millisecond, policy.ConnectionPool.Tcp.ConnectTimeout.AsDuration())
+	assert.Equal(int32(10), policy.ConnectionPool.Http.Http1MaxPendingRequests)
+	assert.Equal(int32(50), policy.ConnectionPool.Http.Http2MaxRequests)
+	assert.Equal(time.Minute, policy.ConnectionPool.Http.IdleTimeout.AsDuration())
+	assert.Equal(uint32(5), policy.OutlierDetection.Consecutive_5XxErrors.GetValue())
+	assert.Equal(30*time.Second, policy.OutlierDetection.Interval.AsDuration())
+	assert.Equal(time.Minute, policy.OutlierDetection.BaseEjectionTime.AsDuration())
+	assert.Equal(int32(50), policy.OutlierDetection.MaxEjectionPercent)
+}
+
+// TestCreateOrUpdateRateLimitFilter tests the rate limit EnvoyFilters of an ingress rule
+// GIVEN an ingress rule with a rate limited path
+// WHEN the rate limit filter is created, and the rate limit is then removed from the rule
+// THEN the shared local rate limit filter and the route patch of the rule are created, and the route patch is deleted
+// when the rule no longer has a rate limit
+func TestCreateOrUpdateRateLimitFilter(t *testing.T) {
+	assert := assert.New(t)
+
+	rule := vzapi.IngressRule{
+		Destination: vzapi.IngressDestination{Host: "test-service"},
+		Paths: []vzapi.IngressPath{
+			{Path: "/ui", PathType: "prefix"},
+			{Path: "/api", PathType: "prefix", RateLimit: &vzapi.IngressRateLimit{Requests: 100, Unit: vzapi.RateLimitUnitMinute}},
+		},
+	}
+	trait := newTrafficTestTrait(rule)
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).Build()
+	reconciler := newIngressTraitReconciler(cli)
+	name := "test-space-test-trait-rule-0-ratelimit"
+
+	status := reconcileresults.ReconcileResults{}
+	reconciler.createOrUpdateRateLimitFilter(context.TODO(), trait, rule, "test-trait-rule-0-vs", name, &status, vzlog.DefaultLogger())
+	assert.NoError(status.Errors[0])
+	assert.Equal(envoyFilterKind, status.Relations[0].Kind)
+	assert.Equal(name, status.Relations[0].Name)
+
+	shared := &istioclient.EnvoyFilter{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: localRateLimitFilterName}, shared))
+	assert.Equal(istionet.EnvoyFilter_HTTP_FILTER, shared.Spec.ConfigPatches[0].ApplyTo)
+
+	filter := &istioclient.EnvoyFilter{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: name}, filter))
+	assert.Equal("test-space-test-trait", filter.Labels[constants.LabelIngressTraitNsn])
+	assert.Equal("true", filter.Labels[rateLimitFilterLabel])
+	assert.Len(filter.Spec.ConfigPatches, 1)
+	patch := filter.Spec.ConfigPatches[0]
+	assert.Equal(istionet.EnvoyFilter_HTTP_ROUTE, patch.ApplyTo)
+	assert.Equal("test-space-test-trait-rule-0-vs-path-1", patch.Match.GetRouteConfiguration().Vhost.Route.Name)
+	bucket := patch.Patch.Value.AsMap()["typed_per_filter_config"].(map[string]interface{})[localRateLimitFilter].(map[string]interface{})["value"].(map[string]interface{})["token_bucket"].(map[string]interface{})
+	assert.Equal(float64(100), bucket["max_tokens"])
+	assert.Equal("60s", bucket["fill_interval"])
+
+	// Remove the rate limit, the filter is deleted because it is one of the resources of the trait, and the shared
+	// filter is deleted because no other trait uses rate limiting
+	rule.Paths[1].RateLimit = nil
+	trait.Status.Resources = []oamrt.TypedReference{{APIVersion: envoyFilterAPIVersion, Kind: envoyFilterKind, Name: name}}
+	status = reconcileresults.ReconcileResults{}
+	assert.False(reconciler.createOrUpdateRateLimitFilter(context.TODO(), trait, rule, "test-trait-rule-0-vs", name, &status, vzlog.DefaultLogger()))
+	reconciler.deleteStaleRateLimitFilters(context.TODO(), trait, map[string]bool{}, &status, vzlog.DefaultLogger())
+	assert.Empty(status.Errors)
+	err := cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: name}, filter)
+	assert.True(k8serrors.IsNotFound(err))
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: localRateLimitFilterName}, shared)
+	assert.True(k8serrors.IsNotFound(err))
+}
+
+// TestDeleteStaleRateLimitFilters tests the deletion of the rate limit EnvoyFilters of the removed rules
+// GIVEN the rate limit EnvoyFilters of three rules of an ingress trait, and of another ingress trait
+// WHEN the third rule is removed, and then the rate limits of the other rules are removed
+// THEN the filter of the third rule is deleted, the filter of a rule which was not reconciled is kept in the status,
+// and the shared filter is only deleted when no rate limit filter is left
+func TestDeleteStaleRateLimitFilters(t *testing.T) {
+	assert := assert.New(t)
+
+	newFilter := func(name string, traitNsn string) *istioclient.EnvoyFilter {
+		return &istioclient.EnvoyFilter{ObjectMeta: metav1.ObjectMeta{
+			Namespace: constants.IstioSystemNamespace,
+			Name:      name,
+			Labels:    map[string]string{constants.LabelIngressTraitNsn: traitNsn, rateLimitFilterLabel: "true"},
+		}}
+	}
+	shared := &istioclient.EnvoyFilter{ObjectMeta: metav1.ObjectMeta{Namespace: constants.IstioSystemNamespace, Name: localRateLimitFilterName}}
+	other := newFilter("test-space-other-trait-rule-0-ratelimit", "test-space-other-trait")
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(
+		newFilter("test-space-test-trait-rule-0-ratelimit", "test-space-test-trait"),
+		newFilter("test-space-test-trait-rule-1-ratelimit", "test-space-test-trait"),
+		newFilter("test-space-test-trait-rule-2-ratelimit", "test-space-test-trait"),
+		other,
+		shared,
+	).Build()
+	reconciler := newIngressTraitReconciler(cli)
+	trait := newTrafficTestTrait(vzapi.IngressRule{})
+	for i := 0; i < 3; i++ {
+		trait.Status.Resources = append(trait.Status.Resources, oamrt.TypedReference{APIVersion: envoyFilterAPIVersion, Kind: envoyFilterKind, Name: buildRateLimitFilterName(trait, i)})
+	}
+
+	// Rule 0 was reconciled, rule 1 was not reconciled and rule 2 was removed
+	status := reconcileresults.ReconcileResults{Relations: []vzapi.QualifiedResourceRelation{
+		{APIVersion: envoyFilterAPIVersion, Kind: envoyFilterKind, Name: "test-space-test-trait-rule-0-ratelimit", Role: "envoyfilter"}}}
+	desired := map[string]bool{"test-space-test-trait-rule-0-ratelimit": true, "test-space-test-trait-rule-1-ratelimit": true}
+	reconciler.deleteStaleRateLimitFilters(context.TODO(), trait, desired, &status, vzlog.DefaultLogger())
+	assert.Empty(status.Errors)
+	assert.Len(status.Relations, 2)
+	assert.Equal("test-space-test-trait-rule-1-ratelimit", status.Relations[1].Name)
+	err := cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: "test-space-test-trait-rule-2-ratelimit"}, &istioclient.EnvoyFilter{})
+	assert.True(k8serrors.IsNotFound(err))
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: "test-space-test-trait-rule-1-ratelimit"}, &istioclient.EnvoyFilter{}))
+
+	// The other trait still uses rate limiting, the shared filter is kept
+	status = reconcileresults.ReconcileResults{}
+	reconciler.deleteStaleRateLimitFilters(context.TODO(), trait, map[string]bool{}, &status, vzlog.DefaultLogger())
+	assert.Empty(status.Errors)
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: localRateLimitFilterName}, &istioclient.EnvoyFilter{}))
+
+	// No trait uses rate limiting anymore, the shared filter is deleted when the other trait is deleted
+	otherTrait := newTrafficTestTrait(vzapi.IngressRule{})
+	otherTrait.Name = "other-trait"
+	assert.NoError(cleanupEnvoyFilters(otherTrait, cli, vzlog.DefaultLogger()))
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: localRateLimitFilterName}, &istioclient.EnvoyFilter{})
+	assert.True(k8serrors.IsNotFound(err))
+}
+
+// TestCleanupEnvoyFilters tests the deletion of the EnvoyFilters of a deleted ingress trait
+// GIVEN EnvoyFilters of two ingress traits
+// WHEN the envoy filters of one of the traits are cleaned up
+// THEN only the filters of that trait are deleted
+func TestCleanupEnvoyFilters(t *testing.T) {
+	assert := assert.New(t)
+
+	newFilter := func(name string, traitNsn string) client.Object {
+		return &istioclient.EnvoyFilter{ObjectMeta: metav1.ObjectMeta{
+			Namespace: constants.IstioSystemNamespace,
+			Name:      name,
+			Labels:    map[string]string{constants.LabelIngressTraitNsn: traitNsn},
+		}}
+	}
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(
+		newFilter("test-space-test-trait-rule-0-ratelimit", "test-space-test-trait"),
+		newFilter("test-space-test-trait-claims", "test-space-test-trait"),
+		newFilter("test-space-other-trait-rule-0-ratelimit", "test-space-other-trait"),
+	).Build()
+
+	trait := newTrafficTestTrait(vzapi.IngressRule{})
+	assert.NoError(cleanupEnvoyFilters(trait, cli, vzlog.DefaultLogger()))
+
+	filters := istioclient.EnvoyFilterList{}
+	assert.NoError(cli.List(context.TODO(), &filters))
+	assert.Len(filters.Items, 1)
+	assert.Equal("test-space-other-trait-rule-0-ratelimit", filters.Items[0].Name)
+}
+
+// TestGetRateLimitFillInterval tests the fill interval of the rate limit units
+func TestGetRateLimitFillInterval(t *testing.T) {
+	tests := map[string]string{
+		"":                        "1s",
+		vzapi.RateLimitUnitSecond: "1s",
+		vzapi.RateLimitUnitMinute: "60s",
+		vzapi.RateLimitUnitHour:   "3600s",
+	}
+	for unit, expected := range tests {
+		interval, err := getRateLimitFillInterval(unit)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, interval)
+	}
+	_, err := getRateLimitFillInterval("day")
+	assert.Error(t, err)
+}
+
+// newTrafficTestTrait creates an ingress trait with a single rule for testing
+func newTrafficTestTrait(rule vzapi.IngressRule) *vzapi.IngressTrait {
+	return &vzapi.IngressTrait{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "oam.verrazzano.io/v1alpha1", Kind: "IngressTrait"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: trafficTestNamespace, Name: trafficTestTrait},
+		Spec:       vzapi.IngressTraitSpec{Rules: []vzapi.IngressRule{rule}},
+	}
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package loggingtrait
@@ -57,6 +57,8 @@ type LoggingTraitReconciler struct {
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=pods,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=fluentbit.fluent.io,resources=fluentbitconfigs;filters;parsers;outputs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=fluentbit.fluent.io,resources=clusteroutputs,verbs=get;list;watch
 
 func (r *LoggingTraitReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	if ctx == nil {
@@ -102,6 +104,17 @@ func (r *LoggingTraitReconciler) Reconcile(ctx context.Context, req ctrl.Request
 // doReconcile performs the reconciliation operations for the logging trait
 func (r *LoggingTraitReconciler) doReconcile(ctx context.Context, trait *oamv1alpha1.LoggingTrait, log vzlog.VerrazzanoLogger) (ctrl.Result, error) {
 	if trait.DeletionTimestamp.IsZero() {
+		// With Fluent Bit, the logs are processed by the Fluent Bit daemon set of the cluster instead of a sidecar
+		if trait.Spec.FluentBit != nil {
+			// Remove the Fluentd sidecar and its ConfigMap if the trait used them before switching to Fluent Bit
+			if result, err := r.reconcileTraitDelete(ctx, log, trait); err != nil {
+				return result, err
+			}
+			return r.reconcileFluentBit(ctx, log, trait)
+		}
+		if err := r.deleteFluentBitResources(ctx, log, trait); err != nil {
+			return reconcile.Result{}, err
+		}
 		result, supported, err := r.reconcileTraitCreateOrUpdate(ctx, log, trait)
 		if err != nil {
 			return result, err
@@ -115,6 +128,10 @@ func (r *LoggingTraitReconciler) doReconcile(ctx context.Context, trait *oamv1al
 		}
 		return result, err
 	}
+	// The Fluent Bit resources are owned by the trait, they are deleted with the trait
+	if trait.Spec.FluentBit != nil {
+		return reconcile.Result{}, nil
+	}
 
 	return r.reconcileTraitDelete(ctx, log, trait)
 }
@@ -0,0 +1,407 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package loggingtrait
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	fluentbitv1alpha2 "github.com/fluent/fluent-operator/v2/apis/fluentbit/v1alpha2"
+	"github.com/fluent/fluent-operator/v2/apis/fluentbit/v1alpha2/plugins/filter"
+	"github.com/fluent/fluent-operator/v2/apis/fluentbit/v1alpha2/plugins/parser"
+	oamv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	vznav "github.com/verrazzano/verrazzano/application-operator/controllers/navigation"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	k8sValidations "k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	fluentBitAPIVersion  = "fluentbit.fluent.io/v1alpha2"
+	fluentBitConfigKind  = "FluentBitConfig"
+	fluentBitFilterKind  = "Filter"
+	fluentBitParserKind  = "Parser"
+	fluentBitOutputKind  = "Output"
+	defaultFluentBitKey  = "log"
+	fluentBitConfigLabel = "fluentbit.verrazzano.io/namespace-config"
+	fluentBitConfigValue = "verrazzano"
+	fluentBitTagPrefix   = `kube\.var\.log\.containers\.`
+	weblogicDomainKind   = "Domain"
+	coherenceKind        = "Coherence"
+)
+
+// reconcileFluentBit renders the Fluent Bit processing of the logs of the workload of the trait as Fluent Operator
+// resources in the namespace of the trait.  The resources are owned by the trait, they are recorded in the status
+// of the trait so that the resources no longer needed are deleted.
+func (r *LoggingTraitReconciler) reconcileFluentBit(ctx context.Context, log vzlog.VerrazzanoLogger, trait *oamv1alpha1.LoggingTrait) (ctrl.Result, error) {
+	if err := validateFluentBitLogging(trait.Spec.FluentBit); err != nil {
+		log.Errorf("Invalid Fluent Bit logging of the logging trait %s: %v", trait.Name, err)
+		return reconcile.Result{}, err
+	}
+	workload, err := vznav.FetchWorkloadFromTrait(ctx, r, log, trait)
+	if err != nil || workload == nil {
+		return reconcile.Result{}, err
+	}
+	matchRegex := buildFluentBitMatchRegex(trait.Namespace, r.fetchWorkloadPodPrefixes(ctx, log, workload))
+	spec := applyLoggingProfile(trait.Spec.FluentBit)
+
+	var resources []client.Object
+	resources = append(resources, createFluentBitConfig(trait))
+	for _, p := range spec.Parsers {
+		resources = append(resources, createFluentBitParser(trait, p))
+	}
+	if f := createFluentBitFilter(trait, spec, matchRegex); f != nil {
+		resources = append(resources, f)
+	}
+	if trait.Spec.FluentBit.Output != nil && len(trait.Spec.FluentBit.Output.ClusterOutput) > 0 {
+		output, err := r.createFluentBitOutput(ctx, trait, matchRegex)
+		if err != nil {
+			log.Errorf("Failed to create the Fluent Bit output of the logging trait %s: %v", trait.Name, err)
+			return reconcile.Result{}, err
+		}
+		resources = append(resources, output)
+	}
+	if len(spec.Profile) > 0 && spec.ProfileIndex {
+		output, err := r.createFluentBitProfileOutput(ctx, log, trait, matchRegex)
+		if err != nil {
+			log.Errorf("Failed to create the Fluent Bit output of the profile of the logging trait %s: %v", trait.Name, err)
+			return reconcile.Result{}, err
+		}
+		if output != nil {
+			resources = append(resources, output)
+		}
+	}
+
+	var refs []oamrt.TypedReference
+	for _, resource := range resources {
+		if err := r.createOrUpdateFluentBitResource(ctx, trait, resource); err != nil {
+			log.Errorf("Failed creating or updating the Fluent Bit resource %s: %v", resource.GetName(), err)
+			return reconcile.Result{}, err
+		}
+		refs = append(refs, oamrt.TypedReference{
+			APIVersion: fluentBitAPIVersion,
+			Kind:       resource.GetObjectKind().GroupVersionKind().Kind,
+			Name:       resource.GetName(),
+		})
+	}
+	return reconcile.Result{}, r.updateFluentBitResources(ctx, log, trait, refs)
+}
+
+// createOrUpdateFluentBitResource creates or updates a Fluent Operator resource of the trait
+func (r *LoggingTraitReconciler) createOrUpdateFluentBitResource(ctx context.Context, trait *oamv1alpha1.LoggingTrait, desired client.Object) error {
+	existing := desired.DeepCopyObject().(client.Object)
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, existing, func() error {
+		existing.SetLabels(desired.GetLabels())
+		switch obj := existing.(type) {
+		case *fluentbitv1alpha2.FluentBitConfig:
+			obj.Spec = desired.(*fluentbitv1alpha2.FluentBitConfig).Spec
+		case *fluentbitv1alpha2.Parser:
+			obj.Spec = desired.(*fluentbitv1alpha2.Parser).Spec
+		case *fluentbitv1alpha2.Filter:
+			obj.Spec = desired.(*fluentbitv1alpha2.Filter).Spec
+		case *fluentbitv1alpha2.Output:
+			obj.Spec = desired.(*fluentbitv1alpha2.Output).Spec
+		}
+		return controllerutil.SetControllerReference(trait, existing, r.Scheme)
+	})
+	return err
+}
+
+// updateFluentBitResources deletes the Fluent Operator resources created by an earlier reconcile of the trait that
+// are no longer needed, and records the current resources in the status of the trait
+func (r *LoggingTraitReconciler) updateFluentBitResources(ctx context.Context, log vzlog.VerrazzanoLogger, trait *oamv1alpha1.LoggingTrait, refs []oamrt.TypedReference) error {
+	current := map[oamrt.TypedReference]bool{}
+	for _, ref := range refs {
+		current[ref] = true
+	}
+	var resources []oamrt.TypedReference
+	for _, ref := range trait.Status.Resources {
+		if ref.APIVersion != fluentBitAPIVersion {
+			resources = append(resources, ref)
+			continue
+		}
+		if current[oamrt.TypedReference{APIVersion: ref.APIVersion, Kind: ref.Kind, Name: ref.Name}] {
+			continue
+		}
+		if err := r.deleteFluentBitResource(ctx, trait.Namespace, ref); err != nil {
+			log.Errorf("Failed deleting the Fluent Bit resource %s: %v", ref.Name, err)
+			return err
+		}
+	}
+	resources = append(resources, refs...)
+	if equalResources(trait.Status.Resources, resources) {
+		return nil
+	}
+	trait.Status.Resources = resources
+	return r.Status().Update(ctx, trait)
+}
+
+// deleteFluentBitResources deletes the Fluent Operator resources recorded in the status of the trait, when the trait
+// no longer uses Fluent Bit
+func (r *LoggingTraitReconciler) deleteFluentBitResources(ctx context.Context, log vzlog.VerrazzanoLogger, trait *oamv1alpha1.LoggingTrait) error {
+	var resources []oamrt.TypedReference
+	for _, ref := range trait.Status.Resources {
+		if ref.APIVersion != fluentBitAPIVersion {
+			resources = append(resources, ref)
+			continue
+		}
+		if err := r.deleteFluentBitResource(ctx, trait.Namespace, ref); err != nil {
+			log.Errorf("Failed deleting the Fluent Bit resource %s: %v", ref.Name, err)
+			return err
+		}
+	}
+	if len(resources) == len(trait.Status.Resources) {
+		return nil
+	}
+	trait.Status.Resources = resources
+	return r.Status().Update(ctx, trait)
+}
+
+// deleteFluentBitResource deletes a Fluent Operator resource, ignoring the resources and the kinds that do not exist
+func (r *LoggingTraitReconciler) deleteFluentBitResource(ctx context.Context, namespace string, ref oamrt.TypedReference) error {
+	resource := &unstructured.Unstructured{}
+	resource.SetAPIVersion(ref.APIVersion)
+	resource.SetKind(ref.Kind)
+	resource.SetNamespace(namespace)
+	resource.SetName(ref.Name)
+	err := r.Delete(ctx, resource)
+	if err != nil && !k8serrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return err
+	}
+	return nil
+}
+
+// createFluentBitConfig creates the FluentBitConfig selecting the parsers, filters and outputs of the trait.  The
+// label of the FluentBitConfig makes the Fluent Bit daemon set of the cluster load it.
+func createFluentBitConfig(trait *oamv1alpha1.LoggingTrait) *fluentbitv1alpha2.FluentBitConfig {
+	selector := metav1.LabelSelector{MatchLabels: map[string]string{constants.LabelLoggingTrait: trait.Name}}
+	return &fluentbitv1alpha2.FluentBitConfig{
+		TypeMeta: metav1.TypeMeta{APIVersion: fluentBitAPIVersion, Kind: fluentBitConfigKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: trait.Namespace,
+			Name:      trait.Name + "-fbc",
+			Labels:    map[string]string{fluentBitConfigLabel: fluentBitConfigValue, constants.LabelLoggingTrait: trait.Name},
+		},
+		Spec: fluentbitv1alpha2.NamespacedFluentBitCfgSpec{
+			FilterSelector: selector,
+			ParserSelector: selector,
+			OutputSelector: selector,
+		},
+	}
+}
+
+// createFluentBitParser creates the Parser of a parser of the trait
+func createFluentBitParser(trait *oamv1alpha1.LoggingTrait, p oamv1alpha1.FluentBitParser) *fluentbitv1alpha2.Parser {
+	spec := fluentbitv1alpha2.ParserSpec{}
+	switch p.Format {
+	case oamv1alpha1.FluentBitParserFormatRegex:
+		spec.Regex = &parser.Regex{Regex: p.Regex, TimeKey: p.TimeKey, TimeFormat: p.TimeFormat}
+	case oamv1alpha1.FluentBitParserFormatJSON:
+		spec.JSON = &parser.JSON{TimeKey: p.TimeKey, TimeFormat: p.TimeFormat}
+	case oamv1alpha1.FluentBitParserFormatLogfmt:
+		spec.Logfmt = &parser.Logfmt{}
+	}
+	return &fluentbitv1alpha2.Parser{
+		TypeMeta: metav1.TypeMeta{APIVersion: fluentBitAPIVersion, Kind: fluentBitParserKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: trait.Namespace,
+			Name:      buildFluentBitParserName(trait, p),
+			Labels:    map[string]string{constants.LabelLoggingTrait: trait.Name},
+		},
+		Spec: spec,
+	}
+}
+
+// buildFluentBitParserName builds the name of the Parser of a parser of the trait
+func buildFluentBitParserName(trait *oamv1alpha1.LoggingTrait, p oamv1alpha1.FluentBitParser) string {
+	return fmt.Sprintf("%s-%s-parser", trait.Name, p.Name)
+}
+
+// createFluentBitFilter creates the Filter applying the multiline rules, the parsers and the field filters of the
+// Fluent Bit logging of the trait to the logs of the workload, in that order.  Nil is returned when the Fluent Bit
+// logging has none of them.
+func createFluentBitFilter(trait *oamv1alpha1.LoggingTrait, spec *oamv1alpha1.FluentBitLogging, matchRegex string) *fluentbitv1alpha2.Filter {
+	keyName := spec.KeyName
+	if len(keyName) == 0 {
+		keyName = defaultFluentBitKey
+	}
+	var items []fluentbitv1alpha2.FilterItem
+	if spec.Multiline != nil && len(spec.Multiline.Parsers) > 0 {
+		items = append(items, fluentbitv1alpha2.FilterItem{Multiline: &filter.Multiline{
+			Multi: &filter.Multi{Parser: strings.Join(spec.Multiline.Parsers, ","), KeyContent: keyName},
+		}})
+	}
+	if len(spec.Parsers) > 0 {
+		var names []string
+		for _, p := range spec.Parsers {
+			names = append(names, buildFluentBitParserName(trait, p))
+		}
+		reserveData := true
+		items = append(items, fluentbitv1alpha2.FilterItem{Parser: &filter.Parser{
+			KeyName:     keyName,
+			Parser:      strings.Join(names, ","),
+			ReserveData: &reserveData,
+		}})
+	}
+	if rules := createFieldRules(spec.Fields); len(rules) > 0 {
+		items = append(items, fluentbitv1alpha2.FilterItem{Modify: &filter.Modify{Rules: rules}})
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	return &fluentbitv1alpha2.Filter{
+		TypeMeta: metav1.TypeMeta{APIVersion: fluentBitAPIVersion, Kind: fluentBitFilterKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: trait.Namespace,
+			Name:      trait.Name + "-filter",
+			Labels:    map[string]string{constants.LabelLoggingTrait: trait.Name},
+		},
+		Spec: fluentbitv1alpha2.FilterSpec{
+			MatchRegex:  matchRegex,
+			FilterItems: items,
+		},
+	}
+}
+
+// createFieldRules creates the modify filter rules of the field filters, the fields are added first, then renamed
+// and removed
+func createFieldRules(fields *oamv1alpha1.FluentBitFieldFilters) []filter.Rule {
+	if fields == nil {
+		return nil
+	}
+	var rules []filter.Rule
+	if len(fields.Add) > 0 {
+		rules = append(rules, filter.Rule{Add: fields.Add})
+	}
+	if len(fields.Rename) > 0 {
+		rules = append(rules, filter.Rule{Rename: fields.Rename})
+	}
+	for _, field := range fields.Remove {
+		rules = append(rules, filter.Rule{Remove: field})
+	}
+	return rules
+}
+
+// createFluentBitOutput creates the Output sending the logs of the workload with the output plugin configuration of
+// the ClusterOutput of the trait
+func (r *LoggingTraitReconciler) createFluentBitOutput(ctx context.Context, trait *oamv1alpha1.LoggingTrait, matchRegex string) (*fluentbitv1alpha2.Output, error) {
+	clusterOutput := &fluentbitv1alpha2.ClusterOutput{}
+	if err := r.Get(ctx, types.NamespacedName{Name: trait.Spec.FluentBit.Output.ClusterOutput}, clusterOutput); err != nil {
+		return nil, err
+	}
+	spec := *clusterOutput.Spec.DeepCopy()
+	spec.Match = ""
+	spec.MatchRegex = matchRegex
+	return &fluentbitv1alpha2.Output{
+		TypeMeta: metav1.TypeMeta{APIVersion: fluentBitAPIVersion, Kind: fluentBitOutputKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: trait.Namespace,
+			Name:      trait.Name + "-output",
+			Labels:    map[string]string{constants.LabelLoggingTrait: trait.Name},
+		},
+		Spec: spec,
+	}, nil
+}
+
+// fetchWorkloadPodPrefixes returns the prefixes of the names of the pods of the workload.  The pods of WebLogic
+// domains are named after the domain UID, the pods of Coherence clusters after the cluster, and the pods of the other
+// workloads after the child resources of the workload.
+func (r *LoggingTraitReconciler) fetchWorkloadPodPrefixes(ctx context.Context, log vzlog.VerrazzanoLogger, workload *unstructured.Unstructured) []string {
+	switch workload.GetKind() {
+	case weblogicDomainKind:
+		if domainUID, ok, _ := unstructured.NestedString(workload.Object, "spec", "domainUID"); ok && len(domainUID) > 0 {
+			return []string{domainUID}
+		}
+		return []string{workload.GetName()}
+	case coherenceKind:
+		return []string{workload.GetName()}
+	}
+	children, err := vznav.FetchWorkloadChildren(ctx, r, log, workload)
+	if err != nil {
+		log.Errorw(fmt.Sprintf("Failed to retrieve the workloads child resources: %v", err), "workload", workload.UnstructuredContent())
+	}
+	var prefixes []string
+	for _, child := range children {
+		prefixes = append(prefixes, child.GetName())
+	}
+	// If there are no child resources fallback to the workload
+	if len(prefixes) == 0 {
+		prefixes = append(prefixes, workload.GetName())
+	}
+	sort.Strings(prefixes)
+	return prefixes
+}
+
+// buildFluentBitMatchRegex builds the regular expression matching the tags of the logs of the pods with the name
+// prefixes in the namespace.  The Fluent Operator prefixes the regular expression with the hash of the namespace.
+func buildFluentBitMatchRegex(namespace string, prefixes []string) string {
+	var quoted []string
+	for _, prefix := range prefixes {
+		quoted = append(quoted, regexp.QuoteMeta(prefix))
+	}
+	return fmt.Sprintf("%s(?:%s)-[^_]*_%s_.*", fluentBitTagPrefix, strings.Join(quoted, "|"), regexp.QuoteMeta(namespace))
+}
+
+// validateFluentBitLogging validates the Fluent Bit logging of a trait
+func validateFluentBitLogging(spec *oamv1alpha1.FluentBitLogging) error {
+	var errMessages []string
+	names := map[string]bool{}
+	for i, p := range spec.Parsers {
+		field := fmt.Sprintf("fluentBit.parsers[%d]", i)
+		if names[p.Name] {
+			errMessages = append(errMessages, fmt.Sprintf("%s.name %q is not unique", field, p.Name))
+		}
+		names[p.Name] = true
+		for _, msg := range k8sValidations.IsDNS1123Label(p.Name) {
+			errMessages = append(errMessages, fmt.Sprintf("%s.name: %s", field, msg))
+		}
+		switch p.Format {
+		case oamv1alpha1.FluentBitParserFormatRegex:
+			if len(p.Regex) == 0 {
+				errMessages = append(errMessages, fmt.Sprintf("%s.regex must be specified with the %q format", field, p.Format))
+			}
+		case oamv1alpha1.FluentBitParserFormatJSON, oamv1alpha1.FluentBitParserFormatLogfmt:
+			if len(p.Regex) > 0 {
+				errMessages = append(errMessages, fmt.Sprintf("%s.regex cannot be specified with the %q format", field, p.Format))
+			}
+		default:
+			errMessages = append(errMessages, fmt.Sprintf("%s.format must be one of %q, %q or %q", field,
+				oamv1alpha1.FluentBitParserFormatRegex, oamv1alpha1.FluentBitParserFormatJSON, oamv1alpha1.FluentBitParserFormatLogfmt))
+		}
+	}
+	if spec.Multiline != nil && len(spec.Multiline.Parsers) == 0 {
+		errMessages = append(errMessages, "fluentBit.multiline.parsers must not be empty")
+	}
+	errMessages = append(errMessages, validateLoggingProfile(spec)...)
+	if len(errMessages) > 0 {
+		return fmt.Errorf("%s", strings.Join(errMessages, ", "))
+	}
+	return nil
+}
+
+// equalResources returns true if the two lists of resources are identical
+func equalResources(a []oamrt.TypedReference, b []oamrt.TypedReference) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package loggingtrait
+
+import (
+	"context"
+	"testing"
+
+	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	oamcore "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	fluentbitv1alpha2 "github.com/fluent/fluent-operator/v2/apis/fluentbit/v1alpha2"
+	"github.com/fluent/fluent-operator/v2/apis/fluentbit/v1alpha2/plugins/output"
+	asserts "github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	k8sapps "k8s.io/api/apps/v1"
+	k8score "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smeta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestReconcileFluentBit tests the Fluent Bit processing of the logs of a workload
+// GIVEN a logging trait with parsers, multiline rules, field filters and a custom output
+// WHEN the logging trait is reconciled
+// THEN the Fluent Operator resources of the trait are created in the namespace of the trait, and the resources no
+// longer needed are deleted when the trait is updated
+func TestReconcileFluentBit(t *testing.T) {
+	assert := asserts.New(t)
+
+	scheme := runtime.NewScheme()
+	_ = vzapi.AddToScheme(scheme)
+	_ = k8sapps.AddToScheme(scheme)
+	_ = oamcore.SchemeBuilder.AddToScheme(scheme)
+	_ = fluentbitv1alpha2.AddToScheme(scheme)
+
+	deployment := &k8sapps.Deployment{
+		TypeMeta:   k8smeta.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: k8smeta.ObjectMeta{Namespace: namespaceName, Name: deploymentName},
+		Spec: k8sapps.DeploymentSpec{
+			Template: k8score.PodTemplateSpec{Spec: k8score.PodSpec{Containers: []k8score.Container{{Name: "app", Image: "app"}}}},
+		},
+	}
+	clusterOutput := &fluentbitv1alpha2.ClusterOutput{
+		ObjectMeta: k8smeta.ObjectMeta{Name: "loki"},
+		Spec:       fluentbitv1alpha2.OutputSpec{MatchRegex: "kube.*", Loki: &output.Loki{Host: "loki.example.com"}},
+	}
+	trait := &vzapi.LoggingTrait{
+		TypeMeta:   k8smeta.TypeMeta{APIVersion: "oam.verrazzano.io/v1alpha1", Kind: vzapi.LoggingTraitKind},
+		ObjectMeta: k8smeta.ObjectMeta{Namespace: namespaceName, Name: traitName, UID: "test-trait-uid"},
+		Spec: vzapi.LoggingTraitSpec{
+			WorkloadReference: oamrt.TypedReference{APIVersion: "apps/v1", Kind: "Deployment", Name: deploymentName},
+			FluentBit: &vzapi.FluentBitLogging{
+				Multiline: &vzapi.FluentBitMultiline{Parsers: []string{"java"}},
+				Parsers: []vzapi.FluentBitParser{
+					{Name: "access", Format: vzapi.FluentBitParserFormatRegex, Regex: `^(?<host>[^ ]*) (?<path>[^ ]*)$`},
+					{Name: "app", Format: vzapi.FluentBitParserFormatJSON, TimeKey: "time"},
+				},
+				Fields: &vzapi.FluentBitFieldFilters{Add: map[string]string{"team": "hello"}, Remove: []string{"stream"}},
+				Output: &vzapi.FluentBitOutput{ClusterOutput: "loki"},
+			},
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment, clusterOutput, trait).Build()
+	reconciler := LoggingTraitReconciler{Client: cli, Scheme: scheme}
+
+	_, err := reconciler.doReconcile(context.TODO(), trait, vzlog.DefaultLogger())
+	assert.NoError(err)
+
+	config := &fluentbitv1alpha2.FluentBitConfig{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-fbc"}, config))
+	assert.Equal("verrazzano", config.Labels["fluentbit.verrazzano.io/namespace-config"])
+	assert.Equal(traitName, config.Spec.FilterSelector.MatchLabels[constants.LabelLoggingTrait])
+	assert.Equal(traitName, config.OwnerReferences[0].Name)
+
+	parser := &fluentbitv1alpha2.Parser{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-access-parser"}, parser))
+	assert.Equal(`^(?<host>[^ ]*) (?<path>[^ ]*)$`, parser.Spec.Regex.Regex)
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-app-parser"}, parser))
+	assert.Equal("time", parser.Spec.JSON.TimeKey)
+
+	filter := &fluentbitv1alpha2.Filter{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-filter"}, filter))
+	assert.Equal(`kube\.var\.log\.containers\.(?:test-deployment-name)-[^_]*_test-namespace_.*`, filter.Spec.MatchRegex)
+	assert.Len(filter.Spec.FilterItems, 3)
+	assert.Equal("java", filter.Spec.FilterItems[0].Multiline.Parser)
+	assert.Equal(traitName+"-access-parser,"+traitName+"-app-parser", filter.Spec.FilterItems[1].Parser.Parser)
+	assert.Equal("log", filter.Spec.FilterItems[1].Parser.KeyName)
+	assert.True(*filter.Spec.FilterItems[1].Parser.ReserveData)
+	assert.Len(filter.Spec.FilterItems[2].Modify.Rules, 2)
+
+	out := &fluentbitv1alpha2.Output{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-output"}, out))
+	assert.Equal("loki.example.com", out.Spec.Loki.Host)
+	assert.Equal(filter.Spec.MatchRegex, out.Spec.MatchRegex)
+
+	updated := &vzapi.LoggingTrait{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName}, updated))
+	assert.Len(updated.Status.Resources, 5)
+
+	// The resources no longer needed are deleted
+	updated.Spec.FluentBit.Parsers = updated.Spec.FluentBit.Parsers[1:]
+	updated.Spec.FluentBit.Output = nil
+	_, err = reconciler.doReconcile(context.TODO(), updated, vzlog.DefaultLogger())
+	assert.NoError(err)
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-access-parser"}, parser)
+	assert.True(errors.IsNotFound(err))
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-output"}, out)
+	assert.True(errors.IsNotFound(err))
+	assert.Len(updated.Status.Resources, 3)
+
+	// All the resources are deleted when the trait no longer uses Fluent Bit
+	assert.NoError(reconciler.deleteFluentBitResources(context.TODO(), vzlog.DefaultLogger(), updated))
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-fbc"}, config)
+	assert.True(errors.IsNotFound(err))
+	assert.Empty(updated.Status.Resources)
+}
+
+// TestReconcileFluentBitFromSidecar tests switching the logging of a workload from a Fluentd sidecar to Fluent Bit
+// GIVEN a workload with the Fluentd sidecar and ConfigMap injected by a logging trait
+// WHEN the logging trait is updated to use Fluent Bit and reconciled
+// THEN the Fluentd sidecar, its volume and its ConfigMap are removed, and the Fluent Bit resources are created
+func TestReconcileFluentBitFromSidecar(t *testing.T) {
+	assert := asserts.New(t)
+
+	scheme := runtime.NewScheme()
+	_ = vzapi.AddToScheme(scheme)
+	_ = k8sapps.AddToScheme(scheme)
+	_ = k8score.AddToScheme(scheme)
+	_ = oamcore.SchemeBuilder.AddToScheme(scheme)
+	_ = fluentbitv1alpha2.AddToScheme(scheme)
+
+	configMapName := loggingNamePart + "-" + deploymentName + "-deployment"
+	deployment := &k8sapps.Deployment{
+		TypeMeta:   k8smeta.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: k8smeta.ObjectMeta{Namespace: namespaceName, Name: deploymentName},
+		Spec: k8sapps.DeploymentSpec{
+			Template: k8score.PodTemplateSpec{
+				Spec: k8score.PodSpec{
+					Containers: []k8score.Container{{Name: "app", Image: "app"}, {Name: loggingNamePart, Image: "fluentd"}},
+					Volumes: []k8score.Volume{{Name: configMapName, VolumeSource: k8score.VolumeSource{
+						ConfigMap: &k8score.ConfigMapVolumeSource{LocalObjectReference: k8score.LocalObjectReference{Name: configMapName}}}}},
+				},
+			},
+		},
+	}
+	configMap := &k8score.ConfigMap{ObjectMeta: k8smeta.ObjectMeta{Namespace: namespaceName, Name: configMapName}}
+	trait := &vzapi.LoggingTrait{
+		TypeMeta:   k8smeta.TypeMeta{APIVersion: "oam.verrazzano.io/v1alpha1", Kind: vzapi.LoggingTraitKind},
+		ObjectMeta: k8smeta.ObjectMeta{Namespace: namespaceName, Name: traitName, UID: "test-trait-uid"},
+		Spec: vzapi.LoggingTraitSpec{
+			WorkloadReference: oamrt.TypedReference{APIVersion: "apps/v1", Kind: "Deployment", Name: deploymentName},
+			FluentBit:         &vzapi.FluentBitLogging{},
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment, configMap, trait).Build()
+	reconciler := LoggingTraitReconciler{Client: cli, Scheme: scheme}
+
+	_, err := reconciler.doReconcile(context.TODO(), trait, vzlog.DefaultLogger())
+	assert.NoError(err)
+
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: deploymentName}, deployment))
+	assert.Len(deployment.Spec.Template.Spec.Containers, 1)
+	assert.Equal("app", deployment.Spec.Template.Spec.Containers[0].Name)
+	assert.Empty(deployment.Spec.Template.Spec.Volumes)
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: configMapName}, configMap)
+	assert.True(errors.IsNotFound(err))
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-fbc"}, &fluentbitv1alpha2.FluentBitConfig{}))
+}
+
+// TestFetchWorkloadPodPrefixes tests the prefixes of the names of the pods of the workloads
+// GIVEN WebLogic domains with and without a domain UID
+// WHEN the prefixes of the pods are fetched
+// THEN the pods are named after the domain UID, or the name of the domain
+func TestFetchWorkloadPodPrefixes(t *testing.T) {
+	assert := asserts.New(t)
+
+	reconciler := LoggingTraitReconciler{Client: fake.NewClientBuilder().Build()}
+	domain := &unstructured.Unstructured{}
+	domain.SetAPIVersion("weblogic.oracle/v8")
+	domain.SetKind("Domain")
+	domain.SetName("hello-domain")
+	assert.Equal([]string{"hello-domain"}, reconciler.fetchWorkloadPodPrefixes(context.TODO(), vzlog.DefaultLogger(), domain))
+	assert.NoError(unstructured.SetNestedField(domain.Object, "hello", "spec", "domainUID"))
+	assert.Equal([]string{"hello"}, reconciler.fetchWorkloadPodPrefixes(context.TODO(), vzlog.DefaultLogger(), domain))
+}
+
+// TestValidateFluentBitLogging tests the validation of the Fluent Bit logging of a trait
+// GIVEN Fluent Bit logging with invalid parsers and multiline rules
+// WHEN the Fluent Bit logging is validated
+// THEN an error names each invalid setting
+func TestValidateFluentBitLogging(t *testing.T) {
+	assert := asserts.New(t)
+
+	assert.NoError(validateFluentBitLogging(&vzapi.FluentBitLogging{Parsers: []vzapi.FluentBitParser{{Name: "app", Format: vzapi.FluentBitParserFormatLogfmt}}}))
+
+	err := validateFluentBitLogging(&vzapi.FluentBitLogging{
+		Multiline: &vzapi.FluentBitMultiline{},
+		Parsers: []vzapi.FluentBitParser{
+			{Name: "app", Format: vzapi.FluentBitParserFormatRegex},
+			{Name: "app", Format: vzapi.FluentBitParserFormatJSON, Regex: "^$"},
+			{Name: "Bad_Name", Format: "xml"},
+		},
+	})
+	assert.ErrorContains(err, `fluentBit.parsers[0].regex must be specified with the "regex" format`)
+	assert.ErrorContains(err, `fluentBit.parsers[1].name "app" is not unique`)
+	assert.ErrorContains(err, `fluentBit.parsers[1].regex cannot be specified with the "json" format`)
+	assert.ErrorContains(err, "fluentBit.parsers[2].name")
+	assert.ErrorContains(err, "fluentBit.parsers[2].format must be one of")
+	assert.ErrorContains(err, "fluentBit.multiline.parsers must not be empty")
+}
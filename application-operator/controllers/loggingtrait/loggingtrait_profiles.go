@@ -0,0 +1,186 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package loggingtrait
+
+import (
+	"context"
+	"fmt"
+
+	fluentbitv1alpha2 "github.com/fluent/fluent-operator/v2/apis/fluentbit/v1alpha2"
+	oamv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzstring "github.com/verrazzano/verrazzano/pkg/string"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// applicationClusterOutputName is the name of the ClusterOutput sending the application logs to the OpenSearch
+	// of the cluster
+	applicationClusterOutputName = "opensearch-application-clusteroutput"
+	// profileWriteOperation is the write operation of the profile outputs, the index patterns of the profiles match
+	// the data stream template of the application logs and data streams only accept create operations
+	profileWriteOperation = "create"
+)
+
+// loggingProfile is a log parsing profile of the catalog
+type loggingProfile struct {
+	// The Fluent Bit built-in multiline parsers of the profile
+	multiline []string
+	// The parsers extracting the fields of the log lines, tried in order
+	parsers []oamv1alpha1.FluentBitParser
+	// The pattern of the OpenSearch index of the parsed logs, the namespace of the workload is substituted
+	indexPattern string
+}
+
+// weblogicLogRegex matches the entries of the WebLogic server and domain logs, the fields are the ones extracted by
+// the Fluentd sidecar of the WebLogic workloads from the log files.  The profile only parses the entries written to
+// the standard output, the log files are still parsed by the Fluentd sidecar.
+const weblogicLogRegex = `^####<(?<timestamp>.*?)> <(?<level>.*?)> <(?<subSystem>.*?)> <(?<machineName>.*?)> ` +
+	`<(?<serverName>.*?)> <(?<threadName>.*?)> <(?<userId>.*?)> <(?<transactionId>.*?)> ` +
+	`<(?<diagnosticContextId>.*?)> <(?<rawTime>.*?)> <\[severity-value: (?<severity>\d+)\].*?> ` +
+	`<(?<messageID>.*?)> <(?<message>[\s\S]*?)>\s*$`
+
+// weblogicStdoutRegex matches the entries of the WebLogic server logs written to the standard output
+const weblogicStdoutRegex = `^<(?<timestamp>.*?)> <(?<level>.*?)> <(?<subSystem>.*?)> <(?<messageID>.*?)> <(?<message>[\s\S]*?)>\s*$`
+
+// weblogicAccessRegex matches the entries of the WebLogic HTTP access logs, in common log format
+const weblogicAccessRegex = `^(?<host>\S+) (?<ident>\S+) (?<user>\S+) \[(?<time>[^\]]*)\] "(?<method>\S+)(?: +(?<path>[^"]*?)(?: +(?<protocol>\S+))?)?" (?<code>\d{3}) (?<size>\S+)`
+
+// helidonConsoleRegex matches the entries of the Helidon console log handler
+const helidonConsoleRegex = `^(?<timestamp>\d{4}\.\d{2}\.\d{2} \d{2}:\d{2}:\d{2}) (?<level>[A-Z]+) (?<logger>\S+) Thread\[(?<thread>[^\]]*)\]: (?<message>[\s\S]*)$`
+
+// coherenceLogRegex matches the entries of the Coherence logs
+const coherenceLogRegex = `^(?<timestamp>\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\.\d{3})/(?<uptime>[\d.]+) (?<product>.+?) ` +
+	`<(?<level>[^>]+)> \(thread=(?<thread>[^,]+), member=(?<member>[^)]+)\):\s*(?<message>[\s\S]*)$`
+
+// loggingProfiles is the catalog of the log parsing profiles, by name
+var loggingProfiles = map[string]loggingProfile{
+	oamv1alpha1.LoggingProfileWebLogic: {
+		multiline: []string{"java"},
+		parsers: []oamv1alpha1.FluentBitParser{
+			{Name: "weblogic-server", Format: oamv1alpha1.FluentBitParserFormatRegex, Regex: weblogicLogRegex},
+			{Name: "weblogic-stdout", Format: oamv1alpha1.FluentBitParserFormatRegex, Regex: weblogicStdoutRegex},
+			{Name: "weblogic-access", Format: oamv1alpha1.FluentBitParserFormatRegex, Regex: weblogicAccessRegex, TimeKey: "time", TimeFormat: "%d/%b/%Y:%H:%M:%S %z"},
+		},
+		indexPattern: "verrazzano-application-%s.weblogic",
+	},
+	oamv1alpha1.LoggingProfileHelidon: {
+		multiline: []string{"java"},
+		parsers: []oamv1alpha1.FluentBitParser{
+			{Name: "helidon-json", Format: oamv1alpha1.FluentBitParserFormatJSON},
+			{Name: "helidon-console", Format: oamv1alpha1.FluentBitParserFormatRegex, Regex: helidonConsoleRegex, TimeKey: "timestamp", TimeFormat: "%Y.%m.%d %H:%M:%S"},
+		},
+		indexPattern: "verrazzano-application-%s.helidon",
+	},
+	oamv1alpha1.LoggingProfileCoherence: {
+		multiline: []string{"java"},
+		parsers: []oamv1alpha1.FluentBitParser{
+			{Name: "coherence", Format: oamv1alpha1.FluentBitParserFormatRegex, Regex: coherenceLogRegex, TimeKey: "timestamp", TimeFormat: "%Y-%m-%d %H:%M:%S.%L"},
+		},
+		indexPattern: "verrazzano-application-%s.coherence",
+	},
+	oamv1alpha1.LoggingProfileJSON: {
+		parsers: []oamv1alpha1.FluentBitParser{
+			{Name: "json", Format: oamv1alpha1.FluentBitParserFormatJSON},
+		},
+		indexPattern: "verrazzano-application-%s.json",
+	},
+	oamv1alpha1.LoggingProfileLogfmt: {
+		parsers: []oamv1alpha1.FluentBitParser{
+			{Name: "logfmt", Format: oamv1alpha1.FluentBitParserFormatLogfmt},
+		},
+		indexPattern: "verrazzano-application-%s.logfmt",
+	},
+}
+
+// applyLoggingProfile returns the Fluent Bit logging of a trait with the multiline rules and the parsers of its
+// profile.  The parsers of the trait are tried before the ones of the profile.
+func applyLoggingProfile(spec *oamv1alpha1.FluentBitLogging) *oamv1alpha1.FluentBitLogging {
+	profile, ok := loggingProfiles[spec.Profile]
+	if !ok {
+		return spec
+	}
+	merged := spec.DeepCopy()
+	if len(profile.multiline) > 0 {
+		if merged.Multiline == nil {
+			merged.Multiline = &oamv1alpha1.FluentBitMultiline{}
+		}
+		for _, name := range profile.multiline {
+			if !vzstring.SliceContainsString(merged.Multiline.Parsers, name) {
+				merged.Multiline.Parsers = append(merged.Multiline.Parsers, name)
+			}
+		}
+	}
+	merged.Parsers = append(merged.Parsers, profile.parsers...)
+	return merged
+}
+
+// buildProfileIndex builds the name of the OpenSearch index of the logs of a namespace parsed by a profile.  Names of
+// namespaces cannot contain dots, so the index names differ from the ones of the application data streams.  The
+// logs sent to the index of the profile are also sent to the application data stream by the application
+// ClusterOutput, which is why the index of the profile is opt-in: it doubles the OpenSearch storage of the logs.
+func buildProfileIndex(profileName string, namespace string) string {
+	return fmt.Sprintf(loggingProfiles[profileName].indexPattern, namespace)
+}
+
+// createFluentBitProfileOutput creates the Output sending the logs of the workload parsed by the profile of the trait
+// to the index of the profile, with the OpenSearch configuration of the application ClusterOutput, when the trait
+// enables the index of the profile.  Nil is returned when the cluster does not send the application logs to OpenSearch.
+func (r *LoggingTraitReconciler) createFluentBitProfileOutput(ctx context.Context, log vzlog.VerrazzanoLogger, trait *oamv1alpha1.LoggingTrait, matchRegex string) (*fluentbitv1alpha2.Output, error) {
+	clusterOutput := &fluentbitv1alpha2.ClusterOutput{}
+	err := r.Get(ctx, types.NamespacedName{Name: applicationClusterOutputName}, clusterOutput)
+	if k8serrors.IsNotFound(err) || (err == nil && clusterOutput.Spec.OpenSearch == nil) {
+		log.Infof("The ClusterOutput %s does not send the logs to OpenSearch, the logs parsed by the profile %s of the logging trait %s are not indexed",
+			applicationClusterOutputName, trait.Spec.FluentBit.Profile, trait.Name)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	spec := fluentbitv1alpha2.OutputSpec{
+		MatchRegex: matchRegex,
+		RetryLimit: clusterOutput.Spec.RetryLimit,
+		OpenSearch: clusterOutput.Spec.OpenSearch.DeepCopy(),
+	}
+	spec.OpenSearch.Index = buildProfileIndex(trait.Spec.FluentBit.Profile, trait.Namespace)
+	spec.OpenSearch.LogstashFormat = nil
+	spec.OpenSearch.WriteOperation = profileWriteOperation
+	return &fluentbitv1alpha2.Output{
+		TypeMeta: metav1.TypeMeta{APIVersion: fluentBitAPIVersion, Kind: fluentBitOutputKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: trait.Namespace,
+			Name:      trait.Name + "-profile-output",
+			Labels:    map[string]string{constants.LabelLoggingTrait: trait.Name},
+		},
+		Spec: spec,
+	}, nil
+}
+
+// validateLoggingProfile validates the profile of the Fluent Bit logging of a trait
+func validateLoggingProfile(spec *oamv1alpha1.FluentBitLogging) []string {
+	if len(spec.Profile) == 0 {
+		if spec.ProfileIndex {
+			return []string{"fluentBit.profileIndex requires a fluentBit.profile"}
+		}
+		return nil
+	}
+	profile, ok := loggingProfiles[spec.Profile]
+	if !ok {
+		return []string{fmt.Sprintf("fluentBit.profile %q is not one of %q, %q, %q, %q or %q", spec.Profile,
+			oamv1alpha1.LoggingProfileWebLogic, oamv1alpha1.LoggingProfileHelidon, oamv1alpha1.LoggingProfileCoherence,
+			oamv1alpha1.LoggingProfileJSON, oamv1alpha1.LoggingProfileLogfmt)}
+	}
+	var errMessages []string
+	for i, p := range spec.Parsers {
+		for _, pp := range profile.parsers {
+			if p.Name == pp.Name {
+				errMessages = append(errMessages, fmt.Sprintf("fluentBit.parsers[%d].name %q is used by the profile %q", i, p.Name, spec.Profile))
+			}
+		}
+	}
+	return errMessages
+}
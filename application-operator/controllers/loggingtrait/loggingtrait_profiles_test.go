@@ -0,0 +1,228 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package loggingtrait
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	fluentbitv1alpha2 "github.com/fluent/fluent-operator/v2/apis/fluentbit/v1alpha2"
+	"github.com/fluent/fluent-operator/v2/apis/fluentbit/v1alpha2/plugins/output"
+	asserts "github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	k8sapps "k8s.io/api/apps/v1"
+	k8score "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smeta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestReconcileLoggingProfile tests the Fluent Bit processing of the logs of a workload with a log parsing profile
+// GIVEN a logging trait with the WebLogic profile, the index of the profile enabled and a parser of its own
+// WHEN the logging trait is reconciled
+// THEN the parsers of the trait and of the profile are applied to the logs of the workload, and the parsed logs are
+// sent to the OpenSearch index of the profile
+func TestReconcileLoggingProfile(t *testing.T) {
+	assert := asserts.New(t)
+
+	clusterOutput := &fluentbitv1alpha2.ClusterOutput{
+		ObjectMeta: k8smeta.ObjectMeta{Name: applicationClusterOutputName},
+		Spec: fluentbitv1alpha2.OutputSpec{
+			MatchRegex: "^(?!.*_verrazzano-).*$",
+			RetryLimit: "no_limits",
+			OpenSearch: &output.OpenSearch{Host: "verrazzano-authproxy-opensearch"},
+		},
+	}
+	trait := newProfileTrait(vzapi.LoggingProfileWebLogic)
+	trait.Spec.FluentBit.ProfileIndex = true
+	trait.Spec.FluentBit.Parsers = []vzapi.FluentBitParser{{Name: "app", Format: vzapi.FluentBitParserFormatJSON}}
+	cli := fake.NewClientBuilder().WithScheme(newProfileScheme()).WithObjects(newProfileDeployment(), clusterOutput, trait).Build()
+	reconciler := LoggingTraitReconciler{Client: cli, Scheme: cli.Scheme()}
+
+	_, err := reconciler.doReconcile(context.TODO(), trait, vzlog.DefaultLogger())
+	assert.NoError(err)
+
+	for _, name := range []string{"app", "weblogic-server", "weblogic-stdout", "weblogic-access"} {
+		parser := &fluentbitv1alpha2.Parser{}
+		assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-" + name + "-parser"}, parser))
+	}
+	filter := &fluentbitv1alpha2.Filter{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-filter"}, filter))
+	assert.Equal("java", filter.Spec.FilterItems[0].Multiline.Parser)
+	assert.True(strings.HasPrefix(filter.Spec.FilterItems[1].Parser.Parser, traitName+"-app-parser,"+traitName+"-weblogic-server-parser"))
+
+	out := &fluentbitv1alpha2.Output{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-profile-output"}, out))
+	assert.Equal("verrazzano-application-test-namespace.weblogic", out.Spec.OpenSearch.Index)
+	assert.Equal("create", out.Spec.OpenSearch.WriteOperation)
+	assert.Equal("verrazzano-authproxy-opensearch", out.Spec.OpenSearch.Host)
+	assert.Equal("no_limits", out.Spec.RetryLimit)
+	assert.Equal(filter.Spec.MatchRegex, out.Spec.MatchRegex)
+
+	// The resources of the profile are deleted when the trait no longer uses the profile
+	updated := &vzapi.LoggingTrait{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName}, updated))
+	updated.Spec.FluentBit.Profile = ""
+	updated.Spec.FluentBit.ProfileIndex = false
+	_, err = reconciler.doReconcile(context.TODO(), updated, vzlog.DefaultLogger())
+	assert.NoError(err)
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-profile-output"}, out)
+	assert.True(errors.IsNotFound(err))
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-weblogic-server-parser"}, &fluentbitv1alpha2.Parser{})
+	assert.True(errors.IsNotFound(err))
+}
+
+// TestReconcileLoggingProfileWithoutIndex tests a log parsing profile without the index of the profile
+// GIVEN a logging trait with the Helidon profile and the application ClusterOutput
+// WHEN the logging trait is reconciled
+// THEN the logs are parsed but no output is created for the profile, the logs are only sent to the application
+// data stream
+func TestReconcileLoggingProfileWithoutIndex(t *testing.T) {
+	assert := asserts.New(t)
+
+	clusterOutput := &fluentbitv1alpha2.ClusterOutput{
+		ObjectMeta: k8smeta.ObjectMeta{Name: applicationClusterOutputName},
+		Spec:       fluentbitv1alpha2.OutputSpec{OpenSearch: &output.OpenSearch{Host: "verrazzano-authproxy-opensearch"}},
+	}
+	trait := newProfileTrait(vzapi.LoggingProfileHelidon)
+	cli := fake.NewClientBuilder().WithScheme(newProfileScheme()).WithObjects(newProfileDeployment(), clusterOutput, trait).Build()
+	reconciler := LoggingTraitReconciler{Client: cli, Scheme: cli.Scheme()}
+
+	_, err := reconciler.doReconcile(context.TODO(), trait, vzlog.DefaultLogger())
+	assert.NoError(err)
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-helidon-json-parser"}, &fluentbitv1alpha2.Parser{}))
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-profile-output"}, &fluentbitv1alpha2.Output{})
+	assert.True(errors.IsNotFound(err))
+}
+
+// TestReconcileLoggingProfileWithoutOpenSearch tests a log parsing profile when the cluster has no OpenSearch
+// GIVEN a logging trait with the Coherence profile, the index of the profile enabled and no application ClusterOutput
+// WHEN the logging trait is reconciled
+// THEN the logs are parsed but no output is created for the profile
+func TestReconcileLoggingProfileWithoutOpenSearch(t *testing.T) {
+	assert := asserts.New(t)
+
+	trait := newProfileTrait(vzapi.LoggingProfileCoherence)
+	trait.Spec.FluentBit.ProfileIndex = true
+	cli := fake.NewClientBuilder().WithScheme(newProfileScheme()).WithObjects(newProfileDeployment(), trait).Build()
+	reconciler := LoggingTraitReconciler{Client: cli, Scheme: cli.Scheme()}
+
+	_, err := reconciler.doReconcile(context.TODO(), trait, vzlog.DefaultLogger())
+	assert.NoError(err)
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-coherence-parser"}, &fluentbitv1alpha2.Parser{}))
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-profile-output"}, &fluentbitv1alpha2.Output{})
+	assert.True(errors.IsNotFound(err))
+}
+
+// TestLoggingProfileParsers tests the parsers of the log parsing profiles
+// GIVEN sample log lines of WebLogic, Helidon and Coherence
+// WHEN the log lines are matched by the regular expressions of the parsers of the profiles
+// THEN the fields of the log lines are extracted
+func TestLoggingProfileParsers(t *testing.T) {
+	assert := asserts.New(t)
+
+	tests := []struct {
+		parser string
+		line   string
+		fields map[string]string
+	}{
+		{
+			parser: "weblogic-server",
+			line: "####<Mar 1, 2023 12:00:00,000 PM UTC> <Notice> <WebLogicServer> <hello-adminserver> <AdminServer> " +
+				"<main> <<WLS Kernel>> <> <abc-123> <1677672000000> <[severity-value: 32] [partition-id: 0] [partition-name: DOMAIN] > " +
+				"<BEA-000365> <Server state changed to RUNNING.>",
+			fields: map[string]string{"level": "Notice", "userId": "<WLS Kernel>", "severity": "32", "messageID": "BEA-000365", "message": "Server state changed to RUNNING."},
+		},
+		{
+			parser: "weblogic-stdout",
+			line:   "<Mar 1, 2023 12:00:00,000 PM UTC> <Notice> <WebLogicServer> <BEA-000365> <Server state changed to RUNNING.>",
+			fields: map[string]string{"level": "Notice", "subSystem": "WebLogicServer", "messageID": "BEA-000365"},
+		},
+		{
+			parser: "weblogic-access",
+			line:   `10.0.0.1 - - [01/Mar/2023:12:00:00 +0000] "GET /todo/rest/items HTTP/1.1" 200 42`,
+			fields: map[string]string{"host": "10.0.0.1", "method": "GET", "path": "/todo/rest/items", "protocol": "HTTP/1.1", "code": "200", "size": "42"},
+		},
+		{
+			parser: "helidon-console",
+			line:   "2023.03.01 12:00:00 INFO io.helidon.webserver.NettyWebServer Thread[main,5,main]: Channel '@default' started",
+			fields: map[string]string{"level": "INFO", "logger": "io.helidon.webserver.NettyWebServer", "thread": "main,5,main"},
+		},
+		{
+			parser: "coherence",
+			line:   "2023-03-01 12:00:00.000/1.234 Oracle Coherence GE 14.1.1.0.0 <Info> (thread=main, member=1): Started cluster",
+			fields: map[string]string{"level": "Info", "thread": "main", "member": "1", "message": "Started cluster"},
+		},
+	}
+	parsers := map[string]vzapi.FluentBitParser{}
+	for _, profile := range loggingProfiles {
+		for _, p := range profile.parsers {
+			parsers[p.Name] = p
+		}
+	}
+	for _, tt := range tests {
+		t.Run(tt.parser, func(t *testing.T) {
+			// Fluent Bit uses the Onigmo syntax of named groups, the Go syntax is used for the test
+			re := regexp.MustCompile(strings.ReplaceAll(parsers[tt.parser].Regex, "(?<", "(?P<"))
+			match := re.FindStringSubmatch(tt.line)
+			assert.NotNil(match, tt.line)
+			for field, value := range tt.fields {
+				assert.Equal(value, match[re.SubexpIndex(field)], field)
+			}
+		})
+	}
+}
+
+// TestValidateLoggingProfile tests the validation of the log parsing profile of a trait
+// GIVEN Fluent Bit logging with an unknown profile, or parsers named after the parsers of the profile
+// WHEN the Fluent Bit logging is validated
+// THEN an error names each invalid setting
+func TestValidateLoggingProfile(t *testing.T) {
+	assert := asserts.New(t)
+
+	assert.NoError(validateFluentBitLogging(&vzapi.FluentBitLogging{Profile: vzapi.LoggingProfileHelidon}))
+	assert.ErrorContains(validateFluentBitLogging(&vzapi.FluentBitLogging{Profile: "nginx"}), `fluentBit.profile "nginx" is not one of`)
+	assert.ErrorContains(validateFluentBitLogging(&vzapi.FluentBitLogging{ProfileIndex: true}), "fluentBit.profileIndex requires a fluentBit.profile")
+	err := validateFluentBitLogging(&vzapi.FluentBitLogging{
+		Profile: vzapi.LoggingProfileJSON,
+		Parsers: []vzapi.FluentBitParser{{Name: "json", Format: vzapi.FluentBitParserFormatJSON}},
+	})
+	assert.ErrorContains(err, `fluentBit.parsers[0].name "json" is used by the profile "json"`)
+}
+
+func newProfileScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = vzapi.AddToScheme(scheme)
+	_ = k8sapps.AddToScheme(scheme)
+	_ = fluentbitv1alpha2.AddToScheme(scheme)
+	return scheme
+}
+
+func newProfileDeployment() client.Object {
+	return &k8sapps.Deployment{
+		TypeMeta:   k8smeta.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: k8smeta.ObjectMeta{Namespace: namespaceName, Name: deploymentName},
+		Spec: k8sapps.DeploymentSpec{
+			Template: k8score.PodTemplateSpec{Spec: k8score.PodSpec{Containers: []k8score.Container{{Name: "app", Image: "app"}}}},
+		},
+	}
+}
+
+func newProfileTrait(profile string) *vzapi.LoggingTrait {
+	return &vzapi.LoggingTrait{
+		TypeMeta:   k8smeta.TypeMeta{APIVersion: "oam.verrazzano.io/v1alpha1", Kind: vzapi.LoggingTraitKind},
+		ObjectMeta: k8smeta.ObjectMeta{Namespace: namespaceName, Name: traitName, UID: "test-trait-uid"},
+		Spec: vzapi.LoggingTraitSpec{
+			WorkloadReference: oamrt.TypedReference{APIVersion: "apps/v1", Kind: "Deployment", Name: deploymentName},
+			FluentBit:         &vzapi.FluentBitLogging{Profile: profile},
+		},
+	}
+}
@@ -160,6 +160,61 @@ func LoggingTraitFromWorkloadLabels(ctx context.Context, cli client.Reader, log
 	return nil, nil
 }
 
+// TracingTraitFromWorkloadLabels returns the TracingTrait object associated with the workload or nil if
+// there is no associated tracing trait for the workload. If there is an associated tracing trait and the lookup of the
+// trait fails, an error is returned and the reconcile should be retried.
+func TracingTraitFromWorkloadLabels(ctx context.Context, cli client.Reader, log vzlog.VerrazzanoLogger, namespace string, workloadMeta v1.ObjectMeta) (*vzapi.TracingTrait, error) {
+	log.Debugf("Getting tracing trait from OAM labels: %v", workloadMeta.Labels)
+	component, err := ComponentFromWorkloadLabels(ctx, cli, namespace, workloadMeta.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	hasTracingTrait := false
+	for _, t := range component.Traits {
+		u, err := ConvertRawExtensionToUnstructured(&t.Trait)
+		if err != nil {
+			return nil, err
+		}
+
+		if u.GetKind() == vzapi.TracingTraitKind {
+			hasTracingTrait = true
+			tracingTraitList := &vzapi.TracingTraitList{}
+			tracingTraitList.APIVersion = u.GetAPIVersion()
+			tracingTraitList.Kind = u.GetKind()
+
+			if err := cli.List(ctx, tracingTraitList, client.InNamespace(namespace)); err != nil {
+				return nil, err
+			}
+
+			ownerUIDs := make(map[types.UID]struct{}, len(workloadMeta.OwnerReferences))
+			for _, owner := range workloadMeta.OwnerReferences {
+				ownerUIDs[owner.UID] = struct{}{}
+			}
+			log.Debugf("Workload owner UID's: %v", ownerUIDs)
+
+			for _, item := range tracingTraitList.Items {
+				for _, owner := range item.GetOwnerReferences() {
+					log.Debugf("Comparing tracing trait owner with UID: %s and name: %s", owner.UID, item.Spec.WorkloadReference.Name)
+					if _, ok := ownerUIDs[owner.UID]; ok {
+						if workloadMeta.Name == item.Spec.WorkloadReference.Name {
+							log.Debug("Matched Trait")
+							return &item, nil
+						}
+					}
+				}
+			}
+		}
+	}
+
+	if hasTracingTrait {
+		log.Debugf("Unable to lookup associated TracingTrait for workload %s", workloadMeta.Name)
+		return nil, fmt.Errorf("lookup of TracingTrait failed for workload %s", workloadMeta.Name)
+	}
+	log.Debugf("Workload %s has no associated tracing trait", workloadMeta.Name)
+	return nil, nil
+}
+
 // MetricsTraitFromWorkloadLabels returns the MetricsTrait object associated with the workload or nil if
 // there is no associated metrics trait for the workload. If there is an associated metrics trait and the lookup of the
 // trait fails, an error is returned and the reconcile should be retried.
@@ -601,6 +601,70 @@ func TestLoggingTraitFromWorkloadLabels(t *testing.T) {
 	assert.Equal(result.Name, "test-logging-trait")
 }
 
+// TestTracingTraitFromWorkloadLabels tests the TracingTraitFromWorkloadLabels function
+func TestTracingTraitFromWorkloadLabels(t *testing.T) {
+	assert := asserts.New(t)
+
+	logger := vzlog.DefaultLogger()
+	ctx := context.TODO()
+
+	componentName := "unit-test-component"
+	componentNamespace := "unit-test-namespace"
+
+	ownerReferences := []metav1.OwnerReference{{
+		Name: "test-workload-name",
+		UID:  "test-workload-uid"}}
+
+	objectMeta := metav1.ObjectMeta{
+		Labels:          map[string]string{oam.LabelAppComponent: componentName, oam.LabelAppName: "unit-test-app-config"},
+		OwnerReferences: ownerReferences,
+	}
+
+	expectTracingTrait := func(cli *mocks.MockClient, item vzapi.TracingTrait) {
+		cli.EXPECT().
+			Get(gomock.Eq(ctx), gomock.Eq(client.ObjectKey{Namespace: componentNamespace, Name: "unit-test-app-config"}), gomock.Not(gomock.Nil()), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, key client.ObjectKey, appConfig *oamcore.ApplicationConfiguration, opts ...client.GetOption) error {
+				component := oamcore.ApplicationConfigurationComponent{ComponentName: componentName,
+					Traits: []oamcore.ComponentTrait{{
+						Trait: runtime.RawExtension{Object: &item}},
+					}}
+				appConfig.Spec.Components = []oamcore.ApplicationConfigurationComponent{component}
+				return nil
+			})
+		cli.EXPECT().
+			List(gomock.Eq(ctx), gomock.Not(gomock.Nil()), client.InNamespace(componentNamespace)).
+			DoAndReturn(func(ctx context.Context, list *vzapi.TracingTraitList, opts ...client.ListOption) error {
+				list.Items = []vzapi.TracingTrait{item}
+				return nil
+			})
+	}
+
+	// GIVEN a workload with associated TracingTrait but no OwnerReferences
+	// WHEN a call to TracingTraitFromWorkloadLabels is made
+	// THEN return an error
+	mocker := gomock.NewController(t)
+	cli := mocks.NewMockClient(mocker)
+	expectTracingTrait(cli, vzapi.TracingTrait{TypeMeta: metav1.TypeMeta{Kind: vzapi.TracingTraitKind}})
+
+	result, err := TracingTraitFromWorkloadLabels(ctx, cli, logger, componentNamespace, objectMeta)
+	assert.Error(err)
+	assert.Nil(result)
+
+	// GIVEN a workload with associated TracingTrait and OwnerReferences
+	// WHEN a call to TracingTraitFromWorkloadLabels is made
+	// THEN return the associated TracingTrait
+	mocker = gomock.NewController(t)
+	cli = mocks.NewMockClient(mocker)
+	expectTracingTrait(cli, vzapi.TracingTrait{
+		TypeMeta:   metav1.TypeMeta{Kind: vzapi.TracingTraitKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracing-trait", OwnerReferences: ownerReferences},
+	})
+
+	result, err = TracingTraitFromWorkloadLabels(ctx, cli, logger, componentNamespace, objectMeta)
+	assert.NoError(err)
+	assert.Equal("test-tracing-trait", result.Name)
+}
+
 // TestMetricsTraitFromWorkloadLabels tests the MetricsTraitFromWorkloadLabels function
 func TestMetricsTraitFromWorkloadLabels(t *testing.T) {
 	assert := asserts.New(t)
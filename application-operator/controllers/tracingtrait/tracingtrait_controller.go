@@ -0,0 +1,534 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package tracingtrait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/clusters"
+	vznav "github.com/verrazzano/verrazzano/application-operator/controllers/navigation"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/reconcileresults"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	vzlog "github.com/verrazzano/verrazzano/pkg/log"
+	vzlog2 "github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzstring "github.com/verrazzano/verrazzano/pkg/string"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	telemetryapi "istio.io/api/telemetry/v1alpha1"
+	istiotelemetry "istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/rand"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	controllerName = "tracingtrait"
+
+	// The finalizer name used by this controller
+	finalizerName = "tracingtrait.finalizers.verrazzano.io"
+
+	// The name of the Istio Telemetry resource holding the tracing settings of a namespace
+	telemetryName       = "verrazzano-tracing"
+	telemetryAPIVersion = "telemetry.istio.io/v1alpha1"
+	telemetryKind       = "Telemetry"
+
+	// Kubernetes resource Kinds
+	weblogicDomainKind = "Domain"
+	coherenceKind      = "Coherence"
+
+	// In code defaults for tracing trait configuration
+	defaultSamplingPercentage = 100
+	collectorOTLPGRPCPort     = 4317
+
+	// The roles of the resources related to a tracing trait
+	tracedRole    = "traced"
+	telemetryRole = "telemetry"
+)
+
+// OpenTelemetry SDK environment variables set on the containers of the workload
+const (
+	otelServiceName        = "OTEL_SERVICE_NAME"
+	otelResourceAttributes = "OTEL_RESOURCE_ATTRIBUTES"
+	otelTracesExporter     = "OTEL_TRACES_EXPORTER"
+	otelExporterEndpoint   = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	otelExporterProtocol   = "OTEL_EXPORTER_OTLP_PROTOCOL"
+	otelTracesSampler      = "OTEL_TRACES_SAMPLER"
+	otelTracesSamplerArg   = "OTEL_TRACES_SAMPLER_ARG"
+	otelPropagators        = "OTEL_PROPAGATORS"
+)
+
+// otelEnvNames are the names of the environment variables managed by the tracing trait
+var otelEnvNames = []string{otelServiceName, otelResourceAttributes, otelTracesExporter, otelExporterEndpoint,
+	otelExporterProtocol, otelTracesSampler, otelTracesSamplerArg, otelPropagators}
+
+// otelPropagatorsByFormat are the OpenTelemetry propagators of the propagation formats of the tracing trait
+var otelPropagatorsByFormat = map[string]string{
+	vzapi.TracingPropagationTraceContext: "tracecontext,baggage",
+	vzapi.TracingPropagationB3:           "b3",
+	vzapi.TracingPropagationB3Multi:      "b3multi",
+	vzapi.TracingPropagationJaeger:       "jaeger",
+}
+
+// defaultCollectorEndpoint is the OTLP endpoint of the Jaeger collector installed by Verrazzano
+var defaultCollectorEndpoint = fmt.Sprintf("http://%s-%s.%s.svc.cluster.local:%d", vzconst.JaegerInstanceName,
+	vzconst.JaegerCollectorComponentName, vzconst.VerrazzanoMonitoringNamespace, collectorOTLPGRPCPort)
+
+// Reconciler reconciles a TracingTrait object
+type Reconciler struct {
+	client.Client
+	Log    *zap.SugaredLogger
+	Scheme *runtime.Scheme
+}
+
+// SetupWithManager creates a controller and adds it to the manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&vzapi.TracingTrait{}).
+		Complete(r)
+}
+
+// Reconcile reconciles a tracing trait with related resources
+// +kubebuilder:rbac:groups=oam.verrazzano.io,resources=tracingtraits,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=oam.verrazzano.io,resources=tracingtraits/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets;daemonsets;replicasets,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=telemetry.istio.io,resources=telemetries,verbs=get;list;watch;create;update;patch;delete
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if ctx == nil {
+		return ctrl.Result{}, errors.New("context cannot be nil")
+	}
+
+	// We do not want any resource to get reconciled if it is in namespace kube-system
+	// This is due to a bug found in OKE, it should not affect functionality of any vz operators
+	// If this is the case then return success
+	if req.Namespace == vzconst.KubeSystem {
+		log := zap.S().With(vzlog.FieldResourceNamespace, req.Namespace, vzlog.FieldResourceName, req.Name, vzlog.FieldController, controllerName)
+		log.Infof("Tracing trait resource %v should not be reconciled in kube-system namespace, ignoring", req.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+
+	trait := &vzapi.TracingTrait{}
+	if err := r.Get(ctx, req.NamespacedName, trait); err != nil {
+		return clusters.IgnoreNotFoundWithLog(err, zap.S())
+	}
+	log, err := clusters.GetResourceLogger("tracingtrait", req.NamespacedName, trait)
+	if err != nil {
+		zap.S().Errorf("Failed to create controller logger for tracing trait resource: %v", err)
+		return clusters.NewRequeueWithDelay(), nil
+	}
+	log.Oncef("Reconciling tracing trait resource %v, generation %v", req.NamespacedName, trait.Generation)
+
+	res, err := r.doReconcile(ctx, trait, log)
+	if clusters.ShouldRequeue(res) {
+		return res, nil
+	}
+	// Never return an error since it has already been logged and we don't want the
+	// controller runtime to log again (with stack trace).  Just re-queue if there is an error.
+	if err != nil {
+		return clusters.NewRequeueWithDelay(), nil
+	}
+
+	log.Oncef("Finished reconciling tracing trait %v", req.NamespacedName)
+
+	return ctrl.Result{}, nil
+}
+
+// doReconcile performs the reconciliation operations for the tracing trait
+func (r *Reconciler) doReconcile(ctx context.Context, trait *vzapi.TracingTrait, log vzlog2.VerrazzanoLogger) (ctrl.Result, error) {
+	if trait.DeletionTimestamp.IsZero() {
+		return r.reconcileTraitCreateOrUpdate(ctx, trait, log)
+	}
+	return r.reconcileTraitDelete(ctx, trait, log)
+}
+
+// reconcileTraitCreateOrUpdate reconciles a tracing trait that is being created or updated.  The OpenTelemetry
+// environment variables are set on the containers of the workload, or removed when tracing is disabled, and the
+// tracing settings of the namespace are updated.  The WebLogic domains and Coherence clusters are owned by their
+// workload controllers, which set the environment variables when they update them.
+func (r *Reconciler) reconcileTraitCreateOrUpdate(ctx context.Context, trait *vzapi.TracingTrait, log vzlog2.VerrazzanoLogger) (ctrl.Result, error) {
+	if err := validateTracingTrait(trait); err != nil {
+		log.Errorf("Invalid tracing trait %s: %v", trait.Name, err)
+		return reconcile.Result{}, err
+	}
+	if err := r.addFinalizerIfRequired(ctx, trait, log); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	workload, err := vznav.FetchWorkloadFromTrait(ctx, r, log, trait)
+	if err != nil || workload == nil {
+		return reconcile.Result{}, err
+	}
+
+	var env []corev1.EnvVar
+	if isTracingEnabled(trait) {
+		env = buildTracingEnv(trait, workload)
+	}
+	status := &reconcileresults.ReconcileResults{}
+	if !isTracedByWorkload(trait) {
+		for _, resource := range r.fetchTracedResources(ctx, log, workload) {
+			status.RecordOutcome(r.updateTracingEnv(ctx, resource, env))
+		}
+	}
+	status.RecordOutcome(r.createOrUpdateTelemetry(ctx, trait.Namespace, log))
+	return r.updateTraitStatus(ctx, trait, status, log)
+}
+
+// reconcileTraitDelete reconciles a tracing trait that is being deleted.  The OpenTelemetry environment variables
+// are removed from the containers of the workload, if the workload still exists, and the tracing settings of the
+// namespace are updated.
+func (r *Reconciler) reconcileTraitDelete(ctx context.Context, trait *vzapi.TracingTrait, log vzlog2.VerrazzanoLogger) (ctrl.Result, error) {
+	status := &reconcileresults.ReconcileResults{}
+	workload, err := vznav.FetchWorkloadFromTrait(ctx, r, log, trait)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	if workload != nil && !isTracedByWorkload(trait) {
+		for _, resource := range r.fetchTracedResources(ctx, log, workload) {
+			status.RecordOutcome(r.updateTracingEnv(ctx, resource, nil))
+		}
+	}
+	status.RecordOutcome(r.createOrUpdateTelemetry(ctx, trait.Namespace, log))
+	// Only remove the finalizer if all related resources were successfully updated.
+	if !status.ContainsErrors() {
+		if err := r.removeFinalizerIfRequired(ctx, trait, log); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return r.updateTraitStatus(ctx, trait, status, log)
+}
+
+// addFinalizerIfRequired adds the finalizer to the trait if required
+// The finalizer is only added if the trait is not being deleted and the finalizer has not previously been added
+func (r *Reconciler) addFinalizerIfRequired(ctx context.Context, trait *vzapi.TracingTrait, log vzlog2.VerrazzanoLogger) error {
+	if trait.GetDeletionTimestamp().IsZero() && !vzstring.SliceContainsString(trait.Finalizers, finalizerName) {
+		traitName := vznav.GetNamespacedNameFromObjectMeta(trait.ObjectMeta)
+		log.Debugf("Adding finalizer from trait %s", traitName)
+		_, err := controllerutil.CreateOrUpdate(ctx, r.Client, trait, func() error {
+			trait.Finalizers = append(trait.Finalizers, finalizerName)
+			return nil
+		})
+		if err != nil {
+			return log.ErrorfNewErr("Failed to add finalizer to trait %s: %v", traitName, err)
+		}
+	}
+	return nil
+}
+
+// removeFinalizerIfRequired removes the finalizer from the trait if required
+// The finalizer is only removed if the trait is being deleted and the finalizer had been added
+func (r *Reconciler) removeFinalizerIfRequired(ctx context.Context, trait *vzapi.TracingTrait, log vzlog2.VerrazzanoLogger) error {
+	if !trait.DeletionTimestamp.IsZero() && vzstring.SliceContainsString(trait.Finalizers, finalizerName) {
+		traitName := vznav.GetNamespacedNameFromObjectMeta(trait.ObjectMeta)
+		log.Debugf("Removing finalizer from trait %s", traitName)
+		_, err := controllerutil.CreateOrUpdate(ctx, r.Client, trait, func() error {
+			trait.Finalizers = vzstring.RemoveStringFromSlice(trait.Finalizers, finalizerName)
+			return nil
+		})
+		if err != nil {
+			log.Errorf("Failed to remove finalizer for trait %s: %v", traitName, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// fetchTracedResources returns the resources of the workload whose containers are traced, the child resources of
+// the workload or the workload itself.
+func (r *Reconciler) fetchTracedResources(ctx context.Context, log vzlog2.VerrazzanoLogger, workload *unstructured.Unstructured) []*unstructured.Unstructured {
+	resources, err := vznav.FetchWorkloadChildren(ctx, r, log, workload)
+	if err != nil {
+		log.Errorw(fmt.Sprintf("Failed to retrieve the workloads child resources: %v", err), "workload", workload.UnstructuredContent())
+	}
+	// If there are no child resources fallback to the workload
+	if len(resources) == 0 {
+		resources = append(resources, workload)
+	}
+	var traced []*unstructured.Unstructured
+	for _, resource := range resources {
+		if _, ok := locateEnvFields(resource); ok {
+			traced = append(traced, resource)
+		} else {
+			log.Debugf("The %s %s has no containers to trace", resource.GetKind(), resource.GetName())
+		}
+	}
+	return traced
+}
+
+// updateTracingEnv sets the OpenTelemetry environment variables of the containers of a resource.  The environment
+// variables are removed when the list of environment variables is empty.
+func (r *Reconciler) updateTracingEnv(ctx context.Context, resource *unstructured.Unstructured, env []corev1.EnvVar) (vzapi.QualifiedResourceRelation, controllerutil.OperationResult, error) {
+	rel := vzapi.QualifiedResourceRelation{APIVersion: resource.GetAPIVersion(), Kind: resource.GetKind(), Namespace: resource.GetNamespace(), Name: resource.GetName(), Role: tracedRole}
+	changed, err := mutateTracingEnv(resource, env)
+	if err != nil || !changed {
+		return rel, controllerutil.OperationResultNone, err
+	}
+	if err = r.Update(ctx, resource); err != nil {
+		return rel, controllerutil.OperationResultNone, err
+	}
+	return rel, controllerutil.OperationResultUpdated, nil
+}
+
+// createOrUpdateTelemetry creates or updates the Istio Telemetry resource holding the tracing settings of the
+// namespace.  The requests are sampled at the highest percentage of the enabled tracing traits of the namespace, the
+// Telemetry resource is deleted when the namespace has no enabled tracing trait.
+func (r *Reconciler) createOrUpdateTelemetry(ctx context.Context, namespace string, log vzlog2.VerrazzanoLogger) (vzapi.QualifiedResourceRelation, controllerutil.OperationResult, error) {
+	rel := vzapi.QualifiedResourceRelation{APIVersion: telemetryAPIVersion, Kind: telemetryKind, Namespace: namespace, Name: telemetryName, Role: telemetryRole}
+	traits := vzapi.TracingTraitList{}
+	if err := r.List(ctx, &traits, client.InNamespace(namespace)); err != nil {
+		return rel, controllerutil.OperationResultNone, err
+	}
+	percentage := int32(-1)
+	for i := range traits.Items {
+		trait := &traits.Items[i]
+		if trait.DeletionTimestamp.IsZero() && isTracingEnabled(trait) && getSamplingPercentage(trait) > percentage {
+			percentage = getSamplingPercentage(trait)
+		}
+	}
+
+	telemetry := &istiotelemetry.Telemetry{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: telemetryName}}
+	if percentage < 0 {
+		log.Debugf("Deleting the Telemetry %s/%s, the namespace has no enabled tracing trait", namespace, telemetryName)
+		if err := r.Delete(ctx, telemetry); err != nil && !k8serrors.IsNotFound(err) {
+			return rel, controllerutil.OperationResultNone, err
+		}
+		return rel, controllerutil.OperationResultNone, nil
+	}
+	res, err := common.CreateOrUpdateProtobuf(ctx, r.Client, telemetry, func() error {
+		telemetry.Spec.Tracing = []*telemetryapi.Tracing{
+			{RandomSamplingPercentage: wrapperspb.Double(float64(percentage))},
+		}
+		return nil
+	})
+	return rel, res, err
+}
+
+// updateTraitStatus updates the trait's status conditions and resources if they have changed.
+// The return value can be used as the result of the Reconcile method.
+func (r *Reconciler) updateTraitStatus(ctx context.Context, trait *vzapi.TracingTrait, results *reconcileresults.ReconcileResults, log vzlog2.VerrazzanoLogger) (reconcile.Result, error) {
+	name := vznav.GetNamespacedNameFromObjectMeta(trait.ObjectMeta)
+
+	// If the status content has changed persist the updated status.
+	if trait.DeletionTimestamp.IsZero() && updateStatusIfRequired(&trait.Status, results) {
+		err := r.Status().Update(ctx, trait)
+		if err != nil {
+			return vzlog.IgnoreConflictWithLog(fmt.Sprintf("Failed to update tracing trait %s status", name.Name), err, zap.S())
+		}
+		log.Debugf("Updated tracing trait %s status", name.Name)
+	}
+
+	// If the results contained errors then requeue immediately.
+	if results.ContainsErrors() {
+		vzlog.ResultErrorsWithLog(fmt.Sprintf("Failed to reconcile tracing trait %s", name), results.Errors, zap.S())
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	// If the status has not change and there are no errors
+	// requeue with a jittered delay to account for situations where a workload
+	// changes but without necessarily updating the trait spec.
+	var seconds = rand.IntnRange(45, 90)
+	var duration = time.Duration(seconds) * time.Second
+	log.Debugf("Reconciled tracing trait %s successfully", name.Name)
+	return reconcile.Result{Requeue: true, RequeueAfter: duration}, nil
+}
+
+// updateStatusIfRequired updates the traits status (i.e. resources and conditions) if they have changed.
+// Returns a boolean indicating if status resources or conditions have been updated.
+func updateStatusIfRequired(status *vzapi.TracingTraitStatus, results *reconcileresults.ReconcileResults) bool {
+	updated := false
+	if !vzapi.QualifiedResourceRelationSlicesEquivalent(status.Resources, results.Relations) {
+		status.Resources = results.Relations
+		updated = true
+	}
+	conditionedStatus := results.CreateConditionedStatus()
+	if !reconcileresults.ConditionedStatusEquivalent(&status.ConditionedStatus, &conditionedStatus) {
+		status.ConditionedStatus = conditionedStatus
+		updated = true
+	}
+	return updated
+}
+
+// AddTracingEnv sets the OpenTelemetry environment variables of a tracing trait on the containers of a WebLogic
+// domain or Coherence cluster.  The workload controllers call it when they build the resource, so the tracing trait
+// controller does not update the resources they own.  Nothing is set when the trait is nil, disabled, invalid or
+// being deleted.
+func AddTracingEnv(trait *vzapi.TracingTrait, resource *unstructured.Unstructured) error {
+	if trait == nil || !trait.DeletionTimestamp.IsZero() || !isTracingEnabled(trait) || validateTracingTrait(trait) != nil {
+		return nil
+	}
+	_, err := mutateTracingEnv(resource, buildTracingEnv(trait, resource))
+	return err
+}
+
+// isTracedByWorkload returns true if the environment variables of the workload of the trait are set by the workload
+// controller
+func isTracedByWorkload(trait *vzapi.TracingTrait) bool {
+	kind := trait.Spec.WorkloadReference.Kind
+	return kind == vzconst.VerrazzanoWebLogicWorkloadKind || kind == vzconst.VerrazzanoCoherenceWorkloadKind
+}
+
+// buildTracingEnv builds the OpenTelemetry environment variables of the containers of the workload.  The service
+// name of the traces is the name of the OAM component of the workload.
+func buildTracingEnv(trait *vzapi.TracingTrait, workload *unstructured.Unstructured) []corev1.EnvVar {
+	serviceName := trait.Labels[oam.LabelAppComponent]
+	if len(serviceName) == 0 {
+		serviceName = workload.GetName()
+	}
+	attributes := []string{"k8s.namespace.name=" + trait.Namespace}
+	if appName := trait.Labels[oam.LabelAppName]; len(appName) > 0 {
+		attributes = append(attributes, "service.namespace="+appName)
+	}
+	endpoint := defaultCollectorEndpoint
+	protocol := vzapi.TracingProtocolGRPC
+	if trait.Spec.Exporter != nil {
+		endpoint = trait.Spec.Exporter.Endpoint
+		if len(trait.Spec.Exporter.Protocol) > 0 {
+			protocol = trait.Spec.Exporter.Protocol
+		}
+	}
+	propagation := trait.Spec.Propagation
+	if len(propagation) == 0 {
+		propagation = vzapi.TracingPropagationTraceContext
+	}
+	ratio := strconv.FormatFloat(float64(getSamplingPercentage(trait))/100, 'f', -1, 64)
+	return []corev1.EnvVar{
+		{Name: otelServiceName, Value: serviceName},
+		{Name: otelResourceAttributes, Value: strings.Join(attributes, ",")},
+		{Name: otelTracesExporter, Value: "otlp"},
+		{Name: otelExporterEndpoint, Value: endpoint},
+		{Name: otelExporterProtocol, Value: protocol},
+		{Name: otelTracesSampler, Value: "parentbased_traceidratio"},
+		{Name: otelTracesSamplerArg, Value: ratio},
+		{Name: otelPropagators, Value: otelPropagatorsByFormat[propagation]},
+	}
+}
+
+// mutateTracingEnv replaces the OpenTelemetry environment variables of the containers of a resource.  Returns true
+// if the resource is changed.
+func mutateTracingEnv(resource *unstructured.Unstructured, env []corev1.EnvVar) (bool, error) {
+	paths, ok := locateEnvFields(resource)
+	if !ok {
+		return false, nil
+	}
+	original := resource.DeepCopy()
+	for _, path := range paths {
+		if path.containers {
+			containers, found, err := unstructured.NestedSlice(resource.Object, path.fields...)
+			if err != nil || !found {
+				return false, err
+			}
+			for i := range containers {
+				container, ok := containers[i].(map[string]interface{})
+				if !ok {
+					continue
+				}
+				existing, _, err := unstructured.NestedSlice(container, "env")
+				if err != nil {
+					return false, err
+				}
+				setEnv(container, []string{"env"}, mergeTracingEnv(existing, env))
+			}
+			if err := unstructured.SetNestedSlice(resource.Object, containers, path.fields...); err != nil {
+				return false, err
+			}
+			continue
+		}
+		existing, _, err := unstructured.NestedSlice(resource.Object, path.fields...)
+		if err != nil {
+			return false, err
+		}
+		setEnv(resource.Object, path.fields, mergeTracingEnv(existing, env))
+	}
+	return !reflect.DeepEqual(original.Object, resource.Object), nil
+}
+
+// mergeTracingEnv replaces the OpenTelemetry environment variables of a list of environment variables
+func mergeTracingEnv(existing []interface{}, env []corev1.EnvVar) []interface{} {
+	var merged []interface{}
+	for _, e := range existing {
+		if m, ok := e.(map[string]interface{}); ok && vzstring.SliceContainsString(otelEnvNames, fmt.Sprint(m["name"])) {
+			continue
+		}
+		merged = append(merged, e)
+	}
+	for _, e := range env {
+		merged = append(merged, map[string]interface{}{"name": e.Name, "value": e.Value})
+	}
+	return merged
+}
+
+// setEnv sets a list of environment variables, the field is removed when the list is empty
+func setEnv(obj map[string]interface{}, fields []string, env []interface{}) {
+	if len(env) == 0 {
+		unstructured.RemoveNestedField(obj, fields...)
+		return
+	}
+	_ = unstructured.SetNestedSlice(obj, env, fields...)
+}
+
+// envFields identifies the environment variables of the containers of a resource, either a list of containers or
+// a list of environment variables shared by all the containers
+type envFields struct {
+	fields     []string
+	containers bool
+}
+
+// locateEnvFields locates the environment variables of the containers of a resource
+func locateEnvFields(resource *unstructured.Unstructured) ([]envFields, bool) {
+	switch resource.GetKind() {
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet":
+		return []envFields{
+			{fields: []string{"spec", "template", "spec", "containers"}, containers: true},
+		}, true
+	case "ContainerizedWorkload":
+		return []envFields{
+			{fields: []string{"spec", "containers"}, containers: true},
+		}, true
+	case weblogicDomainKind:
+		return []envFields{{fields: []string{"spec", "serverPod", "env"}}}, true
+	case coherenceKind:
+		return []envFields{{fields: []string{"spec", "env"}}}, true
+	}
+	return nil, false
+}
+
+// isTracingEnabled returns true if tracing is enabled by the trait
+func isTracingEnabled(trait *vzapi.TracingTrait) bool {
+	return trait.Spec.Enabled == nil || *trait.Spec.Enabled
+}
+
+// getSamplingPercentage returns the percentage of the requests traced by the trait
+func getSamplingPercentage(trait *vzapi.TracingTrait) int32 {
+	if trait.Spec.SamplingPercentage == nil {
+		return defaultSamplingPercentage
+	}
+	return *trait.Spec.SamplingPercentage
+}
+
+// validateTracingTrait validates the settings of a tracing trait that are not validated by the schema of the trait
+func validateTracingTrait(trait *vzapi.TracingTrait) error {
+	if trait.Spec.Exporter == nil {
+		return nil
+	}
+	u, err := url.Parse(trait.Spec.Exporter.Endpoint)
+	if err != nil || !u.IsAbs() || len(u.Host) == 0 {
+		return fmt.Errorf("exporter.endpoint %q is not an absolute URL", trait.Spec.Exporter.Endpoint)
+	}
+	return nil
+}
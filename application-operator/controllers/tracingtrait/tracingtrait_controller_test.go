@@ -0,0 +1,266 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package tracingtrait
+
+import (
+	"context"
+	"testing"
+
+	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
+	asserts "github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	istiotelemetry "istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+	k8sapps "k8s.io/api/apps/v1"
+	k8score "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smeta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	namespaceName  = "test-namespace"
+	traitName      = "test-trait-name"
+	deploymentName = "test-deployment-name"
+)
+
+// TestReconcileTracingTrait tests the reconciliation of a tracing trait
+// GIVEN a tracing trait of a Deployment with a sampling percentage and the B3 propagation format
+// WHEN the tracing trait is reconciled
+// THEN the OpenTelemetry environment variables are set on the containers of the Deployment, the other environment
+// variables are kept, and the tracing settings of the namespace are set
+func TestReconcileTracingTrait(t *testing.T) {
+	assert := asserts.New(t)
+
+	trait := newTrait()
+	trait.Spec.SamplingPercentage = int32Ptr(25)
+	trait.Spec.Propagation = vzapi.TracingPropagationB3
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(newDeployment(), trait).Build()
+	reconciler := Reconciler{Client: cli, Scheme: cli.Scheme()}
+
+	res, err := reconciler.doReconcile(context.TODO(), trait, vzlog.DefaultLogger())
+	assert.NoError(err)
+	assert.True(res.Requeue)
+
+	deployment := &k8sapps.Deployment{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: deploymentName}, deployment))
+	env := envMap(deployment.Spec.Template.Spec.Containers[0].Env)
+	assert.Equal("bar", env["FOO"])
+	assert.Equal("hello-component", env[otelServiceName])
+	assert.Equal("k8s.namespace.name=test-namespace,service.namespace=hello-app", env[otelResourceAttributes])
+	assert.Equal(defaultCollectorEndpoint, env[otelExporterEndpoint])
+	assert.Equal("http://jaeger-operator-jaeger-collector.verrazzano-monitoring.svc.cluster.local:4317", env[otelExporterEndpoint])
+	assert.Equal(vzapi.TracingProtocolGRPC, env[otelExporterProtocol])
+	assert.Equal("0.25", env[otelTracesSamplerArg])
+	assert.Equal("b3", env[otelPropagators])
+
+	telemetry := &istiotelemetry.Telemetry{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: telemetryName}, telemetry))
+	assert.Equal(float64(25), telemetry.Spec.Tracing[0].RandomSamplingPercentage.GetValue())
+
+	updated := &vzapi.TracingTrait{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName}, updated))
+	assert.Contains(updated.Finalizers, finalizerName)
+	assert.Len(updated.Status.Resources, 2)
+
+	// The OpenTelemetry environment variables are removed when tracing is disabled
+	updated.Spec.Enabled = boolPtr(false)
+	assert.NoError(cli.Update(context.TODO(), updated))
+	_, err = reconciler.doReconcile(context.TODO(), updated, vzlog.DefaultLogger())
+	assert.NoError(err)
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: deploymentName}, deployment))
+	assert.Equal([]k8score.EnvVar{{Name: "FOO", Value: "bar"}}, deployment.Spec.Template.Spec.Containers[0].Env)
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: telemetryName}, telemetry)
+	assert.True(errors.IsNotFound(err))
+}
+
+// TestReconcileTracingTraitDelete tests the reconciliation of a deleted tracing trait
+// GIVEN a tracing trait being deleted with a custom exporter
+// WHEN the tracing trait is reconciled
+// THEN the OpenTelemetry environment variables are removed from the containers of the Deployment, the tracing
+// settings of the namespace are deleted, and the finalizer is removed
+func TestReconcileTracingTraitDelete(t *testing.T) {
+	assert := asserts.New(t)
+
+	trait := newTrait()
+	trait.Spec.Exporter = &vzapi.TracingExporter{Endpoint: "http://otel-collector.tracing:4318", Protocol: vzapi.TracingProtocolHTTPProtobuf}
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(newDeployment(), trait).Build()
+	reconciler := Reconciler{Client: cli, Scheme: cli.Scheme()}
+
+	_, err := reconciler.doReconcile(context.TODO(), trait, vzlog.DefaultLogger())
+	assert.NoError(err)
+	deployment := &k8sapps.Deployment{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: deploymentName}, deployment))
+	env := envMap(deployment.Spec.Template.Spec.Containers[0].Env)
+	assert.Equal("http://otel-collector.tracing:4318", env[otelExporterEndpoint])
+	assert.Equal(vzapi.TracingProtocolHTTPProtobuf, env[otelExporterProtocol])
+	assert.Equal("1", env[otelTracesSamplerArg])
+	assert.Equal("tracecontext,baggage", env[otelPropagators])
+
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName}, trait))
+	assert.NoError(cli.Delete(context.TODO(), trait))
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName}, trait))
+	_, err = reconciler.doReconcile(context.TODO(), trait, vzlog.DefaultLogger())
+	assert.NoError(err)
+
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: deploymentName}, deployment))
+	assert.Equal([]k8score.EnvVar{{Name: "FOO", Value: "bar"}}, deployment.Spec.Template.Spec.Containers[0].Env)
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: telemetryName}, &istiotelemetry.Telemetry{})
+	assert.True(errors.IsNotFound(err))
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName}, trait)
+	assert.True(errors.IsNotFound(err))
+}
+
+// TestReconcileKubeSystem tests to make sure we do not reconcile
+// Any resource that belong to the kube-system namespace
+func TestReconcileKubeSystem(t *testing.T) {
+	assert := asserts.New(t)
+
+	reconciler := Reconciler{Client: fake.NewClientBuilder().WithScheme(newScheme()).Build()}
+	result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "kube-system", Name: traitName}})
+	assert.NoError(err)
+	assert.True(result.IsZero())
+}
+
+// TestMutateTracingEnv tests the locations of the environment variables of the traced resources
+// GIVEN a WebLogic domain and a Coherence cluster
+// WHEN the OpenTelemetry environment variables are set and then removed
+// THEN the environment variables of the server pods and of the Coherence cluster are updated
+func TestMutateTracingEnv(t *testing.T) {
+	assert := asserts.New(t)
+
+	env := []k8score.EnvVar{{Name: otelServiceName, Value: "hello"}}
+	domain := &unstructured.Unstructured{}
+	domain.SetKind("Domain")
+	assert.NoError(unstructured.SetNestedSlice(domain.Object, []interface{}{map[string]interface{}{"name": "JAVA_OPTIONS", "value": "-Dweblogic.StdoutDebugEnabled=false"}}, "spec", "serverPod", "env"))
+	changed, err := mutateTracingEnv(domain, env)
+	assert.NoError(err)
+	assert.True(changed)
+	domainEnv, _, _ := unstructured.NestedSlice(domain.Object, "spec", "serverPod", "env")
+	assert.Len(domainEnv, 2)
+	changed, err = mutateTracingEnv(domain, env)
+	assert.NoError(err)
+	assert.False(changed)
+
+	coherence := &unstructured.Unstructured{}
+	coherence.SetKind("Coherence")
+	changed, err = mutateTracingEnv(coherence, env)
+	assert.NoError(err)
+	assert.True(changed)
+	changed, err = mutateTracingEnv(coherence, nil)
+	assert.NoError(err)
+	assert.True(changed)
+	_, found, _ := unstructured.NestedSlice(coherence.Object, "spec", "env")
+	assert.False(found)
+
+	service := &unstructured.Unstructured{}
+	service.SetKind("Service")
+	changed, err = mutateTracingEnv(service, env)
+	assert.NoError(err)
+	assert.False(changed)
+}
+
+// TestAddTracingEnv tests the environment variables set by the WebLogic and Coherence workload controllers
+// GIVEN a tracing trait of a VerrazzanoWebLogicWorkload
+// WHEN the workload controller adds the tracing environment variables to the WebLogic domain
+// THEN the environment variables are set on the server pods while the trait is enabled, and the tracing trait
+// controller does not update the domain
+func TestAddTracingEnv(t *testing.T) {
+	assert := asserts.New(t)
+
+	trait := newTrait()
+	trait.Spec.WorkloadReference = oamrt.TypedReference{APIVersion: "oam.verrazzano.io/v1alpha1", Kind: "VerrazzanoWebLogicWorkload", Name: "hello-domain"}
+	assert.True(isTracedByWorkload(trait))
+
+	domain := &unstructured.Unstructured{}
+	domain.SetKind("Domain")
+	domain.SetName("hello-domain")
+	assert.NoError(AddTracingEnv(trait, domain))
+	domainEnv, _, _ := unstructured.NestedSlice(domain.Object, "spec", "serverPod", "env")
+	assert.Len(domainEnv, len(otelEnvNames))
+	assert.Contains(domainEnv, map[string]interface{}{"name": otelServiceName, "value": "hello-component"})
+
+	// Nothing is set without an enabled trait
+	domain = &unstructured.Unstructured{}
+	domain.SetKind("Domain")
+	assert.NoError(AddTracingEnv(nil, domain))
+	trait.Spec.Enabled = boolPtr(false)
+	assert.NoError(AddTracingEnv(trait, domain))
+	_, found, _ := unstructured.NestedSlice(domain.Object, "spec", "serverPod", "env")
+	assert.False(found)
+}
+
+// TestValidateTracingTrait tests the validation of the exporter of a tracing trait
+// GIVEN tracing traits with valid and invalid exporter endpoints
+// WHEN the tracing traits are validated
+// THEN an error is returned for the endpoints that are not absolute URLs
+func TestValidateTracingTrait(t *testing.T) {
+	assert := asserts.New(t)
+
+	trait := newTrait()
+	assert.NoError(validateTracingTrait(trait))
+	trait.Spec.Exporter = &vzapi.TracingExporter{Endpoint: "https://collector.example.com:4317"}
+	assert.NoError(validateTracingTrait(trait))
+	trait.Spec.Exporter = &vzapi.TracingExporter{Endpoint: "collector:4317"}
+	assert.ErrorContains(validateTracingTrait(trait), `exporter.endpoint "collector:4317" is not an absolute URL`)
+}
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = vzapi.AddToScheme(scheme)
+	_ = k8sapps.AddToScheme(scheme)
+	_ = k8score.AddToScheme(scheme)
+	_ = istiotelemetry.AddToScheme(scheme)
+	return scheme
+}
+
+func newDeployment() client.Object {
+	return &k8sapps.Deployment{
+		TypeMeta:   k8smeta.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: k8smeta.ObjectMeta{Namespace: namespaceName, Name: deploymentName},
+		Spec: k8sapps.DeploymentSpec{
+			Template: k8score.PodTemplateSpec{
+				Spec: k8score.PodSpec{
+					Containers: []k8score.Container{{Name: "hello", Image: "hello", Env: []k8score.EnvVar{{Name: "FOO", Value: "bar"}}}},
+				},
+			},
+		},
+	}
+}
+
+func newTrait() *vzapi.TracingTrait {
+	return &vzapi.TracingTrait{
+		TypeMeta: k8smeta.TypeMeta{APIVersion: "oam.verrazzano.io/v1alpha1", Kind: vzapi.TracingTraitKind},
+		ObjectMeta: k8smeta.ObjectMeta{
+			Namespace: namespaceName,
+			Name:      traitName,
+			Labels:    map[string]string{oam.LabelAppName: "hello-app", oam.LabelAppComponent: "hello-component"},
+		},
+		Spec: vzapi.TracingTraitSpec{
+			WorkloadReference: oamrt.TypedReference{APIVersion: "apps/v1", Kind: "Deployment", Name: deploymentName},
+		},
+	}
+}
+
+func envMap(env []k8score.EnvVar) map[string]string {
+	m := map[string]string{}
+	for _, e := range env {
+		m[e.Name] = e.Value
+	}
+	return m
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
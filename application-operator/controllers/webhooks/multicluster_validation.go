@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package webhooks
@@ -6,11 +6,13 @@ package webhooks
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	clusters "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
 	"github.com/verrazzano/verrazzano/application-operator/constants"
 	clusterutil "github.com/verrazzano/verrazzano/application-operator/controllers/clusters"
 	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -19,8 +21,11 @@ import (
 
 func validateMultiClusterResource(c client.Client, r clusterutil.MultiClusterResource) error {
 	p := r.GetPlacement()
-	if len(p.Clusters) == 0 {
-		return fmt.Errorf("One or more target clusters must be provided")
+	if len(p.Clusters) == 0 && p.ClusterSelector == nil {
+		return fmt.Errorf("One or more target clusters or a cluster selector must be provided")
+	}
+	if err := validatePlacementSelector(p); err != nil {
+		return err
 	}
 	if !isLocalClusterManagedCluster(c) {
 		if err := validateTargetClustersExist(c, p); err != nil {
@@ -65,6 +70,69 @@ func validateTargetClustersExist(c client.Client, p clusters.Placement) error {
 	return nil
 }
 
+// validatePlacementSelector validates the cluster selector and the spread of a placement
+func validatePlacementSelector(p clusters.Placement) error {
+	if p.ClusterSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(p.ClusterSelector); err != nil {
+			return fmt.Errorf("invalid cluster selector: %v", err)
+		}
+	}
+	if p.Spread != nil && p.ClusterSelector == nil {
+		return fmt.Errorf("a placement spread requires a cluster selector")
+	}
+	return nil
+}
+
+// managedClusterUserPrefix is the prefix of the names of the service account users of the managed cluster agents on
+// the admin cluster, followed by the name of the managed cluster
+const managedClusterUserPrefix = "system:serviceaccount:" + constants.VerrazzanoMultiClusterNamespace + ":verrazzano-cluster-"
+
+// driftAdoption describes an update of a multicluster resource that has a drift policy
+type driftAdoption struct {
+	// The drift policy of the resource before the update
+	policy clusters.DriftPolicy
+	// The placement of the resource before the update
+	placement clusters.Placement
+	// The adopting cluster before and after the update
+	oldAdoptingCluster string
+	adoptingCluster    string
+	// True if the update changes more than the template and the adopting cluster
+	otherChanges bool
+}
+
+// validateDriftAdoption validates the update of a multicluster resource by the agent of a managed cluster.  The
+// managed clusters may only adopt the changes made to the resource created from the template: the drift policy must
+// be Adopt, the managed cluster must be placed and be the adopting cluster, or the first one to adopt changes, and
+// only the template can change.  The updates made by other users are not restricted.
+func validateDriftAdoption(ctx context.Context, c client.Client, user string, update driftAdoption) error {
+	if !strings.HasPrefix(user, managedClusterUserPrefix) {
+		return nil
+	}
+	clusterName := strings.TrimPrefix(user, managedClusterUserPrefix)
+	if update.policy != clusters.DriftPolicyAdopt {
+		return fmt.Errorf("managed cluster %s can only update resources with the %s drift policy", clusterName, clusters.DriftPolicyAdopt)
+	}
+	if update.otherChanges {
+		return fmt.Errorf("managed cluster %s can only update the template and the adopting cluster", clusterName)
+	}
+	if len(update.oldAdoptingCluster) > 0 && update.oldAdoptingCluster != clusterName {
+		return fmt.Errorf("managed cluster %s is not the adopting cluster %s", clusterName, update.oldAdoptingCluster)
+	}
+	if update.adoptingCluster != clusterName {
+		return fmt.Errorf("managed cluster %s must be set as the adopting cluster", clusterName)
+	}
+	placement, err := clusterutil.ResolvePlacement(ctx, c, update.placement)
+	if err != nil {
+		return fmt.Errorf("failed to resolve the placement: %v", err)
+	}
+	for _, cluster := range placement.Clusters {
+		if cluster.Name == clusterName {
+			return nil
+		}
+	}
+	return fmt.Errorf("managed cluster %s is not in the placement", clusterName)
+}
+
 // translateErrorToResponse translates an error to an admission.Response
 func translateErrorToResponse(err error) admission.Response {
 	if err == nil {
@@ -1,11 +1,13 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package webhooks
 
 import (
 	"encoding/json"
+	"testing"
 
+	"github.com/stretchr/testify/assert"
 	appopclustersapi "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
 	"github.com/verrazzano/verrazzano/application-operator/constants"
 	clustersapi "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
@@ -15,6 +17,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
@@ -103,3 +106,34 @@ func newScheme() *runtime.Scheme {
 	_ = clustersapi.AddToScheme(scheme)
 	return scheme
 }
+
+// TestValidatePlacementSelector tests the validation of placements selecting clusters by labels
+// GIVEN a multi-cluster resource placed by a cluster selector
+// WHEN the multi-cluster resource is validated
+// THEN the validation succeeds without clusters listed by name, and fails for an invalid selector or a spread
+// without a selector
+func TestValidatePlacementSelector(t *testing.T) {
+	asrt := assert.New(t)
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).Build()
+
+	testVP := testProject
+	testVP.Spec.Placement = appopclustersapi.Placement{
+		ClusterSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"region": "eu"}},
+		Spread:          &appopclustersapi.PlacementSpread{TopologyKey: "zone", MaxClustersPerDomain: 1},
+	}
+	asrt.NoError(validateMultiClusterResource(cli, &testVP))
+
+	testVP.Spec.Placement.ClusterSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+		{Key: "region", Operator: "Near"},
+	}}
+	asrt.ErrorContains(validateMultiClusterResource(cli, &testVP), "invalid cluster selector")
+
+	testVP.Spec.Placement = appopclustersapi.Placement{
+		Clusters: []appopclustersapi.Cluster{{Name: constants.DefaultClusterName}},
+		Spread:   &appopclustersapi.PlacementSpread{TopologyKey: "zone", MaxClustersPerDomain: 1},
+	}
+	asrt.EqualError(validateMultiClusterResource(cli, &testVP), "a placement spread requires a cluster selector")
+
+	testVP.Spec.Placement = appopclustersapi.Placement{}
+	asrt.EqualError(validateMultiClusterResource(cli, &testVP), "One or more target clusters or a cluster selector must be provided")
+}
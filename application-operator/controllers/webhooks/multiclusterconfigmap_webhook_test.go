@@ -1,10 +1,11 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package webhooks
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus/testutil"
@@ -232,3 +233,39 @@ func TestMultiClusterConfigmapHandleFailed(t *testing.T) {
 	reconcileFailedCounterAfter := testutil.ToFloat64(reconcileerrorCounterObject.Get())
 	assert.Equal(reconcileFailedCounterBefore, reconcileFailedCounterAfter-1)
 }
+
+// TestMultiClusterConfigMapDriftAdoption tests the validation of the updates of a MultiClusterConfigMap by managed
+// clusters
+// GIVEN a call to validate the update of a MultiClusterConfigMap resource with the Adopt drift policy
+// WHEN the template is updated by the agent of a managed cluster
+// THEN the validation only succeeds when the managed cluster is the adopting cluster
+func TestMultiClusterConfigMapDriftAdoption(t *testing.T) {
+	asrt := assert.New(t)
+	v := newMultiClusterConfigmapValidator()
+	managedCluster := testManagedCluster
+	asrt.NoError(v.client.Create(context.TODO(), &managedCluster))
+
+	old := v1alpha12.MultiClusterConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-mccm-name", Namespace: "application-ns"},
+		Spec: v1alpha12.MultiClusterConfigMapSpec{
+			Placement:   v1alpha12.Placement{Clusters: []v1alpha12.Cluster{{Name: "test-managed-cluster-name"}}},
+			Template:    v1alpha12.ConfigMapTemplate{Data: map[string]string{"key": "value"}},
+			DriftPolicy: v1alpha12.DriftPolicyAdopt,
+		},
+	}
+	updated := *old.DeepCopy()
+	updated.Spec.Template.Data["key"] = "changed"
+	updated.Spec.AdoptingCluster = "test-managed-cluster-name"
+
+	req := newAdmissionRequest(admissionv1.Update, updated)
+	req.OldObject.Raw, _ = json.Marshal(old)
+	req.UserInfo.Username = managedClusterUserPrefix + "test-managed-cluster-name"
+	res := v.Handle(context.TODO(), req)
+	asrt.True(res.Allowed, "Expected multi-cluster configmap update validation to succeed.")
+
+	old.Spec.AdoptingCluster = "other-cluster"
+	req.OldObject.Raw, _ = json.Marshal(old)
+	res = v.Handle(context.TODO(), req)
+	asrt.False(res.Allowed, "Expected multi-cluster configmap update validation to fail.")
+	asrt.Equal("managed cluster test-managed-cluster-name is not the adopting cluster other-cluster", string(res.Result.Reason))
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package webhooks
@@ -6,6 +6,7 @@ package webhooks
 import (
 	"context"
 	"net/http"
+	"reflect"
 
 	"github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
 	"github.com/verrazzano/verrazzano/application-operator/metricsexporter"
@@ -56,8 +57,33 @@ func (v *MultiClusterSecretValidator) Handle(ctx context.Context, req admission.
 				errorCounterMetricObject.Inc(zapLogForMetrics, err)
 				return admission.Denied(err.Error())
 			}
+			if req.Operation == k8sadmission.Update && len(req.OldObject.Raw) > 0 {
+				old := &v1alpha1.MultiClusterSecret{}
+				if err = v.decoder.DecodeRaw(req.OldObject, old); err != nil {
+					errorCounterMetricObject.Inc(zapLogForMetrics, err)
+					return admission.Errored(http.StatusBadRequest, err)
+				}
+				if err = validateDriftAdoption(ctx, v.client, req.UserInfo.Username, newMultiClusterSecretDriftAdoption(old, mcs)); err != nil {
+					errorCounterMetricObject.Inc(zapLogForMetrics, err)
+					return admission.Denied(err.Error())
+				}
+			}
 		}
 	}
 	counterMetricObject.Inc(zapLogForMetrics, err)
 	return admission.Allowed("")
 }
+
+// newMultiClusterSecretDriftAdoption returns the drift adoption of an update of a MultiClusterSecret
+func newMultiClusterSecretDriftAdoption(old *v1alpha1.MultiClusterSecret, updated *v1alpha1.MultiClusterSecret) driftAdoption {
+	spec := updated.Spec.DeepCopy()
+	spec.Template = old.Spec.Template
+	spec.AdoptingCluster = old.Spec.AdoptingCluster
+	return driftAdoption{
+		policy:             old.Spec.DriftPolicy,
+		placement:          old.Spec.Placement,
+		oldAdoptingCluster: old.Spec.AdoptingCluster,
+		adoptingCluster:    updated.Spec.AdoptingCluster,
+		otherChanges:       !reflect.DeepEqual(spec, &old.Spec),
+	}
+}
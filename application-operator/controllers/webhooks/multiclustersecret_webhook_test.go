@@ -1,10 +1,11 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package webhooks
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus/testutil"
@@ -232,3 +233,117 @@ func TestMultiClusterSecretHandleFailed(t *testing.T) {
 	reconcileFailedCounterAfter := testutil.ToFloat64(reconcileerrorCounterObject.Get())
 	assert.Equal(reconcileFailedCounterBefore, reconcileFailedCounterAfter-1)
 }
+
+// TestMultiClusterSecretDriftAdoption tests the validation of the updates of a MultiClusterSecret by managed clusters
+// GIVEN a call to validate the update of a MultiClusterSecret resource
+// WHEN the update is made by the agent of a managed cluster
+// THEN the validation only succeeds when the managed cluster adopts the changes of the template of a
+// MultiClusterSecret with the Adopt drift policy placed in the managed cluster, and is the adopting cluster
+func TestMultiClusterSecretDriftAdoption(t *testing.T) {
+	const managedClusterUser = managedClusterUserPrefix + "test-managed-cluster-name"
+	tests := []struct {
+		name            string
+		user            string
+		policy          v1alpha12.DriftPolicy
+		adoptingCluster string
+		mutate          func(spec *v1alpha12.MultiClusterSecretSpec)
+		allowed         bool
+		reason          string
+	}{
+		{
+			name: "first adoption",
+			user: managedClusterUser,
+			mutate: func(spec *v1alpha12.MultiClusterSecretSpec) {
+				spec.Template.Data = map[string][]byte{"password": []byte("changed")}
+				spec.AdoptingCluster = "test-managed-cluster-name"
+			},
+			allowed: true,
+		},
+		{
+			name: "adoption without the adopting cluster",
+			user: managedClusterUser,
+			mutate: func(spec *v1alpha12.MultiClusterSecretSpec) {
+				spec.Template.Data = map[string][]byte{"password": []byte("changed")}
+			},
+			reason: "managed cluster test-managed-cluster-name must be set as the adopting cluster",
+		},
+		{
+			name:            "adoption from another adopting cluster",
+			user:            managedClusterUser,
+			adoptingCluster: "other-cluster",
+			mutate: func(spec *v1alpha12.MultiClusterSecretSpec) {
+				spec.Template.Data = map[string][]byte{"password": []byte("changed")}
+				spec.AdoptingCluster = "test-managed-cluster-name"
+			},
+			reason: "managed cluster test-managed-cluster-name is not the adopting cluster other-cluster",
+		},
+		{
+			name:   "drift policy not Adopt",
+			user:   managedClusterUser,
+			policy: v1alpha12.DriftPolicyReportOnly,
+			mutate: func(spec *v1alpha12.MultiClusterSecretSpec) {
+				spec.Template.Data = map[string][]byte{"password": []byte("changed")}
+				spec.AdoptingCluster = "test-managed-cluster-name"
+			},
+			reason: "managed cluster test-managed-cluster-name can only update resources with the Adopt drift policy",
+		},
+		{
+			name: "drift policy changed",
+			user: managedClusterUser,
+			mutate: func(spec *v1alpha12.MultiClusterSecretSpec) {
+				spec.AdoptingCluster = "test-managed-cluster-name"
+				spec.DriftPolicy = v1alpha12.DriftPolicyReportOnly
+			},
+			reason: "managed cluster test-managed-cluster-name can only update the template and the adopting cluster",
+		},
+		{
+			name: "not placed",
+			user: managedClusterUserPrefix + "other-cluster",
+			mutate: func(spec *v1alpha12.MultiClusterSecretSpec) {
+				spec.AdoptingCluster = "other-cluster"
+			},
+			reason: "managed cluster other-cluster is not in the placement",
+		},
+		{
+			name: "admin user",
+			user: "admin",
+			mutate: func(spec *v1alpha12.MultiClusterSecretSpec) {
+				spec.DriftPolicy = v1alpha12.DriftPolicyEnforce
+				spec.AdoptingCluster = "other-cluster"
+			},
+			allowed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asrt := assert.New(t)
+			v := newMultiClusterSecretValidator()
+			managedCluster := testManagedCluster
+			asrt.NoError(v.client.Create(context.TODO(), &managedCluster))
+
+			old := v1alpha12.MultiClusterSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-mcsecret-name", Namespace: "application-ns"},
+				Spec: v1alpha12.MultiClusterSecretSpec{
+					Placement:       v1alpha12.Placement{Clusters: []v1alpha12.Cluster{{Name: "test-managed-cluster-name"}}},
+					Template:        v1alpha12.SecretTemplate{Data: map[string][]byte{"password": []byte("secret")}},
+					DriftPolicy:     v1alpha12.DriftPolicyAdopt,
+					AdoptingCluster: tt.adoptingCluster,
+				},
+			}
+			if len(tt.policy) > 0 {
+				old.Spec.DriftPolicy = tt.policy
+			}
+			updated := *old.DeepCopy()
+			tt.mutate(&updated.Spec)
+
+			req := newAdmissionRequest(admissionv1.Update, updated)
+			req.OldObject.Raw, _ = json.Marshal(old)
+			req.UserInfo.Username = tt.user
+			res := v.Handle(context.TODO(), req)
+			asrt.Equal(tt.allowed, res.Allowed)
+			if !tt.allowed {
+				asrt.Equal(tt.reason, string(res.Result.Reason))
+			}
+		})
+	}
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package webhooks
@@ -7,12 +7,16 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"reflect"
 
 	"github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
 	"github.com/verrazzano/verrazzano/application-operator/metricsexporter"
 
 	"github.com/verrazzano/verrazzano/application-operator/constants"
 	k8sadmission "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
@@ -54,7 +58,17 @@ func (v *VerrazzanoProjectValidator) Handle(ctx context.Context, req admission.R
 	if prj.ObjectMeta.DeletionTimestamp.IsZero() {
 		switch req.Operation {
 		case k8sadmission.Create, k8sadmission.Update:
-			return translateErrorToResponse(validateVerrazzanoProject(v.client, prj))
+			if err := validateVerrazzanoProject(v.client, prj); err != nil {
+				return translateErrorToResponse(err)
+			}
+			oldPrj := &v1alpha1.VerrazzanoProject{}
+			if req.Operation == k8sadmission.Update && len(req.OldObject.Raw) > 0 {
+				if err := v.decoder.DecodeRaw(req.OldObject, oldPrj); err != nil {
+					errorCounterMetricObject.Inc(zapLogForMetrics, err)
+					return admission.Errored(http.StatusBadRequest, err)
+				}
+			}
+			return translateErrorToResponse(validateProjectRoleBindingsEscalation(ctx, v.client, req.UserInfo, prj, oldPrj))
 		}
 	}
 	counterMetricObject.Inc(zapLogForMetrics, err)
@@ -76,6 +90,10 @@ func validateVerrazzanoProject(c client.Client, vp *v1alpha1.VerrazzanoProject)
 		return err
 	}
 
+	if err := validateProjectRoleBindings(vp); err != nil {
+		return err
+	}
+
 	if err := validateNamespaceCanBeUsed(c, vp); err != nil {
 		return err
 	}
@@ -104,6 +122,89 @@ func validateNetworkPolicies(vp *v1alpha1.VerrazzanoProject) error {
 	return nil
 }
 
+// validateProjectRoleBindings validates the role bindings specified in the project
+func validateProjectRoleBindings(vp *v1alpha1.VerrazzanoProject) error {
+	names := make(map[string]bool)
+	for _, binding := range vp.Spec.Template.Security.ProjectRoleBindings {
+		if names[binding.Name] {
+			return fmt.Errorf("project role binding name %s is not unique", binding.Name)
+		}
+		names[binding.Name] = true
+		if binding.Role == "" {
+			return fmt.Errorf("project role binding %s must specify a role", binding.Name)
+		}
+		if len(binding.Subjects) == 0 {
+			return fmt.Errorf("project role binding %s must specify one or more subjects", binding.Name)
+		}
+	}
+	return nil
+}
+
+// validateProjectRoleBindingsEscalation validates that the requesting user is allowed to bind the ClusterRole of each
+// role binding created or changed in the project, in all the project namespaces, and of each unchanged role binding
+// in the namespaces added to the project, so that a user cannot grant more privileges than they have through a
+// project.  This is the check done by Kubernetes for the RoleBindings created directly by the user, which does not
+// apply to the RoleBindings created by the operator for the project.
+func validateProjectRoleBindingsEscalation(ctx context.Context, c client.Client, user authenticationv1.UserInfo, vp *v1alpha1.VerrazzanoProject, oldVP *v1alpha1.VerrazzanoProject) error {
+	oldNamespaces := make(map[string]bool)
+	for _, ns := range oldVP.Spec.Template.Namespaces {
+		oldNamespaces[ns.Metadata.Name] = true
+	}
+	for _, binding := range vp.Spec.Template.Security.ProjectRoleBindings {
+		unchanged := containsProjectRoleBinding(oldVP.Spec.Template.Security.ProjectRoleBindings, binding)
+		for _, ns := range vp.Spec.Template.Namespaces {
+			if unchanged && oldNamespaces[ns.Metadata.Name] {
+				continue
+			}
+			allowed, err := canBindClusterRole(ctx, c, user, ns.Metadata.Name, binding.Role)
+			if err != nil {
+				return fmt.Errorf("failed to check the permission to bind the ClusterRole %s of project role binding %s: %v", binding.Role, binding.Name, err)
+			}
+			if !allowed {
+				return fmt.Errorf("user %s is not allowed to bind the ClusterRole %s of project role binding %s in namespace %s", user.Username, binding.Role, binding.Name, ns.Metadata.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// containsProjectRoleBinding returns true if the given role binding is in the list, unchanged
+func containsProjectRoleBinding(bindings []v1alpha1.ProjectRoleBinding, binding v1alpha1.ProjectRoleBinding) bool {
+	for _, b := range bindings {
+		if reflect.DeepEqual(b, binding) {
+			return true
+		}
+	}
+	return false
+}
+
+// canBindClusterRole returns true if the given user is allowed to bind the given ClusterRole in the namespace
+func canBindClusterRole(ctx context.Context, c client.Client, user authenticationv1.UserInfo, namespace string, role string) (bool, error) {
+	extra := make(map[string]authorizationv1.ExtraValue)
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "bind",
+				Group:     rbacv1.GroupName,
+				Resource:  "clusterroles",
+				Name:      role,
+			},
+			User:   user.Username,
+			Groups: user.Groups,
+			UID:    user.UID,
+			Extra:  extra,
+		},
+	}
+	if err := c.Create(ctx, review); err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
+
 func validateNamespaceCanBeUsed(c client.Client, vp *v1alpha1.VerrazzanoProject) error {
 	projectsList := &v1alpha1.VerrazzanoProjectList{}
 	listOptions := &client.ListOptions{Namespace: constants.VerrazzanoMultiClusterNamespace}
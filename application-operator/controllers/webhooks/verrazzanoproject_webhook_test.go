@@ -1,10 +1,11 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package webhooks
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -15,8 +16,11 @@ import (
 	"github.com/verrazzano/verrazzano/application-operator/metricsexporter"
 	"github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
 	admissionv1 "k8s.io/api/admission/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
@@ -162,6 +166,102 @@ func TestNetworkPolicyMissingNamespace(t *testing.T) {
 	asrt.Containsf(res.Result.Reason, "namespace ns1 used in NetworkPolicy net1 does not exist in project", "Error validating VerrazzanProject with NetworkPolicyTemplate")
 }
 
+// TestProjectRoleBindings tests the validation of VerrazzanoProject role bindings
+// GIVEN a call validate VerrazzanoProject role bindings
+// WHEN the role bindings are missing a role or subjects, or their names are not unique
+// THEN the validation should fail
+func TestProjectRoleBindings(t *testing.T) {
+	asrt := assert.New(t)
+
+	subjects := []rbacv1.Subject{{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "developers"}}
+	testVP := v1alpha12.VerrazzanoProject{}
+	testVP.Spec.Template.Security.ProjectRoleBindings = []v1alpha12.ProjectRoleBinding{
+		{Name: "developers", Role: "project-developer", Subjects: subjects},
+		{Name: "operators", Role: "verrazzano-project-monitor", Subjects: subjects},
+	}
+	asrt.NoError(validateProjectRoleBindings(&testVP))
+
+	testVP.Spec.Template.Security.ProjectRoleBindings[1].Name = "developers"
+	asrt.EqualError(validateProjectRoleBindings(&testVP), "project role binding name developers is not unique")
+
+	testVP.Spec.Template.Security.ProjectRoleBindings[1] = v1alpha12.ProjectRoleBinding{Name: "operators", Subjects: subjects}
+	asrt.EqualError(validateProjectRoleBindings(&testVP), "project role binding operators must specify a role")
+
+	testVP.Spec.Template.Security.ProjectRoleBindings[1] = v1alpha12.ProjectRoleBinding{Name: "operators", Role: "view"}
+	asrt.EqualError(validateProjectRoleBindings(&testVP), "project role binding operators must specify one or more subjects")
+}
+
+// bindReviewClient is a client that allows the user to bind the given ClusterRoles in the SubjectAccessReviews
+type bindReviewClient struct {
+	client.Client
+	user  string
+	roles []string
+}
+
+func (c bindReviewClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if review, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+		attributes := review.Spec.ResourceAttributes
+		for _, role := range c.roles {
+			review.Status.Allowed = review.Status.Allowed || (review.Spec.User == c.user && attributes.Verb == "bind" &&
+				attributes.Group == rbacv1.GroupName && attributes.Resource == "clusterroles" && attributes.Name == role)
+		}
+		return nil
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+// TestProjectRoleBindingsEscalation tests the validation of the privileges granted by VerrazzanoProject role bindings
+// GIVEN a call validate VerrazzanoProject on create or update
+// WHEN the role bindings are created or changed by a user who is not allowed to bind their ClusterRole
+// THEN the validation should fail, unless the role bindings and the namespaces they apply to are unchanged
+func TestProjectRoleBindingsEscalation(t *testing.T) {
+	asrt := assert.New(t)
+	v := newVerrazzanoProjectValidator()
+	asrt.NoError(v.client.Create(context.TODO(), &testManagedCluster))
+	v.client = bindReviewClient{Client: v.client, user: "developer", roles: []string{"verrazzano-project-monitor"}}
+
+	subjects := []rbacv1.Subject{{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "developers"}}
+	oldVP := *testProject.DeepCopy()
+	oldVP.Spec.Template.Security.ProjectRoleBindings = []v1alpha12.ProjectRoleBinding{
+		{Name: "admins", Role: "cluster-admin", Subjects: subjects},
+	}
+	testVP := *oldVP.DeepCopy()
+	testVP.Spec.Template.Security.ProjectRoleBindings = append(testVP.Spec.Template.Security.ProjectRoleBindings,
+		v1alpha12.ProjectRoleBinding{Name: "operators", Role: "verrazzano-project-monitor", Subjects: subjects})
+
+	req := newAdmissionRequest(admissionv1.Create, testVP)
+	req.UserInfo.Username = "developer"
+	res := v.Handle(context.TODO(), req)
+	asrt.False(res.Allowed, "Expected project validation to fail for a ClusterRole the user cannot bind.")
+	asrt.Equal("user developer is not allowed to bind the ClusterRole cluster-admin of project role binding admins in namespace newNS1", string(res.Result.Reason))
+
+	// The role bindings that are not changed are not validated again
+	oldRaw, _ := json.Marshal(oldVP)
+	req = newAdmissionRequest(admissionv1.Update, testVP)
+	req.UserInfo.Username = "developer"
+	req.OldObject.Raw = oldRaw
+	res = v.Handle(context.TODO(), req)
+	asrt.True(res.Allowed, "Expected project validation to succeed.")
+
+	// The role bindings that are not changed are validated in the namespaces added to the project
+	addedVP := *testVP.DeepCopy()
+	addedVP.Spec.Template.Namespaces = append(addedVP.Spec.Template.Namespaces, v1alpha12.NamespaceTemplate{Metadata: metav1.ObjectMeta{Name: "addedNS"}})
+	req = newAdmissionRequest(admissionv1.Update, addedVP)
+	req.UserInfo.Username = "developer"
+	req.OldObject.Raw = oldRaw
+	res = v.Handle(context.TODO(), req)
+	asrt.False(res.Allowed, "Expected project validation to fail for a ClusterRole the user cannot bind.")
+	asrt.Equal("user developer is not allowed to bind the ClusterRole cluster-admin of project role binding admins in namespace addedNS", string(res.Result.Reason))
+
+	// A changed role binding is validated again
+	testVP.Spec.Template.Security.ProjectRoleBindings[0].Subjects = append(subjects, rbacv1.Subject{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: "developer"})
+	req = newAdmissionRequest(admissionv1.Update, testVP)
+	req.UserInfo.Username = "developer"
+	req.OldObject.Raw = oldRaw
+	res = v.Handle(context.TODO(), req)
+	asrt.False(res.Allowed, "Expected project validation to fail for a ClusterRole the user cannot bind.")
+}
+
 // TestNamespaceUniquenessForProjects tests that the namespace of a VerrazzanoProject N does not conflict with a preexisting project
 // GIVEN a call validate VerrazzanoProject on create or update
 // WHEN the VerrazzanoProject has a a namespace that conflicts with any pre-existing projects
@@ -1,4 +1,4 @@
-// Copyright (c) 2020, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2020, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package wlsworkload
@@ -15,7 +15,8 @@ const (
 	scratchVolMountPath = "/scratch"
 )
 
-// WlsFluentdParsingRules defines the FLUENTD parsing rules for WLS
+// WlsFluentdParsingRules defines the FLUENTD parsing rules for WLS.  The log parsing profiles of the logging traits
+// are processed by Fluent Bit and do not change these rules, the weblogic profile uses the same fields.
 const WlsFluentdParsingRules = `<match fluent.**>
   @type null
 </match>
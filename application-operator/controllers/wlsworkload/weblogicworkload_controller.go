@@ -27,9 +27,11 @@ import (
 	"github.com/verrazzano/verrazzano/application-operator/controllers/logging"
 	"github.com/verrazzano/verrazzano/application-operator/controllers/metricstrait"
 	vznav "github.com/verrazzano/verrazzano/application-operator/controllers/navigation"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/tracingtrait"
 	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
 	"go.uber.org/zap"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -239,6 +241,7 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 // scopes and traits, and then writes out the CR (or deletes it if the workload is being deleted).
 // +kubebuilder:rbac:groups=oam.verrazzano.io,resources=verrazzanoweblogicworkloads,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=oam.verrazzano.io,resources=verrazzanoweblogicworkloads/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	if ctx == nil {
 		return ctrl.Result{}, errors.New("context cannot be nil")
@@ -330,6 +333,11 @@ func (r *Reconciler) doReconcile(ctx context.Context, workload *vzapi.Verrazzano
 		return reconcile.Result{}, err
 	}
 
+	// Add the tracing environment variables of the tracing trait to the Domain if it exists
+	if err = r.addTracingTrait(ctx, log, workload, u); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// Add the monitoringExporter to the spec if not already present
 	if err = addDefaultMonitoringExporter(u); err != nil {
 		return reconcile.Result{}, err
@@ -372,8 +380,9 @@ func (r *Reconciler) doReconcile(ctx context.Context, workload *vzapi.Verrazzano
 
 	// Create or update Cluster resources
 	for i := range *cus {
-		if err = r.createOrUpdateResource(ctx, workload, log, &((*cus)[i]), func(_ interface{}) error {
-			return nil
+		cu := &((*cus)[i])
+		if err = r.createOrUpdateResource(ctx, workload, log, cu, func(specCopy interface{}) error {
+			return r.mutateClusterSpec(ctx, cu, specCopy)
 		}); err != nil {
 			log.Errorf("Failed creating or updating WebLogic CR: %v", err)
 			return reconcile.Result{}, err
@@ -515,6 +524,41 @@ func (r *Reconciler) createOrUpdateResource(ctx context.Context, workload *vzapi
 	return nil
 }
 
+// mutateClusterSpec sets the spec of a Cluster resource from the workload.  The replicas of an existing Cluster that is
+// scaled by the HorizontalPodAutoscaler of an autoscaler trait are kept, otherwise each reconciliation of the workload
+// would reset the replicas set by the HorizontalPodAutoscaler.
+func (r *Reconciler) mutateClusterSpec(ctx context.Context, u *unstructured.Unstructured, specCopy interface{}) error {
+	replicas, found, err := unstructured.NestedFieldCopy(u.Object, specField, "replicas")
+	if err != nil {
+		return err
+	}
+	if err := unstructured.SetNestedField(u.Object, specCopy, specField); err != nil {
+		return err
+	}
+	if !found || len(u.GetResourceVersion()) == 0 {
+		return nil
+	}
+	autoscaled, err := r.isScaledByAutoscalerTrait(ctx, u)
+	if err != nil || !autoscaled {
+		return err
+	}
+	return unstructured.SetNestedField(u.Object, replicas, specField, "replicas")
+}
+
+// isScaledByAutoscalerTrait returns true if a resource is scaled by the HorizontalPodAutoscaler of an autoscaler trait
+func (r *Reconciler) isScaledByAutoscalerTrait(ctx context.Context, u *unstructured.Unstructured) (bool, error) {
+	hpas := autoscalingv2.HorizontalPodAutoscalerList{}
+	if err := r.List(ctx, &hpas, client.InNamespace(u.GetNamespace()), client.HasLabels{constants.LabelAutoscalerTrait}); err != nil {
+		return false, err
+	}
+	for _, hpa := range hpas.Items {
+		if hpa.Spec.ScaleTargetRef.Kind == u.GetKind() && hpa.Spec.ScaleTargetRef.Name == u.GetName() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // fetchWorkload fetches the VerrazzanoWebLogicWorkload data given a namespaced name
 func (r *Reconciler) fetchWorkload(ctx context.Context, name types.NamespacedName, log *zap.SugaredLogger) (*vzapi.VerrazzanoWebLogicWorkload, error) {
 	var workload vzapi.VerrazzanoWebLogicWorkload
@@ -1004,13 +1048,23 @@ func getDefaultMonitoringExporter() (interface{}, error) {
 	return result, nil
 }
 
+// addTracingTrait adds the OpenTelemetry environment variables of the tracing trait to the server pods of the workload
+func (r *Reconciler) addTracingTrait(ctx context.Context, log vzlog.VerrazzanoLogger, workload *vzapi.VerrazzanoWebLogicWorkload, weblogic *unstructured.Unstructured) error {
+	tracingTrait, err := vznav.TracingTraitFromWorkloadLabels(ctx, r.Client, log, workload.GetNamespace(), workload.ObjectMeta)
+	if err != nil {
+		return err
+	}
+	return tracingtrait.AddTracingEnv(tracingTrait, weblogic)
+}
+
 // addLoggingTrait adds the logging trait sidecar to the workload
 func (r *Reconciler) addLoggingTrait(ctx context.Context, log vzlog.VerrazzanoLogger, workload *vzapi.VerrazzanoWebLogicWorkload, weblogic *unstructured.Unstructured) error {
 	loggingTrait, err := vznav.LoggingTraitFromWorkloadLabels(ctx, r.Client, log, workload.GetNamespace(), workload.ObjectMeta)
 	if err != nil {
 		return err
 	}
-	if loggingTrait == nil {
+	// The logs of a Fluent Bit logging trait are processed by the Fluent Bit daemon set, without a sidecar
+	if loggingTrait == nil || loggingTrait.Spec.FluentBit != nil {
 		return nil
 	}
 	configMapName := loggingNamePart + "-" + weblogic.GetName() + "-" + strings.ToLower(weblogic.GetKind())
@@ -26,6 +26,7 @@ import (
 	"github.com/verrazzano/verrazzano/application-operator/mocks"
 	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
 	"go.uber.org/zap"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -246,7 +247,7 @@ func TestReconcileCreateWebLogicDomain(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, name types.NamespacedName, appConfig *oamcore.ApplicationConfiguration, opts ...client.GetOption) error {
 			appConfig.Spec.Components = []oamcore.ApplicationConfigurationComponent{{ComponentName: componentName}}
 			return nil
-		}).Times(2)
+		}).Times(3)
 	// expect a call to attempt to get the WebLogic CR - return not found
 	cli.EXPECT().
 		Get(gomock.Any(), gomock.Any(), gomock.Not(gomock.Nil()), gomock.Any()).
@@ -377,7 +378,7 @@ func TestReconcileCreateWebLogicDomainV9(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, name types.NamespacedName, appConfig *oamcore.ApplicationConfiguration, opts ...client.GetOption) error {
 			appConfig.Spec.Components = []oamcore.ApplicationConfigurationComponent{{ComponentName: componentName}}
 			return nil
-		}).Times(2)
+		}).Times(3)
 	// expect call to fetch existing WebLogic Cluster cluster-1
 	cli.EXPECT().
 		Get(gomock.Any(), types.NamespacedName{Namespace: namespace, Name: "cluster-1"}, gomock.Not(gomock.Nil()), gomock.Any()).
@@ -542,7 +543,7 @@ func TestReconcileCreateWebLogicDomainWithMonitoringExporter(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, name types.NamespacedName, appConfig *oamcore.ApplicationConfiguration, opts ...client.GetOption) error {
 			appConfig.Spec.Components = []oamcore.ApplicationConfigurationComponent{{ComponentName: componentName}}
 			return nil
-		}).Times(2)
+		}).Times(3)
 	// expect a call to attempt to get the WebLogic CR - return not found
 	cli.EXPECT().
 		Get(gomock.Any(), gomock.Any(), gomock.Not(gomock.Nil()), gomock.Any()).
@@ -680,7 +681,7 @@ func TestReconcileCreateWebLogicDomainWithLogging(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, name types.NamespacedName, appConfig *oamcore.ApplicationConfiguration, opts ...client.GetOption) error {
 			appConfig.Spec.Components = []oamcore.ApplicationConfigurationComponent{{ComponentName: componentName}}
 			return nil
-		}).Times(2)
+		}).Times(3)
 	// expect a call to attempt to get the WebLogic CR - return not found
 	cli.EXPECT().
 		Get(gomock.Any(), gomock.Any(), gomock.Not(gomock.Nil()), gomock.Any()).
@@ -886,7 +887,7 @@ func TestReconcileCreateWebLogicDomainWithCustomLogging(t *testing.T) {
 				},
 			}
 			return nil
-		}).Times(2)
+		}).Times(3)
 	// expect a call to get the ConfigMap for logging - return not found
 	cli.EXPECT().
 		Get(gomock.Any(), gomock.Eq(types.NamespacedName{Namespace: namespace, Name: "logging-stdout-unit-test-cluster-domain"}), gomock.Not(gomock.Nil()), gomock.Any()).
@@ -952,6 +953,188 @@ func TestReconcileCreateWebLogicDomainWithCustomLogging(t *testing.T) {
 	assert.Equal(false, result.Requeue)
 }
 
+// TestReconcileCreateWebLogicDomainWithFluentBitLogging tests reconciling a VerrazzanoWebLogicWorkload with a
+// logging trait processing the logs with Fluent Bit. We expect to write out a WebLogic domain CR without the custom
+// FLUENTD sidecar and ConfigMap of the logging trait.
+// GIVEN a VerrazzanoWebLogicWorkload resource is created with a Fluent Bit logging trait
+// WHEN the controller Reconcile function is called
+// THEN expect a WebLogic domain CR to be written without custom logging extras.
+func TestReconcileCreateWebLogicDomainWithFluentBitLogging(t *testing.T) {
+	assert := asserts.New(t)
+
+	var mocker = gomock.NewController(t)
+	var cli = mocks.NewMockClient(mocker)
+	mockStatus := mocks.NewMockStatusWriter(mocker)
+
+	appConfigName := "unit-test-app-config"
+	componentName := "unit-test-component"
+	workloadName := "unit-test-verrazzano-weblogic-workload"
+	labels := map[string]string{oam.LabelAppComponent: componentName, oam.LabelAppName: appConfigName,
+		constants.LabelWorkloadType: constants.WorkloadTypeWeblogic}
+
+	// expect call to fetch existing WebLogic Domain
+	cli.EXPECT().
+		Get(gomock.Any(), types.NamespacedName{Namespace: namespace, Name: "unit-test-cluster"}, gomock.Not(gomock.Nil()), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, name types.NamespacedName, domain *unstructured.Unstructured, opts ...client.GetOption) error {
+			return k8serrors.NewNotFound(k8sschema.GroupResource{}, "test")
+		})
+	// expect a call to fetch the VerrazzanoWebLogicWorkload
+	cli.EXPECT().
+		Get(gomock.Any(), types.NamespacedName{Namespace: namespace, Name: workloadName}, gomock.Not(gomock.Nil()), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, name types.NamespacedName, workload *vzapi.VerrazzanoWebLogicWorkload, opts ...client.GetOption) error {
+			workload.Spec.Template = buildDomainV8Template(weblogicDomain)
+			workload.ObjectMeta.Labels = labels
+			workload.APIVersion = vzapi.SchemeGroupVersion.String()
+			workload.Kind = vzconst.VerrazzanoWebLogicWorkloadKind
+			workload.Namespace = namespace
+			workload.Name = workloadName
+			workload.ObjectMeta.Generation = 2
+			workload.Status.LastGeneration = "1"
+			workload.OwnerReferences = []metav1.OwnerReference{
+				{
+					UID: namespace,
+				},
+			}
+			return nil
+		})
+	// expect a call to list the logging traits
+	cli.EXPECT().
+		List(gomock.Any(), &vzapi.LoggingTraitList{TypeMeta: metav1.TypeMeta{Kind: "LoggingTrait", APIVersion: "oam.verrazzano.io/v1alpha1"}}, gomock.Not(gomock.Nil())).
+		DoAndReturn(func(ctx context.Context, loggingTraitList *vzapi.LoggingTraitList, inNamespace client.InNamespace) error {
+			loggingTraitList.Items = []vzapi.LoggingTrait{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								UID: namespace,
+							},
+						},
+					},
+					Spec: vzapi.LoggingTraitSpec{
+						FluentBit: &vzapi.FluentBitLogging{},
+						WorkloadReference: oamrt.TypedReference{
+							Name: workloadName,
+						},
+					},
+				},
+			}
+			return nil
+		})
+	// expect a call to list the FLUENTD config maps
+	cli.EXPECT().
+		List(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+			// return no resources
+			return nil
+		})
+	// no config maps found, so expect a call to create a config map with our parsing rules
+	cli.EXPECT().
+		Create(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, configMap *corev1.ConfigMap, opts ...client.CreateOption) error {
+			assert.Equal(strings.Join(strings.Split(WlsFluentdParsingRules, "{{ .CAFile}}"), ""), configMap.Data["fluentd.conf"])
+			return nil
+		})
+	// expect call to fetch the WDT config Map
+	cli.EXPECT().
+		Get(gomock.Any(), types.NamespacedName{Namespace: namespace, Name: getWDTConfigMapName(weblogicDomainName)}, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, name types.NamespacedName, configMap *corev1.ConfigMap, opts ...client.GetOption) error {
+			return k8serrors.NewNotFound(k8sschema.GroupResource{}, getWDTConfigMapName(weblogicDomainName))
+		})
+	// no WDT config maps found, so expect a call to create a WDT config map
+	cli.EXPECT().
+		Create(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, configMap *corev1.ConfigMap, opts ...client.CreateOption) error {
+			bytes, _ := yaml.JSONToYAML([]byte(defaultWDTConfigMapData))
+			assert.Equal(string(bytes), configMap.Data[webLogicPluginConfigYamlKey])
+			return nil
+		})
+	// expect a call to get the namespace for the domain
+	cli.EXPECT().
+		Get(gomock.Any(), gomock.Eq(client.ObjectKey{Namespace: "", Name: namespace}), gomock.Not(gomock.Nil()), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key client.ObjectKey, namespace *corev1.Namespace, opts ...client.GetOption) error {
+			return nil
+		})
+	// expect a call to get the application configuration for the workload
+	cli.EXPECT().
+		Get(gomock.Any(), gomock.Eq(types.NamespacedName{Namespace: namespace, Name: appConfigName}), gomock.Not(gomock.Nil()), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, name types.NamespacedName, appConfig *oamcore.ApplicationConfiguration, opts ...client.GetOption) error {
+			appConfig.Spec.Components = []oamcore.ApplicationConfigurationComponent{
+				{
+					ComponentName: componentName,
+					Traits: []oamcore.ComponentTrait{
+						{
+							Trait: runtime.RawExtension{
+								Raw: []byte(strings.ReplaceAll(strings.ReplaceAll(loggingTrait, " ", ""), "\n", "")),
+							},
+						},
+					},
+				},
+			}
+			return nil
+		}).Times(3)
+	// expect a call to attempt to get the WebLogic CR - return not found
+	cli.EXPECT().
+		Get(gomock.Any(), gomock.Any(), gomock.Not(gomock.Nil()), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key client.ObjectKey, u *unstructured.Unstructured, opts ...client.GetOption) error {
+			return k8serrors.NewNotFound(k8sschema.GroupResource{}, "")
+		})
+	// expect a call to create the WebLogic domain CR
+	cli.EXPECT().
+		Create(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, u *unstructured.Unstructured, opts ...client.CreateOption) error {
+			assert.Equal(APIVersionV8, u.GetAPIVersion())
+			assert.Equal(DomainKind, u.GetKind())
+
+			// make sure the OAM component and app name labels were copied
+			specLabels, _, _ := unstructured.NestedStringMap(u.Object, specServerPodLabelsFields...)
+			assert.Equal(labels, specLabels)
+
+			// make sure configuration.istio.enabled is false
+			specIstioEnabled, _, _ := unstructured.NestedBool(u.Object, specConfigurationIstioEnabledFields...)
+			assert.Equal(specIstioEnabled, false)
+
+			// make sure only the default FLUENTD sidecar was added, without the custom logging ConfigMap
+			containers, _, _ := unstructured.NestedSlice(u.Object, specServerPodContainersFields...)
+			assert.Equal(1, len(containers))
+			volumes, _, _ := unstructured.NestedSlice(u.Object, specServerPodVolumesFields...)
+			for _, volume := range volumes {
+				assert.NotEqual(loggingNamePart+"-unit-test-cluster-domain", volume.(map[string]interface{})["name"])
+			}
+
+			// make sure the restartVersion is empty
+			domainRestartVersion, _, _ := unstructured.NestedString(u.Object, specRestartVersionFields...)
+			assert.Equal("", domainRestartVersion)
+
+			// make sure monitoringExporter exists
+			validateDefaultMonitoringExporter(u, t)
+
+			// make sure default WDT configMap exists
+			validateDefaultWDTConfigMap(u, t)
+
+			return nil
+		})
+
+	// expect a call to status update
+	cli.EXPECT().Status().Return(mockStatus).AnyTimes()
+
+	// Expect a call to update the status of the Verrazzano resource to update components
+	mockStatus.EXPECT().
+		Update(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, wl *vzapi.VerrazzanoWebLogicWorkload, opts ...client.UpdateOption) error {
+			//		asserts.NotZero(len(verrazzano.Status.Components), "Status.Components len should not be zero")
+			return nil
+		})
+
+	// create a request and reconcile it
+	request := newRequest(namespace, "unit-test-verrazzano-weblogic-workload")
+	reconciler := newReconciler(cli)
+	result, err := reconciler.Reconcile(context.TODO(), request)
+
+	mocker.Finish()
+	assert.NoError(err)
+	assert.Equal(false, result.Requeue)
+}
+
 // TestReconcileCreateWebLogicDomainWithCustomLogging tests the happy path of reconciling a VerrazzanoWebLogicWorkload
 // with a custom logging trait. We expect to write out a WebLogic domain CR with an extra FLUENTD sidecar
 // and associated volumes and mounts. This test, we are testing the case when the ConfigMap already exists
@@ -1073,7 +1256,7 @@ func TestReconcileCreateWebLogicDomainWithCustomLoggingConfigMapExists(t *testin
 				},
 			}
 			return nil
-		}).Times(2)
+		}).Times(3)
 	// expect a call to get the ConfigMap for logging
 	cli.EXPECT().
 		Get(gomock.Any(), gomock.Eq(types.NamespacedName{Namespace: namespace, Name: "logging-stdout-unit-test-cluster-domain"}), gomock.Not(gomock.Nil()), gomock.Any()).
@@ -1197,7 +1380,7 @@ func TestReconcileCreateWebLogicDomainWithWDTConfigMap(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, name types.NamespacedName, appConfig *oamcore.ApplicationConfiguration, opts ...client.GetOption) error {
 			appConfig.Spec.Components = []oamcore.ApplicationConfigurationComponent{{ComponentName: componentName}}
 			return nil
-		})
+		}).Times(2)
 
 	// expect call to fetch the WDT config map
 	cli.EXPECT().
@@ -1347,7 +1530,7 @@ func TestReconcileUpdateFluentdImage(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, name types.NamespacedName, appConfig *oamcore.ApplicationConfiguration, opts ...client.GetOption) error {
 			appConfig.Spec.Components = []oamcore.ApplicationConfigurationComponent{{ComponentName: componentName}}
 			return nil
-		}).Times(2)
+		}).Times(3)
 	// expect a call to create the WebLogic domain CR
 	cli.EXPECT().
 		Update(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -1472,7 +1655,7 @@ func TestReconcileErrorOnCreate(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, name types.NamespacedName, appConfig *oamcore.ApplicationConfiguration, opts ...client.GetOption) error {
 			appConfig.Spec.Components = []oamcore.ApplicationConfigurationComponent{{ComponentName: componentName}}
 			return nil
-		}).Times(2)
+		}).Times(3)
 	// expect a call to attempt to get the WebLogic CR - return not found
 	cli.EXPECT().
 		Get(gomock.Any(), gomock.Any(), gomock.Not(gomock.Nil()), gomock.Any()).
@@ -1743,6 +1926,58 @@ func newScheme() *runtime.Scheme {
 
 // newReconciler creates a new reconciler for testing
 // c - The K8s client to inject into the reconciler
+// TestMutateClusterSpec tests the update of the spec of an existing WebLogic Cluster resource
+// GIVEN an existing Cluster whose replicas differ from the replicas of the workload
+// WHEN the spec of the Cluster is set from the workload
+// THEN the replicas of the workload are set, unless the Cluster is scaled by the HorizontalPodAutoscaler of an
+// autoscaler trait
+func TestMutateClusterSpec(t *testing.T) {
+	assert := asserts.New(t)
+
+	var mocker = gomock.NewController(t)
+	var cli = mocks.NewMockClient(mocker)
+	reconciler := newReconciler(cli)
+
+	newCluster := func() *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion(APIVersionV1)
+		u.SetKind(ClusterKind)
+		u.SetNamespace(namespace)
+		u.SetName("cluster-1")
+		u.SetResourceVersion("1")
+		_ = unstructured.SetNestedField(u.Object, int64(5), specField, "replicas")
+		return u
+	}
+	specCopy := map[string]interface{}{"clusterName": "cluster-1", "replicas": int64(2)}
+
+	// expect a call to list the HorizontalPodAutoscalers of the autoscaler traits, one of which scales the Cluster
+	cli.EXPECT().
+		List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, list *autoscalingv2.HorizontalPodAutoscalerList, opts ...client.ListOption) error {
+			list.Items = []autoscalingv2.HorizontalPodAutoscaler{{Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: APIVersionV1, Kind: ClusterKind, Name: "cluster-1"},
+			}}}
+			return nil
+		})
+	u := newCluster()
+	assert.NoError(reconciler.mutateClusterSpec(context.TODO(), u, specCopy))
+	replicas, _, _ := unstructured.NestedInt64(u.Object, specField, "replicas")
+	assert.Equal(int64(5), replicas)
+	clusterName, _, _ := unstructured.NestedString(u.Object, specField, "clusterName")
+	assert.Equal("cluster-1", clusterName)
+
+	// expect a call to list the HorizontalPodAutoscalers of the autoscaler traits, none of which scales the Cluster
+	cli.EXPECT().
+		List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+	u = newCluster()
+	assert.NoError(reconciler.mutateClusterSpec(context.TODO(), u, specCopy))
+	replicas, _, _ = unstructured.NestedInt64(u.Object, specField, "replicas")
+	assert.Equal(int64(2), replicas)
+
+	mocker.Finish()
+}
+
 func newReconciler(c client.Client) Reconciler {
 	scheme := newScheme()
 	metricsReconciler := &metricstrait.Reconciler{Client: c, Scheme: scheme, Scraper: "verrazzano-system/vmi-system-prometheus-0"}
@@ -1946,7 +2181,7 @@ func TestReconcileRestart(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, name types.NamespacedName, appConfig *oamcore.ApplicationConfiguration, opts ...client.GetOption) error {
 			appConfig.Spec.Components = []oamcore.ApplicationConfigurationComponent{{ComponentName: componentName}}
 			return nil
-		}).Times(2)
+		}).Times(3)
 	// expect a call to create the WebLogic domain CR
 	cli.EXPECT().
 		Update(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -2090,7 +2325,7 @@ func TestReconcileStopDomain(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, name types.NamespacedName, appConfig *oamcore.ApplicationConfiguration, opts ...client.GetOption) error {
 			appConfig.Spec.Components = []oamcore.ApplicationConfigurationComponent{{ComponentName: componentName}}
 			return nil
-		}).Times(2)
+		}).Times(3)
 	// expect a call to update the WebLogic domain CR
 	cli.EXPECT().
 		Update(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -2223,7 +2458,7 @@ func TestReconcileStartDomain(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, name types.NamespacedName, appConfig *oamcore.ApplicationConfiguration, opts ...client.GetOption) error {
 			appConfig.Spec.Components = []oamcore.ApplicationConfigurationComponent{{ComponentName: componentName}}
 			return nil
-		}).Times(2)
+		}).Times(3)
 	// expect a call to update the WebLogic domain CR
 	cli.EXPECT().
 		Update(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -2353,7 +2588,7 @@ func TestReconcileUserProvidedLogHome(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, name types.NamespacedName, appConfig *oamcore.ApplicationConfiguration, opts ...client.GetOption) error {
 			appConfig.Spec.Components = []oamcore.ApplicationConfigurationComponent{{ComponentName: componentName}}
 			return nil
-		}).Times(2)
+		}).Times(3)
 	// expect a call to attempt to get the WebLogic CR - return not found
 	cli.EXPECT().
 		Get(gomock.Any(), gomock.Any(), gomock.Not(gomock.Nil()), gomock.Any()).
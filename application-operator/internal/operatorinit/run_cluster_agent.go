@@ -18,8 +18,10 @@ import (
 	vzlog "github.com/verrazzano/verrazzano/pkg/log"
 	vmcclient "github.com/verrazzano/verrazzano/platform-operator/clientset/versioned/scheme"
 	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
@@ -30,6 +32,8 @@ func StartClusterAgent(metricsAddr string, enableLeaderElection bool, log *zap.S
 		Port:               9443,
 		LeaderElection:     enableLeaderElection,
 		LeaderElectionID:   "5df248b5.verrazzano.io",
+		// The agent is only allowed to get the platform operator deployments by name, which a cached client cannot do
+		ClientDisableCacheFor: []client.Object{&appsv1.Deployment{}},
 	})
 	if err != nil {
 		log.Errorf("Failed to start manager: %v", err)
@@ -5,6 +5,7 @@ package operatorinit
 
 import (
 	"github.com/verrazzano/verrazzano/application-operator/controllers/appconfig"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/autoscalertrait"
 	"github.com/verrazzano/verrazzano/application-operator/controllers/cohworkload"
 	"github.com/verrazzano/verrazzano/application-operator/controllers/containerizedworkload"
 	"github.com/verrazzano/verrazzano/application-operator/controllers/helidonworkload"
@@ -13,6 +14,7 @@ import (
 	"github.com/verrazzano/verrazzano/application-operator/controllers/metricsbinding"
 	"github.com/verrazzano/verrazzano/application-operator/controllers/metricstrait"
 	"github.com/verrazzano/verrazzano/application-operator/controllers/namespace"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/tracingtrait"
 	"github.com/verrazzano/verrazzano/application-operator/controllers/wlsworkload"
 	"github.com/verrazzano/verrazzano/application-operator/metricsexporter"
 	"github.com/verrazzano/verrazzano/pkg/k8sutil"
@@ -138,6 +140,24 @@ func setupAppReconcilers(mgr manager.Manager, defaultMetricsScraper string, log
 		return err
 	}
 
+	if err = (&tracingtrait.Reconciler{
+		Client: mgr.GetClient(),
+		Log:    log,
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		log.Errorf("Failed to create TracingTrait controller: %v", err)
+		return err
+	}
+
+	if err = (&autoscalertrait.Reconciler{
+		Client: mgr.GetClient(),
+		Log:    log,
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		log.Errorf("Failed to create AutoscalerTrait controller: %v", err)
+		return err
+	}
+
 	if err = (&appconfig.Reconciler{
 		Client: mgr.GetClient(),
 		Log:    logger,
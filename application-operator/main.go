@@ -11,6 +11,7 @@ import (
 
 	certapiv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/crossplane/oam-kubernetes-runtime/apis/core"
+	fluentbitv1alpha2 "github.com/fluent/fluent-operator/v2/apis/fluentbit/v1alpha2"
 	promoperapi "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	vzapp "github.com/verrazzano/verrazzano/application-operator/apis/app/v1alpha1"
 	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
@@ -21,6 +22,7 @@ import (
 	"go.uber.org/zap"
 	istioclinet "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	clisecurity "istio.io/client-go/pkg/apis/security/v1beta1"
+	istiotelemetry "istio.io/client-go/pkg/apis/telemetry/v1alpha1"
 	k8sapiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -44,11 +46,13 @@ func init() {
 	_ = vzapp.AddToScheme(scheme)
 	_ = istioclinet.AddToScheme(scheme)
 	_ = clisecurity.AddToScheme(scheme)
+	_ = istiotelemetry.AddToScheme(scheme)
 
 	_ = clustersv1alpha1.AddToScheme(scheme)
 	_ = vmc.AddToScheme(scheme)
 	_ = certapiv1.AddToScheme(scheme)
 	_ = promoperapi.AddToScheme(scheme)
+	_ = fluentbitv1alpha2.AddToScheme(scheme)
 }
 
 var (
@@ -49,6 +49,9 @@ type Reconciler struct {
 	Log          *zap.SugaredLogger
 	Scheme       *runtime.Scheme
 	AgentChannel chan clusters.StatusUpdateMessage
+
+	// watcher watches the multi-cluster resources of the admin cluster between the reconciles
+	watcher *adminWatcher
 }
 
 // SetupWithManager registers our controller with the manager
@@ -83,6 +86,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	if err := r.Get(ctx, req.NamespacedName, &agentSecret); err != nil {
 		// there is no admin cluster we are connected to, so nowhere to send any status updates
 		// received - discard them
+		r.getWatcher().disconnect()
 		discardStatusMessages(r.AgentChannel)
 		return clusters.IgnoreNotFoundWithLog(err, r.Log)
 	}
@@ -90,18 +94,21 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		r.Log.Debugf("the secret %v was deleted", req.NamespacedName)
 		// there is no admin cluster we are connected to, so nowhere to send any status updates
 		// received - discard them
+		r.getWatcher().disconnect()
 		discardStatusMessages(r.AgentChannel)
 		return clusters.NewRequeueWithRandomDelay(requeueDelayMinSeconds, requeueDelayMaxSeconds), nil
 	}
 	if err := validateAgentSecret(&agentSecret); err != nil {
 		// agent secret is invalid - log and also discard status messages on the channel since there
 		// is no valid admin cluster to send status updates to
+		r.getWatcher().disconnect()
 		discardStatusMessages(r.AgentChannel)
 		return clusters.NewRequeueWithRandomDelay(requeueDelayMinSeconds, requeueDelayMaxSeconds), fmt.Errorf("Agent secret validation failed: %v", err)
 	}
 	r.Log.Debug("Reconciling multi-cluster agent")
 
-	// Process one iteration of the agent thread
+	// Process one iteration of the agent thread.  The agent iterates periodically even when it watches the
+	// admin cluster, to report that it is connected and to poll the resources that are not watched.
 	err := r.doReconcile(ctx, agentSecret)
 	if err != nil {
 		r.Log.Errorf("failed processing multi-cluster resources: %v", err)
@@ -109,6 +116,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	return clusters.NewRequeueWithRandomDelay(requeueDelayMinSeconds, requeueDelayMaxSeconds), nil
 }
 
+// getWatcher returns the watcher of the admin cluster, creating it on first use
+func (r *Reconciler) getWatcher() *adminWatcher {
+	if r.watcher == nil {
+		r.watcher = newAdminWatcher(r.Log, r.AgentChannel)
+	}
+	return r.watcher
+}
+
 // doReconcile - process one iteration of the agent thread
 func (r *Reconciler) doReconcile(ctx context.Context, agentSecret corev1.Secret) error {
 	managedClusterName := string(agentSecret.Data[constants.ClusterNameData])
@@ -121,6 +136,7 @@ func (r *Reconciler) doReconcile(ctx context.Context, agentSecret corev1.Secret)
 		ProjectNamespaces:   []string{},
 		StatusUpdateChannel: r.AgentChannel,
 		ManagedClusterName:  managedClusterName,
+		watcher:             r.getWatcher(),
 	}
 
 	// Read current agent state from config map
@@ -134,6 +150,7 @@ func (r *Reconciler) doReconcile(ctx context.Context, agentSecret corev1.Secret)
 	// If we are unauthorized to create a client on the admin cluster
 	// the cluster must have been deregistered
 	if apierrors.IsUnauthorized(err) {
+		r.getWatcher().disconnect()
 		return s.syncDeregistration()
 	}
 	if err != nil {
@@ -166,8 +183,18 @@ func (r *Reconciler) doReconcile(ctx context.Context, agentSecret corev1.Secret)
 		r.Log.Errorf("Failed to update VMC status on admin cluster: %v", err)
 	}
 
-	// Sync multi-cluster objects
+	// Upgrade Verrazzano if a fleet upgrade of the admin cluster targets this cluster
+	err = s.syncFleetUpgrade()
+	if err != nil {
+		// we couldn't upgrade Verrazzano - but we should keep going with the rest of the work
+		r.Log.Errorf("Failed to sync the fleet upgrade: %v", err)
+	}
+
+	// Sync multi-cluster objects, then watch them on the admin cluster to sync them as soon as they change
+	r.getWatcher().syncMutex.Lock()
 	s.SyncMultiClusterResources()
+	r.getWatcher().syncMutex.Unlock()
+	r.getWatcher().connect(s)
 
 	// Delete the managed cluster resources if deregistration occurs
 	err = s.syncDeregistration()
@@ -257,7 +284,7 @@ func createAdminClient(secret *corev1.Secret) (client.Client, error) {
 	_ = oamv1alpha2.SchemeBuilder.AddToScheme(scheme)
 	_ = corev1.SchemeBuilder.AddToScheme(scheme)
 
-	clientset, err := client.New(config, client.Options{Scheme: scheme})
+	clientset, err := client.NewWithWatch(config, client.Options{Scheme: scheme})
 	if err != nil {
 		return nil, err
 	}
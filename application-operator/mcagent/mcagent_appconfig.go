@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package mcagent
@@ -34,7 +34,11 @@ func (s *Syncer) syncMCApplicationConfigurationObjects(namespace string) error {
 	}
 
 	for _, mcAppConfig := range allAdminMCAppConfigs.Items {
-		if s.isThisCluster(mcAppConfig.Spec.Placement) {
+		placed, err := s.isThisCluster(mcAppConfig.Spec.Placement)
+		if err != nil {
+			return err
+		}
+		if placed {
 			// Synchronize the components referenced by the application
 			err := s.syncComponentList(mcAppConfig)
 			if err != nil {
@@ -67,7 +71,11 @@ func (s *Syncer) syncMCApplicationConfigurationObjects(namespace string) error {
 	}
 	for i, mcAppConfig := range allLocalMCAppConfigs.Items {
 		// Delete each MultiClusterApplicationConfiguration object that is not on the admin cluster or no longer placed on this cluster
-		if !s.appConfigPlacedOnCluster(&allAdminMCAppConfigs, mcAppConfig.Name, mcAppConfig.Namespace) {
+		placed, err := s.appConfigPlacedOnCluster(&allAdminMCAppConfigs, mcAppConfig.Name, mcAppConfig.Namespace)
+		if err != nil {
+			return err
+		}
+		if !placed {
 			err := s.LocalClient.Delete(s.Context, &allLocalMCAppConfigs.Items[i])
 			if err != nil {
 				s.Log.Errorf("Failed to delete MultiClusterApplicationConfiguration with name %q in namespace %q: %v", mcAppConfig.Name, mcAppConfig.Namespace, err)
@@ -89,6 +97,13 @@ func (s *Syncer) createOrUpdateMCAppConfig(mcAppConfig clustersv1alpha1.MultiClu
 	mcAppConfigNew.Namespace = mcAppConfig.Namespace
 	mcAppConfigNew.Name = mcAppConfig.Name
 
+	// Copy the clusters the resource is placed in, as resolved against the managed clusters of the admin cluster
+	placement, err := s.resolvePlacement(mcAppConfig.Spec.Placement)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	mcAppConfig.Spec.Placement = placement
+
 	// Create or update on the local cluster
 	return controllerutil.CreateOrUpdate(s.Context, s.LocalClient, &mcAppConfigNew, func() error {
 		mutateMCAppConfig(mcAppConfig, &mcAppConfigNew)
@@ -110,13 +125,13 @@ func mutateMCAppConfig(mcAppConfig clustersv1alpha1.MultiClusterApplicationConfi
 
 // appConfigPlacedOnCluster returns boolean indicating if the list contains the object with the specified name and namespace and the placement
 // includes the local cluster
-func (s *Syncer) appConfigPlacedOnCluster(mcAdminList *clustersv1alpha1.MultiClusterApplicationConfigurationList, name string, namespace string) bool {
+func (s *Syncer) appConfigPlacedOnCluster(mcAdminList *clustersv1alpha1.MultiClusterApplicationConfigurationList, name string, namespace string) (bool, error) {
 	for _, item := range mcAdminList.Items {
 		if item.Name == name && item.Namespace == namespace {
 			return s.isThisCluster(item.Spec.Placement)
 		}
 	}
-	return false
+	return false, nil
 }
 
 func (s *Syncer) updateMultiClusterAppConfigStatus(name types.NamespacedName, newCond clustersv1alpha1.Condition, newClusterStatus clustersv1alpha1.ClusterLevelStatus) error {
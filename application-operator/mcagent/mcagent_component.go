@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package mcagent
@@ -31,7 +31,11 @@ func (s *Syncer) syncMCComponentObjects(namespace string) error {
 
 	// Write each of the records that are targeted to this cluster
 	for _, mcComponent := range allAdminMCComponents.Items {
-		if s.isThisCluster(mcComponent.Spec.Placement) {
+		placed, err := s.isThisCluster(mcComponent.Spec.Placement)
+		if err != nil {
+			return err
+		}
+		if placed {
 			_, err := s.createOrUpdateMCComponent(mcComponent)
 			if err != nil {
 				s.Log.Errorw(fmt.Sprintf("Failed syncing object: %v", err),
@@ -53,7 +57,11 @@ func (s *Syncer) syncMCComponentObjects(namespace string) error {
 	}
 	for i, mcComponent := range allLocalMCComponents.Items {
 		// Delete each MultiClusterComponent object that is not on the admin cluster or no longer placed on this cluster
-		if !s.componentPlacedOnCluster(&allAdminMCComponents, mcComponent.Name, mcComponent.Namespace) {
+		placed, err := s.componentPlacedOnCluster(&allAdminMCComponents, mcComponent.Name, mcComponent.Namespace)
+		if err != nil {
+			return err
+		}
+		if !placed {
 			err := s.LocalClient.Delete(s.Context, &allLocalMCComponents.Items[i])
 			if err != nil {
 				s.Log.Errorf("Failed to delete MultiClusterComponent with name %q and namespace %q: %v", mcComponent.Name, mcComponent.Namespace, err)
@@ -70,6 +78,13 @@ func (s *Syncer) createOrUpdateMCComponent(mcComponent clustersv1alpha1.MultiClu
 	mcComponentNew.Namespace = mcComponent.Namespace
 	mcComponentNew.Name = mcComponent.Name
 
+	// Copy the clusters the resource is placed in, as resolved against the managed clusters of the admin cluster
+	placement, err := s.resolvePlacement(mcComponent.Spec.Placement)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	mcComponent.Spec.Placement = placement
+
 	// Create or update on the local cluster
 	return controllerutil.CreateOrUpdate(s.Context, s.LocalClient, &mcComponentNew, func() error {
 		mutateMCComponent(mcComponent, &mcComponentNew)
@@ -103,11 +118,11 @@ func mutateMCComponent(mcComponent clustersv1alpha1.MultiClusterComponent, mcCom
 }
 
 // componentPlacedOnCluster returns boolean indicating if the list contains the object with the specified name and namespace
-func (s *Syncer) componentPlacedOnCluster(mcAdminList *clustersv1alpha1.MultiClusterComponentList, name string, namespace string) bool {
+func (s *Syncer) componentPlacedOnCluster(mcAdminList *clustersv1alpha1.MultiClusterComponentList, name string, namespace string) (bool, error) {
 	for _, item := range mcAdminList.Items {
 		if item.Name == name && item.Namespace == namespace {
 			return s.isThisCluster(item.Spec.Placement)
 		}
 	}
-	return false
+	return false, nil
 }
@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package mcagent
@@ -19,41 +19,28 @@ import (
 
 // Synchronize MultiClusterConfigMap objects to the local cluster
 func (s *Syncer) syncMCConfigMapObjects(namespace string) error {
-	// Get all the MultiClusterConfigMap objects from the admin cluster
-	allAdminMCConfigMaps := clustersv1alpha1.MultiClusterConfigMapList{}
-	listOptions := &client.ListOptions{Namespace: namespace}
-	err := s.AdminClient.List(s.Context, &allAdminMCConfigMaps, listOptions)
-	// When placements are changed a forbidden error can be returned.  In this case,
-	// we want to fall through and delete orphaned resources.
-	if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsForbidden(err) {
+	allAdminMCConfigMaps, err := s.createOrUpdateMCConfigMaps(namespace)
+	if err != nil {
 		return err
 	}
 
-	// Write each of the records that are targeted to this cluster
-	for _, mcConfigMap := range allAdminMCConfigMaps.Items {
-		if s.isThisCluster(mcConfigMap.Spec.Placement) {
-			_, err := s.createOrUpdateMCConfigMap(mcConfigMap)
-			if err != nil {
-				s.Log.Errorw(fmt.Sprintf("Failed syncing object: %v", err),
-					"MultiClusterConfigMap",
-					types.NamespacedName{Namespace: mcConfigMap.Namespace, Name: mcConfigMap.Name})
-			}
-		}
-	}
-
 	// Delete orphaned MultiClusterConfigMap resources.
 	// Get the list of MultiClusterConfigMap resources on the
 	// local cluster and compare to the list received from the admin cluster.
 	// The admin cluster is the source of truth.
 	allLocalMCConfigMaps := clustersv1alpha1.MultiClusterConfigMapList{}
-	err = s.LocalClient.List(s.Context, &allLocalMCConfigMaps, listOptions)
+	err = s.LocalClient.List(s.Context, &allLocalMCConfigMaps, &client.ListOptions{Namespace: namespace})
 	if err != nil {
 		s.Log.Errorf("Failed to list MultiClusterConfigMap on local cluster: %v", err)
 		return nil
 	}
 	for i, mcConfigMap := range allLocalMCConfigMaps.Items {
 		// Delete each MultiClusterConfigMap object that is not on the admin cluster or no longer placed on this cluster
-		if !s.configMapPlacedOnCluster(&allAdminMCConfigMaps, mcConfigMap.Name, mcConfigMap.Namespace) {
+		placed, err := s.configMapPlacedOnCluster(allAdminMCConfigMaps, mcConfigMap.Name, mcConfigMap.Namespace)
+		if err != nil {
+			return err
+		}
+		if !placed {
 			err := s.LocalClient.Delete(s.Context, &allLocalMCConfigMaps.Items[i])
 			if err != nil {
 				s.Log.Errorf("Failed to delete MultiClusterConfigMap with name %q and namespace %q: %v", mcConfigMap.Name, mcConfigMap.Namespace, err)
@@ -64,12 +51,59 @@ func (s *Syncer) syncMCConfigMapObjects(namespace string) error {
 	return nil
 }
 
+// createOrUpdateMCConfigMaps creates or updates the MultiClusterConfigMap objects of the admin cluster placed in this cluster,
+// and handles the drift of the ConfigMaps created from them.  Returns the MultiClusterConfigMap objects of the admin cluster.
+func (s *Syncer) createOrUpdateMCConfigMaps(namespace string) (*clustersv1alpha1.MultiClusterConfigMapList, error) {
+	// Get all the MultiClusterConfigMap objects from the admin cluster
+	allAdminMCConfigMaps := clustersv1alpha1.MultiClusterConfigMapList{}
+	listOptions := &client.ListOptions{Namespace: namespace}
+	err := s.AdminClient.List(s.Context, &allAdminMCConfigMaps, listOptions)
+	// When placements are changed a forbidden error can be returned.  In this case,
+	// we want to fall through and delete orphaned resources.
+	if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsForbidden(err) {
+		return nil, err
+	}
+
+	// Write each of the records that are targeted to this cluster
+	for _, mcConfigMap := range allAdminMCConfigMaps.Items {
+		placed, err := s.isThisCluster(mcConfigMap.Spec.Placement)
+		if err != nil {
+			return nil, err
+		}
+		if placed {
+			opResult, err := s.createOrUpdateMCConfigMap(mcConfigMap)
+			if err != nil {
+				s.Log.Errorw(fmt.Sprintf("Failed syncing object: %v", err),
+					"MultiClusterConfigMap",
+					types.NamespacedName{Namespace: mcConfigMap.Namespace, Name: mcConfigMap.Name})
+				continue
+			}
+			// Drift is only detected once the local MultiClusterConfigMap is up to date with the admin cluster
+			if opResult == controllerutil.OperationResultNone {
+				if err := s.syncMCConfigMapDrift(mcConfigMap); err != nil {
+					s.Log.Errorw(fmt.Sprintf("Failed syncing drift: %v", err),
+						"MultiClusterConfigMap",
+						types.NamespacedName{Namespace: mcConfigMap.Namespace, Name: mcConfigMap.Name})
+				}
+			}
+		}
+	}
+	return &allAdminMCConfigMaps, nil
+}
+
 // Create or update a MultiClusterConfigMap
 func (s *Syncer) createOrUpdateMCConfigMap(mcConfigMap clustersv1alpha1.MultiClusterConfigMap) (controllerutil.OperationResult, error) {
 	var mcConfigMapNew clustersv1alpha1.MultiClusterConfigMap
 	mcConfigMapNew.Namespace = mcConfigMap.Namespace
 	mcConfigMapNew.Name = mcConfigMap.Name
 
+	// Copy the clusters the resource is placed in, as resolved against the managed clusters of the admin cluster
+	placement, err := s.resolvePlacement(mcConfigMap.Spec.Placement)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	mcConfigMap.Spec.Placement = placement
+
 	// Create or update on the local cluster
 	return controllerutil.CreateOrUpdate(s.Context, s.LocalClient, &mcConfigMapNew, func() error {
 		mutateMCConfigMap(mcConfigMap, &mcConfigMapNew)
@@ -103,11 +137,11 @@ func mutateMCConfigMap(mcConfigMap clustersv1alpha1.MultiClusterConfigMap, mcCon
 }
 
 // configMapPlacedOnCluster returns boolean indicating if the list contains the object with the specified name and namespace
-func (s *Syncer) configMapPlacedOnCluster(mcAdminList *clustersv1alpha1.MultiClusterConfigMapList, name string, namespace string) bool {
+func (s *Syncer) configMapPlacedOnCluster(mcAdminList *clustersv1alpha1.MultiClusterConfigMapList, name string, namespace string) (bool, error) {
 	for _, item := range mcAdminList.Items {
 		if item.Name == name && item.Namespace == namespace {
 			return s.isThisCluster(item.Spec.Placement)
 		}
 	}
-	return false
+	return false, nil
 }
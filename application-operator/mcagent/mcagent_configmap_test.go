@@ -229,6 +229,18 @@ func TestDeleteMCConfigMap(t *testing.T) {
 			return nil
 		})
 
+	// Managed Cluster - expect calls to get the MultiClusterConfigMap and its ConfigMap to detect drift
+	//                   The ConfigMap has not been created yet
+	mcMock.EXPECT().
+		Get(gomock.Any(), types.NamespacedName{Namespace: testMCConfigMapNamespace, Name: testMCConfigMapName}, gomock.AssignableToTypeOf(&clustersv1alpha1.MultiClusterConfigMap{}), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, name types.NamespacedName, mcConfigMap *clustersv1alpha1.MultiClusterConfigMap, opts ...client.GetOption) error {
+			testMCConfigMap.DeepCopyInto(mcConfigMap)
+			return nil
+		})
+	mcMock.EXPECT().
+		Get(gomock.Any(), types.NamespacedName{Namespace: testMCConfigMapNamespace, Name: testMCConfigMapName}, gomock.Not(gomock.Nil()), gomock.Any()).
+		Return(errors.NewNotFound(schema.GroupResource{Group: "", Resource: "ConfigMap"}, testMCConfigMapName))
+
 	// Managed Cluster - expect call to list MultiClusterConfigMap objects - return list including an orphaned object
 	mcMock.EXPECT().
 		List(gomock.Any(), &clustersv1alpha1.MultiClusterConfigMapList{}, gomock.Not(gomock.Nil())).
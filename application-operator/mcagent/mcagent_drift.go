@@ -0,0 +1,182 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package mcagent
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/clusters"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// syncMCSecretDrift detects the changes made in this cluster to the Secret created from the given MultiClusterSecret
+// of the admin cluster, handles them as specified by the drift policy of the MultiClusterSecret, and reports them in
+// the status of the MultiClusterSecret on the admin cluster
+func (s *Syncer) syncMCSecretDrift(mcSecret clustersv1alpha1.MultiClusterSecret) error {
+	name := types.NamespacedName{Namespace: mcSecret.Namespace, Name: mcSecret.Name}
+	secret := corev1.Secret{}
+	applied, err := s.isTemplateApplied(name, &clustersv1alpha1.MultiClusterSecret{}, &secret)
+	if err != nil || !applied {
+		return err
+	}
+
+	desired := map[string][]byte{}
+	for key, value := range mcSecret.Spec.Template.Data {
+		desired[key] = value
+	}
+	for key, value := range mcSecret.Spec.Template.StringData {
+		desired[key] = []byte(value)
+	}
+	keys := getDriftedKeys(desired, secret.Data)
+
+	var drift *clustersv1alpha1.DriftStatus
+	if len(keys) > 0 {
+		drift = s.newDriftStatus(mcSecret.Spec.DriftPolicy, mcSecret.Spec.AdoptingCluster, keys)
+		s.Log.Infof("Secret %s has drifted from MultiClusterSecret in keys %v, drift %s", name, keys, drift.Action)
+		switch drift.Action {
+		case clustersv1alpha1.DriftOverwritten:
+			secret.Data = desired
+			if err := s.LocalClient.Update(s.Context, &secret); err != nil {
+				return fmt.Errorf("failed to overwrite the drifted Secret %s: %v", name, err)
+			}
+		case clustersv1alpha1.DriftAdopted:
+			mcSecret.Spec.Template.Data = secret.Data
+			mcSecret.Spec.Template.StringData = nil
+			mcSecret.Spec.AdoptingCluster = s.ManagedClusterName
+			if err := s.AdminClient.Update(s.Context, &mcSecret); err != nil {
+				return fmt.Errorf("failed to adopt the drifted Secret %s: %v", name, err)
+			}
+		}
+	}
+
+	if !s.setDriftStatus(&mcSecret.Status, drift) {
+		return nil
+	}
+	return s.AdminClient.Status().Update(s.Context, &mcSecret)
+}
+
+// syncMCConfigMapDrift detects the changes made in this cluster to the ConfigMap created from the given
+// MultiClusterConfigMap of the admin cluster, handles them as specified by the drift policy of the
+// MultiClusterConfigMap, and reports them in the status of the MultiClusterConfigMap on the admin cluster
+func (s *Syncer) syncMCConfigMapDrift(mcConfigMap clustersv1alpha1.MultiClusterConfigMap) error {
+	name := types.NamespacedName{Namespace: mcConfigMap.Namespace, Name: mcConfigMap.Name}
+	configMap := corev1.ConfigMap{}
+	applied, err := s.isTemplateApplied(name, &clustersv1alpha1.MultiClusterConfigMap{}, &configMap)
+	if err != nil || !applied {
+		return err
+	}
+
+	keys := getDriftedKeys(getConfigMapData(mcConfigMap.Spec.Template.Data, mcConfigMap.Spec.Template.BinaryData),
+		getConfigMapData(configMap.Data, configMap.BinaryData))
+
+	var drift *clustersv1alpha1.DriftStatus
+	if len(keys) > 0 {
+		drift = s.newDriftStatus(mcConfigMap.Spec.DriftPolicy, mcConfigMap.Spec.AdoptingCluster, keys)
+		s.Log.Infof("ConfigMap %s has drifted from MultiClusterConfigMap in keys %v, drift %s", name, keys, drift.Action)
+		switch drift.Action {
+		case clustersv1alpha1.DriftOverwritten:
+			configMap.Data = mcConfigMap.Spec.Template.Data
+			configMap.BinaryData = mcConfigMap.Spec.Template.BinaryData
+			if err := s.LocalClient.Update(s.Context, &configMap); err != nil {
+				return fmt.Errorf("failed to overwrite the drifted ConfigMap %s: %v", name, err)
+			}
+		case clustersv1alpha1.DriftAdopted:
+			mcConfigMap.Spec.Template.Data = configMap.Data
+			mcConfigMap.Spec.Template.BinaryData = configMap.BinaryData
+			mcConfigMap.Spec.AdoptingCluster = s.ManagedClusterName
+			if err := s.AdminClient.Update(s.Context, &mcConfigMap); err != nil {
+				return fmt.Errorf("failed to adopt the drifted ConfigMap %s: %v", name, err)
+			}
+		}
+	}
+
+	if !s.setDriftStatus(&mcConfigMap.Status, drift) {
+		return nil
+	}
+	return s.AdminClient.Status().Update(s.Context, &mcConfigMap)
+}
+
+// isTemplateApplied fetches the local multi cluster resource and the resource created from it, and returns true if
+// the current generation of the multi cluster resource has been applied to the resource.  Until then, the resource
+// differs from the template because it has not been updated yet, and not because it has drifted.
+func (s *Syncer) isTemplateApplied(name types.NamespacedName, mcObj client.Object, obj client.Object) (bool, error) {
+	if err := s.LocalClient.Get(s.Context, name, mcObj); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	if err := s.LocalClient.Get(s.Context, name, obj); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return clusters.IsMultiClusterGenerationApplied(obj, mcObj.GetGeneration()), nil
+}
+
+// setDriftStatus sets the drift of the resource in this cluster in the given status.  When the resource no longer
+// drifts, a reported drift is cleared since it no longer describes the resource, while a drift that was overwritten
+// or adopted is kept as the record of the last drift.  Returns true if the status changed.
+func (s *Syncer) setDriftStatus(status *clustersv1alpha1.MultiClusterResourceStatus, drift *clustersv1alpha1.DriftStatus) bool {
+	if drift == nil {
+		for _, clusterStatus := range status.Clusters {
+			if clusterStatus.Name == s.ManagedClusterName && clusterStatus.Drift != nil && clusterStatus.Drift.Action != clustersv1alpha1.DriftReported {
+				return false
+			}
+		}
+	}
+	return clusters.SetClusterDriftStatus(status, s.ManagedClusterName, drift)
+}
+
+// newDriftStatus returns the drift status of the given drifted keys, with the action taken for the drift policy.
+// The changes are only adopted from the adopting cluster, or from the first cluster that adopts changes when there is
+// no adopting cluster yet, so that the clusters do not overwrite each other's changes.  The changes made in the other
+// clusters are reported.
+func (s *Syncer) newDriftStatus(policy clustersv1alpha1.DriftPolicy, adoptingCluster string, keys []string) *clustersv1alpha1.DriftStatus {
+	action := clustersv1alpha1.DriftOverwritten
+	switch policy {
+	case clustersv1alpha1.DriftPolicyReportOnly:
+		action = clustersv1alpha1.DriftReported
+	case clustersv1alpha1.DriftPolicyAdopt:
+		action = clustersv1alpha1.DriftAdopted
+		if len(adoptingCluster) > 0 && adoptingCluster != s.ManagedClusterName {
+			action = clustersv1alpha1.DriftReported
+		}
+	}
+	return &clustersv1alpha1.DriftStatus{
+		Action:       action,
+		DetectedTime: time.Now().Format(time.RFC3339),
+		Keys:         keys,
+	}
+}
+
+// getDriftedKeys returns the sorted keys whose values differ between the desired and the actual data
+func getDriftedKeys(desired map[string][]byte, actual map[string][]byte) []string {
+	var keys []string
+	for key, value := range desired {
+		if actualValue, ok := actual[key]; !ok || !bytes.Equal(value, actualValue) {
+			keys = append(keys, key)
+		}
+	}
+	for key := range actual {
+		if _, ok := desired[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// getConfigMapData returns the string and binary data of a ConfigMap as a single map
+func getConfigMapData(data map[string]string, binaryData map[string][]byte) map[string][]byte {
+	configMapData := map[string][]byte{}
+	for key, value := range data {
+		configMapData[key] = []byte(value)
+	}
+	for key, value := range binaryData {
+		configMapData[key] = value
+	}
+	return configMapData
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package mcagent
+
+import (
+	"context"
+	"testing"
+
+	asserts "github.com/stretchr/testify/assert"
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestSyncMCSecretDrift tests the detection of the drift of a Secret created from a MultiClusterSecret
+// GIVEN a MultiClusterSecret with each drift policy, and a Secret changed in the managed cluster
+// WHEN the drift of the MultiClusterSecret is synced
+// THEN the Secret is overwritten, kept or adopted as specified by the drift policy, and the drift is reported
+// in the status of the MultiClusterSecret on the admin cluster, while the changes of a cluster other than the adopting
+// cluster are only reported
+func TestSyncMCSecretDrift(t *testing.T) {
+	tests := []struct {
+		name             string
+		policy           clustersv1alpha1.DriftPolicy
+		adoptingCluster  string
+		appliedGen       string
+		expectedAction   clustersv1alpha1.DriftAction
+		expectedLocal    map[string][]byte
+		expectedTemplate map[string][]byte
+		expectedAdopting string
+	}{
+		{
+			name:             "default policy",
+			appliedGen:       "0",
+			expectedAction:   clustersv1alpha1.DriftOverwritten,
+			expectedLocal:    map[string][]byte{"username": []byte("admin"), "password": []byte("secret")},
+			expectedTemplate: map[string][]byte{"username": []byte("admin"), "password": []byte("secret")},
+		},
+		{
+			name:             "report only",
+			policy:           clustersv1alpha1.DriftPolicyReportOnly,
+			appliedGen:       "0",
+			expectedAction:   clustersv1alpha1.DriftReported,
+			expectedLocal:    map[string][]byte{"username": []byte("admin"), "password": []byte("changed"), "extra": []byte("value")},
+			expectedTemplate: map[string][]byte{"username": []byte("admin"), "password": []byte("secret")},
+		},
+		{
+			name:             "adopt",
+			policy:           clustersv1alpha1.DriftPolicyAdopt,
+			appliedGen:       "0",
+			expectedAction:   clustersv1alpha1.DriftAdopted,
+			expectedLocal:    map[string][]byte{"username": []byte("admin"), "password": []byte("changed"), "extra": []byte("value")},
+			expectedTemplate: map[string][]byte{"username": []byte("admin"), "password": []byte("changed"), "extra": []byte("value")},
+			expectedAdopting: testClusterName,
+		},
+		{
+			name:             "adopt in another cluster",
+			policy:           clustersv1alpha1.DriftPolicyAdopt,
+			adoptingCluster:  "other-cluster",
+			appliedGen:       "0",
+			expectedAction:   clustersv1alpha1.DriftReported,
+			expectedLocal:    map[string][]byte{"username": []byte("admin"), "password": []byte("changed"), "extra": []byte("value")},
+			expectedTemplate: map[string][]byte{"username": []byte("admin"), "password": []byte("secret")},
+			expectedAdopting: "other-cluster",
+		},
+		{
+			name:             "template not applied yet",
+			policy:           clustersv1alpha1.DriftPolicyAdopt,
+			appliedGen:       "1",
+			expectedLocal:    map[string][]byte{"username": []byte("admin"), "password": []byte("changed"), "extra": []byte("value")},
+			expectedTemplate: map[string][]byte{"username": []byte("admin"), "password": []byte("secret")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := asserts.New(t)
+			mcSecret := &clustersv1alpha1.MultiClusterSecret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testMCSecretNamespace, Name: testMCSecretName},
+				Spec: clustersv1alpha1.MultiClusterSecretSpec{
+					Template: clustersv1alpha1.SecretTemplate{
+						Data:       map[string][]byte{"username": []byte("admin")},
+						StringData: map[string]string{"password": "secret"},
+					},
+					DriftPolicy:     tt.policy,
+					AdoptingCluster: tt.adoptingCluster,
+				},
+				Status: clustersv1alpha1.MultiClusterResourceStatus{
+					Clusters: []clustersv1alpha1.ClusterLevelStatus{{Name: testClusterName, State: clustersv1alpha1.Succeeded}},
+				},
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testMCSecretNamespace, Name: testMCSecretName,
+					Annotations: map[string]string{constants.MultiClusterGenerationAnnotation: tt.appliedGen}},
+				Data: map[string][]byte{"username": []byte("admin"), "password": []byte("changed"), "extra": []byte("value")},
+			}
+			adminClient := fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(mcSecret.DeepCopy()).Build()
+			localClient := fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(mcSecret.DeepCopy(), secret).Build()
+			s := &Syncer{AdminClient: adminClient, LocalClient: localClient, Log: zap.S(), ManagedClusterName: testClusterName, Context: context.TODO()}
+
+			adminMCSecret := clustersv1alpha1.MultiClusterSecret{}
+			assert.NoError(adminClient.Get(context.TODO(), client.ObjectKeyFromObject(mcSecret), &adminMCSecret))
+			assert.NoError(s.syncMCSecretDrift(adminMCSecret))
+
+			assert.NoError(localClient.Get(context.TODO(), client.ObjectKeyFromObject(secret), secret))
+			assert.Equal(tt.expectedLocal, secret.Data)
+			assert.NoError(adminClient.Get(context.TODO(), client.ObjectKeyFromObject(mcSecret), &adminMCSecret))
+			template := map[string][]byte{}
+			for key, value := range adminMCSecret.Spec.Template.Data {
+				template[key] = value
+			}
+			for key, value := range adminMCSecret.Spec.Template.StringData {
+				template[key] = []byte(value)
+			}
+			assert.Equal(tt.expectedTemplate, template)
+			assert.Equal(tt.expectedAdopting, adminMCSecret.Spec.AdoptingCluster)
+
+			drift := adminMCSecret.Status.Clusters[0].Drift
+			if len(tt.expectedAction) == 0 {
+				assert.Nil(drift)
+				return
+			}
+			assert.NotNil(drift)
+			assert.Equal(tt.expectedAction, drift.Action)
+			assert.Equal([]string{"extra", "password"}, drift.Keys)
+		})
+	}
+}
+
+// TestSyncMCConfigMapDrift tests the detection of the drift of a ConfigMap created from a MultiClusterConfigMap
+// GIVEN a MultiClusterConfigMap with the ReportOnly drift policy, and a ConfigMap changed in the managed cluster
+// WHEN the drift of the MultiClusterConfigMap is synced
+// THEN the drift is reported in the status of the MultiClusterConfigMap on the admin cluster, and cleared once
+// the ConfigMap no longer drifts
+func TestSyncMCConfigMapDrift(t *testing.T) {
+	assert := asserts.New(t)
+	mcConfigMap := &clustersv1alpha1.MultiClusterConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testMCConfigMapNamespace, Name: testMCConfigMapName},
+		Spec: clustersv1alpha1.MultiClusterConfigMapSpec{
+			Template: clustersv1alpha1.ConfigMapTemplate{
+				Data:       map[string]string{"key1": "value1"},
+				BinaryData: map[string][]byte{"key2": []byte("value2")},
+			},
+			DriftPolicy: clustersv1alpha1.DriftPolicyReportOnly,
+		},
+		Status: clustersv1alpha1.MultiClusterResourceStatus{
+			Clusters: []clustersv1alpha1.ClusterLevelStatus{{Name: testClusterName, State: clustersv1alpha1.Succeeded}},
+		},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testMCConfigMapNamespace, Name: testMCConfigMapName,
+			Annotations: map[string]string{constants.MultiClusterGenerationAnnotation: "0"}},
+		Data:       map[string]string{"key1": "changed"},
+		BinaryData: map[string][]byte{"key2": []byte("value2")},
+	}
+	adminClient := fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(mcConfigMap.DeepCopy()).Build()
+	localClient := fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(mcConfigMap.DeepCopy(), configMap).Build()
+	s := &Syncer{AdminClient: adminClient, LocalClient: localClient, Log: zap.S(), ManagedClusterName: testClusterName, Context: context.TODO()}
+
+	adminMCConfigMap := clustersv1alpha1.MultiClusterConfigMap{}
+	assert.NoError(adminClient.Get(context.TODO(), client.ObjectKeyFromObject(mcConfigMap), &adminMCConfigMap))
+	assert.NoError(s.syncMCConfigMapDrift(adminMCConfigMap))
+	assert.NoError(adminClient.Get(context.TODO(), client.ObjectKeyFromObject(mcConfigMap), &adminMCConfigMap))
+	drift := adminMCConfigMap.Status.Clusters[0].Drift
+	assert.NotNil(drift)
+	assert.Equal(clustersv1alpha1.DriftReported, drift.Action)
+	assert.Equal([]string{"key1"}, drift.Keys)
+	assert.NoError(localClient.Get(context.TODO(), client.ObjectKeyFromObject(configMap), configMap))
+	assert.Equal("changed", configMap.Data["key1"])
+
+	// The reported drift is cleared once the ConfigMap is reverted
+	configMap.Data["key1"] = "value1"
+	assert.NoError(localClient.Update(context.TODO(), configMap))
+	assert.NoError(s.syncMCConfigMapDrift(adminMCConfigMap))
+	assert.NoError(adminClient.Get(context.TODO(), client.ObjectKeyFromObject(mcConfigMap), &adminMCConfigMap))
+	assert.Nil(adminMCConfigMap.Status.Clusters[0].Drift)
+}
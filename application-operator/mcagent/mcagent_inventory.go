@@ -0,0 +1,86 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package mcagent
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// getKubernetesVersionFunc returns the version of Kubernetes running on this managed cluster, overridden in unit tests
+var getKubernetesVersionFunc = k8sutil.GetKubernetesVersion
+
+// getInventory returns the inventory of the Verrazzano installation and the Kubernetes cluster of this managed
+// cluster, to be reported in the status of the VMC on the admin cluster
+func (s *Syncer) getInventory() (*v1alpha1.ManagedClusterInventory, error) {
+	inventory := v1alpha1.ManagedClusterInventory{}
+
+	vzList := v1beta1.VerrazzanoList{}
+	if err := s.LocalClient.List(s.Context, &vzList); err != nil {
+		return nil, fmt.Errorf("unable to list Verrazzano resources: %v", err)
+	}
+	if len(vzList.Items) > 0 {
+		vz := vzList.Items[0]
+		inventory.VerrazzanoVersion = vz.Status.Version
+		inventory.Profile = string(vz.Spec.Profile)
+		if inventory.Profile == "" {
+			inventory.Profile = string(v1beta1.Prod)
+		}
+		inventory.VerrazzanoState = string(vz.Status.State)
+		if vz.Status.Available != nil {
+			inventory.AvailableComponents = *vz.Status.Available
+		}
+		for _, component := range vz.Status.Components {
+			if component == nil || component.State == v1beta1.CompStateDisabled {
+				continue
+			}
+			inventory.Components = append(inventory.Components, v1alpha1.ComponentInventory{
+				Name:      component.Name,
+				State:     string(component.State),
+				Available: component.Available != nil && *component.Available == v1beta1.ComponentAvailable,
+				Version:   component.Version,
+			})
+		}
+		sort.Slice(inventory.Components, func(i, j int) bool {
+			return inventory.Components[i].Name < inventory.Components[j].Name
+		})
+	}
+
+	nodeList := corev1.NodeList{}
+	if err := s.LocalClient.List(s.Context, &nodeList); err != nil {
+		return nil, fmt.Errorf("unable to list nodes: %v", err)
+	}
+	inventory.Nodes = len(nodeList.Items)
+	for _, node := range nodeList.Items {
+		if isNodeReady(node) {
+			inventory.ReadyNodes++
+		}
+	}
+
+	kubernetesVersion, err := getKubernetesVersionFunc()
+	if err != nil {
+		return nil, err
+	}
+	inventory.KubernetesVersion = kubernetesVersion
+
+	now := v1.Now()
+	inventory.LastUpdateTime = &now
+	return &inventory, nil
+}
+
+// isNodeReady returns true if the node has the Ready condition
+func isNodeReady(node corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package mcagent
+
+import (
+	"context"
+	"testing"
+
+	asserts "github.com/stretchr/testify/assert"
+	clustersapi "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestGetInventory tests gathering the inventory of the managed cluster
+// GIVEN a managed cluster with Verrazzano installed and nodes that are ready and not ready
+// WHEN the inventory is gathered
+// THEN the inventory reports the Verrazzano version and profile, the enabled components sorted by name, the
+// Kubernetes version and the number of nodes and ready nodes
+func TestGetInventory(t *testing.T) {
+	assert := asserts.New(t)
+	defer overrideKubernetesVersion()()
+
+	available := "1/2"
+	componentAvailable := v1beta1.ComponentAvailability(v1beta1.ComponentAvailable)
+	componentUnavailable := v1beta1.ComponentAvailability(v1beta1.ComponentUnavailable)
+	vz := &v1beta1.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano"},
+		Status: v1beta1.VerrazzanoStatus{
+			Version:   testManagedVerrazzanoVersion,
+			State:     v1beta1.VzStateReady,
+			Available: &available,
+			Components: v1beta1.ComponentStatusMap{
+				"rancher":       {Name: "rancher", State: v1beta1.CompStateReady, Available: &componentUnavailable, Version: "v2.7.3"},
+				"cert-manager":  {Name: "cert-manager", State: v1beta1.CompStateReady, Available: &componentAvailable, Version: "v1.9.1"},
+				"opensearch":    {Name: "opensearch", State: v1beta1.CompStateDisabled},
+				"missing-state": nil,
+			},
+		},
+	}
+	scheme := newTestScheme()
+	_ = v1beta1.AddToScheme(scheme)
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(vz,
+		newInventoryNode("node1", corev1.ConditionTrue),
+		newInventoryNode("node2", corev1.ConditionFalse),
+		newInventoryNode("node3", corev1.ConditionTrue),
+	).Build()
+	s := &Syncer{LocalClient: cli, Log: zap.S(), Context: context.TODO()}
+
+	inventory, err := s.getInventory()
+	assert.NoError(err)
+	assert.Equal(testManagedVerrazzanoVersion, inventory.VerrazzanoVersion)
+	assert.Equal(string(v1beta1.Prod), inventory.Profile)
+	assert.Equal(string(v1beta1.VzStateReady), inventory.VerrazzanoState)
+	assert.Equal(available, inventory.AvailableComponents)
+	assert.Equal([]clustersapi.ComponentInventory{
+		{Name: "cert-manager", State: string(v1beta1.CompStateReady), Available: true, Version: "v1.9.1"},
+		{Name: "rancher", State: string(v1beta1.CompStateReady), Available: false, Version: "v2.7.3"},
+	}, inventory.Components)
+	assert.Equal(testManagedKubernetesVersion, inventory.KubernetesVersion)
+	assert.Equal(3, inventory.Nodes)
+	assert.Equal(2, inventory.ReadyNodes)
+	assert.NotNil(inventory.LastUpdateTime)
+}
+
+// newInventoryNode returns a node with the given Ready condition status
+func newInventoryNode(name string, ready corev1.ConditionStatus) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+		},
+	}
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package mcagent
@@ -35,7 +35,11 @@ func (s *Syncer) syncSecretObjects(namespace string) error {
 
 	// Write each of the secrets that are targeted for the local cluster
 	for _, mcAppConfig := range allAdminMCAppConfigs.Items {
-		if s.isThisCluster(mcAppConfig.Spec.Placement) {
+		placed, err := s.isThisCluster(mcAppConfig.Spec.Placement)
+		if err != nil {
+			return err
+		}
+		if placed {
 			for _, adminSecret := range mcAppConfig.Spec.Secrets {
 				secret := corev1.Secret{}
 				namespacedName := types.NamespacedName{Name: adminSecret, Namespace: namespace}
@@ -70,7 +74,11 @@ func (s *Syncer) syncSecretObjects(namespace string) error {
 			continue
 		}
 		// Delete Secret object if it is no longer placed on this local cluster
-		if !s.k8sSecretPlacedOnCluster(secret, &allAdminMCAppConfigs) {
+		placed, err := s.k8sSecretPlacedOnCluster(secret, &allAdminMCAppConfigs)
+		if err != nil {
+			return err
+		}
+		if !placed {
 			err := s.LocalClient.Delete(s.Context, &allLocalSecrets.Items[i])
 			if err != nil {
 				s.Log.Errorf("Failed to delete Secret with name %s and namespace %s: %v", secret.Name, secret.Namespace, err)
@@ -81,13 +89,15 @@ func (s *Syncer) syncSecretObjects(namespace string) error {
 			secretAppConfigs := strings.Split(appConfigs, ",")
 			var actualAppConfigs []string
 			for _, mcAppConfig := range allAdminMCAppConfigs.Items {
-				for _, cluster := range mcAppConfig.Spec.Placement.Clusters {
-					if cluster.Name == s.ManagedClusterName {
-						for _, appConfigSecret := range mcAppConfig.Spec.Secrets {
-							// Save the name of the MultiClusterApplicationConfiguration if we have a secret match
-							if appConfigSecret == secret.Name {
-								actualAppConfigs = append(actualAppConfigs, mcAppConfig.Name)
-							}
+				placed, err := s.isThisCluster(mcAppConfig.Spec.Placement)
+				if err != nil {
+					return err
+				}
+				if placed {
+					for _, appConfigSecret := range mcAppConfig.Spec.Secrets {
+						// Save the name of the MultiClusterApplicationConfiguration if we have a secret match
+						if appConfigSecret == secret.Name {
+							actualAppConfigs = append(actualAppConfigs, mcAppConfig.Name)
 						}
 					}
 				}
@@ -139,18 +149,22 @@ func mutateSecret(managedClusterName string, mcAppConfigName string, secret core
 }
 
 // k8sSecretPlacedOnCluster returns boolean indicating if the secret is placed on the local cluster
-func (s *Syncer) k8sSecretPlacedOnCluster(secret corev1.Secret, allAdminMCAppConfigs *clustersv1alpha1.MultiClusterApplicationConfigurationList) bool {
+func (s *Syncer) k8sSecretPlacedOnCluster(secret corev1.Secret, allAdminMCAppConfigs *clustersv1alpha1.MultiClusterApplicationConfigurationList) (bool, error) {
 	for _, mcAppConfig := range allAdminMCAppConfigs.Items {
 		// Both a matching application configuration label and a matching cluster label be found for the
 		// secret to be placed on the local cluster.
 		if vzstring.CommaSeparatedStringContains(secret.Labels[mcAppConfigsLabel], mcAppConfig.Name) {
-			for _, cluster := range mcAppConfig.Spec.Placement.Clusters {
+			placement, err := s.resolvePlacement(mcAppConfig.Spec.Placement)
+			if err != nil {
+				return false, err
+			}
+			for _, cluster := range placement.Clusters {
 				if cluster.Name == secret.Labels[managedClusterLabel] {
-					return true
+					return true, nil
 				}
 			}
 		}
 	}
 
-	return false
+	return false, nil
 }
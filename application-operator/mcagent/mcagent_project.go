@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package mcagent
@@ -29,7 +29,11 @@ func (s *Syncer) syncVerrazzanoProjects() error {
 	// Write each of the records in verrazzano-mc namespace
 	for _, vp := range allAdminProjects.Items {
 		if vp.Namespace == constants.VerrazzanoMultiClusterNamespace {
-			if s.isThisCluster(vp.Spec.Placement) {
+			placed, err := s.isThisCluster(vp.Spec.Placement)
+			if err != nil {
+				return err
+			}
+			if placed {
 				_, err := s.createOrUpdateVerrazzanoProject(vp)
 				if err != nil {
 					s.Log.Errorw(fmt.Sprintf("Failed syncing object: %v", err),
@@ -88,11 +92,39 @@ func (s *Syncer) createOrUpdateVerrazzanoProject(vp clustersv1alpha1.VerrazzanoP
 	vpNew.Namespace = vp.Namespace
 	vpNew.Name = vp.Name
 
+	// Copy the clusters the resource is placed in, as resolved against the managed clusters of the admin cluster
+	placement, err := s.resolvePlacement(vp.Spec.Placement)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	vp.Spec.Placement = placement
+
 	// Create or update on the local cluster
-	return controllerutil.CreateOrUpdate(s.Context, s.LocalClient, &vpNew, func() error {
+	opResult, err := controllerutil.CreateOrUpdate(s.Context, s.LocalClient, &vpNew, func() error {
 		mutateVerrazzanoProject(vp, &vpNew)
 		return nil
 	})
+	if err != nil {
+		return opResult, err
+	}
+
+	// Report the usage of the quotas of the project on the local cluster to the admin cluster
+	return opResult, s.updateVerrazzanoProjectQuotaStatus(vp, vpNew)
+}
+
+// updateVerrazzanoProjectQuotaStatus updates the usage of the quotas of this cluster in the status
+// of the VerrazzanoProject on the admin cluster
+func (s *Syncer) updateVerrazzanoProjectQuotaStatus(vp clustersv1alpha1.VerrazzanoProject, vpLocal clustersv1alpha1.VerrazzanoProject) error {
+	var quotas []clustersv1alpha1.ProjectQuotaStatus
+	for _, quota := range vpLocal.Status.Quotas {
+		if quota.Cluster == s.ManagedClusterName {
+			quotas = append(quotas, quota)
+		}
+	}
+	if !clusters.SetClusterQuotaStatus(&vp.Status, s.ManagedClusterName, quotas) {
+		return nil
+	}
+	return s.AdminClient.Status().Update(s.Context, &vp)
 }
 
 func (s *Syncer) updateVerrazzanoProjectStatus(name types.NamespacedName, newCond clustersv1alpha1.Condition, newClusterStatus clustersv1alpha1.ClusterLevelStatus) error {
@@ -102,7 +134,7 @@ func (s *Syncer) updateVerrazzanoProjectStatus(name types.NamespacedName, newCon
 		return err
 	}
 	fetched.Status.Conditions = append(fetched.Status.Conditions, newCond)
-	clusters.SetClusterLevelStatus(&fetched.Status, newClusterStatus)
+	clusters.SetClusterLevelStatus(&fetched.Status.MultiClusterResourceStatus, newClusterStatus)
 	return s.AdminClient.Status().Update(s.Context, &fetched)
 }
 
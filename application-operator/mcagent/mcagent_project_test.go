@@ -19,6 +19,7 @@ import (
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -575,6 +576,57 @@ func TestRemovePlacementVerrazzanoProject(t *testing.T) {
 	assert.NoError(err)
 }
 
+// TestUpdateVerrazzanoProjectQuotaStatus tests reporting the quota usage of a project to the admin cluster
+// GIVEN a VerrazzanoProject on the managed cluster reporting the usage of its quotas
+// WHEN the quota usage is different from the usage reported on the admin cluster
+// THEN the quota usage of the managed cluster is updated in the status of the VerrazzanoProject on the admin cluster
+func TestUpdateVerrazzanoProjectQuotaStatus(t *testing.T) {
+	assert := asserts.New(t)
+	log := zap.S().With("test")
+
+	adminMocker := gomock.NewController(t)
+	adminMock := mocks.NewMockClient(adminMocker)
+	adminStatusMock := mocks.NewMockStatusWriter(adminMocker)
+
+	otherQuota := clustersv1alpha1.ProjectQuotaStatus{
+		Cluster: "other-cluster",
+		Hard:    corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+	}
+	localQuota := clustersv1alpha1.ProjectQuotaStatus{
+		Cluster: testClusterName,
+		Hard:    corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+		Used:    corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+	}
+	adminProj, err := getTestVerrazzanoProject(constants.VerrazzanoMultiClusterNamespace, "testProject", []clustersv1alpha1.NamespaceTemplate{testNamespace1}, []clustersv1alpha1.Cluster{{Name: testClusterName}})
+	assert.NoError(err)
+	adminProj.Status.Quotas = []clustersv1alpha1.ProjectQuotaStatus{otherQuota}
+	localProj := adminProj
+	localProj.Status.Quotas = []clustersv1alpha1.ProjectQuotaStatus{localQuota}
+
+	// Admin Cluster - expect call to update the status of the VerrazzanoProject with the quota usage of both clusters
+	adminMock.EXPECT().Status().Return(adminStatusMock)
+	adminStatusMock.EXPECT().
+		Update(gomock.Any(), gomock.AssignableToTypeOf(&clustersv1alpha1.VerrazzanoProject{}), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, vp *clustersv1alpha1.VerrazzanoProject, opts ...client.UpdateOption) error {
+			assert.Equal([]clustersv1alpha1.ProjectQuotaStatus{otherQuota, localQuota}, vp.Status.Quotas)
+			return nil
+		})
+
+	s := &Syncer{
+		AdminClient:        adminMock,
+		Log:                log,
+		ManagedClusterName: testClusterName,
+		Context:            context.TODO(),
+	}
+	assert.NoError(s.updateVerrazzanoProjectQuotaStatus(adminProj, localProj))
+
+	// The quota usage is already reported, the status is not updated again
+	adminProj.Status.Quotas = []clustersv1alpha1.ProjectQuotaStatus{otherQuota, localQuota}
+	assert.NoError(s.updateVerrazzanoProjectQuotaStatus(adminProj, localProj))
+
+	adminMocker.Finish()
+}
+
 // getTestVerrazzanoProject creates and returns VerrazzanoProject used in tests
 func getTestVerrazzanoProject(vpNamespace string, vpName string, nsNames []clustersv1alpha1.NamespaceTemplate, clusters []clustersv1alpha1.Cluster) (clustersv1alpha1.VerrazzanoProject, error) {
 	proj := clustersv1alpha1.VerrazzanoProject{}
@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package mcagent
@@ -17,41 +17,28 @@ import (
 
 // Synchronize MultiClusterSecret objects to the local cluster
 func (s *Syncer) syncMCSecretObjects(namespace string) error {
-	// Get all the MultiClusterSecret objects from the admin cluster
-	allAdminMCSecrets := clustersv1alpha1.MultiClusterSecretList{}
-	listOptions := &client.ListOptions{Namespace: namespace}
-	err := s.AdminClient.List(s.Context, &allAdminMCSecrets, listOptions)
-	// When placements are changed a forbidden error can be returned.  In this case,
-	// we want to fall through and delete orphaned resources.
-	if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsForbidden(err) {
+	allAdminMCSecrets, err := s.createOrUpdateMCSecrets(namespace)
+	if err != nil {
 		return err
 	}
 
-	// Write each of the records that are targeted to this cluster
-	for _, mcSecret := range allAdminMCSecrets.Items {
-		if s.isThisCluster(mcSecret.Spec.Placement) {
-			_, err := s.createOrUpdateMCSecret(mcSecret)
-			if err != nil {
-				s.Log.Errorw(fmt.Sprintf("Failed syncing object: %v", err),
-					"MultiClusterSecret",
-					types.NamespacedName{Namespace: mcSecret.Namespace, Name: mcSecret.Name})
-			}
-		}
-	}
-
 	// Delete orphaned or no longer placed MultiClusterSecret resources.
 	// Get the list of MultiClusterSecret resources on the
 	// local cluster and compare to the list received from the admin cluster.
 	// The admin cluster is the source of truth.
 	allLocalMCSecrets := clustersv1alpha1.MultiClusterSecretList{}
-	err = s.LocalClient.List(s.Context, &allLocalMCSecrets, listOptions)
+	err = s.LocalClient.List(s.Context, &allLocalMCSecrets, &client.ListOptions{Namespace: namespace})
 	if err != nil {
 		s.Log.Errorf("Failed to list MultiClusterSecret on local cluster: %v", err)
 		return nil
 	}
 	for si, mcSecret := range allLocalMCSecrets.Items {
 		// Delete each MultiClusterSecret object that is not on the admin cluster or no longer placed on this cluster
-		if !s.secretPlacedOnCluster(&allAdminMCSecrets, mcSecret.Name, mcSecret.Namespace) {
+		placed, err := s.secretPlacedOnCluster(allAdminMCSecrets, mcSecret.Name, mcSecret.Namespace)
+		if err != nil {
+			return err
+		}
+		if !placed {
 			err := s.LocalClient.Delete(s.Context, &allLocalMCSecrets.Items[si])
 			if err != nil {
 				s.Log.Errorf("Failed to delete MultiClusterSecret with name %q and namespace %q: %v", mcSecret.Name, mcSecret.Namespace, err)
@@ -62,12 +49,59 @@ func (s *Syncer) syncMCSecretObjects(namespace string) error {
 	return nil
 }
 
+// createOrUpdateMCSecrets creates or updates the MultiClusterSecret objects of the admin cluster placed in this cluster,
+// and handles the drift of the Secrets created from them.  Returns the MultiClusterSecret objects of the admin cluster.
+func (s *Syncer) createOrUpdateMCSecrets(namespace string) (*clustersv1alpha1.MultiClusterSecretList, error) {
+	// Get all the MultiClusterSecret objects from the admin cluster
+	allAdminMCSecrets := clustersv1alpha1.MultiClusterSecretList{}
+	listOptions := &client.ListOptions{Namespace: namespace}
+	err := s.AdminClient.List(s.Context, &allAdminMCSecrets, listOptions)
+	// When placements are changed a forbidden error can be returned.  In this case,
+	// we want to fall through and delete orphaned resources.
+	if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsForbidden(err) {
+		return nil, err
+	}
+
+	// Write each of the records that are targeted to this cluster
+	for _, mcSecret := range allAdminMCSecrets.Items {
+		placed, err := s.isThisCluster(mcSecret.Spec.Placement)
+		if err != nil {
+			return nil, err
+		}
+		if placed {
+			opResult, err := s.createOrUpdateMCSecret(mcSecret)
+			if err != nil {
+				s.Log.Errorw(fmt.Sprintf("Failed syncing object: %v", err),
+					"MultiClusterSecret",
+					types.NamespacedName{Namespace: mcSecret.Namespace, Name: mcSecret.Name})
+				continue
+			}
+			// Drift is only detected once the local MultiClusterSecret is up to date with the admin cluster
+			if opResult == controllerutil.OperationResultNone {
+				if err := s.syncMCSecretDrift(mcSecret); err != nil {
+					s.Log.Errorw(fmt.Sprintf("Failed syncing drift: %v", err),
+						"MultiClusterSecret",
+						types.NamespacedName{Namespace: mcSecret.Namespace, Name: mcSecret.Name})
+				}
+			}
+		}
+	}
+	return &allAdminMCSecrets, nil
+}
+
 // Create or update a MultiClusterSecret
 func (s *Syncer) createOrUpdateMCSecret(mcSecret clustersv1alpha1.MultiClusterSecret) (controllerutil.OperationResult, error) {
 	var mcSecretNew clustersv1alpha1.MultiClusterSecret
 	mcSecretNew.Namespace = mcSecret.Namespace
 	mcSecretNew.Name = mcSecret.Name
 
+	// Copy the clusters the resource is placed in, as resolved against the managed clusters of the admin cluster
+	placement, err := s.resolvePlacement(mcSecret.Spec.Placement)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	mcSecret.Spec.Placement = placement
+
 	// Create or update on the local cluster
 	return controllerutil.CreateOrUpdate(s.Context, s.LocalClient, &mcSecretNew, func() error {
 		mutateMCSecret(mcSecret, &mcSecretNew)
@@ -95,11 +129,11 @@ func mutateMCSecret(mcSecret clustersv1alpha1.MultiClusterSecret, mcSecretNew *c
 
 // secretPlacedOnCluster returns boolean indicating if the list contains the object with the specified name and namespace
 // and indicates the object is placed on the local cluster
-func (s *Syncer) secretPlacedOnCluster(mcAdminList *clustersv1alpha1.MultiClusterSecretList, name string, namespace string) bool {
+func (s *Syncer) secretPlacedOnCluster(mcAdminList *clustersv1alpha1.MultiClusterSecretList, name string, namespace string) (bool, error) {
 	for _, item := range mcAdminList.Items {
 		if item.Name == name && item.Namespace == namespace {
 			return s.isThisCluster(item.Spec.Placement)
 		}
 	}
-	return false
+	return false, nil
 }
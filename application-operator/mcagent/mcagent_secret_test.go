@@ -12,13 +12,17 @@ import (
 	"github.com/golang/mock/gomock"
 	asserts "github.com/stretchr/testify/assert"
 	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
 	clusterstest "github.com/verrazzano/verrazzano/application-operator/controllers/clusters/test"
 	"github.com/verrazzano/verrazzano/application-operator/mocks"
+	clustersapi "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 const testClusterName = "managed1"
@@ -290,6 +294,18 @@ func TestDeleteMCSecret(t *testing.T) {
 			return nil
 		})
 
+	// Managed Cluster - expect calls to get the MultiClusterSecret and its Secret to detect drift
+	//                   The Secret has not been created yet
+	mcMock.EXPECT().
+		Get(gomock.Any(), types.NamespacedName{Namespace: testMCSecretNamespace, Name: testMCSecretName}, gomock.AssignableToTypeOf(&clustersv1alpha1.MultiClusterSecret{}), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, name types.NamespacedName, mcSecret *clustersv1alpha1.MultiClusterSecret, opts ...client.GetOption) error {
+			testMCSecret.DeepCopyInto(mcSecret)
+			return nil
+		})
+	mcMock.EXPECT().
+		Get(gomock.Any(), types.NamespacedName{Namespace: testMCSecretNamespace, Name: testMCSecretName}, gomock.Not(gomock.Nil()), gomock.Any()).
+		Return(errors.NewNotFound(schema.GroupResource{Group: "", Resource: "Secret"}, testMCSecretName))
+
 	// Managed Cluster - expect call to list MultiClusterSecret objects - return list including an orphaned object
 	mcMock.EXPECT().
 		List(gomock.Any(), &clustersv1alpha1.MultiClusterSecretList{}, gomock.Not(gomock.Nil())).
@@ -321,6 +337,140 @@ func TestDeleteMCSecret(t *testing.T) {
 	assert.NoError(err)
 }
 
+// TestSyncMCSecretPlacedByLabels tests the synchronization of a MultiClusterSecret placed by cluster labels
+// GIVEN a MultiClusterSecret selecting the managed clusters of a region
+// WHEN the MultiClusterSecret objects are synchronized
+// THEN the MultiClusterSecret is created with the clusters of the region while this cluster is in the region,
+// and deleted when this cluster is moved to another region
+func TestSyncMCSecretPlacedByLabels(t *testing.T) {
+	assert := asserts.New(t)
+
+	testMCSecret, err := getSampleMCSecret("testdata/multicluster-secret.yaml")
+	assert.NoError(err, "failed to get sample secret data")
+	testMCSecret.Spec.Placement = clustersv1alpha1.Placement{
+		ClusterSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"region": "eu"}},
+	}
+	thisVMC := clustersapi.VerrazzanoManagedCluster{ObjectMeta: metav1.ObjectMeta{
+		Namespace: constants.VerrazzanoMultiClusterNamespace, Name: testClusterName, Labels: map[string]string{"region": "eu"}}}
+	otherVMC := clustersapi.VerrazzanoManagedCluster{ObjectMeta: metav1.ObjectMeta{
+		Namespace: constants.VerrazzanoMultiClusterNamespace, Name: "managed2", Labels: map[string]string{"region": "eu"}}}
+
+	scheme := newScheme()
+	_ = clustersapi.AddToScheme(scheme)
+	adminClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&testMCSecret, &thisVMC, &otherVMC).Build()
+	localClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	newSyncer := func() *Syncer {
+		return &Syncer{
+			AdminClient:        adminClient,
+			LocalClient:        localClient,
+			Log:                zap.S().With("test"),
+			ManagedClusterName: testClusterName,
+			Context:            context.TODO(),
+		}
+	}
+
+	assert.NoError(newSyncer().syncMCSecretObjects(testMCSecretNamespace))
+	mcSecret := clustersv1alpha1.MultiClusterSecret{}
+	assert.NoError(localClient.Get(context.TODO(), types.NamespacedName{Namespace: testMCSecretNamespace, Name: testMCSecretName}, &mcSecret))
+	assert.Equal(clustersv1alpha1.Placement{Clusters: []clustersv1alpha1.Cluster{{Name: "managed1"}, {Name: "managed2"}}}, mcSecret.Spec.Placement)
+
+	thisVMC.Labels["region"] = "us"
+	assert.NoError(adminClient.Update(context.TODO(), &thisVMC))
+	assert.NoError(newSyncer().syncMCSecretObjects(testMCSecretNamespace))
+	err = localClient.Get(context.TODO(), types.NamespacedName{Namespace: testMCSecretNamespace, Name: testMCSecretName}, &mcSecret)
+	assert.True(errors.IsNotFound(err))
+}
+
+// TestSyncMCSecretManagedClustersNotListed tests the synchronization of a MultiClusterSecret placed by cluster labels
+// GIVEN a MultiClusterSecret selecting the managed clusters of a region, synchronized to this cluster
+// WHEN the MultiClusterSecret objects are synchronized and the managed clusters cannot be listed
+// THEN an error is returned and the MultiClusterSecret is not deleted from this cluster
+func TestSyncMCSecretManagedClustersNotListed(t *testing.T) {
+	assert := asserts.New(t)
+
+	testMCSecret, err := getSampleMCSecret("testdata/multicluster-secret.yaml")
+	assert.NoError(err, "failed to get sample secret data")
+	testMCSecret.Spec.Placement = clustersv1alpha1.Placement{
+		ClusterSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"region": "eu"}},
+	}
+	thisVMC := clustersapi.VerrazzanoManagedCluster{ObjectMeta: metav1.ObjectMeta{
+		Namespace: constants.VerrazzanoMultiClusterNamespace, Name: testClusterName, Labels: map[string]string{"region": "eu"}}}
+
+	scheme := newScheme()
+	_ = clustersapi.AddToScheme(scheme)
+	adminClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&testMCSecret, &thisVMC).Build()
+	localClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	s := &Syncer{
+		AdminClient:        adminClient,
+		LocalClient:        localClient,
+		Log:                zap.S().With("test"),
+		ManagedClusterName: testClusterName,
+		Context:            context.TODO(),
+	}
+	assert.NoError(s.syncMCSecretObjects(testMCSecretNamespace))
+
+	s = &Syncer{
+		AdminClient:        vmcListFailureClient{adminClient},
+		LocalClient:        localClient,
+		Log:                zap.S().With("test"),
+		ManagedClusterName: testClusterName,
+		Context:            context.TODO(),
+	}
+	assert.Error(s.syncMCSecretObjects(testMCSecretNamespace))
+	mcSecret := clustersv1alpha1.MultiClusterSecret{}
+	assert.NoError(localClient.Get(context.TODO(), types.NamespacedName{Namespace: testMCSecretNamespace, Name: testMCSecretName}, &mcSecret))
+}
+
+// TestSyncMCSecretInvalidClusterSelector tests the synchronization of a MultiClusterSecret placed by cluster labels
+// GIVEN a MultiClusterSecret synchronized to this cluster
+// WHEN the cluster selector of the MultiClusterSecret is changed to an invalid selector
+// THEN an error is returned and the MultiClusterSecret is not deleted from this cluster
+func TestSyncMCSecretInvalidClusterSelector(t *testing.T) {
+	assert := asserts.New(t)
+
+	testMCSecret, err := getSampleMCSecret("testdata/multicluster-secret.yaml")
+	assert.NoError(err, "failed to get sample secret data")
+	testMCSecret.Spec.Placement = clustersv1alpha1.Placement{
+		ClusterSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"region": "eu"}},
+	}
+	thisVMC := clustersapi.VerrazzanoManagedCluster{ObjectMeta: metav1.ObjectMeta{
+		Namespace: constants.VerrazzanoMultiClusterNamespace, Name: testClusterName, Labels: map[string]string{"region": "eu"}}}
+
+	scheme := newScheme()
+	_ = clustersapi.AddToScheme(scheme)
+	adminClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&testMCSecret, &thisVMC).Build()
+	localClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	s := &Syncer{
+		AdminClient:        adminClient,
+		LocalClient:        localClient,
+		Log:                zap.S().With("test"),
+		ManagedClusterName: testClusterName,
+		Context:            context.TODO(),
+	}
+	assert.NoError(s.syncMCSecretObjects(testMCSecretNamespace))
+
+	testMCSecret.Spec.Placement.ClusterSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+		{Key: "region", Operator: "Near", Values: []string{"eu"}},
+	}}
+	assert.NoError(adminClient.Update(context.TODO(), &testMCSecret))
+	s.managedClustersListed = false
+	assert.Error(s.syncMCSecretObjects(testMCSecretNamespace))
+	mcSecret := clustersv1alpha1.MultiClusterSecret{}
+	assert.NoError(localClient.Get(context.TODO(), types.NamespacedName{Namespace: testMCSecretNamespace, Name: testMCSecretName}, &mcSecret))
+}
+
+// vmcListFailureClient is a client failing to list the VerrazzanoManagedCluster resources
+type vmcListFailureClient struct {
+	client.Client
+}
+
+func (c vmcListFailureClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if _, ok := list.(*clustersapi.VerrazzanoManagedClusterList); ok {
+		return errors.NewServiceUnavailable("unavailable")
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
 // getSampleMCSecret creates and returns a sample MultiClusterSecret used in tests
 func getSampleMCSecret(filePath string) (clustersv1alpha1.MultiClusterSecret, error) {
 	mcSecret := clustersv1alpha1.MultiClusterSecret{}
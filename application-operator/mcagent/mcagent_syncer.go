@@ -37,6 +37,14 @@ type Syncer struct {
 	// List of namespaces to watch for multi-cluster objects.
 	ProjectNamespaces   []string
 	StatusUpdateChannel chan clusters.StatusUpdateMessage
+
+	// VerrazzanoManagedCluster resources of the admin cluster, listed at most once per sync
+	// to resolve the placements selecting clusters by labels
+	managedClusters       []v1alpha1.VerrazzanoManagedCluster
+	managedClustersListed bool
+
+	// watcher of the admin cluster, the resources that it watches are not polled
+	watcher *adminWatcher
 }
 
 type adminStatusUpdateFuncType = func(name types.NamespacedName, newCond clustersv1alpha1.Condition, newClusterStatus clustersv1alpha1.ClusterLevelStatus) error
@@ -49,21 +57,67 @@ var (
 	retryDelay = 3 * time.Second
 )
 
-// Check if the placement is for this cluster
-func (s *Syncer) isThisCluster(placement clustersv1alpha1.Placement) bool {
+// Check if the placement is for this cluster.  An error is returned if the placement cannot be resolved, in which
+// case the resource must neither be created nor deleted.
+func (s *Syncer) isThisCluster(placement clustersv1alpha1.Placement) (bool, error) {
+	resolved, err := s.resolvePlacement(placement)
+	if err != nil {
+		return false, err
+	}
 	// Loop through the cluster list looking for the cluster name
-	for _, cluster := range placement.Clusters {
+	for _, cluster := range resolved.Clusters {
 		if cluster.Name == s.ManagedClusterName {
-			return true
+			return true, nil
 		}
 	}
-	return false
+	return false, nil
 }
 
-// processStatusUpdates monitors the StatusUpdateChannel for any
-// received messages and processes a batch of them
+// resolvePlacement returns a placement listing the clusters of the given placement by name.  The clusters
+// selected by labels are resolved against the VerrazzanoManagedCluster resources of the admin cluster, so
+// that the copies of the resources on this cluster list the clusters they are placed in.  An error is returned if
+// the VerrazzanoManagedCluster resources cannot be listed or the cluster selector is invalid.
+func (s *Syncer) resolvePlacement(placement clustersv1alpha1.Placement) (clustersv1alpha1.Placement, error) {
+	if !clusters.HasDynamicPlacement(placement) && len(placement.ExcludedClusters) == 0 {
+		return placement, nil
+	}
+	var vmcs []v1alpha1.VerrazzanoManagedCluster
+	if clusters.HasDynamicPlacement(placement) {
+		var err error
+		vmcs, err = s.getManagedClusters()
+		if err != nil {
+			return clustersv1alpha1.Placement{}, err
+		}
+	}
+	names, err := clusters.GetPlacementClusters(placement, vmcs)
+	if err != nil {
+		return clustersv1alpha1.Placement{}, fmt.Errorf("failed to resolve the cluster selector of a placement: %v", err)
+	}
+	resolved := clustersv1alpha1.Placement{}
+	for _, name := range names {
+		resolved.Clusters = append(resolved.Clusters, clustersv1alpha1.Cluster{Name: name})
+	}
+	return resolved, nil
+}
+
+// getManagedClusters returns the VerrazzanoManagedCluster resources of the admin cluster
+func (s *Syncer) getManagedClusters() ([]v1alpha1.VerrazzanoManagedCluster, error) {
+	if s.managedClustersListed {
+		return s.managedClusters, nil
+	}
+	vmcList := v1alpha1.VerrazzanoManagedClusterList{}
+	if err := s.AdminClient.List(s.Context, &vmcList, client.InNamespace(constants.VerrazzanoMultiClusterNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list VerrazzanoManagedCluster objects on the admin cluster: %v", err)
+	}
+	s.managedClusters = vmcList.Items
+	s.managedClustersListed = true
+	return s.managedClusters, nil
+}
+
+// processStatusUpdates processes the messages received on the StatusUpdateChannel that have not been
+// processed yet by the watcher of the admin cluster
 func (s *Syncer) processStatusUpdates() {
-	for i := 0; i < constants.StatusUpdateBatchSize; i++ {
+	for {
 		// Use a select with default so as to not block on the channel if there are no updates
 		select {
 		case msg := <-s.StatusUpdateChannel:
@@ -74,7 +128,7 @@ func (s *Syncer) processStatusUpdates() {
 					msg.NewClusterStatus.Name, retryCount, err)
 			}
 		default:
-			break
+			return
 		}
 	}
 }
@@ -177,6 +231,15 @@ func (s *Syncer) updateVMCStatus() error {
 	// If Thanos is disabled, we want to empty the host so Prometheus federation returns
 	vmc.Status.ThanosQueryStore = thanosAPIHost
 
+	// Report the Verrazzano version, component health and Kubernetes inventory of the managed cluster. Failing to
+	// gather the inventory must not prevent recording that the agent connected, so keep the last reported inventory.
+	inventory, err := s.getInventory()
+	if err != nil {
+		s.Log.Errorf("Failed to get the inventory to update VMC %s: %v", vmcName, err)
+	} else {
+		vmc.Status.Inventory = inventory
+	}
+
 	// update status of VMC
 	return s.AdminClient.Status().Update(s.Context, &vmc)
 }
@@ -199,32 +262,28 @@ func (s *Syncer) SyncMultiClusterResources() {
 		s.Log.Errorf("Failed syncing VerrazzanoProject objects: %v", err)
 	}
 
-	// Synchronize objects one namespace at a time
+	// Synchronize objects one namespace at a time, except for the objects synced by the watches of the admin cluster,
+	// whose drift is still detected
 	for _, namespace := range s.ProjectNamespaces {
-		err = s.syncSecretObjects(namespace)
-		if err != nil {
-			s.Log.Errorf("Failed to sync Secret objects: %v", err)
-		}
-		err = s.syncMCSecretObjects(namespace)
-		if err != nil {
-			s.Log.Errorf("Failed to sync MultiClusterSecret objects: %v", err)
-		}
-		err = s.syncMCConfigMapObjects(namespace)
-		if err != nil {
-			s.Log.Errorf("Failed to sync MultiClusterConfigMap objects: %v", err)
-		}
-		err = s.syncMCComponentObjects(namespace)
-		if err != nil {
-			s.Log.Errorf("Failed to sync MultiClusterComponent objects: %v", err)
-		}
-		err = s.syncMCApplicationConfigurationObjects(namespace)
-		if err != nil {
-			s.Log.Errorf("Failed to sync MultiClusterApplicationConfiguration objects: %v", err)
+		for _, kind := range []string{clustersv1alpha1.MultiClusterSecretKind, clustersv1alpha1.MultiClusterConfigMapKind,
+			clustersv1alpha1.MultiClusterComponentKind, clustersv1alpha1.MultiClusterAppConfigKind} {
+			if s.watcher.isSynced(kind, namespace) {
+				if syncDrift := watchedKinds[kind].syncDrift; syncDrift != nil {
+					if err := syncDrift(s, namespace); err != nil {
+						s.Log.Errorf("Failed to sync the drift of %s objects: %v", kind, err)
+					}
+				}
+				continue
+			}
+			if err := watchedKinds[kind].sync(s, namespace); err != nil {
+				s.Log.Errorf("Failed to sync %s objects: %v", kind, err)
+				continue
+			}
+			s.watcher.markSynced(kind, namespace)
 		}
-
-		s.processStatusUpdates()
-
 	}
+
+	s.processStatusUpdates()
 }
 
 // getAPIServerURL returns the API Server URL for Verrazzano instance.
@@ -39,13 +39,18 @@ func TestSyncer_isThisCluster(t *testing.T) {
 		{"same cluster multi-placement", "mycluster1", v1alpha1.Placement{Clusters: []v1alpha1.Cluster{{Name: "othercluster"}, {Name: "mycluster1"}}}, true},
 		{"different cluster single placement", "mycluster1", v1alpha1.Placement{Clusters: []v1alpha1.Cluster{{Name: "othercluster"}}}, false},
 		{"different cluster multi-placement", "mycluster1", v1alpha1.Placement{Clusters: []v1alpha1.Cluster{{Name: "othercluster"}, {Name: "mycluster2"}}}, false},
+		{"excluded cluster", "mycluster1", v1alpha1.Placement{Clusters: []v1alpha1.Cluster{{Name: "mycluster1"}}, ExcludedClusters: []v1alpha1.Cluster{{Name: "mycluster1"}}}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &Syncer{
 				ManagedClusterName: tt.managedClusterName,
 			}
-			if got := s.isThisCluster(tt.placement); got != tt.want {
+			got, err := s.isThisCluster(tt.placement)
+			if err != nil {
+				t.Errorf("isThisCluster() error = %v", err)
+			}
+			if got != tt.want {
 				t.Errorf("isThisCluster() = %v, want %v", got, tt.want)
 			}
 		})
@@ -6,6 +6,7 @@ package mcagent
 import (
 	"context"
 	"fmt"
+	"os"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -18,6 +19,7 @@ import (
 	clustersapi "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
 	vzconstants "github.com/verrazzano/verrazzano/pkg/constants"
 	"github.com/verrazzano/verrazzano/pkg/mcconstants"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
@@ -40,6 +42,8 @@ var validSecret = corev1.Secret{
 
 const testManagedPrometheusHost = "prometheus"
 const testManagedThanosQueryStoreAPIHost = "thanos-query-store.example.com"
+const testManagedVerrazzanoVersion = "1.6.0"
+const testManagedKubernetesVersion = "v1.25.7"
 
 // TestReconcileAgentSecretDeleted tests agent thread when the registration secret is deleted
 // GIVEN a request to process the agent loop
@@ -120,6 +124,29 @@ func TestValidateSecret(t *testing.T) {
 	assert.Contains(err.Error(), fmt.Sprintf("missing the required field %s", mcconstants.KubeconfigKey))
 }
 
+// TestCreateAdminClient tests creating the client of the admin cluster
+// GIVEN an agent secret with the kubeconfig of the admin cluster
+// WHEN the admin cluster client is created
+// THEN the client supports watches, so that the multi cluster resources are watched instead of polled
+func TestCreateAdminClient(t *testing.T) {
+	assert := asserts.New(t)
+	server := newServer()
+	defer server.Close()
+	assert.NoError(createFakeKubeConfig(server.URL))
+	defer deleteFakeKubeConfig()
+	kubeconfigPath, err := getFakeKubeConfigPath()
+	assert.NoError(err)
+	kubeconfig, err := os.ReadFile(kubeconfigPath)
+	assert.NoError(err)
+
+	secret := validSecret
+	secret.Data = map[string][]byte{constants.ClusterNameData: []byte("cluster1"), mcconstants.KubeconfigKey: kubeconfig}
+	adminClient, err := getAdminClientFunc(&secret)
+	assert.NoError(err)
+	_, ok := adminClient.(client.WithWatch)
+	assert.True(ok)
+}
+
 // Test_getEnvValue tests getEnvValue
 // GIVEN a request for a specified ENV name
 // WHEN the env array contains such an env
@@ -226,6 +253,7 @@ func TestReconcile_AgentSecretPresenceAndValidity(t *testing.T) {
 			getAdminClientFunc = func(secret *corev1.Secret) (client.Client, error) {
 				return adminMock, nil
 			}
+			defer overrideKubernetesVersion()()
 
 			secretToUse := validSecret
 			if !tt.fields.AgentSecretValid {
@@ -295,6 +323,9 @@ func expectAdminVMCStatusUpdateSuccess(adminMock *mocks.MockClient, vmcName type
 			assert.NotNil(vmc.Status.APIUrl)
 			assert.Equal(testManagedPrometheusHost, vmc.Status.PrometheusHost)
 			assert.Equal(testManagedThanosQueryStoreAPIHost, vmc.Status.ThanosQueryStore)
+			assert.NotNil(vmc.Status.Inventory)
+			assert.Equal(testManagedVerrazzanoVersion, vmc.Status.Inventory.VerrazzanoVersion)
+			assert.Equal(testManagedKubernetesVersion, vmc.Status.Inventory.KubernetesVersion)
 			return nil
 		})
 }
@@ -381,10 +412,12 @@ func TestSyncer_updateVMCStatus(t *testing.T) {
 		LocalClient:        localClientMock,
 	}
 	vmcName := types.NamespacedName{Name: s.ManagedClusterName, Namespace: constants.VerrazzanoMultiClusterNamespace}
+	defer overrideKubernetesVersion()()
 
 	expectGetAPIServerURLCalled(localClientMock)
 	expectGetPrometheusHostCalled(localClientMock)
 	expectGetThanosQueryHostCalled(localClientMock)
+	expectGetInventoryCalled(localClientMock)
 	// Mock the success of status updates and assert that updateVMCStatus returns nil error
 	expectAdminVMCStatusUpdateSuccess(adminMock, vmcName, adminStatusMock, assert)
 	assert.Nil(s.updateVMCStatus())
@@ -424,6 +457,32 @@ func expectGetThanosQueryHostCalled(mock *mocks.MockClient) {
 	expectGetIngress(mock, constants.VerrazzanoSystemNamespace, vzconstants.ThanosQueryStoreIngress, testManagedThanosQueryStoreAPIHost)
 }
 
+func expectGetInventoryCalled(mock *mocks.MockClient) {
+	// Expect a call to list the Verrazzano resources and return the installed Verrazzano
+	mock.EXPECT().
+		List(gomock.Any(), gomock.AssignableToTypeOf(&v1beta1.VerrazzanoList{}), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, list *v1beta1.VerrazzanoList, opts ...client.ListOption) error {
+			list.Items = []v1beta1.Verrazzano{{Status: v1beta1.VerrazzanoStatus{Version: testManagedVerrazzanoVersion}}}
+			return nil
+		})
+	// Expect a call to list the nodes and return no nodes
+	mock.EXPECT().
+		List(gomock.Any(), gomock.AssignableToTypeOf(&corev1.NodeList{}), gomock.Any()).
+		Return(nil)
+}
+
+// overrideKubernetesVersion overrides getting the Kubernetes version of the managed cluster, and returns a function
+// restoring it
+func overrideKubernetesVersion() func() {
+	originalFunc := getKubernetesVersionFunc
+	getKubernetesVersionFunc = func() (string, error) {
+		return testManagedKubernetesVersion, nil
+	}
+	return func() {
+		getKubernetesVersionFunc = originalFunc
+	}
+}
+
 // Expects a call to get an ingress with the given name and namespace, and returns an ingress with the specified
 // ingressHost
 func expectGetIngress(mock *mocks.MockClient, ingressNamespace string, ingressName string, ingressHost string) {
@@ -557,8 +616,12 @@ func expectAllCallsNoApps(adminMock *mocks.MockClient, mcMock *mocks.MockClient,
 	expectGetAPIServerURLCalled(mcMock)
 	expectGetPrometheusHostCalled(mcMock)
 	expectGetThanosQueryHostCalled(mcMock)
+	expectGetInventoryCalled(mcMock)
 	expectAdminVMCStatusUpdateSuccess(adminMock, vmcName, adminStatusMock, assert)
 
+	// Managed Cluster - expect call to get the fleet upgrade secret - return not found
+	expectGetFleetUpgradeSecretNotFound(mcMock)
+
 	// Managed Cluster - expect call to get MC app config CRD - return exists
 	expectGetMCAppConfigCRD(mcMock)
 
@@ -580,6 +643,12 @@ func expectAllCallsNoApps(adminMock *mocks.MockClient, mcMock *mocks.MockClient,
 	expectGetManifestSecretNotFound(adminMock, clusterName)
 }
 
+func expectGetFleetUpgradeSecretNotFound(mock *mocks.MockClient) {
+	mock.EXPECT().
+		Get(gomock.Any(), types.NamespacedName{Namespace: constants.VerrazzanoSystemNamespace, Name: constants.MCFleetUpgradeSecret}, gomock.Not(gomock.Nil()), gomock.Any()).
+		Return(errors.NewNotFound(schema.GroupResource{Group: "", Resource: "Secret"}, constants.MCFleetUpgradeSecret))
+}
+
 func expectGetMCAppConfigCRD(mock *mocks.MockClient) {
 	mock.EXPECT().
 		Get(gomock.Any(), types.NamespacedName{Name: mcAppConfCRDName}, gomock.Not(gomock.Nil()), gomock.Any()).
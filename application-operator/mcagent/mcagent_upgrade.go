@@ -0,0 +1,155 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package mcagent
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/mcconstants"
+	"github.com/verrazzano/verrazzano/pkg/semver"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	platformOperatorDeployment        = "verrazzano-platform-operator"
+	platformOperatorWebhookDeployment = "verrazzano-platform-operator-webhook"
+)
+
+// syncFleetUpgrade upgrades Verrazzano on this managed cluster to the version pushed to the fleet upgrade secret by
+// a VerrazzanoFleetUpgrade of the admin cluster. The platform operator image in the secret, if any, is set on the
+// platform operator deployments before the upgrade is requested, so that the platform operator supports the target
+// version. The progress of the upgrade is reported to the admin cluster in the inventory of the VMC status.
+func (s *Syncer) syncFleetUpgrade() error {
+	upgradeSecret := corev1.Secret{}
+	err := s.LocalClient.Get(s.Context, types.NamespacedName{Namespace: constants.VerrazzanoSystemNamespace, Name: constants.MCFleetUpgradeSecret}, &upgradeSecret)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	version := string(upgradeSecret.Data[mcconstants.FleetUpgradeVersionKey])
+	if len(version) == 0 {
+		return nil
+	}
+
+	vzList := v1beta1.VerrazzanoList{}
+	if err := s.LocalClient.List(s.Context, &vzList); err != nil {
+		return fmt.Errorf("unable to list Verrazzano resources: %v", err)
+	}
+	if len(vzList.Items) == 0 {
+		return nil
+	}
+	vz := &vzList.Items[0]
+	targetVersion, err := semver.NewSemVersion(version)
+	if err != nil {
+		return fmt.Errorf("invalid version %s in the fleet upgrade secret: %v", version, err)
+	}
+	if isVersionAtLeast(vz.Spec.Version, targetVersion) || isVersionAtLeast(vz.Status.Version, targetVersion) {
+		// The upgrade has already been requested, or Verrazzano is already at or above the target version
+		return nil
+	}
+
+	if operatorImage := string(upgradeSecret.Data[mcconstants.FleetUpgradeOperatorImageKey]); len(operatorImage) > 0 {
+		if err := validateOperatorImageUpgrade(vz.Status.Version, targetVersion); err != nil {
+			return err
+		}
+		if err := s.updatePlatformOperatorImage(operatorImage); err != nil {
+			return fmt.Errorf("failed to update the Verrazzano platform operator image for version %s: %v", version, err)
+		}
+	}
+
+	// The update is rejected until the platform operator of the target version is running, in which case
+	// it is retried on the next sync
+	s.Log.Infof("Upgrading Verrazzano %s/%s from version %s to version %s", vz.Namespace, vz.Name, vz.Status.Version, version)
+	vz.Spec.Version = version
+	if err := s.LocalClient.Update(s.Context, vz); err != nil {
+		return fmt.Errorf("failed to set the version of Verrazzano %s/%s to %s: %v", vz.Namespace, vz.Name, version, err)
+	}
+	return nil
+}
+
+// updatePlatformOperatorImage sets the image of the containers of the platform operator deployments. Only the tag or
+// the digest of the image can change, an image of another repository is rejected so that the admin cluster cannot
+// run arbitrary images on this cluster.
+func (s *Syncer) updatePlatformOperatorImage(image string) error {
+	repository := getImageRepository(image)
+	for _, name := range []string{platformOperatorDeployment, platformOperatorWebhookDeployment} {
+		deployment := appsv1.Deployment{}
+		err := s.LocalClient.Get(s.Context, types.NamespacedName{Namespace: vzconst.VerrazzanoInstallNamespace, Name: name}, &deployment)
+		if errors.IsNotFound(err) && name == platformOperatorWebhookDeployment {
+			// The platform operator webhooks are served by the platform operator in older versions
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		updated := false
+		for _, containers := range [][]corev1.Container{deployment.Spec.Template.Spec.InitContainers, deployment.Spec.Template.Spec.Containers} {
+			for i := range containers {
+				if containers[i].Image == image {
+					continue
+				}
+				if getImageRepository(containers[i].Image) != repository {
+					return fmt.Errorf("the image %s is not in the repository of the image %s of the deployment %s/%s", image, containers[i].Image, deployment.Namespace, deployment.Name)
+				}
+				containers[i].Image = image
+				updated = true
+			}
+		}
+		if !updated {
+			continue
+		}
+		s.Log.Infof("Updating the image of the deployment %s/%s to %s", deployment.Namespace, deployment.Name, image)
+		if err := s.LocalClient.Update(s.Context, &deployment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateOperatorImageUpgrade validates that the platform operator image can be changed for the upgrade from the
+// installed version to the target version.  Only the image of the platform operator deployments is changed, not its
+// CRDs, RBAC rules and webhooks, so the image can only be changed for an upgrade to a version of the same minor
+// version.  The upgrades to other minor versions require the installation of the platform operator of the target
+// version.
+func validateOperatorImageUpgrade(version string, targetVersion *semver.SemVersion) error {
+	v, err := semver.NewSemVersion(version)
+	if err != nil {
+		return fmt.Errorf("the platform operator image cannot be changed, the installed version %q of Verrazzano is not known: %v", version, err)
+	}
+	if v.Major != targetVersion.Major || v.Minor != targetVersion.Minor {
+		return fmt.Errorf("the platform operator image can only be changed for an upgrade within a minor version, the upgrade from version %s to version %s requires the installation of the platform operator of version %s", version, targetVersion.ToString(), targetVersion.ToString())
+	}
+	return nil
+}
+
+// getImageRepository returns the repository of an image, without its tag and digest
+func getImageRepository(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image
+}
+
+// isVersionAtLeast returns true if the given version is set, and at or above the target version
+func isVersionAtLeast(version string, targetVersion *semver.SemVersion) bool {
+	if len(version) == 0 {
+		return false
+	}
+	v, err := semver.NewSemVersion(version)
+	if err != nil {
+		return false
+	}
+	return v.IsGreaterThanOrEqualTo(targetVersion)
+}
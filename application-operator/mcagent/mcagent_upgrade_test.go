@@ -0,0 +1,170 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package mcagent
+
+import (
+	"context"
+	"testing"
+
+	asserts "github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/mcconstants"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testOperatorImage    = "ghcr.io/verrazzano/verrazzano-platform-operator:v1.6.0"
+	testNewOperatorImage = "ghcr.io/verrazzano/verrazzano-platform-operator:v1.6.1"
+)
+
+// TestSyncFleetUpgrade tests upgrading Verrazzano to the version pushed by a fleet upgrade
+func TestSyncFleetUpgrade(t *testing.T) {
+	tests := []struct {
+		name             string
+		secretData       map[string][]byte
+		specVersion      string
+		statusVersion    string
+		expectedVersion  string
+		expectedOperator string
+		expectedErr      bool
+	}{
+		{
+			name:          "no fleet upgrade secret",
+			statusVersion: "1.5.0",
+		},
+		{
+			name:            "upgrade without operator image",
+			secretData:      map[string][]byte{mcconstants.FleetUpgradeVersionKey: []byte("1.6.0")},
+			statusVersion:   "1.5.0",
+			expectedVersion: "1.6.0",
+		},
+		{
+			name: "upgrade with operator image",
+			secretData: map[string][]byte{
+				mcconstants.FleetUpgradeVersionKey:       []byte("1.6.1"),
+				mcconstants.FleetUpgradeOperatorImageKey: []byte(testNewOperatorImage),
+			},
+			specVersion:      "1.6.0",
+			statusVersion:    "1.6.0",
+			expectedVersion:  "1.6.1",
+			expectedOperator: testNewOperatorImage,
+		},
+		{
+			name: "operator image of another repository",
+			secretData: map[string][]byte{
+				mcconstants.FleetUpgradeVersionKey:       []byte("1.6.1"),
+				mcconstants.FleetUpgradeOperatorImageKey: []byte("example.com/other/operator:v1.6.1"),
+			},
+			statusVersion: "1.6.0",
+			expectedErr:   true,
+		},
+		{
+			name: "operator image for an upgrade to another minor version",
+			secretData: map[string][]byte{
+				mcconstants.FleetUpgradeVersionKey:       []byte("1.7.0"),
+				mcconstants.FleetUpgradeOperatorImageKey: []byte("ghcr.io/verrazzano/verrazzano-platform-operator:v1.7.0"),
+			},
+			statusVersion: "1.6.0",
+			expectedErr:   true,
+		},
+		{
+			name: "operator image with an unknown installed version",
+			secretData: map[string][]byte{
+				mcconstants.FleetUpgradeVersionKey:       []byte("1.6.1"),
+				mcconstants.FleetUpgradeOperatorImageKey: []byte(testNewOperatorImage),
+			},
+			expectedErr: true,
+		},
+		{
+			name: "already at the target version",
+			secretData: map[string][]byte{
+				mcconstants.FleetUpgradeVersionKey:       []byte("1.6.0"),
+				mcconstants.FleetUpgradeOperatorImageKey: []byte(testNewOperatorImage),
+			},
+			statusVersion: "1.6.0",
+		},
+		{
+			name:          "already above the target version",
+			secretData:    map[string][]byte{mcconstants.FleetUpgradeVersionKey: []byte("1.6.0")},
+			statusVersion: "1.6.1",
+		},
+		{
+			name:          "upgrade above the target version already requested",
+			secretData:    map[string][]byte{mcconstants.FleetUpgradeVersionKey: []byte("1.6.0")},
+			specVersion:   "v1.7.0",
+			statusVersion: "1.5.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := asserts.New(t)
+			vz := &v1beta1.Verrazzano{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano"},
+				Spec:       v1beta1.VerrazzanoSpec{Version: tt.specVersion},
+				Status:     v1beta1.VerrazzanoStatus{Version: tt.statusVersion},
+			}
+			operator := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: vzconst.VerrazzanoInstallNamespace, Name: platformOperatorDeployment},
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							InitContainers: []corev1.Container{{Name: "webhookswait", Image: testOperatorImage}},
+							Containers:     []corev1.Container{{Name: platformOperatorDeployment, Image: testOperatorImage}},
+						},
+					},
+				},
+			}
+			objs := []client.Object{vz, operator}
+			if tt.secretData != nil {
+				objs = append(objs, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: constants.VerrazzanoSystemNamespace, Name: constants.MCFleetUpgradeSecret},
+					Data:       tt.secretData,
+				})
+			}
+			scheme := newTestScheme()
+			_ = v1beta1.AddToScheme(scheme)
+			_ = appsv1.AddToScheme(scheme)
+			cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+			s := &Syncer{LocalClient: cli, Log: zap.S(), Context: context.TODO()}
+
+			err := s.syncFleetUpgrade()
+			if tt.expectedErr {
+				assert.Error(err)
+			} else {
+				assert.NoError(err)
+			}
+
+			updated := v1beta1.Verrazzano{}
+			assert.NoError(cli.Get(context.TODO(), client.ObjectKeyFromObject(vz), &updated))
+			expectedVersion := tt.specVersion
+			if len(tt.expectedVersion) > 0 {
+				expectedVersion = tt.expectedVersion
+			}
+			assert.Equal(expectedVersion, updated.Spec.Version)
+
+			assert.NoError(cli.Get(context.TODO(), client.ObjectKeyFromObject(operator), operator))
+			expectedOperator := testOperatorImage
+			if len(tt.expectedOperator) > 0 {
+				expectedOperator = tt.expectedOperator
+			}
+			assert.Equal(expectedOperator, operator.Spec.Template.Spec.InitContainers[0].Image)
+			assert.Equal(expectedOperator, operator.Spec.Template.Spec.Containers[0].Image)
+		})
+	}
+}
+
+// TestGetImageRepository tests getting the repository of an image
+func TestGetImageRepository(t *testing.T) {
+	asserts.Equal(t, "ghcr.io/verrazzano/operator", getImageRepository("ghcr.io/verrazzano/operator:v1.6.0"))
+	asserts.Equal(t, "ghcr.io/verrazzano/operator", getImageRepository("ghcr.io/verrazzano/operator@sha256:abc"))
+	asserts.Equal(t, "localhost:5000/operator", getImageRepository("localhost:5000/operator"))
+	asserts.Equal(t, "localhost:5000/operator", getImageRepository("localhost:5000/operator:v1.6.0"))
+}
@@ -0,0 +1,451 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package mcagent
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/clusters"
+	"github.com/verrazzano/verrazzano/application-operator/metricsexporter"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// The resources of a kind in a namespace are listed again by the agent when they have not been synced
+	// by their watch within this period, in case an event was missed
+	watchResyncPeriod = 10 * time.Minute
+
+	// A failed watch is restarted with an exponential backoff between these durations
+	minWatchBackoff = time.Second
+	maxWatchBackoff = 5 * time.Minute
+)
+
+// watchKey identifies the watch of a kind of multi-cluster resource in a namespace of the admin cluster
+type watchKey struct {
+	kind      string
+	namespace string
+}
+
+// watchedKind describes a kind of multi-cluster resource watched on the admin cluster
+type watchedKind struct {
+	newList func() client.ObjectList
+	// sync syncs the resources of the kind in a namespace of the admin cluster to this cluster
+	sync func(s *Syncer, namespace string) error
+	// syncDrift detects the drift of the resources created in this cluster from the resources of the kind in a
+	// namespace.  The watches of the admin cluster are not notified of the changes made in this cluster, so the drift
+	// is detected by every periodic sync of the agent, including for the namespaces synced by their watch.
+	syncDrift func(s *Syncer, namespace string) error
+}
+
+// watchedKinds are the kinds of multi-cluster resources watched on the admin cluster, other than the
+// VerrazzanoProjects, in each namespace of the projects placed in this cluster
+var watchedKinds = map[string]watchedKind{
+	clustersv1alpha1.MultiClusterSecretKind: {
+		newList: func() client.ObjectList { return &clustersv1alpha1.MultiClusterSecretList{} },
+		sync:    (*Syncer).syncMCSecretObjects,
+		syncDrift: func(s *Syncer, namespace string) error {
+			_, err := s.createOrUpdateMCSecrets(namespace)
+			return err
+		},
+	},
+	clustersv1alpha1.MultiClusterConfigMapKind: {
+		newList: func() client.ObjectList { return &clustersv1alpha1.MultiClusterConfigMapList{} },
+		sync:    (*Syncer).syncMCConfigMapObjects,
+		syncDrift: func(s *Syncer, namespace string) error {
+			_, err := s.createOrUpdateMCConfigMaps(namespace)
+			return err
+		},
+	},
+	clustersv1alpha1.MultiClusterComponentKind: {
+		newList: func() client.ObjectList { return &clustersv1alpha1.MultiClusterComponentList{} },
+		sync:    (*Syncer).syncMCComponentObjects,
+	},
+	clustersv1alpha1.MultiClusterAppConfigKind: {
+		newList: func() client.ObjectList { return &clustersv1alpha1.MultiClusterApplicationConfigurationList{} },
+		sync: func(s *Syncer, namespace string) error {
+			// The secrets of the application configurations are synced first, so that they exist when the
+			// application configurations are created
+			if err := s.syncSecretObjects(namespace); err != nil {
+				s.Log.Errorf("Failed to sync Secret objects: %v", err)
+			}
+			return s.syncMCApplicationConfigurationObjects(namespace)
+		},
+	},
+}
+
+// projectKind describes the VerrazzanoProjects watched in the multi-cluster namespace of the admin cluster
+var projectKind = watchedKind{
+	newList: func() client.ObjectList { return &clustersv1alpha1.VerrazzanoProjectList{} },
+	sync: func(s *Syncer, _ string) error {
+		return s.syncVerrazzanoProjects()
+	},
+}
+
+// watchState is the state of the watch of a kind of multi-cluster resource in a namespace
+type watchState struct {
+	cancel context.CancelFunc
+	// resourceVersion is the resource version the watch resumes from when it is restarted
+	resourceVersion string
+	connected       bool
+	lastSync        time.Time
+}
+
+// adminWatcher watches the multi-cluster resources of the admin cluster, in the namespaces the managed cluster
+// is allowed to access, and syncs them to this cluster as soon as they change.  The resources that cannot be
+// watched are synced by the periodic sync of the agent, which is the polling fallback.  The adminWatcher also
+// sends the status updates of the multi-cluster resources to the admin cluster as soon as they are received.
+type adminWatcher struct {
+	log           *zap.SugaredLogger
+	statusChannel chan clusters.StatusUpdateMessage
+
+	// mutex protects the fields below
+	mutex sync.Mutex
+	// syncer is connected to the admin cluster, it is nil while the agent is not connected
+	syncer       *Syncer
+	watches      map[watchKey]*watchState
+	statusCancel context.CancelFunc
+
+	// syncMutex serializes the syncs of the multi-cluster resources
+	syncMutex sync.Mutex
+}
+
+// newAdminWatcher returns an adminWatcher that is not connected to the admin cluster
+func newAdminWatcher(log *zap.SugaredLogger, statusChannel chan clusters.StatusUpdateMessage) *adminWatcher {
+	return &adminWatcher{
+		log:           log,
+		statusChannel: statusChannel,
+		watches:       map[watchKey]*watchState{},
+	}
+}
+
+// connect connects the watcher to the admin cluster of the given syncer, and watches the VerrazzanoProjects and the
+// multi-cluster resources in the namespaces of the projects of the syncer.  The watches of namespaces that are no
+// longer part of a project are stopped.  If the admin cluster client does not support watches, all the resources
+// are synced by polling.
+func (w *adminWatcher) connect(s *Syncer) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.syncer = &Syncer{
+		AdminClient:         s.AdminClient,
+		LocalClient:         s.LocalClient,
+		Log:                 s.Log,
+		ManagedClusterName:  s.ManagedClusterName,
+		StatusUpdateChannel: s.StatusUpdateChannel,
+	}
+	if w.statusCancel == nil && w.statusChannel != nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		w.statusCancel = cancel
+		go w.processStatusUpdates(ctx)
+	}
+
+	keys := map[watchKey]bool{}
+	if _, ok := s.AdminClient.(client.WithWatch); ok {
+		keys[watchKey{kind: clustersv1alpha1.VerrazzanoProjectKind, namespace: constants.VerrazzanoMultiClusterNamespace}] = true
+		for _, namespace := range s.ProjectNamespaces {
+			for kind := range watchedKinds {
+				keys[watchKey{kind: kind, namespace: namespace}] = true
+			}
+		}
+	} else {
+		w.log.Debug("The admin cluster client does not support watches, polling the multi-cluster resources")
+	}
+
+	for key, state := range w.watches {
+		if !keys[key] {
+			state.cancel()
+			delete(w.watches, key)
+		}
+	}
+	for key := range keys {
+		if _, ok := w.watches[key]; !ok {
+			ctx, cancel := context.WithCancel(context.Background())
+			w.watches[key] = &watchState{cancel: cancel}
+			go w.run(ctx, key)
+		}
+	}
+}
+
+// disconnect stops all the watches and the status updates, when the agent is no longer connected to the admin cluster
+func (w *adminWatcher) disconnect() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.syncer = nil
+	for key, state := range w.watches {
+		state.cancel()
+		delete(w.watches, key)
+	}
+	if w.statusCancel != nil {
+		w.statusCancel()
+		w.statusCancel = nil
+	}
+}
+
+// isSynced returns true if the resources of the given kind in the given namespace are watched, and have been synced
+// within the resync period, in which case the periodic sync of the agent does not need to list them
+func (w *adminWatcher) isSynced(kind string, namespace string) bool {
+	if w == nil {
+		return false
+	}
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	state, ok := w.watches[watchKey{kind: kind, namespace: namespace}]
+	return ok && state.connected && time.Since(state.lastSync) < watchResyncPeriod
+}
+
+// markSynced records that the resources of the given kind in the given namespace have been synced
+func (w *adminWatcher) markSynced(kind string, namespace string) {
+	if w == nil {
+		return
+	}
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if state, ok := w.watches[watchKey{kind: kind, namespace: namespace}]; ok {
+		state.lastSync = time.Now()
+	}
+}
+
+// run watches the given kind of multi-cluster resource in the given namespace until the context is cancelled.
+// The watch is restarted from the last resource version it received, with an exponential backoff when it fails.
+func (w *adminWatcher) run(ctx context.Context, key watchKey) {
+	backoff := minWatchBackoff
+	for ctx.Err() == nil {
+		err := w.watch(ctx, key)
+		w.setConnected(key, false)
+		if err == nil {
+			// The watch was closed by the API server, it is resumed after the minimum backoff
+			backoff = minWatchBackoff
+		} else {
+			w.log.Infof("Watch of %s objects in namespace %s on the admin cluster failed, retrying in %v: %v", key.kind, key.namespace, backoff, err)
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+		if err != nil {
+			backoff *= 2
+			if backoff > maxWatchBackoff {
+				backoff = maxWatchBackoff
+			}
+		}
+	}
+}
+
+// watch watches the given kind of multi-cluster resource in the given namespace, and syncs the resources of the
+// kind in the namespace each time they change.  The watch starts from the last resource version received, if any,
+// or else from the current resource version after syncing the resources.  This function returns nil when the
+// watch is closed by the API server or the context is cancelled.
+func (w *adminWatcher) watch(ctx context.Context, key watchKey) error {
+	s := w.getSyncer(ctx)
+	if s == nil {
+		return fmt.Errorf("not connected to the admin cluster")
+	}
+	watchClient, ok := s.AdminClient.(client.WithWatch)
+	if !ok {
+		return fmt.Errorf("the admin cluster client does not support watches")
+	}
+	kind := getWatchedKind(key.kind)
+
+	resourceVersion := w.getResourceVersion(key)
+	if len(resourceVersion) == 0 {
+		// Start watching from the current resource version, after syncing the resources changed since the last sync
+		list := kind.newList()
+		if err := watchClient.List(ctx, list, client.InNamespace(key.namespace), client.Limit(1)); err != nil {
+			return err
+		}
+		resourceVersion = list.GetResourceVersion()
+		if err := w.sync(s, key, nil); err != nil {
+			return err
+		}
+	}
+
+	watcher, err := watchClient.Watch(ctx, kind.newList(), client.InNamespace(key.namespace),
+		&client.ListOptions{Raw: &metav1.ListOptions{ResourceVersion: resourceVersion, AllowWatchBookmarks: true}})
+	if err != nil {
+		return err
+	}
+	defer watcher.Stop()
+	w.setResourceVersion(key, resourceVersion)
+	w.setConnected(key, true)
+
+	// The events already received are all handled before the resources are synced once for all of them
+	var pending []watch.Event
+	for {
+		var event watch.Event
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok = <-watcher.ResultChan():
+		default:
+			if err := w.syncEvents(s, key, pending); err != nil {
+				return err
+			}
+			pending = nil
+			select {
+			case <-ctx.Done():
+				return nil
+			case event, ok = <-watcher.ResultChan():
+			}
+		}
+		if !ok {
+			// The watch was closed by the API server
+			return w.syncEvents(s, key, pending)
+		}
+		if event.Type == watch.Error {
+			err := apierrors.FromObject(event.Object)
+			if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+				// The resource version is too old, the resources are listed again when the watch is restarted
+				w.setResourceVersion(key, "")
+			}
+			return err
+		}
+		if _, ok := event.Object.(client.Object); ok {
+			pending = append(pending, event)
+		}
+	}
+}
+
+// syncEvents syncs the resources changed by the given watch events, and records the resource version of the last
+// event, from which the watch is resumed when it is restarted
+func (w *adminWatcher) syncEvents(s *Syncer, key watchKey, events []watch.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	var objs []client.Object
+	for _, event := range events {
+		if event.Type != watch.Bookmark {
+			objs = append(objs, event.Object.(client.Object))
+		}
+	}
+	if len(objs) > 0 {
+		if err := w.sync(s, key, objs); err != nil {
+			return err
+		}
+	}
+	w.setResourceVersion(key, events[len(events)-1].Object.(client.Object).GetResourceVersion())
+	return nil
+}
+
+// sync syncs the resources of the given kind in the given namespace, and records the lag between the changes of
+// the given objects on the admin cluster and their sync to this cluster
+func (w *adminWatcher) sync(s *Syncer, key watchKey, objs []client.Object) error {
+	w.syncMutex.Lock()
+	defer w.syncMutex.Unlock()
+
+	// Use a new syncer for each sync, so that the managed clusters are listed again
+	syncer := *s
+	syncer.managedClusters = nil
+	syncer.managedClustersListed = false
+	if err := getWatchedKind(key.kind).sync(&syncer, key.namespace); err != nil {
+		return err
+	}
+	w.markSynced(key.kind, key.namespace)
+
+	if lagMetric, err := metricsexporter.GetLabeledDurationMetric(metricsexporter.MultiClusterSyncLag); err == nil {
+		for _, obj := range objs {
+			lagMetric.Observe(key.kind, time.Since(getChangeTime(obj)))
+		}
+	}
+	return nil
+}
+
+// processStatusUpdates sends the status updates received on the status channel to the admin cluster, until the
+// context is cancelled
+func (w *adminWatcher) processStatusUpdates(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-w.statusChannel:
+			s := w.getSyncer(ctx)
+			if s == nil {
+				// The agent is no longer connected to the admin cluster, the status update is discarded
+				continue
+			}
+			if err := s.performAdminStatusUpdate(msg); err != nil {
+				s.Log.Errorf("Failed to update status on admin cluster for %s/%s from cluster %s after %d retries: %v",
+					msg.Resource.GetNamespace(), msg.Resource.GetName(),
+					msg.NewClusterStatus.Name, retryCount, err)
+			}
+		}
+	}
+}
+
+// getSyncer returns a syncer connected to the admin cluster with the given context, or nil if the agent is not
+// connected to the admin cluster
+func (w *adminWatcher) getSyncer(ctx context.Context) *Syncer {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.syncer == nil {
+		return nil
+	}
+	s := *w.syncer
+	s.Context = ctx
+	return &s
+}
+
+func (w *adminWatcher) getResourceVersion(key watchKey) string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if state, ok := w.watches[key]; ok {
+		return state.resourceVersion
+	}
+	return ""
+}
+
+func (w *adminWatcher) setResourceVersion(key watchKey, resourceVersion string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if state, ok := w.watches[key]; ok {
+		state.resourceVersion = resourceVersion
+	}
+}
+
+func (w *adminWatcher) setConnected(key watchKey, connected bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if state, ok := w.watches[key]; ok {
+		state.connected = connected
+	}
+}
+
+// getWatchedKind returns the description of the given watched kind
+func getWatchedKind(kind string) watchedKind {
+	if kind == clustersv1alpha1.VerrazzanoProjectKind {
+		return projectKind
+	}
+	return watchedKinds[kind]
+}
+
+// getChangeTime returns the last time the given object was changed, from its managed fields, creation
+// and deletion timestamps
+func getChangeTime(obj client.Object) time.Time {
+	changeTime := obj.GetCreationTimestamp().Time
+	if deletionTime := obj.GetDeletionTimestamp(); deletionTime != nil && deletionTime.After(changeTime) {
+		changeTime = deletionTime.Time
+	}
+	for _, field := range obj.GetManagedFields() {
+		if field.Time != nil && field.Time.After(changeTime) {
+			changeTime = field.Time.Time
+		}
+	}
+	return changeTime
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package mcagent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	asserts "github.com/stretchr/testify/assert"
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/clusters"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testWatchTimeout = 5 * time.Second
+const testWatchInterval = 10 * time.Millisecond
+
+// TestAdminWatcherSync tests the sync of the multi-cluster resources watched on the admin cluster
+// GIVEN a watcher connected to an admin cluster
+// WHEN a MultiClusterSecret placed in this cluster is created, then deleted, on the admin cluster
+// THEN the MultiClusterSecret is created, then deleted, on this cluster without waiting for the periodic sync,
+// and the periodic sync does not poll the watched MultiClusterSecrets
+func TestAdminWatcherSync(t *testing.T) {
+	assert := asserts.New(t)
+	adminClient := fake.NewClientBuilder().WithScheme(newTestScheme()).Build()
+	localClient := fake.NewClientBuilder().WithScheme(newTestScheme()).Build()
+	w := newAdminWatcher(zap.S(), nil)
+	defer w.disconnect()
+
+	w.connect(&Syncer{
+		AdminClient:        adminClient,
+		LocalClient:        localClient,
+		Log:                zap.S(),
+		ManagedClusterName: testClusterName,
+		ProjectNamespaces:  []string{testMCSecretNamespace},
+	})
+	assert.Eventually(func() bool {
+		return w.isSynced(clustersv1alpha1.MultiClusterSecretKind, testMCSecretNamespace)
+	}, testWatchTimeout, testWatchInterval)
+	assert.False(w.isSynced(clustersv1alpha1.MultiClusterSecretKind, "other-namespace"))
+
+	mcSecret := &clustersv1alpha1.MultiClusterSecret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testMCSecretNamespace, Name: testMCSecretName},
+		Spec: clustersv1alpha1.MultiClusterSecretSpec{
+			Placement: clustersv1alpha1.Placement{Clusters: []clustersv1alpha1.Cluster{{Name: testClusterName}}},
+		},
+	}
+	assert.NoError(adminClient.Create(context.TODO(), mcSecret))
+	assert.Eventually(func() bool {
+		return localClient.Get(context.TODO(), client.ObjectKeyFromObject(mcSecret), &clustersv1alpha1.MultiClusterSecret{}) == nil
+	}, testWatchTimeout, testWatchInterval)
+
+	assert.NoError(adminClient.Delete(context.TODO(), mcSecret))
+	assert.Eventually(func() bool {
+		err := localClient.Get(context.TODO(), client.ObjectKeyFromObject(mcSecret), &clustersv1alpha1.MultiClusterSecret{})
+		return errors.IsNotFound(err)
+	}, testWatchTimeout, testWatchInterval)
+
+	// The watches of the namespaces that are no longer part of a project are stopped
+	w.connect(&Syncer{AdminClient: adminClient, LocalClient: localClient, Log: zap.S(), ManagedClusterName: testClusterName})
+	assert.False(w.isSynced(clustersv1alpha1.MultiClusterSecretKind, testMCSecretNamespace))
+}
+
+// TestAdminWatcherDriftSync tests the drift detection of the multi-cluster resources watched on the admin cluster
+// GIVEN a MultiClusterSecret synced by the watch of the admin cluster
+// WHEN the Secret created from the MultiClusterSecret is changed in this cluster
+// THEN the drift is detected and overwritten by the periodic sync, even though the MultiClusterSecret is watched
+func TestAdminWatcherDriftSync(t *testing.T) {
+	assert := asserts.New(t)
+	scheme := newTestScheme()
+	_ = apiextensionsv1.AddToScheme(scheme)
+	adminClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	localClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: mcAppConfCRDName}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testMCSecretNamespace,
+			Labels: map[string]string{vzconst.VerrazzanoManagedLabelKey: constants.LabelVerrazzanoManagedDefault}}},
+	).Build()
+	w := newAdminWatcher(zap.S(), nil)
+	defer w.disconnect()
+
+	s := &Syncer{
+		AdminClient:        adminClient,
+		LocalClient:        localClient,
+		Log:                zap.S(),
+		ManagedClusterName: testClusterName,
+		Context:            context.TODO(),
+		ProjectNamespaces:  []string{testMCSecretNamespace},
+		watcher:            w,
+	}
+	w.connect(s)
+	mcSecret := &clustersv1alpha1.MultiClusterSecret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testMCSecretNamespace, Name: testMCSecretName},
+		Spec: clustersv1alpha1.MultiClusterSecretSpec{
+			Template:  clustersv1alpha1.SecretTemplate{StringData: map[string]string{"password": "secret"}},
+			Placement: clustersv1alpha1.Placement{Clusters: []clustersv1alpha1.Cluster{{Name: testClusterName}}},
+		},
+		Status: clustersv1alpha1.MultiClusterResourceStatus{
+			Clusters: []clustersv1alpha1.ClusterLevelStatus{{Name: testClusterName, State: clustersv1alpha1.Succeeded}},
+		},
+	}
+	assert.NoError(adminClient.Create(context.TODO(), mcSecret))
+	localMCSecret := &clustersv1alpha1.MultiClusterSecret{}
+	assert.Eventually(func() bool {
+		return localClient.Get(context.TODO(), client.ObjectKeyFromObject(mcSecret), localMCSecret) == nil &&
+			w.isSynced(clustersv1alpha1.MultiClusterSecretKind, testMCSecretNamespace)
+	}, testWatchTimeout, testWatchInterval)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testMCSecretNamespace, Name: testMCSecretName},
+		Data:       map[string][]byte{"password": []byte("changed")},
+	}
+	clusters.SetMultiClusterGeneration(secret, localMCSecret.Generation)
+	assert.NoError(localClient.Create(context.TODO(), secret))
+
+	s.SyncMultiClusterResources()
+	assert.NoError(localClient.Get(context.TODO(), client.ObjectKeyFromObject(secret), secret))
+	assert.Equal([]byte("secret"), secret.Data["password"])
+	assert.NoError(adminClient.Get(context.TODO(), client.ObjectKeyFromObject(mcSecret), mcSecret))
+	assert.Len(mcSecret.Status.Clusters, 1)
+	assert.NotNil(mcSecret.Status.Clusters[0].Drift)
+	assert.Equal(clustersv1alpha1.DriftOverwritten, mcSecret.Status.Clusters[0].Drift.Action)
+}
+
+// TestAdminWatcherPollingFallback tests the polling fallback of the watcher
+// GIVEN an admin cluster client that does not support watches
+// WHEN the watcher is connected to the admin cluster
+// THEN no resource is watched, so that all the resources are polled by the periodic sync
+func TestAdminWatcherPollingFallback(t *testing.T) {
+	noWatchClient := struct{ client.Client }{fake.NewClientBuilder().WithScheme(newTestScheme()).Build()}
+	w := newAdminWatcher(zap.S(), nil)
+	defer w.disconnect()
+
+	w.connect(&Syncer{
+		AdminClient:       noWatchClient,
+		LocalClient:       fake.NewClientBuilder().WithScheme(newTestScheme()).Build(),
+		Log:               zap.S(),
+		ProjectNamespaces: []string{testMCSecretNamespace},
+	})
+	asserts.Empty(t, w.watches)
+	asserts.False(t, w.isSynced(clustersv1alpha1.MultiClusterSecretKind, testMCSecretNamespace))
+}
+
+// TestAdminWatcherStatusUpdates tests that the status updates are sent to the admin cluster as soon as they are received
+// GIVEN a watcher connected to an admin cluster
+// WHEN a status update of a MultiClusterSecret is received
+// THEN the status of the MultiClusterSecret is updated on the admin cluster, until the watcher is disconnected
+func TestAdminWatcherStatusUpdates(t *testing.T) {
+	assert := asserts.New(t)
+	mcSecret := &clustersv1alpha1.MultiClusterSecret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testMCSecretNamespace, Name: testMCSecretName},
+	}
+	adminClient := struct{ client.Client }{fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(mcSecret).Build()}
+	statusChannel := make(chan clusters.StatusUpdateMessage, 1)
+	w := newAdminWatcher(zap.S(), statusChannel)
+	w.connect(&Syncer{AdminClient: adminClient, Log: zap.S(), ManagedClusterName: testClusterName, Context: context.TODO()})
+
+	statusChannel <- clusters.StatusUpdateMessage{
+		NewCondition:     clustersv1alpha1.Condition{Type: clustersv1alpha1.DeployComplete, Status: corev1.ConditionTrue},
+		NewClusterStatus: clustersv1alpha1.ClusterLevelStatus{Name: testClusterName, State: clustersv1alpha1.Succeeded},
+		Resource:         mcSecret,
+	}
+	assert.Eventually(func() bool {
+		updated := clustersv1alpha1.MultiClusterSecret{}
+		assert.NoError(adminClient.Get(context.TODO(), client.ObjectKeyFromObject(mcSecret), &updated))
+		return len(updated.Status.Clusters) == 1 && updated.Status.Clusters[0].State == clustersv1alpha1.Succeeded
+	}, testWatchTimeout, testWatchInterval)
+
+	w.disconnect()
+	assert.Nil(w.getSyncer(context.TODO()))
+}
+
+// TestGetChangeTime tests getting the last time an object was changed
+// GIVEN an object created, then updated
+// WHEN getChangeTime is called
+// THEN the time of the update is returned
+func TestGetChangeTime(t *testing.T) {
+	created := time.Now().Add(-time.Hour).Truncate(time.Second)
+	updated := time.Now().Add(-time.Minute).Truncate(time.Second)
+	obj := &clustersv1alpha1.MultiClusterSecret{}
+	obj.CreationTimestamp = metav1.NewTime(created)
+	asserts.Equal(t, created, getChangeTime(obj))
+
+	obj.ManagedFields = []metav1.ManagedFieldsEntry{{Time: &metav1.Time{Time: updated}}, {}}
+	asserts.Equal(t, updated, getChangeTime(obj))
+}
@@ -4,6 +4,8 @@
 package metricsexporter
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
@@ -28,8 +30,9 @@ type configuration struct {
 	registry      prometheus.Registerer
 }
 type data struct {
-	simpleCounterMetricMap map[metricName]*SimpleCounterMetric
-	durationMetricMap      map[metricName]*DurationMetrics
+	simpleCounterMetricMap   map[metricName]*SimpleCounterMetric
+	durationMetricMap        map[metricName]*DurationMetrics
+	labeledDurationMetricMap map[metricName]*LabeledDurationMetrics
 }
 type metricsDelegate struct {
 }
@@ -66,3 +69,13 @@ func (d *DurationMetrics) TimerStop() {
 	d.timer.ObserveDuration()
 
 }
+
+// Duration Metrics partitioned by a label
+type LabeledDurationMetrics struct {
+	metric *prometheus.SummaryVec
+}
+
+// Records the given Duration for the given label value
+func (d *LabeledDurationMetrics) Observe(label string, duration time.Duration) {
+	d.metric.WithLabelValues(label).Observe(duration.Seconds())
+}
@@ -59,6 +59,7 @@ const (
 	VzProjHandleCounter                    metricName = "VzProj handle counter"
 	VzProjHandleError                      metricName = "VzProj handle error"
 	VzProjHandleDuration                   metricName = "VzProj handle duration"
+	MultiClusterSyncLag                    metricName = "MultiCluster sync lag"
 )
 
 func init() {
@@ -71,8 +72,9 @@ func RequiredInitialization() {
 	MetricsExp = metricsExporter{
 		internalConfig: initConfiguration(),
 		internalData: data{
-			simpleCounterMetricMap: initCounterMetricMap(),
-			durationMetricMap:      initDurationMetricMap(),
+			simpleCounterMetricMap:   initCounterMetricMap(),
+			durationMetricMap:        initDurationMetricMap(),
+			labeledDurationMetricMap: initLabeledDurationMetricMap(),
 		},
 	}
 }
@@ -92,6 +94,9 @@ func InitializeAllMetricsArray() {
 	for _, value := range MetricsExp.internalData.durationMetricMap {
 		MetricsExp.internalConfig.allMetrics = append(MetricsExp.internalConfig.allMetrics, value.metric)
 	}
+	for _, value := range MetricsExp.internalData.labeledDurationMetricMap {
+		MetricsExp.internalConfig.allMetrics = append(MetricsExp.internalConfig.allMetrics, value.metric)
+	}
 
 }
 
@@ -315,6 +320,18 @@ func initDurationMetricMap() map[metricName]*DurationMetrics {
 	}
 }
 
+// initLabeledDurationMetricMap initializes the labeledDurationMetricMap for the metricsExporter object
+func initLabeledDurationMetricMap() map[metricName]*LabeledDurationMetrics {
+	return map[metricName]*LabeledDurationMetrics{
+		MultiClusterSyncLag: {
+			metric: prometheus.NewSummaryVec(prometheus.SummaryOpts{
+				Name: "vz_application_operator_multicluster_sync_lag",
+				Help: "The duration in seconds between the change of a multicluster resource on the admin cluster and its sync to the managed cluster",
+			}, []string{"kind"}),
+		},
+	}
+}
+
 // registerMetricsHandlersHelper is a helper function that assists in registering metrics
 func registerMetricsHandlersHelper() error {
 	var errorObserved error
@@ -404,6 +421,16 @@ func GetDurationMetric(name metricName) (*DurationMetrics, error) {
 	}
 	return durationMetric, nil
 }
+
+// GetLabeledDurationMetric returns a labeledDurationMetric from the labeledDurationMetricMap given a metricName
+func GetLabeledDurationMetric(name metricName) (*LabeledDurationMetrics, error) {
+	labeledDurationMetric, ok := MetricsExp.internalData.labeledDurationMetricMap[name]
+	if !ok {
+		return nil, fmt.Errorf("%v not found in labeledDurationMetricMap due to metricName being defined, but not being a key in the map", name)
+	}
+	return labeledDurationMetric, nil
+}
+
 func ExposeControllerMetrics(controllerName string, successname metricName, errorname metricName, durationname metricName) (*SimpleCounterMetric, *SimpleCounterMetric, *DurationMetrics, *zap.SugaredLogger, error) {
 	zapLogForMetrics := zap.S().With(vzlogInit.FieldController, controllerName)
 	counterMetricObject, err := GetSimpleCounterMetric(successname)
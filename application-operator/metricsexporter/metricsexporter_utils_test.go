@@ -5,6 +5,7 @@ package metricsexporter
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -96,6 +97,43 @@ func TestGetDurationMetric(t *testing.T) {
 	}
 }
 
+// TestGetLabeledDurationMetric tests GetLabeledDurationMetric function
+// GIVEN a metricName
+// WHEN a call to GetLabeledDurationMetric is made
+// THEN return no error if the metricName is valid, else return an error
+func TestGetLabeledDurationMetric(t *testing.T) {
+	tests := []struct {
+		name       string
+		metricName metricName
+		wantErr    bool
+	}{
+		{
+			testNameValid,
+			"MultiCluster sync lag",
+			false,
+		},
+		{
+			testNameInvalid,
+			"MultiClusterSecret handle duration",
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			durationObject, err := GetLabeledDurationMetric(tt.metricName)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, durationObject)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, durationObject)
+				durationObject.Observe("MultiClusterSecret", time.Second)
+			}
+		})
+	}
+}
+
 // TestExposeControllerMetrics tests the ExposeControllerMetrics function
 // GIVEN a set of 3 metricNames
 // WHEN a call to ExposeControllerMetrics is made
@@ -0,0 +1,162 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FleetUpgradeAnnotation is the annotation set on a VerrazzanoManagedCluster by the fleet upgrade controller, with
+// the name of the VerrazzanoFleetUpgrade that is upgrading the managed cluster.
+const FleetUpgradeAnnotation = "clusters.verrazzano.io/fleet-upgrade"
+
+// FleetUpgradePushedAnnotation is the annotation set on a VerrazzanoManagedCluster by the VerrazzanoManagedCluster
+// controller, with the name of the VerrazzanoFleetUpgrade last pushed to the managed cluster. The fleet upgrade secret
+// is deleted from the managed cluster once the FleetUpgradeAnnotation is removed.
+const FleetUpgradePushedAnnotation = "clusters.verrazzano.io/fleet-upgrade-pushed"
+
+// The VerrazzanoFleetUpgrade custom resource rolls out a Verrazzano version to a set of managed clusters
+// registered with the admin cluster.
+
+// VerrazzanoFleetUpgradeSpec defines the desired state of a Verrazzano Fleet Upgrade.
+type VerrazzanoFleetUpgradeSpec struct {
+	// The Verrazzano version to upgrade the managed clusters to.
+	Version string `json:"version"`
+
+	// The image of the Verrazzano platform operator of the target version. The image is set on the platform
+	// operator deployments of each managed cluster before the upgrade of Verrazzano. It must be in the repository
+	// of the platform operator image of the managed clusters, only the tag or the digest can differ. Only the image
+	// is changed, not the CRDs, RBAC rules and webhooks of the platform operator, so the image can only be set for
+	// upgrades within a minor version, the upgrades of the managed clusters to another minor version are rejected.
+	// If omitted, the platform operator of the managed clusters must already support the target version.
+	// +optional
+	OperatorImage string `json:"operatorImage,omitempty"`
+
+	// The names of the VerrazzanoManagedClusters to upgrade.
+	// +optional
+	Clusters []string `json:"clusters,omitempty"`
+
+	// The label selector of the VerrazzanoManagedClusters to upgrade, in addition to the clusters listed by name.
+	// +optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+
+	// The number of clusters in each wave of the upgrade. The clusters are assigned to waves in the order of their
+	// names, and a wave starts once all clusters of the previous wave are upgraded. If omitted, all clusters are
+	// upgraded in a single wave.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	WaveSize int `json:"waveSize,omitempty"`
+
+	// The maximum number of clusters of a wave that are upgraded at the same time. The default is 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxUnavailable int `json:"maxUnavailable,omitempty"`
+
+	// If true, no upgrade of a cluster is started while the upgrade of another cluster has failed.
+	// +optional
+	PauseOnFailure bool `json:"pauseOnFailure,omitempty"`
+
+	// If true, no upgrade of a cluster is started. Upgrades already in progress are still tracked.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// The time allowed for the upgrade of a single cluster before it is considered to have failed. The default
+	// is one hour.
+	// +optional
+	ClusterTimeout *metav1.Duration `json:"clusterTimeout,omitempty"`
+}
+
+// FleetUpgradePhase identifies the phase of a Verrazzano Fleet Upgrade.
+type FleetUpgradePhase string
+
+const (
+	FleetUpgradePending    FleetUpgradePhase = "Pending"
+	FleetUpgradeInProgress FleetUpgradePhase = "InProgress"
+	FleetUpgradePaused     FleetUpgradePhase = "Paused"
+	FleetUpgradeCompleted  FleetUpgradePhase = "Completed"
+	FleetUpgradeFailed     FleetUpgradePhase = "Failed"
+)
+
+// ClusterUpgradeState identifies the state of the upgrade of a single managed cluster.
+type ClusterUpgradeState string
+
+const (
+	ClusterUpgradePending   ClusterUpgradeState = "Pending"
+	ClusterUpgradeUpgrading ClusterUpgradeState = "Upgrading"
+	ClusterUpgradeSucceeded ClusterUpgradeState = "Succeeded"
+	ClusterUpgradeFailed    ClusterUpgradeState = "Failed"
+)
+
+// ClusterUpgradeStatus describes the progress of the upgrade of a single managed cluster.
+type ClusterUpgradeStatus struct {
+	// The name of the VerrazzanoManagedCluster.
+	Name string `json:"name"`
+	// The wave the cluster is upgraded in, starting at zero.
+	Wave int `json:"wave"`
+	// The state of the upgrade of the cluster.
+	State ClusterUpgradeState `json:"state"`
+	// The Verrazzano version last reported by the managed cluster.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// A message with details about the state of the upgrade of the cluster.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// The time the upgrade of the cluster started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// The time the upgrade of the cluster completed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// VerrazzanoFleetUpgradeStatus defines the observed state of a Verrazzano Fleet Upgrade.
+type VerrazzanoFleetUpgradeStatus struct {
+	// The phase of the fleet upgrade.
+	// +optional
+	Phase FleetUpgradePhase `json:"phase,omitempty"`
+	// A message with details about the phase of the fleet upgrade.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// The wave being upgraded, starting at zero.
+	// +optional
+	CurrentWave int `json:"currentWave,omitempty"`
+	// The progress of the upgrade of each targeted managed cluster.
+	// +optional
+	Clusters []ClusterUpgradeStatus `json:"clusters,omitempty"`
+	// The generation of the VerrazzanoFleetUpgrade last processed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=vfu;vfus
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.version"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Wave",type="integer",JSONPath=".status.currentWave"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VerrazzanoFleetUpgrade specifies the Verrazzano Fleet Upgrade API.
+type VerrazzanoFleetUpgrade struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// The desired state of a Verrazzano Fleet Upgrade resource.
+	Spec VerrazzanoFleetUpgradeSpec `json:"spec,omitempty"`
+	// The observed state of a Verrazzano Fleet Upgrade resource.
+	Status VerrazzanoFleetUpgradeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VerrazzanoFleetUpgradeList contains a list of Verrazzano Fleet Upgrade resources.
+type VerrazzanoFleetUpgradeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VerrazzanoFleetUpgrade `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VerrazzanoFleetUpgrade{}, &VerrazzanoFleetUpgradeList{})
+}
@@ -33,6 +33,30 @@ type VerrazzanoManagedClusterSpec struct {
 	// Verrazzano Kubernetes operator.
 	// +optional
 	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// The policy for detecting that the managed cluster is detached from the admin cluster, and for cleaning up the
+	// state of the managed cluster on the admin cluster after it is detached.
+	// +optional
+	Detachment *DetachmentPolicy `json:"detachment,omitempty"`
+}
+
+// DetachmentPolicy defines when a managed cluster whose agent no longer connects to the admin cluster is considered
+// detached, and whether the state of the managed cluster on the admin cluster is then cleaned up.
+type DetachmentPolicy struct {
+	// The time since the agent of the managed cluster last connected to the admin cluster after which the
+	// managed cluster is considered inactive. The default is three minutes.
+	// +optional
+	InactiveAfter *metav1.Duration `json:"inactiveAfter,omitempty"`
+	// If true, the Thanos Query endpoint, Prometheus scrape configuration, CA certificate, Keycloak client,
+	// Argo CD registration and Rancher registration of the managed cluster are removed from the admin cluster once
+	// the managed cluster has been inactive for the cleanup grace period. They are created again when the agent of
+	// the managed cluster reconnects.
+	// +optional
+	CleanupEnabled bool `json:"cleanupEnabled,omitempty"`
+	// The time a managed cluster must be inactive before its state on the admin cluster is cleaned up.
+	// The default is 24 hours.
+	// +optional
+	CleanupGracePeriod *metav1.Duration `json:"cleanupGracePeriod,omitempty"`
 }
 
 // ConditionType identifies the condition of the Verrazzano Managed Cluster which can be checked with `kubectl wait`.
@@ -49,6 +73,18 @@ const (
 	// ConditionManifestPushed = true means the the agent and registration secrets have been successfully transferred
 	// to the managed cluster on a multicluster install
 	ConditionManifestPushed ConditionType = "ManifestPushed"
+
+	// ConditionHealthy = true means that all the Verrazzano components of the managed cluster are available and
+	// all of its nodes are ready, as last reported by the agent of the managed cluster
+	ConditionHealthy ConditionType = "Healthy"
+
+	// ConditionAgentConnected = true means that the agent of the managed cluster has connected to the admin cluster
+	// within the inactivity threshold of the detachment policy
+	ConditionAgentConnected ConditionType = "AgentConnected"
+
+	// ConditionStaleStateCleanedUp = true means that the managed cluster was detached and that its state on the admin
+	// cluster has been cleaned up, as specified by the detachment policy
+	ConditionStaleStateCleanedUp ConditionType = "StaleStateCleanedUp"
 )
 
 // StateType identifies the state of the Verrazzano Managed Cluster.
@@ -83,6 +119,7 @@ const (
 	RegistrationCompleted RancherRegistrationStatus = "Completed"
 	RegistrationFailed    RancherRegistrationStatus = "Failed"
 	DeleteFailed          RancherRegistrationStatus = "DeleteFailed"
+	RegistrationDeleted   RancherRegistrationStatus = "Deleted"
 )
 
 // RancherRegistration defines the Rancher registration state for a managed cluster.
@@ -117,6 +154,53 @@ type ArgoCDRegistration struct {
 	Message string `json:"message,omitempty"`
 }
 
+// ComponentInventory describes the state of a Verrazzano component installed on the managed cluster.
+type ComponentInventory struct {
+	// The name of the component.
+	Name string `json:"name"`
+	// The state of the component.
+	// +optional
+	State string `json:"state,omitempty"`
+	// Whether the component is available for use.
+	// +optional
+	Available bool `json:"available,omitempty"`
+	// The version of the component.
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
+// ManagedClusterInventory describes the Verrazzano installation and the Kubernetes cluster of the managed cluster,
+// as periodically reported by the agent of the managed cluster.
+type ManagedClusterInventory struct {
+	// The version of Verrazzano installed on the managed cluster.
+	// +optional
+	VerrazzanoVersion string `json:"verrazzanoVersion,omitempty"`
+	// The installation profile of Verrazzano on the managed cluster.
+	// +optional
+	Profile string `json:"profile,omitempty"`
+	// The state of the Verrazzano installation on the managed cluster.
+	// +optional
+	VerrazzanoState string `json:"verrazzanoState,omitempty"`
+	// The summary of the component availability, in the form `<available>/<enabled>`.
+	// +optional
+	AvailableComponents string `json:"availableComponents,omitempty"`
+	// The state of the installed Verrazzano components.
+	// +optional
+	Components []ComponentInventory `json:"components,omitempty"`
+	// The version of Kubernetes running on the managed cluster.
+	// +optional
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// The number of nodes in the managed cluster.
+	// +optional
+	Nodes int `json:"nodes,omitempty"`
+	// The number of ready nodes in the managed cluster.
+	// +optional
+	ReadyNodes int `json:"readyNodes,omitempty"`
+	// The last time the agent reported the inventory.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
 // VerrazzanoManagedClusterStatus defines the observed state of a Verrazzano Managed Cluster.
 type VerrazzanoManagedClusterStatus struct {
 	// The Verrazzano API server URL for this managed cluster.
@@ -133,6 +217,9 @@ type VerrazzanoManagedClusterStatus struct {
 	RancherRegistration RancherRegistration `json:"rancherRegistration,omitempty"`
 	// The state of ArgoCD registration for this managed cluster.
 	ArgoCDRegistration ArgoCDRegistration `json:"argoCDRegistration,omitempty"`
+	// The Verrazzano version, component health and Kubernetes inventory reported by the agent of this managed cluster.
+	// +optional
+	Inventory *ManagedClusterInventory `json:"inventory,omitempty"`
 	// The state of this managed cluster.
 	State StateType `json:"state"`
 }
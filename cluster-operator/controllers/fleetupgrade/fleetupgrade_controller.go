@@ -0,0 +1,248 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package fleetupgrade
+
+import (
+	"context"
+	goerrors "errors"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	vzctrl "github.com/verrazzano/verrazzano/pkg/controller"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	"github.com/verrazzano/verrazzano/pkg/semver"
+	vzstring "github.com/verrazzano/verrazzano/pkg/string"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	finalizerName = "fleetupgrade.verrazzano.io"
+
+	// requeueInterval is the interval at which the progress of the upgrade of the managed clusters is checked
+	requeueInterval = 30 * time.Second
+)
+
+// VerrazzanoFleetUpgradeReconciler reconciles a VerrazzanoFleetUpgrade object.
+// The reconciler rolls out the target Verrazzano version to the selected managed clusters in waves, by annotating the
+// VerrazzanoManagedCluster of each cluster to upgrade. The VerrazzanoManagedCluster reconciler then pushes the
+// upgrade to the agent of the managed cluster, which reports the progress in the inventory of the VMC status.
+type VerrazzanoFleetUpgradeReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	log    vzlog.VerrazzanoLogger
+}
+
+var (
+	reconcileTimeMetric = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "vz_cluster_operator_reconcile_fleetupgrade_duration_seconds",
+		Help: "The duration of the reconcile process for fleet upgrade objects",
+	})
+	reconcileErrorCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vz_cluster_operator_reconcile_fleetupgrade_error_total",
+		Help: "The amount of errors encountered in the reconcile process",
+	})
+	reconcileSuccessCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vz_cluster_operator_reconcile_fleetupgrade_success_total",
+		Help: "The number of times the reconcile process succeeded",
+	})
+)
+
+// SetupWithManager creates a new controller and adds it to the manager
+func (r *VerrazzanoFleetUpgradeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&clustersv1alpha1.VerrazzanoFleetUpgrade{}).
+		Complete(r)
+}
+
+// Reconcile is the main controller reconcile function
+func (r *VerrazzanoFleetUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	// Time the reconcile process and set the metric with the elapsed time
+	startTime := time.Now()
+	defer func() {
+		reconcileTimeMetric.Set(time.Since(startTime).Seconds())
+	}()
+
+	if ctx == nil {
+		reconcileErrorCount.Inc()
+		return ctrl.Result{}, goerrors.New("context cannot be nil")
+	}
+	upgrade := &clustersv1alpha1.VerrazzanoFleetUpgrade{}
+	if err := r.Get(ctx, req.NamespacedName, upgrade); err != nil {
+		if errors.IsNotFound(err) {
+			reconcileSuccessCount.Inc()
+			return reconcile.Result{}, nil
+		}
+		reconcileErrorCount.Inc()
+		zap.S().Errorf("Failed to fetch VerrazzanoFleetUpgrade resource: %v", err)
+		return newRequeueWithDelay(), nil
+	}
+
+	// Get the resource logger needed to log message using 'progress' and 'once' methods
+	log, err := vzlog.EnsureResourceLogger(&vzlog.ResourceConfig{
+		Name:           upgrade.Name,
+		Namespace:      upgrade.Namespace,
+		ID:             string(upgrade.UID),
+		Generation:     upgrade.Generation,
+		ControllerName: "fleetupgrade",
+	})
+	if err != nil {
+		reconcileErrorCount.Inc()
+		zap.S().Errorf("Failed to create controller logger for VerrazzanoFleetUpgrade controller", err)
+	}
+
+	r.log = log
+	log.Oncef("Reconciling VerrazzanoFleetUpgrade resource %v", req.NamespacedName)
+	res, err := r.doReconcile(ctx, upgrade)
+	// Never return an error since it has already been logged and we don't want the
+	// controller runtime to log again (with stack trace).  Just re-queue if there is an error.
+	if err != nil {
+		log.ErrorfThrottled("Failed to reconcile VerrazzanoFleetUpgrade %v: %v", req.NamespacedName, err)
+		reconcileErrorCount.Inc()
+		return newRequeueWithDelay(), nil
+	}
+	reconcileSuccessCount.Inc()
+	return res, nil
+}
+
+// doReconcile updates the progress of the fleet upgrade and starts the upgrade of the next managed clusters
+func (r *VerrazzanoFleetUpgradeReconciler) doReconcile(ctx context.Context, upgrade *clustersv1alpha1.VerrazzanoFleetUpgrade) (ctrl.Result, error) {
+	if !upgrade.DeletionTimestamp.IsZero() {
+		if vzstring.SliceContainsString(upgrade.Finalizers, finalizerName) {
+			// Stop pushing the upgrade to the managed clusters
+			if err := r.syncAnnotations(ctx, upgrade, nil); err != nil {
+				return reconcile.Result{}, err
+			}
+			r.log.Infof("Removing finalizer %s", finalizerName)
+			upgrade.Finalizers = vzstring.RemoveStringFromSlice(upgrade.Finalizers, finalizerName)
+			if err := r.Update(ctx, upgrade); err != nil && !errors.IsConflict(err) {
+				return reconcile.Result{}, err
+			}
+		}
+		return reconcile.Result{}, nil
+	}
+
+	// Add our finalizer if not already added
+	if !vzstring.SliceContainsString(upgrade.Finalizers, finalizerName) {
+		r.log.Infof("Adding finalizer %s", finalizerName)
+		upgrade.Finalizers = append(upgrade.Finalizers, finalizerName)
+		if err := r.Update(ctx, upgrade); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	targetVersion, err := semver.NewSemVersion(upgrade.Spec.Version)
+	if err != nil {
+		upgrade.Status.Phase = clustersv1alpha1.FleetUpgradeFailed
+		upgrade.Status.Message = err.Error()
+		return reconcile.Result{}, r.updateStatus(ctx, upgrade)
+	}
+
+	vmcs, err := r.getTargetClusters(ctx, upgrade)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	upgrade.Status = planUpgrade(upgrade, vmcs, targetVersion, metav1.Now())
+	if err := r.syncAnnotations(ctx, upgrade, upgrade.Status.Clusters); err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := r.updateStatus(ctx, upgrade); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if upgrade.Status.Phase == clustersv1alpha1.FleetUpgradeCompleted {
+		r.log.Oncef("Upgraded all managed clusters of VerrazzanoFleetUpgrade %s/%s to version %s", upgrade.Namespace, upgrade.Name, upgrade.Spec.Version)
+		return reconcile.Result{}, nil
+	}
+	return reconcile.Result{Requeue: true, RequeueAfter: requeueInterval}, nil
+}
+
+// getTargetClusters returns the VerrazzanoManagedClusters listed by name or selected by the label selector of
+// the fleet upgrade, sorted by name
+func (r *VerrazzanoFleetUpgradeReconciler) getTargetClusters(ctx context.Context, upgrade *clustersv1alpha1.VerrazzanoFleetUpgrade) ([]clustersv1alpha1.VerrazzanoManagedCluster, error) {
+	selector := labels.Nothing()
+	if upgrade.Spec.ClusterSelector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(upgrade.Spec.ClusterSelector)
+		if err != nil {
+			return nil, r.log.ErrorfNewErr("Failed to parse the cluster selector of VerrazzanoFleetUpgrade %s/%s: %v", upgrade.Namespace, upgrade.Name, err)
+		}
+	}
+
+	vmcList := clustersv1alpha1.VerrazzanoManagedClusterList{}
+	if err := r.List(ctx, &vmcList, client.InNamespace(upgrade.Namespace)); err != nil {
+		return nil, err
+	}
+	var vmcs []clustersv1alpha1.VerrazzanoManagedCluster
+	for _, vmc := range vmcList.Items {
+		if !vmc.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if vzstring.SliceContainsString(upgrade.Spec.Clusters, vmc.Name) || selector.Matches(labels.Set(vmc.Labels)) {
+			vmcs = append(vmcs, vmc)
+		}
+	}
+	sort.Slice(vmcs, func(i, j int) bool {
+		return vmcs[i].Name < vmcs[j].Name
+	})
+	return vmcs, nil
+}
+
+// syncAnnotations sets the fleet upgrade annotation on the VerrazzanoManagedClusters of the clusters being upgraded,
+// and removes it from all the other VerrazzanoManagedClusters annotated with this fleet upgrade. The annotation of a
+// cluster being upgraded by another fleet upgrade is left as is.
+func (r *VerrazzanoFleetUpgradeReconciler) syncAnnotations(ctx context.Context, upgrade *clustersv1alpha1.VerrazzanoFleetUpgrade, clusters []clustersv1alpha1.ClusterUpgradeStatus) error {
+	upgrading := map[string]bool{}
+	for _, cluster := range clusters {
+		if cluster.State == clustersv1alpha1.ClusterUpgradeUpgrading || cluster.State == clustersv1alpha1.ClusterUpgradeFailed {
+			upgrading[cluster.Name] = true
+		}
+	}
+
+	vmcList := clustersv1alpha1.VerrazzanoManagedClusterList{}
+	if err := r.List(ctx, &vmcList, client.InNamespace(upgrade.Namespace)); err != nil {
+		return err
+	}
+	for i := range vmcList.Items {
+		vmc := &vmcList.Items[i]
+		current, annotated := vmc.Annotations[clustersv1alpha1.FleetUpgradeAnnotation]
+		switch {
+		case upgrading[vmc.Name] && !annotated:
+			if vmc.Annotations == nil {
+				vmc.Annotations = map[string]string{}
+			}
+			vmc.Annotations[clustersv1alpha1.FleetUpgradeAnnotation] = upgrade.Name
+			r.log.Infof("Starting the upgrade of managed cluster %s to version %s", vmc.Name, upgrade.Spec.Version)
+		case !upgrading[vmc.Name] && annotated && current == upgrade.Name:
+			delete(vmc.Annotations, clustersv1alpha1.FleetUpgradeAnnotation)
+		default:
+			continue
+		}
+		if err := r.Update(ctx, vmc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateStatus updates the status of the fleet upgrade in the cluster
+func (r *VerrazzanoFleetUpgradeReconciler) updateStatus(ctx context.Context, upgrade *clustersv1alpha1.VerrazzanoFleetUpgrade) error {
+	upgrade.Status.ObservedGeneration = upgrade.Generation
+	r.log.Debugf("Updating Status of VerrazzanoFleetUpgrade %s: %s", upgrade.Name, upgrade.Status.Phase)
+	return r.Status().Update(ctx, upgrade)
+}
+
+func newRequeueWithDelay() ctrl.Result {
+	return vzctrl.NewRequeueWithDelay(2, 3, time.Second)
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package fleetupgrade
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	asserts "github.com/stretchr/testify/assert"
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testNamespace     = "verrazzano-mc"
+	testUpgradeName   = "upgrade"
+	testOldVersion    = "1.5.0"
+	testTargetVersion = "1.6.0"
+)
+
+// TestReconcileStartsFirstWave tests starting a fleet upgrade
+// GIVEN a fleet upgrade of three clusters in waves of two clusters, with one cluster unavailable at a time
+// WHEN the fleet upgrade is reconciled
+// THEN the upgrade of the first cluster of the first wave is started, and the VMC of the cluster is annotated
+func TestReconcileStartsFirstWave(t *testing.T) {
+	assert := asserts.New(t)
+	upgrade := newFleetUpgrade()
+	upgrade.Spec.WaveSize = 2
+	cli := newFakeClient(upgrade,
+		newVMC("c3", testOldVersion, v1beta1.VzStateReady),
+		newVMC("c1", testOldVersion, v1beta1.VzStateReady),
+		newVMC("c2", testOldVersion, v1beta1.VzStateReady),
+	)
+
+	res := reconcileUpgrade(assert, cli)
+	assert.True(res.Requeue)
+
+	upgrade = getFleetUpgrade(assert, cli)
+	assert.Equal(clustersv1alpha1.FleetUpgradeInProgress, upgrade.Status.Phase)
+	assert.Equal(0, upgrade.Status.CurrentWave)
+	assert.Len(upgrade.Status.Clusters, 3)
+	assertCluster(assert, upgrade.Status.Clusters[0], "c1", 0, clustersv1alpha1.ClusterUpgradeUpgrading)
+	assertCluster(assert, upgrade.Status.Clusters[1], "c2", 0, clustersv1alpha1.ClusterUpgradePending)
+	assertCluster(assert, upgrade.Status.Clusters[2], "c3", 1, clustersv1alpha1.ClusterUpgradePending)
+	assert.Contains(upgrade.Finalizers, finalizerName)
+
+	assert.Equal(testUpgradeName, getVMC(assert, cli, "c1").Annotations[clustersv1alpha1.FleetUpgradeAnnotation])
+	assert.Empty(getVMC(assert, cli, "c2").Annotations[clustersv1alpha1.FleetUpgradeAnnotation])
+}
+
+// TestReconcileProgress tests the progress of a fleet upgrade
+// GIVEN a fleet upgrade where the first cluster being upgraded reports the target version
+// WHEN the fleet upgrade is reconciled
+// THEN the upgrade of the first cluster succeeded, the upgrade of the next cluster is started and
+// the annotation of the first cluster is removed
+func TestReconcileProgress(t *testing.T) {
+	assert := asserts.New(t)
+	upgrade := newFleetUpgrade()
+	start := metav1.Now()
+	upgrade.Status.Clusters = []clustersv1alpha1.ClusterUpgradeStatus{
+		{Name: "c1", State: clustersv1alpha1.ClusterUpgradeUpgrading, StartTime: &start},
+		{Name: "c2", State: clustersv1alpha1.ClusterUpgradePending},
+	}
+	c1 := newVMC("c1", testTargetVersion, v1beta1.VzStateReady)
+	c1.Annotations = map[string]string{clustersv1alpha1.FleetUpgradeAnnotation: testUpgradeName}
+	cli := newFakeClient(upgrade, c1, newVMC("c2", testOldVersion, v1beta1.VzStateReady))
+
+	reconcileUpgrade(assert, cli)
+
+	upgrade = getFleetUpgrade(assert, cli)
+	assert.Equal(clustersv1alpha1.FleetUpgradeInProgress, upgrade.Status.Phase)
+	assertCluster(assert, upgrade.Status.Clusters[0], "c1", 0, clustersv1alpha1.ClusterUpgradeSucceeded)
+	assert.NotNil(upgrade.Status.Clusters[0].CompletionTime)
+	assertCluster(assert, upgrade.Status.Clusters[1], "c2", 0, clustersv1alpha1.ClusterUpgradeUpgrading)
+
+	assert.Empty(getVMC(assert, cli, "c1").Annotations[clustersv1alpha1.FleetUpgradeAnnotation])
+	assert.Equal(testUpgradeName, getVMC(assert, cli, "c2").Annotations[clustersv1alpha1.FleetUpgradeAnnotation])
+}
+
+// TestReconcilePauseOnFailure tests a fleet upgrade that pauses on failure
+// GIVEN a fleet upgrade with pause on failure where the cluster being upgraded reports a failed state
+// WHEN the fleet upgrade is reconciled
+// THEN the upgrade of the cluster failed, the fleet upgrade is paused and no other upgrade is started
+func TestReconcilePauseOnFailure(t *testing.T) {
+	assert := asserts.New(t)
+	upgrade := newFleetUpgrade()
+	upgrade.Spec.PauseOnFailure = true
+	start := metav1.Now()
+	upgrade.Status.Clusters = []clustersv1alpha1.ClusterUpgradeStatus{
+		{Name: "c1", State: clustersv1alpha1.ClusterUpgradeUpgrading, StartTime: &start},
+	}
+	cli := newFakeClient(upgrade,
+		newVMC("c1", testOldVersion, v1beta1.VzStateFailed),
+		newVMC("c2", testOldVersion, v1beta1.VzStateReady),
+	)
+
+	reconcileUpgrade(assert, cli)
+
+	upgrade = getFleetUpgrade(assert, cli)
+	assert.Equal(clustersv1alpha1.FleetUpgradePaused, upgrade.Status.Phase)
+	assertCluster(assert, upgrade.Status.Clusters[0], "c1", 0, clustersv1alpha1.ClusterUpgradeFailed)
+	assertCluster(assert, upgrade.Status.Clusters[1], "c2", 0, clustersv1alpha1.ClusterUpgradePending)
+	assert.Empty(getVMC(assert, cli, "c2").Annotations[clustersv1alpha1.FleetUpgradeAnnotation])
+}
+
+// TestReconcileTimeout tests a cluster upgrade that does not complete in time
+// GIVEN a fleet upgrade where the upgrade of a cluster started longer ago than the cluster timeout
+// WHEN the fleet upgrade is reconciled
+// THEN the upgrade of the cluster failed and, without pause on failure, the fleet upgrade failed
+func TestReconcileTimeout(t *testing.T) {
+	assert := asserts.New(t)
+	upgrade := newFleetUpgrade()
+	upgrade.Spec.ClusterTimeout = &metav1.Duration{Duration: 10 * time.Minute}
+	start := metav1.NewTime(time.Now().Add(-time.Hour))
+	upgrade.Status.Clusters = []clustersv1alpha1.ClusterUpgradeStatus{
+		{Name: "c1", State: clustersv1alpha1.ClusterUpgradeUpgrading, StartTime: &start},
+	}
+	cli := newFakeClient(upgrade, newVMC("c1", testOldVersion, v1beta1.VzStateUpgrading))
+
+	reconcileUpgrade(assert, cli)
+
+	upgrade = getFleetUpgrade(assert, cli)
+	assert.Equal(clustersv1alpha1.FleetUpgradeFailed, upgrade.Status.Phase)
+	assertCluster(assert, upgrade.Status.Clusters[0], "c1", 0, clustersv1alpha1.ClusterUpgradeFailed)
+}
+
+// TestReconcileCompleted tests a completed fleet upgrade
+// GIVEN a fleet upgrade selecting clusters by label where all selected clusters report the target version
+// WHEN the fleet upgrade is reconciled
+// THEN the fleet upgrade is completed, the clusters not selected are ignored and the reconcile is not requeued
+func TestReconcileCompleted(t *testing.T) {
+	assert := asserts.New(t)
+	upgrade := newFleetUpgrade()
+	upgrade.Spec.Clusters = nil
+	upgrade.Spec.ClusterSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}
+	c1 := newVMC("c1", testTargetVersion, v1beta1.VzStateReady)
+	c1.Labels = map[string]string{"env": "prod"}
+	c2 := newVMC("c2", testOldVersion, v1beta1.VzStateReady)
+	c2.Labels = map[string]string{"env": "dev"}
+	cli := newFakeClient(upgrade, c1, c2)
+
+	res := reconcileUpgrade(assert, cli)
+	assert.False(res.Requeue)
+
+	upgrade = getFleetUpgrade(assert, cli)
+	assert.Equal(clustersv1alpha1.FleetUpgradeCompleted, upgrade.Status.Phase)
+	assert.Len(upgrade.Status.Clusters, 1)
+	assertCluster(assert, upgrade.Status.Clusters[0], "c1", 0, clustersv1alpha1.ClusterUpgradeSucceeded)
+}
+
+// TestReconcileInvalidVersion tests a fleet upgrade with an invalid target version
+// GIVEN a fleet upgrade with a target version that is not a semantic version
+// WHEN the fleet upgrade is reconciled
+// THEN the fleet upgrade failed and no cluster is annotated
+func TestReconcileInvalidVersion(t *testing.T) {
+	assert := asserts.New(t)
+	upgrade := newFleetUpgrade()
+	upgrade.Spec.Version = "latest"
+	cli := newFakeClient(upgrade, newVMC("c1", testOldVersion, v1beta1.VzStateReady))
+
+	reconcileUpgrade(assert, cli)
+
+	upgrade = getFleetUpgrade(assert, cli)
+	assert.Equal(clustersv1alpha1.FleetUpgradeFailed, upgrade.Status.Phase)
+	assert.Empty(getVMC(assert, cli, "c1").Annotations[clustersv1alpha1.FleetUpgradeAnnotation])
+}
+
+// TestReconcileDelete tests deleting a fleet upgrade
+// GIVEN a fleet upgrade being deleted
+// WHEN the fleet upgrade is reconciled
+// THEN the annotation of the clusters being upgraded is removed, along with the finalizer
+func TestReconcileDelete(t *testing.T) {
+	assert := asserts.New(t)
+	upgrade := newFleetUpgrade()
+	now := metav1.Now()
+	upgrade.DeletionTimestamp = &now
+	upgrade.Finalizers = []string{finalizerName}
+	c1 := newVMC("c1", testOldVersion, v1beta1.VzStateUpgrading)
+	c1.Annotations = map[string]string{clustersv1alpha1.FleetUpgradeAnnotation: testUpgradeName}
+	cli := newFakeClient(upgrade, c1)
+
+	reconcileUpgrade(assert, cli)
+
+	assert.Empty(getVMC(assert, cli, "c1").Annotations[clustersv1alpha1.FleetUpgradeAnnotation])
+}
+
+func reconcileUpgrade(assert *asserts.Assertions, cli client.Client) ctrl.Result {
+	r := &VerrazzanoFleetUpgradeReconciler{Client: cli, Scheme: cli.Scheme()}
+	res, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testUpgradeName}})
+	assert.NoError(err)
+	return res
+}
+
+func assertCluster(assert *asserts.Assertions, cluster clustersv1alpha1.ClusterUpgradeStatus, name string, wave int, state clustersv1alpha1.ClusterUpgradeState) {
+	assert.Equal(name, cluster.Name)
+	assert.Equal(wave, cluster.Wave)
+	assert.Equal(state, cluster.State, cluster.Message)
+}
+
+func newFleetUpgrade() *clustersv1alpha1.VerrazzanoFleetUpgrade {
+	return &clustersv1alpha1.VerrazzanoFleetUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testUpgradeName},
+		Spec: clustersv1alpha1.VerrazzanoFleetUpgradeSpec{
+			Version:  testTargetVersion,
+			Clusters: []string{"c1", "c2", "c3"},
+		},
+	}
+}
+
+func newVMC(name string, version string, state v1beta1.VzStateType) *clustersv1alpha1.VerrazzanoManagedCluster {
+	return &clustersv1alpha1.VerrazzanoManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name},
+		Status: clustersv1alpha1.VerrazzanoManagedClusterStatus{
+			Inventory: &clustersv1alpha1.ManagedClusterInventory{
+				VerrazzanoVersion: version,
+				VerrazzanoState:   string(state),
+			},
+		},
+	}
+}
+
+func newFakeClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = clustersv1alpha1.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func getFleetUpgrade(assert *asserts.Assertions, cli client.Client) *clustersv1alpha1.VerrazzanoFleetUpgrade {
+	upgrade := &clustersv1alpha1.VerrazzanoFleetUpgrade{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testUpgradeName}, upgrade))
+	return upgrade
+}
+
+func getVMC(assert *asserts.Assertions, cli client.Client, name string) *clustersv1alpha1.VerrazzanoManagedCluster {
+	vmc := &clustersv1alpha1.VerrazzanoManagedCluster{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: name}, vmc))
+	return vmc
+}
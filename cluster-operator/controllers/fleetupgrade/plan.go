@@ -0,0 +1,157 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package fleetupgrade
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/pkg/semver"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultMaxUnavailable = 1
+	defaultClusterTimeout = time.Hour
+)
+
+// planUpgrade returns the status of the fleet upgrade, updated from the inventory reported by the given managed
+// clusters. The clusters are assigned to waves in the given order, and the upgrade of the pending clusters of the
+// current wave is started, up to the maximum number of clusters unavailable at the same time.
+func planUpgrade(upgrade *clustersv1alpha1.VerrazzanoFleetUpgrade, vmcs []clustersv1alpha1.VerrazzanoManagedCluster, targetVersion *semver.SemVersion, now metav1.Time) clustersv1alpha1.VerrazzanoFleetUpgradeStatus {
+	status := clustersv1alpha1.VerrazzanoFleetUpgradeStatus{}
+	if len(vmcs) == 0 {
+		status.Phase = clustersv1alpha1.FleetUpgradePending
+		status.Message = "No managed clusters match the clusters of the fleet upgrade"
+		return status
+	}
+
+	previous := map[string]clustersv1alpha1.ClusterUpgradeStatus{}
+	for _, cluster := range upgrade.Status.Clusters {
+		previous[cluster.Name] = cluster
+	}
+	waveSize := upgrade.Spec.WaveSize
+	if waveSize <= 0 {
+		waveSize = len(vmcs)
+	}
+	timeout := defaultClusterTimeout
+	if upgrade.Spec.ClusterTimeout != nil {
+		timeout = upgrade.Spec.ClusterTimeout.Duration
+	}
+
+	for i, vmc := range vmcs {
+		cluster, ok := previous[vmc.Name]
+		if !ok {
+			cluster = clustersv1alpha1.ClusterUpgradeStatus{Name: vmc.Name, State: clustersv1alpha1.ClusterUpgradePending}
+		}
+		cluster.Wave = i / waveSize
+		updateClusterStatus(&cluster, vmc, targetVersion, timeout, now)
+		status.Clusters = append(status.Clusters, cluster)
+	}
+
+	// The current wave is the first wave with clusters that are pending or being upgraded
+	status.CurrentWave = -1
+	var failed []string
+	for _, cluster := range status.Clusters {
+		if cluster.State == clustersv1alpha1.ClusterUpgradeFailed {
+			failed = append(failed, cluster.Name)
+		}
+		if status.CurrentWave < 0 && (cluster.State == clustersv1alpha1.ClusterUpgradePending || cluster.State == clustersv1alpha1.ClusterUpgradeUpgrading) {
+			status.CurrentWave = cluster.Wave
+		}
+	}
+
+	switch {
+	case status.CurrentWave < 0 && len(failed) == 0:
+		status.CurrentWave = status.Clusters[len(status.Clusters)-1].Wave
+		status.Phase = clustersv1alpha1.FleetUpgradeCompleted
+		status.Message = fmt.Sprintf("Upgraded %d managed clusters to version %s", len(status.Clusters), upgrade.Spec.Version)
+	case status.CurrentWave < 0:
+		status.CurrentWave = status.Clusters[len(status.Clusters)-1].Wave
+		status.Phase = clustersv1alpha1.FleetUpgradeFailed
+		status.Message = fmt.Sprintf("Failed to upgrade managed clusters %s", strings.Join(failed, ", "))
+	case upgrade.Spec.Paused:
+		status.Phase = clustersv1alpha1.FleetUpgradePaused
+		status.Message = "The fleet upgrade is paused"
+	case upgrade.Spec.PauseOnFailure && len(failed) > 0:
+		status.Phase = clustersv1alpha1.FleetUpgradePaused
+		status.Message = fmt.Sprintf("The fleet upgrade is paused after failing to upgrade managed clusters %s", strings.Join(failed, ", "))
+	default:
+		startUpgrades(upgrade, &status, vmcs, now)
+		status.Phase = clustersv1alpha1.FleetUpgradeInProgress
+		status.Message = fmt.Sprintf("Upgrading wave %d of the managed clusters to version %s", status.CurrentWave, upgrade.Spec.Version)
+	}
+	return status
+}
+
+// updateClusterStatus updates the state of the upgrade of a cluster from the inventory reported by the agent of the
+// managed cluster
+func updateClusterStatus(cluster *clustersv1alpha1.ClusterUpgradeStatus, vmc clustersv1alpha1.VerrazzanoManagedCluster, targetVersion *semver.SemVersion, timeout time.Duration, now metav1.Time) {
+	inventory := vmc.Status.Inventory
+	if inventory != nil {
+		cluster.Version = inventory.VerrazzanoVersion
+	}
+	if cluster.State == clustersv1alpha1.ClusterUpgradeSucceeded {
+		return
+	}
+
+	var version *semver.SemVersion
+	if inventory != nil && len(inventory.VerrazzanoVersion) > 0 {
+		version, _ = semver.NewSemVersion(inventory.VerrazzanoVersion)
+	}
+	switch {
+	case version != nil && version.IsEqualTo(targetVersion) && inventory.VerrazzanoState == string(v1beta1.VzStateReady):
+		cluster.State = clustersv1alpha1.ClusterUpgradeSucceeded
+		cluster.Message = fmt.Sprintf("Verrazzano version %s is ready", inventory.VerrazzanoVersion)
+		cluster.CompletionTime = &now
+	case version != nil && version.IsGreatherThan(targetVersion):
+		cluster.State = clustersv1alpha1.ClusterUpgradeFailed
+		cluster.Message = fmt.Sprintf("Verrazzano version %s is newer than the target version", inventory.VerrazzanoVersion)
+	case cluster.State != clustersv1alpha1.ClusterUpgradeUpgrading:
+		return
+	case inventory != nil && inventory.VerrazzanoState == string(v1beta1.VzStateFailed):
+		cluster.State = clustersv1alpha1.ClusterUpgradeFailed
+		cluster.Message = "The upgrade of Verrazzano failed on the managed cluster"
+	case cluster.StartTime != nil && now.Sub(cluster.StartTime.Time) > timeout:
+		cluster.State = clustersv1alpha1.ClusterUpgradeFailed
+		cluster.Message = fmt.Sprintf("The upgrade did not complete within %v", timeout)
+	}
+}
+
+// startUpgrades starts the upgrade of the pending clusters of the current wave, as long as the number of clusters
+// being upgraded does not exceed the maximum number of unavailable clusters
+func startUpgrades(upgrade *clustersv1alpha1.VerrazzanoFleetUpgrade, status *clustersv1alpha1.VerrazzanoFleetUpgradeStatus, vmcs []clustersv1alpha1.VerrazzanoManagedCluster, now metav1.Time) {
+	maxUnavailable := upgrade.Spec.MaxUnavailable
+	if maxUnavailable <= 0 {
+		maxUnavailable = defaultMaxUnavailable
+	}
+	upgrading := 0
+	for _, cluster := range status.Clusters {
+		if cluster.State == clustersv1alpha1.ClusterUpgradeUpgrading {
+			upgrading++
+		}
+	}
+
+	for i := range status.Clusters {
+		cluster := &status.Clusters[i]
+		if upgrading >= maxUnavailable {
+			return
+		}
+		if cluster.Wave != status.CurrentWave || cluster.State != clustersv1alpha1.ClusterUpgradePending {
+			continue
+		}
+		// Do not interfere with the upgrade of a cluster by another fleet upgrade
+		if other := vmcs[i].Annotations[clustersv1alpha1.FleetUpgradeAnnotation]; len(other) > 0 && other != upgrade.Name {
+			cluster.Message = fmt.Sprintf("Waiting for VerrazzanoFleetUpgrade %s to complete", other)
+			continue
+		}
+		cluster.State = clustersv1alpha1.ClusterUpgradeUpgrading
+		cluster.Message = fmt.Sprintf("Upgrading Verrazzano to version %s", upgrade.Spec.Version)
+		cluster.StartTime = &now
+		upgrading++
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package vmc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	vzconstants "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/keycloak"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	defaultInactiveAfter      = vzconstants.VMCAgentPollingTimeInterval * vzconstants.MaxTimesVMCAgentPollingTime
+	defaultCleanupGracePeriod = 24 * time.Hour
+
+	// Reasons of the events recorded for the detachment of a managed cluster
+	eventReasonAgentDisconnected = "AgentDisconnected"
+	eventReasonAgentReconnected  = "AgentReconnected"
+	eventReasonCleanupStarted    = "StaleStateCleanupStarted"
+	eventReasonCleanupCompleted  = "StaleStateCleanedUp"
+	eventReasonCleanupFailed     = "StaleStateCleanupFailed"
+)
+
+// getInactiveAfter returns the time since the agent last connected after which the managed cluster is inactive
+func getInactiveAfter(vmc *clustersv1alpha1.VerrazzanoManagedCluster) time.Duration {
+	if vmc.Spec.Detachment != nil && vmc.Spec.Detachment.InactiveAfter != nil {
+		return vmc.Spec.Detachment.InactiveAfter.Duration
+	}
+	return defaultInactiveAfter
+}
+
+// getCleanupGracePeriod returns the time the managed cluster must be inactive before its state is cleaned up
+func getCleanupGracePeriod(vmc *clustersv1alpha1.VerrazzanoManagedCluster) time.Duration {
+	if vmc.Spec.Detachment != nil && vmc.Spec.Detachment.CleanupGracePeriod != nil {
+		return vmc.Spec.Detachment.CleanupGracePeriod.Duration
+	}
+	return defaultCleanupGracePeriod
+}
+
+// isAgentInactive returns true if the agent of the managed cluster has connected to the admin cluster, but not within
+// the inactivity threshold of the detachment policy
+func isAgentInactive(vmc *clustersv1alpha1.VerrazzanoManagedCluster, now time.Time) bool {
+	if vmc.Status.LastAgentConnectTime == nil {
+		return false
+	}
+	return now.Sub(vmc.Status.LastAgentConnectTime.Time) > getInactiveAfter(vmc)
+}
+
+// isConditionTrue returns true if the VMC has the given status condition set to true
+func isConditionTrue(vmc *clustersv1alpha1.VerrazzanoManagedCluster, conditionType clustersv1alpha1.ConditionType) bool {
+	for _, condition := range vmc.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// isConditionFalse returns true if the VMC has the given status condition set to false
+func isConditionFalse(vmc *clustersv1alpha1.VerrazzanoManagedCluster, conditionType clustersv1alpha1.ConditionType) bool {
+	for _, condition := range vmc.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status == corev1.ConditionFalse
+		}
+	}
+	return false
+}
+
+// syncDetachment detects whether the managed cluster is detached from the admin cluster, from the last time its agent
+// connected to the admin cluster, and sets the AgentConnected status condition on the VMC in memory. If the detachment
+// policy enables it, the state of a managed cluster that has been inactive for the cleanup grace period is removed
+// from the admin cluster. This function returns true if the state of the managed cluster has been cleaned up and the
+// agent has not reconnected since, in which case the state must not be created again.
+func (r *VerrazzanoManagedClusterReconciler) syncDetachment(ctx context.Context, vmc *clustersv1alpha1.VerrazzanoManagedCluster) (bool, error) {
+	if vmc.Status.LastAgentConnectTime == nil {
+		// The agent has never connected, so the managed cluster is still being registered
+		return false, nil
+	}
+	now := metav1.Now()
+	lastConnect := vmc.Status.LastAgentConnectTime.UTC().Format(time.RFC3339)
+	cleanedUp := isConditionTrue(vmc, clustersv1alpha1.ConditionStaleStateCleanedUp)
+
+	if !isAgentInactive(vmc, now.Time) {
+		if isConditionFalse(vmc, clustersv1alpha1.ConditionAgentConnected) {
+			r.log.Infof("The agent of managed cluster %s has reconnected", vmc.Name)
+			r.recordEvent(vmc, corev1.EventTypeNormal, eventReasonAgentReconnected, "The agent of the managed cluster reconnected at %s", lastConnect)
+		}
+		r.setStatusCondition(vmc, clustersv1alpha1.Condition{Status: corev1.ConditionTrue, Type: clustersv1alpha1.ConditionAgentConnected,
+			Message: "The agent of the managed cluster is connected", LastTransitionTime: &now}, false)
+		if cleanedUp {
+			r.setStatusCondition(vmc, clustersv1alpha1.Condition{Status: corev1.ConditionFalse, Type: clustersv1alpha1.ConditionStaleStateCleanedUp,
+				Message: "The agent of the managed cluster reconnected after the stale state was cleaned up", LastTransitionTime: &now}, false)
+		}
+		return false, nil
+	}
+
+	if !isConditionFalse(vmc, clustersv1alpha1.ConditionAgentConnected) {
+		r.log.Infof("The agent of managed cluster %s has not connected since %s, the managed cluster is inactive", vmc.Name, lastConnect)
+		r.recordEvent(vmc, corev1.EventTypeWarning, eventReasonAgentDisconnected, "The agent of the managed cluster has not connected since %s", lastConnect)
+	}
+	r.setStatusCondition(vmc, clustersv1alpha1.Condition{Status: corev1.ConditionFalse, Type: clustersv1alpha1.ConditionAgentConnected,
+		Message: fmt.Sprintf("The agent of the managed cluster has not connected since %s", lastConnect), LastTransitionTime: &now}, false)
+
+	if cleanedUp {
+		return true, nil
+	}
+	if vmc.Spec.Detachment == nil || !vmc.Spec.Detachment.CleanupEnabled {
+		return false, nil
+	}
+	inactiveSince := vmc.Status.LastAgentConnectTime.Add(getInactiveAfter(vmc))
+	if now.Sub(inactiveSince) <= getCleanupGracePeriod(vmc) {
+		return false, nil
+	}
+
+	r.log.Infof("Cleaning up the stale state of managed cluster %s on the admin cluster", vmc.Name)
+	r.recordEvent(vmc, corev1.EventTypeNormal, eventReasonCleanupStarted, "Cleaning up the state of the managed cluster, inactive since %s", inactiveSince.UTC().Format(time.RFC3339))
+	if err := r.cleanupStaleState(ctx, vmc); err != nil {
+		r.recordEvent(vmc, corev1.EventTypeWarning, eventReasonCleanupFailed, "Failed to clean up the state of the managed cluster: %v", err)
+		return false, err
+	}
+	r.recordEvent(vmc, corev1.EventTypeNormal, eventReasonCleanupCompleted, "Cleaned up the state of the managed cluster")
+	r.setStatusCondition(vmc, clustersv1alpha1.Condition{Status: corev1.ConditionTrue, Type: clustersv1alpha1.ConditionStaleStateCleanedUp,
+		Message: fmt.Sprintf("The state of the managed cluster was cleaned up after the agent did not connect since %s", lastConnect), LastTransitionTime: &now}, false)
+	return true, nil
+}
+
+// cleanupStaleState removes the state of a detached managed cluster from the admin cluster. The VMC, and the
+// ServiceAccount and secrets used by the agent, are kept so that the agent can reconnect.
+func (r *VerrazzanoManagedClusterReconciler) cleanupStaleState(ctx context.Context, vmc *clustersv1alpha1.VerrazzanoManagedCluster) error {
+	if err := r.syncThanosQueryEndpointDelete(ctx, vmc); err != nil {
+		return err
+	}
+	if err := r.deleteClusterPrometheusConfiguration(ctx, vmc); err != nil {
+		return err
+	}
+	if err := r.mutateManagedClusterCACertsSecret(ctx, vmc, nil); err != nil {
+		return err
+	}
+	if err := deleteClient(r, vmc); err != nil {
+		return err
+	}
+	if err := r.unregisterClusterFromArgoCD(ctx, vmc); err != nil {
+		return err
+	}
+	return r.deleteStaleClusterFromRancher(ctx, vmc)
+}
+
+// deleteStaleClusterFromRancher deletes the Rancher cluster of a detached managed cluster. The Rancher cluster ID is
+// cleared from the VMC status before the Rancher cluster is deleted, so that the VMC is not deleted along with the
+// Rancher cluster, and so that the managed cluster is registered again with Rancher if the agent reconnects.
+func (r *VerrazzanoManagedClusterReconciler) deleteStaleClusterFromRancher(ctx context.Context, vmc *clustersv1alpha1.VerrazzanoManagedCluster) error {
+	if vmc.Status.RancherRegistration.ClusterID == "" {
+		return nil
+	}
+	registeredVMC := vmc.DeepCopy()
+
+	existingVMC := &clustersv1alpha1.VerrazzanoManagedCluster{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: vmc.Namespace, Name: vmc.Name}, existingVMC); err != nil {
+		return err
+	}
+	vmc.Status.RancherRegistration = clustersv1alpha1.RancherRegistration{
+		Status:  clustersv1alpha1.RegistrationDeleted,
+		Message: fmt.Sprintf("Rancher cluster %s deleted after the managed cluster was detached", registeredVMC.Status.RancherRegistration.ClusterID),
+	}
+	existingVMC.Status.RancherRegistration = vmc.Status.RancherRegistration
+	if err := r.Status().Update(ctx, existingVMC); err != nil {
+		return err
+	}
+
+	// On failure, the Rancher cluster ID is restored in the VMC status so that the deletion is retried
+	return r.deleteClusterFromRancher(ctx, registeredVMC)
+}
+
+// recordEvent records an event for the VMC, if the reconciler has an event recorder
+func (r *VerrazzanoManagedClusterReconciler) recordEvent(vmc *clustersv1alpha1.VerrazzanoManagedCluster, eventType string, reason string, messageFmt string, args ...interface{}) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(vmc, eventType, reason, messageFmt, args...)
+}
+
+// leveraged to replace method (unit testing)
+var deleteClient = func(r *VerrazzanoManagedClusterReconciler, vmc *clustersv1alpha1.VerrazzanoManagedCluster) error {
+	// The Keycloak client is only created once the Prometheus host is set in the VMC status
+	if len(vmc.Status.PrometheusHost) == 0 {
+		return nil
+	}
+
+	cfg, cli, err := k8sutil.ClientConfig()
+	if err != nil {
+		return err
+	}
+
+	// create a context that can be leveraged by keycloak method
+	ctx, err := spi.NewMinimalContext(r.Client, r.log)
+	if err != nil {
+		return err
+	}
+
+	err = keycloak.LoginKeycloak(ctx, cfg, cli)
+	if err != nil {
+		return err
+	}
+
+	return keycloak.DeleteClient(ctx, cfg, cli, fmt.Sprintf("verrazzano-%s", vmc.Name))
+}
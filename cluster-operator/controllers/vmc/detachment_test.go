@@ -0,0 +1,161 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package vmc
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	asserts "github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	"github.com/verrazzano/verrazzano/pkg/rancherutil"
+	"github.com/verrazzano/verrazzano/pkg/test/mockmatchers"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/mocks"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TestSyncDetachment tests detecting that a managed cluster is detached from the admin cluster
+// GIVEN a VMC whose agent has never connected, has connected recently, and has not connected within the inactivity threshold
+// WHEN the detachment state is synced
+// THEN the AgentConnected condition is not set, set to true, and set to false with an event, respectively,
+// and the state of the managed cluster is not cleaned up
+func TestSyncDetachment(t *testing.T) {
+	a := asserts.New(t)
+	recorder := record.NewFakeRecorder(10)
+	r := &VerrazzanoManagedClusterReconciler{Client: generateClientObjects(), Recorder: recorder, log: vzlog.DefaultLogger()}
+	vmc := &v1alpha1.VerrazzanoManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: rancherNamespace, Name: "cluster"},
+		Spec: v1alpha1.VerrazzanoManagedClusterSpec{
+			Detachment: &v1alpha1.DetachmentPolicy{
+				InactiveAfter:  &metav1.Duration{Duration: 10 * time.Minute},
+				CleanupEnabled: true,
+			},
+		},
+	}
+
+	cleanedUp, err := r.syncDetachment(context.TODO(), vmc)
+	a.NoError(err)
+	a.False(cleanedUp)
+	a.Empty(vmc.Status.Conditions)
+
+	lastConnect := metav1.NewTime(time.Now().Add(-5 * time.Minute))
+	vmc.Status.LastAgentConnectTime = &lastConnect
+	cleanedUp, err = r.syncDetachment(context.TODO(), vmc)
+	a.NoError(err)
+	a.False(cleanedUp)
+	a.True(isConditionTrue(vmc, v1alpha1.ConditionAgentConnected))
+	a.Empty(recorder.Events)
+
+	lastConnect = metav1.NewTime(time.Now().Add(-time.Hour))
+	cleanedUp, err = r.syncDetachment(context.TODO(), vmc)
+	a.NoError(err)
+	a.False(cleanedUp)
+	a.True(isConditionFalse(vmc, v1alpha1.ConditionAgentConnected))
+	a.False(isConditionTrue(vmc, v1alpha1.ConditionStaleStateCleanedUp))
+	a.Contains(<-recorder.Events, eventReasonAgentDisconnected)
+	a.True(isAgentInactive(vmc, time.Now()))
+}
+
+// TestSyncDetachmentCleanup tests cleaning up the state of a detached managed cluster
+// GIVEN a VMC whose agent has not connected within the inactivity threshold and the cleanup grace period
+// WHEN the detachment state is synced
+// THEN the CA cert, Keycloak client and Rancher registration of the managed cluster are removed, the StaleStateCleanedUp
+// condition is set, and the state is not cleaned up again until the agent reconnects
+func TestSyncDetachmentCleanup(t *testing.T) {
+	a := asserts.New(t)
+	rancherutil.DeleteStoredTokens()
+	defer rancherutil.DeleteStoredTokens()
+
+	const clusterID = "cluster-id"
+	lastConnect := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	vmc := &v1alpha1.VerrazzanoManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: rancherNamespace, Name: "cluster"},
+		Spec: v1alpha1.VerrazzanoManagedClusterSpec{
+			Detachment: &v1alpha1.DetachmentPolicy{
+				CleanupEnabled:     true,
+				CleanupGracePeriod: &metav1.Duration{Duration: time.Hour},
+			},
+		},
+		Status: v1alpha1.VerrazzanoManagedClusterStatus{
+			LastAgentConnectTime: &lastConnect,
+			RancherRegistration:  v1alpha1.RancherRegistration{ClusterID: clusterID, Status: v1alpha1.RegistrationCompleted},
+		},
+	}
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: constants.VerrazzanoMonitoringNamespace, Name: constants.PromManagedClusterCACertsSecretName},
+		Data: map[string][]byte{
+			getCAKey(vmc):      []byte("ca-cert-1"),
+			"ca-other-cluster": []byte("ca-cert-2"),
+		},
+	}
+	adminSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "cattle-system", Name: rancherAdminSecret},
+		Data:       map[string][]byte{"password": []byte("")},
+	}
+	c := generateClientObjects(vmc, caSecret, adminSecret, &v1beta1.Verrazzano{ObjectMeta: metav1.ObjectMeta{Name: "verrazzano"}})
+	recorder := record.NewFakeRecorder(10)
+	r := &VerrazzanoManagedClusterReconciler{Client: c, Recorder: recorder, log: vzlog.DefaultLogger()}
+
+	savedDeleteClient := deleteClient
+	defer func() { deleteClient = savedDeleteClient }()
+	var deletedClients []string
+	deleteClient = func(r *VerrazzanoManagedClusterReconciler, vmc *v1alpha1.VerrazzanoManagedCluster) error {
+		deletedClients = append(deletedClients, vmc.Name)
+		return nil
+	}
+
+	savedRancherHTTPClient := rancherutil.RancherHTTPClient
+	defer func() { rancherutil.RancherHTTPClient = savedRancherHTTPClient }()
+	mock := addTokenMock(mocks.NewMockRequestSender(gomock.NewController(t)))
+	mock.EXPECT().
+		Do(gomock.Not(gomock.Nil()), mockmatchers.MatchesURIMethod("DELETE", clustersPath+"/"+clusterID)).
+		DoAndReturn(func(httpClient *http.Client, req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader([]byte("")))}, nil
+		})
+	rancherutil.RancherHTTPClient = mock
+
+	cleanedUp, err := r.syncDetachment(context.TODO(), vmc)
+	a.NoError(err)
+	a.True(cleanedUp)
+	a.True(isConditionTrue(vmc, v1alpha1.ConditionStaleStateCleanedUp))
+	a.Equal([]string{vmc.Name}, deletedClients)
+	a.Contains(<-recorder.Events, eventReasonAgentDisconnected)
+	a.Contains(<-recorder.Events, eventReasonCleanupStarted)
+	a.Contains(<-recorder.Events, eventReasonCleanupCompleted)
+
+	a.NoError(c.Get(context.TODO(), client.ObjectKeyFromObject(caSecret), caSecret))
+	a.NotContains(caSecret.Data, getCAKey(vmc))
+	a.Contains(caSecret.Data, "ca-other-cluster")
+
+	updated := &v1alpha1.VerrazzanoManagedCluster{}
+	a.NoError(c.Get(context.TODO(), client.ObjectKeyFromObject(vmc), updated))
+	a.Empty(updated.Status.RancherRegistration.ClusterID)
+	a.Equal(v1alpha1.RegistrationDeleted, updated.Status.RancherRegistration.Status)
+
+	// The state is not cleaned up again while the agent does not reconnect
+	cleanedUp, err = r.syncDetachment(context.TODO(), vmc)
+	a.NoError(err)
+	a.True(cleanedUp)
+	a.Len(deletedClients, 1)
+
+	// The state is created again once the agent reconnects
+	lastConnect = metav1.Now()
+	cleanedUp, err = r.syncDetachment(context.TODO(), vmc)
+	a.NoError(err)
+	a.False(cleanedUp)
+	a.True(isConditionTrue(vmc, v1alpha1.ConditionAgentConnected))
+	a.True(isConditionFalse(vmc, v1alpha1.ConditionStaleStateCleanedUp))
+	a.Contains(<-recorder.Events, eventReasonAgentReconnected)
+}
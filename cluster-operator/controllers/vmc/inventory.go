@@ -0,0 +1,47 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package vmc
+
+import (
+	"fmt"
+	"strings"
+
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// setStatusConditionHealthy sets the status condition Healthy on the VMC in memory from the inventory last reported
+// by the agent of the managed cluster - does NOT update the status in the cluster
+func (r *VerrazzanoManagedClusterReconciler) setStatusConditionHealthy(vmc *clustersv1alpha1.VerrazzanoManagedCluster) {
+	inventory := vmc.Status.Inventory
+	if inventory == nil {
+		return
+	}
+
+	var unavailable []string
+	for _, component := range inventory.Components {
+		if !component.Available {
+			unavailable = append(unavailable, component.Name)
+		}
+	}
+	notReadyNodes := inventory.Nodes - inventory.ReadyNodes
+
+	status := corev1.ConditionTrue
+	msg := fmt.Sprintf("All %d components of Verrazzano %s are available and all %d nodes are ready",
+		len(inventory.Components), inventory.VerrazzanoVersion, inventory.Nodes)
+	if len(unavailable) > 0 || notReadyNodes > 0 {
+		status = corev1.ConditionFalse
+		var problems []string
+		if len(unavailable) > 0 {
+			problems = append(problems, fmt.Sprintf("components not available: %s", strings.Join(unavailable, ", ")))
+		}
+		if notReadyNodes > 0 {
+			problems = append(problems, fmt.Sprintf("%d of %d nodes not ready", notReadyNodes, inventory.Nodes))
+		}
+		msg = strings.Join(problems, "; ")
+	}
+	now := metav1.Now()
+	r.setStatusCondition(vmc, clustersv1alpha1.Condition{Status: status, Type: clustersv1alpha1.ConditionHealthy, Message: msg, LastTransitionTime: &now}, false)
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package vmc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TestSetStatusConditionHealthy tests surfacing the inventory reported by the agent of a managed cluster
+// GIVEN a VMC without an inventory, with an inventory of available components and ready nodes, and with an
+// inventory of unavailable components and nodes that are not ready
+// WHEN the Healthy status condition is set
+// THEN the condition is not set without an inventory, is true when all components are available and all nodes
+// are ready, and is false otherwise with a message listing the unavailable components and nodes that are not ready
+func TestSetStatusConditionHealthy(t *testing.T) {
+	r := &VerrazzanoManagedClusterReconciler{log: vzlog.DefaultLogger()}
+
+	vmc := &v1alpha1.VerrazzanoManagedCluster{}
+	r.setStatusConditionHealthy(vmc)
+	assert.Empty(t, vmc.Status.Conditions)
+
+	vmc.Status.Inventory = &v1alpha1.ManagedClusterInventory{
+		VerrazzanoVersion: "1.6.0",
+		Components: []v1alpha1.ComponentInventory{
+			{Name: "cert-manager", Available: true},
+			{Name: "rancher", Available: true},
+		},
+		Nodes:      3,
+		ReadyNodes: 3,
+	}
+	r.setStatusConditionHealthy(vmc)
+	assert.Len(t, vmc.Status.Conditions, 1)
+	assert.Equal(t, v1alpha1.ConditionHealthy, vmc.Status.Conditions[0].Type)
+	assert.Equal(t, corev1.ConditionTrue, vmc.Status.Conditions[0].Status)
+	assert.Equal(t, "All 2 components of Verrazzano 1.6.0 are available and all 3 nodes are ready", vmc.Status.Conditions[0].Message)
+
+	vmc.Status.Inventory.Components[1].Available = false
+	vmc.Status.Inventory.ReadyNodes = 2
+	r.setStatusConditionHealthy(vmc)
+	assert.Len(t, vmc.Status.Conditions, 1)
+	assert.Equal(t, corev1.ConditionFalse, vmc.Status.Conditions[0].Status)
+	assert.Equal(t, "components not available: rancher; 1 of 3 nodes not ready", vmc.Status.Conditions[0].Message)
+}
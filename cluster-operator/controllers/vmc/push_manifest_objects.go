@@ -4,10 +4,15 @@
 package vmc
 
 import (
+	"context"
+
 	clusterapi "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/pkg/mcconstants"
 	"github.com/verrazzano/verrazzano/pkg/rancherutil"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
@@ -59,7 +64,75 @@ func (r *VerrazzanoManagedClusterReconciler) pushManifestObjects(vmc *clusterapi
 		return false, err
 	}
 
+	upgradeModified, err := r.pushFleetUpgradeSecret(vmc, rc, clusterID)
+	if err != nil {
+		return false, err
+	}
+
 	agentModified := agentOperation != controllerutil.OperationResultNone
 	regModified := regOperation != controllerutil.OperationResultNone
-	return agentModified || regModified, nil
+	return agentModified || regModified || upgradeModified, nil
+}
+
+// pushFleetUpgradeSecret creates or updates the fleet upgrade secret on the managed cluster, with the target version
+// and platform operator image of the VerrazzanoFleetUpgrade that is upgrading the managed cluster, if any.
+// The agent of the managed cluster updates the operator image and upgrades Verrazzano to the target version.
+// The secret is deleted from the managed cluster once the managed cluster is no longer upgraded.
+func (r *VerrazzanoManagedClusterReconciler) pushFleetUpgradeSecret(vmc *clusterapi.VerrazzanoManagedCluster, rc *rancherutil.RancherConfig, clusterID string) (bool, error) {
+	upgradeName := vmc.Annotations[clusterapi.FleetUpgradeAnnotation]
+	if len(upgradeName) == 0 {
+		return r.deleteFleetUpgradeSecret(vmc, rc, clusterID)
+	}
+	upgrade := clusterapi.VerrazzanoFleetUpgrade{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: vmc.Namespace, Name: upgradeName}, &upgrade); err != nil {
+		if errors.IsNotFound(err) {
+			r.log.Progressf("Skipping the push of the fleet upgrade secret, VerrazzanoFleetUpgrade %s/%s not found", vmc.Namespace, upgradeName)
+			return false, nil
+		}
+		return false, err
+	}
+
+	upgradeSecret := corev1.Secret{}
+	upgradeSecret.Namespace = constants.VerrazzanoSystemNamespace
+	upgradeSecret.Name = constants.MCFleetUpgradeSecret
+	upgradeOperation, err := createOrUpdateSecretRancherProxy(&upgradeSecret, rc, clusterID, func() error {
+		upgradeSecret.Data = map[string][]byte{
+			mcconstants.FleetUpgradeVersionKey: []byte(upgrade.Spec.Version),
+		}
+		if len(upgrade.Spec.OperatorImage) > 0 {
+			upgradeSecret.Data[mcconstants.FleetUpgradeOperatorImageKey] = []byte(upgrade.Spec.OperatorImage)
+		}
+		return nil
+	}, r.log)
+	if err != nil {
+		return false, err
+	}
+
+	// Record the push of the secret, so that it is deleted once the upgrade of the managed cluster completes
+	if vmc.Annotations[clusterapi.FleetUpgradePushedAnnotation] != upgradeName {
+		vmc.Annotations[clusterapi.FleetUpgradePushedAnnotation] = upgradeName
+		if err := r.Update(context.TODO(), vmc); err != nil {
+			return false, err
+		}
+	}
+	return upgradeOperation != controllerutil.OperationResultNone, nil
+}
+
+// deleteFleetUpgradeSecret deletes the fleet upgrade secret from the managed cluster, if it was pushed to the managed
+// cluster, so that the agent does not act on a fleet upgrade that no longer targets the managed cluster
+func (r *VerrazzanoManagedClusterReconciler) deleteFleetUpgradeSecret(vmc *clusterapi.VerrazzanoManagedCluster, rc *rancherutil.RancherConfig, clusterID string) (bool, error) {
+	if _, pushed := vmc.Annotations[clusterapi.FleetUpgradePushedAnnotation]; !pushed {
+		return false, nil
+	}
+	upgradeSecret := corev1.Secret{}
+	upgradeSecret.Namespace = constants.VerrazzanoSystemNamespace
+	upgradeSecret.Name = constants.MCFleetUpgradeSecret
+	if err := rancherSecretDelete(&upgradeSecret, rc, clusterID, r.log); err != nil {
+		return false, err
+	}
+	delete(vmc.Annotations, clusterapi.FleetUpgradePushedAnnotation)
+	if err := r.Update(context.TODO(), vmc); err != nil {
+		return false, err
+	}
+	return true, nil
 }
@@ -5,6 +5,7 @@ package vmc
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"net/http"
 	"testing"
@@ -17,12 +18,17 @@ import (
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
 	"github.com/verrazzano/verrazzano/pkg/rancherutil"
 	"github.com/verrazzano/verrazzano/pkg/test/mockmatchers"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/mocks"
 	corev1 "k8s.io/api/core/v1"
 	networkv1 "k8s.io/api/networking/v1"
+	k8sapiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -108,8 +114,136 @@ func TestPushManifestObjects(t *testing.T) {
 	}
 }
 
-func generateClientObjects() client.WithWatch {
-	return fake.NewClientBuilder().WithRuntimeObjects(
+// TestPushFleetUpgradeObjects tests the push of the fleet upgrade secret to a managed cluster
+// GIVEN a call to push manifest objects
+//
+//	WHEN the VMC is annotated with a VerrazzanoFleetUpgrade that has an operator image
+//	THEN the fleet upgrade secret should get pushed to the managed cluster, along with the agent and registration secrets
+func TestPushFleetUpgradeObjects(t *testing.T) {
+	a := asserts.New(t)
+
+	upgrade := &v1alpha1.VerrazzanoFleetUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Namespace: rancherNamespace, Name: "upgrade"},
+		Spec: v1alpha1.VerrazzanoFleetUpgradeSpec{
+			Version:       "1.6.0",
+			OperatorImage: "ghcr.io/verrazzano/verrazzano-platform-operator:v1.6.0",
+		},
+	}
+
+	savedRancherHTTPClient := rancherutil.RancherHTTPClient
+	defer func() {
+		rancherutil.RancherHTTPClient = savedRancherHTTPClient
+	}()
+
+	savedRetry := rancherutil.DefaultRetry
+	defer func() {
+		rancherutil.DefaultRetry = savedRetry
+	}()
+	rancherutil.DefaultRetry = wait.Backoff{
+		Steps:    1,
+		Duration: 1 * time.Millisecond,
+		Factor:   1.0,
+		Jitter:   0.1,
+	}
+	defer rancherutil.DeleteStoredTokens()
+
+	vmc := &v1alpha1.VerrazzanoManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   rancherNamespace,
+			Name:        "cluster",
+			Annotations: map[string]string{v1alpha1.FleetUpgradeAnnotation: upgrade.Name},
+		},
+		Status: v1alpha1.VerrazzanoManagedClusterStatus{
+			RancherRegistration: v1alpha1.RancherRegistration{
+				ClusterID: "cluster-id",
+			},
+		},
+	}
+	c := generateClientObjects(upgrade, vmc)
+	r := &VerrazzanoManagedClusterReconciler{
+		Client: c,
+		log:    vzlog.DefaultLogger(),
+	}
+
+	mock := addActiveClusterMock(mocks.NewMockRequestSender(gomock.NewController(t)), a, vmc, r, vmc.Status.RancherRegistration.ClusterID)
+	upgradeSecret := &corev1.Secret{}
+	upgradeSecret.Namespace = constants.VerrazzanoSystemNamespace
+	upgradeSecret.Name = constants.MCFleetUpgradeSecret
+	rancherutil.RancherHTTPClient = addNotFoundMock(mock, upgradeSecret, vmc.Status.RancherRegistration.ClusterID)
+
+	updated, err := r.pushManifestObjects(vmc)
+	a.NoError(err)
+	a.True(updated)
+	a.Equal(upgrade.Name, vmc.Annotations[v1alpha1.FleetUpgradePushedAnnotation])
+}
+
+// TestDeleteFleetUpgradeObjects tests the deletion of the fleet upgrade secret
+// GIVEN a VMC whose upgrade by a VerrazzanoFleetUpgrade has completed
+//
+//	WHEN the manifest objects are pushed to the managed cluster
+//	THEN the fleet upgrade secret pushed to the managed cluster should get deleted
+func TestDeleteFleetUpgradeObjects(t *testing.T) {
+	a := asserts.New(t)
+
+	savedRancherHTTPClient := rancherutil.RancherHTTPClient
+	defer func() {
+		rancherutil.RancherHTTPClient = savedRancherHTTPClient
+	}()
+
+	savedRetry := rancherutil.DefaultRetry
+	defer func() {
+		rancherutil.DefaultRetry = savedRetry
+	}()
+	rancherutil.DefaultRetry = wait.Backoff{
+		Steps:    1,
+		Duration: 1 * time.Millisecond,
+		Factor:   1.0,
+		Jitter:   0.1,
+	}
+	defer rancherutil.DeleteStoredTokens()
+
+	vmc := &v1alpha1.VerrazzanoManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   rancherNamespace,
+			Name:        "cluster",
+			Annotations: map[string]string{v1alpha1.FleetUpgradePushedAnnotation: "upgrade"},
+		},
+		Status: v1alpha1.VerrazzanoManagedClusterStatus{
+			RancherRegistration: v1alpha1.RancherRegistration{
+				ClusterID: "cluster-id",
+			},
+		},
+	}
+	c := generateClientObjects(vmc)
+	r := &VerrazzanoManagedClusterReconciler{
+		Client: c,
+		log:    vzlog.DefaultLogger(),
+	}
+
+	mock := addActiveClusterMock(mocks.NewMockRequestSender(gomock.NewController(t)), a, vmc, r, vmc.Status.RancherRegistration.ClusterID)
+	upgradeSecret := &corev1.Secret{}
+	upgradeSecret.Namespace = constants.VerrazzanoSystemNamespace
+	upgradeSecret.Name = constants.MCFleetUpgradeSecret
+	mock.EXPECT().Do(gomock.Not(gomock.Nil()), mockmatchers.MatchesURIMethod(http.MethodDelete, getTestPath(upgradeSecret, vmc.Status.RancherRegistration.ClusterID, false))).
+		Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader([]byte("")))}, nil)
+	rancherutil.RancherHTTPClient = mock
+
+	updated, err := r.pushManifestObjects(vmc)
+	a.NoError(err)
+	a.True(updated)
+
+	// The push of the secret is no longer recorded, so that the secret is deleted only once
+	a.NoError(c.Get(context.TODO(), types.NamespacedName{Namespace: vmc.Namespace, Name: vmc.Name}, vmc))
+	a.NotContains(vmc.Annotations, v1alpha1.FleetUpgradePushedAnnotation)
+}
+
+func generateClientObjects(objs ...runtime.Object) client.WithWatch {
+	scheme := runtime.NewScheme()
+	_ = k8scheme.AddToScheme(scheme)
+	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+	_ = k8sapiext.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).WithRuntimeObjects(
 		&networkv1.Ingress{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: rancherNamespace,
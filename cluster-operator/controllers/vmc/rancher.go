@@ -562,6 +562,28 @@ func rancherSecretUpdate(secret *corev1.Secret, rc *rancherutil.RancherConfig, c
 	return nil
 }
 
+// rancherSecretDelete simulates a client delete request through the Rancher proxy for secrets.  A secret that is
+// not found is considered deleted.
+func rancherSecretDelete(secret *corev1.Secret, rc *rancherutil.RancherConfig, clusterID string, log vzlog.VerrazzanoLogger) error {
+	if secret == nil {
+		return log.ErrorNewErr("Failed to delete secret, nil value passed to delete request")
+	}
+	reqURL := constructSecretURL(secret, rc.Host, clusterID, false)
+	headers := map[string]string{"Authorization": "Bearer " + rc.APIAccessToken}
+	resp, _, err := rancherutil.SendRequest(http.MethodDelete, reqURL, headers, "", rc, log)
+	if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+		return err
+	}
+
+	if resp == nil {
+		return log.ErrorfNewErr("Failed to find response from DELETE request %s", reqURL)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNotFound {
+		return log.ErrorfNewErr("Failed to delete secret %s/%s from DELETE request %s with code %d", secret.GetNamespace(), secret.GetName(), reqURL, resp.StatusCode)
+	}
+	return nil
+}
+
 // constructSecretURL returns a formatted url string from path requirements and objects
 func constructSecretURL(secret *corev1.Secret, host, clusterID string, create bool) string {
 	if create {
@@ -16,7 +16,6 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	clustersv1alpha1 "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
-	vzconstants "github.com/verrazzano/verrazzano/pkg/constants"
 	vzctrl "github.com/verrazzano/verrazzano/pkg/controller"
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
 	"github.com/verrazzano/verrazzano/pkg/rancherutil"
@@ -30,6 +29,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -45,6 +45,7 @@ type VerrazzanoManagedClusterReconciler struct {
 	client.Client
 	Scheme             *runtime.Scheme
 	RancherIngressHost string
+	Recorder           record.EventRecorder
 	log                vzlog.VerrazzanoLogger
 }
 
@@ -186,6 +187,20 @@ func (r *VerrazzanoManagedClusterReconciler) doReconcile(ctx context.Context, lo
 		return newRequeueWithDelay(), err
 	}
 
+	log.Debugf("Syncing the detachment state for VMC %s", vmc.Name)
+	cleanedUp, err := r.syncDetachment(ctx, vmc)
+	if err != nil {
+		r.handleError(ctx, vmc, "Failed to clean up the state of the detached managed cluster", err, log)
+		return newRequeueWithDelay(), err
+	}
+	if cleanedUp {
+		// The state of the detached managed cluster has been cleaned up, so do not create it again until the agent reconnects
+		if err := r.updateStatus(ctx, vmc); err != nil {
+			log.Errorf("Failed to update status for VMC %s: %v", vmc.Name, err)
+		}
+		return ctrl.Result{Requeue: true, RequeueAfter: constants.ReconcileLoopRequeueInterval}, nil
+	}
+
 	log.Debugf("Syncing the Manifest secret for VMC %s", vmc.Name)
 	vzVMCWaitingForClusterID, err := r.syncManifestSecret(ctx, vmc)
 	if err != nil {
@@ -255,6 +270,7 @@ func (r *VerrazzanoManagedClusterReconciler) doReconcile(ctx context.Context, lo
 	}
 
 	r.setStatusConditionReady(vmc, "Ready")
+	r.setStatusConditionHealthy(vmc)
 	statusErr := r.updateStatus(ctx, vmc)
 
 	if statusErr != nil {
@@ -619,12 +635,8 @@ func (r *VerrazzanoManagedClusterReconciler) setStatusCondition(vmc *clustersv1a
 // updateStatus updates the status of the VMC in the cluster, with all provided conditions, after setting the vmc.Status.State field for the cluster
 func (r *VerrazzanoManagedClusterReconciler) updateStatus(ctx context.Context, vmc *clustersv1alpha1.VerrazzanoManagedCluster) error {
 	if vmc.Status.LastAgentConnectTime != nil {
-		currentTime := metav1.Now()
-		// Using the current plus added time to find the difference with lastAgentConnectTime to validate
-		// if it exceeds the max allowed time before changing the state of the vmc resource.
-		maxPollingTime := currentTime.Add(vzconstants.VMCAgentPollingTimeInterval * vzconstants.MaxTimesVMCAgentPollingTime)
-		timeDiff := maxPollingTime.Sub(vmc.Status.LastAgentConnectTime.Time)
-		if int(timeDiff.Minutes()) > vzconstants.MaxTimesVMCAgentPollingTime {
+		// The VMC is inactive once the agent has not connected within the inactivity threshold of the detachment policy
+		if isAgentInactive(vmc, time.Now()) {
 			vmc.Status.State = clustersv1alpha1.StateInactive
 		} else if vmc.Status.State == "" {
 			vmc.Status.State = clustersv1alpha1.StatePending
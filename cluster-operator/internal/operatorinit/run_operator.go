@@ -7,6 +7,7 @@ import (
 	"context"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/verrazzano/verrazzano/cluster-operator/controllers/fleetupgrade"
 	"github.com/verrazzano/verrazzano/cluster-operator/controllers/rancher"
 	"github.com/verrazzano/verrazzano/cluster-operator/controllers/vmc"
 	"github.com/verrazzano/verrazzano/pkg/k8sutil"
@@ -92,11 +93,21 @@ func StartClusterOperator(metricsAddr string, enableLeaderElection bool, probeAd
 		Client:             mgr.GetClient(),
 		Scheme:             mgr.GetScheme(),
 		RancherIngressHost: ingressHost,
+		Recorder:           mgr.GetEventRecorderFor("verrazzano-cluster-operator"),
 	}).SetupWithManager(mgr); err != nil {
 		log.Error(err, "Failed to setup controller VerrazzanoManagedCluster")
 		os.Exit(1)
 	}
 
+	// Set up the reconciler for VerrazzanoFleetUpgrade objects
+	if err = (&fleetupgrade.VerrazzanoFleetUpgradeReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		log.Error(err, "Failed to setup controller VerrazzanoFleetUpgrade")
+		os.Exit(1)
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		log.Error(err, "unable to set up health check")
 		os.Exit(1)
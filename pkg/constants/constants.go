@@ -61,6 +61,10 @@ const VerrazzanoMultiClusterNamespace = "verrazzano-mc"
 // VerrazzanoMonitoringNamespace is the namespace for monitoring components
 const VerrazzanoMonitoringNamespace = "verrazzano-monitoring"
 
+// PrometheusAdapterConfigMapName is the name of the ConfigMap of the configuration read by the Prometheus Adapter,
+// maintained by the application operator from the configuration of the Helm chart and the rules of the autoscaler traits
+const PrometheusAdapterConfigMapName = "verrazzano-prometheus-adapter"
+
 // CertManagerNamespace - the CertManager namespace
 const CertManagerNamespace = "cert-manager"
 
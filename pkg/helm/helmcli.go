@@ -165,6 +165,40 @@ func Upgrade(log vzlog.VerrazzanoLogger, releaseName string, namespace string, c
 	return rel, nil
 }
 
+// Template renders the manifests of the specified chart without contacting the cluster, like helm template.  The
+// override files array are in order with the first files in the array have lower precedence than latter files.
+func Template(log vzlog.VerrazzanoLogger, releaseName string, namespace string, chartDir string, overrides []HelmOverrides) (string, error) {
+	settings := cli.New()
+	settings.SetNamespace(namespace)
+
+	p := getter.All(settings)
+	vals, err := mergeValues(overrides, p)
+	if err != nil {
+		return "", err
+	}
+	// load chart from the path
+	chart, err := loadChartFn(chartDir)
+	if err != nil {
+		return "", err
+	}
+
+	log.Debugf("Rendering the Helm chart %s for release %s in namespace %s with overrides: %v", chartDir, releaseName, namespace, overrides)
+	client := action.NewInstall(&action.Configuration{})
+	client.Namespace = namespace
+	client.ReleaseName = releaseName
+	client.DryRun = true
+	client.ClientOnly = true
+	client.Replace = true
+	client.IncludeCRDs = true
+
+	rel, err := client.Run(chart, vals)
+	if err != nil {
+		log.Errorf("Failed rendering Helm chart %s for release %s: %v", chartDir, releaseName, err.Error())
+		return "", err
+	}
+	return rel.Manifest, nil
+}
+
 // Uninstall will uninstall the helmRelease in the specified namespace  using helm uninstall
 func Uninstall(log vzlog.VerrazzanoLogger, releaseName string, namespace string, dryRun bool) (err error) {
 	settings := cli.New()
@@ -146,6 +146,36 @@ func TestUpgradeFail(t *testing.T) {
 	assertion.Error(err, "Upgrade should have returned an error")
 }
 
+// TestTemplate tests rendering a Helm chart
+// GIVEN a chart and a set of overrides
+//
+//	WHEN I call Template
+//	THEN the rendered manifests are returned without contacting the cluster
+func TestTemplate(t *testing.T) {
+	assertion := assert.New(t)
+	SetLoadChartFunction(func(chartDir string) (*chart.Chart, error) {
+		c := getChart()
+		c.Templates = append(c.Templates, &chart.File{Name: "templates/name", Data: []byte("name: {{ .Values.name1 }}")})
+		return c, nil
+	})
+	defer SetDefaultLoadChartFunction()
+
+	manifest, err := Template(vzlog.DefaultLogger(), helmRelease, ns, chartdir, []HelmOverrides{{SetOverrides: "name1=modifiedValue1"}})
+	assertion.NoError(err, "Template returned an error")
+	assertion.Contains(manifest, "hello: world")
+	assertion.Contains(manifest, "name: modifiedValue1")
+}
+
+// TestTemplateFail tests the Helm template failure condition
+// GIVEN a chart which cannot be loaded
+//
+//	WHEN I call Template
+//	THEN an error is returned
+func TestTemplateFail(t *testing.T) {
+	_, err := Template(vzlog.DefaultLogger(), helmRelease, ns, "", nil)
+	assert.Error(t, err, "Template should have returned an error")
+}
+
 // TestUninstall tests the Helm Uninstall fn
 // GIVEN a call to Uninstall
 //
@@ -0,0 +1,162 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package vzchecks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/verrazzano/verrazzano/pkg/k8s/node"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	k8score "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ComponentRequirement is the aggregate of the resources requested by an enabled component, including all the replicas
+type ComponentRequirement struct {
+	// Name is the name of the component
+	Name string
+	// CPU is the CPU requested by the pods of the component
+	CPU resource.Quantity
+	// Memory is the memory requested by the pods of the component
+	Memory resource.Quantity
+	// Storage is the storage requested by the PersistentVolumeClaims of the component
+	Storage resource.Quantity
+}
+
+const (
+	componentCPUReqMsg     = "the enabled components request %v CPUs but the schedulable CPUs are %v, the components over the capacity are %s"
+	componentMemoryReqMsg  = "the enabled components request %sG of memory but the schedulable memory is %sG, the components over the capacity are %s"
+	componentStorageReqMsg = "the enabled components request %sG of persistent storage but the capacity of the default StorageClass %s is %sG, the components over the capacity are %s"
+)
+
+// GetComponentRequirements returns the requirements declared by the components enabled in the effective Verrazzano
+// resource, in the order the components are installed
+func GetComponentRequirements(vz *v1beta1.Verrazzano) []ComponentRequirement {
+	var reqs []ComponentRequirement
+	for _, comp := range registry.GetComponents() {
+		requirements, ok := comp.(spi.ComponentResourceRequirements)
+		if !ok || !comp.IsEnabled(vz) {
+			continue
+		}
+		r := requirements.GetResourceRequirements(vz)
+		reqs = append(reqs, ComponentRequirement{Name: comp.Name(), CPU: r.CPU, Memory: r.Memory, Storage: r.Storage})
+	}
+	return reqs
+}
+
+// ComponentRequirementsCheck sums the requirements of the components enabled in the effective Verrazzano resource,
+// and compares them against the CPU and memory of the schedulable nodes, and against the storage capacity of the
+// default StorageClass when its CSI driver publishes it.  The components which push the requests over the capacity
+// are reported.
+func ComponentRequirementsCheck(client clipkg.Client, vz *v1beta1.Verrazzano) []error {
+	nodeList, err := node.GetK8sNodeList(client)
+	if err != nil {
+		return []error{err}
+	}
+	var cpuCapacity, memoryCapacity resource.Quantity
+	for _, n := range nodeList.Items {
+		if !isSchedulable(n) {
+			continue
+		}
+		cpuCapacity.Add(n.Status.Allocatable[k8score.ResourceCPU])
+		memoryCapacity.Add(n.Status.Allocatable[k8score.ResourceMemory])
+	}
+
+	var errs []error
+	reqs := GetComponentRequirements(vz)
+	if total, over := overCapacity(reqs, cpuCapacity, func(r ComponentRequirement) resource.Quantity { return r.CPU }); len(over) > 0 {
+		errs = append(errs, fmt.Errorf(componentCPUReqMsg, total.AsDec().String(), cpuCapacity.AsDec().String(), strings.Join(over, ", ")))
+	}
+	if total, over := overCapacity(reqs, memoryCapacity, func(r ComponentRequirement) resource.Quantity { return r.Memory }); len(over) > 0 {
+		errs = append(errs, fmt.Errorf(componentMemoryReqMsg, convertQuantityToString(total), convertQuantityToString(memoryCapacity), strings.Join(over, ", ")))
+	}
+	storageClass, storageCapacity, err := getStorageCapacity(client)
+	if err != nil {
+		return append(errs, err)
+	}
+	if storageCapacity == nil {
+		return errs
+	}
+	if total, over := overCapacity(reqs, *storageCapacity, func(r ComponentRequirement) resource.Quantity { return r.Storage }); len(over) > 0 {
+		errs = append(errs, fmt.Errorf(componentStorageReqMsg, convertQuantityToString(total), storageClass, convertQuantityToString(*storageCapacity), strings.Join(over, ", ")))
+	}
+	return errs
+}
+
+// getStorageCapacity returns the default StorageClass and the capacity published by its CSI driver in the
+// CSIStorageCapacity objects.  The capacity is nil when there is no default StorageClass or its driver does not
+// publish the capacity, the storage requirements cannot be compared then.
+func getStorageCapacity(client clipkg.Client) (string, *resource.Quantity, error) {
+	storageClasses := &storagev1.StorageClassList{}
+	if err := client.List(context.TODO(), storageClasses); err != nil {
+		return "", nil, err
+	}
+	defaultClass := ""
+	for _, sc := range storageClasses.Items {
+		if sc.Annotations[defaultStorageClassAnnotation] == "true" || sc.Annotations[betaDefaultStorageClassAnnotation] == "true" {
+			defaultClass = sc.Name
+			break
+		}
+	}
+	if defaultClass == "" {
+		return "", nil, nil
+	}
+	capacities := &storagev1.CSIStorageCapacityList{}
+	if err := client.List(context.TODO(), capacities); err != nil {
+		if meta.IsNoMatchError(err) || errors.IsNotFound(err) || errors.IsForbidden(err) {
+			return defaultClass, nil, nil
+		}
+		return "", nil, err
+	}
+	var total *resource.Quantity
+	for _, c := range capacities.Items {
+		if c.StorageClassName != defaultClass || c.Capacity == nil {
+			continue
+		}
+		if total == nil {
+			total = &resource.Quantity{}
+		}
+		total.Add(*c.Capacity)
+	}
+	return defaultClass, total, nil
+}
+
+// overCapacity returns the total of the requests, and the components requesting the resource from the one where the
+// running total exceeds the capacity
+func overCapacity(reqs []ComponentRequirement, capacity resource.Quantity, request func(r ComponentRequirement) resource.Quantity) (resource.Quantity, []string) {
+	var total resource.Quantity
+	var over []string
+	for _, req := range reqs {
+		quantity := request(req)
+		if quantity.IsZero() {
+			continue
+		}
+		total.Add(quantity)
+		if total.Cmp(capacity) > 0 {
+			over = append(over, req.Name)
+		}
+	}
+	return total, over
+}
+
+// isSchedulable returns true when the pods of the components can be scheduled on the node
+func isSchedulable(n k8score.Node) bool {
+	if n.Spec.Unschedulable {
+		return false
+	}
+	for _, taint := range n.Spec.Taints {
+		if taint.Effect == k8score.TaintEffectNoSchedule || taint.Effect == k8score.TaintEffectNoExecute {
+			return false
+		}
+	}
+	return true
+}
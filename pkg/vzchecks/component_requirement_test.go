@@ -0,0 +1,178 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package vzchecks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	client2 "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestGetComponentRequirements tests getting the requirements of the enabled components
+// GIVEN a Verrazzano resource with OpenSearch disabled and Thanos enabled
+// WHEN the component requirements are computed
+// THEN the requirements of Thanos are included and the requirements of OpenSearch are not
+func TestGetComponentRequirements(t *testing.T) {
+	enabled := true
+	disabled := false
+	vz := &v1beta1.Verrazzano{Spec: v1beta1.VerrazzanoSpec{Components: v1beta1.ComponentSpec{
+		OpenSearch: &v1beta1.OpenSearchComponent{Enabled: &disabled},
+		Thanos:     &v1beta1.ThanosComponent{Enabled: &enabled},
+	}}}
+	names := map[string]bool{}
+	for _, req := range GetComponentRequirements(vz) {
+		names[req.Name] = true
+	}
+	assert.True(t, names["thanos"])
+	assert.True(t, names["keycloak"])
+	assert.False(t, names["opensearch"])
+}
+
+// TestOpenSearchRequirement tests aggregating the requests and storage of the OpenSearch node groups
+func TestOpenSearchRequirement(t *testing.T) {
+	three := int32(3)
+	one := int32(1)
+	vz := &v1beta1.Verrazzano{Spec: v1beta1.VerrazzanoSpec{Components: v1beta1.ComponentSpec{
+		OpenSearch: &v1beta1.OpenSearchComponent{Nodes: []v1beta1.OpenSearchNode{
+			{
+				Name:     "master",
+				Replicas: &three,
+				Resources: &v1.ResourceRequirements{Requests: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("1"),
+					v1.ResourceMemory: resource.MustParse("2Gi"),
+				}},
+				Storage: &v1beta1.OpenSearchNodeStorage{Size: "50Gi"},
+			},
+			{Name: "ingest", Replicas: &one},
+		}},
+	}}}
+	req := findRequirement(GetComponentRequirements(vz), "opensearch")
+	assert.NotNil(t, req)
+	assert.Equal(t, "3500m", req.CPU.String())
+	assert.Equal(t, quantityValue("6Gi")+quantityValue("1400Mi"), req.Memory.Value())
+	assert.Equal(t, quantityValue("150Gi"), req.Storage.Value())
+}
+
+// TestMySQLRequirement tests the storage of the Keycloak MySQL volume
+func TestMySQLRequirement(t *testing.T) {
+	vz := &v1beta1.Verrazzano{}
+	req := findRequirement(GetComponentRequirements(vz), "mysql")
+	assert.Equal(t, quantityValue("8Gi"), req.Storage.Value())
+
+	vz.Spec.DefaultVolumeSource = &v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}
+	req = findRequirement(GetComponentRequirements(vz), "mysql")
+	assert.True(t, req.Storage.IsZero())
+
+	vz.Spec.Components.Keycloak = &v1beta1.KeycloakComponent{MySQL: v1beta1.MySQLComponent{
+		VolumeSource: &v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "mysql"}},
+	}}
+	vz.Spec.VolumeClaimSpecTemplates = []v1beta1.VolumeClaimSpecTemplate{{
+		ObjectMeta: metav1.ObjectMeta{Name: "mysql"},
+		Spec: v1.PersistentVolumeClaimSpec{Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+			v1.ResourceStorage: resource.MustParse("20Gi"),
+		}}},
+	}}
+	req = findRequirement(GetComponentRequirements(vz), "mysql")
+	assert.Equal(t, quantityValue("20Gi"), req.Storage.Value())
+}
+
+// TestPrometheusRequirement tests the storage of the Prometheus volume
+func TestPrometheusRequirement(t *testing.T) {
+	enabled := true
+	vz := &v1beta1.Verrazzano{Spec: v1beta1.VerrazzanoSpec{Components: v1beta1.ComponentSpec{
+		Prometheus: &v1beta1.PrometheusComponent{Enabled: &enabled},
+	}}}
+	req := findRequirement(GetComponentRequirements(vz), "prometheus-operator")
+	assert.NotNil(t, req)
+	assert.Equal(t, quantityValue("50Gi"), req.Storage.Value())
+
+	vz.Spec.DefaultVolumeSource = &v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}
+	req = findRequirement(GetComponentRequirements(vz), "prometheus-operator")
+	assert.True(t, req.Storage.IsZero())
+}
+
+// TestComponentRequirementsCheck tests comparing the component requirements against the schedulable nodes
+// GIVEN a Verrazzano resource with the default components enabled
+// WHEN the schedulable nodes are too small, or large enough
+// THEN the components over the capacity are reported when the nodes are too small
+func TestComponentRequirementsCheck(t *testing.T) {
+	vz := &v1beta1.Verrazzano{}
+	small := []client2.Object{newCapacityNode("node1", "2", "4Gi", false)}
+	errs := ComponentRequirementsCheck(fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(small...).Build(), vz)
+	assert.Len(t, errs, 2)
+	assert.Contains(t, errs[0].Error(), "the schedulable CPUs are 2")
+	assert.Contains(t, errs[0].Error(), "opensearch")
+	assert.NotContains(t, errs[0].Error(), "cert-manager")
+	assert.Contains(t, errs[1].Error(), "the schedulable memory is 4.294967296G")
+
+	// The tainted control plane node is not schedulable
+	large := []client2.Object{newCapacityNode("node1", "8", "32Gi", false), newCapacityNode("node2", "8", "32Gi", true)}
+	errs = ComponentRequirementsCheck(fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(large...).Build(), vz)
+	assert.Len(t, errs, 0)
+	errs = ComponentRequirementsCheck(fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(large[1]).Build(), vz)
+	assert.Len(t, errs, 2)
+}
+
+// TestComponentStorageCheck tests comparing the component storage against the capacity of the default StorageClass
+// GIVEN a default StorageClass whose CSI driver publishes its capacity
+// WHEN the capacity is too small, or is not published
+// THEN the components over the capacity are reported when the capacity is too small
+func TestComponentStorageCheck(t *testing.T) {
+	vz := &v1beta1.Verrazzano{}
+	node := newCapacityNode("node1", "64", "256Gi", false)
+	sc := &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "standard",
+		Annotations: map[string]string{defaultStorageClassAnnotation: "true"}}}
+	capacity := resource.MustParse("1Gi")
+	csiCapacity := &storagev1.CSIStorageCapacity{ObjectMeta: metav1.ObjectMeta{Name: "standard-zone1", Namespace: "kube-system"},
+		StorageClassName: "standard", Capacity: &capacity}
+
+	errs := ComponentRequirementsCheck(fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(node, sc, csiCapacity).Build(), vz)
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "the capacity of the default StorageClass standard")
+	assert.Contains(t, errs[0].Error(), "mysql")
+
+	// The capacity is not compared when the CSI driver does not publish it
+	errs = ComponentRequirementsCheck(fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(node, sc).Build(), vz)
+	assert.Len(t, errs, 0)
+
+	capacity = resource.MustParse("1Ti")
+	errs = ComponentRequirementsCheck(fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(node, sc, csiCapacity).Build(), vz)
+	assert.Len(t, errs, 0)
+}
+
+func findRequirement(reqs []ComponentRequirement, name string) *ComponentRequirement {
+	for i := range reqs {
+		if reqs[i].Name == name {
+			return &reqs[i]
+		}
+	}
+	return nil
+}
+
+func newCapacityNode(name string, cpu string, memory string, tainted bool) *v1.Node {
+	n := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: v1.NodeStatus{Allocatable: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse(cpu),
+			v1.ResourceMemory: resource.MustParse(memory),
+		}},
+	}
+	if tainted {
+		n.Spec.Taints = []v1.Taint{{Key: "node-role.kubernetes.io/control-plane", Effect: v1.TaintEffectNoSchedule}}
+	}
+	return n
+}
+
+func quantityValue(s string) int64 {
+	q := resource.MustParse(s)
+	return q.Value()
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package vzchecks
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"k8s.io/client-go/kubernetes"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PreflightStatus is the status of a pre-flight check result
+type PreflightStatus string
+
+const (
+	// PreflightPass means the check passed
+	PreflightPass PreflightStatus = "pass"
+	// PreflightWarn means the check found an issue which may cause the install or upgrade to fail
+	PreflightWarn PreflightStatus = "warn"
+	// PreflightFail means the check found an issue which will cause the install or upgrade to fail
+	PreflightFail PreflightStatus = "fail"
+)
+
+// PreflightResult is the result of a pre-flight check
+type PreflightResult struct {
+	// Check is the name of the check
+	Check string `json:"check"`
+	// Status is the status of the result
+	Status PreflightStatus `json:"status"`
+	// Message describes the result
+	Message string `json:"message"`
+	// Remediation describes how to fix the issue, it is empty when the check passed
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// String formats the result as a single line
+func (r PreflightResult) String() string {
+	if r.Remediation == "" {
+		return fmt.Sprintf("[%s] %s: %s", r.Status, r.Check, r.Message)
+	}
+	return fmt.Sprintf("[%s] %s: %s. %s", r.Status, r.Check, r.Message, r.Remediation)
+}
+
+// PreflightContext holds the cluster clients and the Verrazzano settings used by the pre-flight checks
+type PreflightContext struct {
+	// Client is the controller runtime client of the cluster
+	Client clipkg.Client
+	// KubeClient is the Kubernetes client of the cluster, used to get the Kubernetes version
+	KubeClient kubernetes.Interface
+	// HTTPClient is used to check the image registry reachability
+	HTTPClient *http.Client
+	// Profile is the profile of the Verrazzano resource
+	Profile ProfileType
+	// Verrazzano is the effective Verrazzano resource, merged with the profiles, used to compute the requirements of
+	// the enabled components.  The component requirements are not checked when nil.
+	Verrazzano *v1beta1.Verrazzano
+	// IngressType is the type of the ingress service of the Verrazzano resource, LoadBalancer when empty
+	IngressType string
+	// ImageRegistry is the registry the Verrazzano images are pulled from, the public registry when empty
+	ImageRegistry string
+	// SupportedKubernetesVersions are the Kubernetes versions supported by Verrazzano, from the BOM.
+	// All the versions are assumed to be supported when empty.
+	SupportedKubernetesVersions []string
+	// Installed is true when Verrazzano is already installed in the cluster, the checks of conflicting
+	// installations are skipped in that case
+	Installed bool
+	// LocalOnly is true when the checks must not reach the network outside of the cluster, like in the admission
+	// webhooks.  The image registry is not checked in that case, it is checked by vz install and vz upgrade.
+	LocalOnly bool
+}
+
+// PreflightCheck is a check of the cluster, done before Verrazzano is installed or updated
+type PreflightCheck interface {
+	// Name returns the name of the check
+	Name() string
+	// Check checks the cluster, returning one result per issue found, or a single result when the check passed
+	Check(ctx PreflightContext) []PreflightResult
+}
+
+// preflightCheckFunc implements PreflightCheck with a function
+type preflightCheckFunc struct {
+	name  string
+	check func(name string, ctx PreflightContext) []PreflightResult
+}
+
+func (c preflightCheckFunc) Name() string {
+	return c.name
+}
+
+func (c preflightCheckFunc) Check(ctx PreflightContext) []PreflightResult {
+	return c.check(c.name, ctx)
+}
+
+// NewPreflightCheck returns a PreflightCheck running the check function
+func NewPreflightCheck(name string, check func(name string, ctx PreflightContext) []PreflightResult) PreflightCheck {
+	return preflightCheckFunc{name: name, check: check}
+}
+
+var (
+	preflightChecksLock sync.RWMutex
+	preflightChecks     = defaultPreflightChecks()
+)
+
+// RegisterPreflightCheck adds a check to the pre-flight checks, a check with the same name is replaced
+func RegisterPreflightCheck(check PreflightCheck) {
+	preflightChecksLock.Lock()
+	defer preflightChecksLock.Unlock()
+	for i := range preflightChecks {
+		if preflightChecks[i].Name() == check.Name() {
+			preflightChecks[i] = check
+			return
+		}
+	}
+	preflightChecks = append(preflightChecks, check)
+}
+
+// ResetPreflightChecks restores the default pre-flight checks
+func ResetPreflightChecks() {
+	preflightChecksLock.Lock()
+	defer preflightChecksLock.Unlock()
+	preflightChecks = defaultPreflightChecks()
+}
+
+// RunPreflightChecks runs the registered pre-flight checks in parallel and returns their results, in the order of
+// registration
+func RunPreflightChecks(ctx PreflightContext) []PreflightResult {
+	preflightChecksLock.RLock()
+	checks := append([]PreflightCheck{}, preflightChecks...)
+	preflightChecksLock.RUnlock()
+
+	checkResults := make([][]PreflightResult, len(checks))
+	wg := &sync.WaitGroup{}
+	wg.Add(len(checks))
+	for i := range checks {
+		go func(i int) {
+			defer wg.Done()
+			checkResults[i] = checks[i].Check(ctx)
+		}(i)
+	}
+	wg.Wait()
+
+	var results []PreflightResult
+	for _, checkResult := range checkResults {
+		results = append(results, checkResult...)
+	}
+	return results
+}
+
+// HasPreflightFailure returns true when one of the results is a failure
+func HasPreflightFailure(results []PreflightResult) bool {
+	for _, result := range results {
+		if result.Status == PreflightFail {
+			return true
+		}
+	}
+	return false
+}
+
+func passed(name string, message string) PreflightResult {
+	return PreflightResult{Check: name, Status: PreflightPass, Message: message}
+}
+
+func warned(name string, message string, remediation string) PreflightResult {
+	return PreflightResult{Check: name, Status: PreflightWarn, Message: message, Remediation: remediation}
+}
+
+func failed(name string, message string, remediation string) PreflightResult {
+	return PreflightResult{Check: name, Status: PreflightFail, Message: message, Remediation: remediation}
+}
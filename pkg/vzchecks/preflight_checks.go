@@ -0,0 +1,330 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package vzchecks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/k8s/node"
+	"github.com/verrazzano/verrazzano/pkg/semver"
+	appsv1 "k8s.io/api/apps/v1"
+	k8score "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	NodeResourcesCheck      = "node-resources"
+	ComponentResourcesCheck = "component-resources"
+	StorageClassCheck       = "storage-class"
+	LoadBalancerCheck       = "load-balancer"
+	ClusterDNSCheck         = "cluster-dns"
+	KubernetesVersionCheck  = "kubernetes-version"
+	CertManagerCheck        = "cert-manager-conflict"
+	IstioCheck              = "istio-conflict"
+	PodSecurityCheck        = "pod-security"
+	ImageRegistryCheck      = "image-registry"
+)
+
+const (
+	defaultImageRegistry              = "ghcr.io"
+	registryTimeout                   = 10 * time.Second
+	defaultStorageClassAnnotation     = "storageclass.kubernetes.io/is-default-class"
+	betaDefaultStorageClassAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
+	podSecurityEnforceLabel           = "pod-security.kubernetes.io/enforce"
+	kubeDNSService                    = "kube-dns"
+	kubeSystemNamespace               = "kube-system"
+	nodePortIngressType               = "NodePort"
+)
+
+// verrazzanoNamespaces are the namespaces where Verrazzano installs privileged workloads
+var verrazzanoNamespaces = []string{
+	constants.VerrazzanoInstallNamespace,
+	constants.VerrazzanoSystemNamespace,
+	constants.VerrazzanoMonitoringNamespace,
+	constants.CertManagerNamespace,
+	constants.IstioSystemNamespace,
+	constants.KeycloakNamespace,
+	constants.MySQLOperatorNamespace,
+	constants.RancherSystemNamespace,
+	"ingress-nginx",
+}
+
+// defaultPreflightChecks returns the pre-flight checks run by default
+func defaultPreflightChecks() []PreflightCheck {
+	return []PreflightCheck{
+		NewPreflightCheck(NodeResourcesCheck, checkNodeResources),
+		NewPreflightCheck(ComponentResourcesCheck, checkComponentResources),
+		NewPreflightCheck(StorageClassCheck, checkStorageClass),
+		NewPreflightCheck(LoadBalancerCheck, checkLoadBalancer),
+		NewPreflightCheck(ClusterDNSCheck, checkClusterDNS),
+		NewPreflightCheck(KubernetesVersionCheck, checkKubernetesVersion),
+		NewPreflightCheck(CertManagerCheck, checkCertManagerConflict),
+		NewPreflightCheck(IstioCheck, checkIstioConflict),
+		NewPreflightCheck(PodSecurityCheck, checkPodSecurity),
+		NewPreflightCheck(ImageRegistryCheck, checkImageRegistry),
+	}
+}
+
+// checkNodeResources checks the node count, CPU, memory and ephemeral storage against the profile requirements
+func checkNodeResources(name string, ctx PreflightContext) []PreflightResult {
+	errs := preCheck(ctx.Client, ctx.Profile)
+	if len(errs) == 0 {
+		return []PreflightResult{passed(name, fmt.Sprintf("The nodes meet the requirements of the %s profile", profileName(ctx.Profile)))}
+	}
+	var results []PreflightResult
+	for _, err := range errs {
+		results = append(results, failed(name, err.Error(),
+			"Add worker nodes or increase the resources of the worker nodes, or use a profile with lower requirements"))
+	}
+	return results
+}
+
+// checkComponentResources checks the CPU and memory requested by the enabled components against the schedulable nodes,
+// and the persistent storage against the capacity of the default StorageClass
+func checkComponentResources(name string, ctx PreflightContext) []PreflightResult {
+	if ctx.Verrazzano == nil {
+		return []PreflightResult{passed(name, "The Verrazzano resource is not specified, the requirements of the components are not checked")}
+	}
+	errs := ComponentRequirementsCheck(ctx.Client, ctx.Verrazzano)
+	if len(errs) == 0 {
+		var cpu, memory, storage resource.Quantity
+		for _, req := range GetComponentRequirements(ctx.Verrazzano) {
+			cpu.Add(req.CPU)
+			memory.Add(req.Memory)
+			storage.Add(req.Storage)
+		}
+		return []PreflightResult{passed(name, fmt.Sprintf("The enabled components request %v CPUs, %sG of memory and %sG of persistent storage, within the capacity of the cluster",
+			cpu.AsDec().String(), convertQuantityToString(memory), convertQuantityToString(storage)))}
+	}
+	var results []PreflightResult
+	for _, err := range errs {
+		results = append(results, failed(name, err.Error(),
+			"Add worker nodes or storage, or disable the components over the capacity in the Verrazzano resource"))
+	}
+	return results
+}
+
+// checkStorageClass checks that there is a single default StorageClass, which waits for the first consumer to bind volumes
+func checkStorageClass(name string, ctx PreflightContext) []PreflightResult {
+	storageClasses := &storagev1.StorageClassList{}
+	if err := ctx.Client.List(context.TODO(), storageClasses); err != nil {
+		return []PreflightResult{warned(name, fmt.Sprintf("Unable to list the StorageClasses: %v", err), "")}
+	}
+	var defaults []storagev1.StorageClass
+	for _, sc := range storageClasses.Items {
+		if sc.Annotations[defaultStorageClassAnnotation] == "true" || sc.Annotations[betaDefaultStorageClassAnnotation] == "true" {
+			defaults = append(defaults, sc)
+		}
+	}
+	if len(defaults) == 0 {
+		remediation := fmt.Sprintf("Set the annotation %s=true on a StorageClass, or configure the volume source of the components in the Verrazzano resource", defaultStorageClassAnnotation)
+		if ctx.Profile == Dev {
+			return []PreflightResult{warned(name, "There is no default StorageClass", remediation)}
+		}
+		return []PreflightResult{failed(name, "There is no default StorageClass", remediation)}
+	}
+	if len(defaults) > 1 {
+		var names []string
+		for _, sc := range defaults {
+			names = append(names, sc.Name)
+		}
+		return []PreflightResult{warned(name, fmt.Sprintf("There are multiple default StorageClasses: %s", strings.Join(names, ", ")),
+			fmt.Sprintf("Remove the annotation %s from all but one StorageClass", defaultStorageClassAnnotation))}
+	}
+	sc := defaults[0]
+	if sc.VolumeBindingMode == nil || *sc.VolumeBindingMode == storagev1.VolumeBindingImmediate {
+		return []PreflightResult{warned(name, fmt.Sprintf("The default StorageClass %s binds volumes immediately", sc.Name),
+			fmt.Sprintf("Use the volume binding mode %s, so the volumes are provisioned in the zone where the pods are scheduled", storagev1.VolumeBindingWaitForFirstConsumer))}
+	}
+	return []PreflightResult{passed(name, fmt.Sprintf("The default StorageClass is %s", sc.Name))}
+}
+
+// checkLoadBalancer checks that the cluster can provision LoadBalancer services, unless the ingress uses a NodePort
+func checkLoadBalancer(name string, ctx PreflightContext) []PreflightResult {
+	if ctx.IngressType == nodePortIngressType {
+		return []PreflightResult{passed(name, "The ingress uses a NodePort service")}
+	}
+	services := &k8score.ServiceList{}
+	if err := ctx.Client.List(context.TODO(), services); err != nil {
+		return []PreflightResult{warned(name, fmt.Sprintf("Unable to list the services: %v", err), "")}
+	}
+	var pending []string
+	for _, svc := range services.Items {
+		if svc.Spec.Type != k8score.ServiceTypeLoadBalancer {
+			continue
+		}
+		if len(svc.Status.LoadBalancer.Ingress) > 0 {
+			return []PreflightResult{passed(name, fmt.Sprintf("The LoadBalancer service %s/%s has an external address", svc.Namespace, svc.Name))}
+		}
+		pending = append(pending, svc.Namespace+"/"+svc.Name)
+	}
+	remediation := "Install a load balancer controller in the cluster, or set the ingress type to NodePort in the Verrazzano resource"
+	if len(pending) > 0 {
+		return []PreflightResult{warned(name, fmt.Sprintf("The LoadBalancer services %s do not have an external address", strings.Join(pending, ", ")), remediation)}
+	}
+	// Without LoadBalancer services to look at, rely on the nodes being managed by a cloud provider
+	nodeList, err := node.GetK8sNodeList(ctx.Client)
+	if err != nil {
+		return []PreflightResult{warned(name, fmt.Sprintf("Unable to list the nodes: %v", err), "")}
+	}
+	for _, n := range nodeList.Items {
+		if n.Spec.ProviderID == "" || strings.HasPrefix(n.Spec.ProviderID, "kind://") {
+			return []PreflightResult{warned(name, "Unable to verify that the cluster can provision LoadBalancer services, the nodes are not managed by a cloud provider", remediation)}
+		}
+	}
+	return []PreflightResult{passed(name, "The nodes are managed by a cloud provider")}
+}
+
+// checkClusterDNS checks that the cluster DNS service has ready endpoints
+func checkClusterDNS(name string, ctx PreflightContext) []PreflightResult {
+	endpoints := &k8score.Endpoints{}
+	err := ctx.Client.Get(context.TODO(), types.NamespacedName{Namespace: kubeSystemNamespace, Name: kubeDNSService}, endpoints)
+	if apierrors.IsNotFound(err) {
+		return []PreflightResult{warned(name, fmt.Sprintf("The cluster DNS service %s/%s was not found", kubeSystemNamespace, kubeDNSService),
+			"Verify that a cluster DNS service, such as CoreDNS, is installed and running")}
+	}
+	if err != nil {
+		return []PreflightResult{warned(name, fmt.Sprintf("Unable to get the cluster DNS endpoints: %v", err), "")}
+	}
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return []PreflightResult{passed(name, "The cluster DNS service has ready endpoints")}
+		}
+	}
+	return []PreflightResult{failed(name, fmt.Sprintf("The cluster DNS service %s/%s does not have any ready endpoints", kubeSystemNamespace, kubeDNSService),
+		"Verify that the cluster DNS pods in the kube-system namespace are running and ready")}
+}
+
+// checkKubernetesVersion checks the Kubernetes version against the versions supported by Verrazzano
+func checkKubernetesVersion(name string, ctx PreflightContext) []PreflightResult {
+	if len(ctx.SupportedKubernetesVersions) == 0 {
+		return []PreflightResult{passed(name, "The supported Kubernetes versions are not specified, all versions are assumed to be supported")}
+	}
+	if ctx.KubeClient == nil {
+		return []PreflightResult{warned(name, "Unable to get the Kubernetes version", "")}
+	}
+	versionInfo, err := ctx.KubeClient.Discovery().ServerVersion()
+	if err != nil {
+		return []PreflightResult{warned(name, fmt.Sprintf("Unable to get the Kubernetes version: %v", err), "")}
+	}
+	kubernetesVersion, err := semver.NewSemVersion(versionInfo.String())
+	if err != nil {
+		return []PreflightResult{warned(name, fmt.Sprintf("Invalid Kubernetes version %s: %v", versionInfo.String(), err), "")}
+	}
+	for _, supportedVersion := range ctx.SupportedKubernetesVersions {
+		version, err := semver.NewSemVersion(supportedVersion)
+		if err != nil {
+			continue
+		}
+		if kubernetesVersion.IsEqualToOrPatchVersionOf(version) {
+			return []PreflightResult{passed(name, fmt.Sprintf("The Kubernetes version %s is supported", kubernetesVersion.ToString()))}
+		}
+	}
+	return []PreflightResult{failed(name, fmt.Sprintf("The Kubernetes version %s is not supported, the supported versions are %s",
+		kubernetesVersion.ToString(), strings.Join(ctx.SupportedKubernetesVersions, ", ")),
+		"Upgrade the cluster to a supported Kubernetes version")}
+}
+
+// checkCertManagerConflict checks that cert-manager is not already installed, when installing Verrazzano
+func checkCertManagerConflict(name string, ctx PreflightContext) []PreflightResult {
+	return checkConflict(name, ctx, "cert-manager", clipkg.MatchingLabels{"app.kubernetes.io/name": "cert-manager"},
+		"Uninstall the existing cert-manager, or disable the Verrazzano cert-manager component and configure Verrazzano to use the existing installation")
+}
+
+// checkIstioConflict checks that Istio is not already installed, when installing Verrazzano.  The istiod deployments
+// of the Istio charts and operator are labelled with the revision and istio=pilot, a workload only labelled
+// app=istiod is not an Istio control plane.
+func checkIstioConflict(name string, ctx PreflightContext) []PreflightResult {
+	return checkConflict(name, ctx, "Istio", clipkg.MatchingLabels{"app": "istiod", "istio": "pilot"},
+		"Uninstall the existing Istio, Verrazzano installs and manages its own Istio control plane")
+}
+
+// checkConflict checks that there are no deployments matching the labels, when installing Verrazzano
+func checkConflict(name string, ctx PreflightContext, product string, labels clipkg.MatchingLabels, remediation string) []PreflightResult {
+	if ctx.Installed {
+		return []PreflightResult{passed(name, fmt.Sprintf("Verrazzano is installed, %s is managed by Verrazzano", product))}
+	}
+	deployments := &appsv1.DeploymentList{}
+	if err := ctx.Client.List(context.TODO(), deployments, labels); err != nil {
+		return []PreflightResult{warned(name, fmt.Sprintf("Unable to list the %s deployments: %v", product, err), "")}
+	}
+	if len(deployments.Items) == 0 {
+		return []PreflightResult{passed(name, fmt.Sprintf("%s is not installed", product))}
+	}
+	var results []PreflightResult
+	for _, deployment := range deployments.Items {
+		results = append(results, failed(name, fmt.Sprintf("An existing %s installation was found, deployment %s/%s", product, deployment.Namespace, deployment.Name), remediation))
+	}
+	return results
+}
+
+// checkPodSecurity checks that the PodSecurity admission does not restrict the Verrazzano namespaces
+func checkPodSecurity(name string, ctx PreflightContext) []PreflightResult {
+	var results []PreflightResult
+	for _, nsName := range verrazzanoNamespaces {
+		ns := &k8score.Namespace{}
+		err := ctx.Client.Get(context.TODO(), types.NamespacedName{Name: nsName}, ns)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			results = append(results, warned(name, fmt.Sprintf("Unable to get the namespace %s: %v", nsName, err), ""))
+			continue
+		}
+		if level := ns.Labels[podSecurityEnforceLabel]; level != "" && level != "privileged" {
+			results = append(results, failed(name, fmt.Sprintf("The namespace %s enforces the %s PodSecurity level", nsName, level),
+				fmt.Sprintf("Remove the label %s from the namespace, or set it to privileged", podSecurityEnforceLabel)))
+		}
+	}
+	if len(results) == 0 {
+		return []PreflightResult{passed(name, "The PodSecurity admission does not restrict the Verrazzano namespaces")}
+	}
+	return results
+}
+
+// checkImageRegistry checks that the image registry is reachable, from where the checks are run
+func checkImageRegistry(name string, ctx PreflightContext) []PreflightResult {
+	registry := ctx.ImageRegistry
+	if registry == "" {
+		registry = defaultImageRegistry
+	}
+	if ctx.LocalOnly {
+		return []PreflightResult{passed(name, fmt.Sprintf("The image registry %s is only checked by vz install and vz upgrade", registry))}
+	}
+	httpClient := ctx.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: registryTimeout}
+	}
+	url := registry
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		url = "https://" + url
+	}
+	resp, err := httpClient.Get(strings.TrimSuffix(url, "/") + "/v2/")
+	remediation := "Verify the network access and the proxy settings of the cluster, or use a private registry that is reachable from the cluster"
+	if err != nil {
+		return []PreflightResult{warned(name, fmt.Sprintf("The image registry %s is not reachable: %v", registry, err), remediation)}
+	}
+	defer resp.Body.Close()
+	// The registry API returns 401 to anonymous requests when authentication is required
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return []PreflightResult{warned(name, fmt.Sprintf("The image registry %s returned the status %d", registry, resp.StatusCode), remediation)}
+	}
+	return []PreflightResult{passed(name, fmt.Sprintf("The image registry %s is reachable", registry))}
+}
+
+func profileName(profile ProfileType) string {
+	if profile == "" {
+		return string(Prod)
+	}
+	return string(profile)
+}
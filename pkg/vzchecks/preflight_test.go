@@ -0,0 +1,248 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package vzchecks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	client2 "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestRunPreflightChecks tests running the registered pre-flight checks
+// GIVEN a custom check registered in addition to the default checks
+// WHEN the pre-flight checks are run
+// THEN the results of the default checks and of the custom check are returned
+func TestRunPreflightChecks(t *testing.T) {
+	defer ResetPreflightChecks()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	RegisterPreflightCheck(NewPreflightCheck("custom", func(name string, ctx PreflightContext) []PreflightResult {
+		return []PreflightResult{failed(name, "custom failure", "fix it")}
+	}))
+	ctx := PreflightContext{
+		Client:        fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build(),
+		HTTPClient:    server.Client(),
+		Profile:       Dev,
+		ImageRegistry: server.URL,
+	}
+	results := RunPreflightChecks(ctx)
+	checks := map[string]bool{}
+	for _, result := range results {
+		checks[result.Check] = true
+	}
+	for _, check := range defaultPreflightChecks() {
+		assert.True(t, checks[check.Name()], "Expected a result for the check %s", check.Name())
+	}
+	assert.True(t, checks["custom"])
+	assert.True(t, HasPreflightFailure(results))
+	assert.Equal(t, "[fail] custom: custom failure. fix it", results[len(results)-1].String())
+
+	// Registering a check with the same name replaces it
+	RegisterPreflightCheck(NewPreflightCheck("custom", func(name string, ctx PreflightContext) []PreflightResult {
+		return []PreflightResult{passed(name, "custom success")}
+	}))
+	results = RunPreflightChecks(ctx)
+	assert.Equal(t, "[pass] custom: custom success", results[len(results)-1].String())
+}
+
+// TestCheckStorageClass tests the StorageClass check
+func TestCheckStorageClass(t *testing.T) {
+	immediate := storagev1.VolumeBindingImmediate
+	waitForConsumer := storagev1.VolumeBindingWaitForFirstConsumer
+	var tests = []struct {
+		name    string
+		profile ProfileType
+		objects []client2.Object
+		status  PreflightStatus
+	}{
+		{"no default prod", Prod, []client2.Object{newStorageClass("sc", false, &waitForConsumer)}, PreflightFail},
+		{"no default dev", Dev, nil, PreflightWarn},
+		{"multiple defaults", Prod, []client2.Object{newStorageClass("sc1", true, &waitForConsumer), newStorageClass("sc2", true, &waitForConsumer)}, PreflightWarn},
+		{"immediate binding", Prod, []client2.Object{newStorageClass("sc", true, &immediate)}, PreflightWarn},
+		{"default", Prod, []client2.Object{newStorageClass("sc", true, &waitForConsumer)}, PreflightPass},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := PreflightContext{Client: fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(tt.objects...).Build(), Profile: tt.profile}
+			results := checkStorageClass(StorageClassCheck, ctx)
+			assert.Len(t, results, 1)
+			assert.Equal(t, tt.status, results[0].Status)
+		})
+	}
+}
+
+// TestCheckLoadBalancer tests the load balancer check
+func TestCheckLoadBalancer(t *testing.T) {
+	var tests = []struct {
+		name        string
+		ingressType string
+		objects     []client2.Object
+		status      PreflightStatus
+	}{
+		{"node port", nodePortIngressType, nil, PreflightPass},
+		{"load balancer ready", "", []client2.Object{newLoadBalancer(true)}, PreflightPass},
+		{"load balancer pending", "", []client2.Object{newLoadBalancer(false)}, PreflightWarn},
+		{"kind node", "", []client2.Object{newNode("kind://docker/kind/kind-control-plane")}, PreflightWarn},
+		{"cloud node", "", []client2.Object{newNode("ocid1.instance.oc1.phx.test")}, PreflightPass},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := PreflightContext{Client: fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(tt.objects...).Build(), IngressType: tt.ingressType}
+			results := checkLoadBalancer(LoadBalancerCheck, ctx)
+			assert.Len(t, results, 1)
+			assert.Equal(t, tt.status, results[0].Status)
+		})
+	}
+}
+
+// TestCheckClusterDNS tests the cluster DNS check
+func TestCheckClusterDNS(t *testing.T) {
+	notReady := &v1.Endpoints{ObjectMeta: metav1.ObjectMeta{Namespace: kubeSystemNamespace, Name: kubeDNSService},
+		Subsets: []v1.EndpointSubset{{NotReadyAddresses: []v1.EndpointAddress{{IP: "10.0.0.1"}}}}}
+	ready := &v1.Endpoints{ObjectMeta: metav1.ObjectMeta{Namespace: kubeSystemNamespace, Name: kubeDNSService},
+		Subsets: []v1.EndpointSubset{{Addresses: []v1.EndpointAddress{{IP: "10.0.0.1"}}}}}
+	var tests = []struct {
+		name    string
+		objects []client2.Object
+		status  PreflightStatus
+	}{
+		{"missing", nil, PreflightWarn},
+		{"not ready", []client2.Object{notReady}, PreflightFail},
+		{"ready", []client2.Object{ready}, PreflightPass},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := PreflightContext{Client: fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(tt.objects...).Build()}
+			results := checkClusterDNS(ClusterDNSCheck, ctx)
+			assert.Len(t, results, 1)
+			assert.Equal(t, tt.status, results[0].Status)
+		})
+	}
+}
+
+// TestCheckKubernetesVersion tests the Kubernetes version check
+func TestCheckKubernetesVersion(t *testing.T) {
+	var tests = []struct {
+		name      string
+		version   string
+		supported []string
+		status    PreflightStatus
+	}{
+		{"no supported versions", "v1.25.4", nil, PreflightPass},
+		{"supported", "v1.25.4", []string{"v1.24.0", "v1.25.0"}, PreflightPass},
+		{"not supported", "v1.21.4", []string{"v1.24.0", "v1.25.0"}, PreflightFail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeClient := k8sfake.NewSimpleClientset()
+			kubeClient.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: tt.version}
+			ctx := PreflightContext{KubeClient: kubeClient, SupportedKubernetesVersions: tt.supported}
+			results := checkKubernetesVersion(KubernetesVersionCheck, ctx)
+			assert.Len(t, results, 1)
+			assert.Equal(t, tt.status, results[0].Status)
+		})
+	}
+}
+
+// TestCheckConflicts tests the checks of existing cert-manager and Istio installations
+// GIVEN existing cert-manager and Istio deployments
+// WHEN the conflict checks are run
+// THEN the checks fail when Verrazzano is not installed, and pass when it is installed
+func TestCheckConflicts(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(
+		newDeployment("cert-manager", "cert-manager", map[string]string{"app.kubernetes.io/name": "cert-manager"}),
+		newDeployment("istio-system", "istiod", map[string]string{"app": "istiod", "istio": "pilot"})).Build()
+
+	results := checkCertManagerConflict(CertManagerCheck, PreflightContext{Client: c})
+	assert.Len(t, results, 1)
+	assert.Equal(t, PreflightFail, results[0].Status)
+	results = checkIstioConflict(IstioCheck, PreflightContext{Client: c})
+	assert.Len(t, results, 1)
+	assert.Equal(t, PreflightFail, results[0].Status)
+
+	results = checkCertManagerConflict(CertManagerCheck, PreflightContext{Client: c, Installed: true})
+	assert.Equal(t, PreflightPass, results[0].Status)
+	results = checkIstioConflict(IstioCheck, PreflightContext{Client: fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build()})
+	assert.Equal(t, PreflightPass, results[0].Status)
+
+	// A workload which is only labelled app=istiod is not an Istio control plane
+	c = fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(
+		newDeployment("tenant", "istiod", map[string]string{"app": "istiod"})).Build()
+	results = checkIstioConflict(IstioCheck, PreflightContext{Client: c})
+	assert.Equal(t, PreflightPass, results[0].Status)
+}
+
+// TestCheckPodSecurity tests the PodSecurity check
+// GIVEN a Verrazzano namespace enforcing the restricted PodSecurity level
+// WHEN the PodSecurity check is run
+// THEN the check fails for that namespace only
+func TestCheckPodSecurity(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "verrazzano-system", Labels: map[string]string{podSecurityEnforceLabel: "restricted"}}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "cert-manager", Labels: map[string]string{podSecurityEnforceLabel: "privileged"}}}).Build()
+	results := checkPodSecurity(PodSecurityCheck, PreflightContext{Client: c})
+	assert.Len(t, results, 1)
+	assert.Equal(t, PreflightFail, results[0].Status)
+	assert.Contains(t, results[0].Message, "verrazzano-system")
+}
+
+// TestCheckImageRegistry tests the image registry check
+func TestCheckImageRegistry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/", r.URL.Path)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	results := checkImageRegistry(ImageRegistryCheck, PreflightContext{HTTPClient: server.Client(), ImageRegistry: server.URL})
+	assert.Equal(t, PreflightPass, results[0].Status)
+
+	server.Close()
+	results = checkImageRegistry(ImageRegistryCheck, PreflightContext{HTTPClient: server.Client(), ImageRegistry: server.URL})
+	assert.Equal(t, PreflightWarn, results[0].Status)
+
+	// The registry is not reached when the checks are local
+	results = checkImageRegistry(ImageRegistryCheck, PreflightContext{HTTPClient: server.Client(), ImageRegistry: server.URL, LocalOnly: true})
+	assert.Equal(t, PreflightPass, results[0].Status)
+}
+
+func newStorageClass(name string, isDefault bool, bindingMode *storagev1.VolumeBindingMode) *storagev1.StorageClass {
+	sc := &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: name}, VolumeBindingMode: bindingMode}
+	if isDefault {
+		sc.Annotations = map[string]string{defaultStorageClassAnnotation: "true"}
+	}
+	return sc
+}
+
+func newLoadBalancer(ready bool) *v1.Service {
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "lb"},
+		Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+	}
+	if ready {
+		svc.Status.LoadBalancer.Ingress = []v1.LoadBalancerIngress{{IP: "1.2.3.4"}}
+	}
+	return svc
+}
+
+func newNode(providerID string) *v1.Node {
+	return &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}, Spec: v1.NodeSpec{ProviderID: providerID}}
+}
+
+func newDeployment(namespace string, name string, labels map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels}}
+}
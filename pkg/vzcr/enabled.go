@@ -388,7 +388,9 @@ func IsKubeStateMetricsEnabled(cr runtime.Object) bool {
 			return *vzv1alpha1.Spec.Components.KubeStateMetrics.Enabled
 		}
 	} else if vzv1beta1, ok := cr.(*installv1beta1.Verrazzano); ok {
-		return *vzv1beta1.Spec.Components.KubeStateMetrics.Enabled
+		if vzv1beta1 != nil && vzv1beta1.Spec.Components.KubeStateMetrics != nil && vzv1beta1.Spec.Components.KubeStateMetrics.Enabled != nil {
+			return *vzv1beta1.Spec.Components.KubeStateMetrics.Enabled
+		}
 	}
 	return false
 }
@@ -1223,6 +1223,7 @@ func TestIsKubeStateMetricsEnabled(t *testing.T) {
 				},
 			},
 		}}))
+	asserts.False(IsKubeStateMetricsEnabled(&installv1beta1.Verrazzano{Spec: installv1beta1.VerrazzanoSpec{}}))
 	asserts.False(IsKubeStateMetricsEnabled(
 		&installv1beta1.Verrazzano{Spec: installv1beta1.VerrazzanoSpec{
 			Components: installv1beta1.ComponentSpec{
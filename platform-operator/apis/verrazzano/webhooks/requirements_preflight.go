@@ -0,0 +1,60 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package webhooks
+
+import (
+	"github.com/verrazzano/verrazzano/pkg/vzchecks"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/transform"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PreflightOptions holds the settings of the pre-flight checks run by the requirements webhooks
+type PreflightOptions struct {
+	// KubeClient is used to get the Kubernetes version
+	KubeClient kubernetes.Interface
+	// SupportedKubernetesVersions are the Kubernetes versions supported by Verrazzano, from the BOM embedded in the
+	// platform operator
+	SupportedKubernetesVersions []string
+}
+
+// getPreflightWarnings runs the pre-flight checks when they are enabled, and returns the issues found as warnings.
+// Only the checks of the cluster are run, the checks reaching the network outside of the cluster are left to
+// vz install and vz upgrade so the webhook responds quickly.
+func getPreflightWarnings(log *zap.SugaredLogger, client client.Client, options PreflightOptions, vz *v1beta1.Verrazzano, installed bool) []string {
+	if !config.Get().PreflightChecks {
+		return nil
+	}
+	ctx := vzchecks.PreflightContext{
+		Client:                      client,
+		KubeClient:                  options.KubeClient,
+		Profile:                     vzchecks.ProfileType(vz.Spec.Profile),
+		SupportedKubernetesVersions: options.SupportedKubernetesVersions,
+		Installed:                   installed,
+		LocalOnly:                   true,
+	}
+	// The requirements of the components are computed from the enabled components, which depend on the profile
+	effectiveCR, err := transform.GetEffectiveV1beta1CR(vz)
+	if err != nil {
+		log.Warnf("Failed to merge the profiles with the Verrazzano resource, the requirements of the components are not checked: %v", err)
+	} else {
+		ctx.Verrazzano = effectiveCR
+		vz = effectiveCR
+	}
+	if vz.Spec.Components.IngressNGINX != nil {
+		ctx.IngressType = string(vz.Spec.Components.IngressNGINX.Type)
+	}
+	var warnings []string
+	for _, result := range vzchecks.RunPreflightChecks(ctx) {
+		if result.Status == vzchecks.PreflightPass {
+			continue
+		}
+		log.Warnf(result.String())
+		warnings = append(warnings, result.String())
+	}
+	return warnings
+}
@@ -22,6 +22,8 @@ import (
 type RequirementsValidatorV1alpha1 struct {
 	client  client.Client
 	decoder *admission.Decoder
+	// Preflight holds the settings of the pre-flight checks
+	Preflight PreflightOptions
 }
 
 // InjectClient injects the client.
@@ -51,20 +53,20 @@ func (v *RequirementsValidatorV1alpha1) Handle(ctx context.Context, req admissio
 	if vz.ObjectMeta.DeletionTimestamp.IsZero() {
 		switch req.Operation {
 		case k8sadmission.Create:
-			return validateRequirementsV1alpha1(log, v.client, vz)
+			return validateRequirementsV1alpha1(log, v.client, v.Preflight, vz)
 		case k8sadmission.Update:
 			oldVz := v1alpha1.Verrazzano{}
 			if err := v.decoder.DecodeRaw(req.OldObject, &oldVz); err != nil {
 				return admission.Errored(http.StatusBadRequest, errors.Wrap(err, "unable to decode existing Verrazzano object"))
 			}
-			return validateUpdate(log, v.client, oldVz, vz)
+			return validateUpdate(log, v.client, v.Preflight, oldVz, vz)
 		}
 	}
 	return admission.Allowed("")
 }
 
 // validateRequirementsV1alpha1 presents the user with a warning if the prerequisite checks are not met.
-func validateRequirementsV1alpha1(log *zap.SugaredLogger, client client.Client, vz *v1alpha1.Verrazzano) admission.Response {
+func validateRequirementsV1alpha1(log *zap.SugaredLogger, client client.Client, preflight PreflightOptions, vz *v1alpha1.Verrazzano) admission.Response {
 	response := admission.Allowed("")
 	warnings := getWarningArray(vz)
 	if errs := vzchecks.PrerequisiteCheck(client, vzchecks.ProfileType(vz.Spec.Profile)); len(errs) > 0 {
@@ -73,20 +75,24 @@ func validateRequirementsV1alpha1(log *zap.SugaredLogger, client client.Client,
 			warnings = append(warnings, err.Error())
 		}
 	}
+	vzv1beta1 := &v1beta1.Verrazzano{}
+	if err := vz.ConvertTo(vzv1beta1); err == nil {
+		warnings = append(warnings, getPreflightWarnings(log, client, preflight, vzv1beta1, false)...)
+	}
 	if len(warnings) > 0 {
 		return admission.Allowed("").WithWarnings(warnings...)
 	}
 	return response
 }
 
-func validateUpdate(log *zap.SugaredLogger, client client.Client, oldvz v1alpha1.Verrazzano, newvz *v1alpha1.Verrazzano) admission.Response {
+func validateUpdate(log *zap.SugaredLogger, client client.Client, preflight PreflightOptions, oldvz v1alpha1.Verrazzano, newvz *v1alpha1.Verrazzano) admission.Response {
 	response := admission.Allowed("")
 	newvzv1beta1 := &v1beta1.Verrazzano{}
 	oldvzv1beta1 := &v1beta1.Verrazzano{}
 	err1 := newvz.ConvertTo(newvzv1beta1)
 	err2 := oldvz.ConvertTo(oldvzv1beta1)
 	if err1 == nil && err2 == nil {
-		return validateUpdatev1beta1(log, client, *oldvzv1beta1, newvzv1beta1)
+		return validateUpdatev1beta1(log, client, preflight, *oldvzv1beta1, newvzv1beta1)
 	}
 	return response
 }
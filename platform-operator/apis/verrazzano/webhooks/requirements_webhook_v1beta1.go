@@ -22,6 +22,8 @@ import (
 type RequirementsValidatorV1beta1 struct {
 	client  client.Client
 	decoder *admission.Decoder
+	// Preflight holds the settings of the pre-flight checks
+	Preflight PreflightOptions
 }
 
 // InjectClient injects the client.
@@ -51,20 +53,20 @@ func (v *RequirementsValidatorV1beta1) Handle(ctx context.Context, req admission
 	if vz.ObjectMeta.DeletionTimestamp.IsZero() {
 		switch req.Operation {
 		case k8sadmission.Create:
-			return validateRequirementsV1beta1(log, v.client, vz)
+			return validateRequirementsV1beta1(log, v.client, v.Preflight, vz)
 		case k8sadmission.Update:
 			oldVz := v1beta1.Verrazzano{}
 			if err := v.decoder.DecodeRaw(req.OldObject, &oldVz); err != nil {
 				return admission.Errored(http.StatusBadRequest, errors.Wrap(err, "unable to decode existing Verrazzano object"))
 			}
-			return validateUpdatev1beta1(log, v.client, oldVz, vz)
+			return validateUpdatev1beta1(log, v.client, v.Preflight, oldVz, vz)
 		}
 	}
 	return admission.Allowed("")
 }
 
-// validateRequirementsV1alpha1 presents the user with a warning if the prerequisite checks are not met.
-func validateRequirementsV1beta1(log *zap.SugaredLogger, client client.Client, vz *v1beta1.Verrazzano) admission.Response {
+// validateRequirementsV1beta1 presents the user with a warning if the prerequisite checks are not met.
+func validateRequirementsV1beta1(log *zap.SugaredLogger, client client.Client, preflight PreflightOptions, vz *v1beta1.Verrazzano) admission.Response {
 	response := admission.Allowed("")
 	warnings := getWarningArrayv1beta1(vz)
 	if errs := vzchecks.PrerequisiteCheck(client, vzchecks.ProfileType(vz.Spec.Profile)); len(errs) > 0 {
@@ -73,12 +75,13 @@ func validateRequirementsV1beta1(log *zap.SugaredLogger, client client.Client, v
 			warnings = append(warnings, err.Error())
 		}
 	}
+	warnings = append(warnings, getPreflightWarnings(log, client, preflight, vz, false)...)
 	if len(warnings) > 0 {
 		return admission.Allowed("").WithWarnings(warnings...)
 	}
 	return response
 }
-func validateUpdatev1beta1(log *zap.SugaredLogger, client client.Client, oldvz v1beta1.Verrazzano, newvz *v1beta1.Verrazzano) admission.Response {
+func validateUpdatev1beta1(log *zap.SugaredLogger, client client.Client, preflight PreflightOptions, oldvz v1beta1.Verrazzano, newvz *v1beta1.Verrazzano) admission.Response {
 	response := admission.Allowed("")
 	warnings := getWarningArrayv1beta1(newvz)
 	if newvz.Spec.Components.OpenSearch != nil && oldvz.Spec.Components.OpenSearch != nil {
@@ -101,6 +104,7 @@ func validateUpdatev1beta1(log *zap.SugaredLogger, client client.Client, oldvz v
 			warnings = append(warnings, err.Error())
 		}
 	}
+	warnings = append(warnings, getPreflightWarnings(log, client, preflight, newvz, true)...)
 	if len(warnings) > 0 {
 		return admission.Allowed("").WithWarnings(warnings...)
 	}
@@ -6,6 +6,7 @@ package webhooks
 import (
 	"context"
 	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/vzchecks"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -68,6 +69,33 @@ func TestPrerequisiteValidationNoWarningForV1beta1(t *testing.T) {
 	asrt.Len(res.Warnings, 0, noWarningsFailureMessage)
 }
 
+// TestPreflightChecksWarningForV1beta1 tests presenting the pre-flight check issues as warnings
+// GIVEN a call to validate a Verrazzano resource
+// WHEN the pre-flight checks are enabled and a check fails
+// THEN the admission request should be allowed with a warning for the failed check, and only the local checks are run.
+func TestPreflightChecksWarningForV1beta1(t *testing.T) {
+	asrt := assert.New(t)
+	vzchecks.RegisterPreflightCheck(vzchecks.NewPreflightCheck("custom", func(name string, ctx vzchecks.PreflightContext) []vzchecks.PreflightResult {
+		asrt.True(ctx.Installed)
+		asrt.True(ctx.LocalOnly)
+		return []vzchecks.PreflightResult{{Check: name, Status: vzchecks.PreflightFail, Message: "custom failure"}}
+	}))
+	defer vzchecks.ResetPreflightChecks()
+
+	var nodes []client.Object
+	nodes = append(nodes, node("node1", "3", "16G", "100G"), node("node2", "5", "32G", "140G"))
+	m := newRequirementsValidatorV1beta1(nodes)
+	vz := &v1beta1.Verrazzano{Spec: v1beta1.VerrazzanoSpec{Profile: v1beta1.Dev}}
+	req := newAdmissionRequest(admissionv1.Update, vz, vz)
+	config.Set(config.OperatorConfig{ResourceRequirementsValidation: true, PreflightChecks: true})
+	defer func() {
+		config.Set(config.OperatorConfig{ResourceRequirementsValidation: false})
+	}()
+	res := m.Handle(context.TODO(), req)
+	asrt.True(res.Allowed, allowedFailureMessage)
+	asrt.Contains(res.Warnings, "[fail] custom: custom failure")
+}
+
 // TestPrerequisiteValidationDisabledForV1beta1 tests that the validation checks are disabled.
 // GIVEN a call to validate a Verrazzano resource
 // WHEN the validation checks are disabled
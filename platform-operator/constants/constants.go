@@ -28,6 +28,10 @@ const MCAgentSecret = "verrazzano-cluster-agent" //nolint:gosec //#gosec G101
 // managed cluster name.
 const MCRegistrationSecret = "verrazzano-cluster-registration" //nolint:gosec //#gosec G101
 
+// MCFleetUpgradeSecret contains the Verrazzano version, and optionally the platform operator image, that a
+// VerrazzanoFleetUpgrade on the admin cluster rolls out to the managed cluster.
+const MCFleetUpgradeSecret = "verrazzano-fleet-upgrade" //nolint:gosec //#gosec G101
+
 // MCLocalRegistrationSecret - the name of the local secret that contains the cluster registration information.
 // This is created at Verrazzano install.
 const MCLocalRegistrationSecret = "verrazzano-local-registration" //nolint:gosec //#gosec G101
@@ -0,0 +1,77 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package bugreportcapture
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	"go.uber.org/zap"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// captureHelper implements the VZHelper used by the bug report capture, using the clients of the operator
+type captureHelper struct {
+	client        clipkg.Client
+	kubeClient    kubernetes.Interface
+	dynamicClient dynamic.Interface
+	out           io.Writer
+	state         *helpers.CaptureState
+}
+
+// GetCaptureState returns the state of the capture, so the capture does not use the state of the vz commands
+func (h *captureHelper) GetCaptureState() *helpers.CaptureState {
+	return h.state
+}
+
+func (h *captureHelper) GetOutputStream() io.Writer {
+	return h.out
+}
+
+func (h *captureHelper) GetErrorStream() io.Writer {
+	return h.out
+}
+
+func (h *captureHelper) GetInputStream() io.Reader {
+	return strings.NewReader("")
+}
+
+func (h *captureHelper) GetClient(_ *cobra.Command) (clipkg.Client, error) {
+	return h.client, nil
+}
+
+func (h *captureHelper) GetKubeClient(_ *cobra.Command) (kubernetes.Interface, error) {
+	return h.kubeClient, nil
+}
+
+func (h *captureHelper) GetHTTPClient() *http.Client {
+	return &http.Client{}
+}
+
+func (h *captureHelper) GetDynamicClient(_ *cobra.Command) (dynamic.Interface, error) {
+	return h.dynamicClient, nil
+}
+
+func (h *captureHelper) GetDiscoveryClient(_ *cobra.Command) (discovery.DiscoveryInterface, error) {
+	return nil, fmt.Errorf("the discovery client is not used by the bug report capture")
+}
+
+// logWriter writes the output of the bug report capture to the operator log, at debug level
+type logWriter struct {
+	logger *zap.SugaredLogger
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	if msg := strings.TrimSpace(string(p)); msg != "" {
+		w.logger.Debug(msg)
+	}
+	return len(p), nil
+}
@@ -0,0 +1,257 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package bugreportcapture
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/verrazzano/verrazzano/pkg/log"
+	vzbugreport "github.com/verrazzano/verrazzano/tools/vz/pkg/bugreport"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	"go.uber.org/zap"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	controllerName    = "BugReportCapturer"
+	channelBufferSize = 100
+
+	archivePrefix     = "vz-bug-report-"
+	archiveSuffix     = ".tar.gz"
+	archiveTimeFormat = "20060102-150405"
+	captureDirPattern = "bug-report-capture-"
+)
+
+// Retention limits the bug report archives kept in the store, the oldest archives are deleted first.
+// A zero value disables the limit.
+type Retention struct {
+	MaxArchives int
+	MaxAge      time.Duration
+}
+
+// BugReportCapturer captures a bug report in-cluster when it is triggered, using the same capture logic as the
+// vz bug-report command, and stores the sanitized archive in an ArchiveStore.
+// Captures are serialized, and at most one capture is done every minInterval, so a component flapping between
+// states does not flood the store.
+type BugReportCapturer struct {
+	client          clipkg.Client
+	kubeClient      kubernetes.Interface
+	dynamicClient   dynamic.Interface
+	store           ArchiveStore
+	redactionPolicy string
+	podLogDuration  time.Duration
+	minInterval     time.Duration
+	retention       Retention
+	logger          *zap.SugaredLogger
+	lastCapture     time.Time
+	triggers        chan string // The channel on which capture triggers are sent/received
+	shutdown        chan int    // The channel on which shutdown signals are sent/received
+	lock            sync.Mutex
+}
+
+// NewBugReportCapturer - instantiate a BugReportCapturer.  The redaction policy is the path to a redaction policy file
+// applied to the captured data, the default sanitization rules are applied when it is empty.  The pod log duration
+// limits the captured pod logs to the most recent ones, the complete logs are captured when it is zero.
+func NewBugReportCapturer(c clipkg.Client, kubeClient kubernetes.Interface, dynamicClient dynamic.Interface, store ArchiveStore, redactionPolicy string, podLogDuration time.Duration, minInterval time.Duration, retention Retention) *BugReportCapturer {
+	return &BugReportCapturer{
+		client:          c,
+		kubeClient:      kubeClient,
+		dynamicClient:   dynamicClient,
+		store:           store,
+		redactionPolicy: redactionPolicy,
+		podLogDuration:  podLogDuration,
+		minInterval:     minInterval,
+		retention:       retention,
+		logger:          zap.S().With(log.FieldController, controllerName),
+	}
+}
+
+// Start starts the BugReportCapturer if it is not already running.
+// It is safe to call Start multiple times, additional goroutines will not be created
+func (b *BugReportCapturer) Start() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.shutdown != nil {
+		// already running, so nothing to do
+		return
+	}
+	b.shutdown = make(chan int, channelBufferSize)
+	b.triggers = make(chan string, channelBufferSize)
+
+	// goroutine captures a bug report for each trigger received. If a shutdown signal is received (or channel is closed),
+	// the goroutine returns.
+	go func(triggers chan string, shutdown chan int) {
+		for {
+			select {
+			case reason := <-triggers:
+				b.handleTrigger(reason)
+			case <-shutdown:
+				// shutdown event causes termination
+				return
+			}
+		}
+	}(b.triggers, b.shutdown)
+}
+
+// Pause pauses the BugReportCapturer if it was running.
+// It is safe to call Pause multiple times
+func (b *BugReportCapturer) Pause() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.shutdown != nil {
+		close(b.shutdown)
+		b.shutdown = nil
+		b.triggers = nil
+	}
+}
+
+// Trigger requests a bug report capture, the reason is logged with the capture.
+// It does not block, the trigger is dropped when the BugReportCapturer is not running or is busy.
+func (b *BugReportCapturer) Trigger(reason string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.triggers == nil {
+		return
+	}
+	select {
+	case b.triggers <- reason:
+	default:
+		b.logger.Infof("Dropping the bug report capture triggered by %s, too many captures are pending", reason)
+	}
+}
+
+// handleTrigger captures a bug report, unless a bug report was captured less than minInterval ago
+func (b *BugReportCapturer) handleTrigger(reason string) {
+	if !b.lastCapture.IsZero() && time.Since(b.lastCapture) < b.minInterval {
+		b.logger.Infof("Skipping the bug report capture triggered by %s, the last bug report was captured at %s",
+			reason, b.lastCapture.Format(time.RFC3339))
+		return
+	}
+	b.lastCapture = time.Now()
+	b.logger.Infof("Capturing a bug report, triggered by %s", reason)
+	name, err := b.capture(b.lastCapture)
+	if err != nil {
+		b.logger.Errorf("Failed to capture the bug report triggered by %s: %v", reason, err)
+		return
+	}
+	b.logger.Infof("Stored the bug report %s", name)
+	if err := b.prune(b.lastCapture); err != nil {
+		b.logger.Errorf("Failed to delete the bug reports beyond the retention limits: %v", err)
+	}
+}
+
+// capture captures the cluster snapshot, creates the archive and puts it in the store.  It returns the archive name.
+func (b *BugReportCapturer) capture(now time.Time) (string, error) {
+	captureDir, err := os.MkdirTemp("", captureDirPattern)
+	if err != nil {
+		return "", fmt.Errorf("an error occurred while creating the directory to place cluster resources: %v", err)
+	}
+	defer os.RemoveAll(captureDir)
+
+	// The capture has its own writers and redactor, the state of the vz commands is not shared with the operator
+	state := helpers.NewCaptureState(true)
+	if b.redactionPolicy != "" {
+		if err := state.LoadRedactionPolicy(b.redactionPolicy); err != nil {
+			return "", err
+		}
+	}
+
+	writer := &logWriter{logger: b.logger}
+	vzHelper := &captureHelper{client: b.client, kubeClient: b.kubeClient, dynamicClient: b.dynamicClient, out: writer, state: state}
+	// Capture the resources and the pod logs of the application namespaces, like the vz analyze command
+	clusterSnapshotCtx := helpers.ClusterSnapshotCtx{
+		BugReportDir:         captureDir,
+		MoreNS:               helpers.GetVZManagedNamespaces(b.kubeClient, vzHelper),
+		PrintReportToConsole: false,
+	}
+	podLogs := vzbugreport.PodLogs{IsPodLog: true, Duration: int64(b.podLogDuration.Seconds())}
+	if err := vzbugreport.CaptureClusterSnapshot(b.kubeClient, b.dynamicClient, b.client, vzHelper, podLogs, clusterSnapshotCtx); err != nil {
+		return "", err
+	}
+	if err := state.WriteRedactionManifest(captureDir); err != nil {
+		return "", fmt.Errorf("an error occurred while creating the redaction manifest: %v", err)
+	}
+
+	archive, err := os.CreateTemp("", archivePrefix+"*"+archiveSuffix)
+	if err != nil {
+		return "", fmt.Errorf("an error occurred while creating the bug report archive: %v", err)
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+	if err := helpers.CreateReportArchive(captureDir, archive); err != nil {
+		return "", err
+	}
+	if _, err := archive.Seek(0, 0); err != nil {
+		return "", err
+	}
+
+	name := archiveName(now)
+	if err := b.store.Put(name, archive); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// prune deletes the oldest archives beyond the maximum number of archives, and the archives older than the maximum age
+func (b *BugReportCapturer) prune(now time.Time) error {
+	names, err := b.store.List()
+	if err != nil {
+		return err
+	}
+	type archive struct {
+		name    string
+		created time.Time
+	}
+	var archives []archive
+	for _, name := range names {
+		if created, ok := archiveTime(name); ok {
+			archives = append(archives, archive{name: name, created: created})
+		}
+	}
+	// Newest archives first
+	sort.Slice(archives, func(i, j int) bool {
+		return archives[i].created.After(archives[j].created)
+	})
+
+	var errs []string
+	for i, a := range archives {
+		expired := b.retention.MaxAge > 0 && now.Sub(a.created) > b.retention.MaxAge
+		if !expired && (b.retention.MaxArchives <= 0 || i < b.retention.MaxArchives) {
+			continue
+		}
+		if err := b.store.Delete(a.name); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		b.logger.Infof("Deleted the bug report %s", a.name)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// archiveName returns the name of the archive captured at the given time
+func archiveName(t time.Time) string {
+	return archivePrefix + t.UTC().Format(archiveTimeFormat) + archiveSuffix
+}
+
+// archiveTime returns the time the archive was captured, parsed from its name
+func archiveTime(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, archivePrefix) || !strings.HasSuffix(name, archiveSuffix) {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(archiveTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, archivePrefix), archiveSuffix))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
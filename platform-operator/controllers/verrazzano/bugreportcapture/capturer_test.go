@@ -0,0 +1,151 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package bugreportcapture
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestStart(t *testing.T) {
+	b := newTestCapturer(t.TempDir(), Retention{})
+	assert.Nil(t, b.shutdown)
+	b.Start()
+	assert.NotNil(t, b.shutdown)
+	b.Start()
+	assert.NotNil(t, b.shutdown)
+	b.Pause()
+	assert.Nil(t, b.shutdown)
+	b.Pause()
+	assert.Nil(t, b.shutdown)
+	// Triggers are ignored when paused
+	b.Trigger("test")
+}
+
+// TestCapture tests capturing a bug report in the directory store
+// GIVEN a BugReportCapturer storing the bug reports in a directory
+// WHEN a capture is triggered twice within the minimum interval
+// THEN one bug report archive is stored in the directory
+func TestCapture(t *testing.T) {
+	dir := t.TempDir()
+	b := newTestCapturer(dir, Retention{MaxArchives: 5})
+	b.handleTrigger("component test is Failed")
+	b.handleTrigger("component test is Failed")
+
+	names, err := b.store.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{archiveName(b.lastCapture)}, names)
+	info, err := os.Stat(filepath.Join(dir, names[0]))
+	assert.NoError(t, err)
+	assert.Greater(t, info.Size(), int64(0))
+}
+
+// TestCaptureApplicationNamespaces tests capturing the application namespaces
+// GIVEN a namespace with label verrazzano-managed=true and a pod in that namespace
+// WHEN a bug report is captured
+// THEN the archive contains the pod log and the redaction manifest
+func TestCaptureApplicationNamespaces(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "hello", Labels: map[string]string{"verrazzano-managed": "true"}}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "hello", Name: "hello-0"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "hello"}}},
+	}
+	dir := t.TempDir()
+	b := newTestCapturer(dir, Retention{})
+	b.client = fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(ns, pod).Build()
+	b.kubeClient = k8sfake.NewSimpleClientset(ns, pod)
+
+	name, err := b.capture(time.Now())
+	assert.NoError(t, err)
+
+	files := map[string]bool{}
+	archive, err := os.Open(filepath.Join(dir, name))
+	assert.NoError(t, err)
+	defer archive.Close()
+	gzipReader, err := gzip.NewReader(archive)
+	assert.NoError(t, err)
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		files[header.Name] = true
+	}
+	assert.True(t, files["cluster-snapshot/hello/hello-0/logs.txt"], "the pod log is not captured: %v", files)
+	assert.True(t, files["cluster-snapshot/redaction-manifest.json"], "the redaction manifest is not captured: %v", files)
+}
+
+// TestPrune tests deleting the bug reports beyond the retention limits
+// GIVEN bug reports in the directory store
+// WHEN the bug reports are pruned
+// THEN the bug reports older than the maximum age, and the oldest bug reports beyond the maximum number are deleted
+func TestPrune(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	var names []string
+	for _, age := range []time.Duration{time.Minute, time.Hour, 2 * time.Hour, 3 * time.Hour, 48 * time.Hour} {
+		name := archiveName(now.Add(-age))
+		names = append(names, name)
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("archive"), 0600))
+	}
+	// Files which are not bug reports are left alone
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "lost+found"), []byte{}, 0600))
+
+	b := newTestCapturer(dir, Retention{MaxArchives: 3, MaxAge: 24 * time.Hour})
+	assert.NoError(t, b.prune(now))
+	remaining, err := b.store.List()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{names[0], names[1], names[2], "lost+found"}, remaining)
+
+	b.retention = Retention{MaxAge: 90 * time.Minute}
+	assert.NoError(t, b.prune(now))
+	remaining, err = b.store.List()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{names[0], names[1], "lost+found"}, remaining)
+}
+
+// TestArchiveTime tests parsing the capture time from the archive name
+func TestArchiveTime(t *testing.T) {
+	now := time.Date(2023, 5, 10, 12, 30, 15, 0, time.UTC)
+	created, ok := archiveTime(archiveName(now))
+	assert.True(t, ok)
+	assert.Equal(t, now, created)
+	_, ok = archiveTime("vz-bug-report-latest.tar.gz")
+	assert.False(t, ok)
+	_, ok = archiveTime("other.tar.gz")
+	assert.False(t, ok)
+}
+
+func newTestCapturer(dir string, retention Retention) *BugReportCapturer {
+	c := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		helpers.GetVzProjectsConfigScheme():     "VerrazzanoProjectList",
+		helpers.GetManagedClusterConfigScheme(): "VerrazzanoManagedClusterList",
+		helpers.GetAppConfigScheme():            "ApplicationConfigurationList",
+		helpers.GetComponentConfigScheme():      "ComponentList",
+		helpers.GetIngressTraitConfigScheme():   "IngressTraitList",
+		helpers.GetMetricsTraitConfigScheme():   "MetricsTraitList",
+		helpers.GetMCComponentScheme():          "MultiClusterComponentList",
+		helpers.GetMCAppConfigScheme():          "MultiClusterApplicationConfigurationList",
+	})
+	return NewBugReportCapturer(c, k8sfake.NewSimpleClientset(), dynamicClient, NewDirectoryStore(dir), "", time.Hour, time.Hour, retention)
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package bugreportcapture
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/oracle/oci-go-sdk/v53/common"
+	"github.com/oracle/oci-go-sdk/v53/common/auth"
+	"github.com/oracle/oci-go-sdk/v53/objectstorage"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/validators"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ObjectStoreBucketKey is the key of the bucket name in the object store secret
+	ObjectStoreBucketKey = "bucket"
+	// ObjectStoreNamespaceKey is the key of the Object Storage namespace in the object store secret
+	ObjectStoreNamespaceKey = "namespace"
+)
+
+// ArchiveStore stores the bug report archives
+type ArchiveStore interface {
+	// Put stores the archive with the given name
+	Put(name string, archive *os.File) error
+	// List returns the names of the stored archives
+	List() ([]string, error)
+	// Delete deletes the archive with the given name
+	Delete(name string) error
+}
+
+// directoryStore stores the archives in a directory, typically where a PersistentVolumeClaim is mounted
+type directoryStore struct {
+	dir string
+}
+
+// NewDirectoryStore returns an ArchiveStore storing the archives in a directory
+func NewDirectoryStore(dir string) ArchiveStore {
+	return &directoryStore{dir: dir}
+}
+
+func (d *directoryStore) Put(name string, archive *os.File) error {
+	if err := os.MkdirAll(d.dir, 0700); err != nil {
+		return fmt.Errorf("Failed to create the bug report directory %s: %v", d.dir, err)
+	}
+	f, err := os.OpenFile(filepath.Join(d.dir, name), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("Failed to create the bug report %s: %v", name, err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, archive); err != nil {
+		return fmt.Errorf("Failed to write the bug report %s: %v", name, err)
+	}
+	return nil
+}
+
+func (d *directoryStore) List() ([]string, error) {
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+func (d *directoryStore) Delete(name string) error {
+	return os.Remove(filepath.Join(d.dir, name))
+}
+
+// objectStore stores the archives in an OCI Object Storage bucket.  The bucket, the Object Storage namespace and the
+// OCI credentials are read from a secret in the verrazzano-install namespace each time the store is used, so the
+// credentials can be rotated without restarting the operator.  The credentials use the format of the OCI DNS secret.
+type objectStore struct {
+	client     clipkg.Client
+	secretName string
+}
+
+// NewObjectStore returns an ArchiveStore storing the archives in the OCI Object Storage bucket referenced by a secret
+func NewObjectStore(c clipkg.Client, secretName string) ArchiveStore {
+	return &objectStore{client: c, secretName: secretName}
+}
+
+type objectStoreConfig struct {
+	client    objectstorage.ObjectStorageClient
+	namespace string
+	bucket    string
+}
+
+func (o *objectStore) Put(name string, archive *os.File) error {
+	cfg, err := o.getConfig()
+	if err != nil {
+		return err
+	}
+	info, err := archive.Stat()
+	if err != nil {
+		return err
+	}
+	_, err = cfg.client.PutObject(context.TODO(), objectstorage.PutObjectRequest{
+		NamespaceName: common.String(cfg.namespace),
+		BucketName:    common.String(cfg.bucket),
+		ObjectName:    common.String(name),
+		ContentLength: common.Int64(info.Size()),
+		PutObjectBody: io.NopCloser(archive),
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to upload the bug report %s to the bucket %s: %v", name, cfg.bucket, err)
+	}
+	return nil
+}
+
+func (o *objectStore) List() ([]string, error) {
+	cfg, err := o.getConfig()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	var start *string
+	for {
+		resp, err := cfg.client.ListObjects(context.TODO(), objectstorage.ListObjectsRequest{
+			NamespaceName: common.String(cfg.namespace),
+			BucketName:    common.String(cfg.bucket),
+			Prefix:        common.String(archivePrefix),
+			Start:         start,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Failed to list the bug reports in the bucket %s: %v", cfg.bucket, err)
+		}
+		for _, object := range resp.Objects {
+			if object.Name != nil {
+				names = append(names, *object.Name)
+			}
+		}
+		if resp.NextStartWith == nil {
+			return names, nil
+		}
+		start = resp.NextStartWith
+	}
+}
+
+func (o *objectStore) Delete(name string) error {
+	cfg, err := o.getConfig()
+	if err != nil {
+		return err
+	}
+	_, err = cfg.client.DeleteObject(context.TODO(), objectstorage.DeleteObjectRequest{
+		NamespaceName: common.String(cfg.namespace),
+		BucketName:    common.String(cfg.bucket),
+		ObjectName:    common.String(name),
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to delete the bug report %s from the bucket %s: %v", name, cfg.bucket, err)
+	}
+	return nil
+}
+
+// getConfig reads the object store secret and creates the Object Storage client
+func (o *objectStore) getConfig() (*objectStoreConfig, error) {
+	secret := &corev1.Secret{}
+	if err := o.client.Get(context.TODO(), types.NamespacedName{Namespace: constants.VerrazzanoInstallNamespace, Name: o.secretName}, secret); err != nil {
+		return nil, fmt.Errorf("Failed to get the bug report object store secret %s/%s: %v", constants.VerrazzanoInstallNamespace, o.secretName, err)
+	}
+	bucket := string(secret.Data[ObjectStoreBucketKey])
+	namespace := string(secret.Data[ObjectStoreNamespaceKey])
+	if bucket == "" || namespace == "" {
+		return nil, fmt.Errorf("The bug report object store secret %s must specify the %s and the %s", o.secretName, ObjectStoreBucketKey, ObjectStoreNamespaceKey)
+	}
+
+	var ociAuth validators.OciAuth
+	if err := validators.ValidateSecretContents(o.secretName, secret.Data[validators.OciDNSSecretFileName], &ociAuth); err != nil {
+		return nil, err
+	}
+	var provider common.ConfigurationProvider
+	if ociAuth.Auth.AuthType == validators.InstancePrincipal {
+		var err error
+		if provider, err = auth.InstancePrincipalConfigurationProvider(); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := validators.ValidatePrivateKey(o.secretName, []byte(ociAuth.Auth.Key)); err != nil {
+			return nil, err
+		}
+		provider = common.NewRawConfigurationProvider(ociAuth.Auth.Tenancy, ociAuth.Auth.User, ociAuth.Auth.Region, ociAuth.Auth.Fingerprint, ociAuth.Auth.Key, nil)
+	}
+	client, err := objectstorage.NewObjectStorageClientWithConfigurationProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+	return &objectStoreConfig{client: client, namespace: namespace, bucket: bucket}, nil
+}
@@ -46,6 +46,7 @@ func NewComponent() spi.Component {
 		helm.HelmComponent{
 			ReleaseName:               ComponentName,
 			JSONName:                  ComponentJSONName,
+			ResourceRequirements:      spi.NewResourceRequirements("100m", "128Mi"),
 			ChartDir:                  filepath.Join(config.GetHelmChartsDir(), ComponentName),
 			ChartNamespace:            ComponentNamespace,
 			IgnoreNamespaceOverride:   true,
@@ -52,6 +52,7 @@ func NewComponent() spi.Component {
 		HelmComponent: helm.HelmComponent{
 			ReleaseName:               common.ArgoCDName,
 			JSONName:                  ComponentJSONName,
+			ResourceRequirements:      spi.NewResourceRequirements("250m", "512Mi"),
 			ChartDir:                  filepath.Join(config.GetThirdPartyDir(), "argo-cd"),
 			ChartNamespace:            ComponentNamespace,
 			IgnoreNamespaceOverride:   true,
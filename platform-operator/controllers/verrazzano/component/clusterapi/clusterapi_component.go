@@ -117,6 +117,11 @@ func (c clusterAPIComponent) IsEnabled(effectiveCR runtime.Object) bool {
 	return vzcr.IsClusterAPIEnabled(effectiveCR)
 }
 
+// GetResourceRequirements returns the resources requested by the cluster API controllers
+func (c clusterAPIComponent) GetResourceRequirements(_ *v1beta1.Verrazzano) spi.ResourceRequirements {
+	return spi.NewResourceRequirements("200m", "512Mi")
+}
+
 // GetMinVerrazzanoVersion returns the minimum Verrazzano version required by the component
 func (c clusterAPIComponent) GetMinVerrazzanoVersion() string {
 	return vpoconstants.VerrazzanoVersion1_6_0
@@ -45,6 +45,7 @@ func NewComponent() spi.Component {
 		helm.HelmComponent{
 			ReleaseName:               ComponentName,
 			JSONName:                  ComponentJSONName,
+			ResourceRequirements:      spi.NewResourceRequirements("100m", "256Mi"),
 			ChartDir:                  filepath.Join(config.GetThirdPartyDir(), ComponentName),
 			ChartNamespace:            ComponentNamespace,
 			IgnoreNamespaceOverride:   true,
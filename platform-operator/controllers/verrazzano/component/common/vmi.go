@@ -26,6 +26,7 @@ import (
 	netv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	controllerruntime "sigs.k8s.io/controller-runtime"
@@ -247,6 +248,28 @@ func FindStorageOverrideV1Beta1(effectiveCR *v1beta1.Verrazzano) (*ResourceReque
 	return nil, fmt.Errorf("Failed, unsupported volume source: %v", defaultVolumeSource)
 }
 
+// GetVolumeSourceStorage returns the storage requested by a volume source: none for an emptyDir, the storage of the
+// VolumeClaimSpecTemplate referenced by a PersistentVolumeClaim, and the default storage otherwise
+func GetVolumeSourceStorage(effectiveCR *v1beta1.Verrazzano, volumeSource *corev1.VolumeSource, defaultStorage string) resource.Quantity {
+	if volumeSource != nil && volumeSource.EmptyDir != nil {
+		return resource.Quantity{}
+	}
+	if volumeSource != nil && volumeSource.PersistentVolumeClaim != nil {
+		storageSpec, found := vzconfig.FindVolumeTemplate(volumeSource.PersistentVolumeClaim.ClaimName, effectiveCR)
+		if found {
+			if storage, ok := storageSpec.Resources.Requests[corev1.ResourceStorage]; ok {
+				return storage
+			}
+		}
+	}
+	return resource.MustParse(defaultStorage)
+}
+
+// GetVMIStorage returns the storage requested by a VMI component using the default volume source
+func GetVMIStorage(effectiveCR *v1beta1.Verrazzano) resource.Quantity {
+	return GetVolumeSourceStorage(effectiveCR, effectiveCR.Spec.DefaultVolumeSource, defaultStorageSize)
+}
+
 // IsVMISecretReady returns true if the VMI secret is present in the system namespace
 func IsVMISecretReady(ctx spi.ComponentContext) bool {
 	if err := ctx.Client().Get(context.TODO(),
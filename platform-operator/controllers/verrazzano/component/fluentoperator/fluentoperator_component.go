@@ -58,6 +58,7 @@ func NewComponent() spi.Component {
 		helm.HelmComponent{
 			ReleaseName:               HelmChartReleaseName,
 			JSONName:                  ComponentJSONName,
+			ResourceRequirements:      spi.NewResourceRequirements("100m", "256Mi"),
 			ChartDir:                  filepath.Join(config.GetThirdPartyDir(), HelmChartDir),
 			ChartNamespace:            ComponentNamespace,
 			IgnoreNamespaceOverride:   true,
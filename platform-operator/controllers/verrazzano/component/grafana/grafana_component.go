@@ -40,6 +40,11 @@ const (
 // ComponentJSONName is the JSON name of the component in the Verrazzano CRD
 const ComponentJSONName = "grafana"
 
+const (
+	grafanaCPURequest    = "100m"
+	grafanaMemoryRequest = "256Mi"
+)
+
 type grafanaComponent struct{}
 
 // NewComponent creates a new Grafana component
@@ -96,6 +101,13 @@ func (g grafanaComponent) GetIngressNames(ctx spi.ComponentContext) []types.Name
 	return ingressNames
 }
 
+// GetResourceRequirements returns the resources requested by the Grafana pods and volume
+func (g grafanaComponent) GetResourceRequirements(effectiveCR *installv1beta1.Verrazzano) spi.ResourceRequirements {
+	requirements := spi.NewResourceRequirements(grafanaCPURequest, grafanaMemoryRequest)
+	requirements.Storage = common.GetVMIStorage(effectiveCR)
+	return requirements
+}
+
 // GetJSONName returns the component JSON name
 func (g grafanaComponent) GetJSONName() string {
 	return ComponentJSONName
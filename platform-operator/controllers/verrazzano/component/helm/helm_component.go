@@ -109,11 +109,17 @@ type HelmComponent struct {
 	Certificates []types.NamespacedName
 
 	AvailabilityObjects *ready.AvailabilityObjects
+
+	// ResourceRequirements are the resources requested by the pods of the component with the default replicas
+	ResourceRequirements spi.ResourceRequirements
 }
 
 // Verify that HelmComponent implements Component
 var _ spi.Component = HelmComponent{}
 
+// Verify that HelmComponent declares its resource requirements
+var _ spi.ComponentResourceRequirements = HelmComponent{}
+
 // preInstallFuncSig is the signature for the optional function to run before installing; any KeyValue pairs should be prepended to the Helm overrides list
 type preInstallFuncSig func(context spi.ComponentContext, releaseName string, namespace string, chartDir string) error
 
@@ -169,6 +175,21 @@ func (h HelmComponent) GetJSONName() string {
 	return h.JSONName
 }
 
+// GetReleaseName returns the Helm release name of the component
+func (h HelmComponent) GetReleaseName() string {
+	return h.ReleaseName
+}
+
+// GetChartDir returns the Helm chart directory of the component
+func (h HelmComponent) GetChartDir() string {
+	return h.ChartDir
+}
+
+// GetValuesFile returns the Helm values override file of the component
+func (h HelmComponent) GetValuesFile() string {
+	return h.ValuesFile
+}
+
 // GetOverrides returns the list of install overrides for a component
 func (h HelmComponent) GetOverrides(cr runtime.Object) interface{} {
 	if h.GetInstallOverridesFunc != nil {
@@ -209,6 +230,11 @@ func (h HelmComponent) GetMinVerrazzanoVersion() string {
 	return h.MinVerrazzanoVersion
 }
 
+// GetResourceRequirements returns the resources requested by the pods of the component
+func (h HelmComponent) GetResourceRequirements(_ *v1beta1.Verrazzano) spi.ResourceRequirements {
+	return h.ResourceRequirements
+}
+
 // IsInstalled Indicates whether the component is installed
 func (h HelmComponent) IsInstalled(ctx spi.ComponentContext) (bool, error) {
 	if ctx.IsDryRun() {
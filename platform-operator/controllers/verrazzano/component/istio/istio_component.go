@@ -129,6 +129,11 @@ func (i istioComponent) Namespace() string {
 	return IstioNamespace
 }
 
+// GetResourceRequirements returns the resources requested by istiod and the ingress and egress gateways
+func (i istioComponent) GetResourceRequirements(_ *installv1beta1.Verrazzano) spi.ResourceRequirements {
+	return spi.NewResourceRequirements("700m", "2Gi")
+}
+
 // GetJSONName returns the json name of the verrazzano component in CRD
 func (i istioComponent) GetJSONName() string {
 	return ComponentJSONName
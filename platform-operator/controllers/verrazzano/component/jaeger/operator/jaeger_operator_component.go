@@ -81,6 +81,7 @@ func NewComponent() spi.Component {
 		helm.HelmComponent{
 			ReleaseName:               ComponentName,
 			JSONName:                  ComponentJSONName,
+			ResourceRequirements:      spi.NewResourceRequirements("100m", "128Mi"),
 			ChartDir:                  filepath.Join(config.GetThirdPartyDir(), ChartDir),
 			ChartNamespace:            ComponentNamespace,
 			IgnoreNamespaceOverride:   true,
@@ -1423,6 +1423,29 @@ func CreateOrUpdateClient(ctx spi.ComponentContext, cfg *restclient.Config, cli
 	return nil
 }
 
+// DeleteClient deletes the Keycloak client with the given name, if it exists
+func DeleteClient(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface, clientName string) error {
+	keycloakClients, err := getKeycloakClients(ctx)
+	if err != nil {
+		return err
+	}
+	clientID := getClientID(keycloakClients, clientName)
+	if clientID == "" {
+		ctx.Log().Debugf("DeleteClient: Client %s not found", clientName)
+		return nil
+	}
+
+	clientDeleteCmd := kcAdminScript + " delete clients/" + clientID + " -r " + vzSysRealm
+	ctx.Log().Debugf("DeleteClient: Delete %s client Cmd = %s", clientName, clientDeleteCmd)
+	stdout, stderr, err := k8sutil.ExecPod(cli, cfg, keycloakPod(), ComponentName, bashCMD(clientDeleteCmd))
+	if err != nil {
+		ctx.Log().Errorf("Component Keycloak failed deleting %s client: stdout = %s, stderr = %s", clientName, stdout, stderr)
+		return err
+	}
+	ctx.Log().Oncef("Component Keycloak successfully deleted client %s", clientName)
+	return nil
+}
+
 func setAccessTokenLifespanForRealm(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface, realmName string) error {
 	kcPod := keycloakPod()
 	setTokenCmd := kcAdminScript + " update realms/" + realmName + " -s accessTokenLifespan=1200"
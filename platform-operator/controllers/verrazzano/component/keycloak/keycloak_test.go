@@ -1902,3 +1902,35 @@ func TestAddRealmRoleToUser(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "Failed to add realm role")
 }
+
+func fakeDeleteClientCommand(url *url.URL) (string, string, error) {
+	var commands []string
+	if commands = url.Query()["command"]; len(commands) == 3 {
+		if strings.Contains(commands[2], "get clients") {
+			return "[{\"id\" : \"quick-fox\",\"clientId\" : \"verrazzano-managed1\"}]", "", nil
+		}
+		if strings.Contains(commands[2], "delete clients/quick-fox") {
+			return "", "", nil
+		}
+	}
+	return "", "", fmt.Errorf("Unexpected command")
+}
+
+// TestDeleteClient tests deleting a Keycloak client
+// GIVEN a Keycloak client of a managed cluster
+// WHEN I call DeleteClient for the client, and for a client that does not exist
+// THEN confirm that the existing client is deleted and that no error is returned for the missing client
+func TestDeleteClient(t *testing.T) {
+	k8sutil.ClientConfig = fakeRESTConfig
+	k8sutil.NewPodExecutor = k8sutilfake.NewPodExecutor
+	podExecFunc := k8sutilfake.PodExecResult
+	k8sutilfake.PodExecResult = fakeDeleteClientCommand
+	defer func() { k8sutilfake.PodExecResult = podExecFunc }()
+	cfg, cli, _ := fakeRESTConfig()
+
+	c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build()
+	ctx := spi.NewFakeContext(c, testVZ, nil, false)
+
+	assert.NoError(t, DeleteClient(ctx, cfg, cli, "verrazzano-managed1"))
+	assert.NoError(t, DeleteClient(ctx, cfg, cli, "verrazzano-managed2"))
+}
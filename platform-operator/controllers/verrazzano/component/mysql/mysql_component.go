@@ -41,6 +41,9 @@ const DeploymentPersistentVolumeClaim = "mysql"
 // ComponentJSONName is the JSON name of the verrazzano component in CRD
 const ComponentJSONName = "mysql"
 
+// defaultMySQLStorage is the size of the MySQL volume when no volume source is specified
+const defaultMySQLStorage = "8Gi"
+
 // mysqlComponent represents an MySQL component
 type mysqlComponent struct {
 	helm.HelmComponent
@@ -59,6 +62,7 @@ func NewComponent() spi.Component {
 		HelmComponent: helm.HelmComponent{
 			ReleaseName:               helmReleaseName,
 			JSONName:                  ComponentJSONName,
+			ResourceRequirements:      spi.NewResourceRequirements("250m", "1Gi"),
 			ChartDir:                  filepath.Join(config.GetThirdPartyDir(), ComponentName),
 			ChartNamespace:            ComponentNamespace,
 			IgnoreNamespaceOverride:   true,
@@ -95,6 +99,13 @@ func (c mysqlComponent) IsEnabled(effectiveCR runtime.Object) bool {
 	return vzcr.IsKeycloakEnabled(effectiveCR)
 }
 
+// GetResourceRequirements adds the storage of the MySQL volume to the requirements of the pods
+func (c mysqlComponent) GetResourceRequirements(effectiveCR *v1beta1.Verrazzano) spi.ResourceRequirements {
+	requirements := c.ResourceRequirements
+	requirements.Storage = common.GetVolumeSourceStorage(effectiveCR, getMySQLVolumeSource(effectiveCR), defaultMySQLStorage)
+	return requirements
+}
+
 // PreInstall calls MySQL preInstall function
 func (c mysqlComponent) PreInstall(ctx spi.ComponentContext) error {
 	if err := preInstall(ctx, c.ChartNamespace); err != nil {
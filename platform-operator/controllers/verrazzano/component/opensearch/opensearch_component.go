@@ -13,6 +13,8 @@ import (
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/fluentoperator"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/networkpolicies"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
 
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
@@ -39,6 +41,12 @@ const (
 // ComponentJSONName is the JSON name of the opensearch component in CRD
 const ComponentJSONName = "opensearch"
 
+const (
+	// nodeCPURequest and nodeMemoryRequest are the resources requested by an OpenSearch node without resource overrides
+	nodeCPURequest    = "500m"
+	nodeMemoryRequest = "1400Mi"
+)
+
 type opensearchComponent struct{}
 
 // Namespace returns the component namespace
@@ -61,6 +69,39 @@ func (o opensearchComponent) GetMinVerrazzanoVersion() string {
 	return constants.VerrazzanoVersion1_0_0
 }
 
+// GetResourceRequirements sums the resources requested by the replicas of each OpenSearch node group
+func (o opensearchComponent) GetResourceRequirements(effectiveCR *installv1beta1.Verrazzano) spi.ResourceRequirements {
+	if effectiveCR.Spec.Components.OpenSearch == nil || len(effectiveCR.Spec.Components.OpenSearch.Nodes) == 0 {
+		return spi.NewResourceRequirements(nodeCPURequest, nodeMemoryRequest)
+	}
+	var requirements spi.ResourceRequirements
+	for _, group := range effectiveCR.Spec.Components.OpenSearch.Nodes {
+		if group.Replicas == nil || *group.Replicas == 0 {
+			continue
+		}
+		node := spi.NewResourceRequirements(nodeCPURequest, nodeMemoryRequest)
+		if group.Resources != nil {
+			if q, ok := group.Resources.Requests[corev1.ResourceCPU]; ok {
+				node.CPU = q
+			}
+			if q, ok := group.Resources.Requests[corev1.ResourceMemory]; ok {
+				node.Memory = q
+			}
+		}
+		if group.Storage != nil {
+			if q, err := resource.ParseQuantity(group.Storage.Size); err == nil {
+				node.Storage = q
+			}
+		}
+		for i := int32(0); i < *group.Replicas; i++ {
+			requirements.CPU.Add(node.CPU)
+			requirements.Memory.Add(node.Memory)
+			requirements.Storage.Add(node.Storage)
+		}
+	}
+	return requirements
+}
+
 // GetJSONName returns the josn name of the OpenSearch component in CRD
 func (o opensearchComponent) GetJSONName() string {
 	return ComponentJSONName
@@ -39,6 +39,12 @@ const (
 // ComponentJSONName is the JSON name of the OpenSearch-Dashboards component in CRD
 const ComponentJSONName = "opensearchDashboards"
 
+const (
+	// podCPURequest and podMemoryRequest are the resources requested by an OpenSearch-Dashboards pod
+	podCPURequest    = "100m"
+	podMemoryRequest = "1Gi"
+)
+
 type opensearchDashboardsComponent struct{}
 
 // Namespace returns the component namespace
@@ -61,6 +67,21 @@ func (d opensearchDashboardsComponent) GetMinVerrazzanoVersion() string {
 	return constants.VerrazzanoVersion1_0_0
 }
 
+// GetResourceRequirements multiplies the resources requested by an OpenSearch-Dashboards pod by the number of replicas
+func (d opensearchDashboardsComponent) GetResourceRequirements(effectiveCR *installv1beta1.Verrazzano) spi.ResourceRequirements {
+	replicas := int32(1)
+	if effectiveCR.Spec.Components.OpenSearchDashboards != nil && effectiveCR.Spec.Components.OpenSearchDashboards.Replicas != nil {
+		replicas = *effectiveCR.Spec.Components.OpenSearchDashboards.Replicas
+	}
+	var requirements spi.ResourceRequirements
+	pod := spi.NewResourceRequirements(podCPURequest, podMemoryRequest)
+	for i := int32(0); i < replicas; i++ {
+		requirements.CPU.Add(pod.CPU)
+		requirements.Memory.Add(pod.Memory)
+	}
+	return requirements
+}
+
 // GetJSONName returns the json name of the OpenSearch-Dashboards component in CRD
 func (d opensearchDashboardsComponent) GetJSONName() string {
 	return ComponentJSONName
@@ -1,4 +1,4 @@
-// Copyright (c) 2022, Oracle and/or its affiliates.
+// Copyright (c) 2022, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package adapter
@@ -7,8 +7,13 @@ import (
 	"context"
 	"fmt"
 	installv1beta1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
 	"github.com/verrazzano/verrazzano/pkg/k8s/ready"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
@@ -39,6 +44,46 @@ func preInstall(ctx spi.ComponentContext) error {
 	}); err != nil {
 		return ctx.Log().ErrorfNewErr("Failed to create or update the %s namespace: %v", ComponentNamespace, err)
 	}
+	return createAdapterConfigMap(ctx)
+}
+
+// PreUpgrade implementation for the Prometheus Adapter Component
+func preUpgrade(ctx spi.ComponentContext) error {
+	// Do nothing if dry run
+	if ctx.IsDryRun() {
+		ctx.Log().Debug("Prometheus Adapter preUpgrade dry run")
+		return nil
+	}
+	return createAdapterConfigMap(ctx)
+}
+
+// createAdapterConfigMap creates the ConfigMap of the configuration read by the Prometheus Adapter if it does not
+// exist, so that the Prometheus Adapter can start before the application operator copies the configuration of the
+// chart to it.  The configuration of an installed chart, which holds the rules of the existing autoscaler traits, is
+// copied on upgrade.
+func createAdapterConfigMap(ctx spi.ComponentContext) error {
+	cm := &corev1.ConfigMap{}
+	err := ctx.Client().Get(context.TODO(), types.NamespacedName{Namespace: ComponentNamespace, Name: vzconst.PrometheusAdapterConfigMapName}, cm)
+	if err == nil || !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	config := "{}"
+	chartCM := &corev1.ConfigMap{}
+	err = ctx.Client().Get(context.TODO(), types.NamespacedName{Namespace: ComponentNamespace, Name: ComponentName}, chartCM)
+	if err == nil {
+		config = chartCM.Data[configKey]
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+	ctx.Log().Debugf("Creating the ConfigMap %s of the Prometheus Adapter configuration", vzconst.PrometheusAdapterConfigMapName)
+	cm = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: vzconst.PrometheusAdapterConfigMapName},
+		Data:       map[string]string{configKey: config},
+	}
+	if err := ctx.Client().Create(context.TODO(), cm); err != nil && !apierrors.IsAlreadyExists(err) {
+		return ctx.Log().ErrorfNewErr("Failed to create the ConfigMap %s/%s: %v", ComponentNamespace, vzconst.PrometheusAdapterConfigMapName, err)
+	}
 	return nil
 }
 
@@ -28,6 +28,9 @@ const ComponentJSONName = "prometheusAdapter"
 
 const chartDir = "prometheus-community/prometheus-adapter"
 
+// configKey is the key of the configuration of the Prometheus Adapter in its ConfigMaps
+const configKey = "config.yaml"
+
 type prometheusAdapterComponent struct {
 	helm.HelmComponent
 }
@@ -81,6 +84,14 @@ func (c prometheusAdapterComponent) PreInstall(ctx spi.ComponentContext) error {
 	return c.HelmComponent.PreInstall(ctx)
 }
 
+// PreUpgrade updates resources necessary for the Prometheus Adapter Component upgrade
+func (c prometheusAdapterComponent) PreUpgrade(ctx spi.ComponentContext) error {
+	if err := preUpgrade(ctx); err != nil {
+		return err
+	}
+	return c.HelmComponent.PreUpgrade(ctx)
+}
+
 // MonitorOverrides checks whether monitoring of install overrides is enabled or not
 func (c prometheusAdapterComponent) MonitorOverrides(ctx spi.ComponentContext) bool {
 	if ctx.EffectiveCR().Spec.Components.PrometheusAdapter != nil {
@@ -1,19 +1,22 @@
-// Copyright (c) 2022, Oracle and/or its affiliates.
+// Copyright (c) 2022, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package adapter
 
 import (
+	"context"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -128,6 +131,35 @@ func TestPreInstallDryRun(t *testing.T) {
 	assert.Nil(t, preInstall(ctx))
 }
 
+// TestCreateAdapterConfigMap tests the creation of the ConfigMap of the configuration read by the Prometheus Adapter
+// GIVEN no ConfigMap of the configuration read by the Prometheus Adapter
+// WHEN the Prometheus Adapter is installed or upgraded
+// THEN the ConfigMap is created with the configuration of the installed chart, and an existing ConfigMap is kept
+func TestCreateAdapterConfigMap(t *testing.T) {
+	key := types.NamespacedName{Namespace: ComponentNamespace, Name: vzconst.PrometheusAdapterConfigMapName}
+
+	c := fake.NewClientBuilder().WithScheme(testScheme).Build()
+	assert.NoError(t, preInstall(spi.NewFakeContext(c, &vzapi.Verrazzano{}, nil, false)))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, c.Get(context.TODO(), key, cm))
+	assert.Equal(t, "{}", cm.Data[configKey])
+
+	chartCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: ComponentName},
+		Data:       map[string]string{configKey: "externalRules: []"},
+	}
+	c = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(chartCM).Build()
+	assert.NoError(t, preUpgrade(spi.NewFakeContext(c, &vzapi.Verrazzano{}, nil, false)))
+	assert.NoError(t, c.Get(context.TODO(), key, cm))
+	assert.Equal(t, "externalRules: []", cm.Data[configKey])
+
+	cm.Data[configKey] = "rules: []"
+	assert.NoError(t, c.Update(context.TODO(), cm))
+	assert.NoError(t, preUpgrade(spi.NewFakeContext(c, &vzapi.Verrazzano{}, nil, false)))
+	assert.NoError(t, c.Get(context.TODO(), key, cm))
+	assert.Equal(t, "rules: []", cm.Data[configKey])
+}
+
 // test GetOverrides method
 func TestGetOverrides(t *testing.T) {
 	ref := &corev1.ConfigMapKeySelector{
@@ -39,6 +39,7 @@ func NewComponent() spi.Component {
 		helm.HelmComponent{
 			ReleaseName:               ComponentName,
 			JSONName:                  ComponentJSONName,
+			ResourceRequirements:      spi.NewResourceRequirements("50m", "128Mi"),
 			ChartDir:                  filepath.Join(config.GetThirdPartyDir(), chartDir),
 			ChartNamespace:            ComponentNamespace,
 			IgnoreNamespaceOverride:   true,
@@ -57,6 +57,7 @@ func NewComponent() spi.Component {
 		helm.HelmComponent{
 			ReleaseName:               ComponentName,
 			JSONName:                  ComponentJSONName,
+			ResourceRequirements:      spi.NewResourceRequirements("600m", "2Gi"),
 			ChartDir:                  filepath.Join(config.GetThirdPartyDir(), chartDir),
 			ChartNamespace:            ComponentNamespace,
 			IgnoreNamespaceOverride:   true,
@@ -81,6 +82,15 @@ func (c prometheusComponent) IsEnabled(effectiveCR runtime.Object) bool {
 }
 
 // IsReady checks if the Prometheus Operator deployment is ready
+// GetResourceRequirements adds the storage of Prometheus to the requirements of the pods
+func (c prometheusComponent) GetResourceRequirements(effectiveCR *installv1beta1.Verrazzano) spi.ResourceRequirements {
+	requirements := c.ResourceRequirements
+	if vzcr.IsPrometheusEnabled(effectiveCR) {
+		requirements.Storage = common.GetVolumeSourceStorage(effectiveCR, effectiveCR.Spec.DefaultVolumeSource, defaultPrometheusStorage)
+	}
+	return requirements
+}
+
 func (c prometheusComponent) IsReady(ctx spi.ComponentContext) bool {
 	if c.HelmComponent.IsReady(ctx) {
 		return isPrometheusOperatorReady(ctx)
@@ -117,6 +117,7 @@ func NewComponent() spi.Component {
 		HelmComponent: helm.HelmComponent{
 			ReleaseName:               common.RancherName,
 			JSONName:                  ComponentJSONName,
+			ResourceRequirements:      spi.NewResourceRequirements("1", "3Gi"),
 			ChartDir:                  filepath.Join(config.GetThirdPartyDir(), common.RancherName),
 			ChartNamespace:            ComponentNamespace,
 			IgnoreNamespaceOverride:   true,
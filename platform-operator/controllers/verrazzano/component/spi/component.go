@@ -7,6 +7,7 @@ import (
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
@@ -120,6 +121,28 @@ type ComponentValidator interface {
 	ValidateUpdateV1Beta1(old *v1beta1.Verrazzano, new *v1beta1.Verrazzano) error
 }
 
+// ResourceRequirements are the resources requested by all the pods and the PersistentVolumeClaims of a component
+type ResourceRequirements struct {
+	// CPU is the CPU requested by the pods of the component
+	CPU resource.Quantity
+	// Memory is the memory requested by the pods of the component
+	Memory resource.Quantity
+	// Storage is the storage requested by the PersistentVolumeClaims of the component
+	Storage resource.Quantity
+}
+
+// NewResourceRequirements returns the requirements of a component requesting the CPU and memory, without storage
+func NewResourceRequirements(cpu string, memory string) ResourceRequirements {
+	return ResourceRequirements{CPU: resource.MustParse(cpu), Memory: resource.MustParse(memory)}
+}
+
+// ComponentResourceRequirements is implemented by the components declaring the resources they request, it is used
+// by the pre-flight checks to compare the requirements of the enabled components against the capacity of the cluster
+type ComponentResourceRequirements interface {
+	// GetResourceRequirements returns the resources requested by the component with the effective Verrazzano resource
+	GetResourceRequirements(effectiveCR *v1beta1.Verrazzano) ResourceRequirements
+}
+
 // Generate mocs for the spi.Component interface for use in tests.
 //go:generate mockgen -destination=../../../../mocks/component_mock.go -package=mocks -copyright_file=../../../../hack/boilerplate.go.txt github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi Component
 
@@ -6,6 +6,8 @@ package healthcheck
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/verrazzano/verrazzano/pkg/log"
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
@@ -42,9 +44,40 @@ func (p *HealthChecker) updateAvailability(components []spi.Component) error {
 		return fmt.Errorf("Failed to get new Verrazzano availability: %v", err)
 	}
 	p.sendStatus(status)
+	p.triggerBugReport(vz, status)
 	return nil
 }
 
+// triggerBugReport notifies the BugReportTrigger when a component enters the failed state, or when a component
+// which is ready becomes unavailable.  Components which were already failed or unavailable are not reported again.
+func (p *HealthChecker) triggerBugReport(vz *vzapi.Verrazzano, status *AvailabilityStatus) {
+	if p.bugReportTrigger == nil || status == nil {
+		return
+	}
+	unhealthy := map[string]bool{}
+	var reasons []string
+	for name, comp := range vz.Status.Components {
+		var reason string
+		if comp.State == vzapi.CompStateFailed {
+			reason = fmt.Sprintf("component %s is %s", name, comp.State)
+		} else if available, ok := status.Components[name]; ok && comp.State == vzapi.CompStateReady && available == vzapi.ComponentUnavailable {
+			reason = fmt.Sprintf("component %s is %s", name, available)
+		}
+		if reason == "" {
+			continue
+		}
+		unhealthy[name] = true
+		if !p.unhealthy[name] {
+			reasons = append(reasons, reason)
+		}
+	}
+	p.unhealthy = unhealthy
+	if len(reasons) > 0 {
+		sort.Strings(reasons)
+		p.bugReportTrigger.Trigger(strings.Join(reasons, ", "))
+	}
+}
+
 // newStatus creates a new availability status based on the current state of the component set.
 func (p *HealthChecker) newStatus(log vzlog.VerrazzanoLogger, vz *vzapi.Verrazzano, components []spi.Component) (*AvailabilityStatus, error) {
 	ctx, err := spi.NewContext(log, p.client, vz, nil, false)
@@ -1,4 +1,4 @@
-// Copyright (c) 2022, Oracle and/or its affiliates.
+// Copyright (c) 2022, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package healthcheck
@@ -26,6 +26,14 @@ type HealthChecker struct {
 	logger   *zap.SugaredLogger
 	status   *AvailabilityStatus // Last known AvailabilityStatus
 	shutdown chan int            // The channel on which shutdown signals are sent/received
+	// bugReportTrigger is notified when a component fails or becomes unavailable, it is optional
+	bugReportTrigger BugReportTrigger
+	unhealthy        map[string]bool // Components known to be failed or unavailable
+}
+
+// BugReportTrigger is notified by the HealthChecker when a component enters the failed state or becomes unavailable
+type BugReportTrigger interface {
+	Trigger(reason string)
 }
 
 type AvailabilityStatus struct {
@@ -43,6 +51,11 @@ func NewHealthChecker(updater *VerrazzanoStatusUpdater, c clipkg.Client, tick ti
 	}
 }
 
+// SetBugReportTrigger sets the BugReportTrigger notified when a component fails or becomes unavailable
+func (p *HealthChecker) SetBugReportTrigger(trigger BugReportTrigger) {
+	p.bugReportTrigger = trigger
+}
+
 // Start starts the HealthChecker if it is not already running.
 // It is safe to call Start multiple times, additional goroutines will not be created
 func (p *HealthChecker) Start() {
@@ -1,4 +1,4 @@
-// Copyright (c) 2022, Oracle and/or its affiliates.
+// Copyright (c) 2022, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package healthcheck
@@ -47,3 +47,52 @@ func TestStart(t *testing.T) {
 	p.Pause()
 	p.Pause()
 }
+
+type fakeBugReportTrigger struct {
+	reasons []string
+}
+
+func (f *fakeBugReportTrigger) Trigger(reason string) {
+	f.reasons = append(f.reasons, reason)
+}
+
+// TestTriggerBugReport tests triggering a bug report capture
+// GIVEN a HealthChecker with a BugReportTrigger
+// WHEN a component fails, or a ready component becomes unavailable
+// THEN the BugReportTrigger is notified once, until the component recovers
+func TestTriggerBugReport(t *testing.T) {
+	trigger := &fakeBugReportTrigger{}
+	p := newTestHealthCheck()
+	p.SetBugReportTrigger(trigger)
+
+	vz := &vzapi.Verrazzano{}
+	vz.Status.Components = map[string]*vzapi.ComponentStatusDetails{
+		"a": {State: vzapi.CompStateReady},
+		"b": {State: vzapi.CompStateReady},
+		"c": {State: vzapi.CompStateInstalling},
+	}
+	status := &AvailabilityStatus{Components: map[string]vzapi.ComponentAvailability{
+		"a": vzapi.ComponentAvailable,
+		"b": vzapi.ComponentAvailable,
+		"c": vzapi.ComponentUnavailable,
+	}}
+	// Installing components are not reported
+	p.triggerBugReport(vz, status)
+	assert.Empty(t, trigger.reasons)
+
+	vz.Status.Components["a"].State = vzapi.CompStateFailed
+	status.Components["b"] = vzapi.ComponentUnavailable
+	p.triggerBugReport(vz, status)
+	assert.Equal(t, []string{"component a is Failed, component b is Unavailable"}, trigger.reasons)
+
+	// Components already reported are not reported again
+	p.triggerBugReport(vz, status)
+	assert.Len(t, trigger.reasons, 1)
+
+	// Components are reported again after they recover
+	status.Components["b"] = vzapi.ComponentAvailable
+	p.triggerBugReport(vz, status)
+	status.Components["b"] = vzapi.ComponentUnavailable
+	p.triggerBugReport(vz, status)
+	assert.Equal(t, []string{"component a is Failed, component b is Unavailable", "component b is Unavailable"}, trigger.reasons)
+}
@@ -0,0 +1,267 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package plan
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/verrazzano/verrazzano/pkg/semver"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Operation is the operation a plan is computed for
+type Operation string
+
+const (
+	// InstallOperation is the install, or the update, of the components
+	InstallOperation Operation = "install"
+	// UpgradeOperation is the upgrade of the components to a new Verrazzano version
+	UpgradeOperation Operation = "upgrade"
+)
+
+// Action is what the operation does with a component
+type Action string
+
+const (
+	// ActionInstall means the component is installed, or updated when already installed
+	ActionInstall Action = "install"
+	// ActionUpgrade means the component is upgraded
+	ActionUpgrade Action = "upgrade"
+	// ActionSkip means the component is skipped
+	ActionSkip Action = "skip"
+)
+
+// Step is the action of the operation on a component
+type Step struct {
+	// Component is the name of the component
+	Component string `json:"component"`
+	// Namespace is the namespace of the component
+	Namespace string `json:"namespace,omitempty"`
+	// Action is what the operation does with the component
+	Action Action `json:"action"`
+	// Reason explains why the component is skipped or blocked
+	Reason string `json:"reason,omitempty"`
+	// Dependencies are the components declared as dependencies by the component
+	Dependencies []string `json:"dependencies,omitempty"`
+	// Wave is the position of the component in the dependency graph; the components of a wave only wait for the
+	// components of the previous waves.  It is 0 when the component is skipped or blocked by a dependency cycle.
+	Wave int `json:"wave,omitempty"`
+}
+
+// Plan is the ordered list of the actions of an operation on the components, computed from the effective
+// Verrazzano resource and the component registry
+type Plan struct {
+	// Operation is the operation the plan is computed for
+	Operation Operation `json:"operation"`
+	// Version is the Verrazzano version the minimum versions of the components are checked against
+	Version string `json:"version,omitempty"`
+	// Generation is the generation of the Verrazzano resource the plan is computed from
+	Generation int64 `json:"generation,omitempty"`
+	// Steps are the actions on the components, in the order they are processed
+	Steps []Step `json:"steps"`
+	// Cycles are the dependency cycles found between the components
+	Cycles [][]string `json:"cycles,omitempty"`
+	// Warnings are the issues found in the dependencies, which do not prevent the operation
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// NewPlan computes the plan of an operation.  The components are processed in the order of the registry, and wait
+// for their enabled dependencies to be ready.  The install of a component waits for its dependencies, so the install
+// steps are ordered by wave.  The upgrade of the components is sequential in the order of the registry, so the upgrade
+// steps keep that order, and the components upgraded before one of their dependencies are reported.
+func NewPlan(components []spi.Component, effectiveCR runtime.Object, operation Operation, version string) *Plan {
+	p := &Plan{Operation: operation, Version: version}
+	action := ActionInstall
+	if operation == UpgradeOperation {
+		action = ActionUpgrade
+	}
+
+	index := map[string]int{}
+	for i, comp := range components {
+		index[comp.Name()] = i
+	}
+	steps := make([]Step, len(components))
+	for i, comp := range components {
+		steps[i] = Step{Component: comp.Name(), Namespace: comp.Namespace(), Action: action, Dependencies: comp.GetDependencies()}
+		if !comp.IsEnabled(effectiveCR) {
+			steps[i].Action = ActionSkip
+			steps[i].Reason = "disabled"
+		} else if !isVersionOk(comp.GetMinVerrazzanoVersion(), version) {
+			steps[i].Action = ActionSkip
+			steps[i].Reason = fmt.Sprintf("requires Verrazzano version %s", comp.GetMinVerrazzanoVersion())
+		}
+		for _, dep := range steps[i].Dependencies {
+			if _, ok := index[dep]; !ok {
+				p.Warnings = append(p.Warnings, fmt.Sprintf("Component %s declares the dependency %s, which is not a registered component", comp.Name(), dep))
+			}
+		}
+	}
+
+	p.Cycles = findCycles(steps, index)
+	inCycle := map[string]bool{}
+	for _, cycle := range p.Cycles {
+		for _, name := range cycle {
+			inCycle[name] = true
+		}
+	}
+	waves := map[string]int{}
+	for i := range steps {
+		if steps[i].Action == ActionSkip {
+			continue
+		}
+		steps[i].Wave = computeWave(steps, index, inCycle, waves, i)
+		if steps[i].Wave == 0 {
+			steps[i].Reason = "blocked by a dependency cycle"
+		}
+	}
+
+	if operation == UpgradeOperation {
+		for i, step := range steps {
+			if step.Action == ActionSkip {
+				continue
+			}
+			for _, dep := range step.Dependencies {
+				if j, ok := index[dep]; ok && j > i && steps[j].Action != ActionSkip {
+					p.Warnings = append(p.Warnings, fmt.Sprintf("Component %s is upgraded before its dependency %s", step.Component, dep))
+				}
+			}
+		}
+	} else {
+		sort.SliceStable(steps, func(i, j int) bool {
+			return sortKey(steps[i]) < sortKey(steps[j])
+		})
+	}
+	p.Steps = steps
+	return p
+}
+
+// sortKey orders the install steps by wave, with the blocked and the skipped steps last
+func sortKey(step Step) int {
+	switch {
+	case step.Action == ActionSkip:
+		return 1 << 30
+	case step.Wave == 0:
+		return 1<<30 - 1
+	}
+	return step.Wave
+}
+
+// computeWave returns 1 for a component without enabled dependencies, otherwise one more than the highest wave of its
+// enabled dependencies.  It returns 0 when the component, or one of its dependencies, is part of a dependency cycle.
+func computeWave(steps []Step, index map[string]int, inCycle map[string]bool, waves map[string]int, i int) int {
+	name := steps[i].Component
+	if wave, ok := waves[name]; ok {
+		return wave
+	}
+	if inCycle[name] {
+		waves[name] = 0
+		return 0
+	}
+	wave := 1
+	for _, dep := range steps[i].Dependencies {
+		j, ok := index[dep]
+		// Dependencies are soft, the disabled dependencies are not waited for
+		if !ok || steps[j].Action == ActionSkip {
+			continue
+		}
+		depWave := computeWave(steps, index, inCycle, waves, j)
+		if depWave == 0 {
+			waves[name] = 0
+			return 0
+		}
+		if depWave+1 > wave {
+			wave = depWave + 1
+		}
+	}
+	waves[name] = wave
+	return wave
+}
+
+// findCycles returns the strongly connected components of the dependency graph which are cycles, in the order of the
+// registry
+func findCycles(steps []Step, index map[string]int) [][]string {
+	t := &tarjan{steps: steps, index: index, order: map[string]int{}, lowLink: map[string]int{}, onStack: map[string]bool{}}
+	for _, step := range steps {
+		if _, visited := t.order[step.Component]; !visited {
+			t.visit(step.Component)
+		}
+	}
+	return t.cycles
+}
+
+// tarjan implements the Tarjan algorithm to find the strongly connected components of the dependency graph
+type tarjan struct {
+	steps   []Step
+	index   map[string]int
+	counter int
+	order   map[string]int
+	lowLink map[string]int
+	stack   []string
+	onStack map[string]bool
+	cycles  [][]string
+}
+
+func (t *tarjan) visit(name string) {
+	t.order[name] = t.counter
+	t.lowLink[name] = t.counter
+	t.counter++
+	t.stack = append(t.stack, name)
+	t.onStack[name] = true
+
+	selfLoop := false
+	for _, dep := range t.steps[t.index[name]].Dependencies {
+		if _, ok := t.index[dep]; !ok {
+			continue
+		}
+		if dep == name {
+			selfLoop = true
+		}
+		if _, visited := t.order[dep]; !visited {
+			t.visit(dep)
+			if t.lowLink[dep] < t.lowLink[name] {
+				t.lowLink[name] = t.lowLink[dep]
+			}
+		} else if t.onStack[dep] && t.order[dep] < t.lowLink[name] {
+			t.lowLink[name] = t.order[dep]
+		}
+	}
+	if t.lowLink[name] != t.order[name] {
+		return
+	}
+
+	var component []string
+	for {
+		top := t.stack[len(t.stack)-1]
+		t.stack = t.stack[:len(t.stack)-1]
+		t.onStack[top] = false
+		component = append(component, top)
+		if top == name {
+			break
+		}
+	}
+	if len(component) > 1 || selfLoop {
+		sort.Slice(component, func(i, j int) bool {
+			return t.index[component[i]] < t.index[component[j]]
+		})
+		t.cycles = append(t.cycles, component)
+	}
+}
+
+// isVersionOk returns true when the component can be installed in the Verrazzano version
+func isVersionOk(minVersion string, version string) bool {
+	if len(version) == 0 || len(minVersion) == 0 {
+		return true
+	}
+	vzSemver, err := semver.NewSemVersion(version)
+	if err != nil {
+		return true
+	}
+	compSemver, err := semver.NewSemVersion(minVersion)
+	if err != nil {
+		return true
+	}
+	return !vzSemver.IsLessThan(compSemver)
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package plan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeComponent implements the methods of spi.Component used to compute the plan
+type fakeComponent struct {
+	spi.Component
+	name         string
+	dependencies []string
+	disabled     bool
+	minVersion   string
+}
+
+func (f fakeComponent) Name() string                    { return f.name }
+func (f fakeComponent) Namespace() string               { return "verrazzano-system" }
+func (f fakeComponent) GetDependencies() []string       { return f.dependencies }
+func (f fakeComponent) IsEnabled(_ runtime.Object) bool { return !f.disabled }
+func (f fakeComponent) GetMinVerrazzanoVersion() string { return f.minVersion }
+
+// TestInstallPlan tests computing an install plan
+// GIVEN components with dependencies, a disabled component and a component requiring a later version
+// WHEN the install plan is computed
+// THEN the components are ordered by wave, and the disabled dependencies are not waited for
+func TestInstallPlan(t *testing.T) {
+	components := []spi.Component{
+		fakeComponent{name: "c", dependencies: []string{"b", "disabled"}},
+		fakeComponent{name: "b", dependencies: []string{"a"}},
+		fakeComponent{name: "a"},
+		fakeComponent{name: "disabled", disabled: true},
+		fakeComponent{name: "future", minVersion: "2.0.0"},
+		fakeComponent{name: "d", dependencies: []string{"disabled"}},
+	}
+	p := NewPlan(components, nil, InstallOperation, "1.5.0")
+	assert.Empty(t, p.Cycles)
+	assert.Empty(t, p.Warnings)
+	assert.Equal(t, []string{"a", "d", "b", "c", "disabled", "future"}, stepNames(p))
+	assert.Equal(t, []int{1, 1, 2, 3, 0, 0}, stepWaves(p))
+	assert.Equal(t, ActionSkip, p.Steps[4].Action)
+	assert.Equal(t, "disabled", p.Steps[4].Reason)
+	assert.Equal(t, "requires Verrazzano version 2.0.0", p.Steps[5].Reason)
+
+	// The minimum versions are not checked when the version is not known
+	p = NewPlan(components, nil, InstallOperation, "")
+	assert.Equal(t, ActionInstall, findStep(p, "future").Action)
+}
+
+// TestPlanCycles tests computing a plan with dependency cycles
+// GIVEN components with a dependency cycle, a self dependency and an unknown dependency
+// WHEN the install plan is computed
+// THEN the cycles are reported, the components in or after a cycle are blocked and the unknown dependency is a warning
+func TestPlanCycles(t *testing.T) {
+	components := []spi.Component{
+		fakeComponent{name: "a", dependencies: []string{"c"}},
+		fakeComponent{name: "b", dependencies: []string{"a"}},
+		fakeComponent{name: "c", dependencies: []string{"b"}},
+		fakeComponent{name: "d", dependencies: []string{"a"}},
+		fakeComponent{name: "self", dependencies: []string{"self"}},
+		fakeComponent{name: "e", dependencies: []string{"unknown"}},
+	}
+	p := NewPlan(components, nil, InstallOperation, "")
+	assert.Equal(t, [][]string{{"a", "b", "c"}, {"self"}}, p.Cycles)
+	assert.Equal(t, []string{"Component e declares the dependency unknown, which is not a registered component"}, p.Warnings)
+	assert.Equal(t, "e", p.Steps[0].Component)
+	assert.Equal(t, 1, p.Steps[0].Wave)
+	d := findStep(p, "d")
+	assert.Equal(t, 0, d.Wave)
+	assert.Equal(t, "blocked by a dependency cycle", d.Reason)
+	assert.Contains(t, p.Text(), "a -> b -> c -> a")
+}
+
+// TestUpgradePlan tests computing an upgrade plan
+// GIVEN components where a component is before its dependency in the registry
+// WHEN the upgrade plan is computed
+// THEN the registry order is kept and the component upgraded before its dependency is reported
+func TestUpgradePlan(t *testing.T) {
+	components := []spi.Component{
+		fakeComponent{name: "a", dependencies: []string{"b"}},
+		fakeComponent{name: "b"},
+		fakeComponent{name: "c", dependencies: []string{"disabled"}},
+		fakeComponent{name: "disabled", disabled: true},
+	}
+	p := NewPlan(components, nil, UpgradeOperation, "1.5.0")
+	assert.Equal(t, []string{"a", "b", "c", "disabled"}, stepNames(p))
+	assert.Equal(t, ActionUpgrade, p.Steps[0].Action)
+	assert.Equal(t, []string{"Component a is upgraded before its dependency b"}, p.Warnings)
+	assert.Contains(t, p.Text(), "1. upgrade a (depends on b)")
+}
+
+// TestRender tests rendering a plan as text and as a DOT graph
+func TestRender(t *testing.T) {
+	components := []spi.Component{
+		fakeComponent{name: "a"},
+		fakeComponent{name: "b", dependencies: []string{"a"}},
+		fakeComponent{name: "disabled", disabled: true},
+	}
+	p := NewPlan(components, nil, InstallOperation, "1.5.0")
+	assert.Equal(t, `Component install plan for Verrazzano version 1.5.0
+Wave 1:
+  install a
+Wave 2:
+  install b (depends on a)
+Skipped:
+  disabled (disabled)
+`, p.Text())
+
+	dot := p.DOT()
+	assert.Contains(t, dot, `digraph "verrazzano-install" {`)
+	assert.Contains(t, dot, `"b" [label="b\nwave 2"];`)
+	assert.Contains(t, dot, `"disabled" [label="disabled", style=dashed`)
+	assert.Contains(t, dot, `"b" -> "a";`)
+}
+
+// TestPublishAndGet tests publishing a plan in the ConfigMap and reading it back
+func TestPublishAndGet(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build()
+	_, err := Get(context.TODO(), c)
+	assert.Error(t, err)
+
+	p := NewPlan([]spi.Component{fakeComponent{name: "a"}}, nil, InstallOperation, "1.5.0")
+	assert.NoError(t, Publish(context.TODO(), c, p))
+	// Publishing again updates the ConfigMap
+	p.Generation = 2
+	assert.NoError(t, Publish(context.TODO(), c, p))
+
+	actual, err := Get(context.TODO(), c)
+	assert.NoError(t, err)
+	assert.Equal(t, p, actual)
+}
+
+func stepNames(p *Plan) []string {
+	var names []string
+	for _, step := range p.Steps {
+		names = append(names, step.Component)
+	}
+	return names
+}
+
+func stepWaves(p *Plan) []int {
+	var waves []int
+	for _, step := range p.Steps {
+		waves = append(waves, step.Wave)
+	}
+	return waves
+}
+
+func findStep(p *Plan, name string) *Step {
+	for i := range p.Steps {
+		if p.Steps[i].Component == name {
+			return &p.Steps[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package plan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// ConfigMapName is the name of the ConfigMap the last computed plan is published in
+	ConfigMapName = "verrazzano-component-plan"
+	// ConfigMapKey is the key of the plan in the ConfigMap
+	ConfigMapKey = "plan.json"
+)
+
+// Publish stores the plan in the plan ConfigMap of the verrazzano-install namespace
+func Publish(ctx context.Context, c client.Client, p *Plan) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Failed to marshal the %s plan: %v", p.Operation, err)
+	}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName, Namespace: constants.VerrazzanoInstallNamespace}}
+	_, err = controllerutil.CreateOrUpdate(ctx, c, cm, func() error {
+		cm.Data = map[string]string{ConfigMapKey: string(data)}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to publish the %s plan in the ConfigMap %s/%s: %v", p.Operation, constants.VerrazzanoInstallNamespace, ConfigMapName, err)
+	}
+	return nil
+}
+
+// Get returns the plan published in the plan ConfigMap
+func Get(ctx context.Context, c client.Client) (*Plan, error) {
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Name: ConfigMapName, Namespace: constants.VerrazzanoInstallNamespace}, cm); err != nil {
+		return nil, err
+	}
+	data, ok := cm.Data[ConfigMapKey]
+	if !ok {
+		return nil, fmt.Errorf("The ConfigMap %s/%s does not contain the key %s", constants.VerrazzanoInstallNamespace, ConfigMapName, ConfigMapKey)
+	}
+	p := &Plan{}
+	if err := json.Unmarshal([]byte(data), p); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal the plan in the ConfigMap %s/%s: %v", constants.VerrazzanoInstallNamespace, ConfigMapName, err)
+	}
+	return p, nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package plan
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Text renders the plan as a list of the steps grouped by wave, followed by the skipped components, the dependency
+// cycles and the warnings
+func (p *Plan) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Component %s plan", p.Operation)
+	if len(p.Version) > 0 {
+		fmt.Fprintf(&b, " for Verrazzano version %s", p.Version)
+	}
+	b.WriteString("\n")
+
+	wave := -1
+	var skipped, blocked []Step
+	for i, step := range p.Steps {
+		switch {
+		case step.Action == ActionSkip:
+			skipped = append(skipped, step)
+			continue
+		case step.Wave == 0:
+			blocked = append(blocked, step)
+			continue
+		}
+		if p.Operation == UpgradeOperation {
+			fmt.Fprintf(&b, "  %d. %s %s%s\n", i+1, step.Action, step.Component, dependsOn(step.Dependencies))
+			continue
+		}
+		if step.Wave != wave {
+			wave = step.Wave
+			fmt.Fprintf(&b, "Wave %d:\n", wave)
+		}
+		fmt.Fprintf(&b, "  %s %s%s\n", step.Action, step.Component, dependsOn(step.Dependencies))
+	}
+	if len(blocked) > 0 {
+		b.WriteString("Blocked:\n")
+		for _, step := range blocked {
+			fmt.Fprintf(&b, "  %s (%s)\n", step.Component, step.Reason)
+		}
+	}
+	if len(skipped) > 0 {
+		b.WriteString("Skipped:\n")
+		for _, step := range skipped {
+			fmt.Fprintf(&b, "  %s (%s)\n", step.Component, step.Reason)
+		}
+	}
+	if len(p.Cycles) > 0 {
+		b.WriteString("Dependency cycles:\n")
+		for _, cycle := range p.Cycles {
+			fmt.Fprintf(&b, "  %s -> %s\n", strings.Join(cycle, " -> "), cycle[0])
+		}
+	}
+	if len(p.Warnings) > 0 {
+		b.WriteString("Warnings:\n")
+		for _, warning := range p.Warnings {
+			fmt.Fprintf(&b, "  %s\n", warning)
+		}
+	}
+	return b.String()
+}
+
+// DOT renders the dependency graph of the plan in the Graphviz DOT language.  The edges go from a component to its
+// dependencies, the skipped components are dashed and the components in a dependency cycle are red.
+func (p *Plan) DOT() string {
+	inCycle := map[string]bool{}
+	for _, cycle := range p.Cycles {
+		for _, name := range cycle {
+			inCycle[name] = true
+		}
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", fmt.Sprintf("verrazzano-%s", p.Operation))
+	b.WriteString("  rankdir=BT;\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, step := range p.Steps {
+		var attrs []string
+		// The label is not quoted with %q, which would escape the DOT line break
+		label := fmt.Sprintf(`label="%s"`, step.Component)
+		if step.Wave > 0 {
+			label = fmt.Sprintf(`label="%s\nwave %d"`, step.Component, step.Wave)
+		}
+		attrs = append(attrs, label)
+		if step.Action == ActionSkip {
+			attrs = append(attrs, "style=dashed", `fontcolor="gray"`, `color="gray"`)
+		}
+		if inCycle[step.Component] {
+			attrs = append(attrs, `color="red"`)
+		}
+		fmt.Fprintf(&b, "  %q [%s];\n", step.Component, strings.Join(attrs, ", "))
+	}
+	for _, step := range p.Steps {
+		for _, dep := range step.Dependencies {
+			fmt.Fprintf(&b, "  %q -> %q;\n", step.Component, dep)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func dependsOn(dependencies []string) string {
+	if len(dependencies) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (depends on %s)", strings.Join(dependencies, ", "))
+}
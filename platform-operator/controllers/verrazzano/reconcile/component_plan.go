@@ -0,0 +1,26 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package reconcile
+
+import (
+	"context"
+
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/plan"
+)
+
+// publishComponentPlan computes the plan of the operation from the effective CR and the registry, and publishes it so
+// that it can be previewed with the vz CLI.  The plan is informational, a failure to publish it does not fail the
+// operation.
+func (r *Reconciler) publishComponentPlan(ctx spi.ComponentContext, operation plan.Operation, version string) {
+	p := plan.NewPlan(registry.GetComponents(), ctx.EffectiveCR(), operation, version)
+	p.Generation = ctx.ActualCR().Generation
+	for _, cycle := range p.Cycles {
+		ctx.Log().Infof("Components %v have a dependency cycle and cannot be processed", cycle)
+	}
+	if err := plan.Publish(context.TODO(), r.Client, p); err != nil {
+		ctx.Log().Infof("%v", err)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/plan"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestPublishComponentPlan tests publishing the plan of an install
+// GIVEN a registry with a component depending on another component, and a disabled component
+// WHEN the install plan is published
+// THEN the plan ConfigMap contains the components in the install order
+func TestPublishComponentPlan(t *testing.T) {
+	registry.OverrideGetComponentsFn(func() []spi.Component {
+		return []spi.Component{
+			fakeComponent{HelmComponent: helm.HelmComponent{ReleaseName: "b", Dependencies: []string{"a"}}},
+			fakeComponent{HelmComponent: helm.HelmComponent{ReleaseName: "a"}},
+			fakeComponent{HelmComponent: helm.HelmComponent{ReleaseName: "c"}, enabled: "false"},
+		}
+	})
+	defer registry.ResetGetComponentsFn()
+
+	c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build()
+	vz := &vzapi.Verrazzano{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: 2}}
+	reconciler := newVerrazzanoReconciler(c)
+	reconciler.publishComponentPlan(spi.NewFakeContext(c, vz, nil, false), plan.InstallOperation, "1.5.0")
+
+	p, err := plan.Get(context.TODO(), c)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), p.Generation)
+	assert.Len(t, p.Steps, 3)
+	assert.Equal(t, "a", p.Steps[0].Component)
+	assert.Equal(t, "b", p.Steps[1].Component)
+	assert.Equal(t, plan.ActionSkip, p.Steps[2].Action)
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/rancher"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/plan"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/rbac"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/vzinstance"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
@@ -44,6 +45,7 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	errors2 "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	gofake "k8s.io/client-go/kubernetes/fake"
@@ -928,6 +930,7 @@ func TestUpgradeComponent(t *testing.T) {
 	mockComp.EXPECT().PostUpgrade(gomock.Any()).Return(nil).AnyTimes()
 	mockComp.EXPECT().Name().Return(componentName).AnyTimes()
 	mockComp.EXPECT().IsReady(gomock.Any()).Return(true).AnyTimes()
+	expectComponentPlan(mockComp)
 
 	ingressList := networkingv1.IngressList{Items: []networkingv1.Ingress{}}
 	//sa := rbac.NewServiceAccount(namespace, name, []string{}, map[string]string{})
@@ -1036,6 +1039,8 @@ func TestUpgradeComponentWithBlockingStatus(t *testing.T) {
 	mockComp.EXPECT().PreUpgrade(gomock.Any()).Return(nil).Times(1)
 	mockComp.EXPECT().Upgrade(gomock.Any()).Return(fmt.Errorf("Upgrade in progress")).AnyTimes()
 	mockComp.EXPECT().Name().Return("testcomp").Times(1).AnyTimes()
+	mockComp.EXPECT().IsEnabled(gomock.Any()).Return(true).AnyTimes()
+	expectComponentPlan(mockComp)
 
 	// expect a call to list any secrets with a status other than "deployed" for the component
 	statuses := []string{"unknown", "uninstalled", "superseded", "failed", "uninstalling", "pending-install", "pending-upgrade", "pending-rollback"}
@@ -1055,6 +1060,12 @@ func TestUpgradeComponentWithBlockingStatus(t *testing.T) {
 	// expect a call to delete the secret
 	mock.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
+	// expect the calls to publish the upgrade plan
+	mock.EXPECT().
+		Get(gomock.Any(), types.NamespacedName{Namespace: constants.VerrazzanoInstallNamespace, Name: plan.ConfigMapName}, gomock.Not(gomock.Nil()), gomock.Any()).
+		Return(errors2.NewNotFound(schema.GroupResource{Resource: "ConfigMap"}, plan.ConfigMapName)).AnyTimes()
+	mock.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	// Expect a call to get the status writer and return a mock.
 	mock.EXPECT().Status().Return(mockStatus).AnyTimes()
 	mockStatus.EXPECT().
@@ -1134,6 +1145,7 @@ func TestUpgradeMultipleComponentsOneDisabled(t *testing.T) {
 	mockEnabledComp.EXPECT().PostUpgrade(gomock.Any()).Return(nil).AnyTimes()
 	mockEnabledComp.EXPECT().IsReady(gomock.Any()).Return(true).AnyTimes()
 	mockEnabledComp.EXPECT().IsEnabled(gomock.Any()).Return(true).AnyTimes()
+	expectComponentPlan(mockEnabledComp)
 
 	// Set disabled mock component expectations
 	mockDisabledComp.EXPECT().Name().Return("DisabledComponent").Times(1).AnyTimes()
@@ -1142,6 +1154,7 @@ func TestUpgradeMultipleComponentsOneDisabled(t *testing.T) {
 	mockDisabledComp.EXPECT().Upgrade(gomock.Any()).Return(nil).Times(0)
 	mockDisabledComp.EXPECT().PostUpgrade(gomock.Any()).Return(nil).AnyTimes()
 	mockDisabledComp.EXPECT().IsEnabled(gomock.Any()).Return(false).AnyTimes()
+	expectComponentPlan(mockDisabledComp)
 	ingressList := networkingv1.IngressList{Items: []networkingv1.Ingress{}}
 
 	authConfig := createKeycloakAuthConfig()
@@ -1982,3 +1995,10 @@ func createObjectMeta(namespace string, name string, finalizers []string) metav1
 		Finalizers: finalizers,
 	}
 }
+
+// expectComponentPlan sets the expectations of the calls made to compute the upgrade plan
+func expectComponentPlan(mockComp *mocks.MockComponent) {
+	mockComp.EXPECT().Namespace().Return("").AnyTimes()
+	mockComp.EXPECT().GetDependencies().Return(nil).AnyTimes()
+	mockComp.EXPECT().GetMinVerrazzanoVersion().Return("").AnyTimes()
+}
@@ -4,6 +4,7 @@
 package transform
 
 import (
+	"path/filepath"
 	"strings"
 
 	vzprofiles "github.com/verrazzano/verrazzano/pkg/profiles"
@@ -52,6 +53,12 @@ func GetEffectiveCR(actualCR *v1alpha1.Verrazzano) (*v1alpha1.Verrazzano, error)
 // - Effective CR == base profile + declared profiles + ActualCR (in order)
 // - last definition wins
 func GetEffectiveV1beta1CR(actualCR *v1beta1.Verrazzano) (*v1beta1.Verrazzano, error) {
+	return GetEffectiveV1beta1CRUsingProfilesDir(actualCR, config.GetProfilesDir())
+}
+
+// GetEffectiveV1beta1CRUsingProfilesDir Creates an "effective" v1beta1.Verrazzano CR like GetEffectiveV1beta1CR, using
+// the profile definitions in the specified profiles directory
+func GetEffectiveV1beta1CRUsingProfilesDir(actualCR *v1beta1.Verrazzano, profilesDir string) (*v1beta1.Verrazzano, error) {
 	if actualCR == nil {
 		return nil, nil
 	}
@@ -62,7 +69,7 @@ func GetEffectiveV1beta1CR(actualCR *v1beta1.Verrazzano) (*v1beta1.Verrazzano, e
 	}
 	var profileFiles []string
 	for _, profile := range profiles {
-		profileFiles = append(profileFiles, config.GetProfile(v1beta1.SchemeGroupVersion, profile))
+		profileFiles = append(profileFiles, filepath.Join(profilesDir, v1beta1.SchemeGroupVersion.Version, profile+".yaml"))
 	}
 	// Merge the profile files into an effective profile YAML string
 	effectiveCR, err := vzprofiles.MergeProfilesForV1beta1(actualCR, profileFiles...)
@@ -71,6 +71,10 @@ type OperatorConfig struct {
 	// default-value: false, disabling the validation
 	ResourceRequirementsValidation bool
 
+	// PreflightChecks enables/disables the pre-flight checks of the cluster in the resource requirements validation
+	// webhook; the issues found are returned as warnings.  The image registry is not checked by the webhook.
+	PreflightChecks bool
+
 	// WebhookValidationEnabled enables/disables webhook validation without removing the webhook itself
 	WebhookValidationEnabled bool
 
@@ -87,6 +91,33 @@ type OperatorConfig struct {
 	// detecting a possible condition to repair, and initiating the repair logic.
 	MySQLRepairTimeoutSeconds int64
 
+	// BugReportCaptureEnabled enables/disables the in-cluster bug report capture, when a component fails or becomes
+	// unavailable.  The unavailable components are detected by the health check background task.
+	BugReportCaptureEnabled bool
+
+	// BugReportDir is the directory where the bug reports are stored, typically where a PersistentVolumeClaim is mounted
+	BugReportDir string
+
+	// BugReportObjectStoreSecret is the name of the secret in the verrazzano-install namespace referencing the OCI
+	// Object Storage bucket where the bug reports are stored; when set, the bug reports are not stored in BugReportDir
+	BugReportObjectStoreSecret string
+
+	// BugReportRedactionPolicy is the path to the redaction policy applied to the bug reports
+	BugReportRedactionPolicy string
+
+	// BugReportPodLogSeconds limits the pod logs captured in the bug reports to the last number of seconds; a value
+	// of 0 captures the complete logs
+	BugReportPodLogSeconds int64
+
+	// BugReportMinIntervalSeconds is the minimum amount of time between two bug report captures
+	BugReportMinIntervalSeconds int64
+
+	// BugReportMaxArchives is the maximum number of bug reports kept; a value of 0 disables the limit
+	BugReportMaxArchives int64
+
+	// BugReportRetentionHours is the amount of time the bug reports are kept; a value of 0 disables the limit
+	BugReportRetentionHours int64
+
 	// DryRun Run installs in a dry-run mode
 	DryRun bool
 
@@ -103,11 +134,18 @@ var instance = OperatorConfig{
 	RunWebhookInit:                 false,
 	RunWebhooks:                    false,
 	ResourceRequirementsValidation: false,
+	PreflightChecks:                false,
 	WebhookValidationEnabled:       true,
 	VerrazzanoRootDir:              rootDir,
 	HealthCheckPeriodSeconds:       60,
 	MySQLCheckPeriodSeconds:        60,
 	MySQLRepairTimeoutSeconds:      120,
+	BugReportCaptureEnabled:        false,
+	BugReportDir:                   "/var/lib/verrazzano/bug-reports",
+	BugReportPodLogSeconds:         3600,
+	BugReportMinIntervalSeconds:    3600,
+	BugReportMaxArchives:           5,
+	BugReportRetentionHours:        168,
 	ExperimentalModules:            false,
 }
 
@@ -13,6 +13,7 @@ import (
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/configmaps/components"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/configmaps/overrides"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/secrets"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/bugreportcapture"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/healthcheck"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/mysqlcheck"
@@ -27,6 +28,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"os"
 	controllerruntime "sigs.k8s.io/controller-runtime"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
 	"strings"
 	"sync"
 	"time"
@@ -105,6 +107,16 @@ func StartPlatformOperator(vzconfig config.OperatorConfig, log *zap.SugaredLogge
 		healthCheck.Start()
 	}
 
+	// Setup the in-cluster bug report capture, triggered by the health checker
+	if vzconfig.BugReportCaptureEnabled {
+		capturer, err := newBugReportCapturer(vzconfig, mgr.GetClient(), kubeClient)
+		if err != nil {
+			return errors.Wrap(err, "Failed to setup the bug report capture")
+		}
+		capturer.Start()
+		healthCheck.SetBugReportTrigger(capturer)
+	}
+
 	// Setup secrets reconciler
 	if err = (&secrets.VerrazzanoSecretsReconciler{
 		Client:        mgr.GetClient(),
@@ -152,6 +164,25 @@ func StartPlatformOperator(vzconfig config.OperatorConfig, log *zap.SugaredLogge
 	return nil
 }
 
+// newBugReportCapturer creates the BugReportCapturer, storing the bug reports in the object store when a secret
+// is configured, otherwise in the bug report directory
+func newBugReportCapturer(vzconfig config.OperatorConfig, c clipkg.Client, kubeClient kubernetes.Interface) (*bugreportcapture.BugReportCapturer, error) {
+	dynamicClient, err := k8sutil.GetDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	store := bugreportcapture.NewDirectoryStore(vzconfig.BugReportDir)
+	if vzconfig.BugReportObjectStoreSecret != "" {
+		store = bugreportcapture.NewObjectStore(c, vzconfig.BugReportObjectStoreSecret)
+	}
+	retention := bugreportcapture.Retention{
+		MaxArchives: int(vzconfig.BugReportMaxArchives),
+		MaxAge:      time.Duration(vzconfig.BugReportRetentionHours) * time.Hour,
+	}
+	return bugreportcapture.NewBugReportCapturer(c, kubeClient, dynamicClient, store, vzconfig.BugReportRedactionPolicy,
+		time.Duration(vzconfig.BugReportPodLogSeconds)*time.Second, time.Duration(vzconfig.BugReportMinIntervalSeconds)*time.Second, retention), nil
+}
+
 // generateConfigMapFromHelmChartFiles generates a config map with the files from a given helm chart directory
 func generateConfigMapFromHelmChartFiles(dir string, key string, files []os.DirEntry, configMap *corev1.ConfigMap) error {
 	for _, file := range files {
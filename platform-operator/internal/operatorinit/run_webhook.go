@@ -140,15 +140,20 @@ func setupWebhooksWithManager(log *zap.SugaredLogger, mgr manager.Manager, kubeC
 		},
 	)
 
-	// register requirements validator webhooks.
-	mgr.GetWebhookServer().Register(webhooks.RequirementsV1beta1Path, &webhook.Admission{Handler: &webhooks.RequirementsValidatorV1beta1{}})
-	mgr.GetWebhookServer().Register(webhooks.RequirementsV1alpha1Path, &webhook.Admission{Handler: &webhooks.RequirementsValidatorV1alpha1{}})
-
-	// register MySQL install values webhooks
 	bomFile, err := bom.NewBom(internalconfig.GetDefaultBOMFilePath())
 	if err != nil {
 		return err
 	}
+
+	// register requirements validator webhooks.
+	preflight := webhooks.PreflightOptions{
+		KubeClient:                  kubeClient,
+		SupportedKubernetesVersions: bomFile.GetSupportedKubernetesVersion(),
+	}
+	mgr.GetWebhookServer().Register(webhooks.RequirementsV1beta1Path, &webhook.Admission{Handler: &webhooks.RequirementsValidatorV1beta1{Preflight: preflight}})
+	mgr.GetWebhookServer().Register(webhooks.RequirementsV1alpha1Path, &webhook.Admission{Handler: &webhooks.RequirementsValidatorV1alpha1{Preflight: preflight}})
+
+	// register MySQL install values webhooks
 	mgr.GetWebhookServer().Register(webhooks.MysqlInstallValuesV1beta1path, &webhook.Admission{Handler: &webhooks.MysqlValuesValidatorV1beta1{BomVersion: bomFile.GetVersion()}})
 	mgr.GetWebhookServer().Register(webhooks.MysqlInstallValuesV1alpha1path, &webhook.Admission{Handler: &webhooks.MysqlValuesValidatorV1alpha1{BomVersion: bomFile.GetVersion()}})
 
@@ -83,6 +83,8 @@ func main() {
 		"Enable webhooks validation for the operator")
 	flag.BoolVar(&config.ResourceRequirementsValidation, "resource-validation",
 		config.ResourceRequirementsValidation, "Enables of resource validation webhooks.")
+	flag.BoolVar(&config.PreflightChecks, "preflight-checks", config.PreflightChecks,
+		"Enables the pre-flight checks of the cluster in the resource validation webhooks")
 	flag.BoolVar(&config.RunWebhooks, "run-webhooks", config.RunWebhooks,
 		"Runs in webhook mode; if false, runs the main operator reconcile loop")
 	flag.BoolVar(&config.RunWebhookInit, "run-webhook-init", config.RunWebhookInit,
@@ -97,6 +99,22 @@ func main() {
 		"MySQL check period seconds; set to 0 to disable MySQL checks")
 	flag.Int64Var(&config.MySQLRepairTimeoutSeconds, "mysql-repair-timeout", config.MySQLRepairTimeoutSeconds,
 		"MySQL repair timeout seconds")
+	flag.BoolVar(&config.BugReportCaptureEnabled, "bug-report-capture", config.BugReportCaptureEnabled,
+		"Capture a bug report in-cluster when a component fails or becomes unavailable")
+	flag.StringVar(&config.BugReportDir, "bug-report-dir", config.BugReportDir,
+		"The directory where the bug reports are stored")
+	flag.StringVar(&config.BugReportObjectStoreSecret, "bug-report-object-store-secret", config.BugReportObjectStoreSecret,
+		"The secret referencing the OCI Object Storage bucket where the bug reports are stored")
+	flag.StringVar(&config.BugReportRedactionPolicy, "bug-report-redaction-policy", config.BugReportRedactionPolicy,
+		"The redaction policy file applied to the bug reports")
+	flag.Int64Var(&config.BugReportPodLogSeconds, "bug-report-pod-log-duration", config.BugReportPodLogSeconds,
+		"Duration in seconds of the pod logs captured in the bug reports; set to 0 to capture the complete logs")
+	flag.Int64Var(&config.BugReportMinIntervalSeconds, "bug-report-min-interval", config.BugReportMinIntervalSeconds,
+		"Minimum time between bug report captures in seconds")
+	flag.Int64Var(&config.BugReportMaxArchives, "bug-report-max-archives", config.BugReportMaxArchives,
+		"Maximum number of bug reports kept; set to 0 to disable the limit")
+	flag.Int64Var(&config.BugReportRetentionHours, "bug-report-retention", config.BugReportRetentionHours,
+		"Bug report retention period in hours; set to 0 to disable the limit")
 	flag.BoolVar(&config.ExperimentalModules, "experimental-modules", config.ExperimentalModules, "enable experimental modules")
 
 	// Add the zap logger flag set to the CLI.
@@ -0,0 +1,18 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+// Package profiles is used to reference the embedded profile files in the binary, so that tools such as
+// the vz CLI can compute the effective Verrazzano configuration without the files of the platform operator image.
+package profiles
+
+import (
+	"embed"
+)
+
+//go:embed v1alpha1/*.yaml v1beta1/*.yaml
+var profiles embed.FS
+
+// GetEmbeddedProfiles returns the embedded profiles
+func GetEmbeddedProfiles() embed.FS {
+	return profiles
+}
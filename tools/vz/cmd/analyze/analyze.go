@@ -4,6 +4,7 @@
 package analyze
 
 import (
+	"context"
 	"fmt"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -12,8 +13,10 @@ import (
 	vzbugreport "github.com/verrazzano/verrazzano/tools/vz/pkg/bugreport"
 	"github.com/verrazzano/verrazzano/tools/vz/pkg/constants"
 	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 const (
@@ -26,6 +29,15 @@ vz analyze --capture-dir <path>
 
 # Run analysis tool on the live cluster
 vz analyze
+
+# Run analysis tool on a capture taken after an upgrade, and report the changes since a capture taken before the upgrade
+vz analyze --capture-dir <path> --baseline <baseline-path>
+
+# Run analysis tool on captured directory, write a SARIF report and fail when an issue with impact 7 or above is found
+vz analyze --capture-dir <path> --report-format sarif --report-file <file> --fail-on-impact 7
+
+# Run analysis tool on captured directory with additional rules from a directory and a ConfigMap
+vz analyze --capture-dir <path> --rules-dir <rules-path> --rules-configmap <namespace>/<name>
 `
 )
 
@@ -43,6 +55,10 @@ func NewCmdAnalyze(vzHelper helpers.VZHelper) *cobra.Command {
 	cmd.PersistentFlags().String(constants.ReportFileFlagName, constants.ReportFileFlagValue, constants.ReportFileFlagUsage)
 	cmd.PersistentFlags().String(constants.ReportFormatFlagName, constants.SummaryReport, constants.ReportFormatFlagUsage)
 	cmd.PersistentFlags().BoolP(constants.VerboseFlag, constants.VerboseFlagShorthand, constants.VerboseFlagDefault, constants.VerboseFlagUsage)
+	cmd.PersistentFlags().Int(constants.FailOnImpactFlagName, constants.FailOnImpactFlagDefault, constants.FailOnImpactFlagUsage)
+	cmd.PersistentFlags().String(constants.BaselineFlagName, "", constants.BaselineFlagUsage)
+	cmd.PersistentFlags().String(constants.RulesDirFlagName, "", constants.RulesDirFlagUsage)
+	cmd.PersistentFlags().String(constants.RulesConfigMapFlagName, "", constants.RulesConfigMapFlagUsage)
 	return cmd
 }
 
@@ -74,7 +90,7 @@ func analyzeLiveCluster(cmd *cobra.Command, vzHelper helpers.VZHelper, directory
 	}
 
 	// Get the list of namespaces with label verrazzano-managed=true, where the applications are deployed
-	moreNS := helpers.GetVZManagedNamespaces(kubeClient)
+	moreNS := helpers.GetVZManagedNamespaces(kubeClient, vzHelper)
 
 	// Instruct the helper to display the message for analyzing the live cluster
 	helpers.SetIsLiveCluster()
@@ -84,20 +100,28 @@ func analyzeLiveCluster(cmd *cobra.Command, vzHelper helpers.VZHelper, directory
 		IsPodLog: true,
 		Duration: int64(0),
 	}
-	clusterSnapshotCtx := helpers.ClusterSnapshotCtx{BugReportDir: reportDirectory, MoreNS: moreNS, PrintReportToConsole: true}
+	clusterSnapshotCtx := helpers.ClusterSnapshotCtx{BugReportDir: reportDirectory, MoreNS: moreNS, PrintReportToConsole: !isMachineReadable(getReportFormat(cmd))}
 	return vzbugreport.CaptureClusterSnapshot(kubeClient, dynamicClient, client, vzHelper, podLogs, clusterSnapshotCtx)
 }
 
 func RunCmdAnalyze(cmd *cobra.Command, vzHelper helpers.VZHelper, printReportToConsole bool) error {
 	directoryFlag := cmd.PersistentFlags().Lookup(constants.DirectoryFlagName)
-	if err := setVzK8sVersion(directoryFlag, vzHelper, cmd); err == nil {
+	reportFormat := getReportFormat(cmd)
+	// The versions are part of the machine readable reports, so they are only printed for the human readable reports
+	if err := setVzK8sVersion(directoryFlag, vzHelper, cmd); err == nil && !isMachineReadable(reportFormat) {
 		fmt.Fprintf(vzHelper.GetOutputStream(), helpers.GetVersionOut())
 	}
 	reportFileName, err := cmd.PersistentFlags().GetString(constants.ReportFileFlagName)
 	if err != nil {
 		fmt.Fprintf(vzHelper.GetOutputStream(), "error fetching flags: %s", err.Error())
 	}
-	reportFormat := getReportFormat(cmd)
+	failOnImpact := constants.FailOnImpactFlagDefault
+	if cmd.PersistentFlags().Lookup(constants.FailOnImpactFlagName) != nil {
+		failOnImpact, err = cmd.PersistentFlags().GetInt(constants.FailOnImpactFlagName)
+		if err != nil {
+			return fmt.Errorf("an error occurred while reading value for the flag %s: %s", constants.FailOnImpactFlagName, err.Error())
+		}
+	}
 
 	// set the flag to control the display the resources captured
 	isVerbose, err := cmd.PersistentFlags().GetBool(constants.VerboseFlag)
@@ -122,7 +146,47 @@ func RunCmdAnalyze(cmd *cobra.Command, vzHelper helpers.VZHelper, printReportToC
 			fmt.Fprintf(vzHelper.GetOutputStream(), "error fetching flags: %s", err.Error())
 		}
 	}
-	return analysis.AnalysisMain(vzHelper, directory, reportFileName, reportFormat, printReportToConsole)
+	if err := registerUserRules(cmd, vzHelper); err != nil {
+		return err
+	}
+	return analysis.AnalysisMain(vzHelper, directory, reportFileName, reportFormat, printReportToConsole, failOnImpact, getFlagValue(cmd, constants.BaselineFlagName))
+}
+
+// registerUserRules registers the analysis rules supplied through the rules-dir and rules-configmap flags
+func registerUserRules(cmd *cobra.Command, vzHelper helpers.VZHelper) error {
+	analysis.ResetRules()
+	rulesDir := getFlagValue(cmd, constants.RulesDirFlagName)
+	if rulesDir != "" {
+		if err := analysis.RegisterRulesFromDirectory(rulesDir); err != nil {
+			return err
+		}
+	}
+	rulesConfigMap := getFlagValue(cmd, constants.RulesConfigMapFlagName)
+	if rulesConfigMap == "" {
+		return nil
+	}
+	nsName := strings.SplitN(rulesConfigMap, "/", 2)
+	if len(nsName) != 2 || nsName[0] == "" || nsName[1] == "" {
+		return fmt.Errorf("%q is not valid for flag %s, the value must be specified as <namespace>/<name>", rulesConfigMap, constants.RulesConfigMapFlagName)
+	}
+	kubeClient, err := vzHelper.GetKubeClient(cmd)
+	if err != nil {
+		return err
+	}
+	configMap, err := kubeClient.CoreV1().ConfigMaps(nsName[0]).Get(context.TODO(), nsName[1], metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get the ConfigMap %s holding the analysis rules: %s", rulesConfigMap, err.Error())
+	}
+	return analysis.RegisterRulesFromData(rulesConfigMap, configMap.Data)
+}
+
+// getFlagValue returns the value of a persistent flag, or an empty string when the flag is not defined
+func getFlagValue(cmd *cobra.Command, flagName string) string {
+	flag := cmd.PersistentFlags().Lookup(flagName)
+	if flag == nil {
+		return ""
+	}
+	return flag.Value.String()
 }
 
 // setVzK8sVersion sets vz and k8s version
@@ -151,13 +215,19 @@ func setVzK8sVersion(directoryFlag *pflag.Flag, vzHelper helpers.VZHelper, cmd *
 func validateReportFormat(cmd *cobra.Command) error {
 	reportFormatValue := getReportFormat(cmd)
 	switch reportFormatValue {
-	case constants.SummaryReport, constants.DetailedReport:
+	case constants.SummaryReport, constants.DetailedReport, constants.JSONReport, constants.SARIFReport:
 		return nil
 	default:
-		return fmt.Errorf("%q is not valid for flag report-format, only %q and %q are valid", reportFormatValue, constants.SummaryReport, constants.DetailedReport)
+		return fmt.Errorf("%q is not valid for flag report-format, only %q, %q, %q and %q are valid", reportFormatValue,
+			constants.SummaryReport, constants.DetailedReport, constants.JSONReport, constants.SARIFReport)
 	}
 }
 
+// isMachineReadable returns true for the report formats intended for automation
+func isMachineReadable(reportFormat string) bool {
+	return reportFormat == constants.JSONReport || reportFormat == constants.SARIFReport
+}
+
 // getReportFormat returns the value set for flag report-format
 func getReportFormat(cmd *cobra.Command) string {
 	reportFormat := cmd.PersistentFlags().Lookup(constants.ReportFormatFlagName)
@@ -4,6 +4,7 @@
 package analyze
 
 import (
+	"encoding/json"
 	"github.com/stretchr/testify/assert"
 	"github.com/verrazzano/verrazzano/tools/vz/pkg/constants"
 	"github.com/verrazzano/verrazzano/tools/vz/test/helpers"
@@ -121,7 +122,7 @@ func TestAnalyzeCommandInvalidReportFormat(t *testing.T) {
 	assert.NotNil(t, err)
 	buf, err := os.ReadFile(stderrFile.Name())
 	assert.NoError(t, err)
-	assert.Contains(t, string(buf), "\"invalid-report-format\" is not valid for flag report-format, only \"summary\" and \"detailed\" are valid")
+	assert.Contains(t, string(buf), "\"invalid-report-format\" is not valid for flag report-format, only \"summary\", \"detailed\", \"json\" and \"sarif\" are valid")
 }
 
 // TestAnalyzeWithDefaultReportFormat
@@ -194,6 +195,121 @@ func TestAnalyzeCommandWithReportFile(t *testing.T) {
 	assert.FileExists(t, "TestAnalyzeCommandReportFileOutput")
 }
 
+// TestAnalyzeCommandJSONReport
+// GIVEN a CLI analyze command
+// WHEN I call cmd.Execute with report-format set to "json"
+// THEN expect the command to write a JSON report with the issues and their stable ids to stdout
+func TestAnalyzeCommandJSONReport(t *testing.T) {
+	stdoutFile, stderrFile := createStdTempFiles(t)
+	defer func() {
+		os.Remove(stdoutFile.Name())
+		os.Remove(stderrFile.Name())
+	}()
+	rc := helpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: stdoutFile, ErrOut: stderrFile})
+	cmd := NewCmdAnalyze(rc)
+	assert.NotNil(t, cmd)
+	cmd.PersistentFlags().Set(constants.DirectoryFlagName, ingressIPNotFound)
+	cmd.PersistentFlags().Set(constants.ReportFormatFlagName, constants.JSONReport)
+	err := cmd.Execute()
+	assert.Nil(t, err)
+	buf, err := os.ReadFile(stdoutFile.Name())
+	assert.NoError(t, err)
+	jsonReport := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(buf, &jsonReport))
+	assert.Equal(t, "1.0", jsonReport["schemaVersion"])
+	assert.Contains(t, string(buf), noIPFoundErr)
+	assert.Contains(t, string(buf), "\"id\": \"VZ-")
+}
+
+// TestAnalyzeCommandSARIFReport
+// GIVEN a CLI analyze command
+// WHEN I call cmd.Execute with report-format set to "sarif" and a report-file
+// THEN expect the command to write a SARIF log to the report file
+func TestAnalyzeCommandSARIFReport(t *testing.T) {
+	stdoutFile, stderrFile := createStdTempFiles(t)
+	reportFile := filepath.Join(t.TempDir(), "report.sarif")
+	defer func() {
+		os.Remove(stdoutFile.Name())
+		os.Remove(stderrFile.Name())
+	}()
+	rc := helpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: stdoutFile, ErrOut: stderrFile})
+	cmd := NewCmdAnalyze(rc)
+	assert.NotNil(t, cmd)
+	cmd.PersistentFlags().Set(constants.DirectoryFlagName, ingressIPNotFound)
+	cmd.PersistentFlags().Set(constants.ReportFormatFlagName, constants.SARIFReport)
+	cmd.PersistentFlags().Set(constants.ReportFileFlagName, reportFile)
+	err := cmd.Execute()
+	assert.Nil(t, err)
+	buf, err := os.ReadFile(reportFile)
+	assert.NoError(t, err)
+	sarifLog := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(buf, &sarifLog))
+	assert.Equal(t, "2.1.0", sarifLog["version"])
+	assert.Contains(t, string(buf), "\"ruleId\": \"IngressNoIPFound\"")
+}
+
+// TestAnalyzeCommandFailOnImpact
+// GIVEN a CLI analyze command
+// WHEN I call cmd.Execute with fail-on-impact set
+// THEN expect the command to fail only when an issue with at least that impact is reported
+func TestAnalyzeCommandFailOnImpact(t *testing.T) {
+	var tests = []struct {
+		name         string
+		failOnImpact string
+		expectErr    bool
+	}{
+		{"impact-reached", "10", true},
+		{"disabled", "-1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdoutFile, stderrFile := createStdTempFiles(t)
+			defer func() {
+				os.Remove(stdoutFile.Name())
+				os.Remove(stderrFile.Name())
+			}()
+			rc := helpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: stdoutFile, ErrOut: stderrFile})
+			cmd := NewCmdAnalyze(rc)
+			assert.NotNil(t, cmd)
+			cmd.PersistentFlags().Set(constants.DirectoryFlagName, ingressIPNotFound)
+			cmd.PersistentFlags().Set(constants.ReportFormatFlagName, constants.JSONReport)
+			cmd.PersistentFlags().Set(constants.FailOnImpactFlagName, tt.failOnImpact)
+			err := cmd.Execute()
+			if tt.expectErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), constants.FailOnImpactFlagName)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestAnalyzeCommandWithBaseline
+// GIVEN a CLI analyze command
+// WHEN I call cmd.Execute with a capture-dir and a baseline capture
+// THEN expect the command to report the changes since the baseline capture
+func TestAnalyzeCommandWithBaseline(t *testing.T) {
+	stdoutFile, stderrFile := createStdTempFiles(t)
+	defer func() {
+		os.Remove(stdoutFile.Name())
+		os.Remove(stderrFile.Name())
+	}()
+	rc := helpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: stdoutFile, ErrOut: stderrFile})
+	cmd := NewCmdAnalyze(rc)
+	assert.NotNil(t, cmd)
+	cmd.PersistentFlags().Set(constants.DirectoryFlagName, "../../pkg/analysis/test/baseline/after")
+	cmd.PersistentFlags().Set(constants.BaselineFlagName, "../../pkg/analysis/test/baseline/before")
+	cmd.PersistentFlags().Set(constants.ReportFormatFlagName, constants.JSONReport)
+	err := cmd.Execute()
+	assert.Nil(t, err)
+	buf, err := os.ReadFile(stdoutFile.Name())
+	assert.NoError(t, err)
+	assert.Contains(t, string(buf), "\"type\": \"NewlyFailingPods\"")
+	assert.Contains(t, string(buf), "\"type\": \"ImageChanges\"")
+	assert.NotContains(t, string(buf), "baseline/before/cluster-snapshot\",")
+}
+
 // TestAnalyzeCommandInvalidCapturedDir
 // GIVEN a CLI analyze command
 // WHEN I call cmd.Execute with capture-dir not containing the cluster snapshot
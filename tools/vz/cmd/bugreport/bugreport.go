@@ -45,6 +45,24 @@ vz bug-report --report-file bugreport.tgz --include-namespaces ns1,ns2 --include
    a. vz bug-report --report-file bugreport.tgz --include-namespaces ns1 --include-logs --duration 3h
    b. vz bug-report --report-file bugreport.tgz --include-namespaces ns1,ns2 --include-logs --duration 5m
    c. vz bug-report --report-file bugreport.tgz --include-namespaces ns1,ns2 --include-logs --duration 300s
+
+# Use the --redaction-policy flag to redact additional data, drop fields and omit namespaces from the bug report, as described by a YAML policy file:
+vz bug-report --report-file bugreport.tgz --include-namespaces ns1 --include-logs --redaction-policy redaction-policy.yaml
+
+The redaction policy file has the following format, the rules which fired are listed in redaction-manifest.json in the bug report:
+rules:
+- name: email
+  regex: "[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\\.[a-zA-Z]{2,}"
+- name: ocid
+  regex: "ocid1\\.[a-z0-9]+\\.[a-z0-9-]*\\.[a-z0-9-]*\\.[a-z0-9]+"
+  replacement: "REDACTED-OCID"
+dropPaths:
+- data
+- metadata.annotations.kubectl\\.kubernetes\\.io/last-applied-configuration
+omitNamespaces:
+- tenant-secrets
+allowList:
+- 127\\.0\\.0\\.1
 `
 )
 
@@ -67,6 +85,7 @@ func NewCmdBugReport(vzHelper helpers.VZHelper) *cobra.Command {
 	cmd.PersistentFlags().BoolP(constants.VerboseFlag, constants.VerboseFlagShorthand, constants.VerboseFlagDefault, constants.VerboseFlagUsage)
 	cmd.PersistentFlags().BoolP(constants.BugReportLogFlagName, constants.BugReportLogFlagNameShort, constants.BugReportLogFlagDefault, constants.BugReportLogFlagNameUsage)
 	cmd.PersistentFlags().DurationP(constants.BugReportTimeFlagName, constants.BugReportTimeFlagNameShort, constants.BugReportTimeFlagDefaultTime, constants.BugReportTimeFlagNameUsage)
+	cmd.PersistentFlags().String(constants.BugReportRedactionPolicyFlagName, "", constants.BugReportRedactionPolicyFlagUsage)
 	return cmd
 }
 
@@ -109,6 +128,19 @@ func runCmdBugReport(cmd *cobra.Command, args []string, vzHelper helpers.VZHelpe
 		return err
 	}
 
+	// Read the redaction policy provided using flag --redaction-policy, the default rules are applied without a policy
+	policyFile, err := cmd.PersistentFlags().GetString(constants.BugReportRedactionPolicyFlagName)
+	if err != nil {
+		return fmt.Errorf("an error occurred while reading value for the flag %s: %s", constants.BugReportRedactionPolicyFlagName, err.Error())
+	}
+	helpers.ResetRedactionPolicy()
+	defer helpers.ResetRedactionPolicy()
+	if policyFile != "" {
+		if err := helpers.LoadRedactionPolicy(policyFile); err != nil {
+			return err
+		}
+	}
+
 	// Create the bug report file
 	var bugRepFile *os.File
 	if bugReportFile == constants.BugReportFileDefaultValue {
@@ -180,6 +212,11 @@ func runCmdBugReport(cmd *cobra.Command, args []string, vzHelper helpers.VZHelpe
 			"Please go through errors (if any), in the standard output.\n")
 	}
 
+	// Include the list of the redaction rules which fired in the bug report
+	if err := helpers.WriteRedactionManifest(bugReportDir); err != nil {
+		return fmt.Errorf("an error occurred while creating the redaction manifest: %s", err.Error())
+	}
+
 	// Generate the bug report
 	err = helpers.CreateReportArchive(bugReportDir, bugRepFile)
 	if err != nil {
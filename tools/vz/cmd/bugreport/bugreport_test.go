@@ -151,6 +151,28 @@ func TestBugReportFileNoPermission(t *testing.T) {
 	assert.Contains(t, err.Error(), "permission denied")
 }
 
+// TestBugReportInvalidRedactionPolicy
+// GIVEN a CLI bug-report command with flag --redaction-policy pointing to a file which does not exist
+// WHEN I call cmd.Execute for bug-report
+// THEN expect an error and no bug report file
+func TestBugReportInvalidRedactionPolicy(t *testing.T) {
+	cmd := setUpandVerifyResources(t)
+
+	tmpDir, _ := os.MkdirTemp("", "bug-report")
+	defer cleanupTempDir(t, tmpDir)
+
+	reportFile := tmpDir + string(os.PathSeparator) + "bug-report.tgz"
+	setUpGlobalFlags(cmd)
+	err := cmd.PersistentFlags().Set(constants.BugReportFileFlagName, reportFile)
+	assert.NoError(t, err)
+	err = cmd.PersistentFlags().Set(constants.BugReportRedactionPolicyFlagName, tmpDir+string(os.PathSeparator)+"redaction-policy.yaml")
+	assert.NoError(t, err)
+	err = cmd.Execute()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "redaction policy")
+	assert.NoFileExists(t, reportFile)
+}
+
 // TestBugReportSuccess
 // GIVEN a CLI bug-report command with multiple flags
 // WHEN I call cmd.Execute
@@ -0,0 +1,316 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package helpers
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/verrazzano/verrazzano/pkg/helm"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzyaml "github.com/verrazzano/verrazzano/pkg/yaml"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common/override"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/transform"
+	"github.com/verrazzano/verrazzano/platform-operator/manifests/profiles"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/constants"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/github"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// operatorRootDir is the directory holding the Verrazzano files in the platform operator image, the chart
+	// directories and values files of the components are relative to it
+	operatorRootDir = "/verrazzano"
+
+	// profilesDir is the directory holding the profiles, relative to the Verrazzano root directory
+	profilesDir = "platform-operator/manifests/profiles"
+
+	effectiveCRFile = "verrazzano-effective.yaml"
+	componentsDir   = "components"
+	valuesFile      = "values.yaml"
+	manifestsFile   = "manifests.yaml"
+)
+
+// helmChartComponent is implemented by the components which are installed using a Helm chart
+type helmChartComponent interface {
+	GetReleaseName() string
+	GetChartDir() string
+	GetValuesFile() string
+}
+
+// AddDryRunFlags adds the flags used to render the effective configuration instead of installing or upgrading
+func AddDryRunFlags(cmd *cobra.Command, dryRunHelp string) {
+	cmd.PersistentFlags().Bool(constants.DryRunFlag, false, dryRunHelp)
+	cmd.PersistentFlags().String(constants.DryRunDirFlag, constants.DryRunDirFlagDefault, constants.DryRunDirFlagHelp)
+	cmd.PersistentFlags().String(constants.VerrazzanoRootFlag, "", constants.VerrazzanoRootFlagHelp)
+}
+
+// IsDryRun returns true when the dry run flag was specified
+func IsDryRun(cmd *cobra.Command) (bool, error) {
+	return cmd.PersistentFlags().GetBool(constants.DryRunFlag)
+}
+
+// RenderDryRun renders the effective Verrazzano configuration, computed by merging the profiles with the Verrazzano
+// resource, and the Helm values from the overrides of each enabled component to the dry run directory.  When the
+// Verrazzano root directory is specified, the profiles are read from it, the values files of the components are
+// included in the Helm values and the manifests of the Helm charts are rendered.  Otherwise, the profiles of the
+// given version are used.  The values which the platform operator computes at install time, such as the images from
+// the BOM, are not included.
+func RenderDryRun(cmd *cobra.Command, vzHelper helpers.VZHelper, vz clipkg.Object, version string) error {
+	outputDir, err := cmd.PersistentFlags().GetString(constants.DryRunDirFlag)
+	if err != nil {
+		return err
+	}
+	rootDir, err := cmd.PersistentFlags().GetString(constants.VerrazzanoRootFlag)
+	if err != nil {
+		return err
+	}
+	effectiveCR, err := GetEffectiveVerrazzano(cmd, vzHelper, vz, version)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Join(outputDir, componentsDir), 0755); err != nil {
+		return fmt.Errorf("Failed to create the dry run directory %s: %s", outputDir, err.Error())
+	}
+	effectiveYAML, err := yaml.Marshal(effectiveCR)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, effectiveCRFile), effectiveYAML, 0600); err != nil {
+		return err
+	}
+
+	var client clipkg.Client
+	var rendered []string
+	for _, comp := range registry.GetComponents() {
+		if !comp.IsEnabled(effectiveCR) {
+			continue
+		}
+		overrides, _ := comp.GetOverrides(effectiveCR).([]v1beta1.Overrides)
+		// The client is only needed to read the overrides from ConfigMaps and Secrets
+		if client == nil && hasOverrideRefs(overrides) {
+			client, err = vzHelper.GetClient(cmd)
+			if err != nil {
+				return err
+			}
+		}
+		overrideStrings, err := override.GetInstallOverridesYAMLUsingClient(client, overrides, effectiveCR.Namespace)
+		if err != nil {
+			return fmt.Errorf("Failed to get the overrides of component %s: %s", comp.Name(), err.Error())
+		}
+
+		helmComp, isHelm := comp.(helmChartComponent)
+		var chartValuesFile string
+		if isHelm && rootDir != "" && helmComp.GetValuesFile() != "" {
+			chartValuesFile = relocateOperatorPath(helmComp.GetValuesFile(), rootDir)
+		}
+		values, err := mergeComponentValues(chartValuesFile, overrideStrings)
+		if err != nil {
+			return fmt.Errorf("Failed to merge the Helm values of component %s: %s", comp.Name(), err.Error())
+		}
+
+		compDir := filepath.Join(outputDir, componentsDir, comp.Name())
+		if err := os.MkdirAll(compDir, 0755); err != nil {
+			return err
+		}
+		compValuesFile := filepath.Join(compDir, valuesFile)
+		if err := os.WriteFile(compValuesFile, []byte(values), 0600); err != nil {
+			return err
+		}
+
+		if isHelm && rootDir != "" && helmComp.GetChartDir() != "" {
+			manifests, err := helm.Template(vzlog.DefaultLogger(), helmComp.GetReleaseName(), comp.Namespace(),
+				relocateOperatorPath(helmComp.GetChartDir(), rootDir), []helm.HelmOverrides{{FileOverride: compValuesFile}})
+			if err != nil {
+				// Charts can require values which the platform operator computes at install time, render the other charts
+				fmt.Fprintf(vzHelper.GetErrorStream(), "Unable to render the Helm chart of component %s, the chart may require values computed by the platform operator: %s\n", comp.Name(), err.Error())
+			} else if err := os.WriteFile(filepath.Join(compDir, manifestsFile), []byte(manifests), 0600); err != nil {
+				return err
+			}
+		}
+		rendered = append(rendered, comp.Name())
+	}
+
+	fmt.Fprintf(vzHelper.GetOutputStream(), "Rendered the effective Verrazzano configuration and the Helm values of %d enabled components to %s\n", len(rendered), outputDir)
+	fmt.Fprintf(vzHelper.GetOutputStream(), "Enabled components: %s\n", strings.Join(rendered, ", "))
+	return nil
+}
+
+// GetEffectiveVerrazzano merges the profiles with the Verrazzano resource created from the command line, the same way
+// as the platform operator.  The profiles are read from the Verrazzano root directory when specified, otherwise the
+// profiles of the given version are downloaded.  The profiles embedded in the CLI are used when the version is not
+// known.
+func GetEffectiveVerrazzano(cmd *cobra.Command, vzHelper helpers.VZHelper, vz clipkg.Object, version string) (*v1beta1.Verrazzano, error) {
+	rootDir, err := cmd.PersistentFlags().GetString(constants.VerrazzanoRootFlag)
+	if err != nil {
+		return nil, err
+	}
+	actualCR, err := toV1beta1Verrazzano(vz)
+	if err != nil {
+		return nil, err
+	}
+	if actualCR.Namespace == "" {
+		actualCR.Namespace = "default"
+	}
+
+	profilesRoot := filepath.Join(rootDir, profilesDir)
+	if rootDir == "" {
+		if version != "" {
+			profilesRoot, err = writeVersionProfiles(vzHelper, version)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get the profiles of Verrazzano version %s, the --%s flag can be used to read them from the Verrazzano source: %s",
+					version, constants.VerrazzanoRootFlag, err.Error())
+			}
+		} else {
+			profilesRoot, err = writeEmbeddedProfiles()
+			if err != nil {
+				return nil, err
+			}
+		}
+		defer os.RemoveAll(profilesRoot)
+	}
+	effectiveCR, err := transform.GetEffectiveV1beta1CRUsingProfilesDir(actualCR, profilesRoot)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to merge the profiles with the Verrazzano resource: %s", err.Error())
+	}
+	return effectiveCR, nil
+}
+
+// toV1beta1Verrazzano converts the Verrazzano resource created from the command line to a v1beta1 Verrazzano resource
+func toV1beta1Verrazzano(vz clipkg.Object) (*v1beta1.Verrazzano, error) {
+	switch obj := vz.(type) {
+	case *v1beta1.Verrazzano:
+		return obj.DeepCopy(), nil
+	case *v1alpha1.Verrazzano:
+		vzV1beta1 := &v1beta1.Verrazzano{}
+		err := obj.ConvertTo(vzV1beta1)
+		return vzV1beta1, err
+	case *unstructured.Unstructured:
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return nil, err
+		}
+		if obj.GroupVersionKind().GroupVersion() == v1alpha1.SchemeGroupVersion {
+			vzV1alpha1 := &v1alpha1.Verrazzano{}
+			if err := yaml.Unmarshal(data, vzV1alpha1); err != nil {
+				return nil, err
+			}
+			return toV1beta1Verrazzano(vzV1alpha1)
+		}
+		vzV1beta1 := &v1beta1.Verrazzano{}
+		err = yaml.Unmarshal(data, vzV1beta1)
+		return vzV1beta1, err
+	}
+	return nil, fmt.Errorf("Unexpected type %T for the Verrazzano resource", vz)
+}
+
+// writeEmbeddedProfiles writes the profiles embedded in the CLI to a temporary directory
+func writeEmbeddedProfiles() (string, error) {
+	tmpDir, err := os.MkdirTemp("", "vz-profiles-*")
+	if err != nil {
+		return "", err
+	}
+	embedded := profiles.GetEmbeddedProfiles()
+	err = fs.WalkDir(embedded, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return os.MkdirAll(filepath.Join(tmpDir, path), 0755)
+		}
+		data, err := embedded.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(tmpDir, path), data, 0600)
+	})
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return "", err
+	}
+	return tmpDir, nil
+}
+
+// writeVersionProfiles downloads the v1beta1 profiles of a Verrazzano version to a temporary directory, the profiles
+// embedded in the CLI give the names of the profile files
+func writeVersionProfiles(vzHelper helpers.VZHelper, version string) (string, error) {
+	apiVersion := v1beta1.SchemeGroupVersion.Version
+	entries, err := profiles.GetEmbeddedProfiles().ReadDir(apiVersion)
+	if err != nil {
+		return "", err
+	}
+	tmpDir, err := os.MkdirTemp("", "vz-profiles-*")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Join(tmpDir, apiVersion), 0755); err != nil {
+		os.RemoveAll(tmpDir)
+		return "", err
+	}
+	for _, entry := range entries {
+		data, err := github.GetProfile(vzHelper.GetHTTPClient(), version, apiVersion, entry.Name())
+		if err == nil {
+			err = os.WriteFile(filepath.Join(tmpDir, apiVersion, entry.Name()), data, 0600)
+		}
+		if err != nil {
+			os.RemoveAll(tmpDir)
+			return "", err
+		}
+	}
+	return tmpDir, nil
+}
+
+// mergeComponentValues merges the values file of the component chart with the override values.  The first override
+// has the highest precedence, the same as when the platform operator passes the overrides to Helm.
+func mergeComponentValues(chartValuesFile string, overrideStrings []string) (string, error) {
+	values := map[string]interface{}{}
+	if chartValuesFile != "" {
+		data, err := os.ReadFile(chartValuesFile)
+		if err != nil {
+			return "", err
+		}
+		if err := yaml.Unmarshal(data, &values); err != nil {
+			return "", err
+		}
+	}
+	for i := len(overrideStrings) - 1; i >= 0; i-- {
+		overrideValues := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(overrideStrings[i]), &overrideValues); err != nil {
+			return "", err
+		}
+		if err := vzyaml.MergeMaps(values, overrideValues); err != nil {
+			return "", err
+		}
+	}
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// relocateOperatorPath relocates a path in the platform operator image to the Verrazzano root directory
+func relocateOperatorPath(path string, rootDir string) string {
+	return filepath.Join(rootDir, strings.TrimPrefix(path, operatorRootDir))
+}
+
+func hasOverrideRefs(overrides []v1beta1.Overrides) bool {
+	for _, o := range overrides {
+		if o.ConfigMapRef != nil || o.SecretRef != nil {
+			return true
+		}
+	}
+	return false
+}
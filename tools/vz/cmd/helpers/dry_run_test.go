@@ -0,0 +1,110 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package helpers
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/constants"
+	testhelpers "github.com/verrazzano/verrazzano/tools/vz/test/helpers"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/yaml"
+)
+
+// the root of the Verrazzano source, relative to this package
+const testVerrazzanoRoot = "../../../.."
+
+// TestRenderDryRunWithVerrazzanoRoot tests rendering the effective configuration using a Verrazzano root directory
+// GIVEN a Verrazzano resource using the none profile with the Coherence operator enabled
+//
+//	WHEN RenderDryRun is called with the Verrazzano root directory
+//	THEN the values file of the chart is merged with the overrides and the chart manifests are rendered
+func TestRenderDryRunWithVerrazzanoRoot(t *testing.T) {
+	enabled := true
+	vz := &v1beta1.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Name: "verrazzano"},
+		Spec: v1beta1.VerrazzanoSpec{
+			Profile: v1beta1.None,
+			Components: v1beta1.ComponentSpec{
+				CoherenceOperator: &v1beta1.CoherenceOperatorComponent{
+					Enabled: &enabled,
+					InstallOverrides: v1beta1.InstallOverrides{
+						ValueOverrides: []v1beta1.Overrides{{Values: &apiextensionsv1.JSON{Raw: []byte(`{"replicas": 5}`)}}},
+					},
+				},
+			},
+		},
+	}
+	dryRunDir := t.TempDir()
+	cmd, buf, errBuf := newDryRunTestCommand(t, dryRunDir, testVerrazzanoRoot)
+
+	err := RenderDryRun(cmd, testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: buf, ErrOut: errBuf}), vz, "")
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "Enabled components: verrazzano-network-policies, coherence-operator")
+
+	data, err := os.ReadFile(filepath.Join(dryRunDir, componentsDir, "coherence-operator", valuesFile))
+	assert.NoError(t, err)
+	values := map[string]interface{}{}
+	assert.NoError(t, yaml.Unmarshal(data, &values))
+	assert.Equal(t, float64(5), values["replicas"])
+
+	manifests, err := os.ReadFile(filepath.Join(dryRunDir, componentsDir, "coherence-operator", manifestsFile))
+	assert.NoError(t, err)
+	assert.Contains(t, string(manifests), "replicas: 5")
+}
+
+// TestToV1beta1Verrazzano tests converting the Verrazzano resource created from the command line
+// GIVEN Verrazzano resources of the supported types
+//
+//	WHEN toV1beta1Verrazzano is called
+//	THEN a v1beta1 Verrazzano resource is returned
+func TestToV1beta1Verrazzano(t *testing.T) {
+	vz, err := toV1beta1Verrazzano(&v1alpha1.Verrazzano{ObjectMeta: metav1.ObjectMeta{Name: "a"}, Spec: v1alpha1.VerrazzanoSpec{Profile: v1alpha1.Dev}})
+	assert.NoError(t, err)
+	assert.Equal(t, "a", vz.Name)
+	assert.Equal(t, v1beta1.Dev, vz.Spec.Profile)
+
+	obj := &unstructured.Unstructured{}
+	assert.NoError(t, yaml.Unmarshal([]byte("apiVersion: install.verrazzano.io/v1alpha1\nkind: Verrazzano\nmetadata:\n  name: b\nspec:\n  profile: dev\n  components:\n    elasticsearch:\n      enabled: false\n"), obj))
+	vz, err = toV1beta1Verrazzano(obj)
+	assert.NoError(t, err)
+	assert.Equal(t, "b", vz.Name)
+	assert.False(t, *vz.Spec.Components.OpenSearch.Enabled)
+
+	_, err = toV1beta1Verrazzano(&corev1.ConfigMap{})
+	assert.Error(t, err)
+}
+
+// TestMergeComponentValues tests merging the Helm values of a component
+// GIVEN a values file and overrides
+//
+//	WHEN mergeComponentValues is called
+//	THEN the first override has the highest precedence and the values file the lowest
+func TestMergeComponentValues(t *testing.T) {
+	valuesFile := filepath.Join(t.TempDir(), "values.yaml")
+	assert.NoError(t, os.WriteFile(valuesFile, []byte("a: 1\nb: 1\nc: 1\n"), 0600))
+	merged, err := mergeComponentValues(valuesFile, []string{"a: 3\n", "a: 2\nb: 2\n"})
+	assert.NoError(t, err)
+	assert.Equal(t, "a: 3\nb: 2\nc: 1\n", merged)
+}
+
+func newDryRunTestCommand(t *testing.T, dryRunDir string, rootDir string) (*cobra.Command, *bytes.Buffer, *bytes.Buffer) {
+	cmd := &cobra.Command{}
+	AddDryRunFlags(cmd, "dry run")
+	assert.NoError(t, cmd.PersistentFlags().Set(constants.DryRunFlag, "true"))
+	assert.NoError(t, cmd.PersistentFlags().Set(constants.DryRunDirFlag, dryRunDir))
+	assert.NoError(t, cmd.PersistentFlags().Set(constants.VerrazzanoRootFlag, rootDir))
+	return cmd, new(bytes.Buffer), new(bytes.Buffer)
+}
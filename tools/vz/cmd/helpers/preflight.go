@@ -0,0 +1,73 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package helpers
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/verrazzano/verrazzano/pkg/vzchecks"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/constants"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/github"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	"k8s.io/client-go/kubernetes"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RunPreflightChecks runs the pre-flight checks of the cluster for the effective configuration of the verrazzano
+// install resource, and returns an error when a check fails.  Installed is true when Verrazzano is upgraded.
+// Unlike the checks of the platform operator webhook, these checks reach the image registry and get the Kubernetes
+// versions supported by the version being installed or upgraded to from its BOM.
+func RunPreflightChecks(cmd *cobra.Command, vzHelper helpers.VZHelper, client clipkg.Client, kubeClient kubernetes.Interface, vz clipkg.Object, version string, installed bool) error {
+	imageRegistry, err := cmd.PersistentFlags().GetString(constants.ImageRegistryFlag)
+	if err != nil {
+		return err
+	}
+	effectiveCR, err := GetEffectiveVerrazzano(cmd, vzHelper, vz, version)
+	if err != nil {
+		return err
+	}
+	profile := vzchecks.ProfileType(effectiveCR.Spec.Profile)
+	if profile == "" {
+		profile = vzchecks.Prod
+	}
+	var ingressType string
+	if effectiveCR.Spec.Components.IngressNGINX != nil {
+		ingressType = string(effectiveCR.Spec.Components.IngressNGINX.Type)
+	}
+
+	// The supported Kubernetes versions are only known for a release version, the Kubernetes version
+	// is not checked when installing from manifests
+	var supportedKubernetesVersions []string
+	if version != "" {
+		supportedKubernetesVersions, err = github.GetSupportedKubernetesVersions(vzHelper.GetHTTPClient(), version)
+		if err != nil {
+			fmt.Fprintf(vzHelper.GetOutputStream(), "Unable to get the Kubernetes versions supported by Verrazzano %s, the Kubernetes version will not be checked: %v\n", version, err)
+		}
+	}
+
+	fmt.Fprintf(vzHelper.GetOutputStream(), "Running the pre-flight checks\n")
+	results := vzchecks.RunPreflightChecks(vzchecks.PreflightContext{
+		Client:                      client,
+		KubeClient:                  kubeClient,
+		HTTPClient:                  vzHelper.GetHTTPClient(),
+		Profile:                     profile,
+		Verrazzano:                  effectiveCR,
+		IngressType:                 ingressType,
+		ImageRegistry:               imageRegistry,
+		SupportedKubernetesVersions: supportedKubernetesVersions,
+		Installed:                   installed,
+	})
+	for _, result := range results {
+		fmt.Fprintf(vzHelper.GetOutputStream(), "%s\n", result.String())
+	}
+	if vzchecks.HasPreflightFailure(results) {
+		operation := "installing"
+		if installed {
+			operation = "upgrading"
+		}
+		return fmt.Errorf("The pre-flight checks failed, fix the issues reported before %s Verrazzano", operation)
+	}
+	return nil
+}
@@ -50,6 +50,13 @@ vz install --version v%[1]s --set profile=dev --set components.kiali.enabled=fal
 vz install -f base.yaml,custom.yaml --set profile=prod --log-format json
 vz install -f base.yaml -f custom.yaml --set profile=prod --log-format json
 
+# Render the effective Verrazzano configuration and the Helm values of each enabled component to the vz-dry-run
+# directory for review, instead of installing.
+vz install -f custom.yaml --set profile=dev --dry-run --dry-run-dir vz-dry-run
+
+# Check that the cluster is ready for a prod install before installing the latest version of Verrazzano.
+vz install --set profile=prod --preflight
+
 # Install the latest version of Verrazzano using a Verrazzano CR specified with stdin.
 vz install -f - <<EOF
 apiVersion: install.verrazzano.io/v1beta1
@@ -93,6 +100,7 @@ func NewCmdInstall(vzHelper helpers.VZHelper) *cobra.Command {
 	cmd.PersistentFlags().Var(&logsEnum, constants.LogFormatFlag, constants.LogFormatHelp)
 	cmd.PersistentFlags().StringArrayP(constants.SetFlag, constants.SetFlagShorthand, []string{}, constants.SetFlagHelp)
 	cmd.PersistentFlags().Bool(constants.AutoBugReportFlag, constants.AutoBugReportFlagDefault, constants.AutoBugReportFlagHelp)
+	cmd.PersistentFlags().Bool(constants.PreflightFlag, false, constants.PreflightFlagHelp)
 	// Private registry support
 	cmd.PersistentFlags().String(constants.ImageRegistryFlag, constants.ImageRegistryFlagDefault, constants.ImageRegistryFlagHelp)
 	cmd.PersistentFlags().String(constants.ImagePrefixFlag, constants.ImagePrefixFlagDefault, constants.ImagePrefixFlagHelp)
@@ -103,9 +111,8 @@ func NewCmdInstall(vzHelper helpers.VZHelper) *cobra.Command {
 	// Add flags related to specifying the platform operator manifests as a local file or a URL
 	cmdhelpers.AddManifestsFlags(cmd)
 
-	// Dry run flag is still being discussed - keep hidden for now
-	cmd.PersistentFlags().Bool(constants.DryRunFlag, false, "Simulate an install.")
-	cmd.PersistentFlags().MarkHidden(constants.DryRunFlag)
+	// Add flags related to rendering the effective configuration instead of installing
+	cmdhelpers.AddDryRunFlags(cmd, "Render the effective Verrazzano configuration and the Helm values of each enabled component to the --dry-run-dir directory, instead of installing.")
 
 	// Hide the flag for overriding the default wait timeout for the platform-operator
 	cmd.PersistentFlags().MarkHidden(constants.VPOTimeoutFlag)
@@ -132,6 +139,15 @@ func runCmdInstall(cmd *cobra.Command, args []string, vzHelper helpers.VZHelper)
 		return err
 	}
 
+	// Render the effective configuration instead of installing
+	dryRun, err := cmdhelpers.IsDryRun(cmd)
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		return runDryRun(cmd, vzHelper)
+	}
+
 	// Get the kubernetes clientset.  This will validate that the kubeconfig and context are valid.
 	kubeClient, err := vzHelper.GetKubeClient(cmd)
 	if err != nil {
@@ -212,6 +228,17 @@ func runCmdInstall(cmd *cobra.Command, args []string, vzHelper helpers.VZHelper)
 			return err
 		}
 
+		// Run the pre-flight checks before changing anything in the cluster
+		preflight, err := cmd.PersistentFlags().GetBool(constants.PreflightFlag)
+		if err != nil {
+			return err
+		}
+		if preflight {
+			if err = cmdhelpers.RunPreflightChecks(cmd, vzHelper, client, kubeClient, vz, version, false); err != nil {
+				return err
+			}
+		}
+
 		// Delete leftover verrazzano-platform-operator deployments after an abort.
 		// This allows for the verrazzano-platform-operator validatingWebhookConfiguration to be updated with the correct caBundle.
 		err = cmdhelpers.DeleteFunc(client)
@@ -278,6 +305,23 @@ func installVerrazzano(cmd *cobra.Command, vzHelper helpers.VZHelper, vz clipkg.
 	return nil
 }
 
+// runDryRun renders the effective configuration of the verrazzano install resource which would be created
+func runDryRun(cmd *cobra.Command, vzHelper helpers.VZHelper) error {
+	var version string
+	var err error
+	if !cmdhelpers.ManifestsFlagChanged(cmd) {
+		version, err = cmdhelpers.GetVersion(cmd, vzHelper)
+		if err != nil {
+			return err
+		}
+	}
+	vz, _, err := getVerrazzanoYAML(cmd, vzHelper, version)
+	if err != nil {
+		return err
+	}
+	return cmdhelpers.RenderDryRun(cmd, vzHelper, vz, version)
+}
+
 // getVerrazzanoYAML returns the verrazzano install resource to be created
 func getVerrazzanoYAML(cmd *cobra.Command, vzHelper helpers.VZHelper, version string) (vz clipkg.Object, obj *unstructured.Unstructured, err error) {
 	// Get the list yaml filenames specified
@@ -21,7 +21,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	discoveryfake "k8s.io/client-go/discovery/fake"
 	"os"
 	"path/filepath"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -369,6 +371,86 @@ func TestInstallCmdSets(t *testing.T) {
 	assert.Equal(t, "test", vz.Spec.EnvironmentName)
 }
 
+// TestInstallCmdDryRun
+// GIVEN a CLI install command with --dry-run and --filename and --set specified
+//
+//	WHEN I call cmd.Execute for install
+//	THEN the effective configuration and the Helm values of the enabled components are rendered and Verrazzano is not installed
+func TestInstallCmdDryRun(t *testing.T) {
+	overridesCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "keycloak-overrides"},
+		Data:       map[string]string{"values.yaml": "replicas: 3\nservice:\n  type: ClusterIP\n"},
+	}
+	c := fake.NewClientBuilder().WithScheme(helpers.NewScheme()).WithObjects(overridesCM).Build()
+	cmd, buf, errBuf, _ := createNewTestCommandAndBuffers(t, c)
+	dryRunDir := t.TempDir()
+	cmd.PersistentFlags().Set(constants.FilenameFlag, "../../test/testdata/dry-run-overrides.yaml")
+	cmd.PersistentFlags().Set(constants.SetFlag, "environmentName=test")
+	cmd.PersistentFlags().Set(constants.DryRunFlag, "true")
+	cmd.PersistentFlags().Set(constants.DryRunDirFlag, dryRunDir)
+
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, "", errBuf.String())
+	assert.Contains(t, buf.String(), "Rendered the effective Verrazzano configuration")
+
+	// The effective configuration has the profile merged with the resource
+	data, err := os.ReadFile(filepath.Join(dryRunDir, "verrazzano-effective.yaml"))
+	assert.NoError(t, err)
+	effectiveCR := v1beta1.Verrazzano{}
+	assert.NoError(t, yaml.Unmarshal(data, &effectiveCR))
+	assert.Equal(t, "test", effectiveCR.Spec.EnvironmentName)
+	assert.NotNil(t, effectiveCR.Spec.Components.Keycloak)
+
+	// The first override has the highest precedence
+	data, err = os.ReadFile(filepath.Join(dryRunDir, "components", "keycloak", "values.yaml"))
+	assert.NoError(t, err)
+	values := map[string]interface{}{}
+	assert.NoError(t, yaml.Unmarshal(data, &values))
+	assert.Equal(t, float64(2), values["replicas"])
+	assert.Equal(t, map[string]interface{}{"type": "ClusterIP"}, values["service"])
+
+	// Disabled components are not rendered
+	assert.NoDirExists(t, filepath.Join(dryRunDir, "components", "rancher"))
+
+	// Verrazzano is not installed
+	vzList := v1beta1.VerrazzanoList{}
+	assert.NoError(t, c.List(context.TODO(), &vzList))
+	assert.Empty(t, vzList.Items)
+}
+
+// TestInstallCmdPreflightFailure
+// GIVEN a CLI install command with --preflight and a cluster without worker nodes, running a Kubernetes version
+// that is not supported by the BOM of the Verrazzano version being installed
+//
+//	WHEN I call cmd.Execute for install
+//	THEN the pre-flight check results are displayed and Verrazzano is not installed
+func TestInstallCmdPreflightFailure(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(helpers.NewScheme()).WithObjects(testhelpers.CreateTestVPOObjects()...).Build()
+	cmd, buf, _, rc := createNewTestCommandAndBuffers(t, c)
+	kubeClient, _ := rc.GetKubeClient(cmd)
+	kubeClient.Discovery().(*discoveryfake.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.20.0"}
+	cmd.PersistentFlags().Set(constants.PreflightFlag, "true")
+	cmd.PersistentFlags().Set(constants.SetFlag, "profile=prod")
+	cmd.PersistentFlags().Set(constants.WaitFlag, "false")
+	cmdHelpers.SetDeleteFunc(cmdHelpers.FakeDeleteFunc)
+	defer cmdHelpers.SetDefaultDeleteFunc()
+
+	// Run install command
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "The pre-flight checks failed")
+	assert.Contains(t, buf.String(), "[fail] node-resources")
+	assert.Contains(t, buf.String(), "[pass] image-registry")
+	assert.Contains(t, buf.String(), "[fail] kubernetes-version")
+	assert.Contains(t, buf.String(), "v1.21.0, v1.22.0, v1.23.0, v1.24.0")
+
+	// Verify the vz resource was not created
+	vz := v1alpha1.Verrazzano{}
+	err = c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "verrazzano"}, &vz)
+	assert.Error(t, err)
+}
+
 // TestInstallCmdFilenamesAndSets
 // GIVEN a CLI install command with defaults and --wait=false and --filename and --set specified
 //
@@ -0,0 +1,130 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package plan
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	vzplan "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/plan"
+	cmdhelpers "github.com/verrazzano/verrazzano/tools/vz/cmd/helpers"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/constants"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	CommandName = "plan"
+	helpShort   = "Preview the order in which the Verrazzano components are installed or upgraded"
+	helpLong    = `The command 'plan' shows the plan of the last install or upgrade computed by the Verrazzano Platform Operator: the order in which the components are processed, the components which are skipped and the dependency cycles between the components. With --filename, the install plan of a Verrazzano resource is computed locally, without a cluster. The plan can be rendered as text or as a dependency graph in the Graphviz DOT language.`
+	helpExample = `
+# Show the plan of the last install or upgrade
+vz plan
+
+# Preview the install plan of a Verrazzano resource
+vz plan --filename verrazzano.yaml
+
+# Render the dependency graph of the components as an image
+vz plan --filename verrazzano.yaml --output dot | dot -Tsvg > plan.svg`
+)
+
+// OutputFormat is the format of the plan output
+type OutputFormat string
+
+const (
+	OutputFormatText OutputFormat = "text"
+	OutputFormatDOT  OutputFormat = "dot"
+)
+
+// Implement the pflag.Value interface to support validating the output format options
+
+func (f *OutputFormat) String() string {
+	return string(*f)
+}
+
+// Type is only used in help text
+func (f *OutputFormat) Type() string {
+	return "format"
+}
+
+// Set must have pointer receiver so it doesn't change the value of a copy
+func (f *OutputFormat) Set(value string) error {
+	switch value {
+	case string(OutputFormatText), string(OutputFormatDOT):
+		*f = OutputFormat(value)
+		return nil
+	default:
+		return fmt.Errorf("allowed values are %q and %q", string(OutputFormatText), string(OutputFormatDOT))
+	}
+}
+
+func NewCmdPlan(vzHelper helpers.VZHelper) *cobra.Command {
+	cmd := cmdhelpers.NewCommand(vzHelper, CommandName, helpShort, helpLong)
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runCmdPlan(cmd, vzHelper)
+	}
+	cmd.Example = helpExample
+
+	output := OutputFormatText
+	cmd.PersistentFlags().VarP(&output, constants.OutputFlag, constants.OutputFlagShorthand, constants.PlanOutputFlagHelp)
+	cmd.PersistentFlags().StringSliceP(constants.FilenameFlag, constants.FilenameFlagShorthand, []string{}, constants.FilenameFlagHelp)
+	cmd.PersistentFlags().String(constants.VerrazzanoRootFlag, "", constants.VerrazzanoRootFlagHelp)
+
+	return cmd
+}
+
+// runCmdPlan - run the "vz plan" command
+func runCmdPlan(cmd *cobra.Command, vzHelper helpers.VZHelper) error {
+	filenames, err := cmd.PersistentFlags().GetStringSlice(constants.FilenameFlag)
+	if err != nil {
+		return err
+	}
+	var p *vzplan.Plan
+	if len(filenames) > 0 {
+		p, err = computeInstallPlan(cmd, vzHelper, filenames)
+	} else {
+		p, err = getPublishedPlan(cmd, vzHelper)
+	}
+	if err != nil {
+		return err
+	}
+
+	output := cmd.PersistentFlags().Lookup(constants.OutputFlag).Value.String()
+	if output == string(OutputFormatDOT) {
+		fmt.Fprint(vzHelper.GetOutputStream(), p.DOT())
+	} else {
+		fmt.Fprint(vzHelper.GetOutputStream(), p.Text())
+	}
+	return nil
+}
+
+// computeInstallPlan computes the install plan of the Verrazzano resource in the files, from the components of the CLI
+func computeInstallPlan(cmd *cobra.Command, vzHelper helpers.VZHelper, filenames []string) (*vzplan.Plan, error) {
+	obj, err := cmdhelpers.MergeYAMLFiles(filenames, os.Stdin)
+	if err != nil {
+		return nil, err
+	}
+	effectiveCR, err := cmdhelpers.GetEffectiveVerrazzano(cmd, vzHelper, obj, "")
+	if err != nil {
+		return nil, err
+	}
+	// The version is not known until the install, so the minimum versions of the components are not checked
+	return vzplan.NewPlan(registry.GetComponents(), effectiveCR, vzplan.InstallOperation, ""), nil
+}
+
+// getPublishedPlan returns the plan of the last install or upgrade published by the platform operator
+func getPublishedPlan(cmd *cobra.Command, vzHelper helpers.VZHelper) (*vzplan.Plan, error) {
+	client, err := vzHelper.GetClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+	p, err := vzplan.Get(context.TODO(), client)
+	if errors.IsNotFound(err) {
+		return nil, fmt.Errorf("No plan has been published by the Verrazzano Platform Operator, use --%s to preview the install plan of a Verrazzano resource", constants.FilenameFlag)
+	}
+	return p, err
+}
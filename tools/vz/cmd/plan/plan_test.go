@@ -0,0 +1,86 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package plan
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vzplan "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/plan"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/constants"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	testhelpers "github.com/verrazzano/verrazzano/tools/vz/test/helpers"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const devProfileFile = "../../test/testdata/dev-profile.yaml"
+
+// TestPlanCmdFilename tests previewing the install plan of a Verrazzano resource
+// GIVEN a Verrazzano resource with the dev profile
+// WHEN I run the command vz plan --filename
+// THEN the install plan is computed locally, with the components ordered by wave
+func TestPlanCmdFilename(t *testing.T) {
+	buf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: buf, ErrOut: errBuf})
+	cmd := NewCmdPlan(rc)
+	assert.NotNil(t, cmd)
+	cmd.PersistentFlags().Set(constants.FilenameFlag, devProfileFile)
+
+	assert.NoError(t, cmd.Execute())
+	assert.Contains(t, buf.String(), "Component install plan\nWave 1:\n")
+	assert.Contains(t, buf.String(), "install cluster-issuer (depends on verrazzano-network-policies, cert-manager)")
+	assert.Contains(t, buf.String(), "Skipped:\n  fluent-operator (disabled)\n")
+	assert.NotContains(t, buf.String(), "Dependency cycles:")
+}
+
+// TestPlanCmdDOT tests rendering the dependency graph of the install plan
+// GIVEN a Verrazzano resource with the dev profile
+// WHEN I run the command vz plan --filename --output dot
+// THEN the plan is rendered in the DOT language
+func TestPlanCmdDOT(t *testing.T) {
+	buf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: buf, ErrOut: errBuf})
+	cmd := NewCmdPlan(rc)
+	cmd.PersistentFlags().Set(constants.FilenameFlag, devProfileFile)
+	cmd.PersistentFlags().Set(constants.OutputFlag, string(OutputFormatDOT))
+
+	assert.NoError(t, cmd.Execute())
+	assert.Contains(t, buf.String(), `digraph "verrazzano-install" {`)
+	assert.Contains(t, buf.String(), `"cert-manager" [label="cert-manager\nwave 2"];`)
+
+	assert.Error(t, cmd.PersistentFlags().Set(constants.OutputFlag, "yaml"))
+}
+
+// TestPlanCmdPublished tests showing the plan published by the platform operator
+// GIVEN a cluster with a published upgrade plan, and a cluster without a plan
+// WHEN I run the command vz plan
+// THEN the published plan is shown, or an error is returned when there is no plan
+func TestPlanCmdPublished(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(helpers.NewScheme()).Build()
+	assert.NoError(t, vzplan.Publish(context.TODO(), c, &vzplan.Plan{
+		Operation: vzplan.UpgradeOperation,
+		Version:   "1.5.0",
+		Steps:     []vzplan.Step{{Component: "istio", Action: vzplan.ActionUpgrade, Wave: 1}},
+	}))
+
+	buf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: buf, ErrOut: errBuf})
+	rc.SetClient(c)
+	cmd := NewCmdPlan(rc)
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "Component upgrade plan for Verrazzano version 1.5.0\n  1. upgrade istio\n", buf.String())
+
+	rc.SetClient(fake.NewClientBuilder().WithScheme(helpers.NewScheme()).Build())
+	cmd = NewCmdPlan(rc)
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "No plan has been published")
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/bugreport"
 	cmdhelpers "github.com/verrazzano/verrazzano/tools/vz/cmd/helpers"
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/install"
+	"github.com/verrazzano/verrazzano/tools/vz/cmd/plan"
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/status"
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/uninstall"
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/upgrade"
@@ -42,6 +43,7 @@ func NewRootCmd(vzHelper helpers.VZHelper) *cobra.Command {
 	cmd.AddCommand(uninstall.NewCmdUninstall(vzHelper))
 	cmd.AddCommand(analyze.NewCmdAnalyze(vzHelper))
 	cmd.AddCommand(bugreport.NewCmdBugReport(vzHelper))
+	cmd.AddCommand(plan.NewCmdPlan(vzHelper))
 
 	return cmd
 }
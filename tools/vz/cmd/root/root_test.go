@@ -14,6 +14,7 @@ import (
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/bugreport"
 
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/install"
+	"github.com/verrazzano/verrazzano/tools/vz/cmd/plan"
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/uninstall"
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/upgrade"
 
@@ -34,7 +35,7 @@ func TestNewRootCmd(t *testing.T) {
 	assert.NotNil(t, rootCmd)
 
 	// Verify the expected commands are defined
-	assert.Len(t, rootCmd.Commands(), 7)
+	assert.Len(t, rootCmd.Commands(), 8)
 	foundCount := 0
 	for _, cmd := range rootCmd.Commands() {
 		switch cmd.Name() {
@@ -52,9 +53,11 @@ func TestNewRootCmd(t *testing.T) {
 			foundCount++
 		case bugreport.CommandName:
 			foundCount++
+		case plan.CommandName:
+			foundCount++
 		}
 	}
-	assert.Equal(t, 7, foundCount)
+	assert.Equal(t, 8, foundCount)
 
 	// Verify the expected global flags are defined
 	assert.NotNil(t, rootCmd.PersistentFlags().Lookup(constants.GlobalFlagKubeConfig))
@@ -0,0 +1,121 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	vzconstants "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/templates"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ManagedClusterTemplateInput - the status of a managed cluster, as reported by its agent
+type ManagedClusterTemplateInput struct {
+	Name                  string
+	State                 string
+	Reported              bool
+	VerrazzanoVersion     string
+	Profile               string
+	VerrazzanoState       string
+	AvailableComponents   string
+	UnavailableComponents string
+	KubernetesVersion     string
+	Nodes                 string
+	LastUpdateTime        string
+}
+
+// ManagedClustersTemplateInput - the status of the managed clusters registered with this admin cluster
+type ManagedClustersTemplateInput struct {
+	Clusters []ManagedClusterTemplateInput
+}
+
+// managedClustersOutputTemplate - template for output of the managed clusters of the status command
+const managedClustersOutputTemplate = `
+Managed Clusters
+{{- if not .Clusters }}
+  No managed clusters are registered
+{{- end }}
+{{- range .Clusters }}
+  {{ .Name }}:
+    State: {{ .State }}
+{{- if .Reported }}
+    Verrazzano Version: {{ .VerrazzanoVersion }}
+    Profile: {{ .Profile }}
+    Verrazzano State: {{ .VerrazzanoState }}
+{{- if .AvailableComponents }}
+    Available Components: {{ .AvailableComponents }}
+{{- end }}
+{{- if .UnavailableComponents }}
+    Unavailable Components: {{ .UnavailableComponents }}
+{{- end }}
+    Kubernetes Version: {{ .KubernetesVersion }}
+    Ready Nodes: {{ .Nodes }}
+    Last Reported: {{ .LastUpdateTime }}
+{{- else }}
+    Inventory: not reported by the managed cluster
+{{- end }}
+{{- end }}
+`
+
+// printManagedClusters - print the version and health reported by each managed cluster registered with this
+// admin cluster
+func printManagedClusters(c client.Client, vzHelper helpers.VZHelper) error {
+	vmcList := clustersv1alpha1.VerrazzanoManagedClusterList{}
+	err := c.List(context.TODO(), &vmcList, client.InNamespace(vzconstants.VerrazzanoMultiClusterNamespace))
+	if err != nil && !meta.IsNoMatchError(err) {
+		return fmt.Errorf("Failed to list the managed clusters: %s", err.Error())
+	}
+
+	values := ManagedClustersTemplateInput{}
+	for _, vmc := range vmcList.Items {
+		values.Clusters = append(values.Clusters, getManagedClusterTemplateInput(vmc))
+	}
+	result, err := templates.ApplyTemplate(managedClustersOutputTemplate, values)
+	if err != nil {
+		return fmt.Errorf("Failed to generate %s command output: %s", CommandName, err.Error())
+	}
+	fmt.Fprint(vzHelper.GetOutputStream(), result)
+	return nil
+}
+
+// getManagedClusterTemplateInput - get the template values of a managed cluster
+func getManagedClusterTemplateInput(vmc clustersv1alpha1.VerrazzanoManagedCluster) ManagedClusterTemplateInput {
+	value := ManagedClusterTemplateInput{
+		Name:  vmc.Name,
+		State: string(vmc.Status.State),
+	}
+	if value.State == "" {
+		value.State = string(clustersv1alpha1.StatePending)
+	}
+	inventory := vmc.Status.Inventory
+	if inventory == nil {
+		return value
+	}
+
+	value.Reported = true
+	value.VerrazzanoVersion = inventory.VerrazzanoVersion
+	value.Profile = inventory.Profile
+	value.VerrazzanoState = inventory.VerrazzanoState
+	value.AvailableComponents = inventory.AvailableComponents
+	var unavailable []string
+	for _, component := range inventory.Components {
+		if !component.Available {
+			unavailable = append(unavailable, component.Name)
+		}
+	}
+	value.UnavailableComponents = strings.Join(unavailable, ", ")
+	value.KubernetesVersion = inventory.KubernetesVersion
+	value.Nodes = fmt.Sprintf("%d/%d", inventory.ReadyNodes, inventory.Nodes)
+	if inventory.LastUpdateTime != nil {
+		value.LastUpdateTime = inventory.LastUpdateTime.UTC().Format(time.RFC3339)
+	}
+	return value
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package status
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	vzconstants "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/constants"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	testhelpers "github.com/verrazzano/verrazzano/tools/vz/test/helpers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestStatusCmdManagedClusters tests the status command with the managed clusters
+// GIVEN an admin cluster with a managed cluster that reported its inventory, and a managed cluster that did not
+//
+//	WHEN I run the command vz status --managed-clusters
+//	THEN expect the Verrazzano version, component health, Kubernetes version and node counts of the managed cluster
+//	that reported its inventory
+func TestStatusCmdManagedClusters(t *testing.T) {
+	vz := v1beta1.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Status:     v1beta1.VerrazzanoStatus{Version: version, State: v1beta1.VzStateReady},
+	}
+	lastUpdateTime := metav1.Date(2023, 5, 1, 10, 30, 0, 0, time.UTC)
+	reported := clustersv1alpha1.VerrazzanoManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: vzconstants.VerrazzanoMultiClusterNamespace, Name: "managed1"},
+		Status: clustersv1alpha1.VerrazzanoManagedClusterStatus{
+			State: clustersv1alpha1.StateActive,
+			Inventory: &clustersv1alpha1.ManagedClusterInventory{
+				VerrazzanoVersion:   version,
+				Profile:             "managed-cluster",
+				VerrazzanoState:     string(v1beta1.VzStateReady),
+				AvailableComponents: "1/2",
+				Components: []clustersv1alpha1.ComponentInventory{
+					{Name: "cert-manager", Available: true},
+					{Name: "fluentd", Available: false},
+				},
+				KubernetesVersion: "v1.25.7",
+				Nodes:             3,
+				ReadyNodes:        2,
+				LastUpdateTime:    &lastUpdateTime,
+			},
+		},
+	}
+	notReported := clustersv1alpha1.VerrazzanoManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: vzconstants.VerrazzanoMultiClusterNamespace, Name: "managed2"},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(helpers.NewScheme()).WithObjects(&vz, &reported, &notReported).Build()
+
+	// Send the command output to a byte buffer
+	buf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: buf, ErrOut: errBuf})
+	rc.SetClient(c)
+	statusCmd := NewCmdStatus(rc)
+	assert.NotNil(t, statusCmd)
+	statusCmd.PersistentFlags().Set(constants.ManagedClustersFlag, "true")
+
+	// Run the status command, check for the managed clusters to be displayed after the Verrazzano status
+	err := statusCmd.Execute()
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), `
+Managed Clusters
+  managed1:
+    State: Active
+    Verrazzano Version: 1.2.3
+    Profile: managed-cluster
+    Verrazzano State: Ready
+    Available Components: 1/2
+    Unavailable Components: fluentd
+    Kubernetes Version: v1.25.7
+    Ready Nodes: 2/3
+    Last Reported: 2023-05-01T10:30:00Z
+  managed2:
+    State: Pending
+    Inventory: not reported by the managed cluster
+`)
+}
+
+// TestStatusCmdNoManagedClusters tests the status command without managed clusters
+// GIVEN an admin cluster without managed clusters
+//
+//	WHEN I run the command vz status --managed-clusters
+//	THEN expect a message that no managed clusters are registered
+func TestStatusCmdNoManagedClusters(t *testing.T) {
+	vz := v1beta1.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Status:     v1beta1.VerrazzanoStatus{Version: version, State: v1beta1.VzStateReady},
+	}
+	c := fake.NewClientBuilder().WithScheme(helpers.NewScheme()).WithObjects(&vz).Build()
+
+	buf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: buf, ErrOut: errBuf})
+	rc.SetClient(c)
+	statusCmd := NewCmdStatus(rc)
+	statusCmd.PersistentFlags().Set(constants.ManagedClustersFlag, "true")
+
+	err := statusCmd.Execute()
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "Managed Clusters\n  No managed clusters are registered\n")
+}
@@ -9,24 +9,38 @@ import (
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	cmdhelpers "github.com/verrazzano/verrazzano/tools/vz/cmd/helpers"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/constants"
 	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
 	"github.com/verrazzano/verrazzano/tools/vz/pkg/templates"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 const (
 	CommandName = "status"
 	helpShort   = "Status of the Verrazzano installation and access endpoints"
-	helpLong    = `The command 'status' returns summary information about a Verrazzano installation`
+	helpLong    = `The command 'status' returns summary information about a Verrazzano installation. With --watch, the command streams the state transitions of Verrazzano and its components until Verrazzano is Ready or Failed, and then shows the time taken by each component. With --managed-clusters, the command also shows the version and health reported by each managed cluster`
 	helpExample = `
 vz status
 vz status --context minikube
-vz status --kubeconfig ~/.kube/config --context minikube`
+vz status --kubeconfig ~/.kube/config --context minikube
+
+# Stream the state transitions of an install or upgrade, with a timeout of one hour
+vz status --watch --timeout 60m
+
+# Stream the state transitions as JSON lines
+vz status --watch --log-format json
+
+# Show the version and health of the managed clusters registered with this admin cluster
+vz status --managed-clusters`
 )
 
+var logsEnum = cmdhelpers.LogFormatSimple
+
 // The component output is disabled pending the resolution some issues with
 // the content of the Verrazzano status block
 var componentOutputEnabled = false
@@ -76,6 +90,11 @@ func NewCmdStatus(vzHelper helpers.VZHelper) *cobra.Command {
 	}
 	cmd.Example = helpExample
 
+	cmd.PersistentFlags().Bool(constants.WatchFlag, false, constants.WatchFlagHelp)
+	cmd.PersistentFlags().Duration(constants.TimeoutFlag, time.Minute*30, constants.TimeoutFlagHelp)
+	cmd.PersistentFlags().Var(&logsEnum, constants.LogFormatFlag, constants.LogFormatHelp)
+	cmd.PersistentFlags().Bool(constants.ManagedClustersFlag, false, constants.ManagedClustersFlagHelp)
+
 	return cmd
 }
 
@@ -91,6 +110,23 @@ func runCmdStatus(cmd *cobra.Command, vzHelper helpers.VZHelper) error {
 	if err != nil {
 		return err
 	}
+
+	watch, err := cmd.PersistentFlags().GetBool(constants.WatchFlag)
+	if err != nil {
+		return err
+	}
+	if watch {
+		timeout, err := cmd.PersistentFlags().GetDuration(constants.TimeoutFlag)
+		if err != nil {
+			return err
+		}
+		logFormat, err := cmdhelpers.GetLogFormat(cmd)
+		if err != nil {
+			return err
+		}
+		return watchStatus(client, vzHelper, types.NamespacedName{Namespace: vz.Namespace, Name: vz.Name}, timeout, logFormat)
+	}
+
 	templateValues := TemplateInput{
 		Endpoints:           getEndpoints(vz.Status.VerrazzanoInstance),
 		Components:          getComponents(vz.Status.Components),
@@ -107,6 +143,13 @@ func runCmdStatus(cmd *cobra.Command, vzHelper helpers.VZHelper) error {
 	}
 	fmt.Fprintf(vzHelper.GetOutputStream(), result)
 
+	managedClusters, err := cmd.PersistentFlags().GetBool(constants.ManagedClustersFlag)
+	if err != nil {
+		return err
+	}
+	if managedClusters {
+		return printManagedClusters(client, vzHelper)
+	}
 	return nil
 }
 
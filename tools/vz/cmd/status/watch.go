@@ -0,0 +1,269 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	cmdhelpers "github.com/verrazzano/verrazzano/tools/vz/cmd/helpers"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	"k8s.io/apimachinery/pkg/types"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// watchPollInterval is the time between checks of the Verrazzano resource status while watching
+var watchPollInterval = 2 * time.Second
+
+// Kinds of the JSON lines output while watching
+const (
+	eventKindVerrazzano = "verrazzano"
+	eventKindComponent  = "component"
+	eventKindSummary    = "summary"
+)
+
+// statusEvent is a state transition of the Verrazzano resource or of a component
+type statusEvent struct {
+	Time          string `json:"time"`
+	Kind          string `json:"kind"`
+	Name          string `json:"name"`
+	PreviousState string `json:"previousState,omitempty"`
+	State         string `json:"state"`
+	Duration      string `json:"duration,omitempty"`
+}
+
+// componentTiming is the time taken by the last operation of a component, taken from the component status conditions
+type componentTiming struct {
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	Started   string `json:"started,omitempty"`
+	Completed string `json:"completed,omitempty"`
+	Duration  string `json:"duration,omitempty"`
+
+	startTime time.Time
+}
+
+// statusSummary is output once the Verrazzano resource reaches the Ready or Failed state
+type statusSummary struct {
+	Time       string            `json:"time"`
+	Kind       string            `json:"kind"`
+	Name       string            `json:"name"`
+	State      string            `json:"state"`
+	Duration   string            `json:"duration,omitempty"`
+	Components []componentTiming `json:"components"`
+}
+
+// statusWatcher keeps track of the states last seen for the Verrazzano resource and its components
+type statusWatcher struct {
+	out             io.Writer
+	logFormat       cmdhelpers.LogFormat
+	vzState         *v1beta1.VzStateType
+	componentStates map[string]v1beta1.CompStateType
+}
+
+// watchStatus streams the state transitions of the Verrazzano resource and its components until the
+// Verrazzano resource reaches the Ready or Failed state, and then outputs the time taken by each component.
+// A timeout of zero waits with no limit.
+func watchStatus(client clipkg.Client, vzHelper helpers.VZHelper, namespacedName types.NamespacedName, timeout time.Duration, logFormat cmdhelpers.LogFormat) error {
+	watcher := &statusWatcher{
+		out:             vzHelper.GetOutputStream(),
+		logFormat:       logFormat,
+		componentStates: map[string]v1beta1.CompStateType{},
+	}
+	startTime := time.Now()
+	for {
+		vz, err := helpers.GetVerrazzanoResource(client, namespacedName)
+		if err != nil {
+			// Keep retrying until the timeout, the resource may be temporarily unavailable during an upgrade
+			fmt.Fprintf(vzHelper.GetErrorStream(), "Failed to get the Verrazzano resource %s, retrying: %s\n", namespacedName.String(), err.Error())
+		} else {
+			if err := watcher.update(vz, time.Now()); err != nil {
+				return err
+			}
+			if vz.Status.State == v1beta1.VzStateReady || vz.Status.State == v1beta1.VzStateFailed {
+				if err := watcher.printSummary(vz, time.Now()); err != nil {
+					return err
+				}
+				if vz.Status.State == v1beta1.VzStateFailed {
+					return fmt.Errorf("Verrazzano resource %s reached the %s state", namespacedName.String(), v1beta1.VzStateFailed)
+				}
+				return nil
+			}
+		}
+		if timeout != 0 && time.Since(startTime) > timeout {
+			return fmt.Errorf("Timeout %v exceeded waiting for the Verrazzano resource %s to reach the %s or %s state", timeout.String(), namespacedName.String(), v1beta1.VzStateReady, v1beta1.VzStateFailed)
+		}
+		time.Sleep(watchPollInterval)
+	}
+}
+
+// update outputs the state transitions since the last update, the current states are output on the first update
+func (w *statusWatcher) update(vz *v1beta1.Verrazzano, now time.Time) error {
+	if w.vzState == nil || *w.vzState != vz.Status.State {
+		event := statusEvent{
+			Time:     now.UTC().Format(time.RFC3339),
+			Kind:     eventKindVerrazzano,
+			Name:     fmt.Sprintf("%s/%s", vz.Namespace, vz.Name),
+			State:    string(vz.Status.State),
+			Duration: getTiming("", vz.Status.Conditions).Duration,
+		}
+		if w.vzState != nil {
+			event.PreviousState = string(*w.vzState)
+		}
+		if err := w.printEvent(event); err != nil {
+			return err
+		}
+		state := vz.Status.State
+		w.vzState = &state
+	}
+
+	for _, name := range getSortedComponentNames(vz.Status.Components) {
+		component := vz.Status.Components[name]
+		previous, found := w.componentStates[name]
+		if found && previous == component.State {
+			continue
+		}
+		event := statusEvent{
+			Time:          now.UTC().Format(time.RFC3339),
+			Kind:          eventKindComponent,
+			Name:          name,
+			PreviousState: string(previous),
+			State:         string(component.State),
+			Duration:      getTiming(name, component.Conditions).Duration,
+		}
+		if err := w.printEvent(event); err != nil {
+			return err
+		}
+		w.componentStates[name] = component.State
+	}
+	return nil
+}
+
+// printEvent outputs a state transition, either as a line of text or as a JSON line
+func (w *statusWatcher) printEvent(event statusEvent) error {
+	if w.logFormat == cmdhelpers.LogFormatJSON {
+		return w.printJSONLine(event)
+	}
+	kind := "Component"
+	if event.Kind == eventKindVerrazzano {
+		kind = "Verrazzano"
+	}
+	transition := event.State
+	if event.PreviousState != "" {
+		transition = fmt.Sprintf("%s -> %s", event.PreviousState, event.State)
+	}
+	if event.Duration != "" {
+		transition = fmt.Sprintf("%s (%s)", transition, event.Duration)
+	}
+	_, err := fmt.Fprintf(w.out, "%s %s %s: %s\n", event.Time, kind, event.Name, transition)
+	return err
+}
+
+// printSummary outputs the time taken by each component which is not disabled, either as a table or as a JSON line
+func (w *statusWatcher) printSummary(vz *v1beta1.Verrazzano, now time.Time) error {
+	summary := statusSummary{
+		Time:       now.UTC().Format(time.RFC3339),
+		Kind:       eventKindSummary,
+		Name:       fmt.Sprintf("%s/%s", vz.Namespace, vz.Name),
+		State:      string(vz.Status.State),
+		Duration:   getTiming("", vz.Status.Conditions).Duration,
+		Components: []componentTiming{},
+	}
+	for _, name := range getSortedComponentNames(vz.Status.Components) {
+		component := vz.Status.Components[name]
+		if component.State == v1beta1.CompStateDisabled {
+			continue
+		}
+		timing := getTiming(name, component.Conditions)
+		timing.State = string(component.State)
+		summary.Components = append(summary.Components, timing)
+	}
+	// Order the components by the time they started, so the table reads as a timeline
+	sort.SliceStable(summary.Components, func(i, j int) bool {
+		ti, tj := summary.Components[i].startTime, summary.Components[j].startTime
+		if ti.IsZero() || tj.IsZero() {
+			return !ti.IsZero() && tj.IsZero()
+		}
+		return ti.Before(tj)
+	})
+
+	if w.logFormat == cmdhelpers.LogFormatJSON {
+		return w.printJSONLine(summary)
+	}
+	fmt.Fprintf(w.out, "\nVerrazzano %s reached the %s state", summary.Name, summary.State)
+	if summary.Duration != "" {
+		fmt.Fprintf(w.out, " in %s", summary.Duration)
+	}
+	fmt.Fprint(w.out, "\n\n")
+	tw := tabwriter.NewWriter(w.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "COMPONENT\tSTATE\tSTARTED\tCOMPLETED\tDURATION")
+	for _, timing := range summary.Components {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", timing.Name, timing.State, getValueOrDash(timing.Started), getValueOrDash(timing.Completed), getValueOrDash(timing.Duration))
+	}
+	return tw.Flush()
+}
+
+func (w *statusWatcher) printJSONLine(value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w.out, string(data))
+	return err
+}
+
+// getTiming gets the start and completion time of the last operation from the status conditions. The operation
+// starts with the first PreInstall, InstallStarted, UpgradeStarted or UninstallStarted condition after the
+// previous operation completed.
+func getTiming(name string, conditions []v1beta1.Condition) componentTiming {
+	var started, completed time.Time
+	for _, condition := range conditions {
+		conditionTime, err := time.Parse(time.RFC3339, condition.LastTransitionTime)
+		if err != nil {
+			continue
+		}
+		switch condition.Type {
+		case v1beta1.CondPreInstall, v1beta1.CondInstallStarted, v1beta1.CondUpgradeStarted, v1beta1.CondUninstallStarted:
+			if started.IsZero() || !completed.IsZero() {
+				started = conditionTime
+				completed = time.Time{}
+			}
+		case v1beta1.CondInstallComplete, v1beta1.CondInstallFailed, v1beta1.CondUpgradeComplete, v1beta1.CondUpgradeFailed, v1beta1.CondUninstallComplete, v1beta1.CondUninstallFailed:
+			if !started.IsZero() {
+				completed = conditionTime
+			}
+		}
+	}
+
+	timing := componentTiming{Name: name, startTime: started}
+	if !started.IsZero() {
+		timing.Started = started.UTC().Format(time.RFC3339)
+	}
+	if !completed.IsZero() {
+		timing.Completed = completed.UTC().Format(time.RFC3339)
+		timing.Duration = completed.Sub(started).String()
+	}
+	return timing
+}
+
+func getSortedComponentNames(components v1beta1.ComponentStatusMap) []string {
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func getValueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
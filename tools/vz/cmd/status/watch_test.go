@@ -0,0 +1,227 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package status
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	cmdhelpers "github.com/verrazzano/verrazzano/tools/vz/cmd/helpers"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/constants"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	testhelpers "github.com/verrazzano/verrazzano/tools/vz/test/helpers"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	startTime    = "2023-01-10T10:00:00Z"
+	keycloakDone = "2023-01-10T10:05:00Z"
+	istioDone    = "2023-01-10T10:02:30Z"
+)
+
+// TestWatchStatusReady tests the status command with the watch flag
+// GIVEN an environment with a single VZ resource in the Ready state
+//
+//	WHEN I run the command vz status --watch
+//	THEN expect the current states and a timing table for the enabled components
+func TestWatchStatusReady(t *testing.T) {
+	vz := newWatchedVerrazzano(v1beta1.VzStateReady, v1beta1.CompStateReady)
+	c := fake.NewClientBuilder().WithScheme(helpers.NewScheme()).WithObjects(vz).Build()
+
+	buf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: buf, ErrOut: errBuf})
+	rc.SetClient(c)
+	statusCmd := NewCmdStatus(rc)
+	assert.NotNil(t, statusCmd)
+	statusCmd.PersistentFlags().Set(constants.WatchFlag, "true")
+
+	err := statusCmd.Execute()
+	assert.NoError(t, err)
+	result := buf.String()
+	assert.Contains(t, result, "Verrazzano test/verrazzano: Ready (5m0s)")
+	assert.Contains(t, result, "Component keycloak: Ready (5m0s)")
+	assert.Contains(t, result, "Verrazzano test/verrazzano reached the Ready state in 5m0s")
+	assert.Regexp(t, `istio\s+Ready\s+2023-01-10T10:00:00Z\s+2023-01-10T10:02:30Z\s+2m30s`, result)
+	assert.Regexp(t, `keycloak\s+Ready\s+2023-01-10T10:00:00Z\s+2023-01-10T10:05:00Z\s+5m0s`, result)
+	// Disabled components are not in the timing table
+	assert.NotContains(t, result, "rancher  ")
+}
+
+// TestWatchStatusFailedJSON tests the status command with the watch flag and JSON lines output
+// GIVEN an environment with a single VZ resource in the Failed state
+//
+//	WHEN I run the command vz status --watch --log-format json
+//	THEN expect each line of output to be a JSON object, a summary as the last line and an error to be returned
+func TestWatchStatusFailedJSON(t *testing.T) {
+	vz := newWatchedVerrazzano(v1beta1.VzStateFailed, v1beta1.CompStateFailed)
+	c := fake.NewClientBuilder().WithScheme(helpers.NewScheme()).WithObjects(vz).Build()
+
+	buf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: buf, ErrOut: errBuf})
+	rc.SetClient(c)
+	statusCmd := NewCmdStatus(rc)
+	assert.NotNil(t, statusCmd)
+	statusCmd.PersistentFlags().Set(constants.WatchFlag, "true")
+	statusCmd.PersistentFlags().Set(constants.LogFormatFlag, string(cmdhelpers.LogFormatJSON))
+	defer func() { logsEnum = cmdhelpers.LogFormatSimple }()
+
+	err := statusCmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 5)
+	for _, line := range lines[:len(lines)-1] {
+		event := statusEvent{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &event))
+		assert.NotEmpty(t, event.Kind)
+		assert.NotEmpty(t, event.State)
+	}
+	summary := statusSummary{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &summary))
+	assert.Equal(t, eventKindSummary, summary.Kind)
+	assert.Equal(t, string(v1beta1.VzStateFailed), summary.State)
+	assert.Len(t, summary.Components, 2)
+	assert.Equal(t, "istio", summary.Components[0].Name)
+	assert.Equal(t, "keycloak", summary.Components[1].Name)
+}
+
+// TestWatchStatusTimeout tests the status command with the watch flag
+// GIVEN an environment with a single VZ resource which stays in the Reconciling state
+//
+//	WHEN I run the command vz status --watch with a short timeout
+//	THEN expect a timeout error
+func TestWatchStatusTimeout(t *testing.T) {
+	watchPollInterval = time.Millisecond
+	defer func() { watchPollInterval = 2 * time.Second }()
+
+	vz := newWatchedVerrazzano(v1beta1.VzStateReconciling, v1beta1.CompStateInstalling)
+	c := fake.NewClientBuilder().WithScheme(helpers.NewScheme()).WithObjects(vz).Build()
+
+	buf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: buf, ErrOut: errBuf})
+	rc.SetClient(c)
+	statusCmd := NewCmdStatus(rc)
+	assert.NotNil(t, statusCmd)
+	statusCmd.PersistentFlags().Set(constants.WatchFlag, "true")
+	statusCmd.PersistentFlags().Set(constants.TimeoutFlag, "20ms")
+
+	err := statusCmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Timeout 20ms exceeded")
+	// The states are only output once since they did not change
+	assert.Equal(t, 1, strings.Count(buf.String(), "Component keycloak: Installing"))
+}
+
+// TestWatcherTransitions tests the output of state transitions
+// GIVEN a watcher which has seen a component in the Installing state
+//
+//	WHEN the component moves to the Ready state
+//	THEN expect the transition to be output with the duration taken from the component conditions
+func TestWatcherTransitions(t *testing.T) {
+	buf := new(bytes.Buffer)
+	watcher := &statusWatcher{out: buf, logFormat: cmdhelpers.LogFormatSimple, componentStates: map[string]v1beta1.CompStateType{}}
+	now := time.Date(2023, 1, 10, 10, 6, 0, 0, time.UTC)
+
+	vz := newWatchedVerrazzano(v1beta1.VzStateReconciling, v1beta1.CompStateInstalling)
+	vz.Status.Components["keycloak"].Conditions = vz.Status.Components["keycloak"].Conditions[:1]
+	assert.NoError(t, watcher.update(vz, now))
+	buf.Reset()
+
+	// No transitions, nothing is output
+	assert.NoError(t, watcher.update(vz, now))
+	assert.Empty(t, buf.String())
+
+	vz = newWatchedVerrazzano(v1beta1.VzStateReconciling, v1beta1.CompStateReady)
+	assert.NoError(t, watcher.update(vz, now))
+	assert.Equal(t, "2023-01-10T10:06:00Z Component keycloak: Installing -> Ready (5m0s)\n", buf.String())
+}
+
+// TestGetTiming tests getting the time taken by an operation from the status conditions
+// GIVEN status conditions for an install followed by an upgrade
+//
+//	WHEN the timing is computed
+//	THEN expect the timing of the upgrade, and no duration while the upgrade is in progress
+func TestGetTiming(t *testing.T) {
+	conditions := []v1beta1.Condition{
+		{Type: v1beta1.CondPreInstall, LastTransitionTime: "2023-01-10T10:00:00Z"},
+		{Type: v1beta1.CondInstallStarted, LastTransitionTime: "2023-01-10T10:00:10Z"},
+		{Type: v1beta1.CondInstallComplete, LastTransitionTime: "2023-01-10T10:01:00Z"},
+		{Type: v1beta1.CondUpgradeStarted, LastTransitionTime: "2023-01-11T10:00:00Z"},
+	}
+	timing := getTiming("comp", conditions)
+	assert.Equal(t, "2023-01-11T10:00:00Z", timing.Started)
+	assert.Empty(t, timing.Completed)
+	assert.Empty(t, timing.Duration)
+
+	conditions = append(conditions, v1beta1.Condition{Type: v1beta1.CondUpgradeComplete, LastTransitionTime: "2023-01-11T10:00:42Z"})
+	timing = getTiming("comp", conditions)
+	assert.Equal(t, "42s", timing.Duration)
+
+	timing = getTiming("comp", conditions[:3])
+	assert.Equal(t, "2023-01-10T10:00:00Z", timing.Started)
+	assert.Equal(t, "1m0s", timing.Duration)
+}
+
+// newWatchedVerrazzano returns a Verrazzano resource with the keycloak component in the given state, istio Ready and
+// rancher disabled
+func newWatchedVerrazzano(vzState v1beta1.VzStateType, keycloakState v1beta1.CompStateType) *v1beta1.Verrazzano {
+	vzConditions := []v1beta1.Condition{{Type: v1beta1.CondInstallStarted, Status: corev1.ConditionTrue, LastTransitionTime: startTime}}
+	keycloakConditions := []v1beta1.Condition{{Type: v1beta1.CondInstallStarted, Status: corev1.ConditionTrue, LastTransitionTime: startTime}}
+	switch keycloakState {
+	case v1beta1.CompStateReady:
+		keycloakConditions = append(keycloakConditions, v1beta1.Condition{Type: v1beta1.CondInstallComplete, Status: corev1.ConditionTrue, LastTransitionTime: keycloakDone})
+	case v1beta1.CompStateFailed:
+		keycloakConditions = append(keycloakConditions, v1beta1.Condition{Type: v1beta1.CondInstallFailed, Status: corev1.ConditionTrue, LastTransitionTime: keycloakDone})
+	}
+	switch vzState {
+	case v1beta1.VzStateReady:
+		vzConditions = append(vzConditions, v1beta1.Condition{Type: v1beta1.CondInstallComplete, Status: corev1.ConditionTrue, LastTransitionTime: keycloakDone})
+	case v1beta1.VzStateFailed:
+		vzConditions = append(vzConditions, v1beta1.Condition{Type: v1beta1.CondInstallFailed, Status: corev1.ConditionTrue, LastTransitionTime: keycloakDone})
+	}
+
+	return &v1beta1.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Status: v1beta1.VerrazzanoStatus{
+			Version:    version,
+			Conditions: vzConditions,
+			State:      vzState,
+			Components: v1beta1.ComponentStatusMap{
+				"istio": &v1beta1.ComponentStatusDetails{
+					Name:  "istio",
+					State: v1beta1.CompStateReady,
+					Conditions: []v1beta1.Condition{
+						{Type: v1beta1.CondInstallStarted, Status: corev1.ConditionTrue, LastTransitionTime: startTime},
+						{Type: v1beta1.CondInstallComplete, Status: corev1.ConditionTrue, LastTransitionTime: istioDone},
+					},
+				},
+				"keycloak": &v1beta1.ComponentStatusDetails{
+					Name:       "keycloak",
+					State:      keycloakState,
+					Conditions: keycloakConditions,
+				},
+				"rancher": &v1beta1.ComponentStatusDetails{
+					Name:  "rancher",
+					State: v1beta1.CompStateDisabled,
+				},
+			},
+		},
+	}
+}
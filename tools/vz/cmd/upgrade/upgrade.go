@@ -33,7 +33,11 @@ var helpExample = fmt.Sprintf(`
 vz upgrade
 
 # Upgrade to Verrazzano v%[1]s, stream the logs to the console and timeout after 20m
-vz upgrade --version v%[1]s --timeout 20m`, version.GetCLIVersion())
+vz upgrade --version v%[1]s --timeout 20m
+
+# Render the effective Verrazzano configuration and the Helm values of each enabled component for Verrazzano v%[1]s
+# to the vz-dry-run directory for review, instead of upgrading.
+vz upgrade --version v%[1]s --dry-run --dry-run-dir vz-dry-run`, version.GetCLIVersion())
 
 var logsEnum = cmdhelpers.LogFormatSimple
 
@@ -60,9 +64,11 @@ func NewCmdUpgrade(vzHelper helpers.VZHelper) *cobra.Command {
 	// Flag to skip any confirmation questions
 	cmd.PersistentFlags().BoolP(constants.SkipConfirmationFlag, constants.SkipConfirmationShort, false, constants.SkipConfirmationFlagHelp)
 
-	// Dry run flag is still being discussed - keep hidden for now
-	cmd.PersistentFlags().Bool(constants.DryRunFlag, false, "Simulate an upgrade.")
-	cmd.PersistentFlags().MarkHidden(constants.DryRunFlag)
+	// Flag to run the pre-flight checks before upgrading
+	cmd.PersistentFlags().Bool(constants.PreflightFlag, false, constants.PreflightFlagHelp)
+
+	// Add flags related to rendering the effective configuration instead of upgrading
+	cmdhelpers.AddDryRunFlags(cmd, "Render the effective Verrazzano configuration and the Helm values of each enabled component to the --dry-run-dir directory, instead of upgrading.")
 
 	// Hide the flag for overriding the default wait timeout for the platform-operator
 	cmd.PersistentFlags().MarkHidden(constants.VPOTimeoutFlag)
@@ -87,22 +93,6 @@ func runCmdUpgrade(cmd *cobra.Command, vzHelper helpers.VZHelper) error {
 		return fmt.Errorf("Verrazzano is not installed: %s", err.Error())
 	}
 
-	// Validate any existing private registry settings against new ones and get confirmation from the user
-	if err := cmdhelpers.ValidatePrivateRegistry(cmd, client); err != nil {
-		skipConfirm, errConfirm := cmd.PersistentFlags().GetBool(constants.SkipConfirmationFlag)
-		if errConfirm != nil {
-			return errConfirm
-		}
-		proceed, err := cmdhelpers.ConfirmWithUser(vzHelper, fmt.Sprintf("%s\nProceed to upgrade with new settings?", err.Error()), skipConfirm)
-		if err != nil {
-			return err
-		}
-		if !proceed {
-			fmt.Fprintf(vzHelper.GetOutputStream(), "Upgrade canceled.")
-			return nil
-		}
-	}
-
 	// Get the version Verrazzano is being upgraded to
 	version, err := cmdhelpers.GetVersion(cmd, vzHelper)
 	if err != nil {
@@ -141,6 +131,33 @@ func runCmdUpgrade(cmd *cobra.Command, vzHelper helpers.VZHelper) error {
 		}
 	}
 
+	// Render the effective configuration of the upgraded verrazzano install resource instead of upgrading, with the
+	// profiles of the version being upgraded to.  The dry run does not change anything, so the user is not prompted.
+	dryRun, err := cmdhelpers.IsDryRun(cmd)
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		vz.Spec.Version = version
+		return cmdhelpers.RenderDryRun(cmd, vzHelper, vz, version)
+	}
+
+	// Validate any existing private registry settings against new ones and get confirmation from the user
+	if err := cmdhelpers.ValidatePrivateRegistry(cmd, client); err != nil {
+		skipConfirm, errConfirm := cmd.PersistentFlags().GetBool(constants.SkipConfirmationFlag)
+		if errConfirm != nil {
+			return errConfirm
+		}
+		proceed, err := cmdhelpers.ConfirmWithUser(vzHelper, fmt.Sprintf("%s\nProceed to upgrade with new settings?", err.Error()), skipConfirm)
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			fmt.Fprintf(vzHelper.GetOutputStream(), "Upgrade canceled.")
+			return nil
+		}
+	}
+
 	fmt.Fprintf(vzHelper.GetOutputStream(), fmt.Sprintf("Upgrading Verrazzano to version %s\n", version))
 
 	// Get the timeout value for the upgrade command
@@ -168,6 +185,19 @@ func runCmdUpgrade(cmd *cobra.Command, vzHelper helpers.VZHelper) error {
 	}
 
 	if vz.Spec.Version == "" || !upgradeVersion.IsEqualTo(vzSpecVersion) {
+		// Run the pre-flight checks before changing anything in the cluster
+		preflight, err := cmd.PersistentFlags().GetBool(constants.PreflightFlag)
+		if err != nil {
+			return err
+		}
+		if preflight {
+			upgradedVZ := vz.DeepCopy()
+			upgradedVZ.Spec.Version = version
+			if err = cmdhelpers.RunPreflightChecks(cmd, vzHelper, client, kubeClient, upgradedVZ, version, true); err != nil {
+				return err
+			}
+		}
+
 		// Delete leftover verrazzano-operator deployment after an abort.
 		// This allows for the verrazzano-operator validatingWebhookConfiguration to be updated with the correct caBundle.
 		err = cmdhelpers.DeleteFunc(client)
@@ -20,7 +20,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	discoveryfake "k8s.io/client-go/discovery/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -69,6 +71,45 @@ func TestUpgradeCmdDefaultNoWait(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+// TestUpgradeCmdPreflightFailure
+// GIVEN a CLI upgrade command with --preflight and a cluster running a Kubernetes version that is not supported by
+// the BOM of the Verrazzano version being upgraded to
+//
+//	WHEN I call cmd.Execute for upgrade
+//	THEN the pre-flight check results are displayed and the upgrade is not started
+func TestUpgradeCmdPreflightFailure(t *testing.T) {
+	vz := testhelpers.CreateVerrazzanoObjectWithVersion()
+	c := fake.NewClientBuilder().WithScheme(helpers.NewScheme()).WithObjects(append(testhelpers.CreateTestVPOObjects(), vz)...).Build()
+
+	// Send stdout stderr to a byte buffer
+	buf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: buf, ErrOut: errBuf})
+	rc.SetClient(c)
+	cmd := NewCmdUpgrade(rc)
+	assert.NotNil(t, cmd)
+	kubeClient, _ := rc.GetKubeClient(cmd)
+	kubeClient.Discovery().(*discoveryfake.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.20.0"}
+	cmd.PersistentFlags().Set(constants.PreflightFlag, "true")
+	cmd.PersistentFlags().Set(constants.WaitFlag, "false")
+	cmd.PersistentFlags().Set(constants.VersionFlag, "v1.4.0")
+	cmdHelpers.SetDeleteFunc(cmdHelpers.FakeDeleteFunc)
+	defer cmdHelpers.SetDefaultDeleteFunc()
+
+	// Run upgrade command
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "fix the issues reported before upgrading Verrazzano")
+	assert.Contains(t, buf.String(), "[fail] kubernetes-version")
+	assert.Contains(t, buf.String(), "[pass] istio-conflict")
+
+	// Verify the upgrade was not started
+	vzResource := v1beta1.Verrazzano{}
+	err = c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "verrazzano"}, &vzResource)
+	assert.NoError(t, err)
+	assert.Empty(t, vzResource.Spec.Version)
+}
+
 // TestUpgradeCmdDefaultTimeoutBugReport
 // GIVEN a CLI upgrade command with all defaults and --timeout=2ms
 //
@@ -740,3 +781,51 @@ func TestUpgradeFromPrivateRegistryWithSkipConfirmation(t *testing.T) {
 
 	testhelpers.AssertPrivateRegistryImage(t, c, deployment, imageRegistryForUpgrade, imagePrefixForUpgrade)
 }
+
+// TestUpgradeCmdDryRunFromDifferentPrivateRegistry tests a dry run of an upgrade with different private registry settings
+//
+// GIVEN Verrazzano is installed from a private registry
+//
+//	WHEN I call cmd.Execute for upgrade with --dry-run and different private registry settings
+//	THEN the effective configuration is rendered with the profiles of the upgrade version without asking the user to
+//	proceed, and Verrazzano is not upgraded
+func TestUpgradeCmdDryRunFromDifferentPrivateRegistry(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(helpers.NewScheme()).WithObjects(testhelpers.CreateTestVPOObjects()...).Build()
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: new(bytes.Buffer), ErrOut: new(bytes.Buffer)})
+	rc.SetClient(c)
+	cmd := install.NewCmdInstall(rc)
+	cmd.PersistentFlags().Set(constants.WaitFlag, "false")
+	cmd.PersistentFlags().Set(constants.VersionFlag, testVZMajorRelease)
+	cmd.PersistentFlags().Set(constants.ImageRegistryFlag, testImageRegistry)
+	cmd.PersistentFlags().Set(constants.ImagePrefixFlag, testImagePrefix)
+	cmdHelpers.SetDeleteFunc(cmdHelpers.FakeDeleteFunc)
+	defer cmdHelpers.SetDefaultDeleteFunc()
+	cmdHelpers.SetVPOIsReadyFunc(func(_ client.Client) (bool, error) { return true, nil })
+	defer cmdHelpers.SetDefaultVPOIsReadyFunc()
+	install.SetValidateCRFunc(install.FakeValidateCRFunc)
+	defer install.SetDefaultValidateCRFunc()
+	assert.NoError(t, cmd.Execute())
+
+	vz := &v1beta1.Verrazzano{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "verrazzano"}, vz))
+	vz.Status.Version = testVZMajorRelease
+	assert.NoError(t, c.Status().Update(context.TODO(), vz))
+
+	// No input is available, the dry run would fail if it asked the user to proceed
+	outBuf := new(bytes.Buffer)
+	rc = testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: new(bytes.Buffer), Out: outBuf, ErrOut: new(bytes.Buffer)})
+	rc.SetClient(c)
+	cmd = NewCmdUpgrade(rc)
+	cmd.PersistentFlags().Set(constants.VersionFlag, testVZPatchRelease)
+	cmd.PersistentFlags().Set(constants.ImageRegistryFlag, "newreg.io")
+	cmd.PersistentFlags().Set(constants.ImagePrefixFlag, "newrepo")
+	cmd.PersistentFlags().Set(constants.DryRunFlag, "true")
+	cmd.PersistentFlags().Set(constants.DryRunDirFlag, t.TempDir())
+
+	assert.NoError(t, cmd.Execute())
+	assert.NotContains(t, outBuf.String(), "Proceed to upgrade with new settings?")
+	assert.Contains(t, outBuf.String(), "Rendered the effective Verrazzano configuration")
+
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "verrazzano"}, vz))
+	assert.Empty(t, vz.Spec.Version)
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 // Package cluster handles cluster analysis
@@ -33,6 +33,7 @@ import (
 var clusterAnalysisFunctions = map[string]func(log *zap.SugaredLogger, directory string) (err error){
 	"Verrazzano Status":  AnalyzeVerrazzano, // Execute first, this may share data other analyzers can use
 	"Pod Related Issues": AnalyzePodIssues,
+	"User Rules":         AnalyzeUserRules,
 }
 
 // ClusterDumpDirectoriesRe is used for finding cluster-snapshot directory name matches
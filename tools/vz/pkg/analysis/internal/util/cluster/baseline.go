@@ -0,0 +1,328 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+// Package cluster handles cluster analysis
+package cluster
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/analysis/internal/util/files"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/analysis/internal/util/report"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The baseline comparison looks at two captures of the same cluster, for example captures taken before and after an
+// upgrade, and reports what changed in the current capture. The changes are contributed as issues for the current
+// cluster-snapshot so they are reported along with the issues found by the other analyzers.
+
+const podTemplateHashLabel = "pod-template-hash"
+
+// baselineIssueTypes are the issue types contributed by the baseline comparison itself
+var baselineIssueTypes = map[string]bool{
+	report.ComponentStateChanged:  true,
+	report.NewlyFailingPods:       true,
+	report.NewEventReasons:        true,
+	report.ImageChanges:           true,
+	report.NewIssuesSinceBaseline: true,
+}
+
+// podsSummary holds the pod information from a cluster-snapshot used for the comparison. Pods are keyed by
+// namespace and workload, as pod names change when pods are recreated.
+type podsSummary struct {
+	failing map[string][]string // workload key to the names of the failing pods
+	images  map[string]string   // workload key and container name to the image
+	files   map[string]string   // workload key to the pods.json file
+}
+
+// CompareWithBaseline compares the cluster-snapshots found in the current directory with the ones in the baseline
+// directory. The baselineIssues are the issues which were reported when the baseline directory was analyzed.
+func CompareWithBaseline(log *zap.SugaredLogger, baselineDirectory string, currentDirectory string, baselineIssues []report.Issue) error {
+	log.Debugf("CompareWithBaseline called for %s with baseline %s", currentDirectory, baselineDirectory)
+	baselineRoots, err := files.GetMatchingDirectories(log, baselineDirectory, ClusterDumpDirectoriesRe)
+	if err != nil {
+		return fmt.Errorf("Failed examining directories for the baseline %s: %s", baselineDirectory, err.Error())
+	}
+	currentRoots, err := files.GetMatchingDirectories(log, currentDirectory, ClusterDumpDirectoriesRe)
+	if err != nil {
+		return fmt.Errorf("Failed examining directories for %s: %s", currentDirectory, err.Error())
+	}
+	if len(baselineRoots) == 0 {
+		return fmt.Errorf("No cluster-snapshot was found in the baseline %s", baselineDirectory)
+	}
+
+	for currentRoot, baselineRoot := range pairClusterRoots(baselineDirectory, baselineRoots, currentDirectory, currentRoots) {
+		if err := compareClusterRoots(log, baselineRoot, currentRoot, baselineIssues); err != nil {
+			// Log the error and continue on
+			log.Errorf("Error comparing %s with the baseline %s", currentRoot, baselineRoot, err)
+		}
+	}
+	return nil
+}
+
+// pairClusterRoots pairs each current cluster-snapshot with the baseline cluster-snapshot at the same relative path.
+// When both directories only have a single cluster-snapshot, those are paired regardless of their paths.
+func pairClusterRoots(baselineDirectory string, baselineRoots []string, currentDirectory string, currentRoots []string) map[string]string {
+	pairs := make(map[string]string)
+	if len(baselineRoots) == 1 && len(currentRoots) == 1 {
+		pairs[currentRoots[0]] = baselineRoots[0]
+		return pairs
+	}
+	baselineByPath := make(map[string]string)
+	for _, baselineRoot := range baselineRoots {
+		if relative, err := filepath.Rel(baselineDirectory, baselineRoot); err == nil {
+			baselineByPath[relative] = baselineRoot
+		}
+	}
+	for _, currentRoot := range currentRoots {
+		if relative, err := filepath.Rel(currentDirectory, currentRoot); err == nil {
+			if baselineRoot, ok := baselineByPath[relative]; ok {
+				pairs[currentRoot] = baselineRoot
+			}
+		}
+	}
+	return pairs
+}
+
+func compareClusterRoots(log *zap.SugaredLogger, baselineRoot string, currentRoot string, baselineIssues []report.Issue) error {
+	var issueReporter = report.IssueReporter{
+		PendingIssues: make(map[string]report.Issue),
+	}
+
+	// Find the issues reported for the current cluster-snapshot before the baseline issues are contributed
+	currentIssues := make([]report.Issue, 0)
+	for _, issue := range report.GetAllSourcesFilteredIssues(log, true, 0, 0) {
+		if issue.Source == currentRoot {
+			currentIssues = append(currentIssues, issue)
+		}
+	}
+
+	compareComponentStates(log, baselineRoot, currentRoot, &issueReporter)
+	comparePods(log, baselineRoot, currentRoot, &issueReporter)
+	compareEventReasons(log, baselineRoot, currentRoot, &issueReporter)
+	issueReporter.Contribute(log, currentRoot)
+
+	return contributeNewIssues(log, currentRoot, currentIssues, baselineIssues)
+}
+
+// compareComponentStates reports the components whose state in the Verrazzano resource status changed
+func compareComponentStates(log *zap.SugaredLogger, baselineRoot string, currentRoot string, issueReporter *report.IssueReporter) {
+	baselineVz, err := getVerrazzanoResource(log, baselineRoot)
+	if err != nil || baselineVz == nil {
+		log.Debugf("No Verrazzano resource found in the baseline %s", baselineRoot)
+		return
+	}
+	currentVz, err := getVerrazzanoResource(log, currentRoot)
+	if err != nil || currentVz == nil {
+		log.Debugf("No Verrazzano resource found in %s", currentRoot)
+		return
+	}
+
+	var messages []string
+	if baselineVz.Status.State != currentVz.Status.State || baselineVz.Status.Version != currentVz.Status.Version {
+		messages = append(messages, fmt.Sprintf("Verrazzano changed from state %q at version %q to state %q at version %q",
+			baselineVz.Status.State, baselineVz.Status.Version, currentVz.Status.State, currentVz.Status.Version))
+	}
+	names := make([]string, 0, len(currentVz.Status.Components))
+	for name := range currentVz.Status.Components {
+		names = append(names, name)
+	}
+	for name := range baselineVz.Status.Components {
+		if _, ok := currentVz.Status.Components[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var baselineState, currentState string
+		if component, ok := baselineVz.Status.Components[name]; ok && component != nil {
+			baselineState = string(component.State)
+		}
+		if component, ok := currentVz.Status.Components[name]; ok && component != nil {
+			currentState = string(component.State)
+		}
+		if baselineState != currentState {
+			messages = append(messages, fmt.Sprintf("Component %s changed from state %q to state %q", name, baselineState, currentState))
+		}
+	}
+	if len(messages) > 0 {
+		issueReporter.AddKnownIssueMessagesFiles(report.ComponentStateChanged, currentRoot, messages,
+			[]string{files.FindFileInClusterRoot(baselineRoot, verrazzanoResource), files.FindFileInClusterRoot(currentRoot, verrazzanoResource)})
+	}
+}
+
+// comparePods reports the pods which are failing and were not failing in the baseline, and the image changes
+func comparePods(log *zap.SugaredLogger, baselineRoot string, currentRoot string, issueReporter *report.IssueReporter) {
+	baselinePods, err := getPodsSummary(log, baselineRoot)
+	if err != nil {
+		log.Debugf("Failed to get the pods for the baseline %s", baselineRoot, err)
+		return
+	}
+	currentPods, err := getPodsSummary(log, currentRoot)
+	if err != nil {
+		log.Debugf("Failed to get the pods for %s", currentRoot, err)
+		return
+	}
+
+	var messages []string
+	var relatedFiles []string
+	for _, key := range sortedKeys(currentPods.failing) {
+		if _, ok := baselinePods.failing[key]; ok {
+			continue
+		}
+		namespace := strings.SplitN(key, "/", 2)[0]
+		for _, podName := range currentPods.failing[key] {
+			messages = append(messages, report.GetRelatedPodMessage(podName, namespace))
+		}
+		relatedFiles = append(relatedFiles, currentPods.files[key])
+	}
+	if len(messages) > 0 {
+		issueReporter.AddKnownIssueMessagesFiles(report.NewlyFailingPods, currentRoot, messages, relatedFiles)
+	}
+
+	messages = nil
+	for _, key := range sortedKeys(currentPods.images) {
+		baselineImage, ok := baselinePods.images[key]
+		if ok && baselineImage != currentPods.images[key] {
+			messages = append(messages, fmt.Sprintf("Container %s changed from image %s to image %s", key, baselineImage, currentPods.images[key]))
+		}
+	}
+	if len(messages) > 0 {
+		issueReporter.AddKnownIssueMessagesFiles(report.ImageChanges, currentRoot, messages, nil)
+	}
+}
+
+// getPodsSummary gets the failing pods and the container images from the pods.json files of a cluster-snapshot
+func getPodsSummary(log *zap.SugaredLogger, clusterRoot string) (*podsSummary, error) {
+	podFiles, err := files.GetMatchingFiles(log, clusterRoot, PodFilesMatchRe)
+	if err != nil {
+		return nil, err
+	}
+	summary := &podsSummary{
+		failing: make(map[string][]string),
+		images:  make(map[string]string),
+		files:   make(map[string]string),
+	}
+	for _, podFile := range podFiles {
+		podList, err := GetPodList(log, podFile)
+		if err != nil || podList == nil {
+			log.Debugf("Failed to get the pods from %s", podFile, err)
+			continue
+		}
+		for _, pod := range podList.Items {
+			key := pod.Namespace + "/" + getPodWorkloadName(pod)
+			summary.files[key] = podFile
+			if IsPodProblematic(pod) {
+				summary.failing[key] = append(summary.failing[key], pod.Name)
+			}
+			for _, container := range pod.Spec.Containers {
+				summary.images[key+"/"+container.Name] = container.Image
+			}
+		}
+	}
+	return summary, nil
+}
+
+// getPodWorkloadName gets the name of the workload which created the pod, falling back to the pod name
+func getPodWorkloadName(pod corev1.Pod) string {
+	if len(pod.GenerateName) == 0 {
+		return pod.Name
+	}
+	name := strings.TrimSuffix(pod.GenerateName, "-")
+	if hash, ok := pod.Labels[podTemplateHashLabel]; ok {
+		name = strings.TrimSuffix(name, "-"+hash)
+	}
+	return name
+}
+
+// compareEventReasons reports the warning event reasons which were not seen in the baseline
+func compareEventReasons(log *zap.SugaredLogger, baselineRoot string, currentRoot string, issueReporter *report.IssueReporter) {
+	baselineReasons, _, err := getWarningEventReasons(log, baselineRoot)
+	if err != nil {
+		log.Debugf("Failed to get the events for the baseline %s", baselineRoot, err)
+		return
+	}
+	currentReasons, reasonFiles, err := getWarningEventReasons(log, currentRoot)
+	if err != nil {
+		log.Debugf("Failed to get the events for %s", currentRoot, err)
+		return
+	}
+
+	var messages []string
+	var relatedFiles []string
+	for _, key := range sortedKeys(currentReasons) {
+		if _, ok := baselineReasons[key]; ok {
+			continue
+		}
+		nsReason := strings.SplitN(key, "/", 2)
+		messages = append(messages, fmt.Sprintf("%d warning event(s) with reason %s in namespace %q", currentReasons[key], nsReason[1], nsReason[0]))
+		relatedFiles = append(relatedFiles, reasonFiles[key])
+	}
+	if len(messages) > 0 {
+		issueReporter.AddKnownIssueMessagesFiles(report.NewEventReasons, currentRoot, messages, relatedFiles)
+	}
+}
+
+// getWarningEventReasons gets the count of warning events by namespace and reason, along with the events file
+func getWarningEventReasons(log *zap.SugaredLogger, clusterRoot string) (map[string]int, map[string]string, error) {
+	eventFiles, err := files.GetMatchingFiles(log, clusterRoot, eventsFileRe)
+	if err != nil {
+		return nil, nil, err
+	}
+	reasons := make(map[string]int)
+	reasonFiles := make(map[string]string)
+	for _, eventFile := range eventFiles {
+		eventList, err := GetEventList(log, eventFile)
+		if err != nil || eventList == nil {
+			continue
+		}
+		for _, event := range eventList.Items {
+			if event.Type != corev1.EventTypeWarning {
+				continue
+			}
+			key := event.InvolvedObject.Namespace + "/" + event.Reason
+			reasons[key]++
+			reasonFiles[key] = eventFile
+		}
+	}
+	return reasons, reasonFiles, nil
+}
+
+// contributeNewIssues reports the issue types which were found in the current cluster-snapshot but not in the baseline
+func contributeNewIssues(log *zap.SugaredLogger, currentRoot string, currentIssues []report.Issue, baselineIssues []report.Issue) error {
+	baselineTypes := make(map[string]bool)
+	for _, issue := range baselineIssues {
+		baselineTypes[issue.Type] = true
+	}
+	var messages []string
+	impact := 0
+	for _, issue := range currentIssues {
+		if baselineTypes[issue.Type] || baselineIssueTypes[issue.Type] || issue.Informational {
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("%s: %s", issue.Type, issue.Summary))
+		if issue.Impact > impact {
+			impact = issue.Impact
+		}
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	sort.Strings(messages)
+	newIssues := report.NewKnownIssueMessagesFiles(report.NewIssuesSinceBaseline, currentRoot, messages, nil)
+	newIssues.Impact = impact
+	return report.ContributeIssue(log, newIssues)
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
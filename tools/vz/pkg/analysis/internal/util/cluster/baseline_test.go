@@ -0,0 +1,95 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+package cluster
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/analysis/internal/util/log"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/analysis/internal/util/report"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const baselineBefore = "../../../test/baseline/before"
+const baselineAfter = "../../../test/baseline/after"
+
+// TestCompareWithBaseline Tests the comparison of two captures
+// GIVEN a capture taken before and after an upgrade
+// WHEN the captures are compared
+// THEN the component state changes, newly failing pods, new event reasons, image changes and new issues are reported
+func TestCompareWithBaseline(t *testing.T) {
+	logger := log.GetDebugEnabledLogger()
+	report.ClearSourcesAnalyzed()
+	defer report.ClearSourcesAnalyzed()
+
+	assert.NoError(t, RunAnalysis(logger, baselineBefore))
+	baselineIssues := report.GetAllSourcesFilteredIssues(logger, true, 0, 0)
+	report.ClearSourcesAnalyzed()
+	assert.NoError(t, RunAnalysis(logger, baselineAfter))
+	// An issue which was also found in the baseline is not a new issue
+	afterRoot := baselineAfter + "/cluster-snapshot"
+	baselineIssues = append(baselineIssues, report.NewKnownIssueMessagesFiles(report.InsufficientCPU, baselineBefore+"/cluster-snapshot", nil, nil))
+	assert.NoError(t, report.ContributeIssue(logger, report.NewKnownIssueMessagesFiles(report.InsufficientCPU, afterRoot, nil, nil)))
+	assert.NoError(t, report.ContributeIssue(logger, report.NewKnownIssueMessagesFiles(report.InsufficientMemory, afterRoot, nil, nil)))
+	assert.NoError(t, CompareWithBaseline(logger, baselineBefore, baselineAfter, baselineIssues))
+
+	issues := make(map[string]report.Issue)
+	for _, issue := range report.GetAllSourcesFilteredIssues(logger, true, 0, 0) {
+		issues[issue.Type] = issue
+	}
+
+	assert.Contains(t, issues, report.ComponentStateChanged)
+	componentMessages := strings.Join(issues[report.ComponentStateChanged].SupportingData[0].Messages, "\n")
+	assert.Contains(t, componentMessages, "Component keycloak changed from state \"Ready\" to state \"Upgrading\"")
+	assert.NotContains(t, componentMessages, "rancher")
+
+	assert.Contains(t, issues, report.NewlyFailingPods)
+	assert.Equal(t, []string{report.GetRelatedPodMessage("keycloak-0", "keycloak")}, issues[report.NewlyFailingPods].SupportingData[0].Messages)
+
+	assert.Contains(t, issues, report.NewEventReasons)
+	eventMessages := strings.Join(issues[report.NewEventReasons].SupportingData[0].Messages, "\n")
+	assert.Contains(t, eventMessages, "FailedScheduling")
+	assert.NotContains(t, eventMessages, "Unhealthy")
+	assert.NotContains(t, eventMessages, "Created")
+
+	// The app pod was recreated with the same image, only the keycloak image changed
+	assert.Contains(t, issues, report.ImageChanges)
+	assert.Equal(t, []string{"Container keycloak/keycloak/keycloak changed from image keycloak:1 to image keycloak:2"}, issues[report.ImageChanges].SupportingData[0].Messages)
+
+	assert.Contains(t, issues, report.NewIssuesSinceBaseline)
+	newIssueMessages := issues[report.NewIssuesSinceBaseline].SupportingData[0].Messages
+	assert.Len(t, newIssueMessages, 1)
+	assert.True(t, strings.HasPrefix(newIssueMessages[0], report.InsufficientMemory))
+	assert.Equal(t, 10, issues[report.NewIssuesSinceBaseline].Impact)
+}
+
+// TestCompareWithBaselineMissing Tests the comparison with a baseline that has no cluster-snapshot
+// GIVEN a baseline directory without a cluster-snapshot
+// WHEN the captures are compared
+// THEN an error is returned
+func TestCompareWithBaselineMissing(t *testing.T) {
+	logger := log.GetDebugEnabledLogger()
+	assert.Error(t, CompareWithBaseline(logger, "../../../test/rules", baselineAfter, nil))
+}
+
+// TestGetPodWorkloadName Tests the workload names used to match pods between captures
+func TestGetPodWorkloadName(t *testing.T) {
+	var tests = []struct {
+		name     string
+		pod      corev1.Pod
+		workload string
+	}{
+		{"no-generate-name", corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "standalone"}}, "standalone"},
+		{"statefulset", corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "mysql-0", GenerateName: "mysql-"}}, "mysql"},
+		{"deployment", corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "app-5d4f8-abcde", GenerateName: "app-5d4f8-",
+			Labels: map[string]string{podTemplateHashLabel: "5d4f8"}}}, "app"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.workload, getPodWorkloadName(tt.pod))
+		})
+	}
+}
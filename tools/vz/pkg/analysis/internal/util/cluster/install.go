@@ -365,6 +365,36 @@ func analyzeIstioIngressService(log *zap.SugaredLogger, clusterRoot string, issu
 // Read the Verrazzano resource and return the list of components which did not reach Ready state
 func getComponentsNotReady(log *zap.SugaredLogger, clusterRoot string) ([]string, error) {
 	var compsNotReady = make([]string, 0)
+	vzRes, err := getVerrazzanoResource(log, clusterRoot)
+	if err != nil {
+		return compsNotReady, err
+	}
+	if vzRes == nil {
+		return nil, nil
+	}
+
+	if vzRes.Status.State != installv1alpha1.VzStateReady {
+		log.Debugf("Verrazzano installation is not complete, installation state %s", vzRes.Status.State)
+
+		// Verrazzano installation is not complete, find out the list of components which are not ready
+		for _, compStatusDetail := range vzRes.Status.Components {
+			if compStatusDetail.State != installv1alpha1.CompStateReady {
+				if compStatusDetail.State == installv1alpha1.CompStateDisabled {
+					continue
+				}
+				log.Debugf("Component %s is not in ready state, state is %s", compStatusDetail.Name, vzRes.Status.State)
+				compsNotReady = append(compsNotReady, compStatusDetail.Name)
+			}
+		}
+		return compsNotReady, nil
+	}
+
+	return compsNotReady, nil
+}
+
+// getVerrazzanoResource reads the Verrazzano resource captured in the cluster root, nil is returned when the
+// resource was not captured
+func getVerrazzanoResource(log *zap.SugaredLogger, clusterRoot string) (*installv1alpha1.Verrazzano, error) {
 	vzResourcesPath := files.FindFileInClusterRoot(clusterRoot, verrazzanoResource)
 	fileInfo, e := os.Stat(vzResourcesPath)
 	if e != nil || fileInfo.Size() == 0 {
@@ -377,20 +407,20 @@ func getComponentsNotReady(log *zap.SugaredLogger, clusterRoot string) ([]string
 	file, err := os.Open(vzResourcesPath)
 	if err != nil {
 		log.Infof("file %s not found", vzResourcesPath)
-		return compsNotReady, err
+		return nil, err
 	}
 	defer file.Close()
 	fileBytes, err := io.ReadAll(file)
 	if err != nil {
 		log.Infof("Failed reading Json file %s", vzResourcesPath)
-		return compsNotReady, err
+		return nil, err
 	}
 
 	var vzResourceList installv1alpha1.VerrazzanoList
 	err = encjson.Unmarshal(fileBytes, &vzResourceList)
 	if err != nil {
 		log.Infof("Failed to unmarshal Verrazzano resource at %s", vzResourcesPath)
-		return compsNotReady, err
+		return nil, err
 	}
 
 	var vzRes installv1alpha1.Verrazzano
@@ -402,27 +432,10 @@ func getComponentsNotReady(log *zap.SugaredLogger, clusterRoot string) ([]string
 		err := encjson.Unmarshal(fileBytes, &vzRes)
 		if err != nil {
 			log.Infof("Failed to unmarshal Verrazzano resource at %s", vzResourcesPath)
-			return compsNotReady, err
+			return nil, err
 		}
 	}
-
-	if vzRes.Status.State != installv1alpha1.VzStateReady {
-		log.Debugf("Verrazzano installation is not complete, installation state %s", vzRes.Status.State)
-
-		// Verrazzano installation is not complete, find out the list of components which are not ready
-		for _, compStatusDetail := range vzRes.Status.Components {
-			if compStatusDetail.State != installv1alpha1.CompStateReady {
-				if compStatusDetail.State == installv1alpha1.CompStateDisabled {
-					continue
-				}
-				log.Debugf("Component %s is not in ready state, state is %s", compStatusDetail.Name, vzRes.Status.State)
-				compsNotReady = append(compsNotReady, compStatusDetail.Name)
-			}
-		}
-		return compsNotReady, nil
-	}
-
-	return compsNotReady, nil
+	return &vzRes, nil
 }
 
 // Read the platform operator log, report the errors found for the list of components which fail to reach Ready state
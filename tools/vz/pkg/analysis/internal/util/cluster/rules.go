@@ -0,0 +1,422 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+// Package cluster handles cluster analysis
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/analysis/internal/util/files"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/analysis/internal/util/json"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/analysis/internal/util/report"
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
+)
+
+// User supplied rules allow issues to be identified without adding a Go analyzer to the CLI. Rules are declared in
+// YAML and are evaluated against each cluster-snapshot alongside the built-in analyzers, for example:
+//
+//	rules:
+//	- name: MyAppCrashing
+//	  summary: The my-app pods are failing to start
+//	  confidence: 8
+//	  impact: 6
+//	  actions:
+//	  - summary: Check the my-app database credentials
+//	    links: ["https://example.com/runbooks/my-app"]
+//	  match:
+//	    jsonPaths:
+//	    - file: ".*/my-app/pods.json"
+//	      path: "items.status.phase"
+//	      value: "Pending|Failed"
+//	    events:
+//	    - namespace: "my-app"
+//	      reason: "BackOff"
+//	    logs:
+//	    - namespace: "my-app"
+//	      pattern: "connection refused"
+//
+// Every matcher that is specified in a rule must match for the rule to fire, an issue is then contributed using the
+// summary, actions, confidence and impact declared by the rule along with the matches as supporting data.
+
+// RuleSet is the top level structure of a rules file
+type RuleSet struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Rule describes a user supplied analysis rule
+type Rule struct {
+	Name          string       `json:"name"`                    // Required, used as the issue type
+	Summary       string       `json:"summary"`                 // Required, the summary of the issue reported
+	Informational bool         `json:"informational,omitempty"` // Optional, the issue is informational only
+	Confidence    int          `json:"confidence"`              // Required if not informational 0-10
+	Impact        int          `json:"impact,omitempty"`        // Optional 0-10
+	Actions       []RuleAction `json:"actions,omitempty"`       // Optional, actions reported in the order specified
+	Match         RuleMatch    `json:"match"`                   // Required, at least one matcher must be specified
+}
+
+// RuleAction describes an action to take when a rule matches
+type RuleAction struct {
+	Summary string   `json:"summary"`
+	Links   []string `json:"links,omitempty"`
+	Steps   []string `json:"steps,omitempty"`
+}
+
+// RuleMatch holds the matchers for a rule
+type RuleMatch struct {
+	JSONPaths []JSONPathMatcher `json:"jsonPaths,omitempty"`
+	Events    []EventMatcher    `json:"events,omitempty"`
+	Logs      []LogMatcher      `json:"logs,omitempty"`
+}
+
+// JSONPathMatcher matches a value at a JSON path in the files of a cluster-snapshot
+type JSONPathMatcher struct {
+	File  string `json:"file"`            // Required, regular expression matched against file paths relative to the cluster-snapshot
+	Path  string `json:"path"`            // Required, the JSON path to inspect
+	Value string `json:"value,omitempty"` // Optional, regular expression matched against the value(s), if not specified the path only needs to exist
+}
+
+// EventMatcher matches events captured in a cluster-snapshot
+type EventMatcher struct {
+	Namespace string `json:"namespace,omitempty"` // Optional, regular expression matched against the namespace
+	Reason    string `json:"reason"`              // Required, regular expression matched against the event reason
+	Message   string `json:"message,omitempty"`   // Optional, regular expression matched against the event message
+}
+
+// LogMatcher matches pod logs captured in a cluster-snapshot
+type LogMatcher struct {
+	Namespace string `json:"namespace,omitempty"` // Optional, regular expression matched against the namespace
+	Pod       string `json:"pod,omitempty"`       // Optional, regular expression matched against the pod name
+	Pattern   string `json:"pattern"`             // Required, regular expression searched for in the pod logs
+}
+
+// userRules are the rules which were registered for this analysis
+var userRules = make([]Rule, 0)
+var userRulesMutex = &sync.Mutex{}
+
+var ruleFilesRe = regexp.MustCompile(`\.(yaml|yml)$`)
+var eventsFileRe = regexp.MustCompile(`events.json$`)
+
+// ParseRules parses and validates the rules in a YAML document
+func ParseRules(data []byte) ([]Rule, error) {
+	ruleSet := RuleSet{}
+	if err := yaml.UnmarshalStrict(data, &ruleSet); err != nil {
+		return nil, err
+	}
+	for i := range ruleSet.Rules {
+		if err := ruleSet.Rules[i].Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return ruleSet.Rules, nil
+}
+
+// LoadRulesFromDirectory parses all of the YAML rule files in a directory
+func LoadRulesFromDirectory(log *zap.SugaredLogger, directory string) ([]Rule, error) {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		log.Debugf("Failed to read rules directory %s", directory, err)
+		return nil, err
+	}
+	rules := make([]Rule, 0)
+	for _, entry := range entries {
+		if entry.IsDir() || !ruleFilesRe.MatchString(entry.Name()) {
+			continue
+		}
+		fileName := filepath.Join(directory, entry.Name())
+		data, err := os.ReadFile(fileName)
+		if err != nil {
+			return nil, err
+		}
+		fileRules, err := ParseRules(data)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid rules file %s: %s", fileName, err.Error())
+		}
+		log.Debugf("Loaded %d rules from %s", len(fileRules), fileName)
+		rules = append(rules, fileRules...)
+	}
+	return rules, nil
+}
+
+// RegisterRules adds rules which will be evaluated when a cluster is analyzed. Rule names must be unique.
+func RegisterRules(rules []Rule) error {
+	userRulesMutex.Lock()
+	defer userRulesMutex.Unlock()
+	for _, rule := range rules {
+		for _, existing := range userRules {
+			if existing.Name == rule.Name {
+				return fmt.Errorf("A rule named %s is already registered", rule.Name)
+			}
+		}
+		userRules = append(userRules, rule)
+	}
+	return nil
+}
+
+// ClearRules clears the registered rules, only for unit tests
+func ClearRules() {
+	userRulesMutex.Lock()
+	userRules = make([]Rule, 0)
+	userRulesMutex.Unlock()
+}
+
+// Validate validates a rule
+func (rule *Rule) Validate() error {
+	if len(rule.Name) == 0 {
+		return errors.New("A name is required for a rule")
+	}
+	if len(rule.Summary) == 0 {
+		return fmt.Errorf("A summary is required for rule %s", rule.Name)
+	}
+	if rule.Confidence < 0 || rule.Confidence > 10 {
+		return fmt.Errorf("Confidence %d is out of range for rule %s", rule.Confidence, rule.Name)
+	}
+	if rule.Impact < 0 || rule.Impact > 10 {
+		return fmt.Errorf("Impact %d is out of range for rule %s", rule.Impact, rule.Name)
+	}
+	for _, action := range rule.Actions {
+		if len(action.Summary) == 0 {
+			return fmt.Errorf("A summary is required for the actions of rule %s", rule.Name)
+		}
+	}
+	if len(rule.Match.JSONPaths) == 0 && len(rule.Match.Events) == 0 && len(rule.Match.Logs) == 0 {
+		return fmt.Errorf("At least one matcher is required for rule %s", rule.Name)
+	}
+	for _, matcher := range rule.Match.JSONPaths {
+		if len(matcher.File) == 0 || len(matcher.Path) == 0 {
+			return fmt.Errorf("A file and path are required for the jsonPaths of rule %s", rule.Name)
+		}
+		if err := validateRegexps(rule.Name, matcher.File, matcher.Value); err != nil {
+			return err
+		}
+	}
+	for _, matcher := range rule.Match.Events {
+		if len(matcher.Reason) == 0 {
+			return fmt.Errorf("A reason is required for the events of rule %s", rule.Name)
+		}
+		if err := validateRegexps(rule.Name, matcher.Namespace, matcher.Reason, matcher.Message); err != nil {
+			return err
+		}
+	}
+	for _, matcher := range rule.Match.Logs {
+		if len(matcher.Pattern) == 0 {
+			return fmt.Errorf("A pattern is required for the logs of rule %s", rule.Name)
+		}
+		if err := validateRegexps(rule.Name, matcher.Namespace, matcher.Pod, matcher.Pattern); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateRegexps(ruleName string, expressions ...string) error {
+	for _, expression := range expressions {
+		if _, err := regexp.Compile(expression); err != nil {
+			return fmt.Errorf("Invalid regular expression %q in rule %s: %s", expression, ruleName, err.Error())
+		}
+	}
+	return nil
+}
+
+// AnalyzeUserRules evaluates the registered user rules against a cluster-snapshot
+func AnalyzeUserRules(log *zap.SugaredLogger, clusterRoot string) (err error) {
+	log.Debugf("AnalyzeUserRules called for %s", clusterRoot)
+
+	userRulesMutex.Lock()
+	rules := make([]Rule, len(userRules))
+	copy(rules, userRules)
+	userRulesMutex.Unlock()
+
+	for _, rule := range rules {
+		supportData, matched, err := evaluateRule(log, clusterRoot, rule)
+		if err != nil {
+			// Log the error and continue on
+			log.Errorf("Error evaluating rule %s", rule.Name, err)
+			continue
+		}
+		if !matched {
+			continue
+		}
+		err = report.ContributeIssue(log, rule.toIssue(clusterRoot, supportData))
+		if err != nil {
+			log.Errorf("Error contributing issue for rule %s", rule.Name, err)
+		}
+	}
+	return nil
+}
+
+// toIssue creates the issue which is reported when the rule matched
+func (rule *Rule) toIssue(source string, supportData []report.SupportData) report.Issue {
+	actions := make([]report.Action, 0, len(rule.Actions))
+	for _, action := range rule.Actions {
+		actions = append(actions, report.Action{Summary: action.Summary, Links: action.Links, Steps: action.Steps})
+	}
+	return report.Issue{
+		Type:           rule.Name,
+		Source:         source,
+		Informational:  rule.Informational,
+		Summary:        rule.Summary,
+		Actions:        actions,
+		SupportingData: supportData,
+		Confidence:     rule.Confidence,
+		Impact:         rule.Impact,
+	}
+}
+
+// evaluateRule returns the supporting data for the rule, matched is only true if all of the matchers matched
+func evaluateRule(log *zap.SugaredLogger, clusterRoot string, rule Rule) (supportData []report.SupportData, matched bool, err error) {
+	for _, matcher := range rule.Match.JSONPaths {
+		data, err := matchJSONPath(log, clusterRoot, matcher)
+		if err != nil || data == nil {
+			return nil, false, err
+		}
+		supportData = append(supportData, *data)
+	}
+	for _, matcher := range rule.Match.Events {
+		data, err := matchEvents(log, clusterRoot, matcher)
+		if err != nil || data == nil {
+			return nil, false, err
+		}
+		supportData = append(supportData, *data)
+	}
+	for _, matcher := range rule.Match.Logs {
+		data, err := matchLogs(log, clusterRoot, matcher)
+		if err != nil || data == nil {
+			return nil, false, err
+		}
+		supportData = append(supportData, *data)
+	}
+	return supportData, true, nil
+}
+
+// matchJSONPath returns supporting data if any of the files matched have a value at the path which matches
+func matchJSONPath(log *zap.SugaredLogger, clusterRoot string, matcher JSONPathMatcher) (*report.SupportData, error) {
+	fileRe := regexp.MustCompile(matcher.File)
+	valueRe := regexp.MustCompile(matcher.Value)
+	jsonFiles, err := files.GetMatchingFiles(log, clusterRoot, regexp.MustCompile(`\.json$`))
+	if err != nil {
+		return nil, err
+	}
+	var paths []report.JSONPath
+	var messages []string
+	for _, fileName := range jsonFiles {
+		relativeName, err := filepath.Rel(clusterRoot, fileName)
+		if err != nil || !fileRe.MatchString(relativeName) {
+			continue
+		}
+		jsonData, err := json.GetJSONDataFromFile(log, fileName)
+		if err != nil {
+			log.Debugf("Skipping rule evaluation on invalid JSON file %s", fileName, err)
+			continue
+		}
+		value, err := json.GetJSONValue(log, jsonData, matcher.Path)
+		if err != nil || value == nil {
+			continue
+		}
+		for _, matchedValue := range matchingValues(value, valueRe) {
+			paths = append(paths, report.JSONPath{File: fileName, Path: matcher.Path})
+			messages = append(messages, fmt.Sprintf("%s has the value %q", matcher.Path, matchedValue))
+		}
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	return &report.SupportData{Messages: messages, JSONPaths: paths}, nil
+}
+
+// matchingValues flattens a JSON value and returns the string form of the leaf values matching the expression
+func matchingValues(value interface{}, valueRe *regexp.Regexp) (matches []string) {
+	switch value := value.(type) {
+	case []interface{}:
+		for _, element := range value {
+			matches = append(matches, matchingValues(element, valueRe)...)
+		}
+	case map[string]interface{}:
+		if valueRe.MatchString("") {
+			matches = append(matches, "")
+		}
+	case nil:
+	default:
+		stringValue := fmt.Sprintf("%v", value)
+		if valueRe.MatchString(stringValue) {
+			matches = append(matches, stringValue)
+		}
+	}
+	return matches
+}
+
+// matchEvents returns supporting data if any events in the cluster-snapshot match
+func matchEvents(log *zap.SugaredLogger, clusterRoot string, matcher EventMatcher) (*report.SupportData, error) {
+	namespaceRe := regexp.MustCompile(matcher.Namespace)
+	reasonRe := regexp.MustCompile(matcher.Reason)
+	messageRe := regexp.MustCompile(matcher.Message)
+	eventFiles, err := files.GetMatchingFiles(log, clusterRoot, eventsFileRe)
+	if err != nil {
+		return nil, err
+	}
+	var messages []string
+	var relatedFiles []string
+	for _, fileName := range eventFiles {
+		eventList, err := GetEventList(log, fileName)
+		if err != nil || eventList == nil {
+			continue
+		}
+		matched := false
+		for _, event := range eventList.Items {
+			if !namespaceRe.MatchString(event.InvolvedObject.Namespace) || !reasonRe.MatchString(event.Reason) || !messageRe.MatchString(event.Message) {
+				continue
+			}
+			matched = true
+			messages = append(messages, fmt.Sprintf("Event %s for %s %q in namespace %q: %s", event.Reason, event.InvolvedObject.Kind,
+				event.InvolvedObject.Name, event.InvolvedObject.Namespace, event.Message))
+		}
+		if matched {
+			relatedFiles = append(relatedFiles, fileName)
+		}
+	}
+	if len(messages) == 0 {
+		return nil, nil
+	}
+	return &report.SupportData{Messages: messages, RelatedFiles: relatedFiles}, nil
+}
+
+// matchLogs returns supporting data if any pod logs in the cluster-snapshot match
+func matchLogs(log *zap.SugaredLogger, clusterRoot string, matcher LogMatcher) (*report.SupportData, error) {
+	namespaceRe := regexp.MustCompile(matcher.Namespace)
+	podRe := regexp.MustCompile(matcher.Pod)
+	patternRe := regexp.MustCompile(matcher.Pattern)
+	logFiles, err := files.GetMatchingFiles(log, clusterRoot, LogFilesMatchRe)
+	if err != nil {
+		return nil, err
+	}
+	selected := make([]string, 0, len(logFiles))
+	for _, fileName := range logFiles {
+		// Pod logs are captured in <cluster-root>/<namespace>/<pod>/logs.txt
+		relativeName, err := filepath.Rel(clusterRoot, fileName)
+		if err != nil {
+			continue
+		}
+		segments := strings.Split(filepath.ToSlash(relativeName), "/")
+		if len(segments) != 3 || !namespaceRe.MatchString(segments[0]) || !podRe.MatchString(segments[1]) {
+			continue
+		}
+		selected = append(selected, fileName)
+	}
+	sort.Strings(selected)
+	matches, err := files.SearchFiles(log, clusterRoot, selected, patternRe, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	return &report.SupportData{TextMatches: matches}, nil
+}
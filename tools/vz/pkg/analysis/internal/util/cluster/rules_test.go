@@ -0,0 +1,75 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/analysis/internal/util/log"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/analysis/internal/util/report"
+)
+
+// TestParseRulesInvalid Tests that invalid rules are rejected
+// GIVEN a call to ParseRules
+// WHEN the rules are not valid
+// THEN an error is returned
+func TestParseRulesInvalid(t *testing.T) {
+	var tests = []struct {
+		name  string
+		rules string
+	}{
+		{"missing-summary", "rules:\n- name: a\n  match:\n    events:\n    - reason: x\n"},
+		{"no-matchers", "rules:\n- name: a\n  summary: s\n"},
+		{"bad-confidence", "rules:\n- name: a\n  summary: s\n  confidence: 11\n  match:\n    events:\n    - reason: x\n"},
+		{"bad-regex", "rules:\n- name: a\n  summary: s\n  match:\n    logs:\n    - pattern: \"(\"\n"},
+		{"missing-path", "rules:\n- name: a\n  summary: s\n  match:\n    jsonPaths:\n    - file: x\n"},
+		{"unknown-field", "rules:\n- name: a\n  summary: s\n  severity: 3\n  match:\n    events:\n    - reason: x\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRules([]byte(tt.rules))
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestRegisterRulesDuplicate Tests that rule names must be unique
+// GIVEN a call to RegisterRules
+// WHEN a rule with the same name is already registered
+// THEN an error is returned
+func TestRegisterRulesDuplicate(t *testing.T) {
+	defer ClearRules()
+	rules := []Rule{{Name: "a", Summary: "s", Match: RuleMatch{Events: []EventMatcher{{Reason: "x"}}}}}
+	assert.NoError(t, RegisterRules(rules))
+	assert.Error(t, RegisterRules(rules))
+}
+
+// TestAnalyzeUserRules Tests that matching user rules contribute issues
+// GIVEN a call to AnalyzeUserRules
+// WHEN rules are loaded from a directory and a cluster-snapshot is analyzed
+// THEN issues are reported for the rules where all of the matchers matched
+func TestAnalyzeUserRules(t *testing.T) {
+	logger := log.GetDebugEnabledLogger()
+	defer ClearRules()
+	report.ClearReports()
+	defer report.ClearReports()
+
+	rules, err := LoadRulesFromDirectory(logger, "../../../test/rules")
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	assert.NoError(t, RegisterRules(rules))
+
+	assert.NoError(t, AnalyzeUserRules(logger, "../../../test/cluster/image-pull-case1/cluster-snapshot"))
+	reportedIssues := report.GetAllSourcesFilteredIssues(logger, true, 0, 0)
+	assert.Len(t, reportedIssues, 1)
+	issue := reportedIssues[0]
+	assert.Equal(t, "BobsBooksStockPending", issue.Type)
+	assert.Equal(t, 8, issue.Confidence)
+	assert.Equal(t, 6, issue.Impact)
+	assert.Len(t, issue.Actions, 1)
+	assert.Len(t, issue.SupportingData, 3)
+	assert.NotEmpty(t, issue.SupportingData[0].JSONPaths)
+	assert.NotEmpty(t, issue.SupportingData[1].Messages)
+	assert.NotEmpty(t, issue.SupportingData[2].TextMatches)
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 // Package files handles searching
@@ -29,10 +29,10 @@ type TimeRange struct {
 
 // TextMatch supplies information about the matched text
 type TextMatch struct {
-	FileName    string
-	FileLine    int
-	Timestamp   metav1.Time
-	MatchedText string
+	FileName    string      `json:"fileName"`
+	FileLine    int         `json:"fileLine"`
+	Timestamp   metav1.Time `json:"timestamp"`
+	MatchedText string      `json:"matchedText"`
 }
 
 var ZeroTime = metav1.NewTime(time.Time{})
@@ -22,9 +22,9 @@ import (
 //   - Link(s) to a Runbook(s) are preferable here as instructions may evolve over time and may be complex
 //   - A list of Steps to take
 type Action struct {
-	Summary string   // Required, Summary of the action to take
-	Links   []string // Optional, runbook or other related Links with action details
-	Steps   []string // Optional, list of Steps to take (pointing to runbook is preferable if Actions are complex)
+	Summary string   `json:"summary"`         // Required, Summary of the action to take
+	Links   []string `json:"links,omitempty"` // Optional, runbook or other related Links with action details
+	Steps   []string `json:"steps,omitempty"` // Optional, list of Steps to take (pointing to runbook is preferable if Actions are complex)
 }
 
 // Validate validates the action
@@ -86,6 +86,11 @@ var KnownActions = map[string]Action{
 	NginxIngressPrivateSubnet:    {Summary: getConsultRunbookAction(ConsultRunbook, RunbookLinks[NginxIngressPrivateSubnet][0])},
 	ExternalDNSConfigureIssue:    {Summary: getConsultRunbookAction(ConsultRunbook, RunbookLinks[ExternalDNSConfigureIssue][0])},
 	KeycloakDataMigrationFailure: {Summary: getConsultRunbookAction(ConsultRunbook, RunbookLinks[KeycloakDataMigrationFailure][0])},
+	ComponentStateChanged:        {Summary: "Review the components whose state changed, components which are no longer Ready are likely related to other issues reported"},
+	NewlyFailingPods:             {Summary: "Review the logs and events of the failing pods, and compare them with the baseline capture"},
+	NewEventReasons:              {Summary: "Review the new warning events, they may indicate the cause of other issues reported"},
+	ImageChanges:                 {Summary: "Confirm that the image changes are expected for the upgrade or change which was made"},
+	NewIssuesSinceBaseline:       {Summary: "Address the new issues first, they are the most likely regressions since the baseline capture"},
 }
 
 func getConsultRunbookAction(summaryF string, runbookLink string) string {
@@ -34,29 +34,29 @@ import (
 
 // JSONPath is a JSON path
 type JSONPath struct {
-	File string // Json filename
-	Path string // Json Path
+	File string `json:"file"` // Json filename
+	Path string `json:"path"` // Json Path
 }
 
 // SupportData is data which helps a user to further identify an issue TODO: Shake this out more as we add more types, see what we really end up needing here
 type SupportData struct {
-	Messages     []string          // Optional, Messages and/or descriptions the supporting data
-	RelatedFiles []string          // Optional, if present provides a list of related files that support the issue identification
-	TextMatches  []files.TextMatch // Optional, if present provides search results that support the issue identification
-	JSONPaths    []JSONPath        // Optional, if present provides a list of Json paths that support the issue identification
+	Messages     []string          `json:"messages,omitempty"`     // Optional, Messages and/or descriptions the supporting data
+	RelatedFiles []string          `json:"relatedFiles,omitempty"` // Optional, if present provides a list of related files that support the issue identification
+	TextMatches  []files.TextMatch `json:"textMatches,omitempty"`  // Optional, if present provides search results that support the issue identification
+	JSONPaths    []JSONPath        `json:"jsonPaths,omitempty"`    // Optional, if present provides a list of Json paths that support the issue identification
 }
 
 // Issue holds the information about an issue, supporting data, and actions
 type Issue struct {
-	Type          string   // Required, This identifies the type of issue. This is either a Known Issue type, or a custom type name
-	Source        string   // Required, This is the source of the analysis, It may be the root of the cluster analyzed (ie: there can be multiple)
-	Informational bool     // Defaults to false, if this is not an issue but an Informational note (TBD: may separate these)
-	Summary       string   // Required, there must be a Summary of the issue included
-	Actions       []Action // Optional, if Actions are known these are included. Actions will be reported in the order specified
-
-	SupportingData []SupportData // Optional but highly desirable for issues when possible. Data that helps support issue identification
-	Confidence     int           // Required if not informational 0-10 ()
-	Impact         int           // Optional 0-10 (TBD: This is a swag at how broad the impact is, 0 low, 10 high, defaults to -1 unknown)
+	Type          string   `json:"type"`              // Required, This identifies the type of issue. This is either a Known Issue type, or a custom type name
+	Source        string   `json:"source"`            // Required, This is the source of the analysis, It may be the root of the cluster analyzed (ie: there can be multiple)
+	Informational bool     `json:"informational"`     // Defaults to false, if this is not an issue but an Informational note (TBD: may separate these)
+	Summary       string   `json:"summary"`           // Required, there must be a Summary of the issue included
+	Actions       []Action `json:"actions,omitempty"` // Optional, if Actions are known these are included. Actions will be reported in the order specified
+
+	SupportingData []SupportData `json:"supportingData,omitempty"` // Optional but highly desirable for issues when possible. Data that helps support issue identification
+	Confidence     int           `json:"confidence"`               // Required if not informational 0-10 ()
+	Impact         int           `json:"impact"`                   // Optional 0-10 (TBD: This is a swag at how broad the impact is, 0 low, 10 high, defaults to -1 unknown)
 }
 
 // Validate validates an issue. A zeroed Issue is not valid, there is some amount of information that must be specified for the Issue to
@@ -118,6 +118,13 @@ const (
 	NginxIngressPrivateSubnet    = "NginxIngressPrivateSubnet"
 	ExternalDNSConfigureIssue    = "ExternalDNSConfigureIssue"
 	KeycloakDataMigrationFailure = "KeycloakDataMigrationFailure"
+
+	// Issue types reported when comparing against a baseline capture
+	ComponentStateChanged  = "ComponentStateChanged"
+	NewlyFailingPods       = "NewlyFailingPods"
+	NewEventReasons        = "NewEventReasons"
+	ImageChanges           = "ImageChanges"
+	NewIssuesSinceBaseline = "NewIssuesSinceBaseline"
 )
 
 // NOTE: How we are handling the issues/actions/reporting is still very much evolving here. Currently supplying some
@@ -147,6 +154,11 @@ var knownIssues = map[string]Issue{
 	NginxIngressPrivateSubnet:    {Type: NginxIngressPrivateSubnet, Summary: "Failed to create LoadBalancer for Nginx Ingress Controller", Informational: false, Impact: 10, Confidence: 10, Actions: []Action{KnownActions[NginxIngressPrivateSubnet]}},
 	ExternalDNSConfigureIssue:    {Type: ExternalDNSConfigureIssue, Summary: "Failed to setup DNS configuration", Informational: false, Impact: 10, Confidence: 10, Actions: []Action{KnownActions[ExternalDNSConfigureIssue]}},
 	KeycloakDataMigrationFailure: {Type: KeycloakDataMigrationFailure, Summary: "Failure(s) migrating Keycloak data during MySQL upgrade", Informational: true, Impact: 10, Confidence: 10, Actions: []Action{KnownActions[KeycloakDataMigrationFailure]}},
+	ComponentStateChanged:        {Type: ComponentStateChanged, Summary: "The state of Verrazzano component(s) changed since the baseline capture", Informational: true, Impact: 0, Confidence: 10, Actions: []Action{KnownActions[ComponentStateChanged]}},
+	NewlyFailingPods:             {Type: NewlyFailingPods, Summary: "Pods which were healthy or not present in the baseline capture are failing", Informational: false, Impact: 5, Confidence: 8, Actions: []Action{KnownActions[NewlyFailingPods]}},
+	NewEventReasons:              {Type: NewEventReasons, Summary: "Warning events with reasons not seen in the baseline capture were detected", Informational: true, Impact: 0, Confidence: 5, Actions: []Action{KnownActions[NewEventReasons]}},
+	ImageChanges:                 {Type: ImageChanges, Summary: "Container images changed since the baseline capture", Informational: true, Impact: 0, Confidence: 10, Actions: []Action{KnownActions[ImageChanges]}},
+	NewIssuesSinceBaseline:       {Type: NewIssuesSinceBaseline, Summary: "Issues were detected which were not detected in the baseline capture", Informational: false, Impact: 10, Confidence: 10, Actions: []Action{KnownActions[NewIssuesSinceBaseline]}},
 }
 
 // NewKnownIssueSupportingData adds a known issue
@@ -0,0 +1,331 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+// Package report handles reporting
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/verrazzano/verrazzano/tools/vz/cmd/version"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/constants"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	"go.uber.org/zap"
+)
+
+// The machine readable reports are intended for automation, for example gating a CI pipeline or feeding a ticketing
+// system. The same filtering is applied as for the human readable report. The JSON report has the following schema,
+// which is versioned using the schemaVersion field:
+//
+//	{
+//	  "schemaVersion": "1.0",
+//	  "cliVersion": "<version of the vz CLI>",
+//	  "verrazzanoVersion": "<version, only when analyzing a live cluster>",
+//	  "kubernetesVersion": "<version, only when analyzing a live cluster>",
+//	  "sources": [{
+//	    "source": "<path of the cluster-snapshot analyzed>",
+//	    "issues": [{
+//	      "id": "<stable issue id, derived from the type and summary>",
+//	      "type": "<known issue type or the name of a user supplied rule>",
+//	      "source": "<path of the cluster-snapshot analyzed>",
+//	      "informational": false,
+//	      "summary": "<summary of the issue>",
+//	      "confidence": 0-10,
+//	      "impact": 0-10,
+//	      "actions": [{"summary": "", "links": [""], "steps": [""]}],
+//	      "supportingData": [{
+//	        "messages": [""],
+//	        "relatedFiles": [""],
+//	        "textMatches": [{"fileName": "", "fileLine": 0, "timestamp": "", "matchedText": ""}],
+//	        "jsonPaths": [{"file": "", "path": ""}]
+//	      }]
+//	    }]
+//	  }]
+//	}
+//
+// The SARIF report follows the SARIF 2.1.0 specification, each issue type is a rule and each issue is a result.
+// The stable issue id is available in the partialFingerprints of a result and the confidence, impact, source and
+// actions are available in the properties of a result.
+
+// MachineReportSchemaVersion is the version of the JSON report schema
+const MachineReportSchemaVersion = "1.0"
+
+const (
+	sarifSchema   = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion  = "2.1.0"
+	sarifToolName = "vz analyze"
+	sarifToolURI  = "https://verrazzano.io"
+
+	// impact at or above which a SARIF result is reported as an error rather than a warning
+	sarifErrorImpact = 7
+)
+
+// JSONReport is the report generated for the json report format
+type JSONReport struct {
+	SchemaVersion     string             `json:"schemaVersion"`
+	CLIVersion        string             `json:"cliVersion,omitempty"`
+	VerrazzanoVersion string             `json:"verrazzanoVersion,omitempty"`
+	KubernetesVersion string             `json:"kubernetesVersion,omitempty"`
+	Sources           []JSONReportSource `json:"sources"`
+}
+
+// JSONReportSource holds the issues reported for a source
+type JSONReportSource struct {
+	Source string            `json:"source"`
+	Issues []JSONReportIssue `json:"issues"`
+}
+
+// JSONReportIssue is an issue along with its stable id
+type JSONReportIssue struct {
+	ID string `json:"id"`
+	Issue
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	HelpURI          string       `json:"helpUri,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string                 `json:"ruleId"`
+	Level               string                 `json:"level"`
+	Message             sarifMessage           `json:"message"`
+	Locations           []sarifLocation        `json:"locations,omitempty"`
+	PartialFingerprints map[string]string      `json:"partialFingerprints"`
+	Properties          map[string]interface{} `json:"properties"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// IssueID returns a stable id for an issue. The id only depends on the type and summary of the issue, so the
+// same issue has the same id across captures and analysis runs.
+func IssueID(issue Issue) string {
+	sum := sha256.Sum256([]byte(issue.Type + "\n" + issue.Summary))
+	return "VZ-" + hex.EncodeToString(sum[:])[:12]
+}
+
+// GenerateMachineReport generates the report in the json or sarif report format. The report is written to the
+// report file when one is specified, otherwise it is written to the output stream.
+func GenerateMachineReport(log *zap.SugaredLogger, vzHelper helpers.VZHelper, reportCtx helpers.ReportCtx) (err error) {
+	jsonReport := getJSONReport(log, reportCtx)
+
+	var document interface{}
+	switch reportCtx.ReportFormat {
+	case constants.JSONReport:
+		document = jsonReport
+	case constants.SARIFReport:
+		document = toSARIF(jsonReport)
+	default:
+		return fmt.Errorf("Report format %s is not a machine readable format", reportCtx.ReportFormat)
+	}
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		log.Errorf("Failed to marshal the %s report, error found : %s", reportCtx.ReportFormat, err.Error())
+		return err
+	}
+
+	var out io.Writer = vzHelper.GetOutputStream()
+	if reportCtx.ReportFile != "" {
+		repFile, err := os.OpenFile(reportCtx.ReportFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+			log.Errorf("Failed to create report file : %s, error found : %s", reportCtx.ReportFile, err.Error())
+			return err
+		}
+		defer repFile.Close()
+		out = repFile
+	}
+	if _, err = fmt.Fprintln(out, string(data)); err != nil {
+		log.Errorf("Failed to write the %s report, error found : %s", reportCtx.ReportFormat, err.Error())
+		return err
+	}
+	return nil
+}
+
+// HighestReportedImpact returns the highest impact of the non-informational issues which are reported, -1 is returned
+// when there are no such issues
+func HighestReportedImpact(log *zap.SugaredLogger, reportCtx helpers.ReportCtx) int {
+	highest := -1
+	for _, issue := range GetAllSourcesFilteredIssues(log, reportCtx.IncludeInfo, reportCtx.MinConfidence, reportCtx.MinImpact) {
+		if !issue.Informational && issue.Impact > highest {
+			highest = issue.Impact
+		}
+	}
+	return highest
+}
+
+// getJSONReport gets the filtered issues sorted by source, and by impact and confidence within a source
+func getJSONReport(log *zap.SugaredLogger, reportCtx helpers.ReportCtx) JSONReport {
+	jsonReport := JSONReport{
+		SchemaVersion:     MachineReportSchemaVersion,
+		CLIVersion:        version.GetCLIVersion(),
+		VerrazzanoVersion: helpers.GetVzVer(),
+		KubernetesVersion: helpers.GetK8sVer(),
+		Sources:           []JSONReportSource{},
+	}
+
+	reportMutex.Lock()
+	defer reportMutex.Unlock()
+	sources := make([]string, 0, len(allSourcesAnalyzed))
+	for source := range allSourcesAnalyzed {
+		sources = append(sources, source)
+	}
+	for source := range reports {
+		if _, ok := allSourcesAnalyzed[source]; !ok {
+			sources = append(sources, source)
+		}
+	}
+	sort.Strings(sources)
+
+	for _, source := range sources {
+		filtered := filterReportIssues(log, reports[source], reportCtx.IncludeInfo, reportCtx.MinConfidence, reportCtx.MinImpact)
+		sort.SliceStable(filtered, func(i, j int) bool {
+			if filtered[i].Impact != filtered[j].Impact {
+				return filtered[i].Impact > filtered[j].Impact
+			}
+			if filtered[i].Confidence != filtered[j].Confidence {
+				return filtered[i].Confidence > filtered[j].Confidence
+			}
+			return filtered[i].Type < filtered[j].Type
+		})
+		reportSource := JSONReportSource{Source: source, Issues: make([]JSONReportIssue, 0, len(filtered))}
+		for _, issue := range filtered {
+			if !reportCtx.IncludeActions {
+				issue.Actions = nil
+			}
+			if !reportCtx.IncludeSupportData {
+				issue.SupportingData = nil
+			}
+			reportSource.Issues = append(reportSource.Issues, JSONReportIssue{ID: IssueID(issue), Issue: issue})
+		}
+		jsonReport.Sources = append(jsonReport.Sources, reportSource)
+	}
+	return jsonReport
+}
+
+// toSARIF converts the JSON report to a SARIF log
+func toSARIF(jsonReport JSONReport) sarifLog {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           sarifToolName,
+			InformationURI: sarifToolURI,
+			Version:        jsonReport.CLIVersion,
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+	rulesAdded := make(map[string]bool)
+	for _, source := range jsonReport.Sources {
+		for _, issue := range source.Issues {
+			if !rulesAdded[issue.Type] {
+				rulesAdded[issue.Type] = true
+				rule := sarifRule{ID: issue.Type, ShortDescription: sarifMessage{Text: issue.Summary}}
+				if links, ok := RunbookLinks[issue.Type]; ok && len(links) > 0 {
+					rule.HelpURI = links[0]
+				}
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:              issue.Type,
+				Level:               sarifLevel(issue.Issue),
+				Message:             sarifMessage{Text: issue.Summary},
+				Locations:           sarifLocations(issue.Issue),
+				PartialFingerprints: map[string]string{"vzIssueId": issue.ID},
+				Properties: map[string]interface{}{
+					"source":     issue.Source,
+					"confidence": issue.Confidence,
+					"impact":     issue.Impact,
+					"actions":    issue.Actions,
+				},
+			})
+		}
+	}
+	return sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}}
+}
+
+// sarifLevel maps the issue to a SARIF result level
+func sarifLevel(issue Issue) string {
+	switch {
+	case issue.Informational:
+		return "note"
+	case issue.Impact >= sarifErrorImpact:
+		return "error"
+	default:
+		return "warning"
+	}
+}
+
+// sarifLocations gets the files in the supporting data of the issue as SARIF locations
+func sarifLocations(issue Issue) []sarifLocation {
+	var locations []sarifLocation
+	for _, data := range issue.SupportingData {
+		for _, match := range data.TextMatches {
+			location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: match.FileName},
+			}}
+			// SARIF line numbers start at 1, the line is not known for matches from a live cluster
+			if match.FileLine > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{StartLine: match.FileLine}
+			}
+			locations = append(locations, location)
+		}
+		for _, path := range data.JSONPaths {
+			locations = append(locations, sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: path.File},
+			}})
+		}
+		for _, fileName := range data.RelatedFiles {
+			locations = append(locations, sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: fileName},
+			}})
+		}
+	}
+	return locations
+}
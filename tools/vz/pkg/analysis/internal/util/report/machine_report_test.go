@@ -0,0 +1,84 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/analysis/internal/util/files"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/analysis/internal/util/log"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/constants"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	help "github.com/verrazzano/verrazzano/tools/vz/test/helpers"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// TestIssueID Tests the stable issue ids
+// GIVEN a call to IssueID
+// WHEN issues have the same type and summary but different sources
+// THEN the ids are the same, and differ from the ids of other issues
+func TestIssueID(t *testing.T) {
+	issue := NewKnownIssueMessagesFiles(ImagePullNotFound, "source1", testSlice, testSlice)
+	other := NewKnownIssueMessagesFiles(ImagePullNotFound, "source2", nil, nil)
+	assert.Equal(t, IssueID(issue), IssueID(other))
+	assert.Regexp(t, "^VZ-[0-9a-f]{12}$", IssueID(issue))
+	assert.NotEqual(t, IssueID(issue), IssueID(NewKnownIssueMessagesFiles(InsufficientCPU, "source1", nil, nil)))
+}
+
+// TestGenerateMachineReport Tests the json and sarif report formats
+// GIVEN a call to GenerateMachineReport
+// WHEN issues were contributed
+// THEN the report contains the issues in the requested format
+func TestGenerateMachineReport(t *testing.T) {
+	logger := log.GetDebugEnabledLogger()
+	ClearReports()
+	defer ClearReports()
+
+	source := "machine-report-source"
+	issue := NewKnownIssueMessagesMatches(ImagePullNotFound, source, testSlice, []files.TextMatch{{FileName: "logs.txt", FileLine: 3, MatchedText: "not found"}})
+	info := NewKnownIssueMessagesFiles(PendingPods, source, testSlice, testSlice)
+	assert.NoError(t, ContributeIssue(logger, issue))
+	assert.NoError(t, ContributeIssue(logger, info))
+	AddSourceAnalyzed(source)
+
+	reportCtx := helpers.ReportCtx{ReportFormat: constants.JSONReport, IncludeSupportData: true, IncludeInfo: true, IncludeActions: true}
+	buf := new(bytes.Buffer)
+	rc := help.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: buf, ErrOut: new(bytes.Buffer)})
+	assert.NoError(t, GenerateMachineReport(logger, rc, reportCtx))
+	jsonReport := JSONReport{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &jsonReport))
+	assert.Equal(t, MachineReportSchemaVersion, jsonReport.SchemaVersion)
+	var reported []JSONReportIssue
+	for _, reportSource := range jsonReport.Sources {
+		if reportSource.Source == source {
+			reported = reportSource.Issues
+		}
+	}
+	// Issues are sorted by impact, highest first
+	assert.Len(t, reported, 2)
+	assert.Equal(t, ImagePullNotFound, reported[0].Type)
+	assert.Equal(t, IssueID(issue), reported[0].ID)
+	assert.Equal(t, "not found", reported[0].SupportingData[0].TextMatches[0].MatchedText)
+	assert.Equal(t, PendingPods, reported[1].Type)
+	assert.Equal(t, 10, HighestReportedImpact(logger, reportCtx))
+
+	reportCtx.ReportFormat = constants.SARIFReport
+	buf.Reset()
+	assert.NoError(t, GenerateMachineReport(logger, rc, reportCtx))
+	sarif := sarifLog{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &sarif))
+	assert.Equal(t, sarifVersion, sarif.Version)
+	assert.Len(t, sarif.Runs, 1)
+	assert.Len(t, sarif.Runs[0].Results, 2)
+	assert.Equal(t, "error", sarif.Runs[0].Results[0].Level)
+	assert.Equal(t, IssueID(issue), sarif.Runs[0].Results[0].PartialFingerprints["vzIssueId"])
+	assert.Equal(t, 3, sarif.Runs[0].Results[0].Locations[0].PhysicalLocation.Region.StartLine)
+	assert.Equal(t, "note", sarif.Runs[0].Results[1].Level)
+
+	reportCtx.ReportFormat = constants.SummaryReport
+	assert.Error(t, GenerateMachineReport(logger, rc, reportCtx))
+}
@@ -297,6 +297,15 @@ func ClearReports() {
 	reportMutex.Unlock()
 }
 
+// ClearSourcesAnalyzed clears the reports and the sources analyzed, this is used to discard the results of a baseline
+// analysis
+func ClearSourcesAnalyzed() {
+	reportMutex.Lock()
+	reports = make(map[string][]Issue)
+	allSourcesAnalyzed = make(map[string]string)
+	reportMutex.Unlock()
+}
+
 // compare two structs are same or not
 func isEqualStructs(s1, s2 any) bool {
 	return reflect.DeepEqual(s1, s2)
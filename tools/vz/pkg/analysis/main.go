@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"github.com/verrazzano/verrazzano/tools/vz/pkg/analysis/internal/util/cluster"
 	"github.com/verrazzano/verrazzano/tools/vz/pkg/analysis/internal/util/report"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/constants"
 	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
 	"go.uber.org/zap"
 )
@@ -23,14 +24,16 @@ var minImpact int
 var minConfidence int
 var logger *zap.SugaredLogger
 
-// The analyze tool will analyze information which has already been captured from an environment
-func AnalysisMain(vzHelper helpers.VZHelper, directory string, reportFile string, reportFormat string, printReportToConsole bool) error {
+// The analyze tool will analyze information which has already been captured from an environment.
+// When failOnImpact is not negative, an error is returned if an issue with at least that impact is reported.
+// When a baselineDirectory is specified, the changes since the baseline capture are reported as well.
+func AnalysisMain(vzHelper helpers.VZHelper, directory string, reportFile string, reportFormat string, printReportToConsole bool, failOnImpact int, baselineDirectory string) error {
 	logger = zap.S()
-	return handleMain(vzHelper, directory, reportFile, reportFormat, printReportToConsole)
+	return handleMain(vzHelper, directory, reportFile, reportFormat, printReportToConsole, failOnImpact, baselineDirectory)
 }
 
 // handleMain is where the main logic is at, separated here to allow for more test coverage
-func handleMain(vzHelper helpers.VZHelper, directory string, reportFile string, reportFormat string, printReportToConsole bool) error {
+func handleMain(vzHelper helpers.VZHelper, directory string, reportFile string, reportFormat string, printReportToConsole bool, failOnImpact int, baselineDirectory string) error {
 	// TODO: how we surface different analysis report types will likely change up, for now it is specified here, and it may also
 	// make sense to treat all cluster dumps the same way whether single or multiple (structure the dumps the same way)
 	// We could also have different types of report output formats as well. For example, the current report format is
@@ -38,20 +41,79 @@ func handleMain(vzHelper helpers.VZHelper, directory string, reportFile string,
 	// in their environment. We also could generate a more detailed "bug-report-type" which someone could call which would
 	// gather up information, sanitize it in a way that it could be sent along to someone else for further analysis, etc...
 
+	// Analyze the baseline first, only the issues are kept so they can be compared with the issues found later
+	var baselineIssues []report.Issue
+	if baselineDirectory != "" {
+		err := Analyze(logger, analyzerType, baselineDirectory)
+		if err != nil {
+			fmt.Fprintf(vzHelper.GetOutputStream(), "Analyze of the baseline failed with error: %s, exiting.\n", err.Error())
+			return fmt.Errorf("\nanalyze of the baseline failed with error: %s, exiting", err.Error())
+		}
+		baselineIssues = report.GetAllSourcesFilteredIssues(logger, true, 0, 0)
+		report.ClearSourcesAnalyzed()
+	}
+
 	// Call the analyzer for the type specified
 	err := Analyze(logger, analyzerType, directory)
 	if err != nil {
 		fmt.Fprintf(vzHelper.GetOutputStream(), "Analyze failed with error: %s, exiting.\n", err.Error())
 		return fmt.Errorf("\nanalyze failed with error: %s, exiting", err.Error())
 	}
+	if baselineDirectory != "" {
+		err = cluster.CompareWithBaseline(logger, baselineDirectory, directory, baselineIssues)
+		if err != nil {
+			fmt.Fprintf(vzHelper.GetOutputStream(), "Comparison with the baseline failed with error: %s, exiting.\n", err.Error())
+			return fmt.Errorf("\ncomparison with the baseline failed with error: %s, exiting", err.Error())
+		}
+	}
 	reportContext := helpers.ReportCtx{ReportFile: reportFile, ReportFormat: reportFormat, IncludeSupportData: includeSupport, IncludeInfo: includeInfo, IncludeActions: includeActions, MinConfidence: minConfidence, MinImpact: minImpact, PrintReportToConsole: printReportToConsole}
 
 	// Generate a report
-	err = report.GenerateHumanReport(logger, vzHelper, reportContext)
+	if reportFormat == constants.JSONReport || reportFormat == constants.SARIFReport {
+		err = report.GenerateMachineReport(logger, vzHelper, reportContext)
+	} else {
+		err = report.GenerateHumanReport(logger, vzHelper, reportContext)
+	}
 	if err != nil {
 		fmt.Fprintf(vzHelper.GetOutputStream(), "\nReport generation failed, exiting.\n")
 		return fmt.Errorf("%s", err.Error())
 	}
+
+	// Fail when an issue with at least the impact requested was reported
+	if failOnImpact >= 0 {
+		if highestImpact := report.HighestReportedImpact(logger, reportContext); highestImpact >= failOnImpact {
+			return fmt.Errorf("analysis reported an issue with impact %d, which is at or above the %s value %d", highestImpact, constants.FailOnImpactFlagName, failOnImpact)
+		}
+	}
+	return nil
+}
+
+// ResetRules removes all of the user supplied analysis rules that were registered
+func ResetRules() {
+	cluster.ClearRules()
+}
+
+// RegisterRulesFromDirectory registers the user supplied analysis rules found in the YAML files of a directory
+func RegisterRulesFromDirectory(directory string) error {
+	rules, err := cluster.LoadRulesFromDirectory(zap.S(), directory)
+	if err != nil {
+		return fmt.Errorf("failed to load analysis rules from %s: %s", directory, err.Error())
+	}
+	return cluster.RegisterRules(rules)
+}
+
+// RegisterRulesFromData registers the user supplied analysis rules held in a map of YAML documents, such as the data
+// of a ConfigMap
+func RegisterRulesFromData(source string, data map[string]string) error {
+	for key, value := range data {
+		rules, err := cluster.ParseRules([]byte(value))
+		if err != nil {
+			return fmt.Errorf("failed to load analysis rules from %s key %s: %s", source, key, err.Error())
+		}
+		if err := cluster.RegisterRules(rules); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
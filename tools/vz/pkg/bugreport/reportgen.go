@@ -63,19 +63,20 @@ func CaptureClusterSnapshot(kubeClient kubernetes.Interface, dynamicClient dynam
 	defer stdErrFile.Close()
 
 	// Create MultiWriters for standard out and err
-	pkghelpers.SetMultiWriterOut(vzHelper.GetOutputStream(), stdOutFile)
-	pkghelpers.SetMultiWriterErr(vzHelper.GetErrorStream(), stdErrFile)
+	state := pkghelpers.GetCaptureState(vzHelper)
+	state.SetOutput(vzHelper.GetOutputStream(), stdOutFile)
+	state.SetError(vzHelper.GetErrorStream(), stdErrFile)
 
 	// Find the Verrazzano resource to analyze.
 	vz, err := pkghelpers.FindVerrazzanoResource(client)
 	if err != nil {
-		pkghelpers.LogMessage(fmt.Sprintf("Verrazzano is not installed: %s", err.Error()))
+		state.LogMessage(fmt.Sprintf("Verrazzano is not installed: %s", err.Error()))
 	}
 
 	// Get the list of namespaces based on the failed components and value specified by flag --include-namespaces
 	nsList, additionalNS := collectNamespaces(kubeClient, clusterSnapshotCtx.MoreNS, vz, vzHelper)
 	var msgPrefix string
-	if pkghelpers.GetIsLiveCluster() {
+	if state.IsLiveCluster() {
 		msgPrefix = constants.AnalysisMsgPrefix
 	} else {
 		msgPrefix = constants.BugReportMsgPrefix
@@ -87,7 +88,7 @@ func CaptureClusterSnapshot(kubeClient kubernetes.Interface, dynamicClient dynam
 	// Capture list of resources from verrazzano-install and verrazzano-system namespaces
 	err = captureResources(client, kubeClient, clusterSnapshotCtx.BugReportDir, vz, vzHelper, nsList)
 	if err != nil {
-		pkghelpers.LogError(fmt.Sprintf("There is an error with capturing the Verrazzano resources: %s", err.Error()))
+		state.LogError(fmt.Sprintf("There is an error with capturing the Verrazzano resources: %s", err.Error()))
 	}
 
 	// Capture OAM resources from the namespaces specified using --include-namespaces
@@ -210,7 +211,7 @@ func captureLogs(wg *sync.WaitGroup, ec chan ErrorsChannelLogs, kubeClient kuber
 		return
 	}
 	// This won't work when there are more than one pods for the same app label
-	pkghelpers.LogMessage(fmt.Sprintf("log from pod %s in %s namespace ...\n", pod.PodList[0].Name, pod.Namespace))
+	pkghelpers.GetCaptureState(vzHelper).LogMessage(fmt.Sprintf("log from pod %s in %s namespace ...\n", pod.PodList[0].Name, pod.Namespace))
 	err := pkghelpers.CapturePodLog(kubeClient, pod.PodList[0], pod.Namespace, bugReportDir, vzHelper, duration)
 	if err != nil {
 		ec <- ErrorsChannelLogs{PodName: pod.PodList[0].Name, ErrorMessage: err.Error()}
@@ -253,9 +254,22 @@ func collectNamespaces(kubeClient kubernetes.Interface, includedNS []string, vz
 		nsList = append(nsList, additionalNS...)
 	}
 
-	// Remove the duplicates from nsList
-	nsList = pkghelpers.RemoveDuplicate(nsList)
-	return nsList, additionalNS
+	// Remove the duplicates from nsList, and the namespaces omitted by the redaction policy
+	nsList = removeOmittedNamespaces(pkghelpers.RemoveDuplicate(nsList), vzHelper)
+	return nsList, removeOmittedNamespaces(additionalNS, vzHelper)
+}
+
+// removeOmittedNamespaces removes the namespaces omitted by the redaction policy from the list of namespaces
+func removeOmittedNamespaces(namespaces []string, vzHelper pkghelpers.VZHelper) []string {
+	var nsList []string
+	for _, ns := range namespaces {
+		if pkghelpers.GetCaptureState(vzHelper).IsNamespaceOmitted(ns) {
+			pkghelpers.GetCaptureState(vzHelper).LogMessage(fmt.Sprintf("Omitting namespace %s as specified by the redaction policy\n", ns))
+			continue
+		}
+		nsList = append(nsList, ns)
+	}
+	return nsList
 }
 
 // captureLogsAllPods captures logs from all pods without filtering in given namespace.
@@ -266,7 +280,7 @@ func captureLogsAllPods(wg *sync.WaitGroup, ec chan ErrorsChannelLogs, kubeClien
 		return
 	}
 	for index := range pods.PodList {
-		pkghelpers.LogMessage(fmt.Sprintf("log from pod %s in %s namespace ...\n", pods.PodList[index].Name, pods.Namespace))
+		pkghelpers.GetCaptureState(vzHelper).LogMessage(fmt.Sprintf("log from pod %s in %s namespace ...\n", pods.PodList[index].Name, pods.Namespace))
 		err := pkghelpers.CapturePodLog(kubeClient, pods.PodList[index], pods.Namespace, bugReportDir, vzHelper, duration)
 		if err != nil {
 			ec <- ErrorsChannelLogs{PodName: pods.PodList[index].Name, ErrorMessage: err.Error()}
@@ -277,15 +291,15 @@ func captureLogsAllPods(wg *sync.WaitGroup, ec chan ErrorsChannelLogs, kubeClien
 // captureAdditionalResources will capture additional resources from additional namespaces
 func captureAdditionalResources(client clipkg.Client, kubeClient kubernetes.Interface, dynamicClient dynamic.Interface, vzHelper pkghelpers.VZHelper, bugReportDir string, additionalNS []string, podLogs PodLogs) {
 	if err := pkghelpers.CaptureOAMResources(dynamicClient, additionalNS, bugReportDir, vzHelper); err != nil {
-		pkghelpers.LogError(fmt.Sprintf("There is an error in capturing the resources : %s", err.Error()))
+		pkghelpers.GetCaptureState(vzHelper).LogError(fmt.Sprintf("There is an error in capturing the resources : %s", err.Error()))
 	}
 	if podLogs.IsPodLog {
 		if err := captureAdditionalLogs(client, kubeClient, bugReportDir, vzHelper, additionalNS, podLogs.Duration); err != nil {
-			pkghelpers.LogError(fmt.Sprintf("There is an error with capturing the logs: %s", err.Error()))
+			pkghelpers.GetCaptureState(vzHelper).LogError(fmt.Sprintf("There is an error with capturing the logs: %s", err.Error()))
 		}
 	}
 	if err := pkghelpers.CaptureMultiClusterOAMResources(dynamicClient, additionalNS, bugReportDir, vzHelper); err != nil {
-		pkghelpers.LogError(fmt.Sprintf("There is an error in capturing the multi-cluster resources : %s", err.Error()))
+		pkghelpers.GetCaptureState(vzHelper).LogError(fmt.Sprintf("There is an error in capturing the multi-cluster resources : %s", err.Error()))
 	}
 }
 
@@ -25,6 +25,11 @@ const (
 	VersionFlagInstallHelp   = "The version of Verrazzano to install"
 	VersionFlagUpgradeHelp   = "The version of Verrazzano to upgrade to"
 	DryRunFlag               = "dry-run"
+	DryRunDirFlag            = "dry-run-dir"
+	DryRunDirFlagDefault     = "vz-dry-run"
+	DryRunDirFlagHelp        = "The directory where the effective Verrazzano configuration and the Helm values of each enabled component are written by --dry-run."
+	VerrazzanoRootFlag       = "verrazzano-root"
+	VerrazzanoRootFlagHelp   = "The root directory of the Verrazzano source for the version, used by --dry-run. When specified, the profiles are read from it, the values files of the components are included in the Helm values and the Helm chart manifests are rendered."
 	SetFlag                  = "set"
 	SetFlagShorthand         = "s"
 	SetFlagHelp              = "Override a Verrazzano resource value (e.g. --set profile=dev).  This flag can be specified multiple times."
@@ -52,6 +57,15 @@ const (
 	VerboseFlagDefault       = false
 	VerboseFlagUsage         = "Enable verbose output."
 	ReadOnly                 = "read-only file system"
+	PreflightFlag            = "preflight"
+	PreflightFlagHelp        = "Run the pre-flight checks of the cluster (node resources, requirements of the enabled components, storage, load balancer, DNS, Kubernetes version, conflicting installations, PodSecurity and image registry) before installing or upgrading. The install or upgrade is stopped when a check fails."
+	WatchFlag                = "watch"
+	WatchFlagHelp            = "Stream the state transitions of Verrazzano and its components until Verrazzano is Ready or Failed, then show the time taken by each component. The wait period is controlled by --timeout."
+	ManagedClustersFlag      = "managed-clusters"
+	ManagedClustersFlagHelp  = "Show the Verrazzano version, profile, component health, Kubernetes version and node counts reported by each managed cluster registered with this admin cluster."
+	OutputFlag               = "output"
+	OutputFlagShorthand      = "o"
+	PlanOutputFlagHelp       = "The format of the plan output. Valid output formats are \"text\" and \"dot\", the Graphviz DOT language."
 	AutoBugReportFlag        = "auto-bug-report"
 	AutoBugReportFlagDefault = true
 	AutoBugReportFlagHelp    = "Automatically call vz bug-report if command fails"
@@ -69,6 +83,12 @@ const VerrazzanoOperatorURL = "https://github.com/verrazzano/verrazzano/releases
 // VerrazzanoPlatformOperatorURL - URL for downloading verrazzano-platform-operator.yaml
 const VerrazzanoPlatformOperatorURL = "https://github.com/verrazzano/verrazzano/releases/download/%s/verrazzano-platform-operator.yaml"
 
+// VerrazzanoBOMURL - URL for downloading the verrazzano-bom.json of a Verrazzano release
+const VerrazzanoBOMURL = "https://raw.githubusercontent.com/verrazzano/verrazzano/%s/platform-operator/verrazzano-bom.json"
+
+// VerrazzanoProfileURL - URL for downloading a profile of a Verrazzano release, by API version and profile file name
+const VerrazzanoProfileURL = "https://raw.githubusercontent.com/verrazzano/verrazzano/%s/platform-operator/manifests/profiles/%s/%s"
+
 const VerrazzanoPlatformOperator = "verrazzano-platform-operator"
 
 const VerrazzanoPlatformOperatorWebhook = "verrazzano-platform-operator-webhook"
@@ -125,10 +145,25 @@ const (
 	ReportFileFlagUsage = "Name of the report output file. (default stdout)"
 
 	ReportFormatFlagName  = "report-format"
-	ReportFormatFlagUsage = "The format of the report output. Valid report formats are \"summary\" and \"detailed\"."
+	ReportFormatFlagUsage = "The format of the report output. Valid report formats are \"summary\", \"detailed\", \"json\" and \"sarif\"."
 
 	SummaryReport  = "summary"
 	DetailedReport = "detailed"
+	JSONReport     = "json"
+	SARIFReport    = "sarif"
+
+	FailOnImpactFlagName    = "fail-on-impact"
+	FailOnImpactFlagDefault = -1
+	FailOnImpactFlagUsage   = "Return a non-zero exit code when an issue with an impact at or above this value (0-10) is reported. Disabled by default."
+
+	BaselineFlagName  = "baseline"
+	BaselineFlagUsage = "Directory holding an earlier capture of the cluster, for example a capture taken before an upgrade. The changes since the baseline capture are reported."
+
+	RulesDirFlagName  = "rules-dir"
+	RulesDirFlagUsage = "Directory holding YAML files with additional analysis rules."
+
+	RulesConfigMapFlagName  = "rules-configmap"
+	RulesConfigMapFlagUsage = "ConfigMap holding additional analysis rules in YAML, specified as <namespace>/<name>."
 )
 
 // Constants for bug report
@@ -144,6 +179,9 @@ const (
 	BugReportIncludeNSFlagShort = "i"
 	BugReportIncludeNSFlagUsage = "A comma-separated list of namespaces, in addition to the ones collected by default (system namespaces), for collecting cluster information. This flag can be specified multiple times, such as --include-namespaces ns1 --include-namespaces ns..."
 
+	BugReportRedactionPolicyFlagName  = "redaction-policy"
+	BugReportRedactionPolicyFlagUsage = "A YAML file with the redaction policy applied to the captured resources, pod logs and the Verrazzano resource. The policy lists the regular expressions of the values to redact, the paths of the fields to drop, the namespaces to omit and an allow list of the values never redacted."
+
 	BugReportDir = "bug-report"
 
 	// File name for the log captured from the pod
@@ -153,6 +191,9 @@ const (
 	BugReportOut = "bug-report.out"
 	BugReportErr = "bug-report.err"
 
+	// File listing the redaction rules which fired while capturing the resources
+	RedactionManifestJSON = "redaction-manifest.json"
+
 	BugReportError   = "ERROR: The bug report noticed one or more issues while capturing the resources. Please go through error(s) in the standard error."
 	BugReportWarning = "WARNING: Please examine the contents of the bug report for any sensitive data"
 
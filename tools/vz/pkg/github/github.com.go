@@ -1,13 +1,15 @@
-// Copyright (c) 2022, Oracle and/or its affiliates.
+// Copyright (c) 2022, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package github
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 
+	"github.com/verrazzano/verrazzano/pkg/bom"
 	"github.com/verrazzano/verrazzano/tools/vz/pkg/constants"
 )
 
@@ -49,3 +51,34 @@ func ListReleases(client *http.Client) ([]string, error) {
 
 	return releaseTags, nil
 }
+
+// GetSupportedKubernetesVersions - return the Kubernetes versions supported by a Verrazzano release, from its BOM
+func GetSupportedKubernetesVersions(client *http.Client, version string) ([]string, error) {
+	jsonBom, err := getFile(client, fmt.Sprintf(constants.VerrazzanoBOMURL, version))
+	if err != nil {
+		return nil, err
+	}
+	vzBom, err := bom.NewBOMFromJSON(jsonBom)
+	if err != nil {
+		return nil, err
+	}
+	return vzBom.GetSupportedKubernetesVersion(), nil
+}
+
+// GetProfile - return a profile of a Verrazzano release, by API version and profile file name
+func GetProfile(client *http.Client, version string, apiVersion string, fileName string) ([]byte, error) {
+	return getFile(client, fmt.Sprintf(constants.VerrazzanoProfileURL, version, apiVersion, fileName))
+}
+
+// getFile - return the content of a file of the Verrazzano repository
+func getFile(client *http.Client, url string) ([]byte, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s getting %s", resp.Status, url)
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -8,7 +8,6 @@ import (
 	"bufio"
 	"compress/gzip"
 	"context"
-	"encoding/json"
 	"fmt"
 	oamcore "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
 	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
@@ -29,6 +28,7 @@ import (
 	"path/filepath"
 	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
 	"strings"
+	"sync"
 )
 
 var errBugReport = "an error occurred while creating the bug report: %s"
@@ -37,12 +37,44 @@ var createFileError = "an error occurred while creating the file %s: %s"
 var containerStartLog = "==== START logs for container %s of pod %s/%s ====\n"
 var containerEndLog = "==== END logs for container %s of pod %s/%s ====\n"
 
-var isError bool
-var isLiveCluster bool
-var isVerbose bool
+// CaptureState is the state of a capture: the writers to which the summary of the captured resources and the errors
+// are logged, the redactor applied to the captured data and whether an error was logged.  The vz commands use a
+// default state, a VZHelper implementing CaptureStateProvider injects its own state into the capture functions.
+type CaptureState struct {
+	mutex         sync.Mutex
+	out           io.Writer
+	err           io.Writer
+	redactor      *redactor
+	verbose       bool
+	liveCluster   bool
+	errorReported bool
+}
+
+// CaptureStateProvider is implemented by the VZHelpers which provide their own capture state
+type CaptureStateProvider interface {
+	GetCaptureState() *CaptureState
+}
 
-var multiWriterOut io.Writer
-var multiWriterErr io.Writer
+var defaultCaptureState = NewCaptureState(false)
+
+// NewCaptureState returns a capture state applying the default redaction rules, the output is logged to the
+// output streams only when verbose is true
+func NewCaptureState(verbose bool) *CaptureState {
+	return &CaptureState{
+		out:      io.Discard,
+		err:      io.Discard,
+		redactor: mustNewRedactor("", nil, nil),
+		verbose:  verbose,
+	}
+}
+
+// GetCaptureState returns the capture state of the VZHelper, or the default state of the vz commands
+func GetCaptureState(vzHelper VZHelper) *CaptureState {
+	if provider, ok := vzHelper.(CaptureStateProvider); ok && provider.GetCaptureState() != nil {
+		return provider.GetCaptureState()
+	}
+	return defaultCaptureState
+}
 
 // CreateReportArchive creates the .tar.gz file specified by bugReportFile, from the files in captureDir
 func CreateReportArchive(captureDir string, bugRepFile *os.File) error {
@@ -162,15 +194,15 @@ func CaptureVZResource(captureDir string, vz *v1beta1.Verrazzano, vzHelper VZHel
 	}
 	defer f.Close()
 
-	LogMessage("Verrazzano resource ...\n")
-	vzJSON, err := json.MarshalIndent(vz, constants.JSONPrefix, constants.JSONIndent)
+	GetCaptureState(vzHelper).LogMessage("Verrazzano resource ...\n")
+	vzJSON, err := GetCaptureState(vzHelper).sanitizeResource(vz, constants.VzResource)
 	if err != nil {
-		LogError(fmt.Sprintf("An error occurred while creating JSON encoding of %s: %s\n", vzRes, err.Error()))
+		GetCaptureState(vzHelper).LogError(fmt.Sprintf("An error occurred while creating JSON encoding of %s: %s\n", vzRes, err.Error()))
 		return err
 	}
-	_, err = f.WriteString(SanitizeString(string(vzJSON)))
+	_, err = f.WriteString(vzJSON)
 	if err != nil {
-		LogError(fmt.Sprintf("An error occurred while writing the file %s: %s\n", vzRes, err.Error()))
+		GetCaptureState(vzHelper).LogError(fmt.Sprintf("An error occurred while writing the file %s: %s\n", vzRes, err.Error()))
 		return err
 	}
 	return nil
@@ -180,10 +212,10 @@ func CaptureVZResource(captureDir string, vz *v1beta1.Verrazzano, vzHelper VZHel
 func captureEvents(kubeClient kubernetes.Interface, namespace, captureDir string, vzHelper VZHelper) error {
 	events, err := kubeClient.CoreV1().Events(namespace).List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
-		LogError(fmt.Sprintf("An error occurred while getting the Events in namespace %s: %s\n", namespace, err.Error()))
+		GetCaptureState(vzHelper).LogError(fmt.Sprintf("An error occurred while getting the Events in namespace %s: %s\n", namespace, err.Error()))
 	}
 	if len(events.Items) > 0 {
-		LogMessage(fmt.Sprintf("Events in namespace: %s ...\n", namespace))
+		GetCaptureState(vzHelper).LogMessage(fmt.Sprintf("Events in namespace: %s ...\n", namespace))
 		if err = createFile(events, namespace, constants.EventsJSON, captureDir, vzHelper); err != nil {
 			return err
 		}
@@ -195,10 +227,10 @@ func captureEvents(kubeClient kubernetes.Interface, namespace, captureDir string
 func capturePods(kubeClient kubernetes.Interface, namespace, captureDir string, vzHelper VZHelper) error {
 	pods, err := kubeClient.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
-		LogError(fmt.Sprintf("An error occurred while getting the Pods in namespace %s: %s\n", namespace, err.Error()))
+		GetCaptureState(vzHelper).LogError(fmt.Sprintf("An error occurred while getting the Pods in namespace %s: %s\n", namespace, err.Error()))
 	}
 	if len(pods.Items) > 0 {
-		LogMessage(fmt.Sprintf("Pods in namespace: %s ...\n", namespace))
+		GetCaptureState(vzHelper).LogMessage(fmt.Sprintf("Pods in namespace: %s ...\n", namespace))
 		if err = createFile(pods, namespace, constants.PodsJSON, captureDir, vzHelper); err != nil {
 			return err
 		}
@@ -210,10 +242,10 @@ func capturePods(kubeClient kubernetes.Interface, namespace, captureDir string,
 func captureIngress(kubeClient kubernetes.Interface, namespace, captureDir string, vzHelper VZHelper) error {
 	ingressList, err := kubeClient.NetworkingV1().Ingresses(namespace).List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
-		LogError(fmt.Sprintf("An error occurred while getting the Ingress in namespace %s: %s\n", namespace, err.Error()))
+		GetCaptureState(vzHelper).LogError(fmt.Sprintf("An error occurred while getting the Ingress in namespace %s: %s\n", namespace, err.Error()))
 	}
 	if len(ingressList.Items) > 0 {
-		LogMessage(fmt.Sprintf("Ingresses in namespace: %s ...\n", namespace))
+		GetCaptureState(vzHelper).LogMessage(fmt.Sprintf("Ingresses in namespace: %s ...\n", namespace))
 		if err = createFile(ingressList, namespace, constants.IngressJSON, captureDir, vzHelper); err != nil {
 			return err
 		}
@@ -225,10 +257,10 @@ func captureIngress(kubeClient kubernetes.Interface, namespace, captureDir strin
 func captureServices(kubeClient kubernetes.Interface, namespace, captureDir string, vzHelper VZHelper) error {
 	serviceList, err := kubeClient.CoreV1().Services(namespace).List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
-		LogError(fmt.Sprintf("An error occurred while getting the Services in namespace %s: %s\n", namespace, err.Error()))
+		GetCaptureState(vzHelper).LogError(fmt.Sprintf("An error occurred while getting the Services in namespace %s: %s\n", namespace, err.Error()))
 	}
 	if len(serviceList.Items) > 0 {
-		LogMessage(fmt.Sprintf("Services in namespace: %s ...\n", namespace))
+		GetCaptureState(vzHelper).LogMessage(fmt.Sprintf("Services in namespace: %s ...\n", namespace))
 		if err = createFile(serviceList, namespace, constants.ServicesJSON, captureDir, vzHelper); err != nil {
 			return err
 		}
@@ -240,10 +272,10 @@ func captureServices(kubeClient kubernetes.Interface, namespace, captureDir stri
 func captureWorkLoads(kubeClient kubernetes.Interface, namespace, captureDir string, vzHelper VZHelper) error {
 	deployments, err := kubeClient.AppsV1().Deployments(namespace).List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
-		LogError(fmt.Sprintf("An error occurred while getting the Deployments in namespace %s: %s\n", namespace, err.Error()))
+		GetCaptureState(vzHelper).LogError(fmt.Sprintf("An error occurred while getting the Deployments in namespace %s: %s\n", namespace, err.Error()))
 	}
 	if len(deployments.Items) > 0 {
-		LogMessage(fmt.Sprintf("Deployments in namespace: %s ...\n", namespace))
+		GetCaptureState(vzHelper).LogMessage(fmt.Sprintf("Deployments in namespace: %s ...\n", namespace))
 		if err = createFile(deployments, namespace, constants.DeploymentsJSON, captureDir, vzHelper); err != nil {
 			return err
 		}
@@ -251,10 +283,10 @@ func captureWorkLoads(kubeClient kubernetes.Interface, namespace, captureDir str
 
 	replicaSets, err := kubeClient.AppsV1().ReplicaSets(namespace).List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
-		LogError(fmt.Sprintf("An error occurred while getting the ReplicaSets in namespace %s: %s\n", namespace, err.Error()))
+		GetCaptureState(vzHelper).LogError(fmt.Sprintf("An error occurred while getting the ReplicaSets in namespace %s: %s\n", namespace, err.Error()))
 	}
 	if len(replicaSets.Items) > 0 {
-		LogMessage(fmt.Sprintf("Replicasets in namespace: %s ...\n", namespace))
+		GetCaptureState(vzHelper).LogMessage(fmt.Sprintf("Replicasets in namespace: %s ...\n", namespace))
 		if err = createFile(replicaSets, namespace, constants.ReplicaSetsJSON, captureDir, vzHelper); err != nil {
 			return err
 		}
@@ -262,10 +294,10 @@ func captureWorkLoads(kubeClient kubernetes.Interface, namespace, captureDir str
 
 	daemonSets, err := kubeClient.AppsV1().DaemonSets(namespace).List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
-		LogError(fmt.Sprintf("An error occurred while getting the DaemonSets in namespace %s: %s\n", namespace, err.Error()))
+		GetCaptureState(vzHelper).LogError(fmt.Sprintf("An error occurred while getting the DaemonSets in namespace %s: %s\n", namespace, err.Error()))
 	}
 	if len(daemonSets.Items) > 0 {
-		LogMessage(fmt.Sprintf("DaemonSets in namespace: %s ...\n", namespace))
+		GetCaptureState(vzHelper).LogMessage(fmt.Sprintf("DaemonSets in namespace: %s ...\n", namespace))
 		if err = createFile(daemonSets, namespace, constants.DaemonSetsJSON, captureDir, vzHelper); err != nil {
 			return err
 		}
@@ -273,10 +305,10 @@ func captureWorkLoads(kubeClient kubernetes.Interface, namespace, captureDir str
 
 	statefulSets, err := kubeClient.AppsV1().StatefulSets(namespace).List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
-		LogError(fmt.Sprintf("An error occurred while getting the StatefulSets in namespace %s: %s\n", namespace, err.Error()))
+		GetCaptureState(vzHelper).LogError(fmt.Sprintf("An error occurred while getting the StatefulSets in namespace %s: %s\n", namespace, err.Error()))
 	}
 	if len(statefulSets.Items) > 0 {
-		LogMessage(fmt.Sprintf("StatefulSets in namespace: %s ...\n", namespace))
+		GetCaptureState(vzHelper).LogMessage(fmt.Sprintf("StatefulSets in namespace: %s ...\n", namespace))
 		if err = createFile(statefulSets, namespace, constants.StatefulSetsJSON, captureDir, vzHelper); err != nil {
 			return err
 		}
@@ -286,8 +318,9 @@ func captureWorkLoads(kubeClient kubernetes.Interface, namespace, captureDir str
 
 // captureLog captures the log from the pod in the captureDir
 func CapturePodLog(kubeClient kubernetes.Interface, pod corev1.Pod, namespace, captureDir string, vzHelper VZHelper, duration int64) error {
+	state := GetCaptureState(vzHelper)
 	podName := pod.Name
-	if len(podName) == 0 {
+	if len(podName) == 0 || state.IsNamespaceOmitted(namespace) {
 		return nil
 	}
 
@@ -300,6 +333,7 @@ func CapturePodLog(kubeClient kubernetes.Interface, pod corev1.Pod, namespace, c
 
 	// Create logs.txt under the directory for the namespace
 	var logPath = filepath.Join(folderPath, constants.LogFile)
+	var logFile = filepath.Join(namespace, podName, constants.LogFile)
 	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
 	if err != nil {
 		return fmt.Errorf(createFileError, logPath, err.Error())
@@ -321,7 +355,7 @@ func CapturePodLog(kubeClient kubernetes.Interface, pod corev1.Pod, namespace, c
 			podLogOptions.InsecureSkipTLSVerifyBackend = true
 			podLog, err := kubeClient.CoreV1().Pods(namespace).GetLogs(podName, &podLogOptions).Stream(context.TODO())
 			if err != nil {
-				LogError(fmt.Sprintf("An error occurred while reading the logs from pod %s: %s\n", podName, err.Error()))
+				state.LogError(fmt.Sprintf("An error occurred while reading the logs from pod %s: %s\n", podName, err.Error()))
 				return nil
 			}
 			defer podLog.Close()
@@ -329,7 +363,7 @@ func CapturePodLog(kubeClient kubernetes.Interface, pod corev1.Pod, namespace, c
 			reader := bufio.NewScanner(podLog)
 			f.WriteString(fmt.Sprintf(containerStartLog, contName, namespace, podName))
 			for reader.Scan() {
-				f.WriteString(SanitizeString(reader.Text() + "\n"))
+				f.WriteString(state.getRedactor().sanitize(reader.Text()+"\n", logFile))
 			}
 			f.WriteString(fmt.Sprintf(containerEndLog, contName, namespace, podName))
 			return nil
@@ -341,6 +375,9 @@ func CapturePodLog(kubeClient kubernetes.Interface, pod corev1.Pod, namespace, c
 
 // createFile creates file from a workload, as a JSON file
 func createFile(v interface{}, namespace, resourceFile, captureDir string, vzHelper VZHelper) error {
+	if GetCaptureState(vzHelper).IsNamespaceOmitted(namespace) {
+		return nil
+	}
 	var folderPath = filepath.Join(captureDir, namespace)
 
 	if _, err := os.Stat(folderPath); os.IsNotExist(err) {
@@ -357,10 +394,14 @@ func createFile(v interface{}, namespace, resourceFile, captureDir string, vzHel
 	}
 	defer f.Close()
 
-	resJSON, _ := json.MarshalIndent(v, constants.JSONPrefix, constants.JSONIndent)
-	_, err = f.WriteString(SanitizeString(string(resJSON)))
+	resJSON, err := GetCaptureState(vzHelper).sanitizeResource(v, filepath.Join(namespace, resourceFile))
 	if err != nil {
-		LogError(fmt.Sprintf("An error occurred while writing the file %s: %s\n", res, err.Error()))
+		GetCaptureState(vzHelper).LogError(fmt.Sprintf("An error occurred while creating JSON encoding of %s: %s\n", res, err.Error()))
+		return nil
+	}
+	_, err = f.WriteString(resJSON)
+	if err != nil {
+		GetCaptureState(vzHelper).LogError(fmt.Sprintf("An error occurred while writing the file %s: %s\n", res, err.Error()))
 	}
 	return nil
 }
@@ -408,22 +449,22 @@ func DoesNamespaceExist(kubeClient kubernetes.Interface, namespace string, vzHel
 	ns, err := kubeClient.CoreV1().Namespaces().Get(context.TODO(), namespace, metav1.GetOptions{})
 
 	if err != nil && errors.IsNotFound(err) {
-		fmt.Fprintf(GetMultiWriterOut(), "Namespace %s not found in the cluster, so will be ignored.\n", namespace)
+		fmt.Fprintf(GetCaptureState(vzHelper).GetOutput(), "Namespace %s not found in the cluster, so will be ignored.\n", namespace)
 		return false, err
 	}
 	if err != nil {
-		LogError(fmt.Sprintf("An error occurred while getting the namespace %s: %s\n", namespace, err.Error()))
+		GetCaptureState(vzHelper).LogError(fmt.Sprintf("An error occurred while getting the namespace %s: %s\n", namespace, err.Error()))
 		return false, err
 	}
 	return ns != nil && len(ns.Name) > 0, nil
 }
 
 // GetVZManagedNamespaces returns the namespaces with label verrazzano-managed=true
-func GetVZManagedNamespaces(kubeClient kubernetes.Interface) []string {
+func GetVZManagedNamespaces(kubeClient kubernetes.Interface, vzHelper VZHelper) []string {
 	var appNS []string
 	nsList, err := kubeClient.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{LabelSelector: constants.VerrazzanoManagedLabel})
 	if err != nil {
-		LogError(fmt.Sprintf("An error occurred while listing the namespaces with label verrazzano-managed=true: %s\n", err.Error()))
+		fmt.Fprintf(vzHelper.GetErrorStream(), "An error occurred while listing the namespaces with label verrazzano-managed=true: %s\n", err.Error())
 		return appNS
 	}
 
@@ -453,11 +494,11 @@ func captureAppConfigurations(dynamicClient dynamic.Interface, namespace, captur
 		return nil
 	}
 	if err != nil {
-		LogError(fmt.Sprintf("An error occurred while getting the ApplicationConfigurations in namespace %s: %s\n", namespace, err.Error()))
+		GetCaptureState(vzHelper).LogError(fmt.Sprintf("An error occurred while getting the ApplicationConfigurations in namespace %s: %s\n", namespace, err.Error()))
 		return nil
 	}
 	if len(appConfigs.Items) > 0 {
-		LogMessage(fmt.Sprintf("ApplicationConfigurations in namespace: %s ...\n", namespace))
+		GetCaptureState(vzHelper).LogMessage(fmt.Sprintf("ApplicationConfigurations in namespace: %s ...\n", namespace))
 		if err = createFile(appConfigs, namespace, constants.AppConfigJSON, captureDir, vzHelper); err != nil {
 			return err
 		}
@@ -472,11 +513,11 @@ func captureComponents(dynamicClient dynamic.Interface, namespace, captureDir st
 		return nil
 	}
 	if err != nil {
-		LogError(fmt.Sprintf("An error occurred while getting the Components in namespace %s: %s\n", namespace, err.Error()))
+		GetCaptureState(vzHelper).LogError(fmt.Sprintf("An error occurred while getting the Components in namespace %s: %s\n", namespace, err.Error()))
 		return nil
 	}
 	if len(comps.Items) > 0 {
-		LogMessage(fmt.Sprintf("Components in namespace: %s ...\n", namespace))
+		GetCaptureState(vzHelper).LogMessage(fmt.Sprintf("Components in namespace: %s ...\n", namespace))
 		if err = createFile(comps, namespace, constants.ComponentJSON, captureDir, vzHelper); err != nil {
 			return err
 		}
@@ -491,11 +532,11 @@ func captureIngressTraits(dynamicClient dynamic.Interface, namespace, captureDir
 		return nil
 	}
 	if err != nil {
-		LogError(fmt.Sprintf("An error occurred while getting the IngressTraits in namespace %s: %s\n", namespace, err.Error()))
+		GetCaptureState(vzHelper).LogError(fmt.Sprintf("An error occurred while getting the IngressTraits in namespace %s: %s\n", namespace, err.Error()))
 		return nil
 	}
 	if len(ingTraits.Items) > 0 {
-		LogMessage(fmt.Sprintf("IngressTraits in namespace: %s ...\n", namespace))
+		GetCaptureState(vzHelper).LogMessage(fmt.Sprintf("IngressTraits in namespace: %s ...\n", namespace))
 		if err = createFile(ingTraits, namespace, constants.IngressTraitJSON, captureDir, vzHelper); err != nil {
 			return err
 		}
@@ -510,11 +551,11 @@ func captureMetricsTraits(dynamicClient dynamic.Interface, namespace, captureDir
 		return nil
 	}
 	if err != nil {
-		LogError(fmt.Sprintf("An error occurred while getting the MetricsTraits in namespace %s: %s\n", namespace, err.Error()))
+		GetCaptureState(vzHelper).LogError(fmt.Sprintf("An error occurred while getting the MetricsTraits in namespace %s: %s\n", namespace, err.Error()))
 		return nil
 	}
 	if len(metricsTraits.Items) > 0 {
-		LogMessage(fmt.Sprintf("MetricsTraits in namespace: %s ...\n", namespace))
+		GetCaptureState(vzHelper).LogMessage(fmt.Sprintf("MetricsTraits in namespace: %s ...\n", namespace))
 		if err = createFile(metricsTraits, namespace, constants.MetricsTraitJSON, captureDir, vzHelper); err != nil {
 			return err
 		}
@@ -529,11 +570,11 @@ func captureMCComponents(dynamicClient dynamic.Interface, namespace, captureDir
 		return nil
 	}
 	if err != nil {
-		LogError(fmt.Sprintf("An error occurred while getting the MulticlusterComponent in namespace %s: %s\n", namespace, err.Error()))
+		GetCaptureState(vzHelper).LogError(fmt.Sprintf("An error occurred while getting the MulticlusterComponent in namespace %s: %s\n", namespace, err.Error()))
 		return nil
 	}
 	if len(mcComps.Items) > 0 {
-		LogMessage(fmt.Sprintf("MulticlusterComponent in namespace: %s ...\n", namespace))
+		GetCaptureState(vzHelper).LogMessage(fmt.Sprintf("MulticlusterComponent in namespace: %s ...\n", namespace))
 		if err = createFile(mcComps, namespace, constants.McComponentJSON, captureDir, vzHelper); err != nil {
 			return err
 		}
@@ -548,11 +589,11 @@ func captureMCAppConfigurations(dynamicClient dynamic.Interface, namespace, capt
 		return nil
 	}
 	if err != nil {
-		LogError(fmt.Sprintf("An error occurred while getting the MultiClusterApplicationConfiguration in namespace %s: %s\n", namespace, err.Error()))
+		GetCaptureState(vzHelper).LogError(fmt.Sprintf("An error occurred while getting the MultiClusterApplicationConfiguration in namespace %s: %s\n", namespace, err.Error()))
 		return nil
 	}
 	if len(mcAppConfigs.Items) > 0 {
-		LogMessage(fmt.Sprintf("MultiClusterApplicationConfiguration in namespace: %s ...\n", namespace))
+		GetCaptureState(vzHelper).LogMessage(fmt.Sprintf("MultiClusterApplicationConfiguration in namespace: %s ...\n", namespace))
 		if err = createFile(mcAppConfigs, namespace, constants.McAppConfigJSON, captureDir, vzHelper); err != nil {
 			return err
 		}
@@ -567,11 +608,11 @@ func CaptureVerrazzanoProjects(dynamicClient dynamic.Interface, captureDir strin
 		return nil
 	}
 	if err != nil {
-		LogError(fmt.Sprintf("An error occurred while getting the VerrazzanoProjects in namespace %s: %s\n", vzconstants.VerrazzanoMultiClusterNamespace, err.Error()))
+		GetCaptureState(vzHelper).LogError(fmt.Sprintf("An error occurred while getting the VerrazzanoProjects in namespace %s: %s\n", vzconstants.VerrazzanoMultiClusterNamespace, err.Error()))
 		return nil
 	}
 	if len(vzProjectConfigs.Items) > 0 {
-		LogMessage(fmt.Sprintf("VerrazzanoProjects in namespace: %s ...\n", vzconstants.VerrazzanoMultiClusterNamespace))
+		GetCaptureState(vzHelper).LogMessage(fmt.Sprintf("VerrazzanoProjects in namespace: %s ...\n", vzconstants.VerrazzanoMultiClusterNamespace))
 		if err = createFile(vzProjectConfigs, vzconstants.VerrazzanoMultiClusterNamespace, constants.VzProjectsJSON, captureDir, vzHelper); err != nil {
 			return err
 		}
@@ -586,11 +627,11 @@ func CaptureVerrazzanoManagedCluster(dynamicClient dynamic.Interface, captureDir
 		return nil
 	}
 	if err != nil {
-		LogError(fmt.Sprintf("An error occurred while getting the VerrazzanoManagedClusters in namespace %s: %s\n", vzconstants.VerrazzanoMultiClusterNamespace, err.Error()))
+		GetCaptureState(vzHelper).LogError(fmt.Sprintf("An error occurred while getting the VerrazzanoManagedClusters in namespace %s: %s\n", vzconstants.VerrazzanoMultiClusterNamespace, err.Error()))
 		return nil
 	}
 	if len(vmcConfigs.Items) > 0 {
-		LogMessage(fmt.Sprintf("VerrazzanoManagedClusters in namespace: %s ...\n", vzconstants.VerrazzanoMultiClusterNamespace))
+		GetCaptureState(vzHelper).LogMessage(fmt.Sprintf("VerrazzanoManagedClusters in namespace: %s ...\n", vzconstants.VerrazzanoMultiClusterNamespace))
 		if err = createFile(vmcConfigs, vzconstants.VerrazzanoMultiClusterNamespace, constants.VmcJSON, captureDir, vzHelper); err != nil {
 			return err
 		}
@@ -672,67 +713,132 @@ func GetManagedClusterConfigScheme() schema.GroupVersionResource {
 
 // LogError logs a message to the standard error
 func LogError(msg string) {
-	isError = true
-	fmt.Fprintf(GetMultiWriterErr(), msg)
+	defaultCaptureState.LogError(msg)
 }
 
 // IsErrorReported returns true when the command logs at least one error to the standard error
 func IsErrorReported() bool {
-	return isError
+	return defaultCaptureState.IsErrorReported()
 }
 
 // SetMultiWriterOut sets MultiWriter for standard output
 func SetMultiWriterOut(outStream io.Writer, outFile *os.File) {
-	// When verbose output is disabled, log the resources captured to outFile alone
-	if isVerbose {
-		multiWriterOut = io.MultiWriter(outStream, outFile)
-	} else {
-		multiWriterOut = io.MultiWriter(outFile)
-	}
+	defaultCaptureState.SetOutput(outStream, outFile)
 }
 
 // GetMultiWriterOut returns the MultiWriter for standard output
 func GetMultiWriterOut() io.Writer {
-	return multiWriterOut
+	return defaultCaptureState.GetOutput()
 }
 
 // SetMultiWriterErr sets MultiWriter for standard error
 func SetMultiWriterErr(errStream io.Writer, errFile *os.File) {
-	// When verbose output is disabled, log the error capturing resources to errFile alone
-	if isVerbose {
-		multiWriterErr = io.MultiWriter(errStream, errFile)
-	} else {
-		multiWriterErr = io.MultiWriter(errFile)
-	}
+	defaultCaptureState.SetError(errStream, errFile)
 }
 
 // GetMultiWriterErr returns the MultiWriter for standard error
 func GetMultiWriterErr() io.Writer {
-	return multiWriterErr
+	return defaultCaptureState.GetError()
 }
 
 // SetIsLiveCluster sets true to isLiveCluster, indicating the live cluster analysis usage
 func SetIsLiveCluster() {
-	isLiveCluster = true
+	defaultCaptureState.SetLiveCluster()
 }
 
 // GetIsLiveCluster returns a boolean indicating whether it is live cluster analysis
 func GetIsLiveCluster() bool {
-	return isLiveCluster
+	return defaultCaptureState.IsLiveCluster()
 }
 
 // LogMessage logs a message to the standard output
 func LogMessage(msg string) {
+	defaultCaptureState.LogMessage(msg)
+}
+
+// SetVerboseOutput sets the verbose output for the commands bug-report and analyze
+func SetVerboseOutput(enableVerbose bool) {
+	defaultCaptureState.mutex.Lock()
+	defer defaultCaptureState.mutex.Unlock()
+	defaultCaptureState.verbose = enableVerbose
+}
+
+// LogError logs a message to the standard error of the capture
+func (s *CaptureState) LogError(msg string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.errorReported = true
+	fmt.Fprint(s.err, msg)
+}
+
+// IsErrorReported returns true when at least one error was logged to the standard error of the capture
+func (s *CaptureState) IsErrorReported() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.errorReported
+}
+
+// LogMessage logs a message to the standard output of the capture
+func (s *CaptureState) LogMessage(msg string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	msgPrefix := constants.BugReportMsgPrefix
-	if isLiveCluster {
+	if s.liveCluster {
 		msgPrefix = constants.AnalysisMsgPrefix
 	}
-	fmt.Fprintf(GetMultiWriterOut(), msgPrefix+msg)
+	fmt.Fprint(s.out, msgPrefix+msg)
 }
 
-// SetVerboseOutput sets the verbose output for the commands bug-report and analyze
-func SetVerboseOutput(enableVerbose bool) {
-	isVerbose = enableVerbose
+// SetOutput sets the standard output of the capture, the messages are logged to outStream only when verbose
+// output is enabled
+func (s *CaptureState) SetOutput(outStream io.Writer, outFile *os.File) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.out = s.multiWriter(outStream, outFile)
+}
+
+// GetOutput returns the standard output of the capture
+func (s *CaptureState) GetOutput() io.Writer {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.out
+}
+
+// SetError sets the standard error of the capture, the errors are logged to errStream only when verbose
+// output is enabled
+func (s *CaptureState) SetError(errStream io.Writer, errFile *os.File) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.err = s.multiWriter(errStream, errFile)
+}
+
+// GetError returns the standard error of the capture
+func (s *CaptureState) GetError() io.Writer {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.err
+}
+
+// SetLiveCluster indicates the capture is done for the live cluster analysis
+func (s *CaptureState) SetLiveCluster() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.liveCluster = true
+}
+
+// IsLiveCluster returns a boolean indicating whether the capture is done for the live cluster analysis
+func (s *CaptureState) IsLiveCluster() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.liveCluster
+}
+
+// multiWriter returns a writer to the file, and to the stream when verbose output is enabled
+func (s *CaptureState) multiWriter(stream io.Writer, file *os.File) io.Writer {
+	if s.verbose {
+		return io.MultiWriter(stream, file)
+	}
+	return io.MultiWriter(file)
 }
 
 // removePod removes given podName from PodList
@@ -300,7 +300,8 @@ func TestDoesNamespaceExist(t *testing.T) {
 
 // TestGetVZManagedNamespaces tests the functionality to return all namespaces managed by verrazzano
 func TestGetVZManagedNamespaces(t *testing.T) {
-	namespaces := GetVZManagedNamespaces(k8sfake.NewSimpleClientset())
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	namespaces := GetVZManagedNamespaces(k8sfake.NewSimpleClientset(), rc)
 	assert.Empty(t, namespaces)
 
 	//  GIVEN a k8s cluster with the required verrazzano-install namespace with label verrazzano-managed=true,
@@ -309,7 +310,7 @@ func TestGetVZManagedNamespaces(t *testing.T) {
 	namespaces = GetVZManagedNamespaces(k8sfake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
 		Name:   constants.VerrazzanoInstall,
 		Labels: map[string]string{"verrazzano-managed": "true"},
-	}}))
+	}}), rc)
 	assert.NotEmpty(t, namespaces)
 	assert.Equal(t, 1, len(namespaces))
 	assert.Equal(t, constants.VerrazzanoInstall, namespaces[0])
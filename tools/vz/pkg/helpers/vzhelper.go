@@ -8,6 +8,7 @@ import (
 	"fmt"
 	oam "github.com/crossplane/oam-kubernetes-runtime/apis/core"
 	"github.com/spf13/cobra"
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
 	"github.com/verrazzano/verrazzano/pkg/k8sutil"
 	"github.com/verrazzano/verrazzano/pkg/semver"
 	v1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
@@ -210,6 +211,7 @@ func NewScheme() *runtime.Scheme {
 	_ = networkingv1.AddToScheme(scheme)
 	_ = oam.AddToScheme(scheme)
 	_ = batchv1.AddToScheme(scheme)
+	_ = clustersv1alpha1.AddToScheme(scheme)
 	return scheme
 }
 
@@ -335,6 +337,16 @@ func SetVzVer(client *client.Client) error {
 	return nil
 }
 
+// GetVzVer returns the verrazzano version which was set, or an empty string
+func GetVzVer() string {
+	return vzVer
+}
+
+// GetK8sVer returns the kubernetes version which was set, or an empty string
+func GetK8sVer() string {
+	return k8sVer
+}
+
 // GetVersionOut returns the customised k8s and vz version string
 func GetVersionOut() string {
 	verOut := ""
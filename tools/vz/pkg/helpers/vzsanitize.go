@@ -1,4 +1,4 @@
-// Copyright (c) 2022, Oracle and/or its affiliates.
+// Copyright (c) 2022, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package helpers
@@ -6,31 +6,397 @@ package helpers
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
-)
+	"sort"
+	"strings"
+	"sync"
 
-var regexToReplacementList = []string{}
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/constants"
+	"sigs.k8s.io/yaml"
+)
 
 const ipv4Regex = "[[:digit:]]{1,3}\\.[[:digit:]]{1,3}\\.[[:digit:]]{1,3}\\.[[:digit:]]{1,3}"
 
-// InitRegexToReplacementMap Initialize the regex string to replacement string map
-// Append to this map for any future additions
-func InitRegexToReplacementMap() {
-	regexToReplacementList = append(regexToReplacementList, ipv4Regex)
+// defaultRedactionRules are always applied, in addition to the rules of the redaction policy
+var defaultRedactionRules = []RedactionRule{
+	{Name: "ipv4", Regex: ipv4Regex},
+}
+
+// RedactionPolicy is a user supplied policy, read from a YAML file, describing the data to remove from the bug report
+type RedactionPolicy struct {
+	// Rules are the regular expressions matching the values to redact, in the resources, pod logs and the Verrazzano resource
+	Rules []RedactionRule `json:"rules,omitempty"`
+	// DropPaths are the paths of the fields to remove from the captured resources, for example data or
+	// metadata.annotations.  The fields are separated by a dot, a dot in a field name is escaped with a backslash,
+	// a * matches any field and the elements of the lists are matched implicitly.
+	DropPaths []string `json:"dropPaths,omitempty"`
+	// OmitNamespaces are the namespaces from which no resources or logs are captured
+	OmitNamespaces []string `json:"omitNamespaces,omitempty"`
+	// AllowList are regular expressions matching the values which are never redacted
+	AllowList []string `json:"allowList,omitempty"`
+}
+
+// RedactionRule is a regular expression matching the values to redact
+type RedactionRule struct {
+	// Name identifies the rule in the redaction manifest
+	Name string `json:"name"`
+	// Regex is the regular expression matching the values to redact
+	Regex string `json:"regex"`
+	// Replacement is the literal string replacing the matched values, the SHA-256 hash of the value is used when empty
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// RedactionManifest lists the redaction rules which fired while capturing the bug report, it is included in the archive
+type RedactionManifest struct {
+	PolicyFile        string          `json:"policyFile,omitempty"`
+	PolicySHA256      string          `json:"policySha256,omitempty"`
+	Rules             []RedactionHits `json:"rules"`
+	DroppedPaths      []RedactionHits `json:"droppedPaths,omitempty"`
+	OmittedNamespaces []string        `json:"omittedNamespaces,omitempty"`
+	AllowListed       int             `json:"allowListed"`
+}
+
+// RedactionHits is the number of times a rule or a path fired and the captured files in which it fired
+type RedactionHits struct {
+	Name  string   `json:"name"`
+	Count int      `json:"count"`
+	Files []string `json:"files,omitempty"`
+}
+
+type compiledRedactionRule struct {
+	name        string
+	regex       *regexp.Regexp
+	replacement string
+}
+
+type redactionPath struct {
+	path     string
+	segments []string
+}
+
+// redactor applies a redaction policy and keeps track of the rules which fired, the captures run in parallel
+type redactor struct {
+	policyFile     string
+	policySHA256   string
+	rules          []compiledRedactionRule
+	dropPaths      []redactionPath
+	allowList      []*regexp.Regexp
+	omitNamespaces []string
+
+	mutex       sync.Mutex
+	ruleHits    map[string]*redactionHitCounter
+	pathHits    map[string]*redactionHitCounter
+	allowListed int
+}
+
+type redactionHitCounter struct {
+	count int
+	files map[string]bool
+}
+
+// LoadRedactionPolicy reads a redaction policy from a YAML file and sets it as the policy applied to the data captured
+// by the vz commands
+func LoadRedactionPolicy(policyFile string) error {
+	return defaultCaptureState.LoadRedactionPolicy(policyFile)
+}
+
+// ResetRedactionPolicy sets the default redaction rules as the policy applied to the data captured by the vz commands
+func ResetRedactionPolicy() {
+	defaultCaptureState.ResetRedactionPolicy()
+}
+
+// IsNamespaceOmitted returns true when the redaction policy of the vz commands omits the namespace from the captured data
+func IsNamespaceOmitted(namespace string) bool {
+	return defaultCaptureState.IsNamespaceOmitted(namespace)
 }
 
 // SanitizeString sanitizes each line in a given file,
-// Sanitizes based on the regex map initialized above
+// Sanitizes based on the default rules and the rules of the redaction policy
 func SanitizeString(l string) string {
-	if len(regexToReplacementList) == 0 {
-		InitRegexToReplacementMap()
+	return defaultCaptureState.getRedactor().sanitize(l, "")
+}
+
+// WriteRedactionManifest writes the manifest listing the redaction rules which fired to the capture directory
+func WriteRedactionManifest(captureDir string) error {
+	return defaultCaptureState.WriteRedactionManifest(captureDir)
+}
+
+// LoadRedactionPolicy reads a redaction policy from a YAML file and sets it as the policy applied to the captured data
+func (s *CaptureState) LoadRedactionPolicy(policyFile string) error {
+	data, err := os.ReadFile(policyFile)
+	if err != nil {
+		return fmt.Errorf("an error occurred while reading the redaction policy %s: %s", policyFile, err.Error())
+	}
+	policy := &RedactionPolicy{}
+	if err := yaml.UnmarshalStrict(data, policy); err != nil {
+		return fmt.Errorf("an error occurred while parsing the redaction policy %s: %s", policyFile, err.Error())
+	}
+	r, err := newRedactor(policyFile, data, policy)
+	if err != nil {
+		return fmt.Errorf("the redaction policy %s is not valid: %s", policyFile, err.Error())
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.redactor = r
+	return nil
+}
+
+// ResetRedactionPolicy sets the default redaction rules as the policy applied to the captured data
+func (s *CaptureState) ResetRedactionPolicy() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.redactor = mustNewRedactor("", nil, nil)
+}
+
+// IsNamespaceOmitted returns true when the redaction policy omits the namespace from the captured data
+func (s *CaptureState) IsNamespaceOmitted(namespace string) bool {
+	for _, ns := range s.getRedactor().omitNamespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteRedactionManifest writes the manifest listing the redaction rules which fired to the capture directory
+func (s *CaptureState) WriteRedactionManifest(captureDir string) error {
+	manifest := s.getRedactor().getManifest()
+	data, err := json.MarshalIndent(manifest, constants.JSONPrefix, constants.JSONIndent)
+	if err != nil {
+		return err
 	}
-	for _, eachRegex := range regexToReplacementList {
-		l = regexp.MustCompile(eachRegex).ReplaceAllString(l, getSha256Hash(l))
+	manifestFile := filepath.Join(captureDir, constants.RedactionManifestJSON)
+	if err := os.WriteFile(manifestFile, data, 0644); err != nil {
+		return fmt.Errorf(createFileError, manifestFile, err.Error())
+	}
+	return nil
+}
+
+// sanitizeResource encodes a resource as JSON, removes the paths dropped by the redaction policy and sanitizes it.
+// The file is the path of the captured file relative to the capture directory, recorded in the redaction manifest.
+func (s *CaptureState) sanitizeResource(v interface{}, file string) (string, error) {
+	r := s.getRedactor()
+	resJSON, err := json.MarshalIndent(v, constants.JSONPrefix, constants.JSONIndent)
+	if err != nil {
+		return "", err
+	}
+	if len(r.dropPaths) > 0 {
+		var res interface{}
+		if err := json.Unmarshal(resJSON, &res); err != nil {
+			return "", err
+		}
+		r.dropFields(res, file)
+		resJSON, err = json.MarshalIndent(res, constants.JSONPrefix, constants.JSONIndent)
+		if err != nil {
+			return "", err
+		}
+	}
+	return r.sanitize(string(resJSON), file), nil
+}
+
+func (s *CaptureState) getRedactor() *redactor {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.redactor
+}
+
+func mustNewRedactor(policyFile string, data []byte, policy *RedactionPolicy) *redactor {
+	r, err := newRedactor(policyFile, data, policy)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// newRedactor compiles the rules of the redaction policy, the default rules are applied first
+func newRedactor(policyFile string, data []byte, policy *RedactionPolicy) (*redactor, error) {
+	r := &redactor{
+		policyFile: policyFile,
+		ruleHits:   map[string]*redactionHitCounter{},
+		pathHits:   map[string]*redactionHitCounter{},
+	}
+	if data != nil {
+		r.policySHA256 = getSha256Hash(string(data))
+	}
+	if policy == nil {
+		policy = &RedactionPolicy{}
+	}
+
+	for _, rule := range append(append([]RedactionRule{}, defaultRedactionRules...), policy.Rules...) {
+		if rule.Name == "" || rule.Regex == "" {
+			return nil, fmt.Errorf("each rule requires a name and a regex")
+		}
+		if _, found := r.ruleHits[rule.Name]; found {
+			return nil, fmt.Errorf("the rule name %s is not unique", rule.Name)
+		}
+		regex, err := regexp.Compile(rule.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("the regex of rule %s is not valid: %s", rule.Name, err.Error())
+		}
+		r.rules = append(r.rules, compiledRedactionRule{name: rule.Name, regex: regex, replacement: rule.Replacement})
+		r.ruleHits[rule.Name] = &redactionHitCounter{files: map[string]bool{}}
+	}
+
+	for _, path := range policy.DropPaths {
+		segments := splitRedactionPath(path)
+		if len(segments) == 0 {
+			return nil, fmt.Errorf("the path %q to drop is not valid", path)
+		}
+		r.dropPaths = append(r.dropPaths, redactionPath{path: path, segments: segments})
+		r.pathHits[path] = &redactionHitCounter{files: map[string]bool{}}
+	}
+
+	for _, allowed := range policy.AllowList {
+		// The allow list entries match the complete value
+		regex, err := regexp.Compile("^(?:" + allowed + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("the allow list entry %s is not valid: %s", allowed, err.Error())
+		}
+		r.allowList = append(r.allowList, regex)
+	}
+	r.omitNamespaces = policy.OmitNamespaces
+	return r, nil
+}
+
+// sanitize replaces the values matched by the rules, other than the values in the allow list
+func (r *redactor) sanitize(l string, file string) string {
+	for _, rule := range r.rules {
+		rule := rule
+		l = rule.regex.ReplaceAllStringFunc(l, func(value string) string {
+			if r.isAllowed(value) {
+				r.mutex.Lock()
+				r.allowListed++
+				r.mutex.Unlock()
+				return value
+			}
+			r.recordHit(r.ruleHits[rule.name], file)
+			if rule.replacement != "" {
+				return rule.replacement
+			}
+			return getSha256Hash(value)
+		})
 	}
 	return l
 }
 
+func (r *redactor) isAllowed(value string) bool {
+	for _, allowed := range r.allowList {
+		if allowed.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// dropFields removes the paths to drop from a resource, or from each item of a list of resources
+func (r *redactor) dropFields(res interface{}, file string) {
+	resources := []interface{}{res}
+	if resMap, ok := res.(map[string]interface{}); ok {
+		if items, ok := resMap["items"].([]interface{}); ok {
+			resources = items
+		}
+	}
+	for _, path := range r.dropPaths {
+		for _, item := range resources {
+			if count := dropPath(item, path.segments); count > 0 {
+				for i := 0; i < count; i++ {
+					r.recordHit(r.pathHits[path.path], file)
+				}
+			}
+		}
+	}
+}
+
+func (r *redactor) recordHit(counter *redactionHitCounter, file string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	counter.count++
+	if file != "" {
+		counter.files[file] = true
+	}
+}
+
+func (r *redactor) getManifest() RedactionManifest {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	manifest := RedactionManifest{
+		PolicyFile:        r.policyFile,
+		PolicySHA256:      r.policySHA256,
+		Rules:             []RedactionHits{},
+		OmittedNamespaces: r.omitNamespaces,
+		AllowListed:       r.allowListed,
+	}
+	for _, rule := range r.rules {
+		manifest.Rules = append(manifest.Rules, newRedactionHits(rule.name, r.ruleHits[rule.name]))
+	}
+	for _, path := range r.dropPaths {
+		manifest.DroppedPaths = append(manifest.DroppedPaths, newRedactionHits(path.path, r.pathHits[path.path]))
+	}
+	return manifest
+}
+
+func newRedactionHits(name string, counter *redactionHitCounter) RedactionHits {
+	hits := RedactionHits{Name: name, Count: counter.count}
+	for file := range counter.files {
+		hits.Files = append(hits.Files, file)
+	}
+	sort.Strings(hits.Files)
+	return hits
+}
+
+// dropPath removes the fields matching the path segments and returns the number of fields removed
+func dropPath(node interface{}, segments []string) int {
+	count := 0
+	switch n := node.(type) {
+	case []interface{}:
+		for _, item := range n {
+			count += dropPath(item, segments)
+		}
+	case map[string]interface{}:
+		for key, value := range n {
+			if segments[0] != "*" && segments[0] != key {
+				continue
+			}
+			if len(segments) == 1 {
+				delete(n, key)
+				count++
+				continue
+			}
+			count += dropPath(value, segments[1:])
+		}
+	}
+	return count
+}
+
+// splitRedactionPath splits a path on the dots which are not escaped with a backslash
+func splitRedactionPath(path string) []string {
+	var segments []string
+	var segment strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '.':
+			segment.WriteByte('.')
+			i++
+		case path[i] == '.':
+			if segment.Len() == 0 {
+				return nil
+			}
+			segments = append(segments, segment.String())
+			segment.Reset()
+		default:
+			segment.WriteByte(path[i])
+		}
+	}
+	if segment.Len() == 0 {
+		return nil
+	}
+	return append(segments, segment.String())
+}
+
 // getSha256Hash generates the one way hash for the input string
 func getSha256Hash(line string) string {
 	data := []byte(line)
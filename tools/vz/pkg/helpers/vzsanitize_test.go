@@ -1,18 +1,112 @@
-// Copyright (c) 2022, Oracle and/or its affiliates.
+// Copyright (c) 2022, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package helpers
 
 import (
-	"github.com/stretchr/testify/assert"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var (
-	testIP = "127.255.255.255"
+	testIP         = "127.255.255.255"
+	testPolicyFile = "../../test/testdata/redaction-policy.yaml"
 )
 
 func TestSanitizeALine(t *testing.T) {
 	assert.NotContains(t, SanitizeString(testIP), testIP)
 	assert.Contains(t, SanitizeString("test.me.test.me"), "test")
 }
+
+// TestRedactionPolicy tests the rules and the allow list of a redaction policy
+// GIVEN a redaction policy with rules and an allow list
+// WHEN a string is sanitized
+// THEN the values matched by the rules are redacted, except the values in the allow list
+func TestRedactionPolicy(t *testing.T) {
+	defer ResetRedactionPolicy()
+	assert.NoError(t, LoadRedactionPolicy(testPolicyFile))
+
+	line := "user jane.doe@example.com created ocid1.tenancy.oc1..aaaabbbb from 10.0.0.1 on 127.0.0.1"
+	sanitized := SanitizeString(line)
+	assert.NotContains(t, sanitized, "jane.doe@example.com")
+	assert.NotContains(t, sanitized, "10.0.0.1")
+	assert.Contains(t, sanitized, "REDACTED-OCID")
+	assert.Contains(t, sanitized, "127.0.0.1")
+
+	manifest := defaultCaptureState.getRedactor().getManifest()
+	assert.Equal(t, testPolicyFile, manifest.PolicyFile)
+	assert.Equal(t, []RedactionHits{{Name: "ipv4", Count: 1}, {Name: "email", Count: 1}, {Name: "ocid", Count: 1}}, manifest.Rules)
+	assert.Equal(t, 1, manifest.AllowListed)
+	assert.True(t, IsNamespaceOmitted("tenant-secrets"))
+	assert.False(t, IsNamespaceOmitted("verrazzano-system"))
+}
+
+// TestRedactionPolicyDropPaths tests dropping the fields of the captured resources
+// GIVEN a redaction policy with paths to drop
+// WHEN a list of ConfigMaps is captured
+// THEN the fields are removed from each ConfigMap and the manifest lists the files in which they were removed
+func TestRedactionPolicyDropPaths(t *testing.T) {
+	defer ResetRedactionPolicy()
+	assert.NoError(t, LoadRedactionPolicy(testPolicyFile))
+
+	cm := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tenant-config",
+			Namespace: "tenant",
+			Annotations: map[string]string{
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+				"owner": "tenant-a",
+			},
+		},
+		Data: map[string]string{"password": "secret"},
+	}
+	resJSON, err := defaultCaptureState.sanitizeResource(corev1.ConfigMapList{Items: []corev1.ConfigMap{cm, cm}}, "tenant/configmaps.json")
+	assert.NoError(t, err)
+
+	cmList := corev1.ConfigMapList{}
+	assert.NoError(t, json.Unmarshal([]byte(resJSON), &cmList))
+	assert.Len(t, cmList.Items, 2)
+	for _, item := range cmList.Items {
+		assert.Nil(t, item.Data)
+		assert.Equal(t, map[string]string{"owner": "tenant-a"}, item.Annotations)
+	}
+
+	captureDir := t.TempDir()
+	assert.NoError(t, WriteRedactionManifest(captureDir))
+	data, err := os.ReadFile(filepath.Join(captureDir, constants.RedactionManifestJSON))
+	assert.NoError(t, err)
+	manifest := RedactionManifest{}
+	assert.NoError(t, json.Unmarshal(data, &manifest))
+	assert.Equal(t, []RedactionHits{
+		{Name: "data", Count: 2, Files: []string{"tenant/configmaps.json"}},
+		{Name: "metadata.annotations.kubectl\\.kubernetes\\.io/last-applied-configuration", Count: 2, Files: []string{"tenant/configmaps.json"}},
+	}, manifest.DroppedPaths)
+	assert.Equal(t, []string{"tenant-secrets"}, manifest.OmittedNamespaces)
+}
+
+// TestRedactionPolicyInvalid tests loading a redaction policy which is not valid
+// GIVEN a redaction policy with an invalid regex, or an unknown field
+// WHEN the policy is loaded
+// THEN an error is returned
+func TestRedactionPolicyInvalid(t *testing.T) {
+	defer ResetRedactionPolicy()
+	policies := []string{
+		"rules:\n- name: bad\n  regex: \"[a-z\"\n",
+		"rules:\n- name: ipv4\n  regex: foo\n",
+		"dropPaths:\n- metadata..name\n",
+		"omitNamespace:\n- foo\n",
+	}
+	for _, policy := range policies {
+		policyFile := filepath.Join(t.TempDir(), "policy.yaml")
+		assert.NoError(t, os.WriteFile(policyFile, []byte(policy), 0600))
+		assert.Error(t, LoadRedactionPolicy(policyFile), policy)
+	}
+	assert.Error(t, LoadRedactionPolicy("not-found.yaml"))
+}
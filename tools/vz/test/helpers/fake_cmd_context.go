@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/verrazzano/verrazzano/platform-operator/manifests/profiles"
 	"github.com/verrazzano/verrazzano/tools/vz/pkg/github"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/dynamic"
@@ -93,9 +94,34 @@ func (rc *FakeRootCmdContext) GetHTTPClient() *http.Client {
 		panic(err)
 	}
 
+	// Predefined response for getting verrazzano-bom.json
+	jsonBomResp, err := os.ReadFile("../../test/testdata/verrazzano-bom-fake.json")
+	if err != nil {
+		panic(err)
+	}
+
 	return &http.Client{
 		Timeout: time.Second * 30,
 		Transport: RoundTripFunc(func(req *http.Request) *http.Response {
+			// Respond with the profiles embedded in the CLI for the profiles of a version
+			if _, profile, ok := strings.Cut(req.URL.Path, "/manifests/profiles/"); ok {
+				data, err := profiles.GetEmbeddedProfiles().ReadFile(profile)
+				if err != nil {
+					return &http.Response{StatusCode: http.StatusNotFound, Status: "404 Not Found", Body: io.NopCloser(&bytes.Buffer{})}
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBuffer(data)),
+					Header:     http.Header{"Content-Type": {"text/plain"}},
+				}
+			}
+			if strings.HasSuffix(req.URL.Path, "/verrazzano-bom.json") {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBuffer(jsonBomResp)),
+					Header:     http.Header{"Content-Type": {"application/json"}},
+				}
+			}
 			if strings.Contains(req.URL.Path, "/releases/download") {
 				return &http.Response{
 					StatusCode: http.StatusOK,